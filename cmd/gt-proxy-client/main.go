@@ -36,6 +36,7 @@ func main() {
 	//   GT_PROXY_CA   — path to PEM proxy CA cert (used to verify server cert)
 	// Optional:
 	//   GT_REAL_BIN   — fallback binary path (default /usr/local/bin/gt.real)
+	//   GT_PROXY_NO_RENEW — set to disable automatic client cert renewal
 	proxyURL := os.Getenv("GT_PROXY_URL")
 	certFile := os.Getenv("GT_PROXY_CERT")
 	keyFile := os.Getenv("GT_PROXY_KEY")
@@ -78,6 +79,18 @@ func main() {
 		Transport: &http.Transport{TLSClientConfig: tlsCfg},
 	}
 
+	// Rotate the short-lived client cert before it expires. Best-effort: on
+	// failure the current cert is still valid and the next call retries.
+	if os.Getenv("GT_PROXY_NO_RENEW") == "" && needsRenewal(clientCert.Leaf, time.Now()) {
+		renewed, err := renewCert(httpClient, proxyURL, certFile, keyFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gt-proxy-client: warning: cert renewal failed: %v\n", err)
+		} else {
+			tlsCfg.Certificates = []tls.Certificate{renewed}
+			httpClient.Transport = &http.Transport{TLSClientConfig: tlsCfg}
+		}
+	}
+
 	// Determine argv: prepend the binary name so the server knows which tool we are.
 	argv := os.Args // os.Args[0] is the binary path; the server needs the tool name as argv[0].
 	// Replace argv[0] with the tool name (gt or bd) based on the binary name.
@@ -118,6 +131,73 @@ func main() {
 	os.Exit(result.ExitCode)
 }
 
+type renewResponse struct {
+	Cert string `json:"cert"`
+	Key  string `json:"key"`
+}
+
+// needsRenewal reports whether less than a third of the cert's lifetime is left.
+func needsRenewal(leaf *x509.Certificate, now time.Time) bool {
+	if leaf == nil {
+		return false
+	}
+	lifetime := leaf.NotAfter.Sub(leaf.NotBefore)
+	return leaf.NotAfter.Sub(now) < lifetime/3
+}
+
+// renewCert asks the proxy for a fresh cert with the same identity and writes
+// it over certFile and keyFile, returning the new key pair.
+func renewCert(client *http.Client, proxyURL, certFile, keyFile string) (tls.Certificate, error) {
+	resp, err := client.Post(proxyURL+"/v1/cert/renew", "application/json", nil) //nolint:gosec // proxyURL is from trusted env var GT_PROXY_URL
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // best-effort close on response body
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return tls.Certificate{}, fmt.Errorf("server error %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	var out renewResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return tls.Certificate{}, fmt.Errorf("decode response: %w", err)
+	}
+	pair, err := tls.X509KeyPair([]byte(out.Cert), []byte(out.Key))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("parse renewed cert: %w", err)
+	}
+
+	// Write the key first so a read-only mount fails before anything changes.
+	if err := writeFileAtomic(keyFile, []byte(out.Key), 0600); err != nil {
+		return pair, fmt.Errorf("write key: %w", err)
+	}
+	if err := writeFileAtomic(certFile, []byte(out.Cert), 0644); err != nil {
+		return pair, fmt.Errorf("write cert: %w", err)
+	}
+	return pair, nil
+}
+
+// writeFileAtomic writes data to a temp file beside path and renames it into
+// place, so concurrent invocations never read a half-written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // no-op after a successful rename
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 // toolNameFromArg0 extracts "gt" or "bd" from the argv[0] binary path.
 func toolNameFromArg0(arg0 string) string {
 	return filepath.Base(arg0)
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
+
+	"github.com/steveyegge/gastown/internal/proxy"
 )
 
 // ProxyConfig is the configuration file schema for gt-proxy-server.
@@ -56,6 +58,11 @@ type ProxyConfig struct {
 	//   - A split-horizon DNS entry that resolves to the proxy IP
 	//   - A mDNS name (e.g. "macbook.local")
 	ExtraSANHosts []string `json:"extra_san_hosts"`
+
+	// PushPolicy enables content checks (secret patterns, blob size limits,
+	// forbidden and per-rig allowed paths) on pushes through the git endpoint.
+	// Omit to accept any push to an authorized ref.
+	PushPolicy *proxy.PushPolicy `json:"push_policy"`
 }
 
 // loadConfig reads the config file at path and returns a ProxyConfig.
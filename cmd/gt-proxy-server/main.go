@@ -134,6 +134,10 @@ func main() {
 		TownRoot:           *townRoot,
 		ExtraSANIPs:        extraSANIPs,
 		ExtraSANHosts:      extraSANHosts,
+		// Revocations and the issued-cert registry live beside the CA that
+		// signed the certs they describe.
+		StateDir:   *caDir,
+		PushPolicy: fileCfg.PushPolicy,
 	}
 
 	srv, err := proxy.New(cfg, ca)
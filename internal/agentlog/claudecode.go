@@ -16,6 +16,9 @@ const (
 	// claudeProjectsDir is the path under $HOME where Claude Code stores projects.
 	claudeProjectsDir = ".claude/projects"
 
+	// claudeConfigDirEnv overrides the Claude Code config directory (~/.claude).
+	claudeConfigDirEnv = "CLAUDE_CONFIG_DIR"
+
 	// watchPollInterval is how often we poll for new JSONL content or files.
 	watchPollInterval = 500 * time.Millisecond
 
@@ -94,8 +97,47 @@ func (a *ClaudeCodeAdapter) Watch(ctx context.Context, sessionID, workDir string
 	return ch, nil
 }
 
+// ReadSession parses the newest Claude Code JSONL file for workDir modified at
+// or after since (any age if since is zero).
+func (a *ClaudeCodeAdapter) ReadSession(sessionID, workDir string, since time.Time) ([]AgentEvent, error) {
+	projectDir, err := claudeProjectDirFor(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving project dir: %w", err)
+	}
+	path, ok := newestJSONLIn(projectDir, since)
+	if !ok {
+		return nil, fmt.Errorf("no transcript files found in %s", projectDir)
+	}
+	nativeID := nativeSessionIDFromPath(path)
+	var events []AgentEvent
+	err = scanJSONLines(path, func(line string) {
+		events = append(events, parseClaudeCodeLine(line, sessionID, a.AgentType(), nativeID)...)
+	})
+	return events, err
+}
+
+// scanJSONLines calls fn for every non-empty line of a JSONL file.
+func scanJSONLines(path string, fn func(line string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	// Conversation lines carry tool output and can be large.
+	scanner.Buffer(make([]byte, 0, 256*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			fn(line)
+		}
+	}
+	return scanner.Err()
+}
+
 // claudeProjectDirFor returns the Claude Code project directory for workDir.
 // Formula: $HOME/.claude/projects/<hash> where hash = workDir with '/' → '-'.
+// $CLAUDE_CONFIG_DIR replaces $HOME/.claude when set.
 // On Windows, backslashes are converted to forward slashes and the drive
 // letter (e.g. "C:") is stripped before hashing, matching Claude Code's
 // cross-platform behavior.
@@ -112,6 +154,9 @@ func claudeProjectDirFor(workDir string) (string, error) {
 		normalized = normalized[2:]
 	}
 	hash := strings.ReplaceAll(normalized, "/", "-")
+	if configDir := os.Getenv(claudeConfigDirEnv); configDir != "" {
+		return filepath.Join(configDir, "projects", hash), nil
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("getting home dir: %w", err)
@@ -142,6 +187,12 @@ func waitForNewestJSONL(ctx context.Context, projectDir string, since time.Time)
 // newestJSONLIn returns the most recently modified .jsonl file in dir whose
 // modification time is >= since (skip if since is zero).
 func newestJSONLIn(dir string, since time.Time) (string, bool) {
+	return newestFileIn(dir, ".jsonl", since)
+}
+
+// newestFileIn returns the most recently modified file in dir with the given
+// suffix whose modification time is >= since (skip if since is zero).
+func newestFileIn(dir, suffix string, since time.Time) (string, bool) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return "", false
@@ -149,7 +200,7 @@ func newestJSONLIn(dir string, since time.Time) (string, bool) {
 	var bestPath string
 	var bestTime time.Time
 	for _, e := range entries {
-		if !strings.HasSuffix(e.Name(), ".jsonl") {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), suffix) {
 			continue
 		}
 		info, err := e.Info()
@@ -157,7 +208,7 @@ func newestJSONLIn(dir string, since time.Time) (string, bool) {
 			continue
 		}
 		// Skip files older than the Gas Town session start — they belong to
-		// previous Claude sessions or unrelated Claude instances.
+		// previous agent sessions or unrelated agent instances.
 		if !since.IsZero() && info.ModTime().Before(since) {
 			continue
 		}
@@ -248,6 +299,7 @@ type ccEntry struct {
 
 // ccMessage is the message field of a ccEntry.
 type ccMessage struct {
+	Model   string      `json:"model,omitempty"`
 	Role    string      `json:"role"`
 	Content []ccContent `json:"content"`
 	Usage   *ccUsage    `json:"usage,omitempty"`
@@ -353,6 +405,7 @@ func parseClaudeCodeLine(line, sessionID, agentType, nativeSessionID string) []A
 				OutputTokens:        u.OutputTokens,
 				CacheReadTokens:     u.CacheReadInputTokens,
 				CacheCreationTokens: u.CacheCreationInputTokens,
+				Model:               entry.Message.Model,
 			})
 		}
 	}
@@ -4,11 +4,13 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestClaudeProjectDirFor(t *testing.T) {
 	// The project hash replaces '/' with '-', so the leading slash becomes '-'.
 	// e.g., /some/work/dir → $HOME/.claude/projects/-some-work-dir
+	t.Setenv("CLAUDE_CONFIG_DIR", "")
 	home, err := os.UserHomeDir()
 	if err != nil {
 		t.Fatalf("getting home dir: %v", err)
@@ -115,3 +117,43 @@ func TestNewAdapter(t *testing.T) {
 		})
 	}
 }
+
+func TestClaudeProjectDirFor_RespectsEnvVar(t *testing.T) {
+	t.Setenv("CLAUDE_CONFIG_DIR", "/custom/claude")
+	got, err := claudeProjectDirFor("/some/work/dir")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join("/custom/claude", "projects", "-some-work-dir"); got != want {
+		t.Errorf("claudeProjectDirFor() = %q, want %q", got, want)
+	}
+}
+
+func TestClaudeCodeAdapter_ReadSession(t *testing.T) {
+	t.Setenv("CLAUDE_CONFIG_DIR", t.TempDir())
+	projectDir, err := claudeProjectDirFor("/some/work/dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	transcript := `{"type":"user","message":{"role":"user","content":[{"type":"text","text":"hi"}]}}
+{"type":"assistant","message":{"model":"claude-sonnet-4-20250514","role":"assistant","content":[{"type":"text","text":"hello"}],"usage":{"input_tokens":10,"output_tokens":5,"cache_read_input_tokens":100}}}
+`
+	if err := os.WriteFile(filepath.Join(projectDir, "abc.jsonl"), []byte(transcript), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := (&ClaudeCodeAdapter{}).ReadSession("hq-mayor", "/some/work/dir", time.Time{})
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	u := events[2]
+	if u.EventType != "usage" || u.Model != "claude-sonnet-4-20250514" || u.CacheReadTokens != 100 || u.NativeSessionID != "abc" {
+		t.Errorf("usage event = %+v", u)
+	}
+}
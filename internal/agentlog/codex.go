@@ -0,0 +1,306 @@
+package agentlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// codexHomeEnv overrides the Codex home directory (~/.codex).
+const codexHomeEnv = "CODEX_HOME"
+
+// CodexAdapter reads OpenAI Codex CLI rollout files.
+//
+// Codex writes one JSONL rollout per session at:
+//
+//	~/.codex/sessions/YYYY/MM/DD/rollout-<timestamp>-<uuid>.jsonl
+//
+// The first line is a session_meta record carrying the session's cwd, which
+// is how a rollout is matched to a Gas Town work directory. Token usage comes
+// from event_msg/token_count records.
+type CodexAdapter struct{}
+
+func (a *CodexAdapter) AgentType() string { return "codex" }
+
+// Watch polls the newest Codex rollout for workDir and streams new events.
+func (a *CodexAdapter) Watch(ctx context.Context, sessionID, workDir string, since time.Time) (<-chan AgentEvent, error) {
+	return watchSnapshots(ctx, func() ([]AgentEvent, error) {
+		return a.ReadSession(sessionID, workDir, since)
+	}), nil
+}
+
+// ReadSession parses the newest Codex rollout whose cwd is workDir and which
+// was modified at or after since.
+func (a *CodexAdapter) ReadSession(sessionID, workDir string, since time.Time) ([]AgentEvent, error) {
+	home, err := codexHome()
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving absolute path: %w", err)
+	}
+	sessionsDir := filepath.Join(home, "sessions")
+	path, ok := newestCodexRollout(sessionsDir, abs, since)
+	if !ok {
+		return nil, fmt.Errorf("no codex rollout for %s in %s", abs, sessionsDir)
+	}
+
+	p := &codexParser{sessionID: sessionID, agentType: a.AgentType()}
+	var events []AgentEvent
+	err = scanJSONLines(path, func(line string) {
+		events = append(events, p.parseLine(line)...)
+	})
+	return events, err
+}
+
+// codexHome returns $CODEX_HOME, defaulting to ~/.codex.
+func codexHome() (string, error) {
+	if dir := os.Getenv(codexHomeEnv); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("getting home dir: %w", err)
+	}
+	return filepath.Join(home, ".codex"), nil
+}
+
+// newestCodexRollout returns the most recently modified rollout under
+// sessionsDir whose session_meta cwd equals workDir.
+func newestCodexRollout(sessionsDir, workDir string, since time.Time) (string, bool) {
+	type candidate struct {
+		path    string
+		modTime time.Time
+	}
+	var candidates []candidate
+	_ = filepath.WalkDir(sessionsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".jsonl") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || (!since.IsZero() && info.ModTime().Before(since)) {
+			return nil
+		}
+		candidates = append(candidates, candidate{path, info.ModTime()})
+		return nil
+	})
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].modTime.After(candidates[j].modTime)
+	})
+	for _, c := range candidates {
+		if codexRolloutCWD(c.path) == workDir {
+			return c.path, true
+		}
+	}
+	return "", false
+}
+
+// codexRolloutCWD reads the cwd from a rollout's session_meta first line.
+func codexRolloutCWD(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	line, err := bufio.NewReaderSize(f, 64*1024).ReadString('\n')
+	if err != nil && line == "" {
+		return ""
+	}
+	var entry codexLine
+	if json.Unmarshal([]byte(line), &entry) != nil || entry.Type != "session_meta" {
+		return ""
+	}
+	var meta codexSessionMeta
+	if json.Unmarshal(entry.Payload, &meta) != nil {
+		return ""
+	}
+	return filepath.Clean(meta.CWD)
+}
+
+// ── Codex rollout structures ──────────────────────────────────────────────────
+
+// codexLine is a top-level line in a Codex rollout file.
+type codexLine struct {
+	Timestamp string          `json:"timestamp"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type codexSessionMeta struct {
+	ID  string `json:"id"`
+	CWD string `json:"cwd"`
+}
+
+// codexPayload covers the payload fields of turn_context, response_item and
+// event_msg lines.
+type codexPayload struct {
+	Type  string `json:"type"`
+	Model string `json:"model,omitempty"`
+
+	// response_item: message
+	Role    string `json:"role,omitempty"`
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content,omitempty"`
+
+	// response_item: reasoning
+	Summary []struct {
+		Text string `json:"text"`
+	} `json:"summary,omitempty"`
+
+	// response_item: function_call / custom_tool_call
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+	Input     string `json:"input,omitempty"`
+
+	// response_item: function_call_output / custom_tool_call_output
+	Output json.RawMessage `json:"output,omitempty"`
+
+	// event_msg: token_count
+	Info *struct {
+		Total codexTokenUsage `json:"total_token_usage"`
+		Last  codexTokenUsage `json:"last_token_usage"`
+	} `json:"info,omitempty"`
+}
+
+// codexTokenUsage mirrors the OpenAI usage block. InputTokens includes
+// CachedInputTokens and OutputTokens includes ReasoningOutputTokens.
+type codexTokenUsage struct {
+	InputTokens           int `json:"input_tokens"`
+	CachedInputTokens     int `json:"cached_input_tokens"`
+	OutputTokens          int `json:"output_tokens"`
+	ReasoningOutputTokens int `json:"reasoning_output_tokens"`
+	TotalTokens           int `json:"total_tokens"`
+}
+
+// codexParser carries the state needed across rollout lines: the native
+// session ID, the current model, and the last cumulative total (Codex can
+// repeat a token_count without a new turn).
+type codexParser struct {
+	sessionID string
+	agentType string
+	nativeID  string
+	model     string
+	lastTotal int
+}
+
+// parseLine parses one rollout line and returns 0 or more AgentEvents.
+func (p *codexParser) parseLine(line string) []AgentEvent {
+	var entry codexLine
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return nil
+	}
+	ts := time.Now()
+	if t, err := time.Parse(time.RFC3339, entry.Timestamp); err == nil {
+		ts = t
+	}
+
+	if entry.Type == "session_meta" {
+		var meta codexSessionMeta
+		if json.Unmarshal(entry.Payload, &meta) == nil {
+			p.nativeID = meta.ID
+		}
+		return nil
+	}
+
+	var payload codexPayload
+	if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+		return nil
+	}
+	event := func(eventType, role, content string) AgentEvent {
+		return AgentEvent{
+			AgentType:       p.agentType,
+			SessionID:       p.sessionID,
+			NativeSessionID: p.nativeID,
+			EventType:       eventType,
+			Role:            role,
+			Content:         content,
+			Timestamp:       ts,
+		}
+	}
+
+	switch entry.Type {
+	case "turn_context":
+		if payload.Model != "" {
+			p.model = payload.Model
+		}
+	case "response_item":
+		switch payload.Type {
+		case "message":
+			var events []AgentEvent
+			for _, c := range payload.Content {
+				if c.Text != "" {
+					events = append(events, event("text", payload.Role, c.Text))
+				}
+			}
+			return events
+		case "reasoning":
+			var parts []string
+			for _, s := range payload.Summary {
+				if s.Text != "" {
+					parts = append(parts, s.Text)
+				}
+			}
+			if len(parts) > 0 {
+				return []AgentEvent{event("thinking", "assistant", strings.Join(parts, "\n"))}
+			}
+		case "function_call", "custom_tool_call":
+			input := payload.Arguments
+			if input == "" {
+				input = payload.Input
+			}
+			return []AgentEvent{event("tool_use", "assistant", payload.Name+": "+input)}
+		case "function_call_output", "custom_tool_call_output":
+			if out := codexOutputText(payload.Output); out != "" {
+				return []AgentEvent{event("tool_result", "user", out)}
+			}
+		}
+	case "event_msg":
+		if payload.Type != "token_count" || payload.Info == nil {
+			return nil
+		}
+		if payload.Info.Total.TotalTokens == p.lastTotal {
+			return nil // repeated report, no new turn
+		}
+		p.lastTotal = payload.Info.Total.TotalTokens
+		u := payload.Info.Last
+		if u.InputTokens == 0 && u.OutputTokens == 0 {
+			return nil
+		}
+		ev := event("usage", "assistant", "")
+		ev.InputTokens = max(u.InputTokens-u.CachedInputTokens, 0)
+		ev.CacheReadTokens = u.CachedInputTokens
+		ev.OutputTokens = u.OutputTokens
+		ev.Model = p.model
+		return []AgentEvent{ev}
+	}
+	return nil
+}
+
+// codexOutputText returns a tool output, which is a plain string in most
+// rollouts and an object with an "output" field in some.
+func codexOutputText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var obj struct {
+		Output string `json:"output"`
+	}
+	if json.Unmarshal(raw, &obj) == nil {
+		return obj.Output
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package agentlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const codexRollout = `{"timestamp":"2026-03-01T10:00:00Z","type":"session_meta","payload":{"id":"codex-uuid","cwd":"%CWD%"}}
+{"timestamp":"2026-03-01T10:00:01Z","type":"turn_context","payload":{"cwd":"%CWD%","model":"gpt-5-codex"}}
+{"timestamp":"2026-03-01T10:00:02Z","type":"response_item","payload":{"type":"message","role":"user","content":[{"type":"input_text","text":"fix the bug"}]}}
+{"timestamp":"2026-03-01T10:00:03Z","type":"response_item","payload":{"type":"reasoning","summary":[{"type":"summary_text","text":"Looking at tests"}]}}
+{"timestamp":"2026-03-01T10:00:04Z","type":"response_item","payload":{"type":"function_call","name":"shell","arguments":"{\"command\":[\"ls\"]}","call_id":"c1"}}
+{"timestamp":"2026-03-01T10:00:05Z","type":"response_item","payload":{"type":"function_call_output","call_id":"c1","output":"main.go"}}
+{"timestamp":"2026-03-01T10:00:06Z","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":1000,"cached_input_tokens":600,"output_tokens":50,"total_tokens":1050},"last_token_usage":{"input_tokens":1000,"cached_input_tokens":600,"output_tokens":50,"total_tokens":1050}}}}
+{"timestamp":"2026-03-01T10:00:06Z","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":1000,"cached_input_tokens":600,"output_tokens":50,"total_tokens":1050},"last_token_usage":{"input_tokens":1000,"cached_input_tokens":600,"output_tokens":50,"total_tokens":1050}}}}
+{"timestamp":"2026-03-01T10:00:07Z","type":"event_msg","payload":{"type":"token_count","info":null}}
+`
+
+func writeCodexRollout(t *testing.T, home, day, name, cwd string) string {
+	t.Helper()
+	dir := filepath.Join(home, "sessions", day)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(strings.ReplaceAll(codexRollout, "%CWD%", cwd)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCodexAdapter_ReadSession(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("CODEX_HOME", home)
+	workDir := t.TempDir()
+
+	writeCodexRollout(t, home, "2026/03/01", "rollout-other.jsonl", "/elsewhere")
+	writeCodexRollout(t, home, "2026/03/01", "rollout-mine.jsonl", workDir)
+
+	events, err := (&CodexAdapter{}).ReadSession("gt-gastown-toast", workDir, time.Time{})
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+
+	var types []string
+	var usage []AgentEvent
+	for _, ev := range events {
+		types = append(types, ev.EventType)
+		if ev.EventType == "usage" {
+			usage = append(usage, ev)
+		}
+		if ev.NativeSessionID != "codex-uuid" || ev.SessionID != "gt-gastown-toast" || ev.AgentType != "codex" {
+			t.Errorf("bad event tags: %+v", ev)
+		}
+	}
+	want := "text,thinking,tool_use,tool_result,usage"
+	if got := strings.Join(types, ","); got != want {
+		t.Errorf("event types = %s, want %s", got, want)
+	}
+	if len(usage) != 1 {
+		t.Fatalf("usage events = %d, want 1 (repeated token_count deduped)", len(usage))
+	}
+	u := usage[0]
+	if u.InputTokens != 400 || u.CacheReadTokens != 600 || u.OutputTokens != 50 || u.Model != "gpt-5-codex" {
+		t.Errorf("usage = %+v, want 400 uncached + 600 cached in, 50 out, gpt-5-codex", u)
+	}
+}
+
+func TestCodexAdapter_ReadSession_NoMatch(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("CODEX_HOME", home)
+	writeCodexRollout(t, home, "2026/03/01", "rollout-other.jsonl", "/elsewhere")
+
+	if _, err := (&CodexAdapter{}).ReadSession("s", t.TempDir(), time.Time{}); err == nil {
+		t.Error("expected error when no rollout matches the work dir")
+	}
+}
@@ -2,8 +2,10 @@
 // and emitting normalized OTEL telemetry events.
 //
 // Design: AgentAdapter is the extension point. Adding support for a new agent
-// (OpenCode, Kiro, etc.) means implementing this interface. The gt agent-log command
-// selects the adapter via --agent flag and defaults to "claudecode".
+// (Kiro, etc.) means implementing this interface. The gt agent-log command
+// selects the adapter via --agent flag and defaults to "claudecode"; gt costs
+// selects it from the session's GT_AGENT preset and prices the "usage" events
+// with a PriceTable.
 package agentlog
 
 import (
@@ -14,7 +16,7 @@ import (
 // AgentEvent is a normalized event extracted from an AI agent's conversation log.
 // All adapters emit this type so downstream telemetry is agent-agnostic.
 type AgentEvent struct {
-	AgentType       string    // "claudecode", "opencode", …
+	AgentType       string    // "claudecode", "codex", "gemini", "opencode"
 	SessionID       string    // Gas Town tmux session name (e.g. "hq-mayor", "gt-wyvern-toast")
 	NativeSessionID string    // agent-native session UUID (e.g. Claude Code session UUID from JSONL filename)
 	EventType       string    // "text", "tool_use", "tool_result", "thinking", "usage"
@@ -24,10 +26,18 @@ type AgentEvent struct {
 
 	// Token usage fields — non-zero only for EventType == "usage".
 	// One "usage" event is emitted per assistant turn (not per content block).
-	InputTokens         int // input_tokens from Claude API usage
-	OutputTokens        int // output_tokens from Claude API usage
-	CacheReadTokens     int // cache_read_input_tokens
-	CacheCreationTokens int // cache_creation_input_tokens
+	// Adapters normalize to Claude API semantics: InputTokens excludes cached
+	// input, and OutputTokens includes reasoning/thinking tokens.
+	InputTokens         int // uncached input tokens
+	OutputTokens        int // output tokens, including reasoning
+	CacheReadTokens     int // input tokens served from the prompt cache
+	CacheCreationTokens int // input tokens written to the prompt cache
+
+	// Model is the model that produced a "usage" event, when the log records it.
+	Model string
+	// CostUSD is the agent's own cost figure for a "usage" event, when the log
+	// records one (OpenCode does). Zero means price the tokens instead.
+	CostUSD float64
 }
 
 // AgentAdapter watches an agent's conversation log and streams normalized events.
@@ -43,14 +53,24 @@ type AgentAdapter interface {
 	// since filters out JSONL files last modified before this time; use zero
 	// to disable filtering (picks up any file regardless of age).
 	Watch(ctx context.Context, sessionID, workDir string, since time.Time) (<-chan AgentEvent, error)
+
+	// ReadSession returns the events recorded so far in the agent's most
+	// recent session log for workDir, without waiting for new ones. since has
+	// the same meaning as for Watch. Returns an error if no log is found.
+	ReadSession(sessionID, workDir string, since time.Time) ([]AgentEvent, error)
 }
 
 // NewAdapter returns the AgentAdapter for the given agent type name.
+// Agent preset names (e.g. "claude") are accepted as aliases.
 // Returns nil if the agent type is unknown.
 func NewAdapter(agentType string) AgentAdapter {
 	switch agentType {
-	case "claudecode", "":
+	case "claudecode", "claude", "":
 		return &ClaudeCodeAdapter{}
+	case "codex":
+		return &CodexAdapter{}
+	case "gemini":
+		return &GeminiAdapter{}
 	case "opencode":
 		return &OpenCodeAdapter{}
 	default:
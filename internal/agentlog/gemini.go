@@ -0,0 +1,186 @@
+package agentlog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GeminiAdapter reads Gemini CLI chat recordings.
+//
+// Gemini CLI rewrites one JSON file per session at:
+//
+//	~/.gemini/tmp/<project-hash>/chats/session-<timestamp>-<id>.json
+//
+// where <project-hash> is the hex SHA-256 of the project's absolute path.
+// Each "gemini" message carries the model and a tokens block.
+type GeminiAdapter struct{}
+
+func (a *GeminiAdapter) AgentType() string { return "gemini" }
+
+// Watch polls the newest Gemini chat for workDir and streams new events.
+func (a *GeminiAdapter) Watch(ctx context.Context, sessionID, workDir string, since time.Time) (<-chan AgentEvent, error) {
+	if _, err := geminiChatsDirFor(workDir); err != nil {
+		return nil, fmt.Errorf("resolving chats dir: %w", err)
+	}
+	return watchSnapshots(ctx, func() ([]AgentEvent, error) {
+		return a.ReadSession(sessionID, workDir, since)
+	}), nil
+}
+
+// ReadSession parses the newest Gemini chat file for workDir modified at or
+// after since.
+func (a *GeminiAdapter) ReadSession(sessionID, workDir string, since time.Time) ([]AgentEvent, error) {
+	chatsDir, err := geminiChatsDirFor(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving chats dir: %w", err)
+	}
+	path, ok := newestFileIn(chatsDir, ".json", since)
+	if !ok {
+		return nil, fmt.Errorf("no chat files found in %s", chatsDir)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is under the Gemini chats dir
+	if err != nil {
+		return nil, err
+	}
+	return parseGeminiChat(data, sessionID, a.AgentType())
+}
+
+// geminiChatsDirFor returns the Gemini CLI chats directory for workDir.
+func geminiChatsDirFor(workDir string) (string, error) {
+	abs, err := filepath.Abs(workDir)
+	if err != nil {
+		return "", fmt.Errorf("resolving absolute path: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("getting home dir: %w", err)
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(home, ".gemini", "tmp", hex.EncodeToString(sum[:]), "chats"), nil
+}
+
+// ── Gemini chat structures ────────────────────────────────────────────────────
+
+type geminiChat struct {
+	SessionID string          `json:"sessionId"`
+	Messages  []geminiMessage `json:"messages"`
+}
+
+type geminiMessage struct {
+	Timestamp string          `json:"timestamp"`
+	Type      string          `json:"type"` // "user", "gemini", "info", "error"
+	Content   json.RawMessage `json:"content"`
+	Model     string          `json:"model,omitempty"`
+	Thoughts  []struct {
+		Subject     string `json:"subject"`
+		Description string `json:"description"`
+	} `json:"thoughts,omitempty"`
+	ToolCalls []struct {
+		Name   string          `json:"name"`
+		Args   json.RawMessage `json:"args"`
+		Result json.RawMessage `json:"result"`
+	} `json:"toolCalls,omitempty"`
+	Tokens *geminiTokens `json:"tokens,omitempty"`
+}
+
+// geminiTokens mirrors the Gemini usage metadata. Input includes Cached;
+// Thoughts and Tool are counted separately and billed as output and input.
+type geminiTokens struct {
+	Input    int `json:"input"`
+	Output   int `json:"output"`
+	Cached   int `json:"cached"`
+	Thoughts int `json:"thoughts"`
+	Tool     int `json:"tool"`
+}
+
+// parseGeminiChat converts a Gemini chat recording into AgentEvents.
+func parseGeminiChat(data []byte, sessionID, agentType string) ([]AgentEvent, error) {
+	var chat geminiChat
+	if err := json.Unmarshal(data, &chat); err != nil {
+		return nil, fmt.Errorf("parsing gemini chat: %w", err)
+	}
+
+	var events []AgentEvent
+	for _, m := range chat.Messages {
+		var role string
+		switch m.Type {
+		case "user":
+			role = "user"
+		case "gemini":
+			role = "assistant"
+		default:
+			continue
+		}
+		ts := time.Now()
+		if t, err := time.Parse(time.RFC3339, m.Timestamp); err == nil {
+			ts = t
+		}
+		event := func(eventType, role, content string) AgentEvent {
+			return AgentEvent{
+				AgentType:       agentType,
+				SessionID:       sessionID,
+				NativeSessionID: chat.SessionID,
+				EventType:       eventType,
+				Role:            role,
+				Content:         content,
+				Timestamp:       ts,
+			}
+		}
+
+		for _, th := range m.Thoughts {
+			if text := strings.TrimSpace(th.Subject + "\n" + th.Description); text != "" {
+				events = append(events, event("thinking", role, text))
+			}
+		}
+		if text := geminiContentText(m.Content); text != "" {
+			events = append(events, event("text", role, text))
+		}
+		for _, tc := range m.ToolCalls {
+			events = append(events, event("tool_use", role, tc.Name+": "+string(tc.Args)))
+			if len(tc.Result) > 0 && string(tc.Result) != "null" {
+				events = append(events, event("tool_result", "user", string(tc.Result)))
+			}
+		}
+		if u := m.Tokens; u != nil && (u.Input > 0 || u.Output > 0 || u.Cached > 0) {
+			ev := event("usage", "assistant", "")
+			ev.InputTokens = max(u.Input-u.Cached, 0) + u.Tool
+			ev.CacheReadTokens = u.Cached
+			ev.OutputTokens = u.Output + u.Thoughts
+			ev.Model = m.Model
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+// geminiContentText returns message content, which older recordings store as
+// a string and newer ones as a list of parts.
+func geminiContentText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var parts []struct {
+		Text string `json:"text"`
+	}
+	if json.Unmarshal(raw, &parts) != nil {
+		return ""
+	}
+	var texts []string
+	for _, p := range parts {
+		if p.Text != "" {
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,59 @@
+package agentlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGeminiAdapter_ReadSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workDir := t.TempDir()
+	chatsDir, err := geminiChatsDirFor(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(chatsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	chat := `{"sessionId":"gem-1","messages":[
+  {"timestamp":"2026-03-01T10:00:00Z","type":"user","content":"hi"},
+  {"timestamp":"2026-03-01T10:00:01Z","type":"info","content":"model switched"},
+  {"timestamp":"2026-03-01T10:00:02Z","type":"gemini","content":[{"text":"Reading files"}],"model":"gemini-2.5-pro",
+   "thoughts":[{"subject":"Plan","description":"list the dir"}],
+   "toolCalls":[{"name":"list_directory","args":{"path":"."},"result":[{"output":"main.go"}]}],
+   "tokens":{"input":1200,"output":80,"cached":1000,"thoughts":20,"tool":5,"total":1305}}
+]}`
+	if err := os.WriteFile(filepath.Join(chatsDir, "session-2026-03-01T10-00-gem1.json"), []byte(chat), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := (&GeminiAdapter{}).ReadSession("gt-mayor", workDir, time.Time{})
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+	var types []string
+	for _, ev := range events {
+		types = append(types, ev.EventType)
+	}
+	want := "text,thinking,text,tool_use,tool_result,usage"
+	if got := strings.Join(types, ","); got != want {
+		t.Fatalf("event types = %s, want %s", got, want)
+	}
+	u := events[len(events)-1]
+	if u.InputTokens != 205 || u.CacheReadTokens != 1000 || u.OutputTokens != 100 || u.Model != "gemini-2.5-pro" {
+		t.Errorf("usage = %+v, want 205 in, 1000 cached, 100 out", u)
+	}
+	if u.NativeSessionID != "gem-1" || u.AgentType != "gemini" {
+		t.Errorf("bad usage tags: %+v", u)
+	}
+}
+
+func TestGeminiAdapter_ReadSession_NoChats(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if _, err := (&GeminiAdapter{}).ReadSession("s", t.TempDir(), time.Time{}); err == nil {
+		t.Error("expected error when no chats exist")
+	}
+}
@@ -2,20 +2,253 @@ package agentlog
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
-// OpenCodeAdapter is a placeholder for future OpenCode conversation log support.
-// OpenCode stores conversations differently from Claude Code; implement Watch
-// here when adding OpenCode telemetry.
+// OpenCodeAdapter reads OpenCode's session storage.
+//
+// OpenCode stores each session, message and message part as its own JSON
+// file under $XDG_DATA_HOME/opencode/storage (default ~/.local/share):
+//
+//	session/<project-id>/<session-id>.json   {"id","directory","parentID","time"}
+//	message/<session-id>/<message-id>.json   {"role","modelID","cost","tokens","time"}
+//	part/<message-id>/<part-id>.json         {"type":"text"|"reasoning"|"tool",…}
+//
+// A session is matched to a Gas Town work directory by its "directory" field.
+// Subagent sessions (those whose parentID is the matched session) are read
+// too, so their spend is counted. OpenCode records its own per-message cost,
+// which is carried on the usage events as CostUSD.
 //
 // See: https://github.com/sst/opencode for OpenCode's storage format.
 type OpenCodeAdapter struct{}
 
 func (a *OpenCodeAdapter) AgentType() string { return "opencode" }
 
-// Watch is not yet implemented for OpenCode.
-func (a *OpenCodeAdapter) Watch(_ context.Context, _, _ string, _ time.Time) (<-chan AgentEvent, error) {
-	return nil, fmt.Errorf("opencode adapter not yet implemented")
+// Watch polls the newest OpenCode session for workDir and streams new events.
+func (a *OpenCodeAdapter) Watch(ctx context.Context, sessionID, workDir string, since time.Time) (<-chan AgentEvent, error) {
+	return watchSnapshots(ctx, func() ([]AgentEvent, error) {
+		return a.ReadSession(sessionID, workDir, since)
+	}), nil
+}
+
+// ReadSession reads the newest OpenCode session for workDir updated at or
+// after since, plus its subagent sessions.
+func (a *OpenCodeAdapter) ReadSession(sessionID, workDir string, since time.Time) ([]AgentEvent, error) {
+	storage, err := openCodeStorageDir()
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving absolute path: %w", err)
+	}
+
+	sessions := readOpenCodeSessions(filepath.Join(storage, "session"))
+	var root *openCodeSession
+	for i := range sessions {
+		s := &sessions[i]
+		if s.ParentID != "" || filepath.Clean(s.Directory) != abs {
+			continue
+		}
+		if !since.IsZero() && s.updated().Before(since) {
+			continue
+		}
+		if root == nil || s.updated().After(root.updated()) {
+			root = s
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no opencode session for %s in %s", abs, storage)
+	}
+
+	ids := []string{root.ID}
+	for _, s := range sessions {
+		if s.ParentID == root.ID {
+			ids = append(ids, s.ID)
+		}
+	}
+	var events []AgentEvent
+	for _, id := range ids {
+		events = append(events, readOpenCodeMessages(storage, id, root.ID, sessionID, a.AgentType())...)
+	}
+	// Interleave subagent activity by time so new events always come last,
+	// which watchSnapshots relies on.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events, nil
+}
+
+// openCodeStorageDir returns $XDG_DATA_HOME/opencode/storage, defaulting
+// XDG_DATA_HOME to ~/.local/share.
+func openCodeStorageDir() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("getting home dir: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "opencode", "storage"), nil
+}
+
+// ── OpenCode storage structures ───────────────────────────────────────────────
+
+type openCodeTime struct {
+	Created int64 `json:"created"` // Unix milliseconds
+	Updated int64 `json:"updated,omitempty"`
+}
+
+type openCodeSession struct {
+	ID        string       `json:"id"`
+	ParentID  string       `json:"parentID,omitempty"`
+	Directory string       `json:"directory"`
+	Time      openCodeTime `json:"time"`
+}
+
+func (s *openCodeSession) updated() time.Time {
+	if s.Time.Updated > 0 {
+		return time.UnixMilli(s.Time.Updated)
+	}
+	return time.UnixMilli(s.Time.Created)
+}
+
+type openCodeMessage struct {
+	ID      string       `json:"id"`
+	Role    string       `json:"role"`
+	ModelID string       `json:"modelID,omitempty"`
+	Cost    float64      `json:"cost,omitempty"`
+	Time    openCodeTime `json:"time"`
+	Tokens  *struct {
+		Input     int `json:"input"`
+		Output    int `json:"output"`
+		Reasoning int `json:"reasoning"`
+		Cache     struct {
+			Read  int `json:"read"`
+			Write int `json:"write"`
+		} `json:"cache"`
+	} `json:"tokens,omitempty"`
+}
+
+type openCodePart struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"` // "text", "reasoning", "tool", …
+	Text  string `json:"text,omitempty"`
+	Tool  string `json:"tool,omitempty"`
+	State *struct {
+		Input  json.RawMessage `json:"input,omitempty"`
+		Output string          `json:"output,omitempty"`
+	} `json:"state,omitempty"`
+}
+
+// readJSONDir unmarshals every .json file in dir into a new T, skipping
+// unreadable or malformed files.
+func readJSONDir[T any](dir string) []T {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []T
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var v T
+		if json.Unmarshal(data, &v) == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// readOpenCodeSessions reads the session files of every project.
+func readOpenCodeSessions(sessionDir string) []openCodeSession {
+	projects, err := os.ReadDir(sessionDir)
+	if err != nil {
+		return nil
+	}
+	var sessions []openCodeSession
+	for _, p := range projects {
+		if p.IsDir() {
+			sessions = append(sessions, readJSONDir[openCodeSession](filepath.Join(sessionDir, p.Name()))...)
+		}
+	}
+	return sessions
+}
+
+// readOpenCodeMessages converts one OpenCode session's messages and parts
+// into AgentEvents, in message order. Events are tagged with rootID so
+// subagent activity reads as part of the parent session.
+func readOpenCodeMessages(storage, openCodeID, rootID, sessionID, agentType string) []AgentEvent {
+	messages := readJSONDir[openCodeMessage](filepath.Join(storage, "message", openCodeID))
+	sort.Slice(messages, func(i, j int) bool {
+		if messages[i].Time.Created != messages[j].Time.Created {
+			return messages[i].Time.Created < messages[j].Time.Created
+		}
+		return messages[i].ID < messages[j].ID
+	})
+
+	var events []AgentEvent
+	for _, m := range messages {
+		ts := time.UnixMilli(m.Time.Created)
+		event := func(eventType, role, content string) AgentEvent {
+			return AgentEvent{
+				AgentType:       agentType,
+				SessionID:       sessionID,
+				NativeSessionID: rootID,
+				EventType:       eventType,
+				Role:            role,
+				Content:         content,
+				Timestamp:       ts,
+			}
+		}
+
+		parts := readJSONDir[openCodePart](filepath.Join(storage, "part", m.ID))
+		sort.Slice(parts, func(i, j int) bool { return parts[i].ID < parts[j].ID })
+		for _, p := range parts {
+			switch p.Type {
+			case "text":
+				if p.Text != "" {
+					events = append(events, event("text", m.Role, p.Text))
+				}
+			case "reasoning":
+				if p.Text != "" {
+					events = append(events, event("thinking", m.Role, p.Text))
+				}
+			case "tool":
+				var input, output string
+				if p.State != nil {
+					input, output = string(p.State.Input), p.State.Output
+				}
+				events = append(events, event("tool_use", m.Role, p.Tool+": "+input))
+				if output != "" {
+					events = append(events, event("tool_result", "user", output))
+				}
+			}
+		}
+
+		if u := m.Tokens; m.Role == "assistant" && u != nil &&
+			(u.Input > 0 || u.Output > 0 || u.Cache.Read > 0 || u.Cache.Write > 0) {
+			ev := event("usage", "assistant", "")
+			ev.InputTokens = u.Input
+			ev.OutputTokens = u.Output + u.Reasoning
+			ev.CacheReadTokens = u.Cache.Read
+			ev.CacheCreationTokens = u.Cache.Write
+			ev.Model = m.ModelID
+			ev.CostUSD = m.Cost
+			events = append(events, ev)
+		}
+	}
+	return events
 }
@@ -0,0 +1,79 @@
+package agentlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeOpenCodeFile(t *testing.T, storage, rel, content string) {
+	t.Helper()
+	path := filepath.Join(storage, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenCodeAdapter_ReadSession(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	storage := filepath.Join(dataHome, "opencode", "storage")
+	workDir := t.TempDir()
+
+	// An older session in the same dir, one elsewhere, the current one and its subagent.
+	writeOpenCodeFile(t, storage, "session/p1/ses_old.json",
+		`{"id":"ses_old","directory":"`+workDir+`","time":{"created":1000,"updated":2000}}`)
+	writeOpenCodeFile(t, storage, "session/p2/ses_else.json",
+		`{"id":"ses_else","directory":"/elsewhere","time":{"created":1000,"updated":9000}}`)
+	writeOpenCodeFile(t, storage, "session/p1/ses_new.json",
+		`{"id":"ses_new","directory":"`+workDir+`","time":{"created":3000,"updated":5000}}`)
+	writeOpenCodeFile(t, storage, "session/p1/ses_sub.json",
+		`{"id":"ses_sub","parentID":"ses_new","directory":"`+workDir+`","time":{"created":3500,"updated":9999}}`)
+
+	writeOpenCodeFile(t, storage, "message/ses_new/msg_1.json",
+		`{"id":"msg_1","role":"user","time":{"created":3000}}`)
+	writeOpenCodeFile(t, storage, "part/msg_1/prt_1.json", `{"id":"prt_1","type":"text","text":"do it"}`)
+	writeOpenCodeFile(t, storage, "message/ses_new/msg_2.json",
+		`{"id":"msg_2","role":"assistant","modelID":"claude-sonnet-4","cost":0.05,"time":{"created":3100},
+		  "tokens":{"input":100,"output":20,"reasoning":5,"cache":{"read":300,"write":40}}}`)
+	writeOpenCodeFile(t, storage, "part/msg_2/prt_2.json",
+		`{"id":"prt_2","type":"tool","tool":"bash","state":{"status":"completed","input":{"command":"ls"},"output":"a.go"}}`)
+	writeOpenCodeFile(t, storage, "message/ses_sub/msg_3.json",
+		`{"id":"msg_3","role":"assistant","modelID":"gpt-5","time":{"created":3600},"tokens":{"input":10,"output":1,"cache":{}}}`)
+	writeOpenCodeFile(t, storage, "message/ses_old/msg_0.json",
+		`{"id":"msg_0","role":"assistant","cost":9,"time":{"created":1500},"tokens":{"input":1,"output":1,"cache":{}}}`)
+
+	events, err := (&OpenCodeAdapter{}).ReadSession("gt-gastown-crew-max", workDir, time.Time{})
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+	var types []string
+	for _, ev := range events {
+		types = append(types, ev.EventType)
+		if ev.NativeSessionID != "ses_new" {
+			t.Errorf("event tagged %q, want root session ses_new", ev.NativeSessionID)
+		}
+	}
+	want := "text,tool_use,tool_result,usage,usage"
+	if got := strings.Join(types, ","); got != want {
+		t.Fatalf("event types = %s, want %s", got, want)
+	}
+	u := events[3]
+	if u.InputTokens != 100 || u.OutputTokens != 25 || u.CacheReadTokens != 300 ||
+		u.CacheCreationTokens != 40 || u.CostUSD != 0.05 || u.Model != "claude-sonnet-4" {
+		t.Errorf("usage = %+v", u)
+	}
+	if sub := events[4]; sub.Model != "gpt-5" || sub.InputTokens != 10 {
+		t.Errorf("subagent usage = %+v", sub)
+	}
+
+	// since past the current session's last update finds nothing.
+	if _, err := (&OpenCodeAdapter{}).ReadSession("s", workDir, time.UnixMilli(6000)); err == nil {
+		t.Error("expected error when no session is recent enough")
+	}
+}
@@ -0,0 +1,106 @@
+package agentlog
+
+import "strings"
+
+// ModelPrice is the USD price per million tokens for one model.
+type ModelPrice struct {
+	InputPerMillion       float64 `json:"input_per_million"`
+	OutputPerMillion      float64 `json:"output_per_million"`
+	CacheReadPerMillion   float64 `json:"cache_read_per_million,omitempty"`
+	CacheCreatePerMillion float64 `json:"cache_create_per_million,omitempty"`
+}
+
+// PriceTable maps model names to prices. A key matches a model exactly or as
+// a prefix (so "gpt-5" covers "gpt-5-codex"); the longest matching key wins.
+// The "default" key prices models that match nothing else.
+type PriceTable map[string]ModelPrice
+
+// DefaultPriceKey is the PriceTable key used for unknown models.
+const DefaultPriceKey = "default"
+
+// DefaultPriceTable returns the built-in list prices for the models used by
+// the supported agent presets. Override or extend it with the "pricing" block
+// of the town settings.
+func DefaultPriceTable() PriceTable {
+	return PriceTable{
+		// Anthropic — https://www.anthropic.com/pricing
+		"claude-opus-4-5-20251101":  {15.0, 75.0, 1.5, 18.75},
+		"claude-sonnet-4-20250514":  {3.0, 15.0, 0.3, 3.75},
+		"claude-3-5-haiku-20241022": {1.0, 5.0, 0.1, 1.25},
+		"claude-opus":               {15.0, 75.0, 1.5, 18.75},
+		"claude-sonnet":             {3.0, 15.0, 0.3, 3.75},
+		"claude-haiku":              {1.0, 5.0, 0.1, 1.25},
+
+		// OpenAI (codex) — https://openai.com/api/pricing
+		"gpt-5":      {1.25, 10.0, 0.125, 0},
+		"gpt-5-mini": {0.25, 2.0, 0.025, 0},
+		"gpt-4.1":    {2.0, 8.0, 0.5, 0},
+		"o4-mini":    {1.1, 4.4, 0.275, 0},
+
+		// Google (gemini) — https://ai.google.dev/pricing
+		"gemini-2.5-pro":        {1.25, 10.0, 0.31, 0},
+		"gemini-2.5-flash":      {0.30, 2.50, 0.075, 0},
+		"gemini-2.5-flash-lite": {0.10, 0.40, 0.025, 0},
+
+		// Fallback for unknown models (Sonnet pricing)
+		DefaultPriceKey: {3.0, 15.0, 0.3, 3.75},
+	}
+}
+
+// Merge returns a copy of t with overrides applied on top.
+func (t PriceTable) Merge(overrides PriceTable) PriceTable {
+	merged := make(PriceTable, len(t)+len(overrides))
+	for model, price := range t {
+		merged[model] = price
+	}
+	for model, price := range overrides {
+		merged[model] = price
+	}
+	return merged
+}
+
+// Lookup returns the price for model. Provider prefixes such as
+// "anthropic/" are ignored.
+func (t PriceTable) Lookup(model string) ModelPrice {
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	if price, ok := t[model]; ok {
+		return price
+	}
+	best := ""
+	for key := range t {
+		if key != DefaultPriceKey && len(key) > len(best) && strings.HasPrefix(model, key) {
+			best = key
+		}
+	}
+	if best != "" {
+		return t[best]
+	}
+	return t[DefaultPriceKey]
+}
+
+// Cost returns the USD cost of a "usage" event. The agent's own figure is
+// used when the log records one; otherwise the tokens are priced.
+func (t PriceTable) Cost(ev AgentEvent) float64 {
+	if ev.EventType != "usage" {
+		return 0
+	}
+	if ev.CostUSD > 0 {
+		return ev.CostUSD
+	}
+	p := t.Lookup(ev.Model)
+	return (float64(ev.InputTokens)*p.InputPerMillion +
+		float64(ev.OutputTokens)*p.OutputPerMillion +
+		float64(ev.CacheReadTokens)*p.CacheReadPerMillion +
+		float64(ev.CacheCreationTokens)*p.CacheCreatePerMillion) / 1_000_000
+}
+
+// SessionCost sums the cost of all "usage" events.
+func (t PriceTable) SessionCost(events []AgentEvent) float64 {
+	var total float64
+	for _, ev := range events {
+		total += t.Cost(ev)
+	}
+	return total
+}
@@ -0,0 +1,67 @@
+package agentlog
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPriceTable_Lookup(t *testing.T) {
+	table := DefaultPriceTable()
+	tests := []struct {
+		model string
+		want  ModelPrice
+	}{
+		{"claude-sonnet-4-20250514", table["claude-sonnet-4-20250514"]},
+		{"claude-haiku-4-5-20251001", table["claude-haiku"]},
+		{"anthropic/claude-opus-4-1", table["claude-opus"]},
+		{"gpt-5-codex", table["gpt-5"]},
+		{"gpt-5-mini-2025-08-07", table["gpt-5-mini"]},
+		{"gemini-2.5-flash-lite", table["gemini-2.5-flash-lite"]},
+		{"", table[DefaultPriceKey]},
+		{"mystery-model", table[DefaultPriceKey]},
+	}
+	for _, tt := range tests {
+		if got := table.Lookup(tt.model); got != tt.want {
+			t.Errorf("Lookup(%q) = %+v, want %+v", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestPriceTable_MergeOverrides(t *testing.T) {
+	base := DefaultPriceTable()
+	merged := base.Merge(PriceTable{
+		"gpt-5":       {InputPerMillion: 2, OutputPerMillion: 20},
+		"local-llama": {},
+	})
+	if merged.Lookup("gpt-5-codex").InputPerMillion != 2 {
+		t.Errorf("override not applied: %+v", merged.Lookup("gpt-5-codex"))
+	}
+	if merged.Lookup("local-llama-70b") != (ModelPrice{}) {
+		t.Errorf("free local model priced: %+v", merged.Lookup("local-llama-70b"))
+	}
+	if base["gpt-5"].InputPerMillion != 1.25 {
+		t.Error("Merge modified the receiver")
+	}
+}
+
+func TestPriceTable_Cost(t *testing.T) {
+	table := PriceTable{
+		"m":             {InputPerMillion: 1, OutputPerMillion: 10, CacheReadPerMillion: 0.1, CacheCreatePerMillion: 2},
+		DefaultPriceKey: {InputPerMillion: 100},
+	}
+	events := []AgentEvent{
+		{EventType: "usage", Model: "m", InputTokens: 1_000_000, OutputTokens: 500_000,
+			CacheReadTokens: 2_000_000, CacheCreationTokens: 250_000},
+		{EventType: "usage", Model: "m", InputTokens: 1_000_000, CostUSD: 0.42},
+		{EventType: "text", Model: "m", InputTokens: 1_000_000},
+	}
+	if got := table.Cost(events[0]); math.Abs(got-6.7) > 1e-9 {
+		t.Errorf("Cost(tokens) = %v, want 6.7", got)
+	}
+	if got := table.Cost(events[1]); got != 0.42 {
+		t.Errorf("Cost(agent-reported) = %v, want 0.42", got)
+	}
+	if got := table.SessionCost(events); math.Abs(got-7.12) > 1e-9 {
+		t.Errorf("SessionCost = %v, want 7.12", got)
+	}
+}
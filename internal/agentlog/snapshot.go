@@ -0,0 +1,44 @@
+package agentlog
+
+import (
+	"context"
+	"time"
+)
+
+// snapshotPollInterval is how often watchSnapshots re-reads a session log.
+const snapshotPollInterval = 2 * time.Second
+
+// watchSnapshots implements Watch for adapters whose logs are rewritten in
+// place (Gemini) or spread across many files (OpenCode, Codex day folders),
+// where tailing one append-only file is not possible. It re-reads the newest
+// session via read on every poll and emits only the events not yet emitted
+// for that native session, so switching to a new session starts from its
+// first event. read must return events in a stable order with new events
+// last.
+func watchSnapshots(ctx context.Context, read func() ([]AgentEvent, error)) <-chan AgentEvent {
+	ch := make(chan AgentEvent, 64)
+	go func() {
+		defer close(ch)
+		emitted := make(map[string]int)
+		for {
+			if events, err := read(); err == nil && len(events) > 0 {
+				nativeID := events[0].NativeSessionID
+				start := min(emitted[nativeID], len(events))
+				for _, ev := range events[start:] {
+					select {
+					case ch <- ev:
+					case <-ctx.Done():
+						return
+					}
+				}
+				emitted[nativeID] = len(events)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(snapshotPollInterval):
+			}
+		}
+	}()
+	return ch
+}
@@ -27,6 +27,7 @@ type EscalationFields struct {
 	ReescalationCount  int    // Number of times this has been re-escalated
 	LastReescalatedAt  string // When last re-escalated (empty if never)
 	LastReescalatedBy  string // Who last re-escalated (empty if never)
+	OnCall             string // Overseer on call when raised (e.g., "overseer/alice"; empty without a roster)
 }
 
 
@@ -96,6 +97,11 @@ func FormatEscalationDescription(title string, fields *EscalationFields) string
 	} else {
 		lines = append(lines, "last_reescalated_by: null")
 	}
+	if fields.OnCall != "" {
+		lines = append(lines, fmt.Sprintf("on_call: %s", fields.OnCall))
+	} else {
+		lines = append(lines, "on_call: null")
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -152,6 +158,8 @@ func ParseEscalationFields(description string) *EscalationFields {
 			fields.LastReescalatedAt = value
 		case "last_reescalated_by":
 			fields.LastReescalatedBy = value
+		case "on_call":
+			fields.OnCall = value
 		}
 	}
 
@@ -182,6 +190,11 @@ func (b *Beads) CreateEscalationBead(title string, fields *EscalationFields) (*I
 		args = append(args, fmt.Sprintf("--labels=severity:%s", fields.Severity))
 	}
 
+	// Label the on-call overseer so per-human ack tracking can filter
+	if fields != nil && fields.OnCall != "" {
+		args = append(args, "--labels=oncall:"+fields.OnCall)
+	}
+
 	// Default actor from BD_ACTOR env var for provenance tracking
 	// Uses getActor() to respect isolated mode (tests)
 	if actor := b.getActor(); actor != "" {
@@ -88,6 +88,7 @@ func TestFormatEscalationDescription(t *testing.T) {
 				"closed_reason: null",
 				"related_bead: null",
 				"original_severity: null",
+				"on_call: null",
 			},
 		},
 		{
@@ -257,6 +258,7 @@ func TestEscalationFieldsRoundTrip(t *testing.T) {
 		ReescalationCount: 1,
 		LastReescalatedAt: "2024-06-15T11:30:00Z",
 		LastReescalatedBy: "deacon",
+		OnCall:            "overseer/alice",
 	}
 
 	formatted := FormatEscalationDescription("Escalation: Agent stuck", original)
@@ -298,6 +300,9 @@ func TestEscalationFieldsRoundTrip(t *testing.T) {
 	if parsed.LastReescalatedBy != original.LastReescalatedBy {
 		t.Errorf("LastReescalatedBy: got %q, want %q", parsed.LastReescalatedBy, original.LastReescalatedBy)
 	}
+	if parsed.OnCall != original.OnCall {
+		t.Errorf("OnCall: got %q, want %q", parsed.OnCall, original.OnCall)
+	}
 }
 
 func TestBumpSeverity(t *testing.T) {
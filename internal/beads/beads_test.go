@@ -621,6 +621,34 @@ source_issue: es-ixjt
 rig: gastown
 commit_sha: a1b2c3d4`,
 		},
+		{
+			name: "with pull request tracking",
+			fields: &MRFields{
+				Branch:   "polecat/nux/gt-pr1",
+				Target:   "main",
+				PRNumber: 42,
+				PRURL:    "https://github.com/octo/repo/pull/42",
+				PRState:  "draft",
+			},
+			want: `branch: polecat/nux/gt-pr1
+target: main
+pr_number: 42
+pr_url: https://github.com/octo/repo/pull/42
+pr_state: draft`,
+		},
+		{
+			name: "with flaky retries",
+			fields: &MRFields{
+				Branch:       "polecat/nux/gt-fl1",
+				Target:       "main",
+				RetryCount:   1,
+				FlakyRetries: 2,
+			},
+			want: `branch: polecat/nux/gt-fl1
+target: main
+retry_count: 1
+flaky_retries: 2`,
+		},
 	}
 
 	for _, tt := range tests {
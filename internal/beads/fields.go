@@ -26,6 +26,7 @@ type AttachmentFields struct {
 	MergeStrategy    string // Convoy merge strategy: "direct", "mr", "local", or "" (default = mr)
 	ConvoyOwned      bool   // If true, convoy has gt:owned label (caller-managed lifecycle)
 	FormulaVars      string // Newline-separated key=value pairs for formula template substitution
+	TraceParent      string // W3C traceparent of the bead's lifecycle trace (set by gt sling)
 }
 
 // ParseAttachmentFields extracts attachment fields from an issue's description.
@@ -97,6 +98,9 @@ func ParseAttachmentFields(issue *Issue) *AttachmentFields {
 		case "formula_vars", "formula-vars", "formulavars":
 			fields.FormulaVars = value
 			hasFields = true
+		case "trace_parent", "trace-parent", "traceparent":
+			fields.TraceParent = value
+			hasFields = true
 		}
 	}
 
@@ -154,6 +158,9 @@ func FormatAttachmentFields(fields *AttachmentFields) string {
 	if fields.FormulaVars != "" {
 		lines = append(lines, "formula_vars: "+fields.FormulaVars)
 	}
+	if fields.TraceParent != "" {
+		lines = append(lines, "trace_parent: "+fields.TraceParent)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -202,6 +209,9 @@ func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
 		"formula_vars":      true,
 		"formula-vars":      true,
 		"formulavars":       true,
+		"trace_parent":      true,
+		"trace-parent":      true,
+		"traceparent":       true,
 	}
 
 	// Collect non-attachment lines from existing description
@@ -581,6 +591,7 @@ type MRFields struct {
 
 	// Conflict resolution fields (for priority scoring)
 	RetryCount      int    // Number of conflict-resolution cycles
+	FlakyRetries    int    // Polls retried after failing only known-flaky gates
 	LastConflictSHA string // SHA of main when conflict occurred
 	ConflictTaskID  string // Link to conflict-resolution task (if any)
 
@@ -594,6 +605,16 @@ type MRFields struct {
 	PreVerified     bool   // Polecat ran full gates after rebasing onto target
 	PreVerifiedAt   string // ISO 8601 timestamp when verification completed
 	PreVerifiedBase string // Target branch SHA at verification time
+
+	// Pull request tracking (merge_strategy "pr")
+	// The refinery opens a GitHub PR per MR and mirrors its lifecycle here.
+	PRNumber int    // GitHub pull request number
+	PRURL    string // GitHub pull request URL
+	PRState  string // Last observed PR state: draft, changes_requested, checks_failed, ready, merged, closed
+
+	// TraceParent is the W3C traceparent of the source issue's lifecycle
+	// trace, so refinery spans join the trace started by gt sling.
+	TraceParent string
 }
 
 // ParseMRFields extracts structured merge-request fields from an issue's description.
@@ -659,6 +680,11 @@ func ParseMRFields(issue *Issue) *MRFields {
 				fields.RetryCount = n
 				hasFields = true
 			}
+		case "flaky_retries", "flaky-retries", "flakyretries":
+			if n, err := parseIntField(value); err == nil {
+				fields.FlakyRetries = n
+				hasFields = true
+			}
 		case "last_conflict_sha", "last-conflict-sha", "lastconflictsha":
 			fields.LastConflictSHA = value
 			hasFields = true
@@ -680,6 +706,20 @@ func ParseMRFields(issue *Issue) *MRFields {
 		case "pre_verified_base", "pre-verified-base", "preverifiedbase":
 			fields.PreVerifiedBase = value
 			hasFields = true
+		case "pr_number", "pr-number", "prnumber":
+			if n, err := parseIntField(value); err == nil {
+				fields.PRNumber = n
+				hasFields = true
+			}
+		case "pr_url", "pr-url", "prurl":
+			fields.PRURL = value
+			hasFields = true
+		case "pr_state", "pr-state", "prstate":
+			fields.PRState = value
+			hasFields = true
+		case "trace_parent", "trace-parent", "traceparent":
+			fields.TraceParent = value
+			hasFields = true
 		}
 	}
 
@@ -735,6 +775,9 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.RetryCount > 0 {
 		lines = append(lines, fmt.Sprintf("retry_count: %d", fields.RetryCount))
 	}
+	if fields.FlakyRetries > 0 {
+		lines = append(lines, fmt.Sprintf("flaky_retries: %d", fields.FlakyRetries))
+	}
 	if fields.LastConflictSHA != "" {
 		lines = append(lines, "last_conflict_sha: "+fields.LastConflictSHA)
 	}
@@ -756,6 +799,18 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.PreVerifiedBase != "" {
 		lines = append(lines, "pre_verified_base: "+fields.PreVerifiedBase)
 	}
+	if fields.PRNumber > 0 {
+		lines = append(lines, fmt.Sprintf("pr_number: %d", fields.PRNumber))
+	}
+	if fields.PRURL != "" {
+		lines = append(lines, "pr_url: "+fields.PRURL)
+	}
+	if fields.PRState != "" {
+		lines = append(lines, "pr_state: "+fields.PRState)
+	}
+	if fields.TraceParent != "" {
+		lines = append(lines, "trace_parent: "+fields.TraceParent)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -789,6 +844,9 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"retry_count":        true,
 		"retry-count":        true,
 		"retrycount":         true,
+		"flaky_retries":      true,
+		"flaky-retries":      true,
+		"flakyretries":       true,
 		"last_conflict_sha":  true,
 		"last-conflict-sha":  true,
 		"lastconflictsha":    true,
@@ -811,6 +869,18 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"pre_verified_base":  true,
 		"pre-verified-base":  true,
 		"preverifiedbase":    true,
+		"pr_number":          true,
+		"pr-number":          true,
+		"prnumber":           true,
+		"pr_url":             true,
+		"pr-url":             true,
+		"prurl":              true,
+		"pr_state":           true,
+		"pr-state":           true,
+		"prstate":            true,
+		"trace_parent":       true,
+		"trace-parent":       true,
+		"traceparent":        true,
 	}
 
 	// Collect non-MR lines from existing description
@@ -689,3 +689,23 @@ func TestSetConvoyFieldsPreservesWatchers(t *testing.T) {
 		t.Errorf("lost prose, got:\n%s", got)
 	}
 }
+
+func TestTraceParentRoundTrip(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	attachment := ParseAttachmentFields(&Issue{Description: FormatAttachmentFields(&AttachmentFields{
+		AttachedMolecule: "gt-wisp-123",
+		TraceParent:      tp,
+	})})
+	if attachment == nil || attachment.TraceParent != tp {
+		t.Errorf("attachment TraceParent: got %+v, want %q", attachment, tp)
+	}
+
+	mr := ParseMRFields(&Issue{Description: "branch: polecat/nux\ntarget: main\ntrace_parent: " + tp})
+	if mr == nil || mr.TraceParent != tp {
+		t.Fatalf("MR TraceParent: got %+v, want %q", mr, tp)
+	}
+	if !strings.Contains(FormatMRFields(mr), "trace_parent: "+tp) {
+		t.Errorf("FormatMRFields dropped trace_parent:\n%s", FormatMRFields(mr))
+	}
+}
@@ -0,0 +1,220 @@
+package budget
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Kind is the scope a budget applies to.
+type Kind string
+
+const (
+	KindTown   Kind = "town"
+	KindRig    Kind = "rig"
+	KindRole   Kind = "role"
+	KindConvoy Kind = "convoy"
+)
+
+// Period is a budget period.
+type Period string
+
+const (
+	PeriodDaily   Period = "daily"
+	PeriodMonthly Period = "monthly"
+)
+
+// Level is how far spend has progressed through a budget.
+type Level string
+
+const (
+	LevelOK   Level = "ok"
+	LevelSoft Level = "soft"
+	LevelHard Level = "hard"
+)
+
+// Spend is cost in USD over one period, broken down by scope.
+type Spend struct {
+	Total    float64            `json:"total_usd"`
+	ByRig    map[string]float64 `json:"by_rig,omitempty"`
+	ByRole   map[string]float64 `json:"by_role,omitempty"`
+	ByConvoy map[string]float64 `json:"by_convoy,omitempty"`
+}
+
+// Add records usd of spend. Empty rig, role or convoy are not attributed.
+func (s *Spend) Add(rig, role, convoy string, usd float64) {
+	s.Total += usd
+	if rig != "" {
+		if s.ByRig == nil {
+			s.ByRig = make(map[string]float64)
+		}
+		s.ByRig[rig] += usd
+	}
+	if role != "" {
+		if s.ByRole == nil {
+			s.ByRole = make(map[string]float64)
+		}
+		s.ByRole[role] += usd
+	}
+	if convoy != "" {
+		if s.ByConvoy == nil {
+			s.ByConvoy = make(map[string]float64)
+		}
+		s.ByConvoy[convoy] += usd
+	}
+}
+
+// Merge adds o into s.
+func (s *Spend) Merge(o Spend) {
+	s.Total += o.Total
+	s.ByRig = mergeInto(s.ByRig, o.ByRig)
+	s.ByRole = mergeInto(s.ByRole, o.ByRole)
+	s.ByConvoy = mergeInto(s.ByConvoy, o.ByConvoy)
+}
+
+func mergeInto(dst, src map[string]float64) map[string]float64 {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]float64, len(src))
+	}
+	for k, v := range src {
+		dst[k] += v
+	}
+	return dst
+}
+
+// Status is one budget evaluated against spend.
+type Status struct {
+	Kind        Kind      `json:"kind"`
+	Name        string    `json:"name,omitempty"`
+	Period      Period    `json:"period"`
+	LimitUSD    float64   `json:"limit_usd"`
+	SpentUSD    float64   `json:"spent_usd"`
+	Percent     float64   `json:"percent"`
+	Level       Level     `json:"level"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+
+	// ProjectedExhaustion is when the limit is reached at the current burn
+	// rate, if that falls within the period.
+	ProjectedExhaustion *time.Time `json:"projected_exhaustion,omitempty"`
+}
+
+// Scope returns a display name for the status scope, e.g. "town" or
+// "rig gastown".
+func (s Status) Scope() string {
+	if s.Kind == KindTown {
+		return string(KindTown)
+	}
+	return fmt.Sprintf("%s %s", s.Kind, s.Name)
+}
+
+// Key identifies the status scope, period and period instance, e.g.
+// "rig:gastown:daily:2026-03-04". It changes when the period rolls over.
+func (s Status) Key() string {
+	return fmt.Sprintf("%s:%s:%s:%s", s.Kind, s.Name, s.Period, s.PeriodStart.Format("2006-01-02"))
+}
+
+// Current reports whether now falls within the status period.
+func (s Status) Current(now time.Time) bool {
+	return !now.Before(s.PeriodStart) && now.Before(s.PeriodEnd)
+}
+
+// PeriodBounds returns the start and end of the period containing now, in
+// now's location.
+func PeriodBounds(p Period, now time.Time) (start, end time.Time) {
+	y, m, d := now.Date()
+	if p == PeriodMonthly {
+		start = time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 1, 0)
+	}
+	start = time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	return start, start.AddDate(0, 0, 1)
+}
+
+// Evaluate checks every configured limit against daily and monthly spend.
+// Statuses are ordered town, rigs, roles, convoys, then by name and period.
+func Evaluate(cfg *Config, daily, monthly Spend, now time.Time) []Status {
+	if cfg.IsEmpty() {
+		return nil
+	}
+	soft := cfg.GetSoftPercent()
+
+	var statuses []Status
+	add := func(kind Kind, name string, limit *Limit, dailySpent, monthlySpent float64) {
+		if limit == nil {
+			return
+		}
+		if limit.DailyUSD > 0 {
+			statuses = append(statuses, evaluate(kind, name, PeriodDaily, limit.DailyUSD, dailySpent, soft, now))
+		}
+		if limit.MonthlyUSD > 0 {
+			statuses = append(statuses, evaluate(kind, name, PeriodMonthly, limit.MonthlyUSD, monthlySpent, soft, now))
+		}
+	}
+
+	add(KindTown, "", cfg.Town, daily.Total, monthly.Total)
+	for _, name := range sortedKeys(cfg.Rigs) {
+		add(KindRig, name, cfg.Rigs[name], daily.ByRig[name], monthly.ByRig[name])
+	}
+	for _, name := range sortedKeys(cfg.Roles) {
+		add(KindRole, name, cfg.Roles[name], daily.ByRole[name], monthly.ByRole[name])
+	}
+	for _, name := range sortedKeys(cfg.Convoys) {
+		add(KindConvoy, name, cfg.Convoys[name], daily.ByConvoy[name], monthly.ByConvoy[name])
+	}
+	return statuses
+}
+
+func evaluate(kind Kind, name string, period Period, limit, spent, softPct float64, now time.Time) Status {
+	start, end := PeriodBounds(period, now)
+	s := Status{
+		Kind:        kind,
+		Name:        name,
+		Period:      period,
+		LimitUSD:    limit,
+		SpentUSD:    spent,
+		Percent:     spent / limit * 100,
+		Level:       LevelOK,
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}
+	switch {
+	case s.Percent >= 100:
+		s.Level = LevelHard
+	case s.Percent >= softPct:
+		s.Level = LevelSoft
+	}
+	s.ProjectedExhaustion = Project(spent, limit, start, end, now)
+	return s
+}
+
+// Project extrapolates the average burn rate since start and returns when
+// spent reaches limit, or nil if that is not before end. An already
+// exhausted budget projects to now.
+func Project(spent, limit float64, start, end, now time.Time) *time.Time {
+	if spent >= limit {
+		return &now
+	}
+	elapsed := now.Sub(start)
+	if spent <= 0 || elapsed <= 0 {
+		return nil
+	}
+	rate := spent / elapsed.Seconds() // USD per second
+	at := now.Add(time.Duration((limit - spent) / rate * float64(time.Second)))
+	if !at.Before(end) {
+		return nil
+	}
+	return &at
+}
+
+func sortedKeys(m map[string]*Limit) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
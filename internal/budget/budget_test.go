@@ -0,0 +1,110 @@
+package budget
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluate_Levels(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	cfg := &Config{
+		Town:    &Limit{DailyUSD: 100, MonthlyUSD: 1000},
+		Rigs:    map[string]*Limit{"gastown": {DailyUSD: 20}, "beads": {DailyUSD: 50}},
+		Roles:   map[string]*Limit{"polecat": {MonthlyUSD: 300}},
+		Convoys: map[string]*Limit{"hq-cv-1": {DailyUSD: 10}},
+	}
+	var daily, monthly Spend
+	daily.Add("gastown", "polecat", "hq-cv-1", 25)
+	daily.Add("beads", "crew", "", 10)
+	monthly.Add("gastown", "polecat", "hq-cv-1", 250)
+	monthly.Add("beads", "crew", "", 100)
+
+	got := Evaluate(cfg, daily, monthly, now)
+	want := []struct {
+		scope  string
+		period Period
+		level  Level
+	}{
+		{"town", PeriodDaily, LevelOK},             // 35/100
+		{"town", PeriodMonthly, LevelOK},           // 350/1000
+		{"rig beads", PeriodDaily, LevelOK},        // 10/50
+		{"rig gastown", PeriodDaily, LevelHard},    // 25/20
+		{"role polecat", PeriodMonthly, LevelSoft}, // 250/300
+		{"convoy hq-cv-1", PeriodDaily, LevelHard}, // 25/10
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Evaluate returned %d statuses, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Scope() != w.scope || got[i].Period != w.period || got[i].Level != w.level {
+			t.Errorf("status[%d] = %s %s %s, want %s %s %s", i,
+				got[i].Scope(), got[i].Period, got[i].Level, w.scope, w.period, w.level)
+		}
+	}
+}
+
+func TestEvaluate_SoftPercent(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	var spend Spend
+	spend.Add("", "", "", 60)
+
+	cfg := &Config{Town: &Limit{DailyUSD: 100}}
+	if got := Evaluate(cfg, spend, Spend{}, now); got[0].Level != LevelOK {
+		t.Errorf("60%% with default soft threshold = %s, want ok", got[0].Level)
+	}
+	cfg.SoftPercent = 50
+	if got := Evaluate(cfg, spend, Spend{}, now); got[0].Level != LevelSoft {
+		t.Errorf("60%% with 50%% soft threshold = %s, want soft", got[0].Level)
+	}
+	if got := Evaluate(&Config{}, spend, spend, now); got != nil {
+		t.Errorf("empty config = %+v, want nil", got)
+	}
+}
+
+func TestProject(t *testing.T) {
+	start := time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	now := start.Add(6 * time.Hour)
+
+	// $25 in 6h → $100 at 24h: right at the period end, so not within it.
+	if got := Project(25, 100, start, end, now); got != nil {
+		t.Errorf("Project at exact period end = %v, want nil", got)
+	}
+	// $50 in 6h → $100 at 12h.
+	got := Project(50, 100, start, end, now)
+	if want := start.Add(12 * time.Hour); got == nil || !got.Equal(want) {
+		t.Errorf("Project = %v, want %v", got, want)
+	}
+	if got := Project(120, 100, start, end, now); got == nil || !got.Equal(now) {
+		t.Errorf("Project when exhausted = %v, want now", got)
+	}
+	if got := Project(0, 100, start, end, now); got != nil {
+		t.Errorf("Project with no spend = %v, want nil", got)
+	}
+}
+
+func TestPeriodBounds(t *testing.T) {
+	now := time.Date(2026, time.December, 31, 18, 30, 0, 0, time.UTC)
+	start, end := PeriodBounds(PeriodDaily, now)
+	if !start.Equal(time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("daily bounds = %v..%v", start, end)
+	}
+	start, end = PeriodBounds(PeriodMonthly, now)
+	if !start.Equal(time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("monthly bounds = %v..%v", start, end)
+	}
+}
+
+func TestConfig_HardActionEnabled(t *testing.T) {
+	var nilCfg *Config
+	if nilCfg.HardActionEnabled(ActionRefuseSling) {
+		t.Error("nil config should enable no actions")
+	}
+	if !(&Config{}).HardActionEnabled(ActionParkRig) {
+		t.Error("empty HardActions should enable every action")
+	}
+	cfg := &Config{HardActions: []string{ActionRefuseSling}}
+	if !cfg.HardActionEnabled(ActionRefuseSling) || cfg.HardActionEnabled(ActionPauseScheduler) {
+		t.Errorf("HardActions %v not honoured", cfg.HardActions)
+	}
+}
@@ -0,0 +1,87 @@
+// Package budget provides types and pure functions for cost budget
+// enforcement. Budgets are configured in town settings; spend is gathered by
+// gt costs (session ledger, live transcripts and digests) and evaluated here.
+// The impure enforcement (escalation, pausing the scheduler, parking rigs)
+// stays in cmd and records what it did in a State file.
+package budget
+
+// Hard-threshold actions. When Config.HardActions is empty, all of them apply.
+const (
+	// ActionRefuseSling makes gt sling refuse work covered by an exhausted budget.
+	ActionRefuseSling = "refuse_sling"
+	// ActionPauseScheduler pauses the capacity scheduler while the town budget
+	// is exhausted.
+	ActionPauseScheduler = "pause_scheduler"
+	// ActionParkRig parks a rig while its budget is exhausted.
+	ActionParkRig = "park_rig"
+)
+
+// DefaultSoftPercent is the share of a budget at which the soft threshold
+// (warn and escalate) trips when Config.SoftPercent is unset.
+const DefaultSoftPercent = 80
+
+// Limit is a spending limit in USD. Zero means no limit for that period.
+type Limit struct {
+	DailyUSD   float64 `json:"daily_usd,omitempty"`
+	MonthlyUSD float64 `json:"monthly_usd,omitempty"`
+}
+
+// Config configures cost budgets. It lives in town settings under "budgets":
+//
+//	"budgets": {
+//	  "town":    {"daily_usd": 200, "monthly_usd": 4000},
+//	  "rigs":    {"gastown": {"daily_usd": 80}},
+//	  "roles":   {"polecat": {"daily_usd": 120}},
+//	  "convoys": {"hq-cv-abc": {"monthly_usd": 500}},
+//	  "soft_percent": 75,
+//	  "hard_actions": ["refuse_sling", "pause_scheduler"]
+//	}
+//
+// Reaching SoftPercent of a limit warns and escalates once per period.
+// Reaching 100% applies HardActions until the period rolls over.
+type Config struct {
+	Town    *Limit            `json:"town,omitempty"`
+	Rigs    map[string]*Limit `json:"rigs,omitempty"`
+	Roles   map[string]*Limit `json:"roles,omitempty"`
+	Convoys map[string]*Limit `json:"convoys,omitempty"`
+
+	// SoftPercent is the soft threshold as a percentage of each limit.
+	// 0 = DefaultSoftPercent.
+	SoftPercent float64 `json:"soft_percent,omitempty"`
+
+	// HardActions lists the actions applied when a limit is reached.
+	// Empty = all actions.
+	HardActions []string `json:"hard_actions,omitempty"`
+}
+
+// GetSoftPercent returns SoftPercent or DefaultSoftPercent if unset.
+func (c *Config) GetSoftPercent() float64 {
+	if c == nil || c.SoftPercent <= 0 {
+		return DefaultSoftPercent
+	}
+	return c.SoftPercent
+}
+
+// HardActionEnabled reports whether action applies at the hard threshold.
+func (c *Config) HardActionEnabled(action string) bool {
+	if c == nil {
+		return false
+	}
+	if len(c.HardActions) == 0 {
+		return true
+	}
+	for _, a := range c.HardActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty reports whether no limit is configured.
+func (c *Config) IsEmpty() bool {
+	if c == nil {
+		return true
+	}
+	return c.Town == nil && len(c.Rigs) == 0 && len(c.Roles) == 0 && len(c.Convoys) == 0
+}
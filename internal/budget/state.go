@@ -0,0 +1,155 @@
+package budget
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State is the last budget evaluation and what enforcement did about it.
+// Stored at <townRoot>/.runtime/budget-state.json. gt sling reads it to
+// refuse work without re-gathering spend; gt costs budget --enforce writes it.
+type State struct {
+	EvaluatedAt time.Time `json:"evaluated_at"`
+	Statuses    []Status  `json:"statuses,omitempty"`
+
+	// Escalated records the Status.Key of every budget already escalated, so
+	// each budget escalates at most once per period.
+	Escalated map[string]string `json:"escalated,omitempty"` // key → level escalated at
+
+	// PausedScheduler and ParkedRigs record what enforcement paused or
+	// parked, so only those are resumed when the budget recovers.
+	PausedScheduler bool     `json:"paused_scheduler,omitempty"`
+	ParkedRigs      []string `json:"parked_rigs,omitempty"`
+}
+
+func stateFile(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "budget-state.json")
+}
+
+// LoadState loads the budget state, returning a zero-value state if the file
+// doesn't exist (never evaluated, nothing enforced).
+func LoadState(townRoot string) (*State, error) {
+	data, err := os.ReadFile(stateFile(townRoot)) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{}, nil
+		}
+		return nil, err
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// SaveState writes the budget state to disk atomically.
+func SaveState(townRoot string, state *State) error {
+	path := stateFile(townRoot)
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".budget-state-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// SlingBlock returns the exhausted budget that forbids slinging a polecat to
+// rig for work in convoy (empty if untracked), or nil if none does. Only
+// statuses for the period containing now count, so a stale evaluation never
+// blocks past the end of its period.
+func (s *State) SlingBlock(rig, convoy string, now time.Time) *Status {
+	for i := range s.Statuses {
+		st := &s.Statuses[i]
+		if st.Level != LevelHard || !st.Current(now) {
+			continue
+		}
+		switch st.Kind {
+		case KindTown:
+			return st
+		case KindRig:
+			if st.Name == rig {
+				return st
+			}
+		case KindRole:
+			if st.Name == "polecat" {
+				return st
+			}
+		case KindConvoy:
+			if convoy != "" && st.Name == convoy {
+				return st
+			}
+		}
+	}
+	return nil
+}
+
+// HardRigs returns the rigs whose own budget is exhausted in the current period.
+func (s *State) HardRigs(now time.Time) []string {
+	var rigs []string
+	for _, st := range s.Statuses {
+		if st.Kind == KindRig && st.Level == LevelHard && st.Current(now) {
+			rigs = appendUnique(rigs, st.Name)
+		}
+	}
+	return rigs
+}
+
+// HardConvoys returns the convoys whose budget is exhausted in the current period.
+func (s *State) HardConvoys(now time.Time) []string {
+	var convoys []string
+	for _, st := range s.Statuses {
+		if st.Kind == KindConvoy && st.Level == LevelHard && st.Current(now) {
+			convoys = appendUnique(convoys, st.Name)
+		}
+	}
+	return convoys
+}
+
+// SchedulerExhausted reports whether a budget covering all scheduler
+// dispatch (the town, or the polecat role) is exhausted in the current period.
+func (s *State) SchedulerExhausted(now time.Time) bool {
+	for _, st := range s.Statuses {
+		if st.Level != LevelHard || !st.Current(now) {
+			continue
+		}
+		if st.Kind == KindTown || (st.Kind == KindRole && st.Name == "polecat") {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, x := range list {
+		if x == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
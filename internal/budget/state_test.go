@@ -0,0 +1,71 @@
+package budget
+
+import (
+	"testing"
+	"time"
+)
+
+func TestState_SaveLoad(t *testing.T) {
+	townRoot := t.TempDir()
+
+	state, err := LoadState(townRoot)
+	if err != nil {
+		t.Fatalf("LoadState on empty town: %v", err)
+	}
+	if len(state.Statuses) != 0 || state.PausedScheduler {
+		t.Fatalf("missing state file should load as zero value, got %+v", state)
+	}
+
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	state.EvaluatedAt = now
+	state.Statuses = Evaluate(&Config{Town: &Limit{DailyUSD: 10}}, Spend{Total: 12}, Spend{}, now)
+	state.PausedScheduler = true
+	state.ParkedRigs = []string{"gastown"}
+	if err := SaveState(townRoot, state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	loaded, err := LoadState(townRoot)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if len(loaded.Statuses) != 1 || loaded.Statuses[0].Level != LevelHard || !loaded.PausedScheduler || len(loaded.ParkedRigs) != 1 {
+		t.Errorf("round trip lost data: %+v", loaded)
+	}
+}
+
+func TestState_SlingBlock(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	cfg := &Config{
+		Rigs:    map[string]*Limit{"gastown": {DailyUSD: 10}},
+		Convoys: map[string]*Limit{"hq-cv-1": {DailyUSD: 10}},
+	}
+	var daily Spend
+	daily.Add("gastown", "polecat", "hq-cv-1", 15)
+	state := &State{Statuses: Evaluate(cfg, daily, Spend{}, now)}
+
+	if st := state.SlingBlock("gastown", "", now); st == nil || st.Kind != KindRig {
+		t.Errorf("SlingBlock(gastown) = %+v, want rig budget", st)
+	}
+	if st := state.SlingBlock("beads", "hq-cv-1", now); st == nil || st.Kind != KindConvoy {
+		t.Errorf("SlingBlock(beads, hq-cv-1) = %+v, want convoy budget", st)
+	}
+	if st := state.SlingBlock("beads", "", now); st != nil {
+		t.Errorf("SlingBlock(beads) = %+v, want nil", st)
+	}
+	// A daily evaluation stops blocking once the day is over.
+	if st := state.SlingBlock("gastown", "", now.Add(24*time.Hour)); st != nil {
+		t.Errorf("SlingBlock next day = %+v, want nil", st)
+	}
+	if rigs := state.HardRigs(now); len(rigs) != 1 || rigs[0] != "gastown" {
+		t.Errorf("HardRigs = %v, want [gastown]", rigs)
+	}
+	if state.SchedulerExhausted(now) {
+		t.Error("SchedulerExhausted with only rig and convoy budgets exhausted")
+	}
+
+	state.Statuses = Evaluate(&Config{Roles: map[string]*Limit{"polecat": {DailyUSD: 10}}}, daily, Spend{}, now)
+	if !state.SchedulerExhausted(now) || state.SlingBlock("beads", "", now) == nil {
+		t.Error("exhausted polecat budget should pause the scheduler and block slings")
+	}
+}
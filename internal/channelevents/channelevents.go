@@ -2,8 +2,9 @@
 //
 // Channel events are JSON files written to ~/gt/events/<channel>/*.event
 // and consumed by await-event subscribers (e.g., the refinery watching for
-// MERGE_READY events). This is distinct from the activity feed events in
-// the events package (~/gt/.events.jsonl).
+// MERGE_READY events). Each event is also published on the event bus with
+// source "channel" so subscribers are woken without polling the directory;
+// the file remains the durable, consumable copy.
 package channelevents
 
 import (
@@ -16,6 +17,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -43,7 +45,7 @@ func Emit(channel, eventType string, payloadPairs []string) (string, error) {
 		return "", fmt.Errorf("creating event directory: %w", err)
 	}
 
-	return emitToDir(eventDir, channel, eventType, payloadPairs)
+	return emitToDir(townRoot, eventDir, channel, eventType, payloadPairs)
 }
 
 // EmitToTown creates an event file using an explicit town root.
@@ -57,11 +59,12 @@ func EmitToTown(townRoot, channel, eventType string, payloadPairs []string) (str
 	if err := os.MkdirAll(eventDir, 0755); err != nil {
 		return "", fmt.Errorf("creating event directory: %w", err)
 	}
-	return emitToDir(eventDir, channel, eventType, payloadPairs)
+	return emitToDir(townRoot, eventDir, channel, eventType, payloadPairs)
 }
 
-// emitToDir writes an event file to the given directory.
-func emitToDir(eventDir, channel, eventType string, payloadPairs []string) (string, error) {
+// emitToDir writes an event file to the given directory and publishes it on
+// the town's event bus.
+func emitToDir(townRoot, eventDir, channel, eventType string, payloadPairs []string) (string, error) {
 	if !ValidChannelName.MatchString(channel) {
 		return "", fmt.Errorf("invalid channel name %q: must match [a-zA-Z0-9_-]", channel)
 	}
@@ -93,5 +96,20 @@ func emitToDir(eventDir, channel, eventType string, payloadPairs []string) (stri
 		return "", fmt.Errorf("writing event file: %w", err)
 	}
 
+	// Best-effort: the event file is already durable, and await-event falls
+	// back to rescanning the directory.
+	busPayload := make(map[string]interface{}, len(payload))
+	for k, v := range payload {
+		busPayload[k] = v
+	}
+	_, _ = eventbus.Append(townRoot, eventbus.Event{
+		Timestamp:  now.UTC().Format(time.RFC3339),
+		Source:     eventbus.SourceChannel,
+		Type:       eventType,
+		Channel:    channel,
+		Payload:    busPayload,
+		Visibility: "audit",
+	})
+
 	return eventFile, nil
 }
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/eventbus"
 )
 
 func TestEmitToTown(t *testing.T) {
@@ -113,3 +115,26 @@ func TestEmitToTown_CreatesDirectory(t *testing.T) {
 		t.Errorf("channel dir should exist after emit: %v", err)
 	}
 }
+
+func TestEmitToTown_PublishesOnBus(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+
+	if _, err := EmitToTown(townRoot, "refinery", "MERGE_READY", []string{"rig=dashboard"}); err != nil {
+		t.Fatalf("EmitToTown failed: %v", err)
+	}
+
+	var got []eventbus.Event
+	if _, err := eventbus.ReadFrom(townRoot, 0, eventbus.Filter{Channels: []string{"refinery"}}, func(ev eventbus.Event) error {
+		got = append(got, ev)
+		return nil
+	}); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 bus event, got %d", len(got))
+	}
+	if got[0].Source != eventbus.SourceChannel || got[0].Type != "MERGE_READY" || got[0].Payload["rig"] != "dashboard" {
+		t.Errorf("unexpected bus event: %+v", got[0])
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/eventbus"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Activity watch command flags
+var (
+	activityWatchTypes    []string
+	activityWatchSources  []string
+	activityWatchChannels []string
+	activityWatchActors   []string
+	activityWatchFrom     int64
+	activityWatchCount    int
+	activityWatchJSON     bool
+)
+
+var activityWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream events from the event bus",
+	Long: `Stream events from the Gas Town event bus as they happen.
+
+All events (activity, channel events, town log lines) share one journal,
+~/gt/.events.jsonl. Each event has an offset: its byte position in the
+journal. Pass --from with an offset to replay from that point (0 replays
+everything); by default only new events are shown.
+
+The subscription is served by the daemon when it is running, and otherwise
+follows the journal directly.
+
+Filters combine with AND; repeating a flag matches any of its values.
+--actor matches by prefix.
+
+Examples:
+  gt activity watch
+  gt activity watch --type merged --type merge_failed
+  gt activity watch --source channel --channel refinery
+  gt activity watch --actor gastown/ --from 0 --json
+  gt activity watch --type done --count 1     # wait for the next done event`,
+	Args: cobra.NoArgs,
+	RunE: runActivityWatch,
+}
+
+func init() {
+	activityWatchCmd.Flags().StringArrayVar(&activityWatchTypes, "type", nil, "Only events of this type (repeatable)")
+	activityWatchCmd.Flags().StringArrayVar(&activityWatchSources, "source", nil, "Only events from this source: gt, channel, townlog (repeatable)")
+	activityWatchCmd.Flags().StringArrayVar(&activityWatchChannels, "channel", nil, "Only channel events on this channel (repeatable)")
+	activityWatchCmd.Flags().StringArrayVar(&activityWatchActors, "actor", nil, "Only events whose actor starts with this prefix (repeatable)")
+	activityWatchCmd.Flags().Int64Var(&activityWatchFrom, "from", eventbus.FromEnd, "Journal offset to replay from (-1 for new events only)")
+	activityWatchCmd.Flags().IntVar(&activityWatchCount, "count", 0, "Exit after this many events (0 streams until interrupted)")
+	activityWatchCmd.Flags().BoolVar(&activityWatchJSON, "json", false, "Output events as JSON lines")
+
+	activityCmd.AddCommand(activityWatchCmd)
+}
+
+func runActivityWatch(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sub, err := eventbus.Subscribe(ctx, townRoot, eventbus.SubscribeRequest{
+		From: activityWatchFrom,
+		Filter: eventbus.Filter{
+			Types:    activityWatchTypes,
+			Sources:  activityWatchSources,
+			Channels: activityWatchChannels,
+			Actors:   activityWatchActors,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	if !activityWatchJSON {
+		via := "daemon"
+		if !sub.Daemon() {
+			via = "journal (daemon not running)"
+		}
+		fmt.Fprintf(os.Stderr, "%s Watching events via %s...\n", style.Dim.Render("⏳"), via)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	n := 0
+	for ev := range sub.Events() {
+		if activityWatchJSON {
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
+		} else {
+			fmt.Println(formatBusEvent(ev))
+		}
+		n++
+		if activityWatchCount > 0 && n >= activityWatchCount {
+			return nil
+		}
+	}
+	return sub.Err()
+}
+
+// formatBusEvent renders an event as one line: offset, time, source, type,
+// actor, and payload.
+func formatBusEvent(ev eventbus.Event) string {
+	kind := ev.Type
+	if ev.Channel != "" {
+		kind = ev.Channel + "/" + ev.Type
+	}
+	actor := ev.Actor
+	if actor == "" {
+		actor = "-"
+	}
+	line := fmt.Sprintf("%s %s %-8s %-24s %s", style.Dim.Render(fmt.Sprintf("@%d", ev.Offset)), ev.Timestamp, ev.Source, style.Bold.Render(kind), actor)
+	if len(ev.Payload) > 0 {
+		if data, err := json.Marshal(ev.Payload); err == nil {
+			line += " " + style.Dim.Render(string(data))
+		}
+	}
+	return line
+}
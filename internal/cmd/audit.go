@@ -422,6 +422,12 @@ func collectFeedEvents(townRoot, actor string, since time.Time) ([]AuditEntry, e
 			continue // Skip malformed lines
 		}
 
+		// Town log lines come from collectTownlogEvents, and channel
+		// events are not activity.
+		if !e.IsActivity() {
+			continue
+		}
+
 		// Apply actor filter
 		if actor != "" && !matchesActor(e.Actor, actor) {
 			continue
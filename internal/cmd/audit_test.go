@@ -3,6 +3,10 @@ package cmd
 import (
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/channelevents"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/townlog"
 )
 
 func TestParseDuration(t *testing.T) {
@@ -148,3 +152,34 @@ func TestFormatType(t *testing.T) {
 		}
 	}
 }
+
+func TestCollectNoDuplicateTownlogEvents(t *testing.T) {
+	// A town log line lands in both town.log and the event bus journal. It
+	// must show up once, from the town log, not again as an activity event.
+	townRoot := t.TempDir()
+	logger := townlog.NewLogger(townRoot)
+	if err := logger.Log(townlog.EventDone, "gastown/polecats/nux", "gt-abc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := events.LogAt(townRoot, events.TypeDone, "gastown/polecats/nux", events.DonePayload("gt-abc", "polecat/nux"), events.VisibilityFeed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := channelevents.EmitToTown(townRoot, "witness", "done", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	townlogEntries, err := collectTownlogEvents(townRoot, "", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	feedEntries, err := collectFeedEvents(townRoot, "", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(townlogEntries) != 1 {
+		t.Errorf("townlog entries = %+v, want 1", townlogEntries)
+	}
+	if len(feedEntries) != 1 || feedEntries[0].Type != events.TypeDone {
+		t.Errorf("feed entries = %+v, want the one activity event", feedEntries)
+	}
+}
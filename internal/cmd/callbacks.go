@@ -417,7 +417,7 @@ func handleHelp(townRoot string, msg *mail.Message, dryRun bool) (string, error)
 	defer router.WaitPendingNotifications()
 	fwd := &mail.Message{
 		From:    "mayor/",
-		To:      "overseer",
+		To:      mail.OnCallOverseer(townRoot),
 		Subject: fmt.Sprintf("[FWD][%s] HELP: %s", strings.ToUpper(string(assessment.Severity)), payload.Topic),
 		Body: fmt.Sprintf("Forwarded from: %s\nAssessment: category=%s severity=%s (suggest → %s)\nRationale: %s\n\n%s",
 			msg.From, assessment.Category, assessment.Severity, assessment.SuggestTo, assessment.Rationale, msg.Body),
@@ -452,7 +452,7 @@ func handleEscalation(townRoot string, msg *mail.Message, dryRun bool) (string,
 	defer router.WaitPendingNotifications()
 	fwd := &mail.Message{
 		From:     "mayor/",
-		To:       "overseer",
+		To:       mail.OnCallOverseer(townRoot),
 		Subject:  fmt.Sprintf("[ESCALATION] %s", topic),
 		Body:     fmt.Sprintf("Escalated by: %s\n\n%s", msg.From, msg.Body),
 		Priority: mail.PriorityUrgent,
@@ -11,6 +11,7 @@ import (
 	"github.com/gofrs/flock"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/estop"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/scheduler/capacity"
 	"github.com/steveyegge/gastown/internal/style"
@@ -384,6 +385,17 @@ func getReadySlingContexts(townRoot string) ([]capacity.PendingBead, error) {
 			continue
 		}
 
+		// Hold work for rigs whose polecats are E-stopped; it dispatches
+		// after thaw instead of tripping the circuit breaker.
+		if estop.IsStopped(townRoot, fields.TargetRig, string(RolePolecat)) {
+			continue
+		}
+
+		// Likewise hold work covered by an exhausted budget until it recovers.
+		if checkBudgetAllowsSling(townRoot, fields.TargetRig, fields.WorkBeadID) != nil {
+			continue
+		}
+
 		// Deduplicate: one dispatch per work bead (oldest context wins)
 		if seenWork[fields.WorkBeadID] {
 			continue
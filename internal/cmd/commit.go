@@ -55,7 +55,7 @@ func runCommit(cmd *cobra.Command, args []string) error {
 	identity := detectSender()
 
 	// If overseer (human), just pass through to git commit
-	if identity == "overseer" {
+	if config.IsOverseerAddress(identity) {
 		return runGitCommit(args, "", "")
 	}
 
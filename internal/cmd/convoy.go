@@ -246,7 +246,12 @@ var convoyStatusCmd = &cobra.Command{
 	Long: `Show detailed status for a convoy.
 
 Displays convoy metadata, tracked issues, and completion progress.
-Without an ID, shows status of all active convoys.`,
+Without an ID, shows status of all active convoys.
+
+Open convoys also show a forecast ETA. Each open bead is expected to take
+its rig's median sling-to-merge time from the events log; the forecast
+respects blocking dependencies and scheduler capacity, and the beads on
+the critical path (the longest dependent chain) are flagged.`,
 	Args: cobra.MaximumNArgs(1),
 	SilenceUsage: true,
 	RunE:         runConvoyStatus,
@@ -422,6 +427,7 @@ func init() {
 	convoyCmd.AddCommand(convoyLandCmd)
 	convoyCmd.AddCommand(convoyStageCmd)
 	convoyCmd.AddCommand(convoyLaunchCmd)
+	convoyCmd.AddCommand(convoyConflictsCmd)
 
 	rootCmd.AddCommand(convoyCmd)
 }
@@ -1833,21 +1839,34 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Spend delivering the tracked work, including MR rework and descendants
+	cost, reworkCost := convoyTrackedCost(tracked)
+
+	// Forecast the ETA of open convoys from merge history and the critical path.
+	// Best-effort: a failed forecast just omits the ETA.
+	var forecast *convoyops.Forecast
+	if normalizeConvoyStatus(convoy.Status) == convoyStatusOpen && completed < len(tracked) {
+		forecast, _ = forecastConvoy(townBeads, convoyID)
+	}
+
 	if convoyStatusJSON {
 		lifecycle := "system-managed"
 		if isOwned {
 			lifecycle = "caller-managed"
 		}
 		type jsonStatus struct {
-			ID            string             `json:"id"`
-			Title         string             `json:"title"`
-			Status        string             `json:"status"`
-			Owned         bool               `json:"owned"`
-			Lifecycle     string             `json:"lifecycle"`
-			MergeStrategy string             `json:"merge_strategy,omitempty"`
-			Tracked       []trackedIssueInfo `json:"tracked"`
-			Completed     int                `json:"completed"`
-			Total         int                `json:"total"`
+			ID            string              `json:"id"`
+			Title         string              `json:"title"`
+			Status        string              `json:"status"`
+			Owned         bool                `json:"owned"`
+			Lifecycle     string              `json:"lifecycle"`
+			MergeStrategy string              `json:"merge_strategy,omitempty"`
+			Tracked       []trackedIssueInfo  `json:"tracked"`
+			Completed     int                 `json:"completed"`
+			Total         int                 `json:"total"`
+			CostUSD       float64             `json:"cost_usd"`
+			ReworkUSD     float64             `json:"rework_usd,omitempty"`
+			Forecast      *convoyops.Forecast `json:"forecast,omitempty"`
 		}
 		out := jsonStatus{
 			ID:            convoy.ID,
@@ -1859,6 +1878,9 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 			Tracked:       tracked,
 			Completed:     completed,
 			Total:         len(tracked),
+			CostUSD:       cost,
+			ReworkUSD:     reworkCost,
+			Forecast:      forecast,
 		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -1879,6 +1901,19 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Merge:     %s\n", merge)
 	}
 	fmt.Printf("  Progress:  %d/%d completed\n", completed, len(tracked))
+	if eta := formatForecast(forecast); eta != "" {
+		fmt.Printf("  ETA:       %s\n", eta)
+		if path := formatCriticalPath(forecast); path != "" {
+			fmt.Printf("  Critical:  %s\n", path)
+		}
+	}
+	if cost > 0 {
+		costLine := fmt.Sprintf("$%.2f", cost)
+		if reworkCost > 0 {
+			costLine += style.Dim.Render(fmt.Sprintf(" ($%.2f rework)", reworkCost))
+		}
+		fmt.Printf("  Cost:      %s\n", costLine)
+	}
 	fmt.Printf("  Created:   %s\n", convoy.CreatedAt)
 	if convoy.ClosedAt != "" {
 		fmt.Printf("  Closed:    %s\n", convoy.ClosedAt)
@@ -1913,6 +1948,10 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 				}
 				line += fmt.Sprintf("  %s", style.Dim.Render(workerDisplay))
 			}
+			if t.CostUSD > 0 {
+				line += fmt.Sprintf("  %s", style.Dim.Render(fmt.Sprintf("$%.2f", t.CostUSD)))
+			}
+			line += criticalMark(forecast, t.ID)
 			fmt.Println(line)
 		}
 	}
@@ -2183,6 +2222,7 @@ type trackedIssueInfo struct {
 	Labels    []string `json:"labels,omitempty"`     // Bead labels (propagated from trackedDependency)
 	Worker    string   `json:"worker,omitempty"`     // Worker currently assigned (e.g., gastown/nux)
 	WorkerAge string   `json:"worker_age,omitempty"` // How long worker has been on this issue
+	CostUSD   float64  `json:"cost_usd,omitempty"`   // Recorded spend delivering this issue (gt convoy status)
 }
 
 // trackedDependency is dep-list data enriched with fresh issue details.
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	convoyops "github.com/steveyegge/gastown/internal/convoy"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Forecast inputs, injectable for tests.
+var (
+	collectConvoyBeadsFn  = collectConvoyBeads
+	loadDurationHistoryFn = convoyops.LoadDurationHistory
+	schedulerCapacityFn   = convoyops.SchedulerCapacity
+)
+
+// forecastConvoy projects when a convoy's open tracked beads will be merged.
+func forecastConvoy(townRoot, convoyID string) (*convoyops.Forecast, error) {
+	beadInfos, deps, err := collectConvoyBeadsFn(convoyID)
+	if err != nil {
+		return nil, err
+	}
+	hist, err := loadDurationHistoryFn(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("reading merge history: %w", err)
+	}
+	return forecastDAG(buildConvoyDAG(beadInfos, deps), hist, schedulerCapacityFn(townRoot)), nil
+}
+
+// forecastDAG forecasts the slingable beads of a DAG. Blocking edges to
+// non-slingable beads (epics, decisions) carry no duration and are ignored.
+func forecastDAG(dag *ConvoyDAG, hist *convoyops.DurationHistory, capacity int) *convoyops.Forecast {
+	var tasks []convoyops.ForecastTask
+	for _, id := range sortedNodeIDs(dag) {
+		node := dag.Nodes[id]
+		if !isSlingableType(node.Type) {
+			continue
+		}
+		tasks = append(tasks, convoyops.ForecastTask{
+			ID:        node.ID,
+			Rig:       node.Rig,
+			Status:    node.Status,
+			BlockedBy: node.BlockedBy,
+		})
+	}
+	return convoyops.ForecastTasks(tasks, hist, capacity, time.Now())
+}
+
+// formatForecast renders the ETA line for status output, e.g.
+// "~3h20m (Tue 17:40) · critical path: gt-a → gt-b".
+func formatForecast(f *convoyops.Forecast) string {
+	if f == nil || f.Done() {
+		return ""
+	}
+	line := fmt.Sprintf("~%s (%s)", convoyops.FormatRemaining(f.Remaining), f.ETA.Local().Format("Mon 15:04"))
+	var notes []string
+	if f.Capacity > 0 {
+		notes = append(notes, fmt.Sprintf("capacity %d", f.Capacity))
+	}
+	if f.Samples == 0 {
+		notes = append(notes, "no merge history")
+	}
+	if len(notes) > 0 {
+		line += " " + style.Dim.Render("["+strings.Join(notes, ", ")+"]")
+	}
+	if len(f.Unschedulable) > 0 {
+		line += " " + style.Warning.Render(fmt.Sprintf("(%d stuck in a dependency cycle)", len(f.Unschedulable)))
+	}
+	return line
+}
+
+// formatCriticalPath renders the critical path as "a → b → c".
+func formatCriticalPath(f *convoyops.Forecast) string {
+	if f == nil || len(f.CriticalPath) == 0 {
+		return ""
+	}
+	return strings.Join(f.CriticalPath, " → ")
+}
+
+// criticalMark returns a suffix flagging beads on the critical path.
+func criticalMark(f *convoyops.Forecast, id string) string {
+	if !f.IsCritical(id) {
+		return ""
+	}
+	return "  " + style.Warning.Render("◆ critical")
+}
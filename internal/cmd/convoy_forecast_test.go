@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	convoyops "github.com/steveyegge/gastown/internal/convoy"
+)
+
+func TestForecastConvoy(t *testing.T) {
+	origCollect, origHist, origCap := collectConvoyBeadsFn, loadDurationHistoryFn, schedulerCapacityFn
+	defer func() {
+		collectConvoyBeadsFn, loadDurationHistoryFn, schedulerCapacityFn = origCollect, origHist, origCap
+	}()
+
+	collectConvoyBeadsFn = func(string) ([]BeadInfo, []DepInfo, error) {
+		return []BeadInfo{
+			{ID: "gt-a", Type: "task", Status: "open", Rig: "gastown"},
+			{ID: "gt-b", Type: "task", Status: "open", Rig: "gastown"},
+			{ID: "gt-c", Type: "task", Status: "open", Rig: "gastown"},
+			{ID: "gt-d", Type: "decision", Status: "open"},
+			{ID: "gt-e", Type: "task", Status: "closed", Rig: "gastown"},
+		}, []DepInfo{
+			{IssueID: "gt-b", DependsOnID: "gt-a", Type: "blocks"},
+			{IssueID: "gt-c", DependsOnID: "gt-d", Type: "blocks"}, // decision: ignored
+		}, nil
+	}
+	loadDurationHistoryFn = func(string) (*convoyops.DurationHistory, error) {
+		return convoyops.ParseDurationHistory(strings.NewReader(""))
+	}
+	schedulerCapacityFn = func(string) int { return 1 }
+
+	f, err := forecastConvoy(t.TempDir(), "hq-cv-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(f.CriticalPath, ",") != "gt-a,gt-b" {
+		t.Errorf("critical path = %v, want [gt-a gt-b]", f.CriticalPath)
+	}
+	// Three open tasks, one polecat, default estimate each.
+	if f.Remaining != 3*convoyops.DefaultTaskDuration {
+		t.Errorf("remaining = %v, want %v", f.Remaining, 3*convoyops.DefaultTaskDuration)
+	}
+	if len(f.Tasks) != 3 {
+		t.Errorf("tasks = %+v, want only open slingable beads", f.Tasks)
+	}
+
+	line := formatForecast(f)
+	if !strings.HasPrefix(line, "~6h (") || !strings.Contains(line, "capacity 1") || !strings.Contains(line, "no merge history") {
+		t.Errorf("formatForecast = %q", line)
+	}
+	if got := formatCriticalPath(f); got != "gt-a → gt-b" {
+		t.Errorf("formatCriticalPath = %q", got)
+	}
+	if criticalMark(f, "gt-c") != "" || criticalMark(f, "gt-a") == "" {
+		t.Error("criticalMark misclassified")
+	}
+}
+
+func TestFormatForecast_Done(t *testing.T) {
+	f := convoyops.ForecastTasks([]convoyops.ForecastTask{{ID: "gt-a", Status: "closed"}}, nil, 0, time.Now())
+	if got := formatForecast(f); got != "" {
+		t.Errorf("formatForecast(done) = %q, want empty", got)
+	}
+	if got := formatForecast(nil); got != "" {
+		t.Errorf("formatForecast(nil) = %q, want empty", got)
+	}
+}
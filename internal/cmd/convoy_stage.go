@@ -52,7 +52,8 @@ type StageResult struct {
 	Errors           []FindingJSON   `json:"errors"`
 	Warnings         []FindingJSON   `json:"warnings"`
 	Waves            []WaveJSON      `json:"waves"`
-	Gated            []GatedTaskJSON `json:"gated,omitempty"` // tasks blocked by open non-slingable nodes
+	Gated            []GatedTaskJSON `json:"gated,omitempty"`          // tasks blocked by open non-slingable nodes
+	FileConflicts    []FileConflict  `json:"file_conflicts,omitempty"` // same-wave tasks predicted to touch the same files
 	Tree             []TreeNodeJSON  `json:"tree"`
 }
 
@@ -211,6 +212,12 @@ Three input forms:
   gt convoy stage <task1> <task2>...  Analyze exactly the given tasks
   gt convoy stage <convoy-id>         Re-analyze an existing convoy's tracked beads
 
+Tasks in the same wave that are predicted to touch the same files (from paths
+in their descriptions, their formula's path hints, and earlier MR diffs) are
+reported as file-conflict warnings. With --serialize-conflicts, the later task
+of each pair is made to wait for the earlier one instead. Use
+'gt convoy conflicts' to check the predictions against the real MR diffs.
+
 The staged convoy can later be launched with 'gt convoy launch'.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runConvoyStage,
@@ -313,7 +320,17 @@ func runConvoyStage(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Step 11a: Append validation bead as final wave (epic input only).
+	// Step 11a: Predict file conflicts between tasks in the same wave.
+	waves, predictions, conflictFindings, err := applyFileConflicts(dag, waves)
+	if err != nil {
+		return err
+	}
+	warns = append(warns, conflictFindings...)
+	if len(conflictFindings) > 0 {
+		status = chooseStatus(errs, warns)
+	}
+
+	// Step 11b: Append validation bead as final wave (epic input only).
 	if input.Kind == StageInputEpic && !convoyStageNoValidate {
 		epicID := input.IDs[0]
 		var validationID string
@@ -327,7 +344,7 @@ func runConvoyStage(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Step 11b: Add gated task warnings and recalculate status.
+	// Step 11c: Add gated task warnings and recalculate status.
 	for _, g := range gated {
 		warns = append(warns, StagingFinding{
 			Severity:     "warning",
@@ -355,6 +372,9 @@ func runConvoyStage(cmd *cobra.Command, args []string) error {
 		fmt.Print(gatedOutput)
 	}
 
+	// Step 13c: Report conflicts serialized by --serialize-conflicts.
+	fmt.Print(renderSerializedConflicts(predictions.Conflicts))
+
 	// Step 14: If warnings, render and print.
 	if len(warns) > 0 {
 		warnOutput := renderWarnings(warns)
@@ -383,6 +403,11 @@ func runConvoyStage(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Convoy created: %s (status: %s)\n", convoyID, status)
 	}
 
+	// Step 15b: Record file predictions for 'gt convoy conflicts'.
+	if err := saveConflictPredictions(convoyID, predictions); err != nil {
+		fmt.Printf("  Warning: could not save file predictions: %v\n", err)
+	}
+
 	// Step 16: If --launch flag is set, transition to open immediately.
 	if convoyStageLaunch {
 		if err := transitionConvoyToOpen(convoyID, convoyLaunchForce); err != nil {
@@ -423,6 +448,18 @@ func runConvoyStageJSON(dag *ConvoyDAG, input *StageInput, errs, warns []Staging
 		return err
 	}
 
+	// Predict file conflicts between tasks in the same wave.
+	waves, predictions, conflictFindings, err := applyFileConflicts(dag, waves)
+	if err != nil {
+		return err
+	}
+	warns = append(warns, conflictFindings...)
+	if len(conflictFindings) > 0 {
+		status = chooseStatus(errs, warns)
+		result.Warnings = buildFindingsJSON(warns)
+	}
+	result.FileConflicts = predictions.Conflicts
+
 	// Append validation bead as final wave (epic input only).
 	var validationBeadID string
 	if input.Kind == StageInputEpic && !convoyStageNoValidate {
@@ -469,6 +506,9 @@ func runConvoyStageJSON(dag *ConvoyDAG, input *StageInput, errs, warns []Staging
 		}
 		result.ConvoyID = convoyID
 	}
+	if err := saveConflictPredictions(result.ConvoyID, predictions); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save file predictions: %v\n", err)
+	}
 
 	out, err := renderJSON(result)
 	if err != nil {
@@ -833,15 +873,16 @@ type ConvoyDAG struct {
 
 // ConvoyDAGNode represents a single bead in the DAG.
 type ConvoyDAGNode struct {
-	ID        string
-	Title     string
-	Type      string // "epic", "task", "bug", etc.
-	Status    string
-	Rig       string
-	BlockedBy []string // IDs of beads that block this one (execution edges)
-	Blocks    []string // IDs of beads this one blocks
-	Children  []string // parent-child children (hierarchy only, not execution)
-	Parent    string   // parent-child parent
+	ID          string
+	Title       string
+	Type        string // "epic", "task", "bug", etc.
+	Status      string
+	Rig         string
+	Description string   // bead description, used to predict touched files
+	BlockedBy   []string // IDs of beads that block this one (execution edges)
+	Blocks      []string // IDs of beads this one blocks
+	Children    []string // parent-child children (hierarchy only, not execution)
+	Parent      string   // parent-child parent
 }
 
 // detectCycles checks the DAG for cycles in execution edges (blocks/conditional-blocks/waits-for).
@@ -1153,11 +1194,12 @@ func appendValidationWave(dag *ConvoyDAG, waves []Wave, epicID string) ([]Wave,
 
 // BeadInfo represents raw bead data from bd show output.
 type BeadInfo struct {
-	ID     string
-	Title  string
-	Type   string // "epic", "task", "bug", etc.
-	Status string
-	Rig    string // resolved rig name
+	ID          string
+	Title       string
+	Type        string // "epic", "task", "bug", etc.
+	Status      string
+	Rig         string // resolved rig name
+	Description string
 }
 
 // DepInfo represents a raw dependency from bd dep list output.
@@ -1178,11 +1220,12 @@ func buildConvoyDAG(beads []BeadInfo, deps []DepInfo) *ConvoyDAG {
 	// Create nodes from beads.
 	for _, b := range beads {
 		dag.Nodes[b.ID] = &ConvoyDAGNode{
-			ID:     b.ID,
-			Title:  b.Title,
-			Type:   b.Type,
-			Status: b.Status,
-			Rig:    b.Rig,
+			ID:          b.ID,
+			Title:       b.Title,
+			Type:        b.Type,
+			Status:      b.Status,
+			Rig:         b.Rig,
+			Description: b.Description,
 		}
 	}
 
@@ -1214,7 +1257,7 @@ func buildConvoyDAG(beads []BeadInfo, deps []DepInfo) *ConvoyDAG {
 // StagingFinding represents an error or warning found during convoy staging analysis.
 type StagingFinding struct {
 	Severity     string   // "error" or "warning"
-	Category     string   // "cycle", "no-rig", "orphan", "blocked-rig", "cross-rig", "capacity", "missing-branch"
+	Category     string   // "cycle", "no-rig", "orphan", "blocked-rig", "cross-rig", "capacity", "missing-branch", "file-conflict"
 	BeadIDs      []string // affected bead IDs
 	Message      string   // human-readable description
 	SuggestedFix string   // actionable fix suggestion
@@ -1426,10 +1469,11 @@ func buildGatedJSON(gated []GatedTask, dag *ConvoyDAG) []GatedTaskJSON {
 
 // bdShowResult matches the JSON output of `bd show <id> --json`.
 type bdShowResult struct {
-	ID        string `json:"id"`
-	Title     string `json:"title"`
-	Status    string `json:"status"`
-	IssueType string `json:"issue_type"`
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	IssueType   string `json:"issue_type"`
+	Description string `json:"description"`
 }
 
 // bdDepResult matches the JSON output of `bd dep list <id> --json`.
@@ -1610,11 +1654,12 @@ func collectEpicBeads(epicID string) ([]BeadInfo, []DepInfo, error) {
 
 		// Add bead info.
 		allBeads = append(allBeads, BeadInfo{
-			ID:     current.ID,
-			Title:  current.Title,
-			Type:   current.IssueType,
-			Status: current.Status,
-			Rig:    rigFromBeadID(current.ID),
+			ID:          current.ID,
+			Title:       current.Title,
+			Type:        current.IssueType,
+			Status:      current.Status,
+			Rig:         rigFromBeadID(current.ID),
+			Description: current.Description,
 		})
 
 		// Fetch deps for this bead.
@@ -1658,11 +1703,12 @@ func collectTaskListBeads(taskIDs []string) ([]BeadInfo, []DepInfo, error) {
 		}
 
 		allBeads = append(allBeads, BeadInfo{
-			ID:     result.ID,
-			Title:  result.Title,
-			Type:   result.IssueType,
-			Status: result.Status,
-			Rig:    rigFromBeadID(result.ID),
+			ID:          result.ID,
+			Title:       result.Title,
+			Type:        result.IssueType,
+			Status:      result.Status,
+			Rig:         rigFromBeadID(result.ID),
+			Description: result.Description,
 		})
 
 		// Fetch deps.
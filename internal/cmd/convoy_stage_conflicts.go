@@ -0,0 +1,777 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// convoyStageSerializeConflicts adds blocking deps between same-wave tasks
+// predicted to touch the same files, instead of only warning about them.
+var convoyStageSerializeConflicts bool
+
+// convoyConflictsJSON controls whether gt convoy conflicts outputs JSON.
+var convoyConflictsJSON bool
+
+func init() {
+	convoyStageCmd.Flags().BoolVar(&convoyStageSerializeConflicts, "serialize-conflicts", false, "Add blocking deps between same-wave tasks predicted to touch the same files")
+	convoyConflictsCmd.Flags().BoolVar(&convoyConflictsJSON, "json", false, "Output as JSON")
+}
+
+// ---------------------------------------------------------------------------
+// File footprint prediction
+// ---------------------------------------------------------------------------
+
+// Sources of a predicted path.
+const (
+	predictSourceDescription = "description" // path mentioned in the bead title or description
+	predictSourceFormula     = "formula"     // path hint from the bead's formula
+	predictSourceMRDiff      = "mr-diff"     // file changed by an earlier MR for the bead
+)
+
+// PredictedPath is a file a task is expected to touch. Paths ending in "/"
+// are directories and paths ending in "*" are prefixes (from globs); a path
+// without a "/" is a bare file name that matches in any directory.
+type PredictedPath struct {
+	Path   string `json:"path"`
+	Source string `json:"source"`
+}
+
+// FilePrediction is the predicted file footprint of one task.
+type FilePrediction struct {
+	BeadID string          `json:"bead_id"`
+	Rig    string          `json:"rig"`
+	Wave   int             `json:"wave,omitempty"`
+	Paths  []PredictedPath `json:"paths"`
+}
+
+func (p *FilePrediction) add(pathHint, source string) {
+	for _, existing := range p.Paths {
+		if existing.Path == pathHint {
+			return
+		}
+	}
+	p.Paths = append(p.Paths, PredictedPath{Path: pathHint, Source: source})
+}
+
+// FileConflict is a pair of tasks in the same wave and rig whose predicted
+// footprints overlap. When serialized, Second is made to wait for First.
+type FileConflict struct {
+	Wave       int      `json:"wave"`
+	First      string   `json:"first"`
+	Second     string   `json:"second"`
+	Paths      []string `json:"paths"`
+	Serialized bool     `json:"serialized,omitempty"`
+}
+
+// ConflictPredictions is the record gt convoy stage saves for a convoy so
+// gt convoy conflicts can later score it against the real MR diffs.
+type ConflictPredictions struct {
+	ConvoyID    string           `json:"convoy_id"`
+	StagedAt    time.Time        `json:"staged_at"`
+	Predictions []FilePrediction `json:"predictions"`
+	Conflicts   []FileConflict   `json:"conflicts"`
+}
+
+// knownFileExts are extensions that make a bare word like "types.go" count
+// as a file name rather than prose.
+var knownFileExts = map[string]bool{
+	"go": true, "mod": true, "sum": true, "md": true, "txt": true,
+	"toml": true, "yaml": true, "yml": true, "json": true, "jsonl": true,
+	"sh": true, "bash": true, "py": true, "rb": true, "rs": true,
+	"js": true, "jsx": true, "ts": true, "tsx": true, "css": true, "html": true,
+	"java": true, "kt": true, "c": true, "h": true, "cc": true, "cpp": true,
+	"proto": true, "sql": true, "tmpl": true,
+}
+
+// pathStopwords are slash-separated words that are not paths.
+var pathStopwords = map[string]bool{
+	"and/or": true, "n/a": true, "i/o": true, "w/o": true, "on/off": true,
+	"yes/no": true, "true/false": true, "read/write": true,
+	"input/output": true, "client/server": true,
+}
+
+var pathHintRe = regexp.MustCompile(`^[A-Za-z0-9_.\-/*]*[A-Za-z][A-Za-z0-9_.\-/*]*$`)
+
+// formulaRefRe matches the formula a bead is (or will be) worked with.
+var formulaRefRe = regexp.MustCompile(`(?m)^\s*(?:attached_)?formula:\s*(\S+)`)
+
+// normalizePathHint turns a token into a path hint, or returns "" if the
+// token doesn't look like a repo-relative path.
+func normalizePathHint(tok string) string {
+	tok = strings.TrimPrefix(tok, "./")
+	if tok == "" || strings.Contains(tok, "://") || strings.HasPrefix(tok, "/") || strings.Contains(tok, "..") {
+		return ""
+	}
+	// Drop line references (file.go:42).
+	if i := strings.IndexByte(tok, ':'); i >= 0 {
+		tok = tok[:i]
+	}
+	if !pathHintRe.MatchString(tok) || pathStopwords[strings.ToLower(tok)] {
+		return ""
+	}
+	if i := strings.IndexByte(tok, '*'); i >= 0 {
+		if i == 0 {
+			return ""
+		}
+		tok = tok[:i] + "*"
+	}
+	if !strings.Contains(tok, "/") {
+		if !knownFileExts[strings.TrimPrefix(path.Ext(tok), ".")] {
+			return ""
+		}
+	}
+	return tok
+}
+
+// extractPathHints returns the paths mentioned in free text, in order of
+// first mention.
+func extractPathHints(text string) []string {
+	seen := make(map[string]bool)
+	var hints []string
+	for _, field := range strings.Fields(text) {
+		tok := strings.Trim(field, "`'\"()[]{}<>,;!?")
+		tok = strings.TrimRight(tok, ".:")
+		if h := normalizePathHint(tok); h != "" && !seen[h] {
+			seen[h] = true
+			hints = append(hints, h)
+		}
+	}
+	return hints
+}
+
+// hintPrefix returns the prefix a directory or glob hint covers.
+func hintPrefix(hint string) (string, bool) {
+	if strings.HasSuffix(hint, "/") {
+		return hint, true
+	}
+	if strings.HasSuffix(hint, "*") {
+		return strings.TrimSuffix(hint, "*"), true
+	}
+	return "", false
+}
+
+// pathHintMatches reports whether a hint covers a concrete file path.
+func pathHintMatches(hint, file string) bool {
+	if prefix, ok := hintPrefix(hint); ok {
+		return strings.HasPrefix(file, prefix)
+	}
+	if hint == file {
+		return true
+	}
+	if !strings.Contains(hint, "/") {
+		return path.Base(file) == hint
+	}
+	// A directory mentioned without its trailing slash.
+	return path.Ext(hint) == "" && strings.HasPrefix(file, hint+"/")
+}
+
+// pathHintsOverlap reports whether two hints could refer to the same file.
+func pathHintsOverlap(a, b string) bool {
+	pa, aPrefix := hintPrefix(a)
+	pb, bPrefix := hintPrefix(b)
+	switch {
+	case aPrefix && bPrefix:
+		return strings.HasPrefix(pa, pb) || strings.HasPrefix(pb, pa)
+	case aPrefix:
+		return pathHintMatches(a, b)
+	case bPrefix:
+		return pathHintMatches(b, a)
+	}
+	return pathHintMatches(a, b) || pathHintMatches(b, a)
+}
+
+// predictTaskFilesFn predicts the footprint of every slingable task in the
+// DAG, keyed by bead ID. Replaceable for tests.
+var predictTaskFilesFn = predictTaskFiles
+
+// predictTaskFiles combines three sources: paths mentioned in the bead
+// itself, path hints declared by the bead's formula, and the files changed
+// by earlier MRs for the bead (re-staged or retried work).
+func predictTaskFiles(dag *ConvoyDAG) map[string]*FilePrediction {
+	preds := make(map[string]*FilePrediction)
+	formulaHints := make(map[string][]string)
+	byRig := make(map[string][]string)
+
+	for _, id := range dagSlingableIDs(dag) {
+		node := dag.Nodes[id]
+		p := &FilePrediction{BeadID: id, Rig: node.Rig}
+		preds[id] = p
+
+		for _, h := range extractPathHints(node.Title + "\n" + node.Description) {
+			p.add(h, predictSourceDescription)
+		}
+		if m := formulaRefRe.FindStringSubmatch(node.Description); m != nil {
+			hints, ok := formulaHints[m[1]]
+			if !ok {
+				hints = formulaPathHintsFn(m[1])
+				formulaHints[m[1]] = hints
+			}
+			for _, h := range hints {
+				p.add(h, predictSourceFormula)
+			}
+		}
+		if node.Rig != "" {
+			byRig[node.Rig] = append(byRig[node.Rig], id)
+		}
+	}
+
+	for rigName, ids := range byRig {
+		for id, files := range mrChangedFilesFn(rigName, ids) {
+			for _, f := range files {
+				preds[id].add(f, predictSourceMRDiff)
+			}
+		}
+	}
+	return preds
+}
+
+// formulaPathHintsFn returns the path hints declared by a formula, or nil
+// if the formula can't be found. Replaceable for tests.
+var formulaPathHintsFn = func(name string) []string {
+	formulaPath, err := findFormulaFile(name)
+	if err != nil {
+		return nil
+	}
+	f, err := parseFormulaFile(formulaPath)
+	if err != nil {
+		return nil
+	}
+	var hints []string
+	for _, p := range f.Paths {
+		if h := normalizePathHint(p); h != "" {
+			hints = append(hints, h)
+		}
+	}
+	return hints
+}
+
+// mrChangedFilesFn returns, for each bead that has one, the files changed by
+// its most relevant MR in the rig. Best effort: lookup failures yield no
+// files. Replaceable for tests.
+var mrChangedFilesFn = mrChangedFiles
+
+func mrChangedFiles(rigName string, beadIDs []string) map[string][]string {
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return nil
+	}
+	mrs, err := beads.New(r.BeadsPath()).ListMergeRequests(beads.ListOptions{
+		Label:    "gt:merge-request",
+		Status:   "all",
+		Priority: -1,
+	})
+	if err != nil || len(mrs) == 0 {
+		return nil
+	}
+	g, err := getRigGit(r.Path)
+	if err != nil {
+		return nil
+	}
+
+	out := make(map[string][]string)
+	for _, id := range beadIDs {
+		for _, mr := range mrs {
+			if !beads.MatchesMRSourceIssue(mr.Description, id) {
+				continue
+			}
+			if files := mrDiffFiles(g, beads.ParseMRFields(mr), r.DefaultBranch()); len(files) > 0 {
+				out[id] = files
+				break
+			}
+		}
+	}
+	return out
+}
+
+// mrDiffFiles returns the files an MR changed: its merge commit's diff once
+// merged, otherwise its branch's diff against the target.
+func mrDiffFiles(g *git.Git, fields *beads.MRFields, defaultBranch string) []string {
+	if fields == nil {
+		return nil
+	}
+	if fields.MergeCommit != "" {
+		if files, err := g.ChangedFiles(fields.MergeCommit+"^", fields.MergeCommit); err == nil {
+			return files
+		}
+	}
+	if fields.Branch == "" {
+		return nil
+	}
+	target := fields.Target
+	if target == "" {
+		target = defaultBranch
+	}
+	if files, err := g.ChangedFiles(target, fields.Branch); err == nil {
+		return files
+	}
+	if files, err := g.ChangedFiles("origin/"+target, "origin/"+fields.Branch); err == nil {
+		return files
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Conflict detection and serialization
+// ---------------------------------------------------------------------------
+
+// overlappingPaths returns the paths on which two predictions overlap,
+// preferring the more specific path of each overlapping pair.
+func overlappingPaths(a, b *FilePrediction) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, pa := range a.Paths {
+		for _, pb := range b.Paths {
+			if !pathHintsOverlap(pa.Path, pb.Path) {
+				continue
+			}
+			p := pa.Path
+			if len(pb.Path) > len(p) {
+				p = pb.Path
+			}
+			if !seen[p] {
+				seen[p] = true
+				paths = append(paths, p)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// detectFileConflicts returns every pair of tasks in the same wave and rig
+// whose predicted footprints overlap. Tasks in different rigs work in
+// different repos and can't conflict.
+func detectFileConflicts(waves []Wave, preds map[string]*FilePrediction) []FileConflict {
+	var conflicts []FileConflict
+	for _, w := range waves {
+		for i, a := range w.Tasks {
+			for _, b := range w.Tasks[i+1:] {
+				pa, pb := preds[a], preds[b]
+				if pa == nil || pb == nil || pa.Rig == "" || pa.Rig != pb.Rig {
+					continue
+				}
+				if paths := overlappingPaths(pa, pb); len(paths) > 0 {
+					conflicts = append(conflicts, FileConflict{Wave: w.Number, First: a, Second: b, Paths: paths})
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// addConflictDepFn records that blocked must wait for blocker.
+// Replaceable for tests.
+var addConflictDepFn = func(blocked, blocker string) error {
+	out, err := BdCmd("dep", "add", blocked, blocker, "--type=blocks").
+		Dir(resolveBeadDir(blocked)).WithAutoCommit().StripBeadsDir().
+		CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("bd dep add %s %s: %w\noutput: %s", blocked, blocker, err, out)
+	}
+	return nil
+}
+
+// serializeFileConflicts makes the second task of each conflicting pair
+// wait for the first, then recomputes waves. A task pushed into a later
+// wave may collide there, so this repeats until no conflicts remain.
+//
+// New edges always run from the lower to the higher bead ID within a wave,
+// and existing edges run from earlier to later waves, so no cycle can form.
+// Returns the final waves and every pair that was serialized.
+func serializeFileConflicts(dag *ConvoyDAG, preds map[string]*FilePrediction) ([]Wave, []FileConflict, error) {
+	var serialized []FileConflict
+	for {
+		waves, _, err := computeWaves(dag)
+		if err != nil {
+			return nil, serialized, err
+		}
+		conflicts := detectFileConflicts(waves, preds)
+		if len(conflicts) == 0 {
+			return waves, serialized, nil
+		}
+
+		// A task that is already moving to a later wave needs no second
+		// edge this round; it is re-checked against its new wave.
+		moved := make(map[string]bool)
+		for _, c := range conflicts {
+			if moved[c.Second] {
+				continue
+			}
+			if err := addConflictDepFn(c.Second, c.First); err != nil {
+				return nil, serialized, err
+			}
+			dag.Nodes[c.First].Blocks = append(dag.Nodes[c.First].Blocks, c.Second)
+			dag.Nodes[c.Second].BlockedBy = append(dag.Nodes[c.Second].BlockedBy, c.First)
+			moved[c.Second] = true
+			c.Serialized = true
+			serialized = append(serialized, c)
+		}
+	}
+}
+
+// fileConflictFinding reports an unserialized conflict as a staging warning.
+func fileConflictFinding(c FileConflict) StagingFinding {
+	return StagingFinding{
+		Severity: "warning",
+		Category: "file-conflict",
+		BeadIDs:  []string{c.First, c.Second},
+		Message: fmt.Sprintf("wave %d: %s and %s are predicted to touch the same files: %s",
+			c.Wave, c.First, c.Second, strings.Join(c.Paths, ", ")),
+		SuggestedFix: fmt.Sprintf("bd dep add %s %s, or re-stage with --serialize-conflicts", c.Second, c.First),
+	}
+}
+
+// applyFileConflicts predicts each task's footprint and checks the waves for
+// tasks likely to conflict in the merge queue. With --serialize-conflicts the
+// conflicting tasks are chained and the recomputed waves are returned;
+// otherwise each conflict becomes a warning.
+func applyFileConflicts(dag *ConvoyDAG, waves []Wave) ([]Wave, *ConflictPredictions, []StagingFinding, error) {
+	preds := predictTaskFilesFn(dag)
+	record := &ConflictPredictions{StagedAt: time.Now().UTC()}
+
+	var findings []StagingFinding
+	if convoyStageSerializeConflicts {
+		var err error
+		waves, record.Conflicts, err = serializeFileConflicts(dag, preds)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("serializing file conflicts: %w", err)
+		}
+	} else {
+		record.Conflicts = detectFileConflicts(waves, preds)
+		for _, c := range record.Conflicts {
+			findings = append(findings, fileConflictFinding(c))
+		}
+	}
+
+	waveOf := make(map[string]int)
+	for _, w := range waves {
+		for _, id := range w.Tasks {
+			waveOf[id] = w.Number
+		}
+	}
+	ids := make([]string, 0, len(preds))
+	for id := range preds {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		p := *preds[id]
+		p.Wave = waveOf[id]
+		record.Predictions = append(record.Predictions, p)
+	}
+	return waves, record, findings, nil
+}
+
+// renderSerializedConflicts formats the deps added by --serialize-conflicts.
+func renderSerializedConflicts(conflicts []FileConflict) string {
+	if len(conflicts) == 0 {
+		return ""
+	}
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("Serialized %d predicted file conflict(s):\n", len(conflicts)))
+	for _, c := range conflicts {
+		buf.WriteString(fmt.Sprintf("  %s now waits for %s (%s)\n", c.Second, c.First, strings.Join(c.Paths, ", ")))
+	}
+	return buf.String()
+}
+
+// conflictPredictionsPath returns where a convoy's predictions are stored.
+func conflictPredictionsPath(townRoot, convoyID string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "convoy", "predictions", convoyID+".json")
+}
+
+// saveConflictPredictions stores a convoy's predictions for gt convoy conflicts.
+func saveConflictPredictions(convoyID string, record *ConflictPredictions) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	record.ConvoyID = convoyID
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	p := conflictPredictionsPath(townRoot, convoyID)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0644)
+}
+
+// loadConflictPredictions reads a convoy's stored predictions.
+func loadConflictPredictions(townRoot, convoyID string) (*ConflictPredictions, error) {
+	data, err := os.ReadFile(conflictPredictionsPath(townRoot, convoyID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no file predictions recorded for %s (stage it with 'gt convoy stage' first)", convoyID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record ConflictPredictions
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("parsing predictions for %s: %w", convoyID, err)
+	}
+	return &record, nil
+}
+
+// ---------------------------------------------------------------------------
+// gt convoy conflicts: prediction accuracy
+// ---------------------------------------------------------------------------
+
+var convoyConflictsCmd = &cobra.Command{
+	Use:   "conflicts <convoy-id>",
+	Short: "Compare predicted file conflicts with the files tasks actually changed",
+	Long: `Score the file predictions made when a convoy was staged against the
+files each task's MR actually changed.
+
+For every task with an MR, shows precision (how many predicted paths were
+touched) and recall (how many changed files were predicted). For task pairs,
+shows which predicted conflicts really overlapped and which real overlaps
+between tasks in the same wave were missed.
+
+Tasks without an MR yet are listed as pending.
+
+Examples:
+  gt convoy conflicts hq-cv-abc
+  gt convoy conflicts hq-cv-abc --json`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runConvoyConflicts,
+}
+
+// TaskPredictionAccuracy scores one task's predicted footprint.
+type TaskPredictionAccuracy struct {
+	BeadID    string   `json:"bead_id"`
+	Predicted []string `json:"predicted"`
+	Actual    []string `json:"actual"`
+	Hits      int      `json:"hits"`    // predicted paths that matched a changed file
+	Covered   int      `json:"covered"` // changed files matched by a predicted path
+	Precision float64  `json:"precision"`
+	Recall    float64  `json:"recall"`
+}
+
+// ConflictAccuracy compares a predicted or actual conflict between two tasks.
+type ConflictAccuracy struct {
+	First     string   `json:"first"`
+	Second    string   `json:"second"`
+	Predicted bool     `json:"predicted"`
+	Actual    bool     `json:"actual"`
+	Files     []string `json:"files,omitempty"` // files both tasks changed
+}
+
+// PredictionAccuracy is the output of gt convoy conflicts.
+type PredictionAccuracy struct {
+	ConvoyID          string                   `json:"convoy_id"`
+	Tasks             []TaskPredictionAccuracy `json:"tasks"`
+	Pending           []string                 `json:"pending,omitempty"`
+	Conflicts         []ConflictAccuracy       `json:"conflicts"`
+	Precision         float64                  `json:"precision"`
+	Recall            float64                  `json:"recall"`
+	ConflictPrecision float64                  `json:"conflict_precision"`
+	ConflictRecall    float64                  `json:"conflict_recall"`
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// scorePredictions compares stored predictions with the files each task
+// actually changed. Tasks without actual files are pending and excluded
+// from the scores. Precision and recall are totals over all scored tasks.
+func scorePredictions(record *ConflictPredictions, actual map[string][]string) *PredictionAccuracy {
+	report := &PredictionAccuracy{ConvoyID: record.ConvoyID}
+
+	var hits, predicted, covered, changed int
+	preds := make(map[string]FilePrediction, len(record.Predictions))
+	for _, p := range record.Predictions {
+		preds[p.BeadID] = p
+		files := actual[p.BeadID]
+		if len(files) == 0 {
+			report.Pending = append(report.Pending, p.BeadID)
+			continue
+		}
+
+		t := TaskPredictionAccuracy{BeadID: p.BeadID, Actual: files}
+		for _, pp := range p.Paths {
+			t.Predicted = append(t.Predicted, pp.Path)
+			for _, f := range files {
+				if pathHintMatches(pp.Path, f) {
+					t.Hits++
+					break
+				}
+			}
+		}
+		for _, f := range files {
+			for _, pp := range p.Paths {
+				if pathHintMatches(pp.Path, f) {
+					t.Covered++
+					break
+				}
+			}
+		}
+		t.Precision = ratio(t.Hits, len(t.Predicted))
+		t.Recall = ratio(t.Covered, len(files))
+		report.Tasks = append(report.Tasks, t)
+
+		hits += t.Hits
+		predicted += len(t.Predicted)
+		covered += t.Covered
+		changed += len(files)
+	}
+	report.Precision = ratio(hits, predicted)
+	report.Recall = ratio(covered, changed)
+
+	// Candidate pairs: every predicted conflict (serialized ones have since
+	// moved apart) plus every same-wave, same-rig pair that ran together.
+	type pair struct{ a, b string }
+	candidates := make(map[pair]bool) // value: predicted
+	for _, c := range record.Conflicts {
+		candidates[pair{c.First, c.Second}] = true
+	}
+	for i, a := range record.Predictions {
+		for _, b := range record.Predictions[i+1:] {
+			if a.Wave != 0 && a.Wave == b.Wave && a.Rig != "" && a.Rig == b.Rig {
+				if _, ok := candidates[pair{a.BeadID, b.BeadID}]; !ok {
+					candidates[pair{a.BeadID, b.BeadID}] = false
+				}
+			}
+		}
+	}
+
+	var confirmed, predictedPairs, actualPairs int
+	for pr, wasPredicted := range candidates {
+		fa, fb := actual[pr.a], actual[pr.b]
+		if len(fa) == 0 || len(fb) == 0 {
+			continue
+		}
+		c := ConflictAccuracy{First: pr.a, Second: pr.b, Predicted: wasPredicted, Files: sharedFiles(fa, fb)}
+		c.Actual = len(c.Files) > 0
+		if !c.Predicted && !c.Actual {
+			continue
+		}
+		if c.Predicted {
+			predictedPairs++
+		}
+		if c.Actual {
+			actualPairs++
+		}
+		if c.Predicted && c.Actual {
+			confirmed++
+		}
+		report.Conflicts = append(report.Conflicts, c)
+	}
+	sort.Slice(report.Conflicts, func(i, j int) bool {
+		if report.Conflicts[i].First != report.Conflicts[j].First {
+			return report.Conflicts[i].First < report.Conflicts[j].First
+		}
+		return report.Conflicts[i].Second < report.Conflicts[j].Second
+	})
+	report.ConflictPrecision = ratio(confirmed, predictedPairs)
+	report.ConflictRecall = ratio(confirmed, actualPairs)
+	return report
+}
+
+func sharedFiles(a, b []string) []string {
+	inA := make(map[string]bool, len(a))
+	for _, f := range a {
+		inA[f] = true
+	}
+	var shared []string
+	for _, f := range b {
+		if inA[f] {
+			shared = append(shared, f)
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
+
+func runConvoyConflicts(cmd *cobra.Command, args []string) error {
+	convoyID := args[0]
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	record, err := loadConflictPredictions(townRoot, convoyID)
+	if err != nil {
+		return err
+	}
+
+	byRig := make(map[string][]string)
+	for _, p := range record.Predictions {
+		if p.Rig != "" {
+			byRig[p.Rig] = append(byRig[p.Rig], p.BeadID)
+		}
+	}
+	actual := make(map[string][]string)
+	for rigName, ids := range byRig {
+		for id, files := range mrChangedFilesFn(rigName, ids) {
+			actual[id] = files
+		}
+	}
+
+	report := scorePredictions(record, actual)
+	if convoyConflictsJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	printPredictionAccuracy(report)
+	return nil
+}
+
+func printPredictionAccuracy(report *PredictionAccuracy) {
+	fmt.Printf("%s\n\n", style.Bold.Render("File prediction accuracy for "+report.ConvoyID))
+	if len(report.Tasks) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("No tasks have MRs yet."))
+		return
+	}
+
+	fmt.Printf("  %-16s %9s %7s %10s %7s\n", "Task", "Predicted", "Actual", "Precision", "Recall")
+	for _, t := range report.Tasks {
+		fmt.Printf("  %-16s %9d %7d %9.0f%% %6.0f%%\n", t.BeadID, len(t.Predicted), len(t.Actual), t.Precision*100, t.Recall*100)
+	}
+	fmt.Printf("\n  Overall: precision %.0f%%, recall %.0f%% (%d task(s) scored", report.Precision*100, report.Recall*100, len(report.Tasks))
+	if len(report.Pending) > 0 {
+		fmt.Printf(", %d pending", len(report.Pending))
+	}
+	fmt.Println(")")
+
+	if len(report.Conflicts) == 0 {
+		fmt.Printf("\n  %s\n", style.Dim.Render("No predicted or actual file conflicts."))
+		return
+	}
+	fmt.Printf("\n%s\n", style.Bold.Render("Conflicts:"))
+	for _, c := range report.Conflicts {
+		switch {
+		case c.Predicted && c.Actual:
+			fmt.Printf("  %s %s ↔ %s: predicted, overlapped on %s\n", style.Success.Render("✓"), c.First, c.Second, strings.Join(c.Files, ", "))
+		case c.Predicted:
+			fmt.Printf("  %s %s ↔ %s: predicted, no overlap\n", style.Dim.Render("○"), c.First, c.Second)
+		default:
+			fmt.Printf("  %s %s ↔ %s: missed, overlapped on %s\n", style.Warning.Render("!"), c.First, c.Second, strings.Join(c.Files, ", "))
+		}
+	}
+	fmt.Printf("\n  Conflict precision %.0f%%, recall %.0f%%\n", report.ConflictPrecision*100, report.ConflictRecall*100)
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractPathHints(t *testing.T) {
+	text := "Fix the race in `internal/refinery/engineer.go:120` and update internal/cmd/,\n" +
+		"see https://example.com/a/b.go. Touches types.go and docs/*.md; and/or README.\n" +
+		"formula: mol-polecat-work"
+	got := extractPathHints(text)
+	want := []string{"internal/refinery/engineer.go", "internal/cmd/", "types.go", "docs/*"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("extractPathHints = %v, want %v", got, want)
+	}
+}
+
+func TestPathHintsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"internal/cmd/convoy.go", "internal/cmd/convoy.go", true},
+		{"internal/cmd/", "internal/cmd/convoy.go", true},
+		{"internal/cmd", "internal/cmd/convoy.go", true},
+		{"internal/cmd/", "internal/cmdline/", false},
+		{"internal/", "internal/cmd/", true},
+		{"docs/*", "docs/guide.md", true},
+		{"convoy.go", "internal/cmd/convoy.go", true},
+		{"internal/cmd/convoy.go", "internal/cmd/sling.go", false},
+		{"internal/a/types.go", "internal/b/types.go", false},
+	}
+	for _, tt := range tests {
+		if got := pathHintsOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("pathHintsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := pathHintsOverlap(tt.b, tt.a); got != tt.want {
+			t.Errorf("pathHintsOverlap(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func prediction(id, rig string, paths ...string) *FilePrediction {
+	p := &FilePrediction{BeadID: id, Rig: rig}
+	for _, path := range paths {
+		p.add(path, predictSourceDescription)
+	}
+	return p
+}
+
+func TestDetectFileConflicts(t *testing.T) {
+	waves := []Wave{
+		{Number: 1, Tasks: []string{"a", "b", "c", "d"}},
+		{Number: 2, Tasks: []string{"e"}},
+	}
+	preds := map[string]*FilePrediction{
+		"a": prediction("a", "gastown", "internal/cmd/convoy.go"),
+		"b": prediction("b", "gastown", "internal/cmd/"),
+		"c": prediction("c", "beads", "internal/cmd/convoy.go"), // other rig
+		"d": prediction("d", "gastown", "internal/git/git.go"),
+		"e": prediction("e", "gastown", "internal/cmd/convoy.go"), // other wave
+	}
+	conflicts := detectFileConflicts(waves, preds)
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %+v, want one", conflicts)
+	}
+	c := conflicts[0]
+	if c.First != "a" || c.Second != "b" || c.Wave != 1 || strings.Join(c.Paths, ",") != "internal/cmd/convoy.go" {
+		t.Errorf("conflict = %+v", c)
+	}
+}
+
+func TestApplyFileConflicts_Warns(t *testing.T) {
+	dag := &ConvoyDAG{Nodes: map[string]*ConvoyDAGNode{
+		"gt-a": {ID: "gt-a", Type: "task", Rig: "gastown", Description: "Edit internal/cmd/convoy.go"},
+		"gt-b": {ID: "gt-b", Type: "task", Rig: "gastown", Description: "formula: mol-cmd"},
+	}}
+	origFormula, origMR := formulaPathHintsFn, mrChangedFilesFn
+	defer func() { formulaPathHintsFn, mrChangedFilesFn = origFormula, origMR }()
+	formulaPathHintsFn = func(name string) []string {
+		if name == "mol-cmd" {
+			return []string{"internal/cmd/"}
+		}
+		return nil
+	}
+	mrChangedFilesFn = func(string, []string) map[string][]string { return nil }
+
+	waves, _, _ := computeWaves(dag)
+	waves, record, findings, err := applyFileConflicts(dag, waves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(waves) != 1 {
+		t.Errorf("warning mode must not change waves: %+v", waves)
+	}
+	if len(findings) != 1 || findings[0].Category != "file-conflict" {
+		t.Fatalf("findings = %+v", findings)
+	}
+	if len(record.Predictions) != 2 || record.Predictions[1].Paths[0].Source != predictSourceFormula {
+		t.Errorf("predictions = %+v", record.Predictions)
+	}
+}
+
+func TestSerializeFileConflicts(t *testing.T) {
+	// Three tasks all touching the same file must end up in three waves.
+	dag := &ConvoyDAG{Nodes: map[string]*ConvoyDAGNode{
+		"a": {ID: "a", Type: "task", Rig: "gastown"},
+		"b": {ID: "b", Type: "task", Rig: "gastown"},
+		"c": {ID: "c", Type: "task", Rig: "gastown"},
+		"d": {ID: "d", Type: "task", Rig: "gastown"},
+	}}
+	preds := map[string]*FilePrediction{
+		"a": prediction("a", "gastown", "go.mod"),
+		"b": prediction("b", "gastown", "go.mod"),
+		"c": prediction("c", "gastown", "go.mod"),
+		"d": prediction("d", "gastown", "README.md"),
+	}
+
+	orig := addConflictDepFn
+	defer func() { addConflictDepFn = orig }()
+	var added []string
+	addConflictDepFn = func(blocked, blocker string) error {
+		added = append(added, blocked+"<-"+blocker)
+		return nil
+	}
+
+	waves, serialized, err := serializeFileConflicts(dag, preds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(waves) != 3 {
+		t.Fatalf("waves = %+v, want 3", waves)
+	}
+	if strings.Join(waves[0].Tasks, ",") != "a,d" {
+		t.Errorf("wave 1 = %v, want [a d]", waves[0].Tasks)
+	}
+	if len(detectFileConflicts(waves, preds)) != 0 {
+		t.Error("conflicts remain after serialization")
+	}
+	if len(serialized) != len(added) || !serialized[0].Serialized {
+		t.Errorf("serialized = %+v, deps added = %v", serialized, added)
+	}
+	if cycle := detectCycles(dag); cycle != nil {
+		t.Errorf("serialization created a cycle: %v", cycle)
+	}
+}
+
+func TestScorePredictions(t *testing.T) {
+	record := &ConflictPredictions{
+		ConvoyID: "hq-cv-test",
+		Predictions: []FilePrediction{
+			{BeadID: "a", Rig: "gastown", Wave: 1, Paths: []PredictedPath{{Path: "internal/cmd/"}, {Path: "docs/x.md"}}},
+			{BeadID: "b", Rig: "gastown", Wave: 2, Paths: []PredictedPath{{Path: "internal/cmd/convoy.go"}}},
+			{BeadID: "c", Rig: "gastown", Wave: 1, Paths: []PredictedPath{{Path: "internal/git/git.go"}}},
+			{BeadID: "d", Rig: "gastown", Wave: 1},
+		},
+		Conflicts: []FileConflict{{Wave: 1, First: "a", Second: "b", Paths: []string{"internal/cmd/convoy.go"}, Serialized: true}},
+	}
+	actual := map[string][]string{
+		"a": {"internal/cmd/convoy.go", "internal/cmd/stage.go"},
+		"b": {"internal/cmd/convoy.go"},
+		"c": {"internal/git/git.go", "internal/cmd/stage.go"},
+	}
+
+	report := scorePredictions(record, actual)
+	if len(report.Tasks) != 3 || strings.Join(report.Pending, ",") != "d" {
+		t.Fatalf("tasks = %+v, pending = %v", report.Tasks, report.Pending)
+	}
+	a := report.Tasks[0]
+	if a.Hits != 1 || a.Covered != 2 || a.Precision != 0.5 || a.Recall != 1 {
+		t.Errorf("task a = %+v", a)
+	}
+	// Hits 1+1+1 of 4 predicted paths; covered 2+1+1 of 5 changed files.
+	if report.Precision != 0.75 || report.Recall != 0.8 {
+		t.Errorf("overall precision/recall = %v/%v", report.Precision, report.Recall)
+	}
+
+	// a↔b predicted and confirmed; a↔c ran together in wave 1 and was missed.
+	if len(report.Conflicts) != 2 {
+		t.Fatalf("conflicts = %+v", report.Conflicts)
+	}
+	if c := report.Conflicts[0]; c.Second != "b" || !c.Predicted || !c.Actual {
+		t.Errorf("a-b = %+v", c)
+	}
+	if c := report.Conflicts[1]; c.Second != "c" || c.Predicted || !c.Actual || c.Files[0] != "internal/cmd/stage.go" {
+		t.Errorf("a-c = %+v", c)
+	}
+	if report.ConflictPrecision != 1 || report.ConflictRecall != 0.5 {
+		t.Errorf("conflict precision/recall = %v/%v", report.ConflictPrecision, report.ConflictRecall)
+	}
+}
@@ -2,10 +2,8 @@
 package cmd
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
-	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -15,6 +13,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/agentlog"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/session"
@@ -28,8 +27,10 @@ var (
 	costsToday   bool
 	costsWeek    bool
 	costsByRole  bool
-	costsByRig   bool
-	costsVerbose bool
+	costsByRig    bool
+	costsByBead   bool
+	costsByConvoy bool
+	costsVerbose  bool
 
 	// Record subcommand flags
 	recordSession  string
@@ -45,12 +46,27 @@ var (
 var costsCmd = &cobra.Command{
 	Use:     "costs",
 	GroupID: GroupDiag,
-	Short:   "Show costs for running Claude sessions",
-	Long: `Display costs for Claude Code sessions in Gas Town.
+	Short:   "Show costs for running agent sessions",
+	Long: `Display costs for agent sessions in Gas Town.
 
-Costs are calculated from Claude Code transcript files in
-$CLAUDE_CONFIG_DIR/projects/ (defaults to ~/.claude/projects/) by summing
-token usage from assistant messages and applying model-specific pricing.
+Costs are calculated from each agent's own session logs, chosen by the
+session's GT_AGENT preset:
+
+  claude    $CLAUDE_CONFIG_DIR/projects/ (defaults to ~/.claude/projects/)
+  codex     $CODEX_HOME/sessions/ (defaults to ~/.codex/sessions/)
+  gemini    ~/.gemini/tmp/<project-hash>/chats/
+  opencode  $XDG_DATA_HOME/opencode/storage/ (defaults to ~/.local/share/)
+
+Token usage is summed per assistant turn and priced per model. Override or
+add model prices (USD per million tokens) in settings/config.json:
+
+  "pricing": {
+    "gpt-5": {"input_per_million": 1.25, "output_per_million": 10,
+              "cache_read_per_million": 0.125}
+  }
+
+Keys match model names exactly or by prefix; "default" prices unknown
+models. OpenCode's own per-message cost is used when it records one.
 
 Examples:
   gt costs              # Live costs from running sessions
@@ -58,12 +74,16 @@ Examples:
   gt costs --week       # This week's costs from digest beads + today's log
   gt costs --by-role    # Breakdown by role (polecat, witness, etc.)
   gt costs --by-rig     # Breakdown by rig
+  gt costs --by-bead    # Spend per bead, rolled up to parent epics (all time)
+  gt costs --by-convoy  # Spend per convoy/mountain (all time)
+  gt costs --by-bead --week  # Attribution limited to the past week
   gt costs --json       # Output as JSON
   gt costs -v           # Show debug output for failures
 
 Subcommands:
   gt costs record       # Record session cost to local log file (Stop hook)
-  gt costs digest       # Aggregate log entries into daily digest bead (Deacon patrol)`,
+  gt costs digest       # Aggregate log entries into daily digest bead (Deacon patrol)
+  gt costs budget       # Budget burn-down and enforcement`,
 	RunE: runCosts,
 }
 
@@ -72,9 +92,9 @@ var costsRecordCmd = &cobra.Command{
 	Short: "Record session cost to local log file (called by Stop hook)",
 	Long: `Record the final cost of a session to a local log file.
 
-This command is intended to be called from a Claude Code Stop hook.
-It reads token usage from the Claude Code transcript file
-($CLAUDE_CONFIG_DIR/projects/... or ~/.claude/projects/...)
+This command is intended to be called from an agent Stop hook.
+It reads token usage from the agent's session log (see 'gt costs --help'
+for locations), selected by GT_AGENT or the session's GT_AGENT,
 and calculates the cost based on model pricing, then appends it to
 ~/.gt/costs.jsonl. This is a simple append operation that never fails
 due to database availability.
@@ -114,6 +134,8 @@ func init() {
 	costsCmd.Flags().BoolVar(&costsWeek, "week", false, "Show this week's total from session events")
 	costsCmd.Flags().BoolVar(&costsByRole, "by-role", false, "Show breakdown by role")
 	costsCmd.Flags().BoolVar(&costsByRig, "by-rig", false, "Show breakdown by rig")
+	costsCmd.Flags().BoolVar(&costsByBead, "by-bead", false, "Show spend per bead, including MR rework and epic rollups")
+	costsCmd.Flags().BoolVar(&costsByConvoy, "by-convoy", false, "Show spend per convoy (and mountain)")
 	costsCmd.Flags().BoolVarP(&costsVerbose, "verbose", "v", false, "Show debug output for failures")
 
 	// Add record subcommand
@@ -135,6 +157,7 @@ type SessionCost struct {
 	Role    string  `json:"role"`
 	Rig     string  `json:"rig,omitempty"`
 	Worker  string  `json:"worker,omitempty"`
+	Agent   string  `json:"agent,omitempty"`
 	Cost    float64 `json:"cost_usd"`
 	Running bool    `json:"running"`
 }
@@ -158,62 +181,22 @@ type CostsOutput struct {
 	ByRole   map[string]float64 `json:"by_role,omitempty"`
 	ByRig    map[string]float64 `json:"by_rig,omitempty"`
 	Period   string             `json:"period,omitempty"`
+
+	// Attribution (--by-bead, --by-convoy)
+	ByBead          []BeadCost   `json:"by_bead,omitempty"`
+	ByConvoy        []ConvoyCost `json:"by_convoy,omitempty"`
+	UnattributedUSD float64      `json:"unattributed_usd,omitempty"`
 }
 
 // costRegex matches cost patterns like "$1.23" or "$12.34"
 var costRegex = regexp.MustCompile(`\$(\d+\.\d{2})`)
 
-// TranscriptMessage represents a message from a Claude Code transcript file.
-type TranscriptMessage struct {
-	Type      string                 `json:"type"`
-	SessionID string                 `json:"sessionId"`
-	CWD       string                 `json:"cwd"`
-	Message   *TranscriptMessageBody `json:"message,omitempty"`
-}
-
-// TranscriptMessageBody contains the message content and usage info.
-type TranscriptMessageBody struct {
-	Model string          `json:"model"`
-	Role  string          `json:"role"`
-	Usage *TranscriptUsage `json:"usage,omitempty"`
-}
-
-// TranscriptUsage contains token usage information.
-type TranscriptUsage struct {
-	InputTokens              int `json:"input_tokens"`
-	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
-	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
-	OutputTokens             int `json:"output_tokens"`
-}
-
-// TokenUsage aggregates token usage across a session.
-type TokenUsage struct {
-	Model                    string
-	InputTokens              int
-	CacheCreationInputTokens int
-	CacheReadInputTokens     int
-	OutputTokens             int
-}
-
-// Model pricing per million tokens (as of Jan 2025).
-// See: https://www.anthropic.com/pricing
-var modelPricing = map[string]struct {
-	InputPerMillion       float64
-	OutputPerMillion      float64
-	CacheReadPerMillion   float64 // 90% discount on input price
-	CacheCreatePerMillion float64 // 25% premium on input price
-}{
-	// Claude Opus 4.5
-	"claude-opus-4-5-20251101": {15.0, 75.0, 1.5, 18.75},
-	// Claude Sonnet 4
-	"claude-sonnet-4-20250514": {3.0, 15.0, 0.3, 3.75},
-	// Claude Haiku 3.5
-	"claude-3-5-haiku-20241022": {1.0, 5.0, 0.1, 1.25},
-	// Fallback for unknown models (use Sonnet pricing)
-	"default": {3.0, 15.0, 0.3, 3.75},
-}
-
 func runCosts(cmd *cobra.Command, args []string) error {
+	// Attribution to beads and convoys
+	if costsByBead || costsByConvoy {
+		return runCostsAttribution()
+	}
+
 	// If querying ledger, use ledger functions
 	if costsToday || costsWeek || costsByRole || costsByRig {
 		return runCostsFromLedger()
@@ -224,12 +207,31 @@ func runCosts(cmd *cobra.Command, args []string) error {
 }
 
 func runLiveCosts() error {
+	costs, total, err := liveSessionCosts()
+	if err != nil {
+		return err
+	}
+
+	if costsJSON {
+		return outputCostsJSON(CostsOutput{
+			Sessions: costs,
+			Total:    total,
+		})
+	}
+
+	return outputCostsHuman(costs, total)
+}
+
+// liveSessionCosts returns the session-log cost of every Gas Town tmux
+// session, sorted by session name, and their total.
+func liveSessionCosts() ([]SessionCost, float64, error) {
 	t := tmux.NewTmux()
+	prices := costsPriceTable()
 
 	// Get all tmux sessions
 	sessions, err := t.ListSessions()
 	if err != nil {
-		return fmt.Errorf("listing sessions: %w", err)
+		return nil, 0, fmt.Errorf("listing sessions: %w", err)
 	}
 
 	var costs []SessionCost
@@ -253,8 +255,10 @@ func runLiveCosts() error {
 			continue
 		}
 
-		// Extract cost from Claude transcript
-		cost, err := extractCostFromWorkDir(workDir)
+		// Extract cost from the agent's session log
+		agentName, _ := t.GetEnvironment(sess, "GT_AGENT")
+		agentType := costsAgentType(strings.TrimSpace(agentName))
+		cost, err := extractCostFromWorkDir(prices, agentType, workDir)
 		if err != nil {
 			if costsVerbose {
 				fmt.Fprintf(os.Stderr, "[costs] could not extract cost for %s: %v\n", sess, err)
@@ -271,6 +275,7 @@ func runLiveCosts() error {
 			Role:    role,
 			Rig:     rig,
 			Worker:  worker,
+			Agent:   agentType,
 			Cost:    cost,
 			Running: running,
 		})
@@ -282,14 +287,7 @@ func runLiveCosts() error {
 		return costs[i].Session < costs[j].Session
 	})
 
-	if costsJSON {
-		return outputCostsJSON(CostsOutput{
-			Sessions: costs,
-			Total:    total,
-		})
-	}
-
-	return outputCostsHuman(costs, total)
+	return costs, total, nil
 }
 
 func runCostsFromLedger() error {
@@ -540,6 +538,35 @@ func querySessionEventsFromLocation(location string) ([]CostEntry, error) {
 
 // queryDigestBeads queries costs.digest events from the past N days and extracts session entries.
 func queryDigestBeads(days int) ([]CostEntry, error) {
+	digests, err := queryCostDigests(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []CostEntry
+	for _, digest := range digests {
+		// If the digest has per-session data (old format), use it directly.
+		// Otherwise, synthesize entries from the aggregate ByRole data.
+		if len(digest.Sessions) > 0 {
+			entries = append(entries, digest.Sessions...)
+		} else {
+			digestDate, _ := time.Parse("2006-01-02", digest.Date)
+			for role, cost := range digest.ByRole {
+				entries = append(entries, CostEntry{
+					SessionID: fmt.Sprintf("digest-%s-%s", digest.Date, role),
+					Role:      role,
+					CostUSD:   cost,
+					EndedAt:   digestDate,
+				})
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// queryCostDigests returns the costs.digest events for dates on or after cutoff.
+func queryCostDigests(cutoff time.Time) ([]CostDigest, error) {
 	// Get list of event IDs
 	listArgs := []string{
 		"list",
@@ -581,11 +608,7 @@ func queryDigestBeads(days int) ([]CostEntry, error) {
 		return nil, fmt.Errorf("parsing event details: %w", err)
 	}
 
-	// Calculate date range
-	now := time.Now()
-	cutoff := now.AddDate(0, 0, -days)
-
-	var entries []CostEntry
+	var digests []CostDigest
 	for _, event := range events {
 		// Filter for costs.digest events only
 		if event.EventKind != "costs.digest" {
@@ -609,23 +632,10 @@ func queryDigestBeads(days int) ([]CostEntry, error) {
 			continue
 		}
 
-		// If the digest has per-session data (old format), use it directly.
-		// Otherwise, synthesize entries from the aggregate ByRole data.
-		if len(digest.Sessions) > 0 {
-			entries = append(entries, digest.Sessions...)
-		} else {
-			for role, cost := range digest.ByRole {
-				entries = append(entries, CostEntry{
-					SessionID: fmt.Sprintf("digest-%s-%s", digest.Date, role),
-					Role:      role,
-					CostUSD:   cost,
-					EndedAt:   digestDate,
-				})
-			}
-		}
+		digests = append(digests, digest)
 	}
 
-	return entries, nil
+	return digests, nil
 }
 
 // parseSessionName extracts role, rig, and worker from a session name.
@@ -675,145 +685,54 @@ func extractCost(content string) float64 {
 	return cost
 }
 
-// getClaudeProjectDir returns the Claude Code project directory for a working directory.
-// Claude Code stores transcripts in <config-dir>/projects/<path-with-dashes-instead-of-slashes>/
-// Respects CLAUDE_CONFIG_DIR env var, falling back to ~/.claude.
-func getClaudeProjectDir(workDir string) (string, error) {
-	configDir, err := config.ClaudeConfigDir()
-	if err != nil {
-		return "", err
+// costsAgentType maps a session's GT_AGENT value to an agentlog adapter type.
+// Custom agents resolve through their preset's command (e.g. a "codex-high"
+// agent running "codex"); anything unrecognized is read as Claude Code.
+func costsAgentType(agentName string) string {
+	if agentName == "" {
+		return "claudecode"
 	}
-
-	// Convert path to Claude's directory naming: replace / with -
-	// Keep leading slash - it becomes a leading dash in Claude's encoding
-	projectName := strings.ReplaceAll(workDir, "/", "-")
-	return filepath.Join(configDir, "projects", projectName), nil
-}
-
-// findLatestTranscript finds the most recently modified .jsonl file in a directory.
-func findLatestTranscript(projectDir string) (string, error) {
-	var latestPath string
-	var latestTime time.Time
-
-	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() && path != projectDir {
-			return fs.SkipDir // Don't recurse into subdirectories
-		}
-		if !d.IsDir() && strings.HasSuffix(path, ".jsonl") {
-			info, err := d.Info()
-			if err != nil {
-				return nil // Skip files we can't stat
-			}
-			if info.ModTime().After(latestTime) {
-				latestTime = info.ModTime()
-				latestPath = path
-			}
-		}
-		return nil
-	})
-
-	if err != nil {
-		return "", err
-	}
-	if latestPath == "" {
-		return "", fmt.Errorf("no transcript files found in %s", projectDir)
+	if agentlog.NewAdapter(agentName) != nil {
+		return agentName
 	}
-	return latestPath, nil
-}
-
-// parseTranscriptUsage reads a transcript file and sums token usage from assistant messages.
-func parseTranscriptUsage(transcriptPath string) (*TokenUsage, error) {
-	file, err := os.Open(transcriptPath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	usage := &TokenUsage{}
-	scanner := bufio.NewScanner(file)
-	// Increase buffer for potentially large JSON lines
-	buf := make([]byte, 0, 256*1024)
-	scanner.Buffer(buf, 1024*1024)
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
+	if info := config.GetAgentPresetByName(agentName); info != nil {
+		if cmdName := filepath.Base(info.Command); agentlog.NewAdapter(cmdName) != nil {
+			return cmdName
 		}
-
-		var msg TranscriptMessage
-		if err := json.Unmarshal(line, &msg); err != nil {
-			continue // Skip malformed lines
-		}
-
-		// Only process assistant messages with usage info
-		if msg.Type != "assistant" || msg.Message == nil || msg.Message.Usage == nil {
-			continue
-		}
-
-		// Capture the model (use first one found, they should all be the same)
-		if usage.Model == "" && msg.Message.Model != "" {
-			usage.Model = msg.Message.Model
-		}
-
-		// Sum token usage
-		u := msg.Message.Usage
-		usage.InputTokens += u.InputTokens
-		usage.CacheCreationInputTokens += u.CacheCreationInputTokens
-		usage.CacheReadInputTokens += u.CacheReadInputTokens
-		usage.OutputTokens += u.OutputTokens
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
 	}
-
-	return usage, nil
+	return "claudecode"
 }
 
-// calculateCost converts token usage to USD cost based on model pricing.
-func calculateCost(usage *TokenUsage) float64 {
-	if usage == nil {
-		return 0.0
+// costsPriceTable returns the built-in model prices with the town's
+// "pricing" overrides applied. Outside a town the built-ins are used.
+func costsPriceTable() agentlog.PriceTable {
+	prices := agentlog.DefaultPriceTable()
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return prices
 	}
-
-	// Look up pricing for the model
-	pricing, ok := modelPricing[usage.Model]
-	if !ok {
-		pricing = modelPricing["default"]
-	}
-
-	// Calculate cost (prices are per million tokens)
-	inputCost := float64(usage.InputTokens) / 1_000_000 * pricing.InputPerMillion
-	cacheReadCost := float64(usage.CacheReadInputTokens) / 1_000_000 * pricing.CacheReadPerMillion
-	cacheCreateCost := float64(usage.CacheCreationInputTokens) / 1_000_000 * pricing.CacheCreatePerMillion
-	outputCost := float64(usage.OutputTokens) / 1_000_000 * pricing.OutputPerMillion
-
-	return inputCost + cacheReadCost + cacheCreateCost + outputCost
-}
-
-// extractCostFromWorkDir extracts cost from Claude Code transcript for a working directory.
-// This reads the most recent transcript file and sums all token usage.
-func extractCostFromWorkDir(workDir string) (float64, error) {
-	projectDir, err := getClaudeProjectDir(workDir)
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
 	if err != nil {
-		return 0, fmt.Errorf("getting project dir: %w", err)
+		if costsVerbose {
+			fmt.Fprintf(os.Stderr, "[costs] could not load pricing overrides: %v\n", err)
+		}
+		return prices
 	}
+	return prices.Merge(settings.Pricing)
+}
 
-	transcriptPath, err := findLatestTranscript(projectDir)
-	if err != nil {
-		return 0, fmt.Errorf("finding transcript: %w", err)
+// extractCostFromWorkDir prices the newest session log the agent wrote for a
+// working directory, read through the agent's agentlog adapter.
+func extractCostFromWorkDir(prices agentlog.PriceTable, agentType, workDir string) (float64, error) {
+	adapter := agentlog.NewAdapter(agentType)
+	if adapter == nil {
+		return 0, fmt.Errorf("unknown agent type %q", agentType)
 	}
-
-	usage, err := parseTranscriptUsage(transcriptPath)
+	events, err := adapter.ReadSession("", workDir, time.Time{})
 	if err != nil {
-		return 0, fmt.Errorf("parsing transcript: %w", err)
+		return 0, fmt.Errorf("reading %s session log: %w", agentType, err)
 	}
-
-	return calculateCost(usage), nil
+	return prices.SessionCost(events), nil
 }
 
 // getTmuxSessionWorkDir gets the current working directory of a tmux session.
@@ -841,9 +760,9 @@ func outputCostsHuman(costs []SessionCost, total float64) error {
 	fmt.Printf("\n%s Live Session Costs\n\n", style.Bold.Render("💰"))
 
 	// Print table header
-	fmt.Printf("%-25s %-10s %-15s %10s %8s\n",
-		"Session", "Role", "Rig/Worker", "Cost", "Status")
-	fmt.Println(strings.Repeat("─", 75))
+	fmt.Printf("%-25s %-10s %-15s %-10s %10s %8s\n",
+		"Session", "Role", "Rig/Worker", "Agent", "Cost", "Status")
+	fmt.Println(strings.Repeat("─", 86))
 
 	// Print each session
 	for _, c := range costs {
@@ -861,16 +780,17 @@ func outputCostsHuman(costs []SessionCost, total float64) error {
 			}
 		}
 
-		fmt.Printf("%-25s %-10s %-15s %10s %8s\n",
+		fmt.Printf("%-25s %-10s %-15s %-10s %10s %8s\n",
 			c.Session,
 			c.Role,
 			rigWorker,
+			c.Agent,
 			fmt.Sprintf("$%.2f", c.Cost),
 			statusIcon)
 	}
 
 	// Print total
-	fmt.Println(strings.Repeat("─", 75))
+	fmt.Println(strings.Repeat("─", 86))
 	fmt.Printf("%s %s\n", style.Bold.Render("Total:"), fmt.Sprintf("$%.2f", total))
 
 	return nil
@@ -916,6 +836,7 @@ type CostLogEntry struct {
 	Role      string    `json:"role"`
 	Rig       string    `json:"rig,omitempty"`
 	Worker    string    `json:"worker,omitempty"`
+	Agent     string    `json:"agent,omitempty"`
 	CostUSD   float64   `json:"cost_usd"`
 	EndedAt   time.Time `json:"ended_at"`
 	WorkItem  string    `json:"work_item,omitempty"`
@@ -966,14 +887,21 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Extract cost from Claude transcript
+	// Resolve the agent from the environment or tmux session
+	agentName := os.Getenv("GT_AGENT")
+	if agentName == "" {
+		agentName, _ = tmux.NewTmux().GetEnvironment(session, "GT_AGENT")
+	}
+	agentType := costsAgentType(strings.TrimSpace(agentName))
+
+	// Extract cost from the agent's session log
 	var cost float64
 	if workDir != "" {
 		var err error
-		cost, err = extractCostFromWorkDir(workDir)
+		cost, err = extractCostFromWorkDir(costsPriceTable(), agentType, workDir)
 		if err != nil {
 			if costsVerbose {
-				fmt.Fprintf(os.Stderr, "[costs] could not extract cost from transcript: %v\n", err)
+				fmt.Fprintf(os.Stderr, "[costs] could not extract cost from session log: %v\n", err)
 			}
 			cost = 0.0
 		}
@@ -982,15 +910,22 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 	// Parse session name
 	role, rig, worker := parseSessionName(session)
 
+	// Attribute the spend to the agent's hooked bead unless told otherwise
+	workItem := recordWorkItem
+	if workItem == "" {
+		workItem = resolveCostWorkItem(session, workDir)
+	}
+
 	// Build log entry
 	entry := CostLogEntry{
 		SessionID: session,
 		Role:      role,
 		Rig:       rig,
 		Worker:    worker,
+		Agent:     agentType,
 		CostUSD:   cost,
 		EndedAt:   time.Now(),
-		WorkItem:  recordWorkItem,
+		WorkItem:  workItem,
 	}
 
 	// Marshal to JSON
@@ -1023,10 +958,10 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output confirmation (silent if cost is zero and no work item)
-	if cost > 0 || recordWorkItem != "" {
+	if cost > 0 || workItem != "" {
 		fmt.Printf("%s Recorded $%.2f for %s", style.Success.Render("✓"), cost, session)
-		if recordWorkItem != "" {
-			fmt.Printf(" (work: %s)", recordWorkItem)
+		if workItem != "" {
+			fmt.Printf(" (work: %s)", workItem)
 		}
 		fmt.Println()
 	}
@@ -1116,6 +1051,7 @@ type CostDigest struct {
 	Sessions     []CostEntry        `json:"sessions,omitempty"`
 	ByRole       map[string]float64 `json:"by_role"`
 	ByRig        map[string]float64 `json:"by_rig,omitempty"`
+	ByWorkItem   map[string]float64 `json:"by_work_item,omitempty"`
 }
 
 // CostDigestPayload is the compact payload stored in the bead.
@@ -1126,6 +1062,7 @@ type CostDigestPayload struct {
 	SessionCount int                `json:"session_count"`
 	ByRole       map[string]float64 `json:"by_role"`
 	ByRig        map[string]float64 `json:"by_rig,omitempty"`
+	ByWorkItem   map[string]float64 `json:"by_work_item,omitempty"`
 }
 
 // runCostsDigest aggregates session cost entries into a daily digest bead.
@@ -1161,9 +1098,10 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 	// Build digest
 	digest := CostDigest{
 		Date:     dateStr,
-		Sessions: costEntries,
-		ByRole:   make(map[string]float64),
-		ByRig:    make(map[string]float64),
+		Sessions:   costEntries,
+		ByRole:     make(map[string]float64),
+		ByRig:      make(map[string]float64),
+		ByWorkItem: make(map[string]float64),
 	}
 
 	for _, e := range costEntries {
@@ -1173,6 +1111,9 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 		if e.Rig != "" {
 			digest.ByRig[e.Rig] += e.CostUSD
 		}
+		if e.WorkItem != "" {
+			digest.ByWorkItem[e.WorkItem] += e.CostUSD
+		}
 	}
 
 	if digestDryRun {
@@ -1215,6 +1156,14 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 
 // querySessionCostEntries reads session cost entries from the local log file for a target date.
 func querySessionCostEntries(targetDate time.Time) ([]CostEntry, error) {
+	targetDay := targetDate.Format("2006-01-02")
+	return readCostLog(func(e CostLogEntry) bool {
+		return e.EndedAt.Format("2006-01-02") == targetDay
+	})
+}
+
+// readCostLog reads the session cost entries in the local log file accepted by keep.
+func readCostLog(keep func(CostLogEntry) bool) ([]CostEntry, error) {
 	logPath := getCostsLogPath()
 
 	// Read log file
@@ -1226,7 +1175,6 @@ func querySessionCostEntries(targetDate time.Time) ([]CostEntry, error) {
 		return nil, fmt.Errorf("reading costs log: %w", err)
 	}
 
-	targetDay := targetDate.Format("2006-01-02")
 	var entries []CostEntry
 
 	// Parse each line as a CostLogEntry
@@ -1245,8 +1193,7 @@ func querySessionCostEntries(targetDate time.Time) ([]CostEntry, error) {
 			continue
 		}
 
-		// Filter by target date
-		if logEntry.EndedAt.Format("2006-01-02") != targetDay {
+		if !keep(logEntry) {
 			continue
 		}
 
@@ -1306,6 +1253,7 @@ func createCostDigestBead(digest CostDigest) (string, error) {
 		SessionCount: digest.SessionCount,
 		ByRole:       digest.ByRole,
 		ByRig:        digest.ByRig,
+		ByWorkItem:   digest.ByWorkItem,
 	}
 	payloadJSON, err := json.Marshal(compactPayload)
 	if err != nil {
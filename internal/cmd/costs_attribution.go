@@ -0,0 +1,543 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// conflictTaskTitlePrefix marks the refinery's conflict-resolution tasks. Their
+// description names the issue the conflicted MR delivers ("- Original issue: X").
+const conflictTaskTitlePrefix = "Resolve merge conflicts:"
+
+// resolveCostWorkItem returns the bead a session's spend is attributed to: the
+// bead hooked to the session's agent, falling back to the hooked bead in the
+// agent's crash-recovery checkpoint (e.g. when the bead database is down).
+func resolveCostWorkItem(sessionName, workDir string) string {
+	if workDir == "" {
+		return ""
+	}
+	if identity, err := session.ParseSessionName(sessionName); err == nil {
+		if addr := identity.Address(); addr != "" {
+			if id := findHookedBeadForAgent(beads.New(workDir), addr); id != "" {
+				return id
+			}
+		}
+	}
+	if cp, err := checkpoint.Read(workDir); err == nil && cp != nil {
+		return cp.HookedBead
+	}
+	return ""
+}
+
+// workItemCosts sums spend per work item on or after since (zero = all
+// history), from digest beads plus the undigested costs log. Spend recorded
+// without a work item is returned as unattributed.
+func workItemCosts(since time.Time) (byWorkItem map[string]float64, unattributed float64, err error) {
+	byWorkItem = make(map[string]float64)
+	add := func(workItem string, cost float64) {
+		if workItem == "" {
+			unattributed += cost
+			return
+		}
+		byWorkItem[workItem] += cost
+	}
+
+	digested := make(map[string]bool)
+	digests, err := queryCostDigests(since)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, d := range digests {
+		if digested[d.Date] {
+			continue
+		}
+		digested[d.Date] = true
+		if len(d.Sessions) > 0 {
+			for _, e := range d.Sessions {
+				add(e.WorkItem, e.CostUSD)
+			}
+			continue
+		}
+		attributed := 0.0
+		for workItem, cost := range d.ByWorkItem {
+			add(workItem, cost)
+			attributed += cost
+		}
+		if rest := d.TotalUSD - attributed; rest > 0.005 {
+			unattributed += rest
+		}
+	}
+
+	entries, err := readCostLog(func(e CostLogEntry) bool {
+		return !e.EndedAt.Before(since) && !digested[e.EndedAt.Format("2006-01-02")]
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, e := range entries {
+		add(e.WorkItem, e.CostUSD)
+	}
+	return byWorkItem, unattributed, nil
+}
+
+// BeadCost is the spend attributed to one bead.
+type BeadCost struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title,omitempty"`
+	IssueType string  `json:"issue_type,omitempty"`
+	DirectUSD float64 `json:"direct_usd"`             // sessions hooked to the bead itself
+	ReworkUSD float64 `json:"rework_usd,omitempty"`   // its MRs and conflict-resolution tasks
+	ChildUSD  float64 `json:"children_usd,omitempty"` // rolled up from descendants (epics)
+	TotalUSD  float64 `json:"total_usd"`
+}
+
+// ConvoyCost is the spend attributed to the work a convoy (or mountain) tracks.
+type ConvoyCost struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title,omitempty"`
+	Status    string  `json:"status,omitempty"`
+	Mountain  bool    `json:"mountain,omitempty"`
+	ReworkUSD float64 `json:"rework_usd,omitempty"`
+	TotalUSD  float64 `json:"total_usd"`
+	WorkItems int     `json:"work_items"`
+}
+
+// costAttribution is how one work item's spend reaches the beads it delivers.
+type costAttribution struct {
+	// Delivery is the bead the work delivers: the work item itself, or the
+	// source issue of an MR or conflict-resolution task.
+	Delivery string
+	// Rework is set when the work item is an MR or conflict-resolution task.
+	Rework bool
+	// Ancestors are Delivery's parents, nearest first (epics).
+	Ancestors []string
+}
+
+// costAttributor resolves work items to the beads, epics and convoys their
+// spend rolls up to. Lookups are cached; show and trackers are replaceable
+// in tests.
+type costAttributor struct {
+	show     func(ids []string) map[string]*beads.Issue
+	trackers func(id string) []string // IDs of beads with a "tracks" dep on id
+
+	issues  map[string]*beads.Issue
+	tracked map[string][]string
+}
+
+func newCostAttributor() *costAttributor {
+	townRoot, _ := workspace.FindFromCwdOrError()
+	return &costAttributor{
+		show: func(ids []string) map[string]*beads.Issue {
+			return showIssuesFromTownRoot(townRoot, ids)
+		},
+		trackers: func(id string) []string {
+			ids, _ := bdDepListRawIDs(filepath.Join(townRoot, ".beads"), id, "up", "tracks")
+			return ids
+		},
+	}
+}
+
+// prefetch loads any of ids not yet cached in one batch.
+func (a *costAttributor) prefetch(ids []string) {
+	if a.issues == nil {
+		a.issues = make(map[string]*beads.Issue)
+	}
+	var missing []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if _, ok := a.issues[id]; !ok && id != "" && !seen[id] {
+			seen[id] = true
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	found := a.show(missing)
+	for _, id := range missing {
+		a.issues[id] = found[id] // nil caches "not found"
+	}
+}
+
+func (a *costAttributor) issue(id string) *beads.Issue {
+	a.prefetch([]string{id})
+	return a.issues[id]
+}
+
+// attribute resolves workItem. Unknown beads attribute to themselves.
+func (a *costAttributor) attribute(workItem string) costAttribution {
+	att := costAttribution{Delivery: workItem}
+	if issue := a.issue(workItem); issue != nil {
+		if source := reworkSourceIssue(issue); source != "" && source != workItem {
+			att.Delivery = source
+			att.Rework = true
+		}
+	}
+
+	seen := map[string]bool{att.Delivery: true}
+	for id := att.Delivery; ; {
+		issue := a.issue(id)
+		if issue == nil {
+			break
+		}
+		parent := issueParent(issue)
+		if parent == "" || seen[parent] {
+			break
+		}
+		seen[parent] = true
+		att.Ancestors = append(att.Ancestors, parent)
+		id = parent
+	}
+	return att
+}
+
+// convoys returns the convoys tracking the attribution's delivery bead or any
+// of its ancestors.
+func (a *costAttributor) convoys(att costAttribution) []string {
+	if a.tracked == nil {
+		a.tracked = make(map[string][]string)
+	}
+	var convoys []string
+	seen := make(map[string]bool)
+	for _, id := range append([]string{att.Delivery}, att.Ancestors...) {
+		trackers, ok := a.tracked[id]
+		if !ok {
+			trackers = a.trackers(id)
+			a.tracked[id] = trackers
+		}
+		a.prefetch(trackers)
+		for _, t := range trackers {
+			if issue := a.issues[t]; issue != nil && issue.Type == "convoy" && !seen[t] {
+				seen[t] = true
+				convoys = append(convoys, t)
+			}
+		}
+	}
+	return convoys
+}
+
+// prefetchWorkItems batch-loads the work items and the beads they deliver.
+func (a *costAttributor) prefetchWorkItems(byWorkItem map[string]float64) {
+	ids := make([]string, 0, len(byWorkItem))
+	for id := range byWorkItem {
+		ids = append(ids, id)
+	}
+	a.prefetch(ids)
+	var sources []string
+	for _, id := range ids {
+		if issue := a.issues[id]; issue != nil {
+			if source := reworkSourceIssue(issue); source != "" {
+				sources = append(sources, source)
+			}
+		}
+	}
+	a.prefetch(sources)
+}
+
+// beadCosts attributes spend to delivered beads and rolls it up to their
+// ancestors. Sorted by total spend, highest first.
+func (a *costAttributor) beadCosts(byWorkItem map[string]float64) []BeadCost {
+	a.prefetchWorkItems(byWorkItem)
+	costs := make(map[string]*BeadCost)
+	get := func(id string) *BeadCost {
+		bc, ok := costs[id]
+		if !ok {
+			bc = &BeadCost{ID: id}
+			if issue := a.issues[id]; issue != nil {
+				bc.Title = issue.Title
+				bc.IssueType = issue.Type
+			}
+			costs[id] = bc
+		}
+		return bc
+	}
+
+	for workItem, cost := range byWorkItem {
+		att := a.attribute(workItem)
+		bc := get(att.Delivery)
+		if att.Rework {
+			bc.ReworkUSD += cost
+		} else {
+			bc.DirectUSD += cost
+		}
+		bc.TotalUSD += cost
+		for _, anc := range att.Ancestors {
+			ac := get(anc)
+			ac.ChildUSD += cost
+			ac.TotalUSD += cost
+		}
+	}
+
+	result := make([]BeadCost, 0, len(costs))
+	for _, bc := range costs {
+		result = append(result, *bc)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalUSD != result[j].TotalUSD {
+			return result[i].TotalUSD > result[j].TotalUSD
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// convoyCosts attributes spend to the convoys tracking the delivered beads or
+// their ancestors. Work tracked by no convoy is not included. Sorted by
+// total spend, highest first.
+func (a *costAttributor) convoyCosts(byWorkItem map[string]float64) []ConvoyCost {
+	a.prefetchWorkItems(byWorkItem)
+	costs := make(map[string]*ConvoyCost)
+	for workItem, cost := range byWorkItem {
+		att := a.attribute(workItem)
+		for _, id := range a.convoys(att) {
+			cc, ok := costs[id]
+			if !ok {
+				cc = &ConvoyCost{ID: id}
+				if issue := a.issues[id]; issue != nil {
+					cc.Title = issue.Title
+					cc.Status = issue.Status
+					cc.Mountain = beads.HasLabel(issue, "mountain")
+				}
+				costs[id] = cc
+			}
+			cc.TotalUSD += cost
+			if att.Rework {
+				cc.ReworkUSD += cost
+			}
+			cc.WorkItems++
+		}
+	}
+
+	result := make([]ConvoyCost, 0, len(costs))
+	for _, cc := range costs {
+		result = append(result, *cc)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalUSD != result[j].TotalUSD {
+			return result[i].TotalUSD > result[j].TotalUSD
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// trackedCost sums the spend delivering any of tracked (directly, as rework,
+// or through a descendant) and returns it with the share per tracked issue.
+func (a *costAttributor) trackedCost(byWorkItem map[string]float64, tracked []string) (total, rework float64, perIssue map[string]float64) {
+	a.prefetchWorkItems(byWorkItem)
+	set := make(map[string]bool, len(tracked))
+	for _, id := range tracked {
+		set[id] = true
+	}
+	perIssue = make(map[string]float64)
+	for workItem, cost := range byWorkItem {
+		att := a.attribute(workItem)
+		for _, id := range append([]string{att.Delivery}, att.Ancestors...) {
+			if set[id] {
+				perIssue[id] += cost
+				total += cost
+				if att.Rework {
+					rework += cost
+				}
+				break
+			}
+		}
+	}
+	return total, rework, perIssue
+}
+
+// reworkSourceIssue returns the issue an MR or conflict-resolution task
+// delivers, or "" for ordinary work.
+func reworkSourceIssue(issue *beads.Issue) string {
+	if strings.HasPrefix(issue.Title, conflictTaskTitlePrefix) {
+		for _, line := range strings.Split(issue.Description, "\n") {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(line), "- Original issue:"); ok {
+				return strings.TrimSpace(v)
+			}
+		}
+	}
+	if issue.Type == "merge-request" || beads.HasLabel(issue, "gt:merge-request") {
+		if fields := beads.ParseMRFields(issue); fields != nil {
+			return fields.SourceIssue
+		}
+	}
+	return ""
+}
+
+// issueParent returns an issue's parent from its parent field, or its
+// parent-child dependency.
+func issueParent(issue *beads.Issue) string {
+	if issue.Parent != "" {
+		return issue.Parent
+	}
+	for _, dep := range issue.Dependencies {
+		if dep.DependencyType == "parent-child" {
+			return dep.ID
+		}
+	}
+	return ""
+}
+
+// showIssuesFromTownRoot fetches issues in one bd show call from the town root,
+// so prefix routing reaches every rig's database. Falls back to one call per
+// issue when the batch fails (e.g. one ID is unknown).
+func showIssuesFromTownRoot(townRoot string, ids []string) map[string]*beads.Issue {
+	result := make(map[string]*beads.Issue)
+	run := func(ids []string) ([]*beads.Issue, error) {
+		args := append([]string{"show"}, ids...)
+		args = append(args, "--json")
+		cmd := exec.Command("bd", args...)
+		if townRoot != "" {
+			cmd.Dir = townRoot
+			cmd.Env = stripEnvKey(os.Environ(), "BEADS_DIR")
+		}
+		var stdout bytes.Buffer
+		cmd.Stdout = &stdout
+		if err := cmd.Run(); err != nil {
+			return nil, err
+		}
+		var issues []*beads.Issue
+		if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
+			return nil, err
+		}
+		return issues, nil
+	}
+
+	issues, err := run(ids)
+	if err != nil && len(ids) > 1 {
+		for _, id := range ids {
+			if one, err := run([]string{id}); err == nil {
+				issues = append(issues, one...)
+			}
+		}
+	}
+	for _, issue := range issues {
+		result[issue.ID] = issue
+	}
+	return result
+}
+
+// runCostsAttribution shows spend by bead (--by-bead) and/or convoy (--by-convoy).
+// Without --today or --week it covers all recorded history.
+func runCostsAttribution() error {
+	now := time.Now()
+	var since time.Time
+	period := "all time"
+	switch {
+	case costsToday:
+		since = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		period = "today"
+	case costsWeek:
+		since = now.AddDate(0, 0, -7)
+		period = "this week"
+	}
+
+	byWorkItem, unattributed, err := workItemCosts(since)
+	if err != nil {
+		return fmt.Errorf("querying costs: %w", err)
+	}
+
+	output := CostsOutput{Period: period, UnattributedUSD: unattributed}
+	for _, cost := range byWorkItem {
+		output.Total += cost
+	}
+	output.Total += unattributed
+
+	a := newCostAttributor()
+	if costsByBead {
+		output.ByBead = a.beadCosts(byWorkItem)
+	}
+	if costsByConvoy {
+		output.ByConvoy = a.convoyCosts(byWorkItem)
+	}
+
+	if costsJSON {
+		return outputCostsJSON(output)
+	}
+
+	if output.Total == 0 {
+		fmt.Println(style.Dim.Render("No cost data found. Costs are recorded when sessions end."))
+		return nil
+	}
+	outputAttributionHuman(output)
+	return nil
+}
+
+func outputAttributionHuman(output CostsOutput) {
+	if costsByBead {
+		fmt.Printf("\n%s Cost by Bead (%s)\n\n", style.Bold.Render("📊"), output.Period)
+		fmt.Printf("%-16s %-8s %9s %9s %9s %9s  %s\n", "Bead", "Type", "Direct", "Rework", "Children", "Total", "Title")
+		fmt.Println(strings.Repeat("─", 90))
+		for _, bc := range output.ByBead {
+			fmt.Printf("%-16s %-8s %9s %9s %9s %9s  %s\n",
+				bc.ID, bc.IssueType, formatCostCell(bc.DirectUSD), formatCostCell(bc.ReworkUSD),
+				formatCostCell(bc.ChildUSD), fmt.Sprintf("$%.2f", bc.TotalUSD), bc.Title)
+		}
+	}
+
+	if costsByConvoy {
+		fmt.Printf("\n%s Cost by Convoy (%s)\n\n", style.Bold.Render("🚚"), output.Period)
+		if len(output.ByConvoy) == 0 {
+			fmt.Println(style.Dim.Render("  No spend on convoy-tracked work"))
+		}
+		for _, cc := range output.ByConvoy {
+			kind := ""
+			if cc.Mountain {
+				kind = " ⛰"
+			}
+			line := fmt.Sprintf("  %-16s $%9.2f  %s%s", cc.ID, cc.TotalUSD, cc.Title, kind)
+			if cc.ReworkUSD > 0 {
+				line += style.Dim.Render(fmt.Sprintf("  ($%.2f rework)", cc.ReworkUSD))
+			}
+			fmt.Println(line)
+		}
+	}
+
+	fmt.Printf("\n%s $%.2f", style.Bold.Render("Total:"), output.Total)
+	if output.UnattributedUSD > 0 {
+		fmt.Printf("  %s", style.Dim.Render(fmt.Sprintf("($%.2f not attributed to a bead)", output.UnattributedUSD)))
+	}
+	fmt.Println()
+}
+
+// formatCostCell renders a cost for a table cell, with "-" for zero.
+func formatCostCell(usd float64) string {
+	if usd == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// convoyTrackedCost sets CostUSD on each tracked issue from all recorded
+// spend and returns the convoy's total and rework spend.
+func convoyTrackedCost(tracked []trackedIssueInfo) (total, rework float64) {
+	if len(tracked) == 0 {
+		return 0, 0
+	}
+	byWorkItem, _, err := workItemCosts(time.Time{})
+	if err != nil || len(byWorkItem) == 0 {
+		return 0, 0
+	}
+	ids := make([]string, len(tracked))
+	for i, t := range tracked {
+		ids[i] = t.ID
+	}
+	total, rework, perIssue := newCostAttributor().trackedCost(byWorkItem, ids)
+	for i := range tracked {
+		tracked[i].CostUSD = perIssue[tracked[i].ID]
+	}
+	return total, rework
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
+)
+
+// fakeCostAttributor serves issues and "tracks" deps from memory.
+func fakeCostAttributor(issues []*beads.Issue, tracks map[string][]string) *costAttributor {
+	byID := make(map[string]*beads.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	return &costAttributor{
+		show: func(ids []string) map[string]*beads.Issue {
+			found := make(map[string]*beads.Issue)
+			for _, id := range ids {
+				if issue, ok := byID[id]; ok {
+					found[id] = issue
+				}
+			}
+			return found
+		},
+		trackers: func(id string) []string { return tracks[id] },
+	}
+}
+
+func attributionFixture() *costAttributor {
+	return fakeCostAttributor([]*beads.Issue{
+		{ID: "gt-epic", Title: "Auth", Type: "epic"},
+		{ID: "gt-login", Title: "Login form", Type: "task", Parent: "gt-epic"},
+		{ID: "gt-logout", Title: "Logout", Type: "task",
+			Dependencies: []beads.IssueDep{{ID: "gt-epic", DependencyType: "parent-child"}}},
+		{ID: "gt-mr1", Title: "Merge login", Type: "merge-request",
+			Description: "branch: polecat/toast\nsource_issue: gt-login\n"},
+		{ID: "gt-fix", Title: conflictTaskTitlePrefix + " Login form", Type: "task",
+			Description: "## Metadata\n- Original MR: gt-mr1\n- Original issue: gt-login\n"},
+		{ID: "gt-solo", Title: "Unrelated", Type: "bug"},
+		{ID: "hq-cv-1", Title: "Auth convoy", Type: "convoy", Status: "open", Labels: []string{"mountain"}},
+	}, map[string][]string{"gt-epic": {"hq-cv-1"}})
+}
+
+func TestCostAttributor_BeadCosts(t *testing.T) {
+	a := attributionFixture()
+	costs := a.beadCosts(map[string]float64{
+		"gt-login":  3,
+		"gt-mr1":    1,
+		"gt-fix":    0.5,
+		"gt-logout": 2,
+		"gt-solo":   1,
+		"gt-gone":   0.25, // unknown bead attributes to itself
+	})
+
+	byID := make(map[string]BeadCost)
+	for _, c := range costs {
+		byID[c.ID] = c
+	}
+	if c := byID["gt-login"]; c.DirectUSD != 3 || c.ReworkUSD != 1.5 || c.TotalUSD != 4.5 {
+		t.Errorf("gt-login = %+v, want direct 3, rework 1.5 (MR + conflict task)", c)
+	}
+	if _, ok := byID["gt-mr1"]; ok {
+		t.Error("MR spend should roll into its source issue, not stand alone")
+	}
+	if c := byID["gt-epic"]; c.ChildUSD != 6.5 || c.TotalUSD != 6.5 || c.DirectUSD != 0 {
+		t.Errorf("gt-epic = %+v, want 6.5 rolled up from children", c)
+	}
+	if c := byID["gt-gone"]; c.TotalUSD != 0.25 {
+		t.Errorf("unknown bead = %+v, want attributed to itself", c)
+	}
+	if costs[0].ID != "gt-epic" {
+		t.Errorf("costs not sorted by total: first is %s", costs[0].ID)
+	}
+}
+
+func TestCostAttributor_ConvoyCosts(t *testing.T) {
+	a := attributionFixture()
+	costs := a.convoyCosts(map[string]float64{
+		"gt-login": 3,
+		"gt-fix":   0.5,
+		"gt-solo":  1,
+	})
+	if len(costs) != 1 {
+		t.Fatalf("convoyCosts = %+v, want only hq-cv-1", costs)
+	}
+	c := costs[0]
+	if c.ID != "hq-cv-1" || !c.Mountain || c.TotalUSD != 3.5 || c.ReworkUSD != 0.5 || c.WorkItems != 2 {
+		t.Errorf("convoy cost = %+v", c)
+	}
+}
+
+func TestCostAttributor_TrackedCost(t *testing.T) {
+	a := attributionFixture()
+	total, rework, perIssue := a.trackedCost(map[string]float64{
+		"gt-login":  3,
+		"gt-mr1":    1,
+		"gt-logout": 2,
+		"gt-solo":   1,
+	}, []string{"gt-login", "gt-epic"})
+	if total != 6 || rework != 1 {
+		t.Errorf("total, rework = %v, %v; want 6, 1", total, rework)
+	}
+	// Spend lands on the nearest tracked bead only.
+	if perIssue["gt-login"] != 4 || perIssue["gt-epic"] != 2 {
+		t.Errorf("perIssue = %v", perIssue)
+	}
+}
+
+func TestReworkSourceIssue_IgnoresOrdinaryIssues(t *testing.T) {
+	issue := &beads.Issue{ID: "gt-x", Type: "task", Description: "source_issue: gt-y"}
+	if got := reworkSourceIssue(issue); got != "" {
+		t.Errorf("reworkSourceIssue(task) = %q, want empty", got)
+	}
+}
+
+func TestWorkItemCosts_FromLog(t *testing.T) {
+	gtHome := t.TempDir()
+	t.Setenv("GT_HOME", gtHome)
+	t.Setenv("PATH", t.TempDir()) // no bd: no digests
+
+	now := time.Now()
+	logDir := filepath.Join(gtHome, ".gt")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(filepath.Join(logDir, "costs.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := json.NewEncoder(f)
+	for _, e := range []CostLogEntry{
+		{SessionID: "a", CostUSD: 1, EndedAt: now, WorkItem: "gt-1"},
+		{SessionID: "b", CostUSD: 2, EndedAt: now, WorkItem: "gt-1"},
+		{SessionID: "c", CostUSD: 0.5, EndedAt: now},
+		{SessionID: "d", CostUSD: 4, EndedAt: now.AddDate(0, 0, -10), WorkItem: "gt-2"},
+	} {
+		if err := enc.Encode(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.Close()
+
+	byWorkItem, unattributed, err := workItemCosts(time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byWorkItem["gt-1"] != 3 || byWorkItem["gt-2"] != 4 || unattributed != 0.5 {
+		t.Errorf("all time = %v, unattributed %v", byWorkItem, unattributed)
+	}
+
+	byWorkItem, _, err = workItemCosts(now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := byWorkItem["gt-2"]; ok {
+		t.Errorf("past week includes 10-day-old spend: %v", byWorkItem)
+	}
+}
+
+func TestResolveCostWorkItem_CheckpointFallback(t *testing.T) {
+	t.Setenv("PATH", t.TempDir()) // no bd: hooked bead query fails
+	workDir := t.TempDir()
+	if got := resolveCostWorkItem("gt-gastown-toast", workDir); got != "" {
+		t.Errorf("no hook, no checkpoint = %q, want empty", got)
+	}
+	if err := checkpoint.Write(workDir, &checkpoint.Checkpoint{HookedBead: "gt-abc"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := resolveCostWorkItem("gt-gastown-toast", workDir); got != "gt-abc" {
+		t.Errorf("checkpoint fallback = %q, want gt-abc", got)
+	}
+}
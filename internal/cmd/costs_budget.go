@@ -0,0 +1,393 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/budget"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// budgetActor is recorded as the scheduler pauser when enforcement pauses it,
+// so enforcement only ever resumes a pause it made itself.
+const budgetActor = "budget"
+
+var (
+	budgetJSON    bool
+	budgetEnforce bool
+)
+
+// Enforcement side effects and convoy lookup, replaceable in tests.
+var (
+	budgetEscalateFn  = budgetEscalate
+	budgetParkRigFn   = parkOneRig
+	budgetUnparkRigFn = unparkOneRig
+
+	isTrackedByConvoyFn = isTrackedByConvoy
+)
+
+var costsBudgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Show cost budget burn-down and enforce budgets",
+	Long: `Show spend against the cost budgets configured in settings/config.json.
+
+Budgets are daily and/or monthly USD limits for the town, individual rigs,
+roles and convoys:
+
+  "budgets": {
+    "town":    {"daily_usd": 200, "monthly_usd": 4000},
+    "rigs":    {"gastown": {"daily_usd": 80}},
+    "roles":   {"polecat": {"daily_usd": 120}},
+    "convoys": {"hq-cv-abc": {"monthly_usd": 500}},
+    "soft_percent": 80,
+    "hard_actions": ["refuse_sling", "pause_scheduler", "park_rig"]
+  }
+
+Spend is gathered from this month's digest beads, the costs log
+(~/.gt/costs.jsonl) and live transcripts of running sessions. Convoy spend is
+attributed through the work item each session recorded. Projected exhaustion
+extrapolates the average burn rate since the start of the period.
+
+With --enforce (run periodically by the daemon's budget patrol):
+  soft threshold   warn and escalate, once per budget per period
+  hard threshold   escalate, then apply hard_actions (all by default):
+                   refuse_sling     gt sling refuses covered work
+                   pause_scheduler  pause the scheduler (town or polecat budget)
+                   park_rig         park the rig (rig budget)
+Anything enforcement paused or parked is resumed once the budget recovers,
+usually when the period rolls over.
+
+Examples:
+  gt costs budget              # Burn-down for every configured budget
+  gt costs budget --json       # Machine-readable output
+  gt costs budget --enforce    # Evaluate and apply thresholds`,
+	RunE: runCostsBudget,
+}
+
+func init() {
+	costsCmd.AddCommand(costsBudgetCmd)
+	costsBudgetCmd.Flags().BoolVar(&budgetJSON, "json", false, "Output as JSON")
+	costsBudgetCmd.Flags().BoolVar(&budgetEnforce, "enforce", false, "Apply soft and hard thresholds (escalate, pause scheduler, park rigs)")
+}
+
+// BudgetOutput is the JSON output of gt costs budget.
+type BudgetOutput struct {
+	EvaluatedAt time.Time       `json:"evaluated_at"`
+	Daily       budget.Spend    `json:"daily"`
+	Monthly     budget.Spend    `json:"monthly"`
+	Statuses    []budget.Status `json:"statuses"`
+	Actions     []string        `json:"actions,omitempty"`
+}
+
+func runCostsBudget(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+	cfg := settings.Budgets
+
+	now := time.Now()
+	output := BudgetOutput{EvaluatedAt: now}
+	if !cfg.IsEmpty() {
+		output.Daily, output.Monthly = gatherBudgetSpend(cfg, now)
+		output.Statuses = budget.Evaluate(cfg, output.Daily, output.Monthly, now)
+	}
+
+	if budgetEnforce {
+		output.Actions, err = enforceBudgets(townRoot, cfg, output.Statuses, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	if budgetJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	}
+
+	if cfg.IsEmpty() {
+		fmt.Println(style.Dim.Render("No budgets configured. Add a \"budgets\" section to settings/config.json."))
+	} else {
+		outputBudgetHuman(output)
+	}
+	for _, a := range output.Actions {
+		fmt.Printf("  %s %s\n", style.Warning.Render("→"), a)
+	}
+	return nil
+}
+
+// gatherBudgetSpend returns today's and this month's spend.
+//
+// Past days come from digest beads; days not yet digested come from the costs
+// log. Running sessions add their live transcript cost beyond what they have
+// already recorded today, attributed to today (a session spanning midnight is
+// counted entirely today). Live spend has no work item, so it counts toward
+// town, rig and role budgets but not convoys.
+func gatherBudgetSpend(cfg *budget.Config, now time.Time) (daily, monthly budget.Spend) {
+	monthStart, _ := budget.PeriodBounds(budget.PeriodMonthly, now)
+	today := now.Format("2006-01-02")
+	convoyOf := newConvoyResolver(len(cfg.Convoys) > 0)
+
+	digested := make(map[string]bool)
+	digests, err := queryCostDigests(monthStart)
+	if err != nil && costsVerbose {
+		fmt.Fprintf(os.Stderr, "[costs] querying digests: %v\n", err)
+	}
+	for _, d := range digests {
+		if digested[d.Date] || d.Date == today {
+			continue
+		}
+		digested[d.Date] = true
+		spend := budget.Spend{Total: d.TotalUSD, ByRig: d.ByRig, ByRole: d.ByRole}
+		for workItem, cost := range d.ByWorkItem {
+			if convoy := convoyOf(workItem); convoy != "" {
+				if spend.ByConvoy == nil {
+					spend.ByConvoy = make(map[string]float64)
+				}
+				spend.ByConvoy[convoy] += cost
+			}
+		}
+		monthly.Merge(spend)
+	}
+
+	entries, err := readCostLog(func(e CostLogEntry) bool {
+		return !e.EndedAt.Before(monthStart) && !digested[e.EndedAt.Format("2006-01-02")]
+	})
+	if err != nil && costsVerbose {
+		fmt.Fprintf(os.Stderr, "[costs] reading costs log: %v\n", err)
+	}
+	recordedToday := make(map[string]float64)
+	for _, e := range entries {
+		convoy := convoyOf(e.WorkItem)
+		monthly.Add(e.Rig, e.Role, convoy, e.CostUSD)
+		if e.EndedAt.Format("2006-01-02") == today {
+			daily.Add(e.Rig, e.Role, convoy, e.CostUSD)
+			recordedToday[e.SessionID] += e.CostUSD
+		}
+	}
+
+	live, _, err := liveSessionCosts()
+	if err != nil && costsVerbose {
+		fmt.Fprintf(os.Stderr, "[costs] reading live sessions: %v\n", err)
+	}
+	for _, c := range live {
+		if extra := c.Cost - recordedToday[c.Session]; extra > 0 {
+			daily.Add(c.Rig, c.Role, "", extra)
+			monthly.Add(c.Rig, c.Role, "", extra)
+		}
+	}
+
+	return daily, monthly
+}
+
+// newConvoyResolver returns a cached work item → open convoy lookup. When
+// disabled (no convoy budgets) it resolves nothing and makes no bd calls.
+func newConvoyResolver(enabled bool) func(workItem string) string {
+	cache := make(map[string]string)
+	return func(workItem string) string {
+		if !enabled || workItem == "" {
+			return ""
+		}
+		convoy, ok := cache[workItem]
+		if !ok {
+			convoy = isTrackedByConvoyFn(workItem)
+			cache[workItem] = convoy
+		}
+		return convoy
+	}
+}
+
+// enforceBudgets applies soft and hard thresholds and saves the budget state
+// read by gt sling. It returns a description of each action taken.
+func enforceBudgets(townRoot string, cfg *budget.Config, statuses []budget.Status, now time.Time) ([]string, error) {
+	state, err := budget.LoadState(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("loading budget state: %w", err)
+	}
+	state.EvaluatedAt = now
+	state.Statuses = statuses
+
+	var actions []string
+
+	// Escalate each budget once per level per period. Keys include the
+	// period start, so entries for past periods drop out here.
+	escalated := make(map[string]string)
+	for _, st := range statuses {
+		key := st.Key()
+		prev := state.Escalated[key]
+		if prev != "" {
+			escalated[key] = prev
+		}
+		if st.Level == budget.LevelOK || prev == string(st.Level) || prev == string(budget.LevelHard) {
+			continue
+		}
+		severity, eventType := "medium", events.TypeBudgetWarning
+		if st.Level == budget.LevelHard {
+			severity, eventType = "high", events.TypeBudgetExceeded
+		}
+		msg := fmt.Sprintf("Budget %s: %s %s spend $%.2f of $%.2f (%.0f%%)",
+			st.Level, st.Scope(), st.Period, st.SpentUSD, st.LimitUSD, st.Percent)
+		if err := budgetEscalateFn(severity, msg); err != nil {
+			fmt.Fprintf(os.Stderr, "%s escalation failed: %v\n", style.Warning.Render("⚠"), err)
+		}
+		_ = events.LogFeed(eventType, budgetActor,
+			events.BudgetPayload(st.Scope(), string(st.Period), st.SpentUSD, st.LimitUSD))
+		escalated[key] = string(st.Level)
+		actions = append(actions, "escalated: "+msg)
+	}
+	state.Escalated = escalated
+
+	// Scheduler: pause while a town or polecat budget is exhausted; resume
+	// only a pause this enforcement made.
+	exhausted := state.SchedulerExhausted(now) && cfg.HardActionEnabled(budget.ActionPauseScheduler)
+	sched, err := capacity.LoadState(townRoot)
+	if err != nil {
+		return actions, fmt.Errorf("loading scheduler state: %w", err)
+	}
+	switch {
+	case exhausted && !sched.Paused:
+		sched.SetPaused(budgetActor)
+		if err := capacity.SaveState(townRoot, sched); err != nil {
+			return actions, fmt.Errorf("saving scheduler state: %w", err)
+		}
+		state.PausedScheduler = true
+		actions = append(actions, "paused scheduler")
+	case !exhausted && state.PausedScheduler:
+		if sched.Paused && sched.PausedBy == budgetActor {
+			sched.SetResumed()
+			if err := capacity.SaveState(townRoot, sched); err != nil {
+				return actions, fmt.Errorf("saving scheduler state: %w", err)
+			}
+			actions = append(actions, "resumed scheduler")
+		}
+		state.PausedScheduler = false
+	}
+
+	// Rigs: park rigs whose own budget is exhausted, unpark the ones
+	// enforcement parked once they recover.
+	var hardRigs []string
+	if cfg.HardActionEnabled(budget.ActionParkRig) {
+		hardRigs = state.HardRigs(now)
+	}
+	var parked []string
+	for _, rigName := range state.ParkedRigs {
+		if slices.Contains(hardRigs, rigName) {
+			parked = append(parked, rigName)
+			continue
+		}
+		if IsRigParked(townRoot, rigName) {
+			if err := budgetUnparkRigFn(rigName); err != nil {
+				fmt.Fprintf(os.Stderr, "%s unparking %s: %v\n", style.Warning.Render("⚠"), rigName, err)
+				parked = append(parked, rigName)
+				continue
+			}
+			actions = append(actions, "unparked rig "+rigName)
+		}
+	}
+	for _, rigName := range hardRigs {
+		if slices.Contains(parked, rigName) || IsRigParked(townRoot, rigName) {
+			continue
+		}
+		if err := budgetParkRigFn(rigName); err != nil {
+			fmt.Fprintf(os.Stderr, "%s parking %s: %v\n", style.Warning.Render("⚠"), rigName, err)
+			continue
+		}
+		parked = append(parked, rigName)
+		actions = append(actions, "parked rig "+rigName)
+	}
+	state.ParkedRigs = parked
+
+	if err := budget.SaveState(townRoot, state); err != nil {
+		return actions, fmt.Errorf("saving budget state: %w", err)
+	}
+	return actions, nil
+}
+
+// budgetEscalate fires an escalation via gt escalate.
+func budgetEscalate(severity, msg string) error {
+	cmd := exec.Command("gt", "escalate", "--severity", severity, msg)
+	return cmd.Run()
+}
+
+// checkBudgetAllowsSling returns an error if an exhausted budget covers
+// slinging beadID to a polecat in rigName, per the last gt costs budget
+// --enforce evaluation.
+func checkBudgetAllowsSling(townRoot, rigName, beadID string) error {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil || settings.Budgets.IsEmpty() || !settings.Budgets.HardActionEnabled(budget.ActionRefuseSling) {
+		return nil
+	}
+	state, err := budget.LoadState(townRoot)
+	if err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	convoy := ""
+	if beadID != "" && len(state.HardConvoys(now)) > 0 {
+		convoy = isTrackedByConvoyFn(beadID)
+	}
+	st := state.SlingBlock(rigName, convoy, now)
+	if st == nil {
+		return nil
+	}
+	return fmt.Errorf("cannot sling to rig %q: %s %s budget exceeded ($%.2f of $%.2f)\nRaise the limit in settings/config.json or wait for the period to roll over (%s)",
+		rigName, st.Scope(), st.Period, st.SpentUSD, st.LimitUSD, st.PeriodEnd.Format("2006-01-02 15:04"))
+}
+
+func outputBudgetHuman(output BudgetOutput) {
+	fmt.Printf("\n%s Cost Budgets\n\n", style.Bold.Render("💰"))
+	fmt.Printf("%-22s %-8s %10s %10s %6s  %s\n", "Scope", "Period", "Spent", "Limit", "Used", "Exhausts")
+	fmt.Println(strings.Repeat("─", 80))
+
+	for _, st := range output.Statuses {
+		used := fmt.Sprintf("%.0f%%", st.Percent)
+		switch st.Level {
+		case budget.LevelHard:
+			used = style.Error.Render(fmt.Sprintf("%6s", used))
+		case budget.LevelSoft:
+			used = style.Warning.Render(fmt.Sprintf("%6s", used))
+		default:
+			used = fmt.Sprintf("%6s", used)
+		}
+
+		exhausts := style.Dim.Render("not this period")
+		if st.Level == budget.LevelHard {
+			exhausts = style.Error.Render("exhausted")
+		} else if st.ProjectedExhaustion != nil {
+			exhausts = formatBudgetExhaustion(*st.ProjectedExhaustion, output.EvaluatedAt)
+		}
+
+		fmt.Printf("%-22s %-8s %10s %10s %s  %s\n",
+			st.Scope(), st.Period,
+			fmt.Sprintf("$%.2f", st.SpentUSD), fmt.Sprintf("$%.2f", st.LimitUSD),
+			used, exhausts)
+	}
+	fmt.Println(strings.Repeat("─", 80))
+	fmt.Printf("%s $%.2f today, $%.2f this month\n", style.Bold.Render("Spend:"), output.Daily.Total, output.Monthly.Total)
+}
+
+// formatBudgetExhaustion renders a projected exhaustion time relative to now.
+func formatBudgetExhaustion(at, now time.Time) string {
+	in := formatDuration(at.Sub(now))
+	if at.YearDay() == now.YearDay() && at.Year() == now.Year() {
+		return fmt.Sprintf("%s (in %s)", at.Format("15:04"), in)
+	}
+	return fmt.Sprintf("%s (in %s)", at.Format("Jan 2 15:04"), in)
+}
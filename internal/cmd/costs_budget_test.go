@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/budget"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+// stubBudgetEnforcement replaces escalation and rig parking with recorders.
+// Parking sets the rig's wisp status so IsRigParked sees it.
+func stubBudgetEnforcement(t *testing.T, townRoot string) (escalations *[]string, unparked *[]string) {
+	t.Helper()
+	escalations, unparked = &[]string{}, &[]string{}
+	oldEsc, oldPark, oldUnpark := budgetEscalateFn, budgetParkRigFn, budgetUnparkRigFn
+	t.Cleanup(func() {
+		budgetEscalateFn, budgetParkRigFn, budgetUnparkRigFn = oldEsc, oldPark, oldUnpark
+	})
+	budgetEscalateFn = func(severity, msg string) error {
+		*escalations = append(*escalations, severity+": "+msg)
+		return nil
+	}
+	budgetParkRigFn = func(rigName string) error {
+		return wisp.NewConfig(townRoot, rigName).Set(RigStatusKey, RigStatusParked)
+	}
+	budgetUnparkRigFn = func(rigName string) error {
+		*unparked = append(*unparked, rigName)
+		return wisp.NewConfig(townRoot, rigName).Unset(RigStatusKey)
+	}
+	return escalations, unparked
+}
+
+func TestEnforceBudgets(t *testing.T) {
+	townRoot := t.TempDir()
+	t.Chdir(townRoot)
+	escalations, unparked := stubBudgetEnforcement(t, townRoot)
+
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.Local)
+	cfg := &budget.Config{
+		Town: &budget.Limit{DailyUSD: 100},
+		Rigs: map[string]*budget.Limit{"gastown": {DailyUSD: 20}},
+	}
+	var daily budget.Spend
+	daily.Add("gastown", "polecat", "", 85)
+
+	// Town at 85% (soft), gastown at 425% (hard).
+	if _, err := enforceBudgets(townRoot, cfg, budget.Evaluate(cfg, daily, budget.Spend{}, now), now); err != nil {
+		t.Fatalf("enforceBudgets: %v", err)
+	}
+	if len(*escalations) != 2 || !strings.HasPrefix((*escalations)[0], "medium: Budget soft: town") || !strings.HasPrefix((*escalations)[1], "high: Budget hard: rig gastown") {
+		t.Errorf("escalations = %q", *escalations)
+	}
+	if !IsRigParked(townRoot, "gastown") {
+		t.Error("gastown should be parked")
+	}
+	sched, _ := capacity.LoadState(townRoot)
+	if sched.Paused {
+		t.Error("scheduler paused although only a rig budget is exhausted")
+	}
+
+	// Re-evaluating the same state escalates nothing new.
+	if _, err := enforceBudgets(townRoot, cfg, budget.Evaluate(cfg, daily, budget.Spend{}, now), now); err != nil {
+		t.Fatal(err)
+	}
+	if len(*escalations) != 2 {
+		t.Errorf("repeat evaluation escalated again: %q", *escalations)
+	}
+
+	// Town exhausted too: the scheduler pauses.
+	daily.Add("beads", "crew", "", 20)
+	if _, err := enforceBudgets(townRoot, cfg, budget.Evaluate(cfg, daily, budget.Spend{}, now), now); err != nil {
+		t.Fatal(err)
+	}
+	sched, _ = capacity.LoadState(townRoot)
+	if !sched.Paused || sched.PausedBy != budgetActor {
+		t.Errorf("scheduler state = %+v, want paused by budget", sched)
+	}
+
+	// Next day: everything enforcement did is undone.
+	tomorrow := now.AddDate(0, 0, 1)
+	if _, err := enforceBudgets(townRoot, cfg, budget.Evaluate(cfg, budget.Spend{}, budget.Spend{}, tomorrow), tomorrow); err != nil {
+		t.Fatal(err)
+	}
+	sched, _ = capacity.LoadState(townRoot)
+	if sched.Paused {
+		t.Error("scheduler still paused after budget recovered")
+	}
+	if len(*unparked) != 1 || IsRigParked(townRoot, "gastown") {
+		t.Errorf("gastown not unparked after budget recovered (unparked %v)", *unparked)
+	}
+	state, _ := budget.LoadState(townRoot)
+	if state.PausedScheduler || len(state.ParkedRigs) != 0 || len(state.Escalated) != 0 {
+		t.Errorf("budget state not cleared: %+v", state)
+	}
+}
+
+func TestEnforceBudgets_LeavesManualPause(t *testing.T) {
+	townRoot := t.TempDir()
+	t.Chdir(townRoot)
+	stubBudgetEnforcement(t, townRoot)
+
+	sched := &capacity.SchedulerState{}
+	sched.SetPaused("mayor")
+	if err := capacity.SaveState(townRoot, sched); err != nil {
+		t.Fatal(err)
+	}
+	if err := budget.SaveState(townRoot, &budget.State{PausedScheduler: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	if _, err := enforceBudgets(townRoot, &budget.Config{Town: &budget.Limit{DailyUSD: 100}}, nil, now); err != nil {
+		t.Fatal(err)
+	}
+	sched, _ = capacity.LoadState(townRoot)
+	if !sched.Paused || sched.PausedBy != "mayor" {
+		t.Errorf("enforcement resumed a pause it did not make: %+v", sched)
+	}
+}
+
+func TestCheckBudgetAllowsSling(t *testing.T) {
+	townRoot := t.TempDir()
+	now := time.Now()
+
+	oldLookup := isTrackedByConvoyFn
+	t.Cleanup(func() { isTrackedByConvoyFn = oldLookup })
+	isTrackedByConvoyFn = func(beadID string) string {
+		if beadID == "gt-tracked" {
+			return "hq-cv-1"
+		}
+		return ""
+	}
+
+	cfg := &budget.Config{
+		Rigs:    map[string]*budget.Limit{"gastown": {DailyUSD: 10}},
+		Convoys: map[string]*budget.Limit{"hq-cv-1": {DailyUSD: 10}},
+	}
+	settings := config.NewTownSettings()
+	settings.Budgets = cfg
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	var daily budget.Spend
+	daily.Add("gastown", "polecat", "hq-cv-1", 15)
+	if err := budget.SaveState(townRoot, &budget.State{Statuses: budget.Evaluate(cfg, daily, budget.Spend{}, now)}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := checkBudgetAllowsSling(townRoot, "gastown", "gt-abc")
+	if err == nil || !strings.Contains(err.Error(), "rig gastown daily budget exceeded") {
+		t.Errorf("sling to over-budget rig: err = %v", err)
+	}
+	if err := checkBudgetAllowsSling(townRoot, "beads", "gt-tracked"); err == nil || !strings.Contains(err.Error(), "convoy hq-cv-1") {
+		t.Errorf("sling of bead in over-budget convoy: err = %v", err)
+	}
+	if err := checkBudgetAllowsSling(townRoot, "beads", "gt-other"); err != nil {
+		t.Errorf("sling within budget refused: %v", err)
+	}
+
+	// refuse_sling can be turned off.
+	cfg.HardActions = []string{budget.ActionParkRig}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkBudgetAllowsSling(townRoot, "gastown", "gt-abc"); err != nil {
+		t.Errorf("sling refused with refuse_sling disabled: %v", err)
+	}
+
+	// No budgets: never refuses, even with stale state.
+	if err := os.Remove(filepath.Join(townRoot, "settings", "config.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkBudgetAllowsSling(townRoot, "gastown", "gt-abc"); err != nil {
+		t.Errorf("sling refused without budgets: %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/agentlog"
 	"github.com/steveyegge/gastown/internal/session"
 )
 
@@ -294,33 +295,52 @@ func TestCostDigestPayload_ExcludesSessions(t *testing.T) {
 	}
 }
 
-func TestGetClaudeProjectDir_Default(t *testing.T) {
-	t.Setenv("CLAUDE_CONFIG_DIR", "")
-	home, err := os.UserHomeDir()
-	if err != nil {
-		t.Fatalf("getting home dir: %v", err)
+func TestCostsAgentType(t *testing.T) {
+	tests := map[string]string{
+		"":          "claudecode",
+		"claude":    "claude",
+		"codex":     "codex",
+		"gemini":    "gemini",
+		"opencode":  "opencode",
+		"cursor":    "claudecode", // no session-log adapter
+		"not-known": "claudecode",
 	}
-
-	got, err := getClaudeProjectDir("/some/work/dir")
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-	want := filepath.Join(home, ".claude", "projects", "-some-work-dir")
-	if got != want {
-		t.Errorf("getClaudeProjectDir() = %q, want %q", got, want)
+	for agent, want := range tests {
+		if got := costsAgentType(agent); got != want {
+			t.Errorf("costsAgentType(%q) = %q, want %q", agent, got, want)
+		}
 	}
 }
 
-func TestGetClaudeProjectDir_RespectsEnvVar(t *testing.T) {
-	customDir := t.TempDir()
-	t.Setenv("CLAUDE_CONFIG_DIR", customDir)
+func TestExtractCostFromWorkDir_Codex(t *testing.T) {
+	codexHome := t.TempDir()
+	t.Setenv("CODEX_HOME", codexHome)
+	workDir := t.TempDir()
 
-	got, err := getClaudeProjectDir("/some/work/dir")
+	dir := filepath.Join(codexHome, "sessions", "2026", "03", "01")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	rollout := `{"type":"session_meta","payload":{"id":"x","cwd":"` + workDir + `"}}
+{"type":"turn_context","payload":{"model":"gpt-5-codex"}}
+{"type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"total_tokens":3000000},"last_token_usage":{"input_tokens":2000000,"cached_input_tokens":1000000,"output_tokens":1000000,"total_tokens":3000000}}}}
+`
+	if err := os.WriteFile(filepath.Join(dir, "rollout-x.jsonl"), []byte(rollout), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prices := agentlog.PriceTable{"gpt-5": {InputPerMillion: 1, OutputPerMillion: 10, CacheReadPerMillion: 0.5}}
+	cost, err := extractCostFromWorkDir(prices, "codex", workDir)
 	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+		t.Fatalf("extractCostFromWorkDir: %v", err)
 	}
-	want := filepath.Join(customDir, "projects", "-some-work-dir")
-	if got != want {
-		t.Errorf("getClaudeProjectDir() = %q, want %q", got, want)
+	// 1M uncached input + 1M cached + 1M output
+	if cost != 11.5 {
+		t.Errorf("cost = %v, want 11.5", cost)
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	if _, err := extractCostFromWorkDir(prices, "gemini", workDir); err == nil {
+		t.Error("expected error for an agent with no session log")
 	}
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
@@ -22,6 +23,10 @@ var (
 	dashboardPort int
 	dashboardBind string
 	dashboardOpen bool
+
+	dashboardTLS      bool
+	dashboardTLSHosts []string
+	dashboardNoAuth   bool
 )
 
 var dashboardCmd = &cobra.Command{
@@ -40,7 +45,19 @@ Example:
   gt dashboard                    # Start on default port 8080
   gt dashboard --port 3000        # Start on port 3000
   gt dashboard --bind 0.0.0.0     # Listen on all interfaces
-  gt dashboard --open             # Start and open browser`,
+  gt dashboard --open             # Start and open browser
+
+Authentication:
+When bound to a non-loopback address the dashboard requires a bearer token
+or (with --tls) a client certificate issued by the town CA. Credentials carry
+a role: "viewer" (read-only) or "operator" (may use /api/run, send mail,
+modify issues and preview sessions). Set dashboard_auth.required in
+settings/config.json to require authentication on localhost too.
+Privileged and denied requests are audited to .events.jsonl.
+
+  gt dashboard token create alice --role operator
+  gt dashboard cert issue bob --role viewer --out ./bob
+  gt dashboard --bind 0.0.0.0 --tls`,
 	RunE: runDashboard,
 }
 
@@ -52,12 +69,16 @@ func init() {
 	}
 	dashboardCmd.Flags().StringVar(&dashboardBind, "bind", defaultBind, "Address to bind to (use 0.0.0.0 for all interfaces)")
 	dashboardCmd.Flags().BoolVar(&dashboardOpen, "open", false, "Open browser automatically")
+	dashboardCmd.Flags().BoolVar(&dashboardTLS, "tls", false, "Serve HTTPS with a certificate from the town CA and accept client certificates")
+	dashboardCmd.Flags().StringSliceVar(&dashboardTLSHosts, "tls-host", nil, "Extra DNS names or IPs for the TLS server certificate")
+	dashboardCmd.Flags().BoolVar(&dashboardNoAuth, "no-auth", false, "Disable authentication even on non-loopback binds (unsafe)")
 	rootCmd.AddCommand(dashboardCmd)
 }
 
 func runDashboard(cmd *cobra.Command, args []string) error {
 	// Check if we're in a workspace - if not, run in setup mode
 	var handler http.Handler
+	var tlsCfg *tls.Config
 	var err error
 
 	townRoot, wsErr := workspace.FindFromCwdOrError()
@@ -82,8 +103,10 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 
 		// Load web timeouts config (nil-safe: NewDashboardMux applies defaults)
 		var webCfg *config.WebTimeoutsConfig
+		var authCfg *config.DashboardAuthConfig
 		if ts, loadErr := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); loadErr == nil {
 			webCfg = ts.WebTimeouts
+			authCfg = ts.DashboardAuth
 		} else {
 			fmt.Fprintf(cmd.ErrOrStderr(), "warning: loading town settings: %v (using defaults)\n", loadErr)
 		}
@@ -92,6 +115,26 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("creating dashboard handler: %w", err)
 		}
+
+		requireAuth := !dashboardNoAuth && (!isLoopbackBind(dashboardBind) || (authCfg != nil && authCfg.Required))
+		auth, authErr := web.NewDashboardAuth(authCfg, requireAuth)
+		if authErr != nil {
+			if requireAuth && !authCfg.HasCredentials() {
+				return fmt.Errorf("%w\n\nBinding to %s exposes the dashboard to the network. Create a token first:\n  gt dashboard token create <name> --role operator\nor pass --no-auth to run without authentication", authErr, dashboardBind)
+			}
+			return fmt.Errorf("configuring dashboard auth: %w", authErr)
+		}
+		if auth.Open() && !isLoopbackBind(dashboardBind) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: dashboard authentication disabled on %s; anyone on the network can run gt commands\n", dashboardBind)
+		}
+		handler = auth.Wrap(handler)
+
+		if dashboardTLS {
+			tlsCfg, err = dashboardTLSConfig(townRoot, dashboardBind, dashboardTLSHosts)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	// Build the listen address and display URL
@@ -104,7 +147,11 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 			displayHost = "localhost"
 		}
 	}
-	url := fmt.Sprintf("http://%s:%d", displayHost, dashboardPort)
+	scheme := "http"
+	if tlsCfg != nil {
+		scheme = "https"
+	}
+	url := fmt.Sprintf("%s://%s:%d", scheme, displayHost, dashboardPort)
 
 	// Open browser if requested
 	if dashboardOpen {
@@ -149,6 +196,10 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 		ReadTimeout:       30 * time.Second,
 		WriteTimeout:      60 * time.Second,
 		IdleTimeout:       120 * time.Second,
+		TLSConfig:         tlsCfg,
+	}
+	if tlsCfg != nil {
+		return server.ListenAndServeTLS("", "")
 	}
 	return server.ListenAndServe()
 }
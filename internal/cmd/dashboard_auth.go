@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/proxy"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/web"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	dashboardTokenRole string
+	dashboardCertRole  string
+	dashboardCertOut   string
+	dashboardCertTTL   time.Duration
+)
+
+var dashboardTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage dashboard bearer tokens",
+	Long: `Manage bearer tokens for gt dashboard.
+
+Tokens carry a role: "viewer" (read-only) or "operator" (may run commands,
+send mail, modify issues and preview sessions). Only a SHA-256 hash of each
+token is stored in settings/config.json.
+
+Use a token with:
+  curl -H "Authorization: Bearer <token>" http://host:8080/api/v1/rigs
+or open http://host:8080/?token=<token> once in a browser to set a cookie.`,
+	RunE: requireSubcommand,
+}
+
+var dashboardTokenCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a dashboard token (printed once)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDashboardTokenCreate,
+}
+
+var dashboardTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List dashboard tokens and client certificates",
+	Args:  cobra.NoArgs,
+	RunE:  runDashboardTokenList,
+}
+
+var dashboardTokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <name>",
+	Short: "Revoke a dashboard token",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDashboardTokenRevoke,
+}
+
+var dashboardCertCmd = &cobra.Command{
+	Use:   "cert",
+	Short: "Manage dashboard client certificates (mTLS)",
+	Long: `Manage client certificates for gt dashboard --tls.
+
+Certificates are issued by the town CA (~/gt/.runtime/ca, shared with
+gt-proxy-server). The certificate CN is mapped to a role in
+settings/config.json; removing the mapping revokes dashboard access.`,
+	RunE: requireSubcommand,
+}
+
+var dashboardCertIssueCmd = &cobra.Command{
+	Use:   "issue <name>",
+	Short: "Issue a client certificate for the dashboard",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDashboardCertIssue,
+}
+
+var dashboardCertRevokeCmd = &cobra.Command{
+	Use:   "revoke <name>",
+	Short: "Remove a client certificate's dashboard role",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDashboardCertRevoke,
+}
+
+func init() {
+	dashboardTokenCreateCmd.Flags().StringVar(&dashboardTokenRole, "role", "viewer", "Token role: viewer or operator")
+	dashboardCertIssueCmd.Flags().StringVar(&dashboardCertRole, "role", "viewer", "Certificate role: viewer or operator")
+	dashboardCertIssueCmd.Flags().StringVar(&dashboardCertOut, "out", ".", "Directory to write <name>.crt, <name>.key and ca.crt")
+	dashboardCertIssueCmd.Flags().DurationVar(&dashboardCertTTL, "ttl", 90*24*time.Hour, "Certificate lifetime")
+
+	dashboardTokenCmd.AddCommand(dashboardTokenCreateCmd, dashboardTokenListCmd, dashboardTokenRevokeCmd)
+	dashboardCertCmd.AddCommand(dashboardCertIssueCmd, dashboardCertRevokeCmd)
+	dashboardCmd.AddCommand(dashboardTokenCmd, dashboardCertCmd)
+}
+
+// loadDashboardAuthSettings loads town settings for modification.
+func loadDashboardAuthSettings() (townRoot string, settings *config.TownSettings, err error) {
+	townRoot, err = workspace.FindFromCwdOrError()
+	if err != nil {
+		return "", nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	settings, err = config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return "", nil, fmt.Errorf("loading town settings: %w", err)
+	}
+	if settings.DashboardAuth == nil {
+		settings.DashboardAuth = &config.DashboardAuthConfig{}
+	}
+	return townRoot, settings, nil
+}
+
+// saveDashboardAuthSettings writes settings back to the town settings file.
+func saveDashboardAuthSettings(townRoot string, settings *config.TownSettings) error {
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		return fmt.Errorf("saving town settings: %w", err)
+	}
+	return nil
+}
+
+func runDashboardTokenCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	role, err := web.ParseRole(dashboardTokenRole)
+	if err != nil {
+		return err
+	}
+	townRoot, settings, err := loadDashboardAuthSettings()
+	if err != nil {
+		return err
+	}
+	for _, t := range settings.DashboardAuth.Tokens {
+		if t.Name == name {
+			return fmt.Errorf("token %q already exists (revoke it first)", name)
+		}
+	}
+
+	token, err := web.GenerateDashboardToken()
+	if err != nil {
+		return err
+	}
+	settings.DashboardAuth.Tokens = append(settings.DashboardAuth.Tokens, config.DashboardToken{
+		Name:      name,
+		Role:      role.String(),
+		SHA256:    web.HashDashboardToken(token),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err := saveDashboardAuthSettings(townRoot, settings); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Created %s token %q\n\n", style.SuccessPrefix, role, name)
+	fmt.Printf("  %s\n\n", token)
+	fmt.Println(style.Dim.Render("Store it now — it cannot be shown again."))
+	return nil
+}
+
+func runDashboardTokenList(cmd *cobra.Command, args []string) error {
+	_, settings, err := loadDashboardAuthSettings()
+	if err != nil {
+		return err
+	}
+	auth := settings.DashboardAuth
+	if !auth.HasCredentials() {
+		fmt.Println(style.Dim.Render("No dashboard tokens or client certificates configured"))
+		return nil
+	}
+	for _, t := range auth.Tokens {
+		fmt.Printf("token  %-20s %-9s %s\n", t.Name, t.Role, t.CreatedAt)
+	}
+	cns := make([]string, 0, len(auth.ClientCerts))
+	for cn := range auth.ClientCerts {
+		cns = append(cns, cn)
+	}
+	sort.Strings(cns)
+	for _, cn := range cns {
+		fmt.Printf("cert   %-20s %s\n", cn, auth.ClientCerts[cn])
+	}
+	if auth.Required {
+		fmt.Println(style.Dim.Render("Authentication is required on all binds"))
+	}
+	return nil
+}
+
+func runDashboardTokenRevoke(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	townRoot, settings, err := loadDashboardAuthSettings()
+	if err != nil {
+		return err
+	}
+	tokens := settings.DashboardAuth.Tokens
+	kept := tokens[:0]
+	for _, t := range tokens {
+		if t.Name != name {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) == len(tokens) {
+		return fmt.Errorf("no dashboard token named %q", name)
+	}
+	settings.DashboardAuth.Tokens = kept
+	if err := saveDashboardAuthSettings(townRoot, settings); err != nil {
+		return err
+	}
+	fmt.Printf("%s Revoked token %q (restart gt dashboard to apply)\n", style.SuccessPrefix, name)
+	return nil
+}
+
+func runDashboardCertIssue(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid certificate name %q: must be a plain file name", name)
+	}
+	role, err := web.ParseRole(dashboardCertRole)
+	if err != nil {
+		return err
+	}
+	townRoot, settings, err := loadDashboardAuthSettings()
+	if err != nil {
+		return err
+	}
+	ca, err := proxy.LoadOrGenerateCA(dashboardCADir(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town CA: %w", err)
+	}
+	certPEM, keyPEM, err := ca.IssueClient(name, dashboardCertTTL)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dashboardCertOut, 0700); err != nil {
+		return fmt.Errorf("creating output dir: %w", err)
+	}
+	certPath := filepath.Join(dashboardCertOut, name+".crt")
+	keyPath := filepath.Join(dashboardCertOut, name+".key")
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil { //nolint:gosec // G306: certificates are public
+		return fmt.Errorf("writing certificate: %w", err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return fmt.Errorf("writing key: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dashboardCertOut, "ca.crt"), ca.CertPEM, 0644); err != nil { //nolint:gosec // G306: CA certificate is public
+		return fmt.Errorf("writing CA certificate: %w", err)
+	}
+
+	if settings.DashboardAuth.ClientCerts == nil {
+		settings.DashboardAuth.ClientCerts = make(map[string]string)
+	}
+	settings.DashboardAuth.ClientCerts[name] = role.String()
+	if err := saveDashboardAuthSettings(townRoot, settings); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Issued %s certificate %q\n", style.SuccessPrefix, role, name)
+	fmt.Printf("  cert: %s\n  key:  %s\n", certPath, keyPath)
+	fmt.Printf("  curl --cacert %s --cert %s --key %s https://host:8080/api/v1/rigs\n",
+		filepath.Join(dashboardCertOut, "ca.crt"), certPath, keyPath)
+	return nil
+}
+
+func runDashboardCertRevoke(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	townRoot, settings, err := loadDashboardAuthSettings()
+	if err != nil {
+		return err
+	}
+	if _, ok := settings.DashboardAuth.ClientCerts[name]; !ok {
+		return fmt.Errorf("no dashboard client certificate named %q", name)
+	}
+	delete(settings.DashboardAuth.ClientCerts, name)
+	if err := saveDashboardAuthSettings(townRoot, settings); err != nil {
+		return err
+	}
+	fmt.Printf("%s Removed dashboard role for certificate %q (restart gt dashboard to apply)\n", style.SuccessPrefix, name)
+	return nil
+}
+
+// dashboardCADir is the town CA directory shared with gt-proxy-server.
+func dashboardCADir(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "ca")
+}
+
+// isLoopbackBind reports whether bind only accepts local connections.
+func isLoopbackBind(bind string) bool {
+	if bind == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(bind)
+	return ip != nil && ip.IsLoopback()
+}
+
+// dashboardTLSConfig builds a TLS config whose server certificate is issued
+// by the town CA. Client certificates from the same CA are verified when
+// presented and mapped to roles by web.DashboardAuth.
+func dashboardTLSConfig(townRoot, bind string, extraHosts []string) (*tls.Config, error) {
+	ca, err := proxy.LoadOrGenerateCA(dashboardCADir(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading town CA: %w", err)
+	}
+
+	ips := []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	dnsNames := []string{}
+	if ip := net.ParseIP(bind); ip != nil && !ip.IsUnspecified() && !ip.IsLoopback() {
+		ips = append(ips, ip)
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		dnsNames = append(dnsNames, hostname)
+	}
+	for _, h := range extraHosts {
+		h = strings.TrimSpace(h)
+		if ip := net.ParseIP(h); ip != nil {
+			ips = append(ips, ip)
+		} else if h != "" {
+			dnsNames = append(dnsNames, h)
+		}
+	}
+
+	certPEM, keyPEM, err := ca.IssueServer("localhost", ips, dnsNames, 365*24*time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("issuing dashboard server cert: %w", err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("loading dashboard server cert: %w", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
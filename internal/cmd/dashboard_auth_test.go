@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func TestIsLoopbackBind(t *testing.T) {
+	cases := map[string]bool{
+		"127.0.0.1": true,
+		"localhost": true,
+		"::1":       true,
+		"0.0.0.0":   false,
+		"10.0.0.5":  false,
+		"":          false,
+	}
+	for bind, want := range cases {
+		if got := isLoopbackBind(bind); got != want {
+			t.Errorf("isLoopbackBind(%q) = %v, want %v", bind, got, want)
+		}
+	}
+}
+
+func TestDashboardTLSConfig(t *testing.T) {
+	townRoot := t.TempDir()
+	cfg, err := dashboardTLSConfig(townRoot, "10.1.2.3", []string{"dash.example", "192.168.1.9"})
+	if err != nil {
+		t.Fatalf("dashboardTLSConfig: %v", err)
+	}
+	if cfg.ClientAuth != tls.VerifyClientCertIfGiven || cfg.ClientCAs == nil {
+		t.Errorf("client auth not configured: %v", cfg.ClientAuth)
+	}
+	leaf := cfg.Certificates[0].Leaf
+	if leaf == nil {
+		t.Fatal("server certificate leaf not parsed")
+	}
+	if err := leaf.VerifyHostname("dash.example"); err != nil {
+		t.Errorf("extra DNS name missing: %v", err)
+	}
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "192.168.1.9"} {
+		if err := leaf.VerifyHostname(ip); err != nil {
+			t.Errorf("IP SAN %s missing: %v", ip, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -12,11 +13,16 @@ import (
 
 var (
 	doctorFix             bool
+	doctorApply           bool
+	doctorPlan            bool
 	doctorVerbose         bool
 	doctorRig             string
 	doctorRestartSessions bool
 	doctorNoStart         bool
 	doctorSlow            string
+	doctorFormat          string
+	doctorJobs            int
+	doctorTimeout         time.Duration
 )
 
 var doctorCmd = &cobra.Command{
@@ -114,13 +120,29 @@ Patrol checks:
 
 Use --fix to attempt automatic fixes for issues that support it.
 Use --no-start with --fix to suppress starting the daemon and agents.
+Use --plan to preview fixes without applying them: each fixable check lists
+the files, beads, sessions, and processes it would change (a check that
+can't list its changes is marked [not previewable]).
+Use --apply (or --fix) to apply fixes; the pre-fix state of every changed
+config file is recorded in an undo journal, and the run ID is printed so a
+bad fix can be rolled back with 'gt doctor undo <run-id>'.
 Use --rig to check a specific rig instead of the entire workspace.
-Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).`,
+Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).
+
+Checks run concurrently (--jobs) within their declared dependencies: when a
+prerequisite such as dolt-binary or dolt-server-reachable fails, checks that
+depend on it are skipped instead of failing noisily. Each check is bounded
+by --timeout. With --fix, checks run one at a time in registration order.
+
+Use --format json or --format junit for machine-readable output keyed by the
+stable check IDs listed above. The exit code is non-zero when any check fails.`,
 	RunE: runDoctor,
 }
 
 func init() {
 	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Attempt to automatically fix issues")
+	doctorCmd.Flags().BoolVar(&doctorApply, "apply", false, "Apply fixes and record an undo journal (same as --fix)")
+	doctorCmd.Flags().BoolVar(&doctorPlan, "plan", false, "Show the changes --fix would make without applying them")
 	doctorCmd.Flags().BoolVarP(&doctorVerbose, "verbose", "v", false, "Show detailed output")
 	doctorCmd.Flags().StringVar(&doctorRig, "rig", "", "Check specific rig only")
 	doctorCmd.Flags().BoolVar(&doctorRestartSessions, "restart-sessions", false, "Restart patrol sessions when fixing stale settings (use with --fix)")
@@ -128,6 +150,9 @@ func init() {
 	doctorCmd.Flags().StringVar(&doctorSlow, "slow", "", "Highlight slow checks (optional threshold, default 1s)")
 	// Allow --slow without a value (uses default 1s)
 	doctorCmd.Flags().Lookup("slow").NoOptDefVal = "1s"
+	doctorCmd.Flags().StringVar(&doctorFormat, "format", doctor.FormatText, "Output format: text, json, or junit")
+	doctorCmd.Flags().IntVarP(&doctorJobs, "jobs", "j", doctor.DefaultParallelism, "Number of checks to run concurrently")
+	doctorCmd.Flags().DurationVar(&doctorTimeout, "timeout", doctor.DefaultCheckTimeout, "Per-check timeout (0 disables)")
 	rootCmd.AddCommand(doctorCmd)
 }
 
@@ -147,9 +172,119 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		NoStart:         doctorNoStart,
 	}
 
+	switch doctorFormat {
+	case doctor.FormatText, doctor.FormatJSON, doctor.FormatJUnit:
+	default:
+		return fmt.Errorf("invalid --format %q: must be text, json, or junit", doctorFormat)
+	}
+
 	// Create doctor and register checks
 	d := doctor.NewDoctor()
+	d.SetParallelism(doctorJobs)
+	d.SetDefaultTimeout(doctorTimeout)
+	registerDoctorChecks(d, doctorRig)
+
+	// Parse slow threshold (0 = disabled)
+	var slowThreshold time.Duration
+	if doctorSlow != "" {
+		var err error
+		slowThreshold, err = time.ParseDuration(doctorSlow)
+		if err != nil {
+			return fmt.Errorf("invalid --slow duration %q: %w", doctorSlow, err)
+		}
+	}
+
+	// Plan mode is a dry run: run the checks, then describe the fixes
+	if doctorPlan {
+		if doctorFormat == doctor.FormatJUnit {
+			return fmt.Errorf("--plan supports --format text or json")
+		}
+		plan := d.Plan(ctx)
+		if doctorFormat == doctor.FormatJSON {
+			return plan.WriteJSON(os.Stdout)
+		}
+		plan.Print(os.Stdout)
+		return nil
+	}
+
+	applying := doctorFix || doctorApply
+	var journal *doctor.Journal
+	if applying {
+		journal = doctor.NewJournal(townRoot)
+		d.SetJournal(journal)
+	}
+
+	// Machine-readable formats skip streaming and write one document at the end
+	if doctorFormat != doctor.FormatText {
+		report, err := writeDoctorReport(os.Stdout, os.Stderr, d, ctx, applying, doctorFormat)
+		if err != nil {
+			return err
+		}
+		printUndoHint(os.Stderr, journal)
+		if report.HasErrors() {
+			return NewSilentExit(1)
+		}
+		return nil
+	}
+
+	// Run checks with streaming output
+	fmt.Println() // Initial blank line
+	var report *doctor.Report
+	if applying {
+		report = d.FixStreaming(ctx, os.Stdout, slowThreshold)
+	} else {
+		report = d.RunStreaming(ctx, os.Stdout, slowThreshold)
+	}
+
+	// Print summary (checks were already printed during streaming)
+	report.PrintSummaryOnly(os.Stdout, doctorVerbose, slowThreshold)
+	printUndoHint(os.Stdout, journal)
+
+	// Exit with error code if there are errors
+	if report.HasErrors() {
+		return fmt.Errorf("doctor found %d error(s)", report.Summary.Errors)
+	}
+
+	return nil
+}
+
+// writeDoctorReport runs (or fixes) the checks and writes a single JSON or
+// JUnit document to stdout. Anything fixes print goes to stderr so the
+// document stays parseable.
+func writeDoctorReport(stdout, stderr io.Writer, d *doctor.Doctor, ctx *doctor.CheckContext, applying bool, format string) (*doctor.Report, error) {
+	ctx.Out = stderr
+	var report *doctor.Report
+	if applying {
+		report = d.FixStreaming(ctx, nil, 0)
+	} else {
+		report = d.RunStreaming(ctx, nil, 0)
+	}
+	var err error
+	if format == doctor.FormatJSON {
+		err = report.WriteJSON(stdout)
+	} else {
+		err = report.WriteJUnit(stdout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("writing %s report: %w", format, err)
+	}
+	return report, nil
+}
 
+// printUndoHint tells the user how to roll back a fix run, if it changed anything.
+func printUndoHint(w io.Writer, journal *doctor.Journal) {
+	if journal == nil || len(journal.Entries) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nUndo journal: %s (%d file(s) captured)\n", journal.RunID, journal.FileCount())
+	fmt.Fprintf(w, "  Roll back with: gt doctor undo %s\n", journal.RunID)
+}
+
+// registerDoctorChecks registers the standard health checks in run order.
+// Registration order is the tiebreaker among checks whose dependencies are
+// satisfied, and the exact order used by --fix. Rig-specific checks are
+// added only when rig is non-empty.
+func registerDoctorChecks(d *doctor.Doctor, rig string) {
 	// Register workspace-level checks first (fundamental)
 	d.RegisterAll(doctor.WorkspaceChecks()...)
 
@@ -161,6 +296,8 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	// 2. bd binary exists
 	// 3. dolt binary exists
 	// 4. Dolt server is reachable (everything downstream depends on this)
+	// Checks that need bd/dolt declare these IDs via CheckDependsOn, so they
+	// are skipped rather than run against a broken backend.
 	d.Register(doctor.NewStaleBinaryCheck())
 	d.Register(doctor.NewBeadsBinaryCheck())
 	d.Register(doctor.NewDoltBinaryCheck())
@@ -185,7 +322,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewOverlayHealthCheck())
 	d.Register(doctor.NewPrefixConflictCheck())
 	d.Register(doctor.NewRigNameMismatchCheck())
-	d.Register(doctor.NewRigConfigSyncCheck()) // Check all registered rigs have config.json
+	d.Register(doctor.NewRigConfigSyncCheck())      // Check all registered rigs have config.json
 	d.Register(doctor.NewStaleDoltPortCheck())      // Check for stale Dolt port files
 	d.Register(doctor.NewStaleSQLServerInfoCheck()) // Check for stale sql-server.info files (GH#2770)
 	d.Register(doctor.NewPrefixMismatchCheck())
@@ -236,6 +373,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewDeprecatedMergeQueueKeysCheck())
 	d.Register(doctor.NewLandWorktreeGitignoreCheck())
 	d.Register(doctor.NewHooksPathAllRigsCheck())
+	d.Register(doctor.NewSandboxCheck())
 
 	// Sparse checkout migration (runs across all rigs, not just --rig mode)
 	d.Register(doctor.NewSparseCheckoutCheck())
@@ -274,36 +412,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewWorktreeGitdirCheck())
 
 	// Rig-specific checks (only when --rig is specified)
-	if doctorRig != "" {
+	if rig != "" {
 		d.RegisterAll(doctor.RigChecks()...)
 	}
-
-	// Parse slow threshold (0 = disabled)
-	var slowThreshold time.Duration
-	if doctorSlow != "" {
-		var err error
-		slowThreshold, err = time.ParseDuration(doctorSlow)
-		if err != nil {
-			return fmt.Errorf("invalid --slow duration %q: %w", doctorSlow, err)
-		}
-	}
-
-	// Run checks with streaming output
-	fmt.Println() // Initial blank line
-	var report *doctor.Report
-	if doctorFix {
-		report = d.FixStreaming(ctx, os.Stdout, slowThreshold)
-	} else {
-		report = d.RunStreaming(ctx, os.Stdout, slowThreshold)
-	}
-
-	// Print summary (checks were already printed during streaming)
-	report.PrintSummaryOnly(os.Stdout, doctorVerbose, slowThreshold)
-
-	// Exit with error code if there are errors
-	if report.HasErrors() {
-		return fmt.Errorf("doctor found %d error(s)", report.Summary.Errors)
-	}
-
-	return nil
 }
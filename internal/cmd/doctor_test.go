@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doctor"
+)
+
+// Check IDs are part of the --format json/junit contract, so they must be
+// unique, kebab-case, and every declared dependency must resolve.
+func TestRegisterDoctorChecks_StableIDs(t *testing.T) {
+	d := doctor.NewDoctor()
+	registerDoctorChecks(d, "gastown")
+
+	idPattern := regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	seen := make(map[string]bool)
+	for _, check := range d.Checks() {
+		id := check.Name()
+		if !idPattern.MatchString(id) {
+			t.Errorf("check ID %q is not kebab-case", id)
+		}
+		if seen[id] {
+			t.Errorf("duplicate check ID %q", id)
+		}
+		seen[id] = true
+	}
+
+	for _, check := range d.Checks() {
+		dd, ok := check.(interface{ DependsOn() []string })
+		if !ok {
+			continue
+		}
+		for _, dep := range dd.DependsOn() {
+			if !seen[dep] {
+				t.Errorf("check %q depends on unregistered check %q", check.Name(), dep)
+			}
+		}
+	}
+}
+
+// gt doctor --plan can only preview a fix when the check implements
+// FixPlanner, so every fixable check registered here must implement it.
+func TestRegisterDoctorChecks_FixableChecksPlan(t *testing.T) {
+	d := doctor.NewDoctor()
+	registerDoctorChecks(d, "gastown")
+
+	for _, check := range d.Checks() {
+		if !check.CanFix() {
+			continue
+		}
+		if _, ok := check.(doctor.FixPlanner); !ok {
+			t.Errorf("fixable check %q does not implement doctor.FixPlanner", check.Name())
+		}
+	}
+}
+
+// Fixes print progress as they go; with --format json|junit that must not
+// end up in the document on stdout.
+func TestWriteDoctorReport_FixKeepsStdoutParseable(t *testing.T) {
+	for _, format := range []string{doctor.FormatJSON, doctor.FormatJUnit} {
+		t.Run(format, func(t *testing.T) {
+			townRoot := t.TempDir()
+			runtimeDir := filepath.Join(townRoot, "gastown", "polecats", "toast", ".runtime")
+			if err := os.MkdirAll(runtimeDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			// PID well above pid_max, so the lock is stale and Fix removes it.
+			lockData := `{"pid":999999999,"acquired_at":"2026-01-01T00:00:00Z"}`
+			if err := os.WriteFile(filepath.Join(runtimeDir, "agent.lock"), []byte(lockData), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			d := doctor.NewDoctor()
+			d.Register(doctor.NewIdentityCollisionCheck())
+			ctx := &doctor.CheckContext{TownRoot: townRoot}
+
+			var stdout, stderr bytes.Buffer
+			if _, err := writeDoctorReport(&stdout, &stderr, d, ctx, true, format); err != nil {
+				t.Fatalf("writeDoctorReport: %v", err)
+			}
+
+			if !strings.Contains(stderr.String(), "Cleaned 1 stale lock(s)") {
+				t.Errorf("expected fix output on stderr, got %q", stderr.String())
+			}
+			var doc any
+			var err error
+			if format == doctor.FormatJSON {
+				err = json.Unmarshal(stdout.Bytes(), &doc)
+			} else {
+				err = xml.Unmarshal(stdout.Bytes(), &doc)
+			}
+			if err != nil {
+				t.Errorf("stdout is not valid %s: %v\n%s", format, err, stdout.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doctor"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var doctorUndoForce bool
+
+var doctorUndoCmd = &cobra.Command{
+	Use:   "undo [run-id]",
+	Short: "Roll back the file changes made by a gt doctor --fix run",
+	Long: `Restore files changed by a gt doctor --fix/--apply run to their pre-fix state.
+
+Every fix run records an undo journal under .runtime/doctor/journal/.
+Undo processes fixes newest-first. For each file it:
+  - restores the previous content, or
+  - deletes the file if the fix created it.
+
+A file modified again since the fix is reported as a conflict and left
+alone. Use --force to overwrite it anyway.
+
+Closed or created beads, killed sessions, and removed databases cannot be
+rolled back. They are listed so you can handle them by hand.
+
+With no run ID, lists recent fix runs.
+
+Examples:
+  gt doctor undo                          # List fix runs
+  gt doctor undo 20261016-142233-a1b2     # Roll back one run
+  gt doctor undo 20261016-142233-a1b2 --force`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDoctorUndo,
+}
+
+func init() {
+	doctorUndoCmd.Flags().BoolVar(&doctorUndoForce, "force", false, "Overwrite files modified since the fix, and allow undoing a run twice")
+	doctorCmd.AddCommand(doctorUndoCmd)
+}
+
+func runDoctorUndo(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	if len(args) == 0 {
+		return listDoctorJournals(townRoot)
+	}
+
+	journal, err := doctor.LoadJournal(townRoot, args[0])
+	if err != nil {
+		return err
+	}
+	result, err := journal.Undo(doctorUndoForce)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range result.Restored {
+		fmt.Printf("  %s restored %s\n", style.Success.Render("✓"), path)
+	}
+	for _, path := range result.Removed {
+		fmt.Printf("  %s removed  %s\n", style.Success.Render("✓"), path)
+	}
+	for _, path := range result.Conflicts {
+		fmt.Printf("  %s skipped  %s %s\n", style.Warning.Render("!"), path, style.Dim.Render("(modified since the fix; use --force)"))
+	}
+	for _, ch := range result.Irreversible {
+		fmt.Printf("  %s cannot undo %s %s %s\n", style.Warning.Render("!"), ch.Action, ch.Kind, ch.Target)
+	}
+
+	fmt.Printf("\nUndid run %s: %d restored, %d removed", journal.RunID, len(result.Restored), len(result.Removed))
+	if len(result.Conflicts) > 0 {
+		fmt.Printf(", %d conflict(s)", len(result.Conflicts))
+	}
+	fmt.Println()
+	if len(result.Conflicts) > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+func listDoctorJournals(townRoot string) error {
+	journals, err := doctor.ListJournals(townRoot)
+	if err != nil {
+		return fmt.Errorf("listing undo journals: %w", err)
+	}
+	if len(journals) == 0 {
+		fmt.Println("No gt doctor fix runs recorded.")
+		return nil
+	}
+	fmt.Printf("%s\n\n", style.Bold.Render("Doctor fix runs"))
+	for _, j := range journals {
+		checks := make([]string, 0, len(j.Entries))
+		for _, e := range j.Entries {
+			checks = append(checks, e.Check)
+		}
+		line := fmt.Sprintf("  %s  %s  %d file(s)", j.RunID, j.StartedAt.Local().Format("2006-01-02 15:04"), j.FileCount())
+		if j.UndoneAt != nil {
+			line += style.Dim.Render("  (undone)")
+		}
+		fmt.Println(line)
+		if len(checks) > 0 {
+			fmt.Printf("    %s\n", style.Dim.Render(strings.Join(checks, ", ")))
+		}
+	}
+	return nil
+}
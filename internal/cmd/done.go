@@ -25,6 +25,7 @@ import (
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/townlog"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var doneCmd = &cobra.Command{
@@ -89,6 +90,14 @@ func init() {
 
 func runDone(cmd *cobra.Command, args []string) (retErr error) {
 	defer func() { telemetry.RecordDone(context.Background(), strings.ToUpper(doneStatus), retErr) }()
+	// gt done joins the bead's lifecycle trace (TRACEPARENT from the polecat
+	// session); its span context is recorded on the MR bead for the refinery.
+	traceCtx := context.Background()
+	if cmd != nil && cmd.Context() != nil {
+		traceCtx = cmd.Context()
+	}
+	traceCtx, doneSpan := telemetry.StartSpan(traceCtx, "gt.done", attribute.String("gt.exit", strings.ToUpper(doneStatus)))
+	defer func() { telemetry.EndSpan(doneSpan, retErr) }()
 	// Guard: Only polecats should call gt done
 	// Crew, deacons, witnesses etc. don't use gt done - they persist across tasks.
 	// Polecat sessions end with gt done — the session is cleaned up, but the
@@ -983,6 +992,9 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 			description += "\nretry_count: 0"
 			description += "\nlast_conflict_sha: null"
 			description += "\nconflict_task_id: null"
+			if tp := telemetry.TraceParent(traceCtx); tp != "" {
+				description += "\ntrace_parent: " + tp
+			}
 
 			// Phase 3: Add pre-verification metadata if polecat ran gates after rebasing.
 			// The refinery uses these fields to fast-path merge without re-running gates.
@@ -45,6 +45,8 @@ WORKFLOW:
 CONFIGURATION:
   Routing is configured in ~/gt/settings/escalation.json:
   - routes: Map severity to action lists (bead, mail:mayor, email:human, sms:human)
+    Use email:oncall, sms:oncall, or mail:@overseer to page the on-call
+    overseer (see gt overseer)
   - contacts: Human email/SMS for external notifications
   - stale_threshold: When unacked escalations are re-escalated (default: 4h)
   - max_reescalations: How many times to bump severity (default: 2)
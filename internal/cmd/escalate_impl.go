@@ -79,6 +79,9 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Printf("  Actions: %s\n", strings.Join(actions, ", "))
 		fmt.Printf("  Mail targets: %s\n", strings.Join(targets, ", "))
+		if onCall := onCallOverseerAddress(townRoot); onCall != "" {
+			fmt.Printf("  On call: %s\n", onCall)
+		}
 		return nil
 	}
 
@@ -91,6 +94,7 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 		EscalatedBy: agentID,
 		EscalatedAt: time.Now().Format(time.RFC3339),
 		RelatedBead: escalateRelatedBead,
+		OnCall:      onCallOverseerAddress(townRoot),
 	}
 
 	issue, err := bd.CreateEscalationBead(description, fields)
@@ -607,6 +611,9 @@ func runEscalateShow(cmd *cobra.Command, args []string) error {
 	if fields.Reason != "" {
 		fmt.Printf("  Reason: %s\n", fields.Reason)
 	}
+	if fields.OnCall != "" {
+		fmt.Printf("  On call: %s\n", fields.OnCall)
+	}
 	if fields.AckedBy != "" {
 		fmt.Printf("  Acknowledged by: %s at %s\n", fields.AckedBy, fields.AckedAt)
 	}
@@ -645,50 +652,59 @@ func executeExternalActions(actions []string, cfg *config.EscalationConfig, bead
 	for _, action := range actions {
 		switch {
 		case strings.HasPrefix(action, "email:"):
-			status := deliveryStatus{Channel: "email", Target: strings.TrimPrefix(action, "email:"), Severity: severity}
-			if cfg.Contacts.HumanEmail == "" {
-				status.Warning = "contacts.human_email not configured"
-				style.PrintWarning("email action '%s' skipped: contacts.human_email not configured in settings/escalation.json", action)
-			} else if cfg.Contacts.SMTPHost == "" {
-				status.Warning = "contacts.smtp_host not configured"
-				style.PrintWarning("email action '%s' skipped: contacts.smtp_host not configured in settings/escalation.json", action)
+			target := strings.TrimPrefix(action, "email:")
+			status := deliveryStatus{Channel: "email", Target: target, Severity: severity}
+			to, warning := escalationRecipient(cfg, townRoot, "email", target)
+			if warning == "" && cfg.Contacts.SMTPHost == "" {
+				warning = "contacts.smtp_host not configured in settings/escalation.json"
+			}
+			if warning != "" {
+				status.Warning = warning
+				style.PrintWarning("email action '%s' skipped: %s", action, warning)
 			} else {
-				if err := sendEscalationEmail(cfg, beadID, severity, description); err != nil {
+				if err := sendEscalationEmail(cfg, to, beadID, severity, description); err != nil {
 					status.Error = err.Error()
 					style.PrintWarning("email send failed: %v", err)
 				} else {
 					status.RuntimeNotified = true
-					fmt.Printf("  📧 Email sent to %s\n", cfg.Contacts.HumanEmail)
+					fmt.Printf("  📧 Email sent to %s\n", to)
 				}
 			}
 			statuses = append(statuses, status)
 
 		case strings.HasPrefix(action, "sms:"):
-			status := deliveryStatus{Channel: "sms", Target: strings.TrimPrefix(action, "sms:"), Severity: severity}
-			if cfg.Contacts.HumanSMS == "" {
-				status.Warning = "contacts.human_sms not configured"
-				style.PrintWarning("sms action '%s' skipped: contacts.human_sms not configured in settings/escalation.json", action)
-			} else if cfg.Contacts.SMSWebhook == "" {
-				status.Warning = "contacts.sms_webhook not configured"
-				style.PrintWarning("sms action '%s' skipped: contacts.sms_webhook not configured in settings/escalation.json", action)
+			target := strings.TrimPrefix(action, "sms:")
+			status := deliveryStatus{Channel: "sms", Target: target, Severity: severity}
+			to, warning := escalationRecipient(cfg, townRoot, "sms", target)
+			if warning == "" && cfg.Contacts.SMSWebhook == "" {
+				warning = "contacts.sms_webhook not configured in settings/escalation.json"
+			}
+			if warning != "" {
+				status.Warning = warning
+				style.PrintWarning("sms action '%s' skipped: %s", action, warning)
 			} else {
-				if err := sendEscalationSMS(cfg, beadID, severity, description); err != nil {
+				if err := sendEscalationSMS(cfg, to, beadID, severity, description); err != nil {
 					status.Error = err.Error()
 					style.PrintWarning("sms send failed: %v", err)
 				} else {
 					status.RuntimeNotified = true
-					fmt.Printf("  📱 SMS sent to %s\n", cfg.Contacts.HumanSMS)
+					fmt.Printf("  📱 SMS sent to %s\n", to)
 				}
 			}
 			statuses = append(statuses, status)
 
-		case action == "slack":
+		case action == "slack" || strings.HasPrefix(action, "slack:"):
+			target := strings.TrimPrefix(strings.TrimPrefix(action, "slack"), ":")
 			status := deliveryStatus{Channel: "slack", Target: "slack", Severity: severity}
-			if cfg.Contacts.SlackWebhook == "" {
-				status.Warning = "contacts.slack_webhook not configured"
-				style.PrintWarning("slack action skipped: contacts.slack_webhook not configured in settings/escalation.json")
+			if target != "" {
+				status.Target = target
+			}
+			webhook, warning := escalationRecipient(cfg, townRoot, "slack", target)
+			if warning != "" {
+				status.Warning = warning
+				style.PrintWarning("slack action '%s' skipped: %s", action, warning)
 			} else {
-				if err := sendEscalationSlack(cfg, beadID, severity, description); err != nil {
+				if err := sendEscalationSlack(webhook, beadID, severity, description); err != nil {
 					status.Error = err.Error()
 					style.PrintWarning("slack post failed: %v", err)
 				} else {
@@ -713,8 +729,72 @@ func executeExternalActions(actions []string, cfg *config.EscalationConfig, bead
 	return statuses
 }
 
+// escalationRecipient resolves the destination of an email, sms, or slack
+// action: an address, phone number, or webhook URL. Target "oncall" is the
+// on-call overseer and an overseer handle is that overseer, both from
+// mayor/overseers.json. "human" is also the on-call overseer when the town
+// has a roster, and the town-wide contacts in settings/escalation.json when
+// it does not. No target, or a target that is not on the roster, uses the
+// contacts too. When the destination is not configured it returns a warning
+// instead.
+func escalationRecipient(cfg *config.EscalationConfig, townRoot, channel, target string) (string, string) {
+	var member *config.OverseerMember
+	switch target {
+	case "":
+	case "human":
+		roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot))
+		switch {
+		case errors.Is(err, config.ErrNotFound):
+		case err != nil:
+			return "", fmt.Sprintf("no on-call overseer: %v", err)
+		default:
+			if member, err = roster.OnCall(time.Now()); err != nil {
+				return "", fmt.Sprintf("no on-call overseer: %v", err)
+			}
+		}
+	default:
+		roster, err := config.LoadTownOverseers(townRoot)
+		switch {
+		case target == "oncall" && err != nil:
+			return "", fmt.Sprintf("no on-call overseer: %v", err)
+		case target == "oncall":
+			if member, err = roster.OnCall(time.Now()); err != nil {
+				return "", fmt.Sprintf("no on-call overseer: %v", err)
+			}
+		case err == nil:
+			member = roster.Member(target)
+		}
+	}
+
+	if member != nil {
+		dest, field := member.Email, "email"
+		switch channel {
+		case "sms":
+			dest, field = member.SMS, "sms"
+		case "slack":
+			dest, field = member.SlackWebhook, "slack_webhook"
+		}
+		if dest == "" {
+			return "", fmt.Sprintf("overseer %s has no %s in mayor/overseers.json", member.Handle, field)
+		}
+		return dest, ""
+	}
+
+	dest, field := cfg.Contacts.HumanEmail, "human_email"
+	switch channel {
+	case "sms":
+		dest, field = cfg.Contacts.HumanSMS, "human_sms"
+	case "slack":
+		dest, field = cfg.Contacts.SlackWebhook, "slack_webhook"
+	}
+	if dest == "" {
+		return "", fmt.Sprintf("contacts.%s not configured in settings/escalation.json", field)
+	}
+	return dest, ""
+}
+
 // sendEscalationEmail sends an escalation notification via SMTP.
-func sendEscalationEmail(cfg *config.EscalationConfig, beadID, severity, description string) error {
+func sendEscalationEmail(cfg *config.EscalationConfig, to, beadID, severity, description string) error {
 	host := cfg.Contacts.SMTPHost
 	port := cfg.Contacts.SMTPPort
 	if port == "" {
@@ -724,7 +804,6 @@ func sendEscalationEmail(cfg *config.EscalationConfig, beadID, severity, descrip
 	if from == "" {
 		from = "gastown@localhost"
 	}
-	to := cfg.Contacts.HumanEmail
 	subject := fmt.Sprintf("[Gas Town %s] %s", strings.ToUpper(severity), description)
 
 	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n"+
@@ -747,7 +826,7 @@ func sendEscalationEmail(cfg *config.EscalationConfig, beadID, severity, descrip
 }
 
 // sendEscalationSlack posts an escalation notification to a Slack webhook.
-func sendEscalationSlack(cfg *config.EscalationConfig, beadID, severity, description string) error {
+func sendEscalationSlack(webhook, beadID, severity, description string) error {
 	severityEmoji := map[string]string{
 		"critical": "🔴",
 		"high":     "🟠",
@@ -767,7 +846,7 @@ func sendEscalationSlack(cfg *config.EscalationConfig, beadID, severity, descrip
 		return fmt.Errorf("marshaling slack payload: %w", err)
 	}
 
-	resp, err := http.Post(cfg.Contacts.SlackWebhook, "application/json", strings.NewReader(string(body)))
+	resp, err := http.Post(webhook, "application/json", strings.NewReader(string(body)))
 	if err != nil {
 		return fmt.Errorf("posting to slack: %w", err)
 	}
@@ -781,9 +860,9 @@ func sendEscalationSlack(cfg *config.EscalationConfig, beadID, severity, descrip
 }
 
 // sendEscalationSMS posts an escalation notification via SMS webhook (e.g. Twilio).
-func sendEscalationSMS(cfg *config.EscalationConfig, beadID, severity, description string) error {
+func sendEscalationSMS(cfg *config.EscalationConfig, to, beadID, severity, description string) error {
 	payload := map[string]string{
-		"to":   cfg.Contacts.HumanSMS,
+		"to":   to,
 		"body": fmt.Sprintf("[Gas Town %s] %s (bead: %s)", strings.ToUpper(severity), description, beadID),
 	}
 	body, err := json.Marshal(payload)
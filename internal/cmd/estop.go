@@ -1,19 +1,25 @@
 // Emergency stop (gt estop / gt thaw) — pause and resume agent work.
 //
-// Original implementation by outdoorsea (PR #3237). Cherry-picked for
-// manual-only operation: no daemon auto-trigger.
+// Original implementation by outdoorsea (PR #3237). Automatic triggers
+// (mass death, pressure, crash loops, quota, cost rate) live in the daemon's
+// auto_estop patrol; this file handles the manual commands and status view.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
-	"path/filepath"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/estop"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -21,9 +27,12 @@ import (
 )
 
 var (
-	estopReason string
-	estopRig    string
-	thawRig     string
+	estopReason     string
+	estopRig        string
+	estopRole       string
+	thawRig         string
+	thawRole        string
+	estopStatusJSON bool
 )
 
 var estopCmd = &cobra.Command{
@@ -41,16 +50,40 @@ Use --rig to freeze a single rig instead of the whole town. Per-rig
 E-stop is useful when traveling or pausing non-critical work while
 keeping other rigs running.
 
-To resume: gt thaw [--rig <name>]
+Use --role to freeze only one kind of agent (witness, refinery, polecat,
+crew). Combined with --rig it freezes that role in one rig; on its own it
+freezes that role in every rig.
+
+While a scope is frozen the daemon does not restart or health-check its
+agents, the scheduler holds work for it, and gt sling refuses to dispatch
+into it.
+
+To resume: gt thaw [--rig <name>] [--role <role>]
+To see what is frozen: gt estop status
 
 Examples:
   gt estop                              # Freeze everything
   gt estop -r "closing laptop"          # Freeze with reason
   gt estop --rig gastown                # Freeze only gastown
-  gt estop --rig beads -r "maintenance" # Freeze beads rig`,
+  gt estop --rig beads -r "maintenance" # Freeze beads rig
+  gt estop --rig gastown --role polecat # Freeze gastown's polecats
+  gt estop --role refinery              # Freeze every refinery`,
 	RunE: runEstop,
 }
 
+var estopStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List frozen E-stop scopes and why",
+	Long: `List every active E-stop scope (town, rig, role) with its trigger,
+reason, age and, for automatic E-stops, the evidence that tripped it.
+
+Examples:
+  gt estop status
+  gt estop status --json`,
+	Args: cobra.NoArgs,
+	RunE: runEstopStatus,
+}
+
 var thawCmd = &cobra.Command{
 	Use:     "thaw",
 	GroupID: GroupServices,
@@ -61,28 +94,81 @@ Sends SIGCONT to all frozen sessions, removes the ESTOP sentinel file,
 and nudges all sessions to alert them that work can continue.
 
 Examples:
-  gt thaw                    # Thaw everything
-  gt thaw --rig gastown      # Thaw only gastown`,
+  gt thaw                               # Thaw everything
+  gt thaw --rig gastown                 # Thaw only gastown
+  gt thaw --rig gastown --role polecat  # Thaw gastown's polecats`,
 	RunE: runThaw,
 }
 
 func init() {
 	estopCmd.Flags().StringVarP(&estopReason, "reason", "r", "", "Reason for the E-stop")
 	estopCmd.Flags().StringVar(&estopRig, "rig", "", "Freeze only this rig (instead of all)")
+	estopCmd.Flags().StringVar(&estopRole, "role", "", "Freeze only this role (witness, refinery, polecat, crew)")
+	estopStatusCmd.Flags().BoolVar(&estopStatusJSON, "json", false, "Output as JSON")
 	thawCmd.Flags().StringVar(&thawRig, "rig", "", "Thaw only this rig (instead of all)")
+	thawCmd.Flags().StringVar(&thawRole, "role", "", "Thaw only this role (witness, refinery, polecat, crew)")
+	estopCmd.AddCommand(estopStatusCmd)
 	rootCmd.AddCommand(estopCmd)
 	rootCmd.AddCommand(thawCmd)
 }
 
+// estopRoles are the roles that can be frozen with --role. Town-level roles
+// (mayor, deacon, overseer) are either exempt or covered by a town E-stop.
+var estopRoles = []session.Role{
+	session.RoleWitness,
+	session.RoleRefinery,
+	session.RolePolecat,
+	session.RoleCrew,
+}
+
+// estopScopeFromFlags builds and validates an E-stop scope from --rig/--role.
+// The rig name ends up in a sentinel file name, so it must be a plain name
+// of a registered rig.
+func estopScopeFromFlags(townRoot, rigName, role string) (estop.Scope, error) {
+	if rigName != "" {
+		if strings.ContainsAny(rigName, `/\@`) || strings.Contains(rigName, "..") {
+			return estop.Scope{}, fmt.Errorf("invalid --rig %q: must be a plain rig name", rigName)
+		}
+		rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+		if err != nil {
+			return estop.Scope{}, fmt.Errorf("loading rigs config: %w", err)
+		}
+		if _, ok := rigsConfig.Rigs[rigName]; !ok {
+			return estop.Scope{}, fmt.Errorf("unknown --rig %q: not a registered rig", rigName)
+		}
+	}
+	if role != "" && !slices.Contains(estopRoles, session.Role(role)) {
+		return estop.Scope{}, fmt.Errorf("invalid --role %q (want one of: witness, refinery, polecat, crew)", role)
+	}
+	return estop.Scope{Rig: rigName, Role: role}, nil
+}
+
+// thawCommandFor returns the gt thaw invocation that clears a scope.
+func thawCommandFor(scope estop.Scope) string {
+	cmd := "gt thaw"
+	if scope.Rig != "" {
+		cmd += " --rig " + scope.Rig
+	}
+	if scope.Role != "" {
+		cmd += " --role " + scope.Role
+	}
+	return cmd
+}
+
 func runEstop(cmd *cobra.Command, args []string) error {
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
-	// Per-rig E-stop
-	if estopRig != "" {
-		return runEstopRig(townRoot, estopRig)
+	scope, err := estopScopeFromFlags(townRoot, estopRig, estopRole)
+	if err != nil {
+		return err
+	}
+
+	// Rig- or role-scoped E-stop
+	if !scope.IsTown() {
+		return runEstopScope(townRoot, scope)
 	}
 
 	if estop.IsActive(townRoot) {
@@ -99,6 +185,9 @@ func runEstop(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create ESTOP file: %w", err)
 	}
 
+	_ = events.LogFeed(events.TypeEstop, "gt",
+		events.EstopPayload("town", estop.TriggerManual, "", estopReason, nil))
+
 	fmt.Printf("%s EMERGENCY STOP\n", style.Error.Render("⛔"))
 	if estopReason != "" {
 		fmt.Printf("   Reason: %s\n", estopReason)
@@ -112,7 +201,7 @@ func runEstop(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	frozen := freezeAllSessions(t, townRoot, "")
+	frozen := freezeAllSessions(t, townRoot, estop.Scope{})
 
 	fmt.Println()
 	fmt.Printf("%s %d session(s) frozen\n", style.Error.Render("⛔"), frozen)
@@ -121,21 +210,25 @@ func runEstop(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runEstopRig(townRoot, rigName string) error {
-	if estop.IsRigActive(townRoot, rigName) {
-		info := estop.ReadRig(townRoot, rigName)
+func runEstopScope(townRoot string, scope estop.Scope) error {
+	label := scope.String()
+	if estop.IsScopeActive(townRoot, scope) {
+		info := estop.ReadScope(townRoot, scope)
 		if info != nil {
 			fmt.Printf("%s E-stop already active for %s (triggered %s: %s)\n",
-				style.Error.Render("⛔"), rigName, info.Trigger, info.Reason)
+				style.Error.Render("⛔"), label, info.Trigger, info.Reason)
 		}
 		return nil
 	}
 
-	if err := estop.ActivateRig(townRoot, rigName, estop.TriggerManual, estopReason); err != nil {
-		return fmt.Errorf("failed to create ESTOP file for %s: %w", rigName, err)
+	if err := estop.ActivateScope(townRoot, scope, estop.TriggerManual, estopReason); err != nil {
+		return fmt.Errorf("failed to create ESTOP file for %s: %w", label, err)
 	}
 
-	fmt.Printf("%s EMERGENCY STOP: %s\n", style.Error.Render("⛔"), style.Bold.Render(rigName))
+	_ = events.LogFeed(events.TypeEstop, "gt",
+		events.EstopPayload(label, estop.TriggerManual, "", estopReason, nil))
+
+	fmt.Printf("%s EMERGENCY STOP: %s\n", style.Error.Render("⛔"), style.Bold.Render(label))
 	if estopReason != "" {
 		fmt.Printf("   Reason: %s\n", estopReason)
 	}
@@ -146,11 +239,11 @@ func runEstopRig(townRoot, rigName string) error {
 		return nil
 	}
 
-	frozen := freezeAllSessions(t, townRoot, rigName)
+	frozen := freezeAllSessions(t, townRoot, scope)
 
 	fmt.Println()
-	fmt.Printf("%s %d session(s) frozen in %s\n", style.Error.Render("⛔"), frozen, rigName)
-	fmt.Printf("   Resume with: %s\n", style.Bold.Render("gt thaw --rig "+rigName))
+	fmt.Printf("%s %d session(s) frozen in %s\n", style.Error.Render("⛔"), frozen, label)
+	fmt.Printf("   Resume with: %s\n", style.Bold.Render(thawCommandFor(scope)))
 
 	return nil
 }
@@ -161,9 +254,14 @@ func runThaw(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
-	// Per-rig thaw
-	if thawRig != "" {
-		return runThawRig(townRoot, thawRig)
+	scope, err := estopScopeFromFlags(townRoot, thawRig, thawRole)
+	if err != nil {
+		return err
+	}
+
+	// Rig- or role-scoped thaw
+	if !scope.IsTown() {
+		return runThawScope(townRoot, scope)
 	}
 
 	if !estop.IsActive(townRoot) {
@@ -175,10 +273,10 @@ func runThaw(cmd *cobra.Command, args []string) error {
 
 	t := tmux.NewTmux()
 	if t.IsAvailable() {
-		thawed := thawAllSessions(t, townRoot, "")
+		thawed := thawAllSessions(t, townRoot, estop.Scope{})
 		fmt.Printf("%s %d session(s) resumed\n", style.Success.Render("✓"), thawed)
 
-		nudged := nudgeAllSessions(t, townRoot, "")
+		nudged := nudgeAllSessions(t, townRoot, estop.Scope{})
 		if nudged > 0 {
 			fmt.Printf("   Nudged %d session(s)\n", nudged)
 		}
@@ -187,6 +285,7 @@ func runThaw(cmd *cobra.Command, args []string) error {
 	if err := estop.Deactivate(townRoot, false); err != nil {
 		return fmt.Errorf("failed to remove ESTOP file: %w", err)
 	}
+	_ = events.LogFeed(events.TypeThaw, "gt", thawPayload("town", info))
 
 	if info != nil {
 		duration := time.Since(info.Timestamp).Round(time.Second)
@@ -196,37 +295,118 @@ func runThaw(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runThawRig(townRoot, rigName string) error {
-	if !estop.IsRigActive(townRoot, rigName) {
-		fmt.Printf("No E-stop active for %s.\n", rigName)
+func runThawScope(townRoot string, scope estop.Scope) error {
+	label := scope.String()
+	if !estop.IsScopeActive(townRoot, scope) {
+		fmt.Printf("No E-stop active for %s.\n", label)
 		return nil
 	}
 
-	info := estop.ReadRig(townRoot, rigName)
+	info := estop.ReadScope(townRoot, scope)
 
 	t := tmux.NewTmux()
 	if t.IsAvailable() {
-		thawed := thawAllSessions(t, townRoot, rigName)
-		fmt.Printf("%s %d session(s) resumed in %s\n", style.Success.Render("✓"), thawed, rigName)
+		thawed := thawAllSessions(t, townRoot, scope)
+		fmt.Printf("%s %d session(s) resumed in %s\n", style.Success.Render("✓"), thawed, label)
 
-		nudged := nudgeAllSessions(t, townRoot, rigName)
+		nudged := nudgeAllSessions(t, townRoot, scope)
 		if nudged > 0 {
 			fmt.Printf("   Nudged %d session(s)\n", nudged)
 		}
 	}
 
-	if err := estop.DeactivateRig(townRoot, rigName); err != nil {
-		return fmt.Errorf("failed to remove ESTOP file for %s: %w", rigName, err)
+	if err := estop.DeactivateScope(townRoot, scope); err != nil {
+		return fmt.Errorf("failed to remove ESTOP file for %s: %w", label, err)
 	}
+	_ = events.LogFeed(events.TypeThaw, "gt", thawPayload(label, info))
 
 	if info != nil {
 		duration := time.Since(info.Timestamp).Round(time.Second)
-		fmt.Printf("   E-stop for %s was active for %s\n", rigName, duration)
+		fmt.Printf("   E-stop for %s was active for %s\n", label, duration)
 	}
 
 	return nil
 }
 
+// estopScopeStatus is the JSON form of one active E-stop in gt estop status.
+type estopScopeStatus struct {
+	Scope    string    `json:"scope"`
+	Rig      string    `json:"rig,omitempty"`
+	Role     string    `json:"role,omitempty"`
+	Trigger  string    `json:"trigger"`
+	Rule     string    `json:"rule,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+	Since    time.Time `json:"since"`
+	Evidence []string  `json:"evidence,omitempty"`
+}
+
+// collectEstopStatus returns every active E-stop scope with its details.
+func collectEstopStatus(townRoot string) []estopScopeStatus {
+	var out []estopScopeStatus
+	for _, scope := range estop.ActiveScopes(townRoot) {
+		info := estop.ReadScope(townRoot, scope)
+		if info == nil {
+			continue
+		}
+		out = append(out, estopScopeStatus{
+			Scope:    scope.String(),
+			Rig:      scope.Rig,
+			Role:     scope.Role,
+			Trigger:  info.Trigger,
+			Rule:     info.Rule,
+			Reason:   info.Reason,
+			Since:    info.Timestamp,
+			Evidence: info.Evidence,
+		})
+	}
+	return out
+}
+
+func runEstopStatus(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	scopes := collectEstopStatus(townRoot)
+
+	if estopStatusJSON {
+		if scopes == nil {
+			scopes = []estopScopeStatus{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(scopes)
+	}
+
+	if len(scopes) == 0 {
+		fmt.Println("No E-stop active.")
+		return nil
+	}
+
+	fmt.Printf("%s %d E-stop scope(s) frozen:\n\n", style.Error.Render("⛔"), len(scopes))
+	for _, s := range scopes {
+		info := &estop.Info{Trigger: s.Trigger, Rule: s.Rule, Evidence: s.Evidence}
+		age := time.Since(s.Since).Round(time.Second)
+		fmt.Printf("  %s (%s, %s ago", style.Bold.Render(s.Scope), estopTriggerLabel(info), age)
+		if s.Reason != "" {
+			fmt.Printf(": %s", s.Reason)
+		}
+		fmt.Println(")")
+		printEstopEvidence(info)
+		fmt.Printf("     %s\n", style.Dim.Render("resume: "+thawCommandFor(estop.Scope{Rig: s.Rig, Role: s.Role})))
+	}
+	return nil
+}
+
+// thawPayload builds the thaw event payload for a cleared E-stop.
+func thawPayload(scope string, info *estop.Info) map[string]interface{} {
+	if info == nil {
+		return events.EstopPayload(scope, estop.TriggerManual, "", "", nil)
+	}
+	return events.EstopPayload(scope, info.Trigger, info.Rule, "thawed by gt thaw", nil)
+}
+
 // exemptSessions are sessions that should NOT be frozen during E-stop.
 var exemptSessions = map[string]bool{
 	session.MayorSessionName():    true,
@@ -235,23 +415,18 @@ var exemptSessions = map[string]bool{
 
 // freezeAllSessions sends SIGTSTP to all Gas Town agent sessions via
 // process-group signaling. Mayor and overseer sessions are exempt.
-// If rigFilter is non-empty, only sessions for that rig are frozen.
-func freezeAllSessions(t *tmux.Tmux, townRoot string, rigFilter string) int {
+// Only sessions covered by scope are frozen.
+func freezeAllSessions(t *tmux.Tmux, townRoot string, scope estop.Scope) int {
 	sessions := collectGTSessions(t, townRoot)
 	frozen := 0
 
-	var rigPrefix string
-	if rigFilter != "" {
-		rigPrefix = session.PrefixFor(rigFilter)
-	}
-
 	for _, sess := range sessions {
 		if exemptSessions[sess] {
 			fmt.Printf("   %s %s (exempt)\n", style.Dim.Render("⏭"), sess)
 			continue
 		}
 
-		if rigFilter != "" && !isRigSession(sess, rigPrefix) {
+		if !sessionInScope(sess, scope) {
 			continue
 		}
 
@@ -267,21 +442,16 @@ func freezeAllSessions(t *tmux.Tmux, townRoot string, rigFilter string) int {
 }
 
 // thawAllSessions sends SIGCONT to all Gas Town agent sessions.
-// If rigFilter is non-empty, only sessions for that rig are thawed.
-func thawAllSessions(t *tmux.Tmux, townRoot string, rigFilter string) int {
+// Only sessions covered by scope are thawed.
+func thawAllSessions(t *tmux.Tmux, townRoot string, scope estop.Scope) int {
 	sessions := collectGTSessions(t, townRoot)
 	thawed := 0
 
-	var rigPrefix string
-	if rigFilter != "" {
-		rigPrefix = session.PrefixFor(rigFilter)
-	}
-
 	for _, sess := range sessions {
 		if exemptSessions[sess] {
 			continue
 		}
-		if rigFilter != "" && !isRigSession(sess, rigPrefix) {
+		if !sessionInScope(sess, scope) {
 			continue
 		}
 		if err := signalSessionGroup(t, sess, syscall.SIGCONT); err != nil {
@@ -294,21 +464,16 @@ func thawAllSessions(t *tmux.Tmux, townRoot string, rigFilter string) int {
 }
 
 // nudgeAllSessions sends a nudge to all GT sessions to alert them of resume.
-// If rigFilter is non-empty, only sessions for that rig are nudged.
-func nudgeAllSessions(t *tmux.Tmux, townRoot string, rigFilter string) int {
+// Only sessions covered by scope are nudged.
+func nudgeAllSessions(t *tmux.Tmux, townRoot string, scope estop.Scope) int {
 	sessions := collectGTSessions(t, townRoot)
 	nudged := 0
 
-	var rigPrefix string
-	if rigFilter != "" {
-		rigPrefix = session.PrefixFor(rigFilter)
-	}
-
 	for _, sess := range sessions {
 		if exemptSessions[sess] {
 			continue
 		}
-		if rigFilter != "" && !isRigSession(sess, rigPrefix) {
+		if !sessionInScope(sess, scope) {
 			continue
 		}
 		if err := t.NudgeSession(sess, "E-stop cleared. Work may resume."); err == nil {
@@ -319,6 +484,21 @@ func nudgeAllSessions(t *tmux.Tmux, townRoot string, rigFilter string) int {
 	return nudged
 }
 
+// sessionInScope reports whether an E-stop scope covers a session. The
+// town-wide scope covers every session.
+func sessionInScope(sess string, scope estop.Scope) bool {
+	if scope.Rig != "" && !isRigSession(sess, session.PrefixFor(scope.Rig)) {
+		return false
+	}
+	if scope.Role != "" {
+		id, err := session.ParseSessionName(sess)
+		if err != nil || string(id.Role) != scope.Role {
+			return false
+		}
+	}
+	return true
+}
+
 // isRigSession checks if a session name belongs to a specific rig prefix.
 func isRigSession(name, rigPrefix string) bool {
 	return strings.HasPrefix(name, rigPrefix+"-") || name == rigPrefix
@@ -392,30 +572,78 @@ func addEstopToStatus(townRoot string) {
 		info := estop.Read(townRoot)
 		if info != nil {
 			age := time.Since(info.Timestamp).Round(time.Second)
-			fmt.Printf("%s  E-STOP ACTIVE (%s, %s ago", style.Error.Render("⛔"), info.Trigger, age)
+			fmt.Printf("%s  E-STOP ACTIVE (%s, %s ago", style.Error.Render("⛔"), estopTriggerLabel(info), age)
 			if info.Reason != "" {
 				fmt.Printf(": %s", info.Reason)
 			}
 			fmt.Println(")")
+			printEstopEvidence(info)
 			fmt.Println()
 		}
 	}
 
-	// Check for per-rig E-stops
-	entries, _ := filepath.Glob(filepath.Join(townRoot, "ESTOP.*"))
-	for _, entry := range entries {
-		rigName := strings.TrimPrefix(filepath.Base(entry), "ESTOP.")
-		info := estop.ReadRig(townRoot, rigName)
-		if info != nil {
-			age := time.Since(info.Timestamp).Round(time.Second)
-			fmt.Printf("%s  E-STOP: %s (%s, %s ago", style.Error.Render("⏸"), rigName, info.Trigger, age)
-			if info.Reason != "" {
-				fmt.Printf(": %s", info.Reason)
-			}
-			fmt.Println(")")
+	// Check for rig- and role-scoped E-stops
+	scoped := 0
+	for _, scope := range estop.ActiveScopes(townRoot) {
+		if scope.IsTown() {
+			continue
+		}
+		info := estop.ReadScope(townRoot, scope)
+		if info == nil {
+			continue
+		}
+		scoped++
+		age := time.Since(info.Timestamp).Round(time.Second)
+		fmt.Printf("%s  E-STOP: %s (%s, %s ago", style.Error.Render("⏸"), scope, estopTriggerLabel(info), age)
+		if info.Reason != "" {
+			fmt.Printf(": %s", info.Reason)
 		}
+		fmt.Println(")")
+		printEstopEvidence(info)
 	}
-	if len(entries) > 0 {
+	if scoped > 0 {
 		fmt.Println()
 	}
 }
+
+// checkRigNotEstopped returns an error if an E-stop freezes polecats in
+// rigName, so new work is not slung into a frozen rig.
+func checkRigNotEstopped(townRoot, rigName string) error {
+	scope, stopped := estop.StoppedBy(townRoot, rigName, string(RolePolecat))
+	if !stopped {
+		return nil
+	}
+	return fmt.Errorf("cannot sling to e-stopped rig %q (E-stop: %s)\n%s", rigName, scope, thawCommandFor(scope))
+}
+
+// agentEstopInfo returns the E-stop that freezes the agent running in this
+// process (identified by GT_RIG and GT_ROLE), or nil if none does.
+func agentEstopInfo(townRoot string) *estop.Info {
+	role, rigName, _ := parseRoleString(os.Getenv("GT_ROLE"))
+	if rigName == "" {
+		rigName = os.Getenv("GT_RIG")
+	}
+	scope, stopped := estop.StoppedBy(townRoot, rigName, string(role))
+	if !stopped {
+		return nil
+	}
+	if info := estop.ReadScope(townRoot, scope); info != nil {
+		return info
+	}
+	return &estop.Info{Trigger: estop.TriggerManual}
+}
+
+// estopTriggerLabel returns "manual", "auto", or "auto:<rule>".
+func estopTriggerLabel(info *estop.Info) string {
+	if info.Rule != "" {
+		return info.Trigger + ":" + info.Rule
+	}
+	return info.Trigger
+}
+
+// printEstopEvidence lists the evidence recorded for an auto-triggered E-stop.
+func printEstopEvidence(info *estop.Info) {
+	for _, e := range info.Evidence {
+		fmt.Printf("     %s\n", style.Dim.Render(e))
+	}
+}
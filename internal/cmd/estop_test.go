@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/estop"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func TestSessionInScope(t *testing.T) {
+	originalRegistry := session.DefaultRegistry()
+	t.Cleanup(func() { session.SetDefaultRegistry(originalRegistry) })
+
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	reg.Register("bd", "beads")
+	session.SetDefaultRegistry(reg)
+
+	tests := []struct {
+		sess  string
+		scope estop.Scope
+		want  bool
+	}{
+		{"gt-furiosa", estop.Scope{}, true},
+		{"hq-deacon", estop.Scope{}, true},
+		{"gt-furiosa", estop.Scope{Rig: "gastown"}, true},
+		{"bd-witness", estop.Scope{Rig: "gastown"}, false},
+		{"gt-furiosa", estop.Scope{Rig: "gastown", Role: "polecat"}, true},
+		{"gt-witness", estop.Scope{Rig: "gastown", Role: "polecat"}, false},
+		{"gt-crew-max", estop.Scope{Rig: "gastown", Role: "polecat"}, false},
+		{"bd-furiosa", estop.Scope{Rig: "gastown", Role: "polecat"}, false},
+		{"gt-refinery", estop.Scope{Role: "refinery"}, true},
+		{"bd-refinery", estop.Scope{Role: "refinery"}, true},
+		{"bd-witness", estop.Scope{Role: "refinery"}, false},
+		{"hq-deacon", estop.Scope{Role: "refinery"}, false},
+	}
+	for _, tt := range tests {
+		if got := sessionInScope(tt.sess, tt.scope); got != tt.want {
+			t.Errorf("sessionInScope(%q, %s) = %v, want %v", tt.sess, tt.scope, got, tt.want)
+		}
+	}
+}
+
+func TestEstopScopeFromFlags(t *testing.T) {
+	townRoot := t.TempDir()
+	rigsConfig := &config.RigsConfig{Version: 1, Rigs: map[string]config.RigEntry{"gastown": {}}}
+	if err := config.SaveRigsConfig(constants.MayorRigsPath(townRoot), rigsConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	scope, err := estopScopeFromFlags(townRoot, "gastown", "polecat")
+	if err != nil {
+		t.Fatalf("estopScopeFromFlags: %v", err)
+	}
+	if scope != (estop.Scope{Rig: "gastown", Role: "polecat"}) {
+		t.Errorf("scope = %+v", scope)
+	}
+	if got := thawCommandFor(scope); got != "gt thaw --rig gastown --role polecat" {
+		t.Errorf("thawCommandFor = %q", got)
+	}
+
+	// The Mayor is exempt from E-stop, so it can't be frozen by role.
+	if _, err := estopScopeFromFlags(townRoot, "", "mayor"); err == nil {
+		t.Error("expected error for --role mayor")
+	}
+
+	// The rig name becomes part of a sentinel file name.
+	for _, rigName := range []string{"beads", "../gastown", "gastown/..", `a\b`, "gastown@polecat", ".."} {
+		if _, err := estopScopeFromFlags(townRoot, rigName, ""); err == nil {
+			t.Errorf("expected error for --rig %q", rigName)
+		}
+	}
+}
+
+func TestCollectEstopStatus(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if got := collectEstopStatus(townRoot); len(got) != 0 {
+		t.Fatalf("collectEstopStatus = %v, want none", got)
+	}
+
+	if err := estop.ActivateRigAuto(townRoot, "gastown", "crash_loop", "witness crash-looping", []string{"gastown/witness restarted 5x"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := estop.ActivateScope(townRoot, estop.Scope{Rig: "beads", Role: "polecat"}, estop.TriggerManual, "maintenance"); err != nil {
+		t.Fatal(err)
+	}
+
+	got := collectEstopStatus(townRoot)
+	if len(got) != 2 {
+		t.Fatalf("collectEstopStatus returned %d scopes, want 2: %+v", len(got), got)
+	}
+	if got[0].Scope != "beads/polecat" || got[0].Role != "polecat" || got[0].Reason != "maintenance" {
+		t.Errorf("got[0] = %+v, want beads/polecat maintenance", got[0])
+	}
+	if got[1].Scope != "gastown" || got[1].Rule != "crash_loop" || len(got[1].Evidence) != 1 {
+		t.Errorf("got[1] = %+v, want gastown crash_loop with evidence", got[1])
+	}
+}
+
+// TestExecuteSling_EstoppedRig verifies that executeSling refuses to dispatch
+// into a rig whose polecats are E-stopped.
+func TestExecuteSling_EstoppedRig(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, ".beads"), 0o755); err != nil {
+		t.Fatalf("failed to create .beads: %v", err)
+	}
+
+	rigName := "testrig"
+	if err := estop.ActivateScope(townRoot, estop.Scope{Rig: rigName, Role: "polecat"}, estop.TriggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := executeSling(SlingParams{
+		BeadID:   "test-123",
+		RigName:  rigName,
+		TownRoot: townRoot,
+	})
+	if err == nil {
+		t.Fatal("expected error when slinging to e-stopped rig, got nil")
+	}
+	if result.ErrMsg != "rig e-stopped" {
+		t.Errorf("expected ErrMsg='rig e-stopped', got %q", result.ErrMsg)
+	}
+	if !strings.Contains(err.Error(), "gt thaw --rig testrig --role polecat") {
+		t.Errorf("error should name the thaw command, got: %s", err)
+	}
+}
+
+func TestCheckRigNotEstopped(t *testing.T) {
+	townRoot := t.TempDir()
+
+	// Freezing only the refinery leaves polecat dispatch open.
+	if err := estop.ActivateScope(townRoot, estop.Scope{Rig: "gastown", Role: "refinery"}, estop.TriggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkRigNotEstopped(townRoot, "gastown"); err != nil {
+		t.Errorf("refinery-only E-stop should not block sling: %v", err)
+	}
+
+	if err := estop.Activate(townRoot, estop.TriggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkRigNotEstopped(townRoot, "gastown"); err == nil {
+		t.Error("town-wide E-stop should block sling")
+	}
+}
@@ -426,7 +426,7 @@ func runHandoffAuto() error {
 	// Log handoff event
 	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
 		agent := detectSender()
-		if agent == "" || agent == "overseer" {
+		if agent == "" || config.IsOverseerAddress(agent) {
 			agent = "unknown"
 		}
 		_ = events.LogFeed(events.TypeHandoff, agent, events.HandoffPayload(subject, false))
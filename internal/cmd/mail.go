@@ -86,6 +86,8 @@ ADDRESS FORMATS:
   <rig>/<polecat>     → Polecat (e.g., greenplace/Toast)
   <rig>/crew/<name>   → Crew worker (e.g., greenplace/crew/max)
   --human             → Special: human overseer
+  overseer/<handle>   → One human on the overseer roster
+  @overseer           → Whoever is on call (see gt overseer)
 
 COMMANDS:
   inbox     View your inbox
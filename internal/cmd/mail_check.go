@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/steveyegge/gastown/internal/estop"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/nudge"
 	"github.com/steveyegge/gastown/internal/style"
@@ -73,12 +72,11 @@ func runMailCheck(cmd *cobra.Command, args []string) error {
 	// checked before going idle (prevents mail from sitting unread).
 	if mailCheckInject {
 		// Agent-side E-stop check (defense-in-depth).
-		// If an E-stop is active (town-wide or per-rig), inject a system reminder
+		// If an E-stop is active (town-wide or scoped to this agent), inject a system reminder
 		// telling the agent to checkpoint and wait. This catches agents that
 		// survived the SIGTSTP freeze.
 		if townRoot, twErr := workspace.FindFromCwd(); twErr == nil {
-			rigName := os.Getenv("GT_RIG")
-			if estop.IsActive(townRoot) || (rigName != "" && estop.IsRigActive(townRoot, rigName)) {
+			if agentEstopInfo(townRoot) != nil {
 				fmt.Print("<system-reminder>\n")
 				fmt.Print("EMERGENCY STOP ACTIVE. All work is paused.\n")
 				fmt.Print("Do NOT start new tasks or tool calls. Checkpoint your current state\n")
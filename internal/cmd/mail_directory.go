@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -107,7 +108,14 @@ func runMailDirectory(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// 5. Well-known addresses
+	// 5. Overseer mailboxes (towns with an overseer roster)
+	if roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot)); err == nil {
+		for _, m := range roster.Overseers {
+			entries = append(entries, DirectoryEntry{Address: config.OverseerMemberAddress(m.Handle), Type: "overseer"})
+		}
+	}
+
+	// 6. Well-known addresses
 	wellKnown := []DirectoryEntry{
 		{Address: "mayor/", Type: "well-known"},
 		{Address: "--human", Type: "well-known"},
@@ -116,6 +124,7 @@ func runMailDirectory(cmd *cobra.Command, args []string) error {
 		{Address: "@crew", Type: "special"},
 		{Address: "@witnesses", Type: "special"},
 		{Address: "@overseer", Type: "special"},
+		{Address: "@overseers", Type: "special"},
 	}
 	entries = append(entries, wellKnown...)
 
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -80,7 +81,8 @@ func findLocalBeadsDir() (string, error) {
 // Priority:
 //  1. GT_ROLE env var → use the role-based identity (agent session)
 //  2. No GT_ROLE → try cwd-based detection (witness/refinery/polecat/crew directories)
-//  3. No match → return "overseer" (human at terminal)
+//  3. No match → return "overseer" (human at terminal), or "overseer/<handle>"
+//     when the town has an overseer roster that identifies the human
 //
 // All Gas Town agents run in tmux sessions with GT_ROLE set at spawn.
 // However, cwd-based detection is also tried to support running commands
@@ -159,7 +161,7 @@ func detectSenderFromRole(role string) string {
 func detectSenderFromCwd() string {
 	cwd, err := os.Getwd()
 	if err != nil {
-		return "overseer"
+		return config.OverseerAddress
 	}
 
 	// Prefer explicit agent identity metadata when available.
@@ -223,7 +225,7 @@ func detectSenderFromCwd() string {
 	}
 
 	// Default to overseer (human)
-	return "overseer"
+	return currentOverseerAddress("")
 }
 
 type agentIdentityFile struct {
@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/protocol"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -99,6 +100,15 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	// Set CC recipients
 	msg.CC = mailCC
 
+	// Protocol mail (MERGED, MERGE_READY, ...) carries a JSON envelope for
+	// the receiving handler. Hand-written bodies get one here, and are
+	// refused if they lack fields the handler requires.
+	if protocol.IsProtocolMessage(msg.Subject) {
+		if err := protocol.Seal(msg); err != nil {
+			return fmt.Errorf("%s: %w", msg.Subject, err)
+		}
+	}
+
 	// Suppress router-side notification when --no-notify is passed.
 	// Otherwise the router handles idle-aware notification per-recipient,
 	// which also works correctly for fan-out (groups, lists, channels).
@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/channelevents"
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -50,7 +51,9 @@ Events are JSON files in ~/gt/events/<channel>/*.event:
 
 BEHAVIOR:
 1. Check for already-pending events (return immediately if found)
-2. If none, poll the directory until a new .event file appears or timeout
+2. If none, wait for the channel's events on the event bus (served by the
+   daemon, or followed in-process when it is not running) until a new
+   .event file appears or timeout
 3. On wake, return all pending event file paths and contents
 4. With --cleanup, delete processed event files automatically
 
@@ -128,8 +131,7 @@ func runMoleculeAwaitEvent(cmd *cobra.Command, args []string) error {
 		home, _ := os.UserHomeDir()
 		townRoot = filepath.Join(home, "gt")
 	}
-	eventDir := filepath.Join(townRoot, "events", awaitEventChannel)
-	if err := os.MkdirAll(eventDir, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Join(townRoot, "events", awaitEventChannel), 0755); err != nil {
 		return fmt.Errorf("creating event directory: %w", err)
 	}
 
@@ -198,7 +200,7 @@ func runMoleculeAwaitEvent(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	result, err := waitForEventFiles(ctx, eventDir)
+	result, err := waitForEventFiles(ctx, townRoot, awaitEventChannel)
 	if err != nil {
 		return fmt.Errorf("event watch failed: %w", err)
 	}
@@ -320,9 +322,53 @@ func calculateEventTimeout(idleCycles int) (time.Duration, error) {
 	return time.ParseDuration(awaitEventTimeout)
 }
 
-// waitForEventFiles checks for pending events, then polls until events appear or timeout.
-// Uses a polling loop instead of inotifywait for cross-platform compatibility.
-func waitForEventFiles(ctx context.Context, eventDir string) (*AwaitEventResult, error) {
+// awaitEventRescan is how often waitForEventFiles rescans the channel
+// directory without a bus notification, for event files written by tools
+// that do not publish on the event bus.
+var awaitEventRescan = 5 * time.Second
+
+// subscribeChannelEvents subscribes to a channel's events on the event bus.
+// The returned channel is closed if the stream ends early. Tests replace it.
+var subscribeChannelEvents = func(ctx context.Context, townRoot, channel string) (<-chan eventbus.Event, func(), error) {
+	sub, err := eventbus.Subscribe(ctx, townRoot, eventbus.SubscribeRequest{
+		From: eventbus.FromEnd,
+		Filter: eventbus.Filter{
+			Sources:  []string{eventbus.SourceChannel},
+			Channels: []string{channel},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return sub.Events(), sub.Close, nil
+}
+
+// waitForEventFiles checks for pending events, then waits for the channel's
+// events on the event bus until event files appear or timeout.
+func waitForEventFiles(ctx context.Context, townRoot, channel string) (*AwaitEventResult, error) {
+	eventDir := filepath.Join(townRoot, "events", channel)
+
+	// Calculate remaining timeout from context
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) <= 0 {
+		events, err := readPendingEvents(eventDir)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 {
+			return &AwaitEventResult{Reason: "event", Events: events}, nil
+		}
+		return &AwaitEventResult{Reason: "timeout"}, nil
+	}
+
+	// Subscribe before the pending check so an event emitted in between
+	// still wakes us.
+	busEvents, closeSub, err := subscribeChannelEvents(ctx, townRoot, channel)
+	if err != nil {
+		return nil, fmt.Errorf("subscribing to event bus: %w", err)
+	}
+	defer closeSub()
+
 	// Check for already-pending events
 	events, err := readPendingEvents(eventDir)
 	if err != nil {
@@ -335,21 +381,8 @@ func waitForEventFiles(ctx context.Context, eventDir string) (*AwaitEventResult,
 		}, nil
 	}
 
-	// Calculate remaining timeout from context
-	deadline, ok := ctx.Deadline()
-	if !ok {
-		return &AwaitEventResult{Reason: "timeout"}, nil
-	}
-	remaining := time.Until(deadline)
-	if remaining <= 0 {
-		return &AwaitEventResult{Reason: "timeout"}, nil
-	}
-
-	// Poll with 500ms interval until event appears or timeout.
-	// This is cross-platform (no inotifywait dependency) and the 500ms
-	// latency is acceptable for the event-driven patrol use case.
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
+	rescan := time.NewTicker(awaitEventRescan)
+	defer rescan.Stop()
 
 	for {
 		select {
@@ -363,17 +396,24 @@ func waitForEventFiles(ctx context.Context, eventDir string) (*AwaitEventResult,
 				}, nil
 			}
 			return &AwaitEventResult{Reason: "timeout"}, nil
-		case <-ticker.C:
-			events, err = readPendingEvents(eventDir)
-			if err != nil {
-				return nil, err
-			}
-			if len(events) > 0 {
-				return &AwaitEventResult{
-					Reason: "event",
-					Events: events,
-				}, nil
+		case _, ok := <-busEvents:
+			if !ok {
+				// The stream ended early (e.g. it failed after the daemon
+				// dropped us). Stop selecting on it and fall back to
+				// rescanning until an event file appears or ctx is done.
+				busEvents = nil
 			}
+		case <-rescan.C:
+		}
+		events, err = readPendingEvents(eventDir)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 {
+			return &AwaitEventResult{
+				Reason: "event",
+				Events: events,
+			}, nil
 		}
 	}
 }
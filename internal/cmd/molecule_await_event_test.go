@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/channelevents"
+	"github.com/steveyegge/gastown/internal/eventbus"
 )
 
 func TestCalculateEventTimeout(t *testing.T) {
@@ -285,22 +288,21 @@ func TestValidChannelName(t *testing.T) {
 	}
 }
 
-func TestWaitForEventFilesPolling(t *testing.T) {
-	// Test that polling picks up events written after the wait starts.
-	dir := t.TempDir()
+func TestWaitForEventFilesWakesOnBusEvent(t *testing.T) {
+	// Test that an event emitted after the wait starts wakes the waiter.
+	townRoot := t.TempDir()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Write an event after a short delay in a goroutine
+	// Emit an event after a short delay in a goroutine
 	go func() {
-		time.Sleep(800 * time.Millisecond) // longer than one poll interval (500ms)
-		content := `{"type":"DELAYED_EVENT","channel":"test"}`
-		os.WriteFile(filepath.Join(dir, "delayed.event"), []byte(content), 0644)
+		time.Sleep(800 * time.Millisecond)
+		channelevents.EmitToTown(townRoot, "test", "DELAYED_EVENT", nil)
 	}()
 
 	start := time.Now()
-	result, err := waitForEventFiles(ctx, dir)
+	result, err := waitForEventFiles(ctx, townRoot, "test")
 	elapsed := time.Since(start)
 
 	if err != nil {
@@ -314,23 +316,65 @@ func TestWaitForEventFilesPolling(t *testing.T) {
 	}
 	// Should have taken at least 800ms (the delay) but less than 5s (timeout)
 	if elapsed < 700*time.Millisecond {
-		t.Errorf("polling returned too quickly (%v), event was delayed 800ms", elapsed)
+		t.Errorf("wait returned too quickly (%v), event was delayed 800ms", elapsed)
 	}
 	if elapsed > 3*time.Second {
-		t.Errorf("polling took too long (%v), expected ~1-1.5s", elapsed)
+		t.Errorf("wait took too long (%v), expected ~1s", elapsed)
+	}
+}
+
+func TestWaitForEventFilesRescansAfterBusCloses(t *testing.T) {
+	// If the bus subscription ends early, the waiter must keep rescanning
+	// the channel directory instead of sleeping until the timeout.
+	origSubscribe, origRescan := subscribeChannelEvents, awaitEventRescan
+	t.Cleanup(func() { subscribeChannelEvents, awaitEventRescan = origSubscribe, origRescan })
+	subscribeChannelEvents = func(context.Context, string, string) (<-chan eventbus.Event, func(), error) {
+		ch := make(chan eventbus.Event)
+		close(ch)
+		return ch, func() {}, nil
+	}
+	awaitEventRescan = 50 * time.Millisecond
+
+	townRoot := t.TempDir()
+	dir := filepath.Join(townRoot, "events", "test")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = os.WriteFile(filepath.Join(dir, "late.event"), []byte(`{"type":"LATE","channel":"test"}`), 0644)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	result, err := waitForEventFiles(ctx, townRoot, "test")
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Reason != "event" {
+		t.Fatalf("expected reason 'event', got %q (elapsed: %v)", result.Reason, elapsed)
+	}
+	if elapsed > 2*time.Second {
+		t.Errorf("wait took %v after the subscription closed, expected a rescan to find the event", elapsed)
 	}
 }
 
 func TestWaitForEventFilesWithPending(t *testing.T) {
 	// When events already exist, waitForEventFiles should return immediately.
-	dir := t.TempDir()
+	townRoot := t.TempDir()
+	dir := filepath.Join(townRoot, "events", "refinery")
+	os.MkdirAll(dir, 0755)
 	content := `{"type":"PATROL_WAKE","channel":"refinery"}`
 	os.WriteFile(filepath.Join(dir, "existing.event"), []byte(content), 0644)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	result, err := waitForEventFiles(ctx, dir)
+	result, err := waitForEventFiles(ctx, townRoot, "refinery")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -349,7 +393,7 @@ func TestWaitForEventFilesTimeout(t *testing.T) {
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-1*time.Second))
 	defer cancel()
 
-	result, err := waitForEventFiles(ctx, dir)
+	result, err := waitForEventFiles(ctx, dir, "test")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -362,7 +406,7 @@ func TestWaitForEventFilesNoDeadline(t *testing.T) {
 	// With a context that has no deadline, should return timeout immediately.
 	dir := t.TempDir()
 
-	result, err := waitForEventFiles(context.Background(), dir)
+	result, err := waitForEventFiles(context.Background(), dir, "test")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
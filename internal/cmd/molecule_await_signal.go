@@ -1,20 +1,16 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
-	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -33,8 +29,9 @@ var moleculeAwaitSignalCmd = &cobra.Command{
 	Short: "Wait for activity feed signal with timeout",
 	Long: `Wait for any activity on the events feed, with optional backoff.
 
-This command is the primary wake mechanism for patrol agents. It tails
-~/gt/.events.jsonl and returns immediately when a new event is appended
+This command is the primary wake mechanism for patrol agents. It subscribes
+to the event bus (~/gt/.events.jsonl) and returns immediately when a new event
+is appended
 (indicating Gas Town activity such as slings, nudges, mail, spawns, etc.).
 
 If no activity occurs within the timeout, the command returns with exit code 0
@@ -360,56 +357,34 @@ func calculateEffectiveTimeout(idleCycles int) (time.Duration, error) {
 	return time.ParseDuration(awaitSignalTimeout)
 }
 
-// waitForActivitySignal tails the events file for new activity.
-// townRoot is the Gas Town workspace root; the events file is at
-// <townRoot>/.events.jsonl. Returns immediately when a new event line is
-// appended, or when context is canceled.
+// waitForActivitySignal waits for new activity on the town's event bus.
+// Returns immediately when a new activity event is appended to
+// <townRoot>/.events.jsonl, or when context is canceled. Channel events and
+// town log lines are not activity: they have their own consumers.
 func waitForActivitySignal(ctx context.Context, townRoot string) (*AwaitSignalResult, error) {
-	return waitForEventsFile(ctx, filepath.Join(townRoot, events.EventsFile))
-}
-
-// waitForEventsFile tails the events file for new lines.
-// This replaces the former bd activity --follow subprocess approach.
-func waitForEventsFile(ctx context.Context, eventsPath string) (*AwaitSignalResult, error) {
-
-	f, err := os.OpenFile(eventsPath, os.O_RDONLY|os.O_CREATE, 0644)
+	sub, err := eventbus.Subscribe(ctx, townRoot, eventbus.SubscribeRequest{
+		From:   eventbus.FromEnd,
+		Filter: eventbus.Filter{Sources: []string{eventbus.SourceGT}},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("opening events file %s: %w", eventsPath, err)
+		return nil, fmt.Errorf("subscribing to event bus: %w", err)
 	}
-	defer f.Close()
-
-	// Seek to end — we only want new events, not historical ones
-	if _, err := f.Seek(0, 2); err != nil {
-		return nil, fmt.Errorf("seeking to end of events file: %w", err)
+	defer sub.Close()
+
+	for ev := range sub.Events() {
+		ev.Offset = 0
+		line, _ := json.Marshal(ev)
+		return &AwaitSignalResult{
+			Reason: "signal",
+			Signal: string(line),
+		}, nil
 	}
-
-	// Poll for new lines using bufio.Reader (not Scanner, which doesn't
-	// resume after EOF). Reader.ReadString properly retries the underlying
-	// file reader, picking up appended data between polls.
-	reader := bufio.NewReader(f)
-	ticker := time.NewTicker(200 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return &AwaitSignalResult{
-				Reason: "timeout",
-			}, nil
-		case <-ticker.C:
-			line, err := reader.ReadString('\n')
-			if err == nil && line != "" {
-				return &AwaitSignalResult{
-					Reason: "signal",
-					Signal: strings.TrimRight(line, "\n"),
-				}, nil
-			}
-			// io.EOF means no new data yet — keep polling
-			if err != nil && err != io.EOF {
-				return nil, fmt.Errorf("reading events file: %w", err)
-			}
-		}
+	if err := sub.Err(); err != nil {
+		return nil, fmt.Errorf("reading event bus: %w", err)
 	}
+	return &AwaitSignalResult{
+		Reason: "timeout",
+	}, nil
 }
 
 // parseIntSimple parses a string to int without using strconv.
@@ -4,8 +4,12 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/channelevents"
+	"github.com/steveyegge/gastown/internal/events"
 )
 
 func TestCalculateEffectiveTimeout(t *testing.T) {
@@ -136,13 +140,13 @@ func TestAwaitSignalResult(t *testing.T) {
 	}
 }
 
-func TestWaitForEventsFile_MissingFile(t *testing.T) {
-	// When the events file doesn't exist, waitForEventsFile creates it and
-	// waits for new events. With no events, it should return timeout.
+func TestWaitForActivitySignal_MissingFile(t *testing.T) {
+	// When the events file doesn't exist, waitForActivitySignal waits for it
+	// to appear. With no events, it should return timeout.
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result, err := waitForEventsFile(ctx, filepath.Join(t.TempDir(), "nonexistent.jsonl"))
+	result, err := waitForActivitySignal(ctx, t.TempDir())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -151,9 +155,10 @@ func TestWaitForEventsFile_MissingFile(t *testing.T) {
 	}
 }
 
-func TestWaitForEventsFile_Timeout(t *testing.T) {
-	// When no new events are appended, waitForEventsFile should return timeout.
-	eventsPath := filepath.Join(t.TempDir(), ".events.jsonl")
+func TestWaitForActivitySignal_Timeout(t *testing.T) {
+	// When no new events are appended, waitForActivitySignal should return timeout.
+	townRoot := t.TempDir()
+	eventsPath := filepath.Join(townRoot, ".events.jsonl")
 	if err := os.WriteFile(eventsPath, []byte(`{"ts":"2024-01-01","type":"test"}`+"\n"), 0644); err != nil {
 		t.Fatal(err)
 	}
@@ -161,7 +166,7 @@ func TestWaitForEventsFile_Timeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result, err := waitForEventsFile(ctx, eventsPath)
+	result, err := waitForActivitySignal(ctx, townRoot)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -170,9 +175,10 @@ func TestWaitForEventsFile_Timeout(t *testing.T) {
 	}
 }
 
-func TestWaitForEventsFile_Signal(t *testing.T) {
-	// When a new event is appended, waitForEventsFile should return signal.
-	eventsPath := filepath.Join(t.TempDir(), ".events.jsonl")
+func TestWaitForActivitySignal_Signal(t *testing.T) {
+	// When a new event is appended, waitForActivitySignal should return signal.
+	townRoot := t.TempDir()
+	eventsPath := filepath.Join(townRoot, ".events.jsonl")
 	// Write initial content (will be skipped — we seek to end)
 	if err := os.WriteFile(eventsPath, []byte(`{"ts":"old","type":"ignore"}`+"\n"), 0644); err != nil {
 		t.Fatal(err)
@@ -192,7 +198,7 @@ func TestWaitForEventsFile_Signal(t *testing.T) {
 		_, _ = f.WriteString(`{"ts":"new","type":"sling","actor":"test"}` + "\n")
 	}()
 
-	result, err := waitForEventsFile(ctx, eventsPath)
+	result, err := waitForActivitySignal(ctx, townRoot)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -204,6 +210,29 @@ func TestWaitForEventsFile_Signal(t *testing.T) {
 	}
 }
 
+func TestWaitForActivitySignal_IgnoresChannelEvents(t *testing.T) {
+	// Channel events and town log lines share the journal but are not activity.
+	townRoot := t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = channelevents.EmitToTown(townRoot, "refinery", "MERGE_READY", nil)
+		time.Sleep(200 * time.Millisecond)
+		_ = events.LogAt(townRoot, events.TypeSling, "mayor", nil, events.VisibilityFeed)
+	}()
+
+	result, err := waitForActivitySignal(ctx, townRoot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Reason != "signal" || !strings.Contains(result.Signal, `"type":"sling"`) {
+		t.Errorf("expected the sling event, got %q: %s", result.Reason, result.Signal)
+	}
+}
+
 func TestWaitForActivitySignal_PathWiring(t *testing.T) {
 	// Verify waitForActivitySignal constructs the correct events path from
 	// townRoot. The events file should be at <townRoot>/.events.jsonl.
@@ -1,18 +1,22 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // moleculeStepDoneCmd is the "gt mol step done" command.
@@ -64,7 +68,7 @@ type StepDoneResult struct {
 	Action        string   `json:"action"` // "continue", "parallel", "done", "no_more_ready"
 }
 
-func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
+func runMoleculeStepDone(cmd *cobra.Command, args []string) (retErr error) {
 	stepID := args[0]
 
 	cwd, err := os.Getwd()
@@ -95,6 +99,22 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("step not found: %w", err)
 	}
 
+	// Record the step in the bead's lifecycle trace. A step in progress has
+	// been worked on since its last update, so the span starts there.
+	stepStart := time.Now()
+	if step.Status == "in_progress" {
+		if t, err := time.Parse(time.RFC3339, step.UpdatedAt); err == nil {
+			stepStart = t
+		}
+	}
+	ctx := context.Background()
+	if cmd != nil && cmd.Context() != nil {
+		ctx = cmd.Context()
+	}
+	_, span := telemetry.StartSpanAt(ctx, "molecule.step", stepStart,
+		attribute.String("gt.step", stepID), attribute.String("gt.step.title", step.Title))
+	defer func() { telemetry.EndSpan(span, retErr) }()
+
 	// Step 2: Extract molecule ID from step ID (gt-xxx.1 -> gt-xxx)
 	// Also handle wisp format (go-wisp-xxx) by using the step's Parent field
 	moleculeID := extractMoleculeIDFromStep(stepID)
@@ -338,10 +338,14 @@ func showAllMountainStatus(townBeads string) error {
 		return enc.Encode(convoys)
 	}
 
+	// Merge history and capacity are shared by every mountain's forecast.
+	hist, _ := loadDurationHistoryFn(townBeads)
+	capacity := schedulerCapacityFn(townBeads)
+
 	fmt.Println("Active Mountains:")
 	for _, c := range convoys {
 		// Get tracked beads for progress.
-		trackedBeads, _, err := collectConvoyBeads(c.ID)
+		trackedBeads, deps, err := collectConvoyBeads(c.ID)
 		if err != nil {
 			fmt.Printf("  %s %q (error reading beads: %v)\n", c.ID, c.Title, err)
 			continue
@@ -366,6 +370,9 @@ func showAllMountainStatus(townBeads string) error {
 		bar := renderProgressBar(pct, 20)
 		fmt.Printf("  %s %q\n", c.ID, c.Title)
 		fmt.Printf("    Progress: %s %d/%d (%d%%)\n", bar, closed, total, pct)
+		if eta := formatForecast(forecastDAG(buildConvoyDAG(trackedBeads, deps), hist, capacity)); eta != "" {
+			fmt.Printf("    ETA:      %s\n", eta)
+		}
 	}
 
 	return nil
@@ -458,6 +465,9 @@ func showMountainDetail(townBeads, inputID string) error {
 
 	total := len(completed) + len(active) + len(ready) + len(skipped) + len(blocked)
 
+	hist, _ := loadDurationHistoryFn(townBeads)
+	forecast := forecastDAG(dag, hist, schedulerCapacityFn(townBeads))
+
 	if mountainJSON {
 		jsonOut := map[string]interface{}{
 			"convoy_id": convoyID,
@@ -470,6 +480,7 @@ func showMountainDetail(townBeads, inputID string) error {
 			"skipped":   len(skipped),
 			"blocked":   len(blocked),
 			"waves":     len(waves),
+			"forecast":  forecast,
 		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -485,6 +496,12 @@ func showMountainDetail(townBeads, inputID string) error {
 	fmt.Printf("Mountain: %s %q\n", convoyID, cv.Title)
 	fmt.Printf("\nProgress: %d/%d closed (%d%%)\n", len(completed), total, pct)
 	fmt.Printf("Wave: %d total\n", len(waves))
+	if eta := formatForecast(forecast); eta != "" {
+		fmt.Printf("ETA: %s\n", eta)
+		if path := formatCriticalPath(forecast); path != "" {
+			fmt.Printf("Critical path: %s\n", path)
+		}
+	}
 
 	if len(completed) > 0 {
 		sort.Strings(completed)
@@ -506,7 +523,7 @@ func showMountainDetail(townBeads, inputID string) error {
 			if node := dag.Nodes[id]; node != nil {
 				title = node.Title
 			}
-			fmt.Printf("  ⟳ %s  %s\n", id, title)
+			fmt.Printf("  ⟳ %s  %s%s\n", id, title, criticalMark(forecast, id))
 		}
 	}
 
@@ -518,7 +535,7 @@ func showMountainDetail(townBeads, inputID string) error {
 			if node := dag.Nodes[id]; node != nil {
 				title = node.Title
 			}
-			fmt.Printf("  ○ %s  %s\n", id, title)
+			fmt.Printf("  ○ %s  %s%s\n", id, title, criticalMark(forecast, id))
 		}
 	}
 
@@ -554,7 +571,7 @@ func showMountainDetail(townBeads, inputID string) error {
 					blockers = " (needs: " + strings.Join(openBlockers, ", ") + ")"
 				}
 			}
-			fmt.Printf("  ◌ %s  %s%s\n", id, title, blockers)
+			fmt.Printf("  ◌ %s  %s%s%s\n", id, title, blockers, criticalMark(forecast, id))
 		}
 	}
 
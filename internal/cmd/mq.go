@@ -196,6 +196,10 @@ var mqStatusCmd = &cobra.Command{
 Shows all MR fields, current status with timestamps, dependencies,
 blockers, and processing history.
 
+Also shows gate health for the MR's rig: each quality gate's flake rate
+over recent commits, and whether the refinery has quarantined it. A
+quarantined gate still runs, but its failures no longer block merges.
+
 Example:
   gt mq status gp-mr-abc123`,
 	Args: cobra.ExactArgs(1),
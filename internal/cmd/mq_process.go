@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ process command flags
+var (
+	mqProcessLoop bool
+)
+
+var mqProcessCmd = &cobra.Command{
+	Use:   "process [rig]",
+	Short: "Land ready merge requests through the batch pipeline",
+	Long: `Run the merge queue engine over the rig's ready merge requests.
+
+Ready MRs are grouped by target branch and merged in priority order. The
+merge_queue settings control how:
+
+  batch.max_batch_size   MRs stacked and gated together (default: 1)
+  max_concurrent         batches gated at once; above 1, later batches are
+                         gated speculatively on the predicted result of
+                         earlier ones
+
+Failing MRs are bisected out of their batch and their polecats are nudged.
+Conflicting MRs get a conflict-resolution task.
+
+With --loop the queue is re-checked every poll_interval until interrupted.
+
+Examples:
+  gt mq process gastown          # One pass over the ready queue
+  gt mq process gastown --loop   # Keep processing until Ctrl-C`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMQProcess,
+}
+
+func init() {
+	mqProcessCmd.Flags().BoolVar(&mqProcessLoop, "loop", false, "Keep processing, polling every merge_queue.poll_interval")
+
+	mqCmd.AddCommand(mqProcessCmd)
+}
+
+func runMQProcess(cmd *cobra.Command, args []string) error {
+	rigName := ""
+	if len(args) > 0 {
+		rigName = args[0]
+	}
+
+	_, r, rigName, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	cfg := eng.Config()
+	if !cfg.Enabled {
+		return fmt.Errorf("merge queue is disabled for rig '%s'", rigName)
+	}
+
+	interval := cfg.PollInterval
+	if interval <= 0 {
+		interval = refinery.DefaultMergeQueueConfig().PollInterval
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		results, err := eng.ProcessQueue(ctx)
+		if err != nil {
+			return fmt.Errorf("processing merge queue: %w", err)
+		}
+		printMQProcessSummary(results)
+
+		if !mqProcessLoop || ctx.Err() != nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func printMQProcessSummary(results []*refinery.BatchResult) {
+	var merged, culprits, conflicts, pending, failed int
+	for _, res := range results {
+		merged += len(res.Merged)
+		culprits += len(res.Culprits)
+		conflicts += len(res.Conflicts)
+		pending += len(res.Pending)
+		if res.Error != nil {
+			failed++
+		}
+	}
+	if len(results) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(no ready MRs)"))
+		return
+	}
+	fmt.Printf("%s %d batch(es): %d merged, %d failed gates, %d conflicted, %d pending",
+		style.Bold.Render("✓"), len(results), merged, culprits, conflicts, pending)
+	if failed > 0 {
+		fmt.Printf(", %d batch error(s)", failed)
+	}
+	fmt.Println()
+}
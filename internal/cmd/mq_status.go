@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/style"
 )
 
@@ -37,6 +38,9 @@ type MRStatusOutput struct {
 	// Dependencies
 	DependsOn []DependencyInfo `json:"depends_on,omitempty"`
 	Blocks    []DependencyInfo `json:"blocks,omitempty"`
+
+	// Gate health for the MR's rig, from the refinery's gate history
+	GateHealth []refinery.GateStats `json:"gate_health,omitempty"`
 }
 
 // DependencyInfo represents a dependency or blocker.
@@ -119,6 +123,10 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 		})
 	}
 
+	if mrFields != nil && mrFields.Rig != "" {
+		output.GateHealth = loadGateHealth(mrFields.Rig)
+	}
+
 	// JSON output
 	if mqStatusJSON {
 		enc := json.NewEncoder(os.Stdout)
@@ -127,11 +135,27 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Human-readable output
-	return printMqStatus(issue, mrFields)
+	return printMqStatus(issue, mrFields, output.GateHealth)
+}
+
+// loadGateHealth returns gate flake statistics for a rig, or nil if the rig
+// or its gate history can't be read.
+var loadGateHealth = func(rigName string) []refinery.GateStats {
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return nil
+	}
+	eng := refinery.NewEngineer(r)
+	_ = eng.LoadConfig() // defaults are fine if the rig config is unreadable
+	stats, err := eng.GateStats()
+	if err != nil {
+		return nil
+	}
+	return stats
 }
 
 // printMqStatus prints detailed MR status in human-readable format.
-func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
+func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields, gateHealth []refinery.GateStats) error {
 	// Header
 	fmt.Printf("%s %s\n", style.Bold.Render("📋 Merge Request:"), issue.ID)
 	fmt.Printf("   %s\n\n", issue.Title)
@@ -212,6 +236,19 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 		}
 	}
 
+	// Gate health (flake rates from the refinery's gate history)
+	if len(gateHealth) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Gate Health"))
+		for _, g := range gateHealth {
+			line := fmt.Sprintf("   %-16s %3.0f%% flaky  %s", g.Gate, g.FlakeRate*100,
+				style.Dim.Render(fmt.Sprintf("(%d of %d commits, %d failed)", g.Flaky, g.Commits, g.Failed)))
+			if g.Quarantined {
+				line += " " + style.Warning.Render("quarantined")
+			}
+			fmt.Println(line)
+		}
+	}
+
 	// Description (if present and not just MR fields)
 	desc := getDescriptionWithoutMRFields(issue.Description)
 	if desc != "" {
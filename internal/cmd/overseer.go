@@ -0,0 +1,749 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Overseer command flags
+var (
+	overseerJSON         bool
+	overseerName         string
+	overseerEmail        string
+	overseerSMS          string
+	overseerSlackWebhook string
+	overseerNext         int
+	overseerOrder        string
+	overseerShift        string
+	overseerStart        string
+	overseerUntil        string
+	overseerFor          string
+	overseerReason       string
+	overseerClear        bool
+	overseerAcksAll      bool
+)
+
+var overseerCmd = &cobra.Command{
+	Use:     "overseer",
+	GroupID: GroupComm,
+	Short:   "Manage the human overseers and who is on call",
+	RunE:    requireSubcommand,
+	Long: `Manage the humans who oversee this town and the on-call rotation.
+
+A town starts with a single overseer (mayor/overseer.json). Adding a second
+human creates a roster in mayor/overseers.json. Each overseer has a handle,
+their own mailbox at overseer/<handle>, and their own email, SMS, and Slack
+contacts.
+
+One overseer is on call at a time, chosen by a fixed-length rotation. A
+hand-off puts someone else on call until a given time (by default, the end
+of the current shift).
+
+Routing:
+  @overseer            mail to whoever is on call
+  @overseers           mail to every overseer
+  overseer/<handle>    mail to one overseer
+  email:oncall         escalation email to whoever is on call
+  sms:<handle>         escalation SMS to one overseer
+
+The human at a terminal is identified by GT_OVERSEER=<handle>, or by
+matching git user.email against the roster.
+
+Commands:
+  gt overseer list                 List the roster
+  gt overseer add <handle>         Add an overseer
+  gt overseer remove <handle>      Remove an overseer
+  gt overseer oncall               Show who is on call and the upcoming shifts
+  gt overseer rotation             Show or change the rotation
+  gt overseer handoff <handle>     Put someone else on call
+  gt overseer acks [handle]        Show escalation acknowledgements per overseer`,
+}
+
+var overseerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the overseer roster",
+	Long: `List the overseers of this town with their contacts.
+
+The overseer currently on call is marked with 📟.
+
+Examples:
+  gt overseer list
+  gt overseer list --json`,
+	Args: cobra.NoArgs,
+	RunE: runOverseerList,
+}
+
+var overseerAddCmd = &cobra.Command{
+	Use:   "add <handle>",
+	Short: "Add an overseer to the roster",
+	Long: `Add a human overseer to the roster.
+
+The handle names the overseer's mailbox (overseer/<handle>) and is used in
+escalation routes (email:<handle>, sms:<handle>). If the town has no roster
+yet, the existing overseer from mayor/overseer.json is added first.
+
+New overseers join the end of the rotation.
+
+Examples:
+  gt overseer add alice --name "Alice Ng" --email alice@example.com
+  gt overseer add bob --email bob@example.com --sms +15551234567`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOverseerAdd,
+}
+
+var overseerRemoveCmd = &cobra.Command{
+	Use:   "remove <handle>",
+	Short: "Remove an overseer from the roster",
+	Long: `Remove a human overseer from the roster and the rotation.
+
+A hand-off to the removed overseer is cancelled. Mail already in their
+mailbox is kept.
+
+Examples:
+  gt overseer remove bob`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOverseerRemove,
+}
+
+var overseerOnCallCmd = &cobra.Command{
+	Use:   "oncall",
+	Short: "Show who is on call",
+	Long: `Show the overseer on call now and the upcoming shifts.
+
+Examples:
+  gt overseer oncall
+  gt overseer oncall --next 8
+  gt overseer oncall --json`,
+	Args: cobra.NoArgs,
+	RunE: runOverseerOnCall,
+}
+
+var overseerRotationCmd = &cobra.Command{
+	Use:   "rotation",
+	Short: "Show or change the on-call rotation",
+	Long: `Show or change the on-call rotation.
+
+The rotation hands on-call duty to each overseer in order for one shift
+each, starting at --start. Without flags, shows the current rotation.
+
+Examples:
+  gt overseer rotation
+  gt overseer rotation --order alice,bob,carol,dan
+  gt overseer rotation --shift 24h --start 2026-10-19T09:00:00-07:00
+  gt overseer rotation --shift 168h --start now`,
+	Args: cobra.NoArgs,
+	RunE: runOverseerRotation,
+}
+
+var overseerHandoffCmd = &cobra.Command{
+	Use:   "handoff [handle]",
+	Short: "Hand on-call duty to another overseer",
+	Long: `Put another overseer on call, overriding the rotation.
+
+By default the hand-off lasts until the end of the current rotation shift.
+Use --until or --for to choose another end time, and --clear to cancel a
+hand-off early.
+
+Mail sent to @overseer and escalations routed to email:oncall / sms:oncall
+go to the new on-call overseer immediately.
+
+Examples:
+  gt overseer handoff bob
+  gt overseer handoff bob --for 8h --reason "dentist"
+  gt overseer handoff carol --until 2026-10-20T09:00:00Z
+  gt overseer handoff --clear`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runOverseerHandoff,
+}
+
+var overseerAcksCmd = &cobra.Command{
+	Use:   "acks [handle]",
+	Short: "Show escalation acknowledgements per overseer",
+	Long: `Show, for each overseer, the escalations raised while they were on
+call and the escalations they acknowledged.
+
+Pending escalations were raised on their shift and are still open and
+unacknowledged. Time to ack is measured from when the escalation was raised.
+
+Examples:
+  gt overseer acks
+  gt overseer acks alice
+  gt overseer acks --all         # Include closed escalations
+  gt overseer acks --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runOverseerAcks,
+}
+
+func init() {
+	overseerListCmd.Flags().BoolVar(&overseerJSON, "json", false, "Output as JSON")
+
+	overseerAddCmd.Flags().StringVar(&overseerName, "name", "", "Display name (default: handle)")
+	overseerAddCmd.Flags().StringVar(&overseerEmail, "email", "", "Email address for escalations")
+	overseerAddCmd.Flags().StringVar(&overseerSMS, "sms", "", "Phone number for SMS escalations")
+	overseerAddCmd.Flags().StringVar(&overseerSlackWebhook, "slack-webhook", "", "Personal Slack webhook for escalations")
+
+	overseerOnCallCmd.Flags().IntVar(&overseerNext, "next", 4, "Number of shifts to show")
+	overseerOnCallCmd.Flags().BoolVar(&overseerJSON, "json", false, "Output as JSON")
+
+	overseerRotationCmd.Flags().StringVar(&overseerOrder, "order", "", "Comma-separated handles in rotation order")
+	overseerRotationCmd.Flags().StringVar(&overseerShift, "shift", "", "Shift length (e.g., 24h, 168h)")
+	overseerRotationCmd.Flags().StringVar(&overseerStart, "start", "", "Start of the first shift (RFC 3339, or \"now\")")
+
+	overseerHandoffCmd.Flags().StringVar(&overseerUntil, "until", "", "End of the hand-off (RFC 3339)")
+	overseerHandoffCmd.Flags().StringVar(&overseerFor, "for", "", "Length of the hand-off (e.g., 8h)")
+	overseerHandoffCmd.Flags().StringVar(&overseerReason, "reason", "", "Why on-call is being handed off")
+	overseerHandoffCmd.Flags().BoolVar(&overseerClear, "clear", false, "Cancel the current hand-off")
+
+	overseerAcksCmd.Flags().BoolVar(&overseerAcksAll, "all", false, "Include closed escalations")
+	overseerAcksCmd.Flags().BoolVar(&overseerJSON, "json", false, "Output as JSON")
+
+	overseerCmd.AddCommand(overseerListCmd)
+	overseerCmd.AddCommand(overseerAddCmd)
+	overseerCmd.AddCommand(overseerRemoveCmd)
+	overseerCmd.AddCommand(overseerOnCallCmd)
+	overseerCmd.AddCommand(overseerRotationCmd)
+	overseerCmd.AddCommand(overseerHandoffCmd)
+	overseerCmd.AddCommand(overseerAcksCmd)
+
+	rootCmd.AddCommand(overseerCmd)
+}
+
+// OverseerListItem represents an overseer in list output.
+type OverseerListItem struct {
+	config.OverseerMember
+	Address string `json:"address"`
+	OnCall  bool   `json:"on_call"`
+}
+
+func runOverseerList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	roster, err := config.LoadTownOverseers(townRoot)
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			fmt.Println("No overseers configured.")
+			fmt.Println("\nTo add one:")
+			fmt.Println("  gt overseer add <handle> --email <address>")
+			return nil
+		}
+		return fmt.Errorf("loading overseer roster: %w", err)
+	}
+
+	onCall := ""
+	if shift, ok := roster.ShiftAt(time.Now()); ok {
+		onCall = shift.Handle
+	}
+
+	items := make([]OverseerListItem, 0, len(roster.Overseers))
+	for _, m := range roster.Overseers {
+		items = append(items, OverseerListItem{
+			OverseerMember: m,
+			Address:        config.OverseerMemberAddress(m.Handle),
+			OnCall:         m.Handle == onCall,
+		})
+	}
+
+	if overseerJSON {
+		out, _ := json.MarshalIndent(items, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Overseers:"))
+	for _, item := range items {
+		marker := "  "
+		if item.OnCall {
+			marker = "📟"
+		}
+		fmt.Printf("  %s %-12s %s\n", marker, item.Handle, item.Name)
+		var contacts []string
+		if item.Email != "" {
+			contacts = append(contacts, item.Email)
+		}
+		if item.SMS != "" {
+			contacts = append(contacts, item.SMS)
+		}
+		if item.SlackWebhook != "" {
+			contacts = append(contacts, "slack")
+		}
+		contacts = append(contacts, item.Address)
+		fmt.Printf("     %s\n", style.Dim.Render(strings.Join(contacts, " · ")))
+	}
+	if _, err := os.Stat(config.OverseerRosterPath(townRoot)); err != nil {
+		fmt.Printf("\n%s\n", style.Dim.Render("Single overseer from mayor/overseer.json; gt overseer add creates a roster."))
+	}
+	return nil
+}
+
+func runOverseerAdd(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	roster, err := loadOverseerRosterForEdit(townRoot)
+	if err != nil {
+		return err
+	}
+
+	member := config.OverseerMember{
+		Handle:       args[0],
+		Name:         overseerName,
+		Email:        overseerEmail,
+		SMS:          overseerSMS,
+		SlackWebhook: overseerSlackWebhook,
+	}
+	if member.Name == "" {
+		member.Name = member.Handle
+	}
+	if err := roster.Add(member); err != nil {
+		return err
+	}
+	if len(roster.Rotation.Order) > 0 {
+		roster.Rotation.Order = append(roster.Rotation.Order, member.Handle)
+	}
+	if roster.Rotation.Start == "" {
+		roster.Rotation.Start = startOfDay(time.Now()).Format(time.RFC3339)
+	}
+
+	if err := config.SaveOverseerRoster(config.OverseerRosterPath(townRoot), roster); err != nil {
+		return fmt.Errorf("saving overseer roster: %w", err)
+	}
+
+	fmt.Printf("%s Added overseer %s (mailbox %s)\n", style.Bold.Render("✓"), member.Handle, config.OverseerMemberAddress(member.Handle))
+	if len(roster.Overseers) > 1 {
+		fmt.Printf("  Roster: %d overseers; see %s\n", len(roster.Overseers), style.Dim.Render("gt overseer oncall"))
+	}
+	return nil
+}
+
+func runOverseerRemove(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading overseer roster: %w", err)
+	}
+	if err := roster.Remove(args[0]); err != nil {
+		return err
+	}
+	if err := config.SaveOverseerRoster(config.OverseerRosterPath(townRoot), roster); err != nil {
+		return fmt.Errorf("saving overseer roster: %w", err)
+	}
+
+	fmt.Printf("%s Removed overseer %s\n", style.Bold.Render("✓"), args[0])
+	if len(roster.Overseers) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("Roster is empty; @overseer falls back to mayor/overseer.json"))
+	}
+	return nil
+}
+
+func runOverseerOnCall(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	roster, err := config.LoadTownOverseers(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading overseer roster: %w", err)
+	}
+
+	now := time.Now()
+	shifts := roster.Schedule(now, overseerNext)
+	if len(shifts) == 0 {
+		return errors.New("overseer roster is empty")
+	}
+
+	if overseerJSON {
+		out, _ := json.MarshalIndent(shifts, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	current := shifts[0]
+	fmt.Printf("📟 %s %s", style.Bold.Render("On call:"), overseerDisplayName(roster, current.Handle))
+	fmt.Printf(" %s\n", style.Dim.Render("until "+formatShiftTime(current.End)))
+	if current.Handoff && roster.Handoff != nil {
+		note := "hand-off"
+		if roster.Handoff.From != "" {
+			note += " from " + roster.Handoff.From
+		}
+		if roster.Handoff.Reason != "" {
+			note += ": " + roster.Handoff.Reason
+		}
+		fmt.Printf("   %s\n", style.Dim.Render(note))
+	}
+
+	if len(shifts) > 1 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Upcoming:"))
+		for _, s := range shifts[1:] {
+			fmt.Printf("  %-24s → %-24s %s\n", formatShiftTime(s.Start), formatShiftTime(s.End), overseerDisplayName(roster, s.Handle))
+		}
+	}
+	return nil
+}
+
+func runOverseerRotation(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	changed := overseerOrder != "" || overseerShift != "" || overseerStart != ""
+	var roster *config.OverseerRoster
+	if changed {
+		roster, err = loadOverseerRosterForEdit(townRoot)
+	} else {
+		roster, err = config.LoadTownOverseers(townRoot)
+	}
+	if err != nil {
+		return fmt.Errorf("loading overseer roster: %w", err)
+	}
+
+	if overseerOrder != "" {
+		var order []string
+		for _, h := range strings.Split(overseerOrder, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				order = append(order, h)
+			}
+		}
+		roster.Rotation.Order = order
+	}
+	if overseerShift != "" {
+		roster.Rotation.Shift = overseerShift
+	}
+	if overseerStart == "now" {
+		roster.Rotation.Start = time.Now().UTC().Format(time.RFC3339)
+	} else if overseerStart != "" {
+		roster.Rotation.Start = overseerStart
+	}
+
+	if changed {
+		if err := config.SaveOverseerRoster(config.OverseerRosterPath(townRoot), roster); err != nil {
+			return fmt.Errorf("saving overseer roster: %w", err)
+		}
+		fmt.Printf("%s Rotation updated\n\n", style.Bold.Render("✓"))
+	}
+
+	order := roster.Rotation.Order
+	if len(order) == 0 {
+		for _, m := range roster.Overseers {
+			order = append(order, m.Handle)
+		}
+	}
+	shift := roster.Rotation.Shift
+	if shift == "" {
+		shift = config.DefaultOverseerShift.String() + " (default)"
+	}
+	start := roster.Rotation.Start
+	if start == "" {
+		start = "unset (shifts count from the Unix epoch)"
+	}
+	fmt.Printf("  Order: %s\n", strings.Join(order, " → "))
+	fmt.Printf("  Shift: %s\n", shift)
+	fmt.Printf("  Start: %s\n", start)
+	return nil
+}
+
+func runOverseerHandoff(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading overseer roster: %w", err)
+	}
+
+	now := time.Now()
+	from := ""
+	if shift, ok := roster.ShiftAt(now); ok {
+		from = shift.Handle
+	}
+
+	if overseerClear {
+		if len(args) > 0 {
+			return errors.New("--clear takes no handle")
+		}
+		if roster.Handoff == nil {
+			fmt.Println("No hand-off in effect.")
+			return nil
+		}
+		roster.Handoff = nil
+		if err := config.SaveOverseerRoster(config.OverseerRosterPath(townRoot), roster); err != nil {
+			return fmt.Errorf("saving overseer roster: %w", err)
+		}
+		to := ""
+		if shift, ok := roster.ShiftAt(now); ok {
+			to = shift.Handle
+		}
+		logOverseerHandoff(from, to, "hand-off cleared")
+		fmt.Printf("%s Hand-off cleared; %s is on call\n", style.Bold.Render("✓"), to)
+		return nil
+	}
+
+	if len(args) == 0 {
+		return errors.New("handle required (or --clear)")
+	}
+	if overseerUntil != "" && overseerFor != "" {
+		return errors.New("use --until or --for, not both")
+	}
+
+	var until time.Time
+	switch {
+	case overseerUntil != "":
+		if until, err = time.Parse(time.RFC3339, overseerUntil); err != nil {
+			return fmt.Errorf("invalid --until %q: %w", overseerUntil, err)
+		}
+	case overseerFor != "":
+		d, err := time.ParseDuration(overseerFor)
+		if err != nil {
+			return fmt.Errorf("invalid --for %q: %w", overseerFor, err)
+		}
+		until = now.Add(d)
+	}
+
+	handle := args[0]
+	if err := roster.HandOff(handle, from, now, until, overseerReason); err != nil {
+		return err
+	}
+	if err := config.SaveOverseerRoster(config.OverseerRosterPath(townRoot), roster); err != nil {
+		return fmt.Errorf("saving overseer roster: %w", err)
+	}
+
+	logOverseerHandoff(from, handle, overseerReason)
+	fmt.Printf("%s %s is on call until %s\n", style.Bold.Render("✓"), handle, roster.Handoff.Until)
+	return nil
+}
+
+// logOverseerHandoff records an on-call change on the activity feed.
+func logOverseerHandoff(from, to, reason string) {
+	payload := map[string]interface{}{
+		"from": from,
+		"to":   to,
+	}
+	if reason != "" {
+		payload["reason"] = reason
+	}
+	_ = events.LogFeed(events.TypeOverseerHandoff, currentOverseerAddress(""), payload)
+}
+
+// OverseerAckSummary is the per-overseer acknowledgement record.
+type OverseerAckSummary struct {
+	Handle     string   `json:"handle"`
+	Paged      int      `json:"paged"`                 // escalations raised while on call
+	Acked      int      `json:"acked"`                 // escalations this overseer acknowledged
+	Pending    []string `json:"pending,omitempty"`     // paged, open, unacknowledged escalation IDs
+	MedianAck  string   `json:"median_ack,omitempty"`  // median time from raise to ack, for acks by this overseer
+	LastAckAt  string   `json:"last_ack_at,omitempty"` // most recent ack by this overseer
+	ackLatency []time.Duration
+}
+
+func runOverseerAcks(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	roster, err := config.LoadTownOverseers(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading overseer roster: %w", err)
+	}
+	if len(args) > 0 && roster.Member(args[0]) == nil {
+		return fmt.Errorf("unknown overseer %q", args[0])
+	}
+
+	bd := beads.New(beads.ResolveBeadsDir(townRoot))
+	status := "--status=open"
+	if overseerAcksAll {
+		status = "--status=all"
+	}
+	out, err := bd.Run("list", "--label=gt:escalation", status, "--json")
+	if err != nil {
+		return fmt.Errorf("listing escalations: %w", err)
+	}
+	var issues []*beads.Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return fmt.Errorf("parsing escalations: %w", err)
+	}
+
+	summaries := summarizeOverseerAcks(roster, issues)
+	if len(args) > 0 {
+		for _, s := range summaries {
+			if s.Handle == args[0] {
+				summaries = []*OverseerAckSummary{s}
+				break
+			}
+		}
+	}
+
+	if overseerJSON {
+		out, _ := json.MarshalIndent(summaries, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Escalation acknowledgements:"))
+	fmt.Printf("  %-12s %6s %6s %8s %10s\n", "OVERSEER", "PAGED", "ACKED", "PENDING", "MEDIAN ACK")
+	for _, s := range summaries {
+		median := s.MedianAck
+		if median == "" {
+			median = "-"
+		}
+		fmt.Printf("  %-12s %6d %6d %8d %10s\n", s.Handle, s.Paged, s.Acked, len(s.Pending), median)
+	}
+	for _, s := range summaries {
+		if len(s.Pending) > 0 {
+			fmt.Printf("\n  %s %s: %s\n", style.Warning.Render("⚠"), s.Handle, strings.Join(s.Pending, ", "))
+		}
+	}
+	return nil
+}
+
+// summarizeOverseerAcks tallies escalations per overseer: those raised on
+// their shift (on_call) and those they acknowledged (acked_by).
+func summarizeOverseerAcks(roster *config.OverseerRoster, issues []*beads.Issue) []*OverseerAckSummary {
+	byAddress := make(map[string]*OverseerAckSummary, len(roster.Overseers))
+	summaries := make([]*OverseerAckSummary, 0, len(roster.Overseers))
+	for _, m := range roster.Overseers {
+		s := &OverseerAckSummary{Handle: m.Handle}
+		byAddress[config.OverseerMemberAddress(m.Handle)] = s
+		summaries = append(summaries, s)
+	}
+
+	for _, issue := range issues {
+		fields := beads.ParseEscalationFields(issue.Description)
+		if s := byAddress[fields.OnCall]; s != nil {
+			s.Paged++
+			if fields.AckedBy == "" && issue.Status != "closed" {
+				s.Pending = append(s.Pending, issue.ID)
+			}
+		}
+		if s := byAddress[fields.AckedBy]; s != nil {
+			s.Acked++
+			if fields.AckedAt > s.LastAckAt {
+				s.LastAckAt = fields.AckedAt
+			}
+			raised, err1 := time.Parse(time.RFC3339, fields.EscalatedAt)
+			acked, err2 := time.Parse(time.RFC3339, fields.AckedAt)
+			if err1 == nil && err2 == nil && !acked.Before(raised) {
+				s.ackLatency = append(s.ackLatency, acked.Sub(raised))
+			}
+		}
+	}
+
+	for _, s := range summaries {
+		if n := len(s.ackLatency); n > 0 {
+			sort.Slice(s.ackLatency, func(i, j int) bool { return s.ackLatency[i] < s.ackLatency[j] })
+			s.MedianAck = s.ackLatency[n/2].Round(time.Minute).String()
+		}
+	}
+	return summaries
+}
+
+// loadOverseerRosterForEdit loads mayor/overseers.json, or starts a new
+// roster seeded with the town's existing single overseer.
+func loadOverseerRosterForEdit(townRoot string) (*config.OverseerRoster, error) {
+	roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot))
+	if err == nil {
+		return roster, nil
+	}
+	if !errors.Is(err, config.ErrNotFound) {
+		return nil, fmt.Errorf("loading overseer roster: %w", err)
+	}
+	single, err := config.LoadOverseerConfig(config.OverseerConfigPath(townRoot))
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return nil, fmt.Errorf("loading overseer config: %w", err)
+	}
+	return config.NewOverseerRosterFrom(single), nil
+}
+
+// currentOverseer returns the roster entry for the human at this terminal.
+// GT_OVERSEER names a handle explicitly; otherwise git user.email is matched
+// against the roster. Returns nil when neither identifies anyone.
+func currentOverseer(townRoot string, roster *config.OverseerRoster) *config.OverseerMember {
+	if handle := os.Getenv("GT_OVERSEER"); handle != "" {
+		return roster.Member(handle)
+	}
+	gitCmd := exec.Command("git", "config", "user.email")
+	gitCmd.Dir = townRoot
+	out, err := gitCmd.Output()
+	if err != nil {
+		return nil
+	}
+	return roster.MemberByEmail(strings.TrimSpace(string(out)))
+}
+
+// currentOverseerAddress returns the mailbox of the human at this terminal:
+// "overseer/<handle>" when they are on the town's roster, otherwise the
+// shared "overseer" mailbox. An empty townRoot is resolved from the cwd.
+func currentOverseerAddress(townRoot string) string {
+	if townRoot == "" {
+		var err error
+		if townRoot, err = workspace.FindFromCwd(); err != nil || townRoot == "" {
+			return config.OverseerAddress
+		}
+	}
+	roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot))
+	if err != nil {
+		return config.OverseerAddress
+	}
+	if m := currentOverseer(townRoot, roster); m != nil {
+		return config.OverseerMemberAddress(m.Handle)
+	}
+	return config.OverseerAddress
+}
+
+// onCallOverseerAddress returns the on-call overseer's mailbox in towns with
+// a roster, or "" in towns with a single overseer.
+func onCallOverseerAddress(townRoot string) string {
+	roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot))
+	if err != nil {
+		return ""
+	}
+	m, err := roster.OnCall(time.Now())
+	if err != nil {
+		return ""
+	}
+	return config.OverseerMemberAddress(m.Handle)
+}
+
+// overseerDisplayName renders a handle with the overseer's display name.
+func overseerDisplayName(roster *config.OverseerRoster, handle string) string {
+	if m := roster.Member(handle); m != nil && m.Name != "" && m.Name != handle {
+		return fmt.Sprintf("%s (%s)", handle, m.Name)
+	}
+	return handle
+}
+
+// formatShiftTime renders a shift boundary in local time.
+func formatShiftTime(t time.Time) string {
+	return t.Local().Format("Mon Jan 2 15:04 MST")
+}
+
+// startOfDay returns local midnight on t's date.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// writeTestOverseerRoster saves a two-person roster where bob is on call
+// through a hand-off.
+func writeTestOverseerRoster(t *testing.T, townRoot string) {
+	t.Helper()
+	roster := config.NewOverseerRoster()
+	_ = roster.Add(config.OverseerMember{Handle: "alice", Name: "Alice", Email: "alice@example.com", SMS: "+15550001"})
+	_ = roster.Add(config.OverseerMember{Handle: "bob", Name: "Bob", Email: "bob@example.com"})
+	now := time.Now()
+	if err := roster.HandOff("bob", "alice", now, now.Add(time.Hour), ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveOverseerRoster(config.OverseerRosterPath(townRoot), roster); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEscalationRecipient(t *testing.T) {
+	townRoot := t.TempDir()
+	cfg := &config.EscalationConfig{Contacts: config.EscalationContacts{HumanEmail: "team@example.com"}}
+
+	// Without a roster, on-call routes have nowhere to go and "human" is the
+	// town-wide contact.
+	if _, warning := escalationRecipient(cfg, townRoot, "email", "oncall"); warning == "" {
+		t.Error("email:oncall without a roster should warn")
+	}
+	if got, _ := escalationRecipient(cfg, townRoot, "email", "human"); got != "team@example.com" {
+		t.Errorf("email:human without a roster = %q, want team@example.com", got)
+	}
+
+	writeTestOverseerRoster(t, townRoot)
+
+	tests := []struct {
+		channel, target string
+		want            string
+		warn            string
+	}{
+		{"email", "human", "bob@example.com", ""},
+		{"email", "oncall", "bob@example.com", ""},
+		{"email", "alice", "alice@example.com", ""},
+		{"sms", "alice", "+15550001", ""},
+		{"sms", "oncall", "", "overseer bob has no sms"},
+		{"sms", "human", "", "overseer bob has no sms"},
+		{"slack", "", "", "contacts.slack_webhook not configured"},
+		{"email", "nobody", "team@example.com", ""}, // unknown targets keep the legacy contact
+	}
+	for _, tt := range tests {
+		got, warning := escalationRecipient(cfg, townRoot, tt.channel, tt.target)
+		if got != tt.want {
+			t.Errorf("%s:%s = %q, want %q", tt.channel, tt.target, got, tt.want)
+		}
+		if (tt.warn == "") != (warning == "") || !strings.Contains(warning, tt.warn) {
+			t.Errorf("%s:%s warning = %q, want %q", tt.channel, tt.target, warning, tt.warn)
+		}
+	}
+}
+
+func TestSummarizeOverseerAcks(t *testing.T) {
+	roster := config.NewOverseerRoster()
+	_ = roster.Add(config.OverseerMember{Handle: "alice"})
+	_ = roster.Add(config.OverseerMember{Handle: "bob"})
+
+	esc := func(id, status string, f beads.EscalationFields) *beads.Issue {
+		return &beads.Issue{ID: id, Status: status, Description: beads.FormatEscalationDescription(id, &f)}
+	}
+	issues := []*beads.Issue{
+		esc("hq-1", "open", beads.EscalationFields{OnCall: "overseer/alice", EscalatedAt: "2026-10-16T09:00:00Z",
+			AckedBy: "overseer/alice", AckedAt: "2026-10-16T09:10:00Z"}),
+		esc("hq-2", "open", beads.EscalationFields{OnCall: "overseer/alice", EscalatedAt: "2026-10-16T10:00:00Z"}),
+		esc("hq-3", "closed", beads.EscalationFields{OnCall: "overseer/alice", EscalatedAt: "2026-10-16T11:00:00Z"}),
+		esc("hq-4", "open", beads.EscalationFields{OnCall: "overseer/alice", EscalatedAt: "2026-10-16T12:00:00Z",
+			AckedBy: "overseer/bob", AckedAt: "2026-10-16T12:30:00Z"}),
+		esc("hq-5", "open", beads.EscalationFields{EscalatedAt: "2026-10-16T13:00:00Z", AckedBy: "mayor/"}),
+	}
+
+	got := summarizeOverseerAcks(roster, issues)
+	if len(got) != 2 {
+		t.Fatalf("got %d summaries", len(got))
+	}
+	alice, bob := got[0], got[1]
+	if alice.Paged != 4 || alice.Acked != 1 || alice.MedianAck != "10m0s" {
+		t.Errorf("alice = %+v", alice)
+	}
+	if len(alice.Pending) != 1 || alice.Pending[0] != "hq-2" {
+		t.Errorf("alice pending = %v, want [hq-2]", alice.Pending)
+	}
+	if bob.Paged != 0 || bob.Acked != 1 || bob.MedianAck != "30m0s" || bob.LastAckAt != "2026-10-16T12:30:00Z" {
+		t.Errorf("bob = %+v", bob)
+	}
+}
+
+func TestCurrentOverseerAddress(t *testing.T) {
+	townRoot := t.TempDir()
+	t.Setenv("GT_OVERSEER", "alice")
+
+	if got := currentOverseerAddress(townRoot); got != "overseer" {
+		t.Errorf("without a roster = %q, want overseer", got)
+	}
+
+	writeTestOverseerRoster(t, townRoot)
+	if got := currentOverseerAddress(townRoot); got != "overseer/alice" {
+		t.Errorf("GT_OVERSEER=alice = %q, want overseer/alice", got)
+	}
+	if got := onCallOverseerAddress(townRoot); got != "overseer/bob" {
+		t.Errorf("onCallOverseerAddress = %q, want overseer/bob", got)
+	}
+
+	t.Setenv("GT_OVERSEER", "nobody")
+	if got := currentOverseerAddress(townRoot); got != "overseer" {
+		t.Errorf("unknown GT_OVERSEER = %q, want overseer", got)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,12 +9,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/proxy"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -1272,6 +1275,10 @@ func nukePolecatFull(polecatName, rigName string, mgr *polecat.Manager, r *rig.R
 		fmt.Printf("  %s killed session\n", style.Success.Render("✓"))
 	}
 
+	// Step 1.5: Revoke the polecat's proxy certs so its container loses access
+	// to gt-proxy-server even if the container outlives the nuke.
+	revokePolecatProxyCerts(filepath.Dir(r.Path), rigName, polecatName)
+
 	// Step 2: Get polecat info before deletion (for branch name + hooked work bead)
 	polecatInfo, getErr := mgr.Get(polecatName)
 	var branchToDelete string
@@ -1368,6 +1375,31 @@ func nukePolecatFull(polecatName, rigName string, mgr *polecat.Manager, r *rig.R
 	return nil
 }
 
+// revokePolecatCertsFn is the proxy admin call used by nuke; a var so tests
+// can stub it.
+var revokePolecatCertsFn = proxy.RevokePolecatCerts
+
+// revokePolecatProxyCerts asks the local gt-proxy-server to revoke every cert
+// issued to the polecat. Best-effort: a town without a running proxy (the
+// common case outside sandboxed mode) is skipped silently, other failures
+// are logged but don't abort the nuke.
+func revokePolecatProxyCerts(townRoot, rigName, polecatName string) {
+	adminAddr := proxy.TownAdminAddr(townRoot)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	revoked, err := revokePolecatCertsFn(ctx, adminAddr, rigName, polecatName, "polecat nuked")
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return
+		}
+		fmt.Printf("  %s proxy cert revocation failed: %v\n", style.Warning.Render("⚠"), err)
+		return
+	}
+	if len(revoked) > 0 {
+		fmt.Printf("  %s revoked %d proxy cert(s)\n", style.Success.Render("✓"), len(revoked))
+	}
+}
+
 // nukeCleanupMolecules burns any molecule attached to a work bead during polecat nuke.
 // This prevents stale attached_molecule references from blocking re-dispatch (gt-npzy).
 // Best-effort: failures are logged but don't abort the nuke.
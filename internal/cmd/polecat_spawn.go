@@ -2,6 +2,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -16,10 +17,13 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/witness"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // SpawnedPolecatInfo contains info about a spawned polecat session.
@@ -33,8 +37,9 @@ type SpawnedPolecatInfo struct {
 	Branch      string // Git branch name (for cleanup on rollback)
 
 	// Internal fields for deferred session start
-	account string
-	agent   string
+	account     string
+	agent       string
+	traceParent string // bead lifecycle trace for the session start span
 }
 
 // AgentID returns the agent identifier (e.g., "gastown/polecats/Toast")
@@ -60,7 +65,14 @@ type SlingSpawnOptions struct {
 // SpawnPolecatForSling creates a fresh polecat and optionally starts its session.
 // This is used by gt sling when the target is a rig name.
 // The caller (sling) handles hook attachment and nudging.
-func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolecatInfo, error) {
+func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (_ *SpawnedPolecatInfo, retErr error) {
+	// The sling exports its trace context via TRACEPARENT; the spawn is a
+	// child span and the session start joins the same trace.
+	slingCtx := telemetry.ContextFromEnv(context.Background())
+	_, span := telemetry.StartSpan(slingCtx, "polecat.spawn",
+		attribute.String("gt.rig", rigName), attribute.String("gt.bead", opts.HookBead))
+	defer func() { telemetry.EndSpan(span, retErr) }()
+
 	// Find workspace
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -81,9 +93,9 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		return nil, fmt.Errorf("rig '%s' not found", rigName)
 	}
 
-	// Get polecat manager (with tmux for session-aware allocation)
+	// Get polecat manager (with the session backend for session-aware allocation)
 	polecatGit := git.NewGit(r.Path)
-	t := tmux.NewTmux()
+	t := session.NewBackend(townRoot)
 	polecatMgr := polecat.NewManager(r, polecatGit, t)
 
 	// Pre-spawn Dolt health check (gt-94llt7): verify Dolt is reachable before
@@ -227,6 +239,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 				Branch:      polecatObj.Branch,
 				account:     opts.Account,
 				agent:       opts.Agent,
+				traceParent: telemetry.TraceParent(slingCtx),
 			}, nil
 		}
 	}
@@ -311,6 +324,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		Branch:      polecatObj.Branch,
 		account:     opts.Account,
 		agent:       opts.Agent,
+		traceParent: telemetry.TraceParent(slingCtx),
 	}, nil
 }
 
@@ -350,13 +364,14 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 	}
 
 	// Start session
-	t := tmux.NewTmux()
+	t := session.NewBackend(townRoot)
 	polecatSessMgr := polecat.NewSessionManager(t, r)
 
 	fmt.Printf("Starting session for %s/%s...\n", s.RigName, s.PolecatName)
 	startOpts := polecat.SessionStartOptions{
 		RuntimeConfigDir: claudeConfigDir,
 		Agent:            s.agent,
+		TraceParent:      s.traceParent,
 	}
 	if err := polecatSessMgr.Start(s.PolecatName, startOpts); err != nil {
 		return "", fmt.Errorf("starting session: %w", err)
@@ -381,8 +396,12 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 	} else {
 		runtimeConfig = config.ResolveRoleAgentConfig("polecat", spawnTownRoot, r.Path)
 	}
-	if err := t.WaitForRuntimeReady(s.SessionName, runtimeConfig, 30*time.Second); err != nil {
-		style.PrintWarning("runtime may not be fully ready: %v", err)
+	// SessionManager.Start already waited on other backends.
+	tm, isTmux := t.(*tmux.Tmux)
+	if isTmux {
+		if err := tm.WaitForRuntimeReady(s.SessionName, runtimeConfig, 30*time.Second); err != nil {
+			style.PrintWarning("runtime may not be fully ready: %v", err)
+		}
 	}
 
 	// Update agent state with retry logic (gt-94llt7: fail-safe Dolt writes).
@@ -405,10 +424,16 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 
 	// Get pane — if this fails, the session may have died during startup.
 	// Kill the dead session to prevent "session already running" on next attempt (gt-jn40ft).
-	pane, err := getSessionPane(s.SessionName)
+	// A headless session has a single terminal, addressed by the session name.
+	pane := s.SessionName
+	if isTmux {
+		pane, err = getSessionPane(s.SessionName)
+	} else if running, _ := t.HasSession(s.SessionName); !running {
+		err = fmt.Errorf("session not found")
+	}
 	if err != nil {
-		// Session likely died — clean up the tmux session so it doesn't block re-sling
-		_ = t.KillSession(s.SessionName)
+		// Session likely died — clean up the session so it doesn't block re-sling
+		_ = session.KillSession(t, s.SessionName)
 		return "", fmt.Errorf("getting pane for %s (session likely died during startup): %w", s.SessionName, err)
 	}
 
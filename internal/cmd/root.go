@@ -142,7 +142,7 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 	touchPolecatHeartbeat()
 
 	// Skip beads check for exempt commands
-	if beadsExemptCommands[cmdName] || isRoleCommand(cmd) {
+	if beadsExemptCommands[cmdName] || isRoleCommand(cmd) || isSandboxCommand(cmd) {
 		return nil
 	}
 
@@ -167,6 +167,18 @@ func isRoleCommand(cmd *cobra.Command) bool {
 	return false
 }
 
+// isSandboxCommand returns true when the invoked command belongs to the
+// `gt sandbox` tree. gt sandbox exec sits in front of every sandboxed polecat
+// launch, and init and probe run inside the sandbox where bd can't be checked.
+func isSandboxCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == "sandbox" {
+			return true
+		}
+	}
+	return false
+}
+
 // initCLITheme initializes the CLI color theme based on settings and environment.
 func initCLITheme() {
 	// Try to load town settings for CLITheme config
@@ -304,7 +316,10 @@ func Execute() int {
 		telemetry.SetProcessOTELAttrs()
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	// Join the bead's lifecycle trace when launched inside an agent session.
+	ctx = telemetry.ContextFromEnv(ctx)
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		// Check for silent exit (scripting commands that signal status via exit code)
 		if code, ok := IsSilentExit(err); ok {
 			return code
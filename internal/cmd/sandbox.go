@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/sandbox"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Sandbox command flags
+var (
+	sandboxJSON     bool
+	sandboxRig      string
+	sandboxPolecat  string
+	sandboxWorktree string
+)
+
+var sandboxCmd = &cobra.Command{
+	Use:     "sandbox",
+	GroupID: GroupConfig,
+	Short:   "Inspect and verify polecat sandbox profiles",
+	RunE:    requireSubcommand,
+	Long: `Inspect and verify the sandbox profiles polecats launch under.
+
+A rig opts in by selecting a profile in settings/config.json:
+
+  "sandbox": {
+    "profile": "standard",
+    "profiles": {
+      "offline": { "network": "none", "memory": "4G", "pids": 2048 }
+    }
+  }
+
+Polecats in a sandboxed rig start through 'gt sandbox exec', which:
+  - issues the polecat a proxy client cert and sets GT_PROXY_*
+  - applies memory, CPU and process limits with a cgroup
+  - mounts everything read-only except the worktree, its git directory
+    and the profile's read_write paths
+  - cuts the network down to gt-proxy-server and the profile's
+    egress_allow list (network "proxy"), or to loopback (network "none")
+
+The built-in "standard" profile allows ~/.claude, ~/.claude.json and
+~/.cache, hides ~/.ssh, ~/.aws and ~/.config/gh, allows egress to the
+Anthropic API, and caps memory at 8G and processes at 4096.
+
+Commands:
+  gt sandbox show <rig>        Show a rig's active profile
+  gt sandbox verify [rig...]   Launch a probe in each sandbox and check enforcement`,
+}
+
+var sandboxShowCmd = &cobra.Command{
+	Use:   "show <rig>",
+	Short: "Show a rig's active sandbox profile",
+	Long: `Show the sandbox profile polecats in a rig launch under.
+
+Examples:
+  gt sandbox show gastown
+  gt sandbox show gastown --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSandboxShow,
+}
+
+var sandboxVerifyCmd = &cobra.Command{
+	Use:   "verify [rig...]",
+	Short: "Check that sandbox profiles are enforced",
+	Long: `Launch a probe under each rig's sandbox profile and check that the
+profile is actually enforced: writable and read-only paths, hidden paths,
+network isolation, the proxy and egress allow-list, and resource limits.
+
+The probe runs as polecat "sandbox-verify" exactly as a polecat would be
+launched. In "proxy" network mode gt-proxy-server must be running.
+
+With no arguments, every rig that selects a profile is verified.
+
+Examples:
+  gt sandbox verify
+  gt sandbox verify gastown --json`,
+	RunE: runSandboxVerify,
+}
+
+var sandboxExecCmd = &cobra.Command{
+	Use:   "exec [flags] -- <command> [args...]",
+	Short: "Run a command under the rig's sandbox profile",
+	Long: `Run a command under the sandbox profile of a polecat's rig.
+
+This is the exec wrapper Gas Town puts in front of the agent command for
+polecats in sandboxed rigs. The town, rig, polecat and worktree come from
+GT_TOWN_ROOT, GT_RIG, GT_POLECAT and GT_POLECAT_PATH unless given as flags.
+
+The command is not run if any part of the sandbox can't be set up.
+
+Examples:
+  gt sandbox exec -- claude --dangerously-skip-permissions
+  gt sandbox exec --rig gastown --polecat nux --worktree . -- bash`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSandboxExec,
+}
+
+var sandboxInitCmd = &cobra.Command{
+	Use:    "init -- <command> [args...]",
+	Short:  "Sandbox init process (internal)",
+	Hidden: true, // Re-executed by gt sandbox exec inside the new namespaces.
+	Args:   cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		code, err := sandbox.Init(args)
+		if err != nil {
+			return fmt.Errorf("sandbox init: %w", err)
+		}
+		if code != 0 {
+			return NewSilentExit(code)
+		}
+		return nil
+	},
+}
+
+var sandboxProbeCmd = &cobra.Command{
+	Use:    "probe",
+	Short:  "Report sandbox enforcement from the inside (internal)",
+	Hidden: true, // Run by gt sandbox verify inside a sandbox.
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := sandbox.Probe()
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	},
+}
+
+func init() {
+	sandboxShowCmd.Flags().BoolVar(&sandboxJSON, "json", false, "Output as JSON")
+	sandboxVerifyCmd.Flags().BoolVar(&sandboxJSON, "json", false, "Output as JSON")
+
+	sandboxExecCmd.Flags().StringVar(&sandboxRig, "rig", "", "Rig name (default: $GT_RIG)")
+	sandboxExecCmd.Flags().StringVar(&sandboxPolecat, "polecat", "", "Polecat name (default: $GT_POLECAT)")
+	sandboxExecCmd.Flags().StringVar(&sandboxWorktree, "worktree", "", "Polecat worktree (default: $GT_POLECAT_PATH, else the current directory)")
+	sandboxExecCmd.Flags().SetInterspersed(false)
+	sandboxInitCmd.Flags().SetInterspersed(false)
+
+	sandboxCmd.AddCommand(sandboxShowCmd)
+	sandboxCmd.AddCommand(sandboxVerifyCmd)
+	sandboxCmd.AddCommand(sandboxExecCmd)
+	sandboxCmd.AddCommand(sandboxInitCmd)
+	sandboxCmd.AddCommand(sandboxProbeCmd)
+	rootCmd.AddCommand(sandboxCmd)
+}
+
+// sandboxTownRoot finds the town for gt sandbox exec, which runs from a
+// polecat worktree with GT_TOWN_ROOT set.
+func sandboxTownRoot() (string, error) {
+	for _, key := range []string{"GT_TOWN_ROOT", "GT_ROOT"} {
+		if root := os.Getenv(key); root != "" {
+			return root, nil
+		}
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	return townRoot, nil
+}
+
+func runSandboxExec(cmd *cobra.Command, args []string) error {
+	townRoot, err := sandboxTownRoot()
+	if err != nil {
+		return err
+	}
+	rig := cmp.Or(sandboxRig, os.Getenv("GT_RIG"))
+	polecat := cmp.Or(sandboxPolecat, os.Getenv("GT_POLECAT"))
+	worktree := cmp.Or(sandboxWorktree, os.Getenv("GT_POLECAT_PATH"), ".")
+
+	spec, err := sandbox.Plan(townRoot, rig, polecat, worktree)
+	if err != nil {
+		return fmt.Errorf("refusing to start unsandboxed: %w", err)
+	}
+	code, err := sandbox.Exec(context.Background(), spec, args)
+	if err != nil {
+		return fmt.Errorf("refusing to start unsandboxed: %w", err)
+	}
+	if code != 0 {
+		return NewSilentExit(code)
+	}
+	return nil
+}
+
+func runSandboxShow(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	rig := args[0]
+	name, profile, err := config.ResolveRigSandbox(filepath.Join(townRoot, rig))
+	if err != nil {
+		return err
+	}
+
+	if sandboxJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Rig     string                 `json:"rig"`
+			Profile string                 `json:"profile,omitempty"`
+			Config  *config.SandboxProfile `json:"config,omitempty"`
+		}{rig, name, profile})
+	}
+
+	if profile == nil {
+		fmt.Printf("%s: not sandboxed\n", rig)
+		return nil
+	}
+	fmt.Printf("%s: profile %s\n", style.Bold.Render(rig), style.Bold.Render(name))
+	fmt.Printf("  network:     %s\n", profile.NetworkMode())
+	if profile.NetworkMode() == config.SandboxNetworkProxy {
+		fmt.Printf("  egress:      %s\n", listOrNone(profile.EgressAllow))
+	}
+	fmt.Printf("  read-write:  worktree, git dir, %s\n", listOrNone(profile.ReadWrite))
+	fmt.Printf("  hidden:      %s\n", listOrNone(profile.Hidden))
+	fmt.Printf("  memory:      %s\n", cmp.Or(profile.Memory, "unlimited"))
+	if profile.CPUs > 0 {
+		fmt.Printf("  cpus:        %g\n", profile.CPUs)
+	} else {
+		fmt.Printf("  cpus:        unlimited\n")
+	}
+	if profile.Pids > 0 {
+		fmt.Printf("  pids:        %d\n", profile.Pids)
+	} else {
+		fmt.Printf("  pids:        unlimited\n")
+	}
+	fmt.Printf("  cert ttl:    %s\n", profile.CertLifetime())
+	return nil
+}
+
+func runSandboxVerify(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	rigs := args
+	if len(rigs) == 0 {
+		rigs = sandboxedRigs(townRoot)
+		if len(rigs) == 0 {
+			fmt.Println("No rigs select a sandbox profile.")
+			return nil
+		}
+	}
+
+	type result struct {
+		Rig    string          `json:"rig"`
+		Report *sandbox.Report `json:"report,omitempty"`
+		Error  string          `json:"error,omitempty"`
+	}
+	var results []result
+	failed := false
+	for _, rig := range rigs {
+		report, err := sandbox.Verify(context.Background(), townRoot, rig)
+		r := result{Rig: rig, Report: report}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		if err != nil || len(report.Failures()) > 0 {
+			failed = true
+		}
+		results = append(results, r)
+	}
+
+	if sandboxJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Error != "" {
+				fmt.Printf("%s %s: %s\n", style.Error.Render("✗"), style.Bold.Render(r.Rig), r.Error)
+				continue
+			}
+			fmt.Printf("%s (profile %s): %s\n", style.Bold.Render(r.Rig), r.Report.Profile, r.Report)
+			for _, c := range r.Report.Checks {
+				mark := style.Success.Render("✓")
+				if !c.OK {
+					mark = style.Error.Render("✗")
+				}
+				line := fmt.Sprintf("  %s %s", mark, c.Name)
+				if !c.OK && c.Detail != "" {
+					line += style.Dim.Render(" — " + c.Detail)
+				}
+				fmt.Println(line)
+			}
+		}
+	}
+	if failed {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// sandboxedRigs returns the registered rigs that select a sandbox profile,
+// including rigs whose sandbox settings are invalid.
+func sandboxedRigs(townRoot string) []string {
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, constants.DirMayor, constants.FileRigsJSON))
+	if err != nil {
+		return nil
+	}
+	var rigs []string
+	for rig := range rigsConfig.Rigs {
+		if name, _, err := config.ResolveRigSandbox(filepath.Join(townRoot, rig)); err != nil || name != "" {
+			rigs = append(rigs, rig)
+		}
+	}
+	sort.Strings(rigs)
+	return rigs
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -77,6 +78,7 @@ func init() {
 // sessionEvent represents a session_start event from our event stream.
 type sessionEvent struct {
 	Timestamp string                 `json:"ts"`
+	Source    string                 `json:"source"`
 	Type      string                 `json:"type"`
 	Actor     string                 `json:"actor"`
 	Payload   map[string]interface{} `json:"payload"`
@@ -329,7 +331,7 @@ func discoverSessions(townRoot string) ([]sessionEvent, error) {
 			continue
 		}
 
-		if event.Type == events.TypeSessionStart {
+		if event.Type == events.TypeSessionStart && eventbus.IsActivitySource(event.Source) {
 			sessions = append(sessions, event)
 		}
 	}
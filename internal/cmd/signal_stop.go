@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -47,7 +48,7 @@ All output goes to stdout as JSON for Claude Code to consume.`,
 func runSignalStop(cmd *cobra.Command, args []string) error {
 	// Detect agent identity
 	address := detectSender()
-	if address == "" || address == "overseer" {
+	if address == "" || config.IsOverseerAddress(address) {
 		// Not an agent session — allow the stop
 		return outputStopAllow()
 	}
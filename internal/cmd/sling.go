@@ -20,6 +20,7 @@ import (
 	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/witness"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var slingCmd = &cobra.Command{
@@ -208,6 +209,23 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		}
 		telemetry.RecordSling(ctx, bead, target, retErr)
 	}()
+
+	// Root span of the bead lifecycle trace. Its trace context is exported
+	// for the rest of the sling so spawned polecats, their sessions and the
+	// bead's attachment fields all join the same trace.
+	ctx, slingSpan := telemetry.StartSpan(ctx, "gt.sling", attribute.StringSlice("gt.args", args))
+	defer func() { telemetry.EndSpan(slingSpan, retErr) }()
+	if tp := telemetry.TraceParent(ctx); tp != "" {
+		prevTraceParent, hadTraceParent := os.LookupEnv(telemetry.EnvTraceParent)
+		os.Setenv(telemetry.EnvTraceParent, tp)
+		defer func() {
+			if hadTraceParent {
+				os.Setenv(telemetry.EnvTraceParent, prevTraceParent)
+			} else {
+				os.Unsetenv(telemetry.EnvTraceParent)
+			}
+		}()
+	}
 	// Polecats cannot sling - check early before writing anything.
 	// Check GT_ROLE first: coordinators (mayor, witness, etc.) may have a stale
 	// GT_POLECAT in their environment from spawning polecats. Only block if the
@@ -959,6 +977,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		NoMerge:          slingNoMerge,
 		ReviewOnly:       slingReviewOnly,
 		FormulaVars:      strings.Join(slingVars, "\n"),
+		TraceParent:      os.Getenv(telemetry.EnvTraceParent),
 	}
 	if err := storeFieldsInBead(beadID, fieldUpdates); err != nil {
 		// Warn but don't fail - polecat will still complete work
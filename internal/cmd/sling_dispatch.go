@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/telemetry"
 )
 
 // SlingParams captures everything needed to sling one bead to a rig.
@@ -122,6 +124,14 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 			}
 			return result, fmt.Errorf("cannot sling to %s rig %q\n%s %s", reason, params.RigName, undoCmd, params.RigName)
 		}
+		if err := checkRigNotEstopped(townRoot, params.RigName); err != nil {
+			result.ErrMsg = "rig e-stopped"
+			return result, err
+		}
+		if err := checkBudgetAllowsSling(townRoot, params.RigName, params.BeadID); err != nil {
+			result.ErrMsg = "budget exceeded"
+			return result, err
+		}
 	}
 
 	// 1. Get bead info + status check
@@ -355,6 +365,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 		ReviewOnly:       params.ReviewOnly,
 		Mode:             params.Mode,
 		FormulaVars:      strings.Join(allVars, "\n"),
+		TraceParent:      os.Getenv(telemetry.EnvTraceParent),
 	}
 	// Use beadToHook for the update target (may differ from beadID when formula-on-bead)
 	if err := storeFieldsInBead(beadToHook, fieldUpdates); err != nil {
@@ -211,6 +211,7 @@ func runSlingFormula(ctx context.Context, args []string) error {
 		Vars:            append([]string(nil), slingVars...),
 		AttachedFormula: formulaName,
 		FormulaVars:     strings.Join(slingVars, "\n"),
+		TraceParent:     os.Getenv(telemetry.EnvTraceParent),
 	}
 	if err := storeFieldsInBead(wispRootID, fieldUpdates); err != nil {
 		fmt.Printf("%s Could not store fields in bead: %v\n", style.Dim.Render("Warning:"), err)
@@ -274,6 +274,7 @@ type beadFieldUpdates struct {
 	MergeStrategy    string // Convoy merge strategy: "direct", "mr", "local"
 	ConvoyOwned      bool   // Convoy has gt:owned label (caller-managed lifecycle)
 	FormulaVars      string // Newline-separated key=value pairs for formula template substitution
+	TraceParent      string // W3C traceparent of the sling span (bead lifecycle trace)
 }
 
 // storeFieldsInBead performs a single read-modify-write to update all attachment fields
@@ -354,6 +355,9 @@ func storeFieldsInBead(beadID string, updates beadFieldUpdates) error {
 	if updates.FormulaVars != "" {
 		fields.FormulaVars = updates.FormulaVars
 	}
+	if updates.TraceParent != "" {
+		fields.TraceParent = updates.TraceParent
+	}
 
 	// Write back once
 	newDesc := beads.SetAttachmentFields(issue, fields)
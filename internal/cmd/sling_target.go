@@ -193,6 +193,12 @@ func resolveTarget(target string, opts ResolveTargetOptions) (*ResolvedTarget, e
 				}
 				return nil, fmt.Errorf("cannot sling to %s rig %q\n%s %s", reason, rigName, undoCmd, rigName)
 			}
+			if err := checkRigNotEstopped(townRoot, rigName); err != nil {
+				return nil, err
+			}
+			if err := checkBudgetAllowsSling(townRoot, rigName, opts.BeadID); err != nil {
+				return nil, err
+			}
 		}
 
 		if opts.BeadID != "" && !opts.Force {
@@ -109,6 +109,8 @@ type OverseerInfo struct {
 	Username   string `json:"username,omitempty"`
 	Source     string `json:"source"`
 	UnreadMail int    `json:"unread_mail"`
+	OnCall     string `json:"on_call,omitempty"`      // On-call overseer handle (towns with a roster)
+	OnCallEnds string `json:"on_call_ends,omitempty"` // End of the current on-call shift (RFC 3339)
 }
 
 // DNDInfo represents Do Not Disturb status for the current agent context.
@@ -779,6 +781,12 @@ func gatherStatus() (TownStatus, error) {
 				overseerInfo.UnreadMail = unread
 			}
 		}
+		if roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot)); err == nil {
+			if shift, ok := roster.ShiftAt(time.Now()); ok {
+				overseerInfo.OnCall = shift.Handle
+				overseerInfo.OnCallEnds = shift.End.Format(time.RFC3339)
+			}
+		}
 	}
 
 	// Build status - parallel fetch global agents and rigs
@@ -973,6 +981,9 @@ func outputStatusText(w io.Writer, status TownStatus) error {
 		if status.Overseer.UnreadMail > 0 {
 			fmt.Fprintf(w, "   📬 %d unread\n", status.Overseer.UnreadMail)
 		}
+		if status.Overseer.OnCall != "" {
+			fmt.Fprintf(w, "   📟 On call: %s %s\n", status.Overseer.OnCall, style.Dim.Render("(until "+status.Overseer.OnCallEnds+")"))
+		}
 		fmt.Fprintln(w)
 	}
 
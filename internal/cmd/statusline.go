@@ -10,7 +10,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
-	"github.com/steveyegge/gastown/internal/estop"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -41,22 +40,10 @@ func init() {
 func runStatusLine(cmd *cobra.Command, args []string) error {
 	// Check E-stop first — prepend red indicator if active
 	if townRoot, twErr := workspace.FindFromCwd(); twErr == nil {
-		showEstop := false
-		var info *estop.Info
-		if estop.IsActive(townRoot) {
-			showEstop = true
-			info = estop.Read(townRoot)
-		} else {
-			// Check per-rig E-stop
-			rigEnv := os.Getenv("GT_RIG")
-			if rigEnv != "" && estop.IsRigActive(townRoot, rigEnv) {
-				showEstop = true
-				info = estop.ReadRig(townRoot, rigEnv)
-			}
-		}
-		if showEstop {
+		// Town-wide, or scoped to this agent's rig/role
+		if info := agentEstopInfo(townRoot); info != nil {
 			ts := ""
-			if info != nil && !info.Timestamp.IsZero() {
+			if !info.Timestamp.IsZero() {
 				ts = info.Timestamp.Format("15:04")
 			}
 			fmt.Printf("#[bg=red,fg=white,bold] ESTOP %s #[default] ", ts)
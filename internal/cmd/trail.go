@@ -427,7 +427,7 @@ func readHookTrailEntries(eventsPath string, since time.Time, limit int) ([]Hook
 		}
 
 		var event events.Event
-		if err := json.Unmarshal([]byte(line), &event); err != nil {
+		if err := json.Unmarshal([]byte(line), &event); err != nil || !event.IsActivity() {
 			continue
 		}
 		if event.Type != events.TypeHook && event.Type != events.TypeUnhook {
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
@@ -58,9 +59,24 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s no GT_ROLE set (human at terminal)\n", style.Dim.Render("Source:"))
 
 		// If overseer, show their configured identity
-		if identity == "overseer" {
+		if config.IsOverseerAddress(identity) {
 			townRoot, err := workspace.FindFromCwd()
-			if err == nil && townRoot != "" {
+			handle, onRoster := config.ParseOverseerAddress(identity)
+			if err == nil && townRoot != "" && onRoster {
+				if roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot)); err == nil {
+					if m := roster.Member(handle); m != nil {
+						fmt.Printf("\n%s\n", style.Bold.Render("Overseer Identity:"))
+						fmt.Printf("  Name:   %s\n", m.Name)
+						fmt.Printf("  Handle: %s\n", m.Handle)
+						if m.Email != "" {
+							fmt.Printf("  Email:  %s\n", m.Email)
+						}
+						if shift, ok := roster.ShiftAt(time.Now()); ok && shift.Handle == m.Handle {
+							fmt.Printf("  📟 On call until %s\n", formatShiftTime(shift.End))
+						}
+					}
+				}
+			} else if err == nil && townRoot != "" {
 				if overseerConfig, err := config.LoadOverseerConfig(config.OverseerConfigPath(townRoot)); err == nil {
 					fmt.Printf("\n%s\n", style.Bold.Render("Overseer Identity:"))
 					fmt.Printf("  Name:  %s\n", overseerConfig.Name)
@@ -219,6 +219,11 @@ func validateRigSettings(c *RigSettings) error {
 			return err
 		}
 	}
+	if c.Sandbox != nil {
+		if err := validateSandboxConfig(c.Sandbox); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -2178,6 +2183,7 @@ func BuildStartupCommand(envVars map[string]string, rigPath, prompt string) stri
 	if len(rc.ExecWrapper) == 0 {
 		rc.ExecWrapper = resolveExecWrapper(rigPath)
 	}
+	withSandboxWrapper(rc, role, rigPath)
 
 	// Copy env vars to avoid mutating caller map
 	resolvedEnv := make(map[string]string, len(envVars)+2)
@@ -2375,6 +2381,7 @@ func BuildStartupCommandWithAgentOverride(envVars map[string]string, rigPath, pr
 	if len(rc.ExecWrapper) == 0 {
 		rc.ExecWrapper = resolveExecWrapper(rigPath)
 	}
+	withSandboxWrapper(rc, role, rigPath)
 
 	// Copy env vars to avoid mutating caller map
 	resolvedEnv := make(map[string]string, len(envVars)+2)
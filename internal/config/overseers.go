@@ -0,0 +1,472 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// OverseerRoster lists the humans who share a town and decides which of them
+// is on call (mayor/overseers.json). A town without a roster has a single
+// overseer, described by mayor/overseer.json.
+type OverseerRoster struct {
+	Type      string           `json:"type"`              // "overseer-roster"
+	Version   int              `json:"version"`           // schema version
+	Overseers []OverseerMember `json:"overseers"`         // roster, in display order
+	Rotation  OverseerRotation `json:"rotation"`          // on-call schedule
+	Handoff   *OverseerHandoff `json:"handoff,omitempty"` // temporary on-call override
+}
+
+// OverseerMember is one human on the roster. Each member has their own
+// mailbox at "overseer/<handle>" and their own contact channels for
+// escalations.
+type OverseerMember struct {
+	Handle       string `json:"handle"`                  // short name, e.g. "alice"
+	Name         string `json:"name"`                    // display name
+	Email        string `json:"email,omitempty"`         // address for email:oncall / email:<handle>
+	SMS          string `json:"sms,omitempty"`           // phone number for sms:oncall / sms:<handle>
+	SlackWebhook string `json:"slack_webhook,omitempty"` // personal webhook for slack:oncall / slack:<handle>
+}
+
+// OverseerRotation is a fixed-length shift rotation. Shift n (counting from
+// Start) belongs to Order[n mod len(Order)].
+type OverseerRotation struct {
+	Order []string `json:"order,omitempty"` // handles in rotation order (default: roster order)
+	Shift string   `json:"shift,omitempty"` // shift length as a Go duration (default: "168h")
+	Start string   `json:"start,omitempty"` // RFC 3339 start of the first shift (default: Unix epoch)
+}
+
+// OverseerHandoff puts someone on call until a fixed time, regardless of the
+// rotation. Used for swaps, sick days, and vacations.
+type OverseerHandoff struct {
+	To     string `json:"to"`               // handle taking over
+	From   string `json:"from,omitempty"`   // handle that handed off
+	At     string `json:"at"`               // RFC 3339 time of the hand-off
+	Until  string `json:"until"`            // RFC 3339 end of the override
+	Reason string `json:"reason,omitempty"` // free-form note
+}
+
+// OnCallShift is one stretch of on-call duty.
+type OnCallShift struct {
+	Handle  string    `json:"handle"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Handoff bool      `json:"handoff,omitempty"` // true if from a hand-off rather than the rotation
+}
+
+// CurrentOverseerRosterVersion is the current schema version for OverseerRoster.
+const CurrentOverseerRosterVersion = 1
+
+// DefaultOverseerShift is the rotation shift length when none is configured.
+const DefaultOverseerShift = 7 * 24 * time.Hour
+
+// OverseerAddress is the shared overseer mailbox. Individual overseers have
+// mailboxes beneath it ("overseer/<handle>").
+const OverseerAddress = "overseer"
+
+// validOverseerHandle restricts handles to characters that are safe in mail
+// addresses and bead labels.
+var validOverseerHandle = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// OverseerRosterPath returns the standard path for the overseer roster in a town.
+func OverseerRosterPath(townRoot string) string {
+	return filepath.Join(townRoot, "mayor", "overseers.json")
+}
+
+// OverseerMemberAddress returns the mailbox address for an overseer handle.
+func OverseerMemberAddress(handle string) string {
+	return OverseerAddress + "/" + handle
+}
+
+// IsOverseerAddress reports whether address is the shared overseer mailbox
+// or an individual overseer's mailbox.
+func IsOverseerAddress(address string) bool {
+	address = strings.TrimSuffix(address, "/")
+	return address == OverseerAddress || strings.HasPrefix(address, OverseerAddress+"/")
+}
+
+// ParseOverseerAddress returns the handle of an "overseer/<handle>" address.
+// It returns false for the shared "overseer" mailbox and for non-overseer
+// addresses.
+func ParseOverseerAddress(address string) (string, bool) {
+	handle, ok := strings.CutPrefix(strings.TrimSuffix(address, "/"), OverseerAddress+"/")
+	if !ok || handle == "" {
+		return "", false
+	}
+	return handle, true
+}
+
+// LoadOverseerRoster loads and validates an overseer roster file.
+func LoadOverseerRoster(path string) (*OverseerRoster, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("reading overseer roster: %w", err)
+	}
+
+	var roster OverseerRoster
+	if err := json.Unmarshal(data, &roster); err != nil {
+		return nil, fmt.Errorf("parsing overseer roster: %w", err)
+	}
+
+	if err := validateOverseerRoster(&roster); err != nil {
+		return nil, err
+	}
+
+	return &roster, nil
+}
+
+// SaveOverseerRoster saves an overseer roster to a file.
+func SaveOverseerRoster(path string, roster *OverseerRoster) error {
+	if err := validateOverseerRoster(roster); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(roster, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding overseer roster: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: roster holds contact details, not secrets
+		return fmt.Errorf("writing overseer roster: %w", err)
+	}
+
+	return nil
+}
+
+// NewOverseerRoster returns an empty roster with the default rotation.
+func NewOverseerRoster() *OverseerRoster {
+	return &OverseerRoster{
+		Type:    "overseer-roster",
+		Version: CurrentOverseerRosterVersion,
+	}
+}
+
+// NewOverseerRosterFrom seeds a roster with the town's single overseer, so
+// that adding a second human keeps the first one's identity. The handle is
+// the overseer's username, or a sanitized form of their name.
+func NewOverseerRosterFrom(c *OverseerConfig) *OverseerRoster {
+	roster := NewOverseerRoster()
+	if c == nil {
+		return roster
+	}
+	handle := sanitizeOverseerHandle(c.Username)
+	if handle == "" {
+		handle = sanitizeOverseerHandle(c.Name)
+	}
+	if handle == "" {
+		return roster
+	}
+	roster.Overseers = append(roster.Overseers, OverseerMember{
+		Handle: handle,
+		Name:   c.Name,
+		Email:  c.Email,
+	})
+	return roster
+}
+
+// sanitizeOverseerHandle lowercases s and drops characters that are not
+// allowed in handles, turning spaces into dashes.
+func sanitizeOverseerHandle(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ' || r == '.':
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// validateOverseerRoster validates an OverseerRoster.
+func validateOverseerRoster(r *OverseerRoster) error {
+	if r.Type != "overseer-roster" && r.Type != "" {
+		return fmt.Errorf("%w: expected type 'overseer-roster', got '%s'", ErrInvalidType, r.Type)
+	}
+	if r.Type == "" {
+		r.Type = "overseer-roster"
+	}
+	if r.Version > CurrentOverseerRosterVersion {
+		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, r.Version, CurrentOverseerRosterVersion)
+	}
+
+	seen := make(map[string]bool, len(r.Overseers))
+	for _, m := range r.Overseers {
+		if m.Handle == "" {
+			return fmt.Errorf("%w: overseers[].handle", ErrMissingField)
+		}
+		if !validOverseerHandle.MatchString(m.Handle) {
+			return fmt.Errorf("invalid overseer handle %q: must match [a-zA-Z0-9_-]", m.Handle)
+		}
+		if seen[m.Handle] {
+			return fmt.Errorf("duplicate overseer handle %q", m.Handle)
+		}
+		seen[m.Handle] = true
+	}
+
+	for _, h := range r.Rotation.Order {
+		if !seen[h] {
+			return fmt.Errorf("rotation order references unknown overseer %q", h)
+		}
+	}
+	if r.Rotation.Shift != "" {
+		d, err := time.ParseDuration(r.Rotation.Shift)
+		if err != nil {
+			return fmt.Errorf("invalid rotation shift %q: %w", r.Rotation.Shift, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("invalid rotation shift %q: must be positive", r.Rotation.Shift)
+		}
+	}
+	if r.Rotation.Start != "" {
+		if _, err := time.Parse(time.RFC3339, r.Rotation.Start); err != nil {
+			return fmt.Errorf("invalid rotation start %q: %w", r.Rotation.Start, err)
+		}
+	}
+
+	if h := r.Handoff; h != nil {
+		if !seen[h.To] {
+			return fmt.Errorf("hand-off to unknown overseer %q", h.To)
+		}
+		if _, err := time.Parse(time.RFC3339, h.Until); err != nil {
+			return fmt.Errorf("invalid hand-off end %q: %w", h.Until, err)
+		}
+	}
+	return nil
+}
+
+// Member returns the roster entry for handle, or nil.
+func (r *OverseerRoster) Member(handle string) *OverseerMember {
+	for i := range r.Overseers {
+		if r.Overseers[i].Handle == handle {
+			return &r.Overseers[i]
+		}
+	}
+	return nil
+}
+
+// MemberByEmail returns the roster entry whose email matches (case-insensitively), or nil.
+func (r *OverseerRoster) MemberByEmail(email string) *OverseerMember {
+	if email == "" {
+		return nil
+	}
+	for i := range r.Overseers {
+		if strings.EqualFold(r.Overseers[i].Email, email) {
+			return &r.Overseers[i]
+		}
+	}
+	return nil
+}
+
+// Add appends a member to the roster. It fails if the handle is taken.
+func (r *OverseerRoster) Add(m OverseerMember) error {
+	if r.Member(m.Handle) != nil {
+		return fmt.Errorf("overseer %q already exists", m.Handle)
+	}
+	r.Overseers = append(r.Overseers, m)
+	return nil
+}
+
+// Remove drops a member from the roster, its rotation slots, and any
+// hand-off to it.
+func (r *OverseerRoster) Remove(handle string) error {
+	idx := -1
+	for i := range r.Overseers {
+		if r.Overseers[i].Handle == handle {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("unknown overseer %q", handle)
+	}
+	r.Overseers = append(r.Overseers[:idx], r.Overseers[idx+1:]...)
+
+	order := r.Rotation.Order[:0]
+	for _, h := range r.Rotation.Order {
+		if h != handle {
+			order = append(order, h)
+		}
+	}
+	r.Rotation.Order = order
+	if len(r.Rotation.Order) == 0 {
+		r.Rotation.Order = nil
+	}
+
+	if r.Handoff != nil && r.Handoff.To == handle {
+		r.Handoff = nil
+	}
+	return nil
+}
+
+// rotationOrder returns the handles in rotation order.
+func (r *OverseerRoster) rotationOrder() []string {
+	if len(r.Rotation.Order) > 0 {
+		return r.Rotation.Order
+	}
+	handles := make([]string, 0, len(r.Overseers))
+	for _, m := range r.Overseers {
+		handles = append(handles, m.Handle)
+	}
+	return handles
+}
+
+// shiftLength returns the rotation shift length.
+func (r *OverseerRoster) shiftLength() time.Duration {
+	if d, err := time.ParseDuration(r.Rotation.Shift); err == nil && d > 0 {
+		return d
+	}
+	return DefaultOverseerShift
+}
+
+// rotationStart returns the start of the first shift.
+func (r *OverseerRoster) rotationStart() time.Time {
+	if t, err := time.Parse(time.RFC3339, r.Rotation.Start); err == nil {
+		return t
+	}
+	return time.Unix(0, 0).UTC()
+}
+
+// RotationShiftAt returns the rotation shift covering t, ignoring any
+// hand-off. It returns false if nobody is in the rotation.
+func (r *OverseerRoster) RotationShiftAt(t time.Time) (OnCallShift, bool) {
+	order := r.rotationOrder()
+	if len(order) == 0 {
+		return OnCallShift{}, false
+	}
+	shift := r.shiftLength()
+	start := r.rotationStart()
+
+	// Floor division so that times before Start map to earlier shifts.
+	elapsed := t.Sub(start)
+	n := int64(elapsed / shift)
+	if elapsed < 0 && elapsed%shift != 0 {
+		n--
+	}
+	idx := int(n % int64(len(order)))
+	if idx < 0 {
+		idx += len(order)
+	}
+	shiftStart := start.Add(time.Duration(n) * shift)
+	return OnCallShift{
+		Handle: order[idx],
+		Start:  shiftStart,
+		End:    shiftStart.Add(shift),
+	}, true
+}
+
+// activeHandoff returns the hand-off in force at t, if any.
+func (r *OverseerRoster) activeHandoff(t time.Time) (OnCallShift, bool) {
+	if r.Handoff == nil {
+		return OnCallShift{}, false
+	}
+	at, _ := time.Parse(time.RFC3339, r.Handoff.At)
+	until, err := time.Parse(time.RFC3339, r.Handoff.Until)
+	if err != nil || !t.Before(until) || t.Before(at) {
+		return OnCallShift{}, false
+	}
+	return OnCallShift{Handle: r.Handoff.To, Start: at, End: until, Handoff: true}, true
+}
+
+// ShiftAt returns the on-call shift covering t: an active hand-off if there
+// is one, otherwise the rotation. It returns false for an empty roster.
+func (r *OverseerRoster) ShiftAt(t time.Time) (OnCallShift, bool) {
+	if s, ok := r.activeHandoff(t); ok {
+		return s, true
+	}
+	return r.RotationShiftAt(t)
+}
+
+// OnCall returns the member on call at t.
+func (r *OverseerRoster) OnCall(t time.Time) (*OverseerMember, error) {
+	s, ok := r.ShiftAt(t)
+	if !ok {
+		return nil, errors.New("overseer roster is empty")
+	}
+	m := r.Member(s.Handle)
+	if m == nil {
+		return nil, fmt.Errorf("on-call overseer %q is not on the roster", s.Handle)
+	}
+	return m, nil
+}
+
+// Schedule returns the next n on-call shifts starting with the one covering
+// t. A hand-off in force at t is listed first and truncates the rotation
+// shift it overlaps.
+func (r *OverseerRoster) Schedule(t time.Time, n int) []OnCallShift {
+	var shifts []OnCallShift
+	cursor := t
+	if h, ok := r.activeHandoff(t); ok {
+		shifts = append(shifts, h)
+		cursor = h.End
+	}
+	for len(shifts) < n {
+		s, ok := r.RotationShiftAt(cursor)
+		if !ok {
+			break
+		}
+		if s.Start.Before(cursor) {
+			s.Start = cursor
+		}
+		shifts = append(shifts, s)
+		cursor = s.End
+	}
+	return shifts
+}
+
+// HandOff puts handle on call from now until the given time. A zero until
+// means the end of the rotation shift covering now.
+func (r *OverseerRoster) HandOff(handle, from string, now, until time.Time, reason string) error {
+	if r.Member(handle) == nil {
+		return fmt.Errorf("unknown overseer %q", handle)
+	}
+	if until.IsZero() {
+		s, ok := r.RotationShiftAt(now)
+		if !ok {
+			return errors.New("no rotation configured; give an explicit end time")
+		}
+		until = s.End
+	}
+	if !until.After(now) {
+		return fmt.Errorf("hand-off end %s is not in the future", until.Format(time.RFC3339))
+	}
+	r.Handoff = &OverseerHandoff{
+		To:     handle,
+		From:   from,
+		At:     now.UTC().Format(time.RFC3339),
+		Until:  until.UTC().Format(time.RFC3339),
+		Reason: reason,
+	}
+	return nil
+}
+
+// LoadTownOverseers returns the town's overseer roster. Towns without
+// mayor/overseers.json get a one-person roster built from
+// mayor/overseer.json; towns with neither get ErrNotFound.
+func LoadTownOverseers(townRoot string) (*OverseerRoster, error) {
+	roster, err := LoadOverseerRoster(OverseerRosterPath(townRoot))
+	if err == nil {
+		return roster, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	single, err := LoadOverseerConfig(OverseerConfigPath(townRoot))
+	if err != nil {
+		return nil, err
+	}
+	return NewOverseerRosterFrom(single), nil
+}
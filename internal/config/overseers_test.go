@@ -0,0 +1,189 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testRoster() *OverseerRoster {
+	r := NewOverseerRoster()
+	for _, h := range []string{"alice", "bob", "carol"} {
+		_ = r.Add(OverseerMember{Handle: h, Name: h, Email: h + "@example.com"})
+	}
+	r.Rotation = OverseerRotation{Shift: "24h", Start: "2026-10-12T09:00:00Z"}
+	return r
+}
+
+func TestOverseerRotationShiftAt(t *testing.T) {
+	r := testRoster()
+	start := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{start, "alice"},
+		{start.Add(23 * time.Hour), "alice"},
+		{start.Add(24 * time.Hour), "bob"},
+		{start.Add(50 * time.Hour), "carol"},
+		{start.Add(72 * time.Hour), "alice"},
+		{start.Add(-time.Hour), "carol"}, // before Start wraps backwards
+	}
+	for _, tt := range tests {
+		s, ok := r.RotationShiftAt(tt.at)
+		if !ok || s.Handle != tt.want {
+			t.Errorf("RotationShiftAt(%s) = %q, want %q", tt.at, s.Handle, tt.want)
+		}
+		if tt.at.Before(s.Start) || !tt.at.Before(s.End) {
+			t.Errorf("RotationShiftAt(%s) = [%s, %s), does not cover it", tt.at, s.Start, s.End)
+		}
+	}
+
+	r.Rotation.Order = []string{"carol", "alice"}
+	if s, _ := r.RotationShiftAt(start.Add(24 * time.Hour)); s.Handle != "alice" {
+		t.Errorf("explicit order: got %q, want alice", s.Handle)
+	}
+}
+
+func TestOverseerHandOff(t *testing.T) {
+	r := testRoster()
+	now := time.Date(2026, 10, 12, 15, 0, 0, 0, time.UTC) // alice's shift
+
+	if err := r.HandOff("dave", "alice", now, time.Time{}, ""); err == nil {
+		t.Fatal("hand-off to unknown overseer should fail")
+	}
+	if err := r.HandOff("bob", "alice", now, now.Add(-time.Minute), ""); err == nil {
+		t.Fatal("hand-off ending in the past should fail")
+	}
+
+	// Default end is the end of the current rotation shift.
+	if err := r.HandOff("carol", "alice", now, time.Time{}, "swap"); err != nil {
+		t.Fatalf("HandOff: %v", err)
+	}
+	if r.Handoff.Until != "2026-10-13T09:00:00Z" {
+		t.Errorf("Until = %s, want end of shift", r.Handoff.Until)
+	}
+	m, err := r.OnCall(now.Add(time.Hour))
+	if err != nil || m.Handle != "carol" {
+		t.Fatalf("OnCall during hand-off = %v, %v", m, err)
+	}
+	if m, _ := r.OnCall(now.Add(18 * time.Hour)); m.Handle != "bob" {
+		t.Errorf("OnCall after hand-off = %q, want bob", m.Handle)
+	}
+
+	shifts := r.Schedule(now, 3)
+	want := []string{"carol", "bob", "carol"}
+	if len(shifts) != len(want) {
+		t.Fatalf("Schedule = %v", shifts)
+	}
+	for i, s := range shifts {
+		if s.Handle != want[i] {
+			t.Errorf("Schedule[%d] = %q, want %q", i, s.Handle, want[i])
+		}
+	}
+	if !shifts[0].Handoff || shifts[1].Handoff {
+		t.Errorf("only the first shift should be a hand-off: %+v", shifts)
+	}
+
+	// Removing the covering overseer cancels the hand-off.
+	if err := r.Remove("carol"); err != nil {
+		t.Fatal(err)
+	}
+	if r.Handoff != nil {
+		t.Error("hand-off to a removed overseer should be cleared")
+	}
+}
+
+func TestOverseerRosterValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*OverseerRoster)
+	}{
+		{"bad handle", func(r *OverseerRoster) { r.Overseers[0].Handle = "a/b" }},
+		{"duplicate handle", func(r *OverseerRoster) { r.Overseers[1].Handle = "alice" }},
+		{"unknown in order", func(r *OverseerRoster) { r.Rotation.Order = []string{"zed"} }},
+		{"bad shift", func(r *OverseerRoster) { r.Rotation.Shift = "-1h" }},
+		{"bad start", func(r *OverseerRoster) { r.Rotation.Start = "monday" }},
+		{"bad hand-off", func(r *OverseerRoster) { r.Handoff = &OverseerHandoff{To: "zed", Until: "2026-10-13T09:00:00Z"} }},
+	}
+	for _, tt := range tests {
+		r := testRoster()
+		tt.mutate(r)
+		if err := validateOverseerRoster(r); err == nil {
+			t.Errorf("%s: expected validation error", tt.name)
+		}
+	}
+	if err := validateOverseerRoster(testRoster()); err != nil {
+		t.Errorf("valid roster: %v", err)
+	}
+}
+
+func TestLoadTownOverseers(t *testing.T) {
+	town := t.TempDir()
+
+	if _, err := LoadTownOverseers(town); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("empty town: err = %v, want ErrNotFound", err)
+	}
+
+	// A single overseer becomes a one-person roster.
+	if err := SaveOverseerConfig(OverseerConfigPath(town), &OverseerConfig{Name: "Steve Yegge", Email: "steve@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := LoadTownOverseers(town)
+	if err != nil {
+		t.Fatalf("LoadTownOverseers: %v", err)
+	}
+	if len(r.Overseers) != 1 || r.Overseers[0].Handle != "steve-yegge" {
+		t.Fatalf("seeded roster = %+v", r.Overseers)
+	}
+	if _, err := os.Stat(OverseerRosterPath(town)); !os.IsNotExist(err) {
+		t.Error("loading must not create overseers.json")
+	}
+
+	// An explicit roster takes precedence and round-trips.
+	if err := SaveOverseerRoster(OverseerRosterPath(town), testRoster()); err != nil {
+		t.Fatal(err)
+	}
+	r, err = LoadTownOverseers(town)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Overseers) != 3 || r.Type != "overseer-roster" || r.Rotation.Shift != "24h" {
+		t.Errorf("loaded roster = %+v", r)
+	}
+
+	if err := os.WriteFile(filepath.Join(town, "mayor", "overseers.json"), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTownOverseers(town); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("wrong type: err = %v", err)
+	}
+}
+
+func TestParseOverseerAddress(t *testing.T) {
+	tests := []struct {
+		addr       string
+		wantHandle string
+		wantOK     bool
+		isOverseer bool
+	}{
+		{"overseer", "", false, true},
+		{"overseer/alice", "alice", true, true},
+		{"overseer/alice/", "alice", true, true},
+		{"overseer/", "", false, true},
+		{"mayor/", "", false, false},
+		{"overseers/alice", "", false, false},
+	}
+	for _, tt := range tests {
+		h, ok := ParseOverseerAddress(tt.addr)
+		if h != tt.wantHandle || ok != tt.wantOK {
+			t.Errorf("ParseOverseerAddress(%q) = %q, %v", tt.addr, h, ok)
+		}
+		if got := IsOverseerAddress(tt.addr); got != tt.isOverseer {
+			t.Errorf("IsOverseerAddress(%q) = %v", tt.addr, got)
+		}
+	}
+}
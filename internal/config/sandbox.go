@@ -0,0 +1,291 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// Sandbox network modes.
+const (
+	// SandboxNetworkProxy gives the polecat a private network namespace whose
+	// only ways out are gt-proxy-server and an egress allow-list.
+	SandboxNetworkProxy = "proxy"
+	// SandboxNetworkNone gives the polecat loopback only.
+	SandboxNetworkNone = "none"
+	// SandboxNetworkHost shares the host network (no isolation).
+	SandboxNetworkHost = "host"
+)
+
+// DefaultSandboxProfile is the built-in profile used when a rig selects
+// "standard" without declaring it.
+const DefaultSandboxProfile = "standard"
+
+// DefaultSandboxCertTTL is the lifetime of the proxy cert issued at each
+// sandboxed launch.
+const DefaultSandboxCertTTL = 24 * time.Hour
+
+// ErrInvalidSandbox indicates an invalid sandbox profile.
+var ErrInvalidSandbox = errors.New("invalid sandbox profile")
+
+// SandboxConfig declares the sandbox profiles polecats in a rig may run under
+// and selects one. Witness, refinery and crew sessions are never sandboxed.
+type SandboxConfig struct {
+	// Profile names the profile polecats launch under: a key of Profiles or
+	// a built-in ("standard"). Empty disables sandboxing.
+	Profile string `json:"profile,omitempty"`
+
+	// Profiles declares rig-specific profiles. An entry named like a
+	// built-in replaces it.
+	Profiles map[string]*SandboxProfile `json:"profiles,omitempty"`
+}
+
+// SandboxProfile describes what a sandboxed polecat may touch.
+//
+// The whole filesystem is mounted read-only except the polecat's worktree,
+// its git directory and ReadWrite. /tmp is a private tmpfs.
+type SandboxProfile struct {
+	// ReadWrite lists extra writable paths. Relative paths are rooted at the
+	// worktree; "~/" expands to the user's home. Missing paths are skipped.
+	ReadWrite []string `json:"read_write,omitempty"`
+
+	// Hidden lists paths replaced by an empty directory, e.g. "~/.ssh".
+	Hidden []string `json:"hidden,omitempty"`
+
+	// Network is "proxy" (default), "none" or "host".
+	Network string `json:"network,omitempty"`
+
+	// EgressAllow lists host:port destinations the agent may reach through
+	// the sandbox's HTTPS proxy in "proxy" mode, e.g. "api.anthropic.com:443".
+	// A leading "*." matches any subdomain.
+	EgressAllow []string `json:"egress_allow,omitempty"`
+
+	// Memory caps memory use, e.g. "8G" or "512M". Empty means no limit.
+	Memory string `json:"memory,omitempty"`
+
+	// CPUs caps CPU time in cores, e.g. 2 or 0.5. Zero means no limit.
+	CPUs float64 `json:"cpus,omitempty"`
+
+	// Pids caps the number of processes. Zero means no limit.
+	Pids int `json:"pids,omitempty"`
+
+	// CertTTL is the lifetime of the proxy client cert issued at launch.
+	// Default: 24h.
+	CertTTL string `json:"cert_ttl,omitempty"`
+}
+
+// BuiltinSandboxProfiles returns the profiles every rig can select by name.
+func BuiltinSandboxProfiles() map[string]*SandboxProfile {
+	return map[string]*SandboxProfile{
+		DefaultSandboxProfile: {
+			ReadWrite:   []string{"~/.claude", "~/.claude.json", "~/.cache"},
+			Hidden:      []string{"~/.ssh", "~/.aws", "~/.config/gh"},
+			Network:     SandboxNetworkProxy,
+			EgressAllow: []string{"api.anthropic.com:443", "statsig.anthropic.com:443"},
+			Memory:      "8G",
+			Pids:        4096,
+		},
+	}
+}
+
+// NetworkMode returns the profile's network mode, defaulting to proxy.
+func (p *SandboxProfile) NetworkMode() string {
+	if p.Network == "" {
+		return SandboxNetworkProxy
+	}
+	return p.Network
+}
+
+// MemoryBytes returns the memory limit in bytes, or 0 for no limit.
+func (p *SandboxProfile) MemoryBytes() (int64, error) {
+	return ParseByteSize(p.Memory)
+}
+
+// CertLifetime returns the proxy cert lifetime.
+func (p *SandboxProfile) CertLifetime() time.Duration {
+	if d, err := time.ParseDuration(p.CertTTL); err == nil && d > 0 {
+		return d
+	}
+	return DefaultSandboxCertTTL
+}
+
+// HasLimits reports whether the profile sets any resource limit.
+func (p *SandboxProfile) HasLimits() bool {
+	return p.Memory != "" || p.CPUs > 0 || p.Pids > 0
+}
+
+// ParseByteSize parses sizes like "512M", "8G" or "1048576" (binary units).
+// An empty string is 0.
+func ParseByteSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	mult := int64(1)
+	upper := strings.ToUpper(strings.TrimSuffix(strings.TrimSuffix(s, "iB"), "B"))
+	switch {
+	case strings.HasSuffix(upper, "K"):
+		mult = 1 << 10
+	case strings.HasSuffix(upper, "M"):
+		mult = 1 << 20
+	case strings.HasSuffix(upper, "G"):
+		mult = 1 << 30
+	case strings.HasSuffix(upper, "T"):
+		mult = 1 << 40
+	}
+	if mult > 1 {
+		upper = upper[:len(upper)-1]
+	}
+	n, err := strconv.ParseFloat(upper, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// Active returns the selected profile and its name, or nil when sandboxing
+// is off.
+func (c *SandboxConfig) Active() (string, *SandboxProfile, error) {
+	if c == nil || c.Profile == "" {
+		return "", nil, nil
+	}
+	if p, ok := c.Profiles[c.Profile]; ok && p != nil {
+		return c.Profile, p, nil
+	}
+	if p, ok := BuiltinSandboxProfiles()[c.Profile]; ok {
+		return c.Profile, p, nil
+	}
+	return "", nil, fmt.Errorf("%w: unknown profile %q (have %s)", ErrInvalidSandbox, c.Profile, strings.Join(c.ProfileNames(), ", "))
+}
+
+// ProfileNames lists declared and built-in profile names, sorted.
+func (c *SandboxConfig) ProfileNames() []string {
+	seen := make(map[string]bool)
+	for name := range BuiltinSandboxProfiles() {
+		seen[name] = true
+	}
+	if c != nil {
+		for name := range c.Profiles {
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateSandboxConfig validates the selection and every declared profile.
+func validateSandboxConfig(c *SandboxConfig) error {
+	for name, p := range c.Profiles {
+		if p == nil {
+			return fmt.Errorf("%w: profile %q is empty", ErrInvalidSandbox, name)
+		}
+		if err := ValidateSandboxProfile(p); err != nil {
+			return fmt.Errorf("profile %q: %w", name, err)
+		}
+	}
+	_, _, err := c.Active()
+	return err
+}
+
+// ValidateSandboxProfile checks a profile's fields.
+func ValidateSandboxProfile(p *SandboxProfile) error {
+	switch p.Network {
+	case "", SandboxNetworkProxy, SandboxNetworkNone, SandboxNetworkHost:
+	default:
+		return fmt.Errorf("%w: network must be %q, %q or %q, got %q",
+			ErrInvalidSandbox, SandboxNetworkProxy, SandboxNetworkNone, SandboxNetworkHost, p.Network)
+	}
+	for _, hp := range p.EgressAllow {
+		if _, port, err := net.SplitHostPort(hp); err != nil || port == "" {
+			return fmt.Errorf("%w: egress_allow entry %q must be host:port", ErrInvalidSandbox, hp)
+		}
+	}
+	if _, err := p.MemoryBytes(); err != nil {
+		return fmt.Errorf("%w: memory: %v", ErrInvalidSandbox, err)
+	}
+	if p.CPUs < 0 || p.Pids < 0 {
+		return fmt.Errorf("%w: cpus and pids must not be negative", ErrInvalidSandbox)
+	}
+	if p.CertTTL != "" {
+		if d, err := time.ParseDuration(p.CertTTL); err != nil || d <= 0 {
+			return fmt.Errorf("%w: cert_ttl %q is not a positive duration", ErrInvalidSandbox, p.CertTTL)
+		}
+	}
+	for _, path := range append(append([]string{}, p.ReadWrite...), p.Hidden...) {
+		if path == "" || path == "/" || path == "~" || path == "~/" {
+			return fmt.Errorf("%w: path %q would expose too much", ErrInvalidSandbox, path)
+		}
+	}
+	return nil
+}
+
+// ResolveRigSandbox returns the sandbox profile polecats in the rig at
+// rigPath launch under, or nil when the rig is not sandboxed.
+func ResolveRigSandbox(rigPath string) (string, *SandboxProfile, error) {
+	if rigPath == "" {
+		return "", nil, nil
+	}
+	settings, err := LoadRigSettings(RigSettingsPath(rigPath))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+	return settings.Sandbox.Active()
+}
+
+// SandboxExecWrapper is the command prefix that launches an agent under the
+// rig's sandbox profile. gt sandbox exec reads the rig, polecat and worktree
+// from GT_RIG, GT_POLECAT and GT_POLECAT_PATH.
+var SandboxExecWrapper = []string{"gt", "sandbox", "exec", "--"}
+
+// withSandboxWrapper puts SandboxExecWrapper in front of rc's exec wrapper
+// when role is a polecat in a sandboxed rig. A rig whose sandbox settings
+// can't be resolved still launches through the wrapper, which then refuses
+// to start the agent rather than running it unsandboxed.
+func withSandboxWrapper(rc *RuntimeConfig, role, rigPath string) {
+	if role != constants.RolePolecat {
+		return
+	}
+	name, _, err := ResolveRigSandbox(rigPath)
+	if err == nil && name == "" {
+		return
+	}
+	rc.ExecWrapper = append(append([]string{}, SandboxExecWrapper...), rc.ExecWrapper...)
+}
+
+// expandSandboxPath resolves a profile path: "~/" against home, relative
+// paths against worktree.
+func expandSandboxPath(path, home, worktree string) string {
+	switch {
+	case strings.HasPrefix(path, "~/"):
+		return filepath.Join(home, path[2:])
+	case filepath.IsAbs(path):
+		return filepath.Clean(path)
+	default:
+		return filepath.Join(worktree, path)
+	}
+}
+
+// SandboxPaths resolves the profile's ReadWrite and Hidden paths.
+func (p *SandboxProfile) SandboxPaths(home, worktree string) (readWrite, hidden []string) {
+	for _, path := range p.ReadWrite {
+		readWrite = append(readWrite, expandSandboxPath(path, home, worktree))
+	}
+	for _, path := range p.Hidden {
+		hidden = append(hidden, expandSandboxPath(path, home, worktree))
+	}
+	return readWrite, hidden
+}
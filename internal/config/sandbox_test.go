@@ -0,0 +1,142 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in   string
+		want int64
+		ok   bool
+	}{
+		{"", 0, true},
+		{"1048576", 1 << 20, true},
+		{"512M", 512 << 20, true},
+		{"8G", 8 << 30, true},
+		{"8GiB", 8 << 30, true},
+		{"1.5k", 1536, true},
+		{"lots", 0, false},
+		{"-1G", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %d, %v; want %d, ok=%v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSandboxConfigActive(t *testing.T) {
+	t.Parallel()
+	var nilConfig *SandboxConfig
+	if name, p, err := nilConfig.Active(); name != "" || p != nil || err != nil {
+		t.Errorf("nil config: got %q, %v, %v", name, p, err)
+	}
+
+	c := &SandboxConfig{Profile: DefaultSandboxProfile}
+	name, p, err := c.Active()
+	if err != nil || name != DefaultSandboxProfile || p.NetworkMode() != SandboxNetworkProxy {
+		t.Errorf("builtin: got %q, %+v, %v", name, p, err)
+	}
+
+	// A declared profile overrides the built-in of the same name.
+	c.Profiles = map[string]*SandboxProfile{DefaultSandboxProfile: {Network: SandboxNetworkNone}}
+	if _, p, _ := c.Active(); p.NetworkMode() != SandboxNetworkNone {
+		t.Errorf("override: network = %q", p.NetworkMode())
+	}
+
+	c.Profile = "missing"
+	if _, _, err := c.Active(); !errors.Is(err, ErrInvalidSandbox) {
+		t.Errorf("unknown profile: err = %v", err)
+	}
+}
+
+func TestValidateSandboxProfile(t *testing.T) {
+	t.Parallel()
+	for name, p := range BuiltinSandboxProfiles() {
+		if err := ValidateSandboxProfile(p); err != nil {
+			t.Errorf("builtin %q: %v", name, err)
+		}
+	}
+	bad := map[string]*SandboxProfile{
+		"network":  {Network: "vpn"},
+		"egress":   {EgressAllow: []string{"api.anthropic.com"}},
+		"memory":   {Memory: "plenty"},
+		"cpus":     {CPUs: -1},
+		"cert_ttl": {CertTTL: "soon"},
+		"home":     {ReadWrite: []string{"~"}},
+		"root":     {Hidden: []string{"/"}},
+	}
+	for name, p := range bad {
+		if err := ValidateSandboxProfile(p); !errors.Is(err, ErrInvalidSandbox) {
+			t.Errorf("%s: err = %v, want ErrInvalidSandbox", name, err)
+		}
+	}
+}
+
+func TestSandboxPaths(t *testing.T) {
+	t.Parallel()
+	p := &SandboxProfile{
+		ReadWrite: []string{"~/.claude", "build", "/var/cache/go"},
+		Hidden:    []string{"~/.ssh"},
+	}
+	rw, hidden := p.SandboxPaths("/home/me", "/town/rig/polecats/nux")
+	want := []string{"/home/me/.claude", "/town/rig/polecats/nux/build", "/var/cache/go"}
+	if strings.Join(rw, ",") != strings.Join(want, ",") {
+		t.Errorf("ReadWrite = %v, want %v", rw, want)
+	}
+	if len(hidden) != 1 || hidden[0] != "/home/me/.ssh" {
+		t.Errorf("Hidden = %v", hidden)
+	}
+}
+
+func TestBuildStartupCommand_SandboxWrapper(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "testrig")
+
+	rigSettings := NewRigSettings()
+	rigSettings.Runtime = &RuntimeConfig{
+		Command:     "claude",
+		ExecWrapper: []string{"exitbox", "run", "--"},
+	}
+	rigSettings.Sandbox = &SandboxConfig{Profile: DefaultSandboxProfile}
+	if err := SaveRigSettings(RigSettingsPath(rigPath), rigSettings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	cmd := BuildStartupCommand(map[string]string{"GT_ROLE": "polecat"}, rigPath, "hello")
+	if !strings.Contains(cmd, "gt sandbox exec -- exitbox run -- claude") {
+		t.Errorf("expected sandbox wrapper before the rig's exec wrapper, got: %q", cmd)
+	}
+
+	cmd = BuildStartupCommand(map[string]string{"GT_ROLE": "witness"}, rigPath, "hello")
+	if strings.Contains(cmd, "gt sandbox exec") {
+		t.Errorf("only polecats are sandboxed, got: %q", cmd)
+	}
+}
+
+func TestBuildStartupCommand_InvalidSandboxFailsClosed(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "testrig")
+	path := RigSettingsPath(rigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	body := `{"type":"rig-settings","version":1,"sandbox":{"profile":"missing"}}`
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := BuildStartupCommand(map[string]string{"GT_ROLE": "polecat"}, rigPath, "hello")
+	if !strings.Contains(cmd, "gt sandbox exec --") {
+		t.Errorf("a rig with broken sandbox settings must still launch through the wrapper, got: %q", cmd)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/agentlog"
+	"github.com/steveyegge/gastown/internal/budget"
 	"github.com/steveyegge/gastown/internal/scheduler/capacity"
 )
 
@@ -81,6 +83,10 @@ type TownSettings struct {
 	// WebTimeouts configures command execution timeouts for the web dashboard.
 	WebTimeouts *WebTimeoutsConfig `json:"web_timeouts,omitempty"`
 
+	// DashboardAuth configures authentication and roles for gt dashboard.
+	// Required when the dashboard is bound to a non-loopback address.
+	DashboardAuth *DashboardAuthConfig `json:"dashboard_auth,omitempty"`
+
 	// WorkerStatus configures activity-age thresholds for worker status classification.
 	WorkerStatus *WorkerStatusConfig `json:"worker_status,omitempty"`
 
@@ -95,9 +101,23 @@ type TownSettings struct {
 	// Values: "standard", "economy", "budget", or empty for custom configs.
 	CostTier string `json:"cost_tier,omitempty"`
 
+	// SessionBackend selects where agent sessions run.
+	// Values: "tmux" (default), "headless" (PTYs supervised by the daemon,
+	// for hosts without tmux). Can be overridden by GT_SESSION_BACKEND.
+	SessionBackend string `json:"session_backend,omitempty"`
+
 	// Scheduler configures the capacity scheduler for polecat dispatch.
 	Scheduler *capacity.SchedulerConfig `json:"scheduler,omitempty"`
 
+	// Budgets configures daily/monthly cost budgets for the town, rigs, roles
+	// and convoys. Enforced by gt costs budget --enforce (run by the daemon's
+	// budget patrol) and checked by gt sling.
+	Budgets *budget.Config `json:"budgets,omitempty"`
+
+	// Pricing overrides or extends the built-in per-model token prices used by
+	// gt costs, keyed by model name or name prefix (e.g. "gpt-5").
+	Pricing agentlog.PriceTable `json:"pricing,omitempty"`
+
 	// Operational configures operational thresholds (timeouts, retries, intervals).
 	// These were previously hardcoded as Go constants throughout the codebase.
 	// All values are optional — omitted values use compiled-in defaults.
@@ -141,6 +161,37 @@ type WebTimeoutsConfig struct {
 	MaxRunTimeout string `json:"max_run_timeout,omitempty"`
 }
 
+// DashboardAuthConfig configures who may access the web dashboard.
+// Callers authenticate with a bearer token or with a client certificate
+// issued by the town CA (~/gt/.runtime/ca). Each credential carries a role:
+// "viewer" (read-only) or "operator" (may run commands, send mail, edit
+// issues and preview sessions).
+type DashboardAuthConfig struct {
+	// Tokens are the accepted bearer tokens. Only SHA-256 hashes are stored.
+	Tokens []DashboardToken `json:"tokens,omitempty"`
+	// ClientCerts maps client certificate common names to roles.
+	ClientCerts map[string]string `json:"client_certs,omitempty"`
+	// Required enforces authentication even on loopback binds.
+	Required bool `json:"required,omitempty"`
+}
+
+// DashboardToken is a named bearer token for the web dashboard.
+type DashboardToken struct {
+	// Name identifies the token holder in the audit log.
+	Name string `json:"name"`
+	// Role is "viewer" or "operator".
+	Role string `json:"role"`
+	// SHA256 is the hex-encoded SHA-256 hash of the token.
+	SHA256 string `json:"sha256"`
+	// CreatedAt is the RFC 3339 creation time (informational).
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// HasCredentials reports whether any tokens or client certificates are configured.
+func (c *DashboardAuthConfig) HasCredentials() bool {
+	return c != nil && (len(c.Tokens) > 0 || len(c.ClientCerts) > 0)
+}
+
 // DefaultWebTimeoutsConfig returns a WebTimeoutsConfig with sensible defaults.
 func DefaultWebTimeoutsConfig() *WebTimeoutsConfig {
 	return &WebTimeoutsConfig{
@@ -669,6 +720,10 @@ type RigSettings struct {
 	// Takes precedence over RoleAgents["crew"] but is overridden by explicit --agent flags.
 	// Example: {"denali": "codex", "glacier": "gemini"}
 	WorkerAgents map[string]string `json:"worker_agents,omitempty"`
+
+	// Sandbox declares sandbox profiles and selects the one polecats in this
+	// rig launch under. See SandboxConfig.
+	Sandbox *SandboxConfig `json:"sandbox,omitempty"`
 }
 
 // CrewConfig represents crew workspace settings for a rig.
@@ -1280,7 +1335,9 @@ type MergeQueueConfig struct {
 	// PollInterval is how often to poll for new merge requests (e.g., "30s").
 	PollInterval string `json:"poll_interval"`
 
-	// MaxConcurrent is the maximum number of concurrent merges.
+	// MaxConcurrent is the maximum number of merge batches gated concurrently.
+	// Above 1, the refinery speculatively gates later batches on the predicted
+	// result of earlier ones.
 	MaxConcurrent int `json:"max_concurrent"`
 
 	// StaleClaimTimeout is how long a claimed MR can go without updates before
@@ -1557,9 +1614,14 @@ type EscalationConfig struct {
 	// Action formats:
 	//   - "bead"        → Create escalation bead (always first, implicit)
 	//   - "mail:<target>" → Send gt mail to target (e.g., "mail:mayor")
+	//   - "mail:@overseer" → Send gt mail to the on-call overseer
 	//   - "email:human" → Send email to contacts.human_email
+	//   - "email:oncall" → Send email to the on-call overseer (mayor/overseers.json)
+	//   - "email:<handle>" → Send email to a named overseer on the roster
 	//   - "sms:human"   → Send SMS to contacts.human_sms
+	//   - "sms:oncall", "sms:<handle>" → Send SMS to the on-call or a named overseer
 	//   - "slack"       → Post to contacts.slack_webhook
+	//   - "slack:oncall", "slack:<handle>" → Post to an overseer's own webhook
 	//   - "log"         → Write to escalation log file
 	Routes map[string][]string `json:"routes"`
 
@@ -16,6 +16,10 @@ type Machine struct {
 	Host     string `json:"host"`      // for ssh: user@host
 	KeyPath  string `json:"key_path"`  // SSH private key path
 	TownPath string `json:"town_path"` // Path to town root on remote
+
+	// KnownHostsPath overrides the known_hosts file used to verify the
+	// host key of ssh machines (default ~/.ssh/known_hosts).
+	KnownHostsPath string `json:"known_hosts_path,omitempty"`
 }
 
 // registryData is the JSON file structure.
@@ -29,6 +33,7 @@ type MachineRegistry struct {
 	path     string
 	machines map[string]*Machine
 	mu       sync.RWMutex
+	pool     *sshPool // shared SSH clients for ssh machines
 }
 
 // NewMachineRegistry creates a registry from the given config file path.
@@ -37,6 +42,7 @@ func NewMachineRegistry(configPath string) (*MachineRegistry, error) {
 	r := &MachineRegistry{
 		path:     configPath,
 		machines: make(map[string]*Machine),
+		pool:     newSSHPool(),
 	}
 
 	// Load existing config if present
@@ -176,8 +182,7 @@ func (r *MachineRegistry) Connection(name string) (Connection, error) {
 	case "local":
 		return NewLocalConnection(), nil
 	case "ssh":
-		// SSH connection not yet implemented
-		return nil, fmt.Errorf("ssh connections not yet implemented")
+		return newSSHConnection(m, r.pool), nil
 	default:
 		return nil, fmt.Errorf("unknown machine type: %s", m.Type)
 	}
@@ -188,3 +193,8 @@ func (r *MachineRegistry) Connection(name string) (Connection, error) {
 func (r *MachineRegistry) LocalConnection() *LocalConnection {
 	return NewLocalConnection()
 }
+
+// Close releases pooled SSH connections held by the registry.
+func (r *MachineRegistry) Close() error {
+	return r.pool.Close()
+}
@@ -0,0 +1,595 @@
+package connection
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// sshDialTimeout bounds how long establishing an SSH connection may take.
+const sshDialTimeout = 15 * time.Second
+
+// SSHConnection implements Connection for a remote machine over SSH.
+// File operations use SFTP; commands and tmux verbs run in SSH sessions.
+// The underlying SSH client is shared through an sshPool, so creating
+// many SSHConnections to the same machine opens a single TCP connection.
+type SSHConnection struct {
+	machine *Machine
+	pool    *sshPool
+}
+
+// sharedSSHPool holds the SSH clients of connections made with
+// NewSSHConnection. Registries keep a pool of their own.
+var sharedSSHPool = newSSHPool()
+
+// NewSSHConnection creates a connection to an ssh-type machine. Connections
+// to the same machine share one SSH client.
+func NewSSHConnection(m *Machine) *SSHConnection {
+	return newSSHConnection(m, sharedSSHPool)
+}
+
+// newSSHConnection creates a connection whose SSH client comes from pool.
+func newSSHConnection(m *Machine, pool *sshPool) *SSHConnection {
+	return &SSHConnection{machine: m, pool: pool}
+}
+
+// Name returns the machine name.
+func (c *SSHConnection) Name() string {
+	return c.machine.Name
+}
+
+// IsLocal returns false for SSH connections.
+func (c *SSHConnection) IsLocal() bool {
+	return false
+}
+
+// ReadFile reads the named remote file.
+func (c *SSHConnection) ReadFile(path string) ([]byte, error) {
+	client, err := c.sftp()
+	if err != nil {
+		return nil, err
+	}
+	f, err := client.Open(path)
+	if err != nil {
+		return nil, mapRemoteError(err, path, "read")
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, mapRemoteError(err, path, "read")
+	}
+	return data, nil
+}
+
+// WriteFile writes data to the named remote file, creating or truncating it.
+func (c *SSHConnection) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	client, err := c.sftp()
+	if err != nil {
+		return err
+	}
+	f, err := client.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return mapRemoteError(err, path, "write")
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return mapRemoteError(err, path, "write")
+	}
+	if err := f.Close(); err != nil {
+		return mapRemoteError(err, path, "write")
+	}
+	if err := client.Chmod(path, perm); err != nil {
+		return mapRemoteError(err, path, "write")
+	}
+	return nil
+}
+
+// MkdirAll creates a remote directory and all parent directories.
+func (c *SSHConnection) MkdirAll(path string, perm fs.FileMode) error {
+	client, err := c.sftp()
+	if err != nil {
+		return err
+	}
+	if err := client.MkdirAll(path); err != nil {
+		return mapRemoteError(err, path, "mkdir")
+	}
+	if err := client.Chmod(path, perm); err != nil {
+		return mapRemoteError(err, path, "mkdir")
+	}
+	return nil
+}
+
+// Remove removes the named remote file or empty directory.
+func (c *SSHConnection) Remove(path string) error {
+	client, err := c.sftp()
+	if err != nil {
+		return err
+	}
+	if err := client.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil // Already gone
+		}
+		return mapRemoteError(err, path, "remove")
+	}
+	return nil
+}
+
+// RemoveAll removes the named remote file or directory and any children.
+func (c *SSHConnection) RemoveAll(path string) error {
+	client, err := c.sftp()
+	if err != nil {
+		return err
+	}
+	if err := client.RemoveAll(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return mapRemoteError(err, path, "remove")
+	}
+	return nil
+}
+
+// Stat returns file info for the named remote file.
+func (c *SSHConnection) Stat(path string) (FileInfo, error) {
+	client, err := c.sftp()
+	if err != nil {
+		return nil, err
+	}
+	fi, err := client.Stat(path)
+	if err != nil {
+		return nil, mapRemoteError(err, path, "stat")
+	}
+	return FromOSFileInfo(fi), nil
+}
+
+// Glob returns the names of all remote files matching the pattern.
+func (c *SSHConnection) Glob(pattern string) ([]string, error) {
+	client, err := c.sftp()
+	if err != nil {
+		return nil, err
+	}
+	matches, err := client.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// Exists returns true if the remote path exists.
+func (c *SSHConnection) Exists(path string) (bool, error) {
+	_, err := c.Stat(path)
+	if err != nil {
+		var nf *NotFoundError
+		if errors.As(err, &nf) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Exec runs a command on the remote machine and returns its combined output.
+func (c *SSHConnection) Exec(cmd string, args ...string) ([]byte, error) {
+	return c.run(shellJoin(cmd, args...))
+}
+
+// ExecDir runs a command in the specified remote directory.
+func (c *SSHConnection) ExecDir(dir, cmd string, args ...string) ([]byte, error) {
+	return c.run("cd " + shellQuote(dir) + " && " + shellJoin(cmd, args...))
+}
+
+// ExecEnv runs a command with additional environment variables.
+// Variables are passed through env(1) rather than the SSH "env" request,
+// which most sshd configurations reject (AcceptEnv).
+func (c *SSHConnection) ExecEnv(env map[string]string, cmd string, args ...string) ([]byte, error) {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	envArgs := make([]string, 0, len(env)+len(args)+1)
+	for _, k := range keys {
+		envArgs = append(envArgs, k+"="+env[k])
+	}
+	envArgs = append(envArgs, cmd)
+	envArgs = append(envArgs, args...)
+	return c.run(shellJoin("env", envArgs...))
+}
+
+// TmuxNewSession creates a new tmux session on the remote machine.
+func (c *SSHConnection) TmuxNewSession(name, dir string) error {
+	args := []string{"new-session", "-d", "-s", name}
+	if dir != "" {
+		args = append(args, "-c", dir)
+	}
+	if _, err := c.tmux(args...); err != nil {
+		return err
+	}
+	// Match tmux.NewSession: let the window follow the attaching client's size.
+	_, _ = c.tmux("set-option", "-wt", name, "window-size", "latest")
+	return nil
+}
+
+// TmuxKillSession terminates a remote tmux session and its processes.
+// Mirrors KillSessionWithProcesses: disarm respawn, signal the pane's process
+// tree, then kill the session. Missing sessions are not an error.
+func (c *SSHConnection) TmuxKillSession(name string) error {
+	t := shellQuote(name)
+	script := strings.Join([]string{
+		"tmux set-option -t " + t + " remain-on-exit off 2>/dev/null",
+		"tmux set-hook -t " + t + " -u pane-died 2>/dev/null",
+		"pid=$(tmux display-message -p -t " + t + " '#{pane_pid}' 2>/dev/null)",
+		`if [ -n "$pid" ]; then pkill -TERM -P "$pid" 2>/dev/null; kill -TERM "$pid" 2>/dev/null; fi`,
+		"tmux kill-session -t " + t + " 2>/dev/null",
+		"true",
+	}, "; ")
+	_, err := c.run(script)
+	return err
+}
+
+// TmuxSendKeys sends keys to a remote tmux session, followed by Enter.
+func (c *SSHConnection) TmuxSendKeys(session, keys string) error {
+	if _, err := c.tmux("send-keys", "-t", session, "-l", keys); err != nil {
+		return err
+	}
+	_, err := c.tmux("send-keys", "-t", session, "Enter")
+	return err
+}
+
+// TmuxCapturePane captures the last N lines from a remote tmux pane.
+func (c *SSHConnection) TmuxCapturePane(session string, lines int) (string, error) {
+	return c.tmux("capture-pane", "-p", "-t", session, "-S", fmt.Sprintf("-%d", lines))
+}
+
+// TmuxHasSession returns true if the remote session exists.
+func (c *SSHConnection) TmuxHasSession(name string) (bool, error) {
+	_, err := c.tmux("has-session", "-t", "="+name)
+	if err != nil {
+		if isTmuxAbsent(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// TmuxListSessions returns all remote tmux session names.
+func (c *SSHConnection) TmuxListSessions() ([]string, error) {
+	out, err := c.tmux("list-sessions", "-F", "#{session_name}")
+	if err != nil {
+		if isTmuxAbsent(err) {
+			return nil, nil // No server = no sessions
+		}
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// tmux runs a tmux subcommand remotely and returns trimmed output.
+func (c *SSHConnection) tmux(args ...string) (string, error) {
+	out, err := c.Exec("tmux", args...)
+	if err != nil {
+		return "", &tmuxError{args: args, output: strings.TrimSpace(string(out)), err: err}
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// tmuxError carries tmux's stderr so callers can classify failures.
+type tmuxError struct {
+	args   []string
+	output string
+	err    error
+}
+
+func (e *tmuxError) Error() string {
+	return fmt.Sprintf("tmux %s: %s (%v)", strings.Join(e.args, " "), e.output, e.err)
+}
+
+func (e *tmuxError) Unwrap() error { return e.err }
+
+// isTmuxAbsent reports whether a tmux failure means "no such session" or
+// "no server running" rather than a real error.
+func isTmuxAbsent(err error) bool {
+	var te *tmuxError
+	if !errors.As(err, &te) {
+		return false
+	}
+	return strings.Contains(te.output, "no server running") ||
+		strings.Contains(te.output, "can't find session") ||
+		strings.Contains(te.output, "session not found") ||
+		strings.Contains(te.output, "error connecting to")
+}
+
+// run executes a shell command line in a new SSH session.
+func (c *SSHConnection) run(cmdline string) ([]byte, error) {
+	client, err := c.pool.client(c.machine)
+	if err != nil {
+		return nil, err
+	}
+	session, err := client.NewSession()
+	if err != nil {
+		// The pooled client may have gone stale; drop it and retry once.
+		c.pool.evict(c.machine)
+		if client, err = c.pool.client(c.machine); err != nil {
+			return nil, err
+		}
+		if session, err = client.NewSession(); err != nil {
+			return nil, &ConnectionError{Op: "session", Machine: c.machine.Name, Err: err}
+		}
+	}
+	defer session.Close()
+	return session.CombinedOutput(cmdline)
+}
+
+// sftp returns the pooled SFTP client for this machine.
+func (c *SSHConnection) sftp() (*sftp.Client, error) {
+	return c.pool.sftp(c.machine)
+}
+
+// mapRemoteError converts SFTP errors into the package's error types.
+func mapRemoteError(err error, path, op string) error {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return &NotFoundError{Path: path}
+	case errors.Is(err, fs.ErrPermission):
+		return &PermissionError{Path: path, Op: op}
+	default:
+		return err
+	}
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+			strings.ContainsRune("-_./=:@%+,", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellJoin builds a shell command line with every word quoted.
+func shellJoin(cmd string, args ...string) string {
+	words := make([]string, 0, len(args)+1)
+	words = append(words, shellQuote(cmd))
+	for _, a := range args {
+		words = append(words, shellQuote(a))
+	}
+	return strings.Join(words, " ")
+}
+
+// sshPool shares SSH (and SFTP) clients across connections to the same
+// machine. Clients are keyed by user, address and key so that registry
+// edits to a machine produce a fresh connection.
+type sshPool struct {
+	mu      sync.Mutex
+	clients map[string]*pooledSSH
+	dial    func(m *Machine) (*ssh.Client, error)
+}
+
+type pooledSSH struct {
+	ssh  *ssh.Client
+	sftp *sftp.Client
+}
+
+func newSSHPool() *sshPool {
+	return &sshPool{
+		clients: make(map[string]*pooledSSH),
+		dial:    dialSSH,
+	}
+}
+
+func poolKey(m *Machine) string {
+	return m.Host + "|" + m.KeyPath + "|" + m.KnownHostsPath
+}
+
+// client returns a live SSH client for m, dialing if needed.
+func (p *sshPool) client(m *Machine) (*ssh.Client, error) {
+	pc, err := p.get(m)
+	if err != nil {
+		return nil, err
+	}
+	return pc.ssh, nil
+}
+
+// sftp returns the SFTP client for m, opening the subsystem on first use.
+func (p *sshPool) sftp(m *Machine) (*sftp.Client, error) {
+	pc, err := p.get(m)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pc.sftp != nil {
+		return pc.sftp, nil
+	}
+	client, err := sftp.NewClient(pc.ssh)
+	if err != nil {
+		return nil, &ConnectionError{Op: "sftp", Machine: m.Name, Err: err}
+	}
+	pc.sftp = client
+	return client, nil
+}
+
+func (p *sshPool) get(m *Machine) (*pooledSSH, error) {
+	key := poolKey(m)
+
+	p.mu.Lock()
+	pc, ok := p.clients[key]
+	p.mu.Unlock()
+	if ok {
+		// Cheap liveness probe; a dead transport fails immediately.
+		if _, _, err := pc.ssh.SendRequest("keepalive@openssh.com", true, nil); err == nil {
+			return pc, nil
+		}
+		p.evict(m)
+	}
+
+	client, err := p.dial(m)
+	if err != nil {
+		return nil, &ConnectionError{Op: "connect", Machine: m.Name, Err: err}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if existing, ok := p.clients[key]; ok {
+		// Lost a race with another dialer; keep theirs.
+		_ = client.Close()
+		return existing, nil
+	}
+	pc = &pooledSSH{ssh: client}
+	p.clients[key] = pc
+	return pc, nil
+}
+
+// evict closes and forgets the pooled client for m.
+func (p *sshPool) evict(m *Machine) {
+	p.mu.Lock()
+	pc, ok := p.clients[poolKey(m)]
+	delete(p.clients, poolKey(m))
+	p.mu.Unlock()
+	if ok {
+		pc.close()
+	}
+}
+
+// Close closes every pooled connection.
+func (p *sshPool) Close() error {
+	p.mu.Lock()
+	clients := p.clients
+	p.clients = make(map[string]*pooledSSH)
+	p.mu.Unlock()
+	for _, pc := range clients {
+		pc.close()
+	}
+	return nil
+}
+
+func (pc *pooledSSH) close() {
+	if pc.sftp != nil {
+		_ = pc.sftp.Close()
+	}
+	_ = pc.ssh.Close()
+}
+
+// dialSSH opens an SSH connection to m, verifying the host key against
+// known_hosts. Unknown or mismatched host keys are always rejected.
+func dialSSH(m *Machine) (*ssh.Client, error) {
+	username, addr, err := splitSSHHost(m.Host)
+	if err != nil {
+		return nil, err
+	}
+	hostKeyCallback, err := knownHostsCallback(m.KnownHostsPath)
+	if err != nil {
+		return nil, err
+	}
+	auth, closeAuth, err := sshAuthMethods(m.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	// Authentication finishes inside ssh.Dial, so the agent connection is
+	// no longer needed once it returns.
+	defer closeAuth()
+	return ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            username,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshDialTimeout,
+	})
+}
+
+// splitSSHHost parses "user@host[:port]" into a username and dial address.
+// The user defaults to the current user and the port to 22.
+func splitSSHHost(host string) (username, addr string, err error) {
+	if host == "" {
+		return "", "", fmt.Errorf("ssh machine requires host")
+	}
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		username, host = host[:at], host[at+1:]
+	}
+	if username == "" {
+		u, err := user.Current()
+		if err != nil {
+			return "", "", fmt.Errorf("determining ssh user: %w", err)
+		}
+		username = u.Username
+	}
+	if _, _, splitErr := net.SplitHostPort(host); splitErr != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+	return username, host, nil
+}
+
+// knownHostsCallback builds a host key verifier from a known_hosts file
+// (default ~/.ssh/known_hosts).
+func knownHostsCallback(path string) (ssh.HostKeyCallback, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("locating known_hosts: %w", err)
+		}
+		path = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	cb, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading known_hosts %s: %w", path, err)
+	}
+	return cb, nil
+}
+
+// sshAuthMethods returns public-key auth from the machine's key file,
+// falling back to the SSH agent when no key is configured. The returned
+// func releases the agent connection and must be called after dialing.
+func sshAuthMethods(keyPath string) ([]ssh.AuthMethod, func(), error) {
+	if keyPath != "" {
+		if strings.HasPrefix(keyPath, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				keyPath = filepath.Join(home, keyPath[2:])
+			}
+		}
+		pem, err := os.ReadFile(keyPath) //nolint:gosec // G304: key path comes from the machine registry
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading ssh key: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(pem)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing ssh key %s: %w", keyPath, err)
+		}
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, func() {}, nil
+	}
+
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil, nil, fmt.Errorf("ssh machine has no key_path and SSH_AUTH_SOCK is not set")
+	}
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connecting to ssh agent: %w", err)
+	}
+	return []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(conn).Signers)}, func() { _ = conn.Close() }, nil
+}
+
+// Verify SSHConnection implements Connection.
+var _ Connection = (*SSHConnection)(nil)
@@ -0,0 +1,570 @@
+package connection
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// testSSHServer is a minimal in-process SSH server. Exec requests run
+// through the local shell and the "sftp" subsystem is served from the
+// local filesystem, so an SSHConnection behaves like a remote host that
+// happens to share our disk.
+type testSSHServer struct {
+	addr    string
+	hostKey ssh.Signer
+	dials   atomic.Int32
+
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+func newTestSSHServer(t *testing.T, clientKey ssh.PublicKey) *testSSHServer {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostKey, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) == string(clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("unknown client key")
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testSSHServer{addr: ln.Addr().String(), hostKey: hostKey, listener: ln}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.dials.Add(1)
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.serveConn(conn, config)
+			}()
+		}
+	}()
+
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+	return s
+}
+
+func (s *testSSHServer) serveConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newCh := range chans {
+		if newCh.ChannelType() != "session" {
+			_ = newCh.Reject(ssh.UnknownChannelType, "only sessions")
+			continue
+		}
+		ch, chReqs, err := newCh.Accept()
+		if err != nil {
+			continue
+		}
+		go serveSession(ch, chReqs)
+	}
+}
+
+func serveSession(ch ssh.Channel, reqs <-chan *ssh.Request) {
+	defer ch.Close()
+	for req := range reqs {
+		switch req.Type {
+		case "exec":
+			var payload struct{ Command string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			_ = req.Reply(true, nil)
+
+			cmd := exec.Command("sh", "-c", payload.Command)
+			cmd.Stdin = ch
+			cmd.Stdout = ch
+			cmd.Stderr = ch.Stderr()
+			status := uint32(0)
+			if err := cmd.Run(); err != nil {
+				status = 1
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					status = uint32(exitErr.ExitCode())
+				}
+			}
+			sendExitStatus(ch, status)
+			return
+		case "subsystem":
+			var payload struct{ Name string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil || payload.Name != "sftp" {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			_ = req.Reply(true, nil)
+			server, err := sftp.NewServer(ch)
+			if err != nil {
+				return
+			}
+			if err := server.Serve(); err != nil && !errors.Is(err, io.EOF) {
+				return
+			}
+			return
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func sendExitStatus(ch ssh.Channel, status uint32) {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, status)
+	_, _ = ch.SendRequest("exit-status", false, buf)
+}
+
+// newTestSSHMachine starts a server and returns a machine configured to
+// reach it with a fresh client key and a known_hosts entry for its host key.
+func newTestSSHMachine(t *testing.T) (*Machine, *testSSHServer) {
+	t.Helper()
+	dir := t.TempDir()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	clientKey, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := newTestSSHServer(t, clientKey)
+
+	knownHostsPath := filepath.Join(dir, "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(srv.addr)}, srv.hostKey.PublicKey())
+	if err := os.WriteFile(knownHostsPath, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return &Machine{
+		Name:           "vm",
+		Type:           "ssh",
+		Host:           "tester@" + srv.addr,
+		KeyPath:        keyPath,
+		KnownHostsPath: knownHostsPath,
+	}, srv
+}
+
+func newTestSSHConnection(t *testing.T) (*SSHConnection, *testSSHServer) {
+	t.Helper()
+	m, srv := newTestSSHMachine(t)
+	pool := newSSHPool()
+	t.Cleanup(func() { _ = pool.Close() })
+	return newSSHConnection(m, pool), srv
+}
+
+func TestSSHConnection_FileOps(t *testing.T) {
+	c, _ := newTestSSHConnection(t)
+	dir := t.TempDir()
+
+	if c.IsLocal() {
+		t.Error("IsLocal() = true, want false")
+	}
+	if c.Name() != "vm" {
+		t.Errorf("Name() = %q, want %q", c.Name(), "vm")
+	}
+
+	nested := filepath.Join(dir, "a", "b")
+	if err := c.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	path := filepath.Join(nested, "hello.txt")
+	if err := c.WriteFile(path, []byte("hello"), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	// Overwrite must truncate.
+	if err := c.WriteFile(path, []byte("hi"), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	data, err := c.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != "hi" {
+		t.Errorf("ReadFile = %q, want %q", data, "hi")
+	}
+
+	fi, err := c.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if fi.Size() != 2 || fi.IsDir() {
+		t.Errorf("Stat = size %d dir %v, want size 2 file", fi.Size(), fi.IsDir())
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("Mode = %v, want 0600", fi.Mode().Perm())
+	}
+
+	matches, err := c.Glob(filepath.Join(nested, "*.txt"))
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	if len(matches) != 1 || matches[0] != path {
+		t.Errorf("Glob = %v, want [%s]", matches, path)
+	}
+
+	if ok, err := c.Exists(path); err != nil || !ok {
+		t.Errorf("Exists(file) = %v, %v; want true", ok, err)
+	}
+
+	if err := c.Remove(path); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := c.Remove(path); err != nil {
+		t.Errorf("Remove of missing file should be nil, got %v", err)
+	}
+	if ok, err := c.Exists(path); err != nil || ok {
+		t.Errorf("Exists(removed) = %v, %v; want false", ok, err)
+	}
+
+	if err := c.RemoveAll(filepath.Join(dir, "a")); err != nil {
+		t.Fatalf("RemoveAll: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a")); !os.IsNotExist(err) {
+		t.Errorf("RemoveAll left directory behind: %v", err)
+	}
+}
+
+func TestSSHConnection_NotFound(t *testing.T) {
+	c, _ := newTestSSHConnection(t)
+	missing := filepath.Join(t.TempDir(), "nope")
+
+	_, err := c.ReadFile(missing)
+	var nf *NotFoundError
+	if !errors.As(err, &nf) {
+		t.Fatalf("ReadFile(missing) error = %v, want NotFoundError", err)
+	}
+	if nf.Path != missing {
+		t.Errorf("NotFoundError.Path = %q, want %q", nf.Path, missing)
+	}
+
+	if _, err := c.Stat(missing); !errors.As(err, &nf) {
+		t.Errorf("Stat(missing) error = %v, want NotFoundError", err)
+	}
+}
+
+func TestSSHConnection_Exec(t *testing.T) {
+	c, _ := newTestSSHConnection(t)
+	dir := t.TempDir()
+
+	out, err := c.Exec("echo", "it's", "a $test")
+	if err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "it's a $test" {
+		t.Errorf("Exec output = %q, want arguments passed verbatim", got)
+	}
+
+	out, err = c.ExecDir(dir, "pwd")
+	if err != nil {
+		t.Fatalf("ExecDir: %v", err)
+	}
+	wantDir, _ := filepath.EvalSymlinks(dir)
+	gotDir, _ := filepath.EvalSymlinks(strings.TrimSpace(string(out)))
+	if gotDir != wantDir {
+		t.Errorf("ExecDir pwd = %q, want %q", gotDir, wantDir)
+	}
+
+	out, err = c.ExecEnv(map[string]string{"GT_TEST_VAR": "a b"}, "sh", "-c", "echo $GT_TEST_VAR")
+	if err != nil {
+		t.Fatalf("ExecEnv: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "a b" {
+		t.Errorf("ExecEnv output = %q, want %q", got, "a b")
+	}
+
+	out, err = c.Exec("sh", "-c", "echo boom >&2; exit 3")
+	var exitErr *ssh.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitStatus() != 3 {
+		t.Errorf("Exec failing command error = %v, want exit status 3", err)
+	}
+	if !strings.Contains(string(out), "boom") {
+		t.Errorf("Exec output = %q, want stderr included", out)
+	}
+}
+
+func TestSSHConnection_PoolReusesClient(t *testing.T) {
+	m, srv := newTestSSHMachine(t)
+	pool := newSSHPool()
+	t.Cleanup(func() { _ = pool.Close() })
+
+	a := newSSHConnection(m, pool)
+	b := newSSHConnection(m, pool)
+	for i := 0; i < 3; i++ {
+		if _, err := a.Exec("true"); err != nil {
+			t.Fatalf("Exec: %v", err)
+		}
+		if _, err := b.Stat(t.TempDir()); err != nil {
+			t.Fatalf("Stat: %v", err)
+		}
+	}
+	if n := srv.dials.Load(); n != 1 {
+		t.Errorf("server saw %d connections, want 1", n)
+	}
+
+	// A closed pool redials transparently.
+	_ = pool.Close()
+	if _, err := a.Exec("true"); err != nil {
+		t.Fatalf("Exec after Close: %v", err)
+	}
+	if n := srv.dials.Load(); n != 2 {
+		t.Errorf("server saw %d connections after redial, want 2", n)
+	}
+}
+
+func TestSSHConnection_RejectsUnknownHostKey(t *testing.T) {
+	m, _ := newTestSSHMachine(t)
+
+	// Replace known_hosts with a different key for the same address.
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	other, _ := ssh.NewSignerFromKey(priv)
+	_, addr, _ := splitSSHHost(m.Host)
+	line := knownhosts.Line([]string{knownhosts.Normalize(addr)}, other.PublicKey())
+	if err := os.WriteFile(m.KnownHostsPath, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newSSHConnection(m, newSSHPool())
+	_, err := c.Exec("true")
+	var connErr *ConnectionError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("Exec error = %v, want ConnectionError", err)
+	}
+	var keyErr *knownhosts.KeyError
+	if !errors.As(err, &keyErr) || len(keyErr.Want) == 0 {
+		t.Errorf("error = %v, want host key mismatch", err)
+	}
+
+	// An empty known_hosts means the host is unknown, which is also rejected.
+	if err := os.WriteFile(m.KnownHostsPath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	c = newSSHConnection(m, newSSHPool())
+	if _, err := c.Exec("true"); !errors.As(err, &keyErr) {
+		t.Errorf("Exec error = %v, want unknown host key error", err)
+	}
+}
+
+func TestSSHConnection_Tmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	c, _ := newTestSSHConnection(t)
+
+	// Isolate from any user tmux server.
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	t.Setenv("TMUX", "")
+
+	session := "gt-ssh-test"
+	if ok, err := c.TmuxHasSession(session); err != nil || ok {
+		t.Fatalf("TmuxHasSession before create = %v, %v; want false", ok, err)
+	}
+	if sessions, err := c.TmuxListSessions(); err != nil || len(sessions) != 0 {
+		t.Fatalf("TmuxListSessions with no server = %v, %v; want empty", sessions, err)
+	}
+
+	if err := c.TmuxNewSession(session, t.TempDir()); err != nil {
+		t.Fatalf("TmuxNewSession: %v", err)
+	}
+	t.Cleanup(func() { _ = c.TmuxKillSession(session) })
+
+	if ok, err := c.TmuxHasSession(session); err != nil || !ok {
+		t.Fatalf("TmuxHasSession after create = %v, %v; want true", ok, err)
+	}
+	sessions, err := c.TmuxListSessions()
+	if err != nil {
+		t.Fatalf("TmuxListSessions: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0] != session {
+		t.Errorf("TmuxListSessions = %v, want [%s]", sessions, session)
+	}
+
+	if err := c.TmuxSendKeys(session, "echo gt-marker-$((40+2))"); err != nil {
+		t.Fatalf("TmuxSendKeys: %v", err)
+	}
+	var pane string
+	for i := 0; i < 50; i++ {
+		pane, err = c.TmuxCapturePane(session, 50)
+		if err != nil {
+			t.Fatalf("TmuxCapturePane: %v", err)
+		}
+		if strings.Contains(pane, "gt-marker-42") {
+			break
+		}
+		_, _ = c.Exec("sleep", "0.1")
+	}
+	if !strings.Contains(pane, "gt-marker-42") {
+		t.Errorf("pane output missing command result:\n%s", pane)
+	}
+
+	if err := c.TmuxKillSession(session); err != nil {
+		t.Fatalf("TmuxKillSession: %v", err)
+	}
+	if ok, _ := c.TmuxHasSession(session); ok {
+		t.Error("session still exists after TmuxKillSession")
+	}
+	// Killing a missing session is not an error.
+	if err := c.TmuxKillSession(session); err != nil {
+		t.Errorf("TmuxKillSession on missing session: %v", err)
+	}
+}
+
+func TestSplitSSHHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		wantUser string
+		wantAddr string
+		wantErr  bool
+	}{
+		{host: "alice@box", wantUser: "alice", wantAddr: "box:22"},
+		{host: "alice@box:2222", wantUser: "alice", wantAddr: "box:2222"},
+		{host: "alice@[::1]:2222", wantUser: "alice", wantAddr: "[::1]:2222"},
+		{host: "alice@::1", wantUser: "alice", wantAddr: "[::1]:22"},
+		{host: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			gotUser, gotAddr, err := splitSSHHost(tt.host)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("splitSSHHost(%q) expected error", tt.host)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("splitSSHHost(%q): %v", tt.host, err)
+			}
+			if gotUser != tt.wantUser || gotAddr != tt.wantAddr {
+				t.Errorf("splitSSHHost(%q) = %q, %q; want %q, %q", tt.host, gotUser, gotAddr, tt.wantUser, tt.wantAddr)
+			}
+		})
+	}
+}
+
+func TestRegistryConnection_SSH(t *testing.T) {
+	m, _ := newTestSSHMachine(t)
+	r, err := NewMachineRegistry(filepath.Join(t.TempDir(), "machines.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = r.Close() })
+	if err := r.Add(m); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := r.Connection("vm")
+	if err != nil {
+		t.Fatalf("Connection: %v", err)
+	}
+	if _, ok := conn.(*SSHConnection); !ok {
+		t.Fatalf("Connection returned %T, want *SSHConnection", conn)
+	}
+	if out, err := conn.Exec("echo", "ok"); err != nil || strings.TrimSpace(string(out)) != "ok" {
+		t.Errorf("Exec = %q, %v", out, err)
+	}
+}
+
+func TestDialSSH_ClosesAgentConnection(t *testing.T) {
+	m, _ := newTestSSHMachine(t)
+	pemBytes, err := os.ReadFile(m.KeyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.ParseRawPrivateKey(pemBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: key}); err != nil {
+		t.Fatal(err)
+	}
+	m.KeyPath = ""
+
+	sock := filepath.Join(t.TempDir(), "agent.sock")
+	ln, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	closed := make(chan struct{})
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		_ = agent.ServeAgent(keyring, conn) // returns once the client hangs up
+		close(closed)
+	}()
+	t.Setenv("SSH_AUTH_SOCK", sock)
+
+	client, err := dialSSH(m)
+	if err != nil {
+		t.Fatalf("dialSSH via agent: %v", err)
+	}
+	defer client.Close()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ssh-agent connection left open after dialing")
+	}
+}
@@ -0,0 +1,437 @@
+package convoy
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Forecast defaults.
+const (
+	// DefaultTaskDuration is the sling-to-merge estimate used when the events
+	// log has no completed beads to learn from.
+	DefaultTaskDuration = 2 * time.Hour
+
+	// minRigSamples is the fewest completed beads a rig needs before its own
+	// median is preferred over the town-wide median.
+	minRigSamples = 3
+
+	// durationHistoryLimit is how many of the most recent completions are
+	// kept, so estimates track the town's current pace.
+	durationHistoryLimit = 200
+)
+
+// DurationHistory holds sling-to-merge durations of completed beads, read
+// from the town events log.
+type DurationHistory struct {
+	all     []time.Duration
+	byRig   map[string][]time.Duration
+	slungAt map[string]time.Time // first sling of beads not yet completed
+}
+
+// LoadDurationHistory reads the town events log. A missing log yields an
+// empty history.
+func LoadDurationHistory(townRoot string) (*DurationHistory, error) {
+	f, err := os.Open(filepath.Join(townRoot, events.EventsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return ParseDurationHistory(strings.NewReader(""))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseDurationHistory(f)
+}
+
+// ParseDurationHistory builds a history from events in JSONL form.
+//
+// A bead's duration runs from its first sling to its merged event. Beads
+// that never went through the merge queue fall back to their done event.
+func ParseDurationHistory(r io.Reader) (*DurationHistory, error) {
+	type beadTimes struct {
+		slung, done, merged time.Time
+		rig                 string
+	}
+	beadsByID := make(map[string]*beadTimes)
+	get := func(id string) *beadTimes {
+		b := beadsByID[id]
+		if b == nil {
+			b = &beadTimes{}
+			beadsByID[id] = b
+		}
+		return b
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || !e.IsActivity() {
+			continue
+		}
+		bead, _ := e.Payload["bead"].(string)
+		if bead == "" {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			continue
+		}
+		switch e.Type {
+		case events.TypeSling:
+			if b := get(bead); b.slung.IsZero() {
+				b.slung = ts
+				if target, _ := e.Payload["target"].(string); target != "" {
+					b.rig = strings.SplitN(target, "/", 2)[0]
+				}
+			}
+		case events.TypeDone:
+			get(bead).done = ts
+		case events.TypeMerged:
+			b := get(bead)
+			b.merged = ts
+			b.rig = strings.SplitN(e.Actor, "/", 2)[0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	type sample struct {
+		rig string
+		end time.Time
+		d   time.Duration
+	}
+	var samples []sample
+	h := &DurationHistory{byRig: make(map[string][]time.Duration), slungAt: make(map[string]time.Time)}
+	for id, b := range beadsByID {
+		if b.slung.IsZero() {
+			continue
+		}
+		end := b.merged
+		if end.IsZero() {
+			end = b.done
+		}
+		if end.IsZero() {
+			h.slungAt[id] = b.slung
+			continue
+		}
+		if end.After(b.slung) {
+			samples = append(samples, sample{rig: b.rig, end: end, d: end.Sub(b.slung)})
+		}
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i].end.Before(samples[j].end) })
+	if len(samples) > durationHistoryLimit {
+		samples = samples[len(samples)-durationHistoryLimit:]
+	}
+	for _, s := range samples {
+		h.all = append(h.all, s.d)
+		if s.rig != "" {
+			h.byRig[s.rig] = append(h.byRig[s.rig], s.d)
+		}
+	}
+	return h, nil
+}
+
+// Samples returns how many completed beads the history holds.
+func (h *DurationHistory) Samples() int {
+	if h == nil {
+		return 0
+	}
+	return len(h.all)
+}
+
+// Estimate returns the expected sling-to-merge duration for a bead in rig:
+// the rig's median when it has enough history, else the town median, else
+// DefaultTaskDuration.
+func (h *DurationHistory) Estimate(rig string) time.Duration {
+	if h == nil {
+		return DefaultTaskDuration
+	}
+	if d := h.byRig[rig]; len(d) >= minRigSamples {
+		return median(d)
+	}
+	if len(h.all) > 0 {
+		return median(h.all)
+	}
+	return DefaultTaskDuration
+}
+
+// SlungAt returns when an in-flight bead was first slung.
+func (h *DurationHistory) SlungAt(beadID string) (time.Time, bool) {
+	if h == nil {
+		return time.Time{}, false
+	}
+	t, ok := h.slungAt[beadID]
+	return t, ok
+}
+
+func median(d []time.Duration) time.Duration {
+	s := append([]time.Duration(nil), d...)
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+// SchedulerCapacity returns the town's polecat capacity for forecasting:
+// scheduler.max_polecats when deferred dispatch is on, or 0 (unlimited)
+// for direct dispatch.
+func SchedulerCapacity(townRoot string) int {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil || !settings.Scheduler.IsDeferred() {
+		return 0
+	}
+	return settings.Scheduler.GetMaxPolecats()
+}
+
+// ForecastTask is one tracked bead as input to a forecast.
+type ForecastTask struct {
+	ID        string
+	Rig       string
+	Status    string
+	BlockedBy []string // blocking beads; those outside the task set are ignored
+}
+
+func (t ForecastTask) done() bool {
+	return t.Status == "closed" || t.Status == "tombstone"
+}
+
+func (t ForecastTask) running() bool {
+	return t.Status == "in_progress" || t.Status == "hooked"
+}
+
+// TaskForecast is the projected schedule of one open bead.
+type TaskForecast struct {
+	ID        string        `json:"id"`
+	Remaining time.Duration `json:"remaining"`
+	Start     time.Time     `json:"start"`
+	Finish    time.Time     `json:"finish"`
+	Critical  bool          `json:"critical"`
+}
+
+// Forecast is a projected completion time for a set of beads.
+type Forecast struct {
+	ETA       time.Time     `json:"eta"`
+	Remaining time.Duration `json:"remaining"`
+	// CriticalPath is the longest chain of dependent open beads; any delay
+	// on it delays the ETA.
+	CriticalPath []string `json:"critical_path,omitempty"`
+	Capacity     int      `json:"capacity"` // concurrent polecats assumed; 0 = unlimited
+	Samples      int      `json:"samples"`  // completed beads the estimates are based on
+	// Unschedulable lists open beads that can never start because of a
+	// dependency cycle.
+	Unschedulable []string       `json:"unschedulable,omitempty"`
+	Tasks         []TaskForecast `json:"tasks,omitempty"`
+
+	critical map[string]bool
+}
+
+// Done reports whether every bead is already closed.
+func (f *Forecast) Done() bool {
+	return f != nil && len(f.Tasks) == 0 && len(f.Unschedulable) == 0
+}
+
+// IsCritical reports whether a bead is on the critical path.
+func (f *Forecast) IsCritical(id string) bool {
+	return f != nil && f.critical[id]
+}
+
+// ForecastTasks projects when the open tasks will all be merged.
+//
+// Each open bead is expected to take its rig's historical median; beads
+// already in progress are credited with the time since they were slung.
+// The critical path is the longest chain of dependent open beads by
+// remaining time. The ETA comes from simulating dispatch with at most
+// capacity beads in flight (0 = unlimited), starting ready beads with the
+// longest remaining chain first, so it is never earlier than the critical
+// path allows.
+func ForecastTasks(tasks []ForecastTask, hist *DurationHistory, capacity int, now time.Time) *Forecast {
+	f := &Forecast{Capacity: capacity, Samples: hist.Samples(), ETA: now, critical: make(map[string]bool)}
+
+	open := make(map[string]*ForecastTask)
+	for i := range tasks {
+		if !tasks[i].done() {
+			open[tasks[i].ID] = &tasks[i]
+		}
+	}
+	if len(open) == 0 {
+		return f
+	}
+
+	// Remaining time and open-only dependency edges.
+	remaining := make(map[string]time.Duration, len(open))
+	blockers := make(map[string][]string, len(open))
+	dependents := make(map[string][]string, len(open))
+	for id, t := range open {
+		est := hist.Estimate(t.Rig)
+		if t.running() {
+			if slung, ok := hist.SlungAt(id); ok {
+				est -= now.Sub(slung)
+			}
+			// Running past its estimate: still expect some time left.
+			if est < hist.Estimate(t.Rig)/10 {
+				est = hist.Estimate(t.Rig) / 10
+			}
+		}
+		remaining[id] = est
+		for _, b := range t.BlockedBy {
+			if _, ok := open[b]; ok && b != id {
+				blockers[id] = append(blockers[id], b)
+				dependents[b] = append(dependents[b], id)
+			}
+		}
+	}
+	ids := make([]string, 0, len(open))
+	for id := range open {
+		ids = append(ids, id)
+		sort.Strings(dependents[id])
+	}
+	sort.Strings(ids)
+
+	// tail[id] is the longest remaining chain starting at id.
+	tail := make(map[string]time.Duration, len(open))
+	visiting := make(map[string]bool)
+	var chain func(id string) time.Duration
+	chain = func(id string) time.Duration {
+		if d, ok := tail[id]; ok {
+			return d
+		}
+		if visiting[id] {
+			return 0 // cycle; reported as unschedulable below
+		}
+		visiting[id] = true
+		var longest time.Duration
+		for _, dep := range dependents[id] {
+			if d := chain(dep); d > longest {
+				longest = d
+			}
+		}
+		visiting[id] = false
+		tail[id] = remaining[id] + longest
+		return tail[id]
+	}
+	head := ""
+	for _, id := range ids {
+		if chain(id) > tail[head] || head == "" {
+			head = id
+		}
+	}
+	for id := head; id != ""; {
+		f.CriticalPath = append(f.CriticalPath, id)
+		f.critical[id] = true
+		next := ""
+		for _, dep := range dependents[id] {
+			if !f.critical[dep] && (next == "" || tail[dep] > tail[next]) {
+				next = dep
+			}
+		}
+		id = next
+	}
+
+	// Simulate dispatch.
+	waiting := make(map[string]int, len(open))
+	for _, id := range ids {
+		waiting[id] = len(blockers[id])
+	}
+	start := make(map[string]time.Duration)
+	finish := make(map[string]time.Duration)
+	var running []string
+	var ready []string
+	for _, id := range ids {
+		switch {
+		case open[id].running():
+			start[id] = 0
+			running = append(running, id)
+		case waiting[id] == 0:
+			ready = append(ready, id)
+		}
+	}
+
+	var clock time.Duration
+	for {
+		sort.SliceStable(ready, func(i, j int) bool { return tail[ready[i]] > tail[ready[j]] })
+		for len(ready) > 0 && (capacity <= 0 || len(running) < capacity) {
+			id := ready[0]
+			ready = ready[1:]
+			start[id] = clock
+			running = append(running, id)
+		}
+		if len(running) == 0 {
+			break
+		}
+
+		// Advance to the next completion.
+		next := 0
+		for i, id := range running {
+			if start[id]+remaining[id] < start[running[next]]+remaining[running[next]] {
+				next = i
+			}
+		}
+		id := running[next]
+		running = append(running[:next], running[next+1:]...)
+		clock = start[id] + remaining[id]
+		finish[id] = clock
+		for _, dep := range dependents[id] {
+			waiting[dep]--
+			if waiting[dep] == 0 && !open[dep].running() {
+				ready = append(ready, dep)
+			}
+		}
+	}
+
+	for _, id := range ids {
+		fin, ok := finish[id]
+		if !ok {
+			f.Unschedulable = append(f.Unschedulable, id)
+			continue
+		}
+		f.Tasks = append(f.Tasks, TaskForecast{
+			ID:        id,
+			Remaining: remaining[id],
+			Start:     now.Add(start[id]),
+			Finish:    now.Add(fin),
+			Critical:  f.critical[id],
+		})
+	}
+	f.Remaining = clock
+	f.ETA = now.Add(clock)
+	return f
+}
+
+// FormatRemaining renders a forecast duration compactly, e.g. "45m",
+// "3h20m" or "2d4h".
+func FormatRemaining(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Round(time.Minute)/time.Minute))
+	case d < 24*time.Hour:
+		d = d.Round(10 * time.Minute)
+		h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+		if m == 0 {
+			return fmt.Sprintf("%dh", h)
+		}
+		return fmt.Sprintf("%dh%dm", h, m)
+	default:
+		d = d.Round(time.Hour)
+		days, h := int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour)
+		if h == 0 {
+			return fmt.Sprintf("%dd", days)
+		}
+		return fmt.Sprintf("%dd%dh", days, h)
+	}
+}
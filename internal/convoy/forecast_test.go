@@ -0,0 +1,176 @@
+package convoy
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDurationHistory(t *testing.T) {
+	log := strings.Join([]string{
+		`{"ts":"2026-01-01T10:00:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-a","target":"gastown/polecats/nux"}}`,
+		`{"ts":"2026-01-01T10:30:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-a","target":"gastown/polecats/toast"}}`,
+		`{"ts":"2026-01-01T11:00:00Z","type":"done","actor":"gastown/polecats/nux","payload":{"bead":"gt-a"}}`,
+		`{"ts":"2026-01-01T12:00:00Z","type":"merged","actor":"gastown/refinery","payload":{"bead":"gt-a","mr":"gt-mr-1"}}`,
+		`{"ts":"2026-01-01T10:00:00Z","type":"sling","actor":"mayor","payload":{"bead":"bd-b","target":"beads/polecats/ace"}}`,
+		`{"ts":"2026-01-01T10:45:00Z","type":"done","actor":"beads/polecats/ace","payload":{"bead":"bd-b"}}`,
+		`{"ts":"2026-01-01T13:00:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-c","target":"gastown/polecats/nux"}}`,
+		`not json`,
+	}, "\n")
+
+	h, err := ParseDurationHistory(strings.NewReader(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Samples() != 2 {
+		t.Fatalf("Samples() = %d, want 2", h.Samples())
+	}
+	// gt-a runs from its first sling to merged, not to done.
+	if got := h.byRig["gastown"]; len(got) != 1 || got[0] != 2*time.Hour {
+		t.Errorf("gastown durations = %v, want [2h]", got)
+	}
+	// bd-b never merged, so done ends it.
+	if got := h.byRig["beads"]; len(got) != 1 || got[0] != 45*time.Minute {
+		t.Errorf("beads durations = %v, want [45m]", got)
+	}
+	if slung, ok := h.SlungAt("gt-c"); !ok || slung.Hour() != 13 {
+		t.Errorf("SlungAt(gt-c) = %v, %v", slung, ok)
+	}
+	if _, ok := h.SlungAt("gt-a"); ok {
+		t.Error("completed bead should not be in flight")
+	}
+}
+
+func TestDurationHistory_Estimate(t *testing.T) {
+	h := &DurationHistory{
+		all:   []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 4 * time.Hour, 10 * time.Hour},
+		byRig: map[string][]time.Duration{"gastown": {time.Hour, 2 * time.Hour, 3 * time.Hour}, "beads": {4 * time.Hour}},
+	}
+	if got := h.Estimate("gastown"); got != 2*time.Hour {
+		t.Errorf("rig median = %v, want 2h", got)
+	}
+	// Too few rig samples: fall back to the town median.
+	if got := h.Estimate("beads"); got != 3*time.Hour {
+		t.Errorf("town median = %v, want 3h", got)
+	}
+	var empty *DurationHistory
+	if got := empty.Estimate("gastown"); got != DefaultTaskDuration {
+		t.Errorf("empty history = %v, want default", got)
+	}
+}
+
+func uniformHistory(d time.Duration) *DurationHistory {
+	return &DurationHistory{all: []time.Duration{d}, slungAt: map[string]time.Time{}}
+}
+
+func TestForecastTasks_CriticalPath(t *testing.T) {
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	// a → b → c is the long chain; d is independent; e is done.
+	tasks := []ForecastTask{
+		{ID: "a", Status: "open"},
+		{ID: "b", Status: "open", BlockedBy: []string{"a"}},
+		{ID: "c", Status: "open", BlockedBy: []string{"b", "e"}},
+		{ID: "d", Status: "open"},
+		{ID: "e", Status: "closed"},
+	}
+	f := ForecastTasks(tasks, uniformHistory(time.Hour), 0, now)
+
+	if strings.Join(f.CriticalPath, ",") != "a,b,c" {
+		t.Errorf("critical path = %v, want [a b c]", f.CriticalPath)
+	}
+	if f.Remaining != 3*time.Hour || !f.ETA.Equal(now.Add(3*time.Hour)) {
+		t.Errorf("remaining = %v, eta = %v", f.Remaining, f.ETA)
+	}
+	if !f.IsCritical("b") || f.IsCritical("d") {
+		t.Error("IsCritical misclassified")
+	}
+	if len(f.Tasks) != 4 || f.Done() {
+		t.Errorf("tasks = %+v", f.Tasks)
+	}
+}
+
+func TestForecastTasks_Capacity(t *testing.T) {
+	now := time.Now()
+	tasks := []ForecastTask{
+		{ID: "a", Status: "open"},
+		{ID: "b", Status: "open"},
+		{ID: "c", Status: "open"},
+		{ID: "d", Status: "open"},
+	}
+	// Four independent one-hour tasks on two polecats take two hours.
+	if f := ForecastTasks(tasks, uniformHistory(time.Hour), 2, now); f.Remaining != 2*time.Hour {
+		t.Errorf("capacity 2: remaining = %v, want 2h", f.Remaining)
+	}
+	if f := ForecastTasks(tasks, uniformHistory(time.Hour), 0, now); f.Remaining != time.Hour {
+		t.Errorf("unlimited: remaining = %v, want 1h", f.Remaining)
+	}
+}
+
+func TestForecastTasks_InProgressCredit(t *testing.T) {
+	now := time.Now()
+	h := uniformHistory(2 * time.Hour)
+	h.slungAt["a"] = now.Add(-90 * time.Minute)
+	h.slungAt["b"] = now.Add(-5 * time.Hour)
+	tasks := []ForecastTask{
+		{ID: "a", Status: "in_progress"},
+		{ID: "b", Status: "hooked"},
+	}
+	f := ForecastTasks(tasks, h, 1, now)
+	for _, tf := range f.Tasks {
+		switch tf.ID {
+		case "a":
+			if tf.Remaining != 30*time.Minute {
+				t.Errorf("a remaining = %v, want 30m", tf.Remaining)
+			}
+		case "b":
+			// Overdue: floored at a tenth of the estimate.
+			if tf.Remaining != 12*time.Minute {
+				t.Errorf("b remaining = %v, want 12m", tf.Remaining)
+			}
+		}
+	}
+	// Running beads already hold their slots, even past capacity.
+	if f.Remaining != 30*time.Minute {
+		t.Errorf("remaining = %v, want 30m", f.Remaining)
+	}
+}
+
+func TestForecastTasks_CycleAndDone(t *testing.T) {
+	now := time.Now()
+	tasks := []ForecastTask{
+		{ID: "a", Status: "open", BlockedBy: []string{"b"}},
+		{ID: "b", Status: "open", BlockedBy: []string{"a"}},
+		{ID: "c", Status: "open"},
+	}
+	f := ForecastTasks(tasks, nil, 0, now)
+	if strings.Join(f.Unschedulable, ",") != "a,b" {
+		t.Errorf("unschedulable = %v, want [a b]", f.Unschedulable)
+	}
+	if f.Remaining != DefaultTaskDuration {
+		t.Errorf("remaining = %v, want default", f.Remaining)
+	}
+
+	done := ForecastTasks([]ForecastTask{{ID: "a", Status: "closed"}}, nil, 0, now)
+	if !done.Done() || !done.ETA.Equal(now) {
+		t.Errorf("all closed: %+v", done)
+	}
+}
+
+func TestFormatRemaining(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "<1m"},
+		{45 * time.Minute, "45m"},
+		{3*time.Hour + 18*time.Minute, "3h20m"},
+		{5 * time.Hour, "5h"},
+		{52 * time.Hour, "2d4h"},
+		{48 * time.Hour, "2d"},
+	}
+	for _, tt := range tests {
+		if got := FormatRemaining(tt.d); got != tt.want {
+			t.Errorf("FormatRemaining(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
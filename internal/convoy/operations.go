@@ -185,6 +185,12 @@ var blockingDepTypes = map[string]bool{
 	"merge-blocks":       true,
 }
 
+// IsBlockingDepType reports whether a dependency type gates dispatch.
+// Exported for ETA forecasting in the feed and dashboard.
+func IsBlockingDepType(depType string) bool {
+	return blockingDepTypes[depType]
+}
+
 // isIssueBlocked checks if an issue has unclosed blocking dependencies.
 // Returns true if any blocks, conditional-blocks, waits-for, or merge-blocks
 // dependency targets an issue that is not closed/tombstone.
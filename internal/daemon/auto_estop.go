@@ -0,0 +1,600 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/estop"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/quota"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// Auto E-stop rule names. These are recorded in the ESTOP file and in
+// estop events so operators can see what tripped the stop.
+const (
+	autoEstopRuleMassDeath = "mass_death"
+	autoEstopRulePressure  = "pressure"
+	autoEstopRuleCrashLoop = "crash_loop"
+	autoEstopRuleQuota     = "quota_exhausted"
+	autoEstopRuleCostRate  = "cost_rate"
+)
+
+const (
+	// defaultMassDeathStorms is how many mass-death events within the window trip the stop.
+	defaultMassDeathStorms = 2
+	// defaultMassDeathStormWindow is the lookback for counting mass-death events.
+	defaultMassDeathStormWindow = 10 * time.Minute
+	// defaultPressureStrikes is how many consecutive heartbeats under pressure trip the stop.
+	defaultPressureStrikes = 3
+	// defaultCrashLoopAgents is how many crash-looping agents trip the stop.
+	defaultCrashLoopAgents = 1
+	// defaultCostRateWindow is the lookback for computing the spend rate.
+	defaultCostRateWindow = time.Hour
+	// autoEstopEscalateTimeout bounds the gt escalate call made on a trip.
+	autoEstopEscalateTimeout = 30 * time.Second
+	// maxCostEvidence caps how many costly sessions are listed as evidence.
+	maxCostEvidence = 5
+)
+
+// AutoEstopConfig holds configuration for daemon-driven E-stop triggers.
+// Opt-in: the daemon never trips an E-stop on its own unless enabled.
+//
+// Example (mayor/daemon.json):
+//
+//	"auto_estop": {
+//	  "enabled": true,
+//	  "mass_death": {"enabled": true, "threshold": 2, "window": "10m"},
+//	  "crash_loop": {"enabled": true},
+//	  "quota_exhausted": {"enabled": true},
+//	  "cost_rate": {"enabled": true, "threshold": 50, "window": "1h"},
+//	  "auto_clear": "30m"
+//	}
+type AutoEstopConfig struct {
+	// Enabled controls whether auto E-stop triggers are evaluated.
+	Enabled bool `json:"enabled"`
+
+	// MassDeath trips when mass-death events repeat within Window.
+	MassDeath *AutoEstopRule `json:"mass_death,omitempty"`
+
+	// Pressure trips when checkPressure fails for Threshold consecutive heartbeats.
+	Pressure *AutoEstopRule `json:"pressure,omitempty"`
+
+	// CrashLoop trips when Threshold agents are in RestartTracker crash-loop state.
+	CrashLoop *AutoEstopRule `json:"crash_loop,omitempty"`
+
+	// QuotaExhausted trips when every tracked account is rate-limited.
+	QuotaExhausted *AutoEstopRule `json:"quota_exhausted,omitempty"`
+
+	// CostRate trips when spend recorded in costs.jsonl over Window exceeds
+	// Threshold USD per hour.
+	CostRate *AutoEstopRule `json:"cost_rate,omitempty"`
+
+	// AutoClear is how long an auto E-stop must stay active before the daemon
+	// may clear it, once its triggering rule has stopped firing (e.g. "30m").
+	// Empty means auto E-stops are only cleared by 'gt thaw'.
+	// Manual E-stops are never auto-cleared.
+	AutoClear string `json:"auto_clear,omitempty"`
+}
+
+// AutoEstopRule configures a single auto E-stop trigger.
+type AutoEstopRule struct {
+	// Enabled controls whether this rule can trip the E-stop.
+	Enabled bool `json:"enabled"`
+
+	// Threshold is the rule-specific trip point (see AutoEstopConfig).
+	// Zero uses the rule default; cost_rate has no default and is inert without one.
+	Threshold float64 `json:"threshold,omitempty"`
+
+	// Window is the lookback for mass_death and cost_rate (e.g. "10m").
+	Window string `json:"window,omitempty"`
+}
+
+func (r *AutoEstopRule) active() bool {
+	return r != nil && r.Enabled
+}
+
+func (r *AutoEstopRule) threshold(def float64) float64 {
+	if r.Threshold > 0 {
+		return r.Threshold
+	}
+	return def
+}
+
+func (r *AutoEstopRule) window(def time.Duration) time.Duration {
+	if r.Window != "" {
+		if d, err := time.ParseDuration(r.Window); err == nil && d > 0 {
+			return d
+		}
+	}
+	return def
+}
+
+// autoClearAfter returns the auto-clear delay and whether auto-clear is enabled.
+func (c *AutoEstopConfig) autoClearAfter() (time.Duration, bool) {
+	if c.AutoClear == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(c.AutoClear)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// autoEstopConfig returns the auto_estop config, or an empty config.
+func autoEstopConfig(config *DaemonPatrolConfig) *AutoEstopConfig {
+	if config != nil && config.Patrols != nil && config.Patrols.AutoEstop != nil {
+		return config.Patrols.AutoEstop
+	}
+	return &AutoEstopConfig{}
+}
+
+// autoEstopTrip describes a rule that is currently firing.
+type autoEstopTrip struct {
+	Rule     string
+	Rig      string // empty = town-wide
+	Reason   string
+	Evidence []string
+}
+
+// massDeathStorm records one emitted mass-death event.
+type massDeathStorm struct {
+	at       time.Time
+	sessions []string
+}
+
+// checkAutoEstop evaluates auto E-stop rules, clears auto E-stops whose
+// condition has passed (per the auto-clear policy), and trips new ones.
+// Runs every heartbeat, including while an E-stop is active.
+func (d *Daemon) checkAutoEstop() {
+	if !d.isPatrolActive("auto_estop") {
+		d.pressureStrikes = 0
+		return
+	}
+	cfg := autoEstopConfig(d.patrolConfig)
+
+	firing := d.evaluateAutoEstop(cfg)
+	d.autoClearEstops(cfg, firing, time.Now())
+	for _, trip := range firing {
+		d.tripEstop(trip)
+	}
+}
+
+// evaluateAutoEstop returns every rule that is currently firing.
+func (d *Daemon) evaluateAutoEstop(cfg *AutoEstopConfig) []autoEstopTrip {
+	var firing []autoEstopTrip
+	for _, trip := range []*autoEstopTrip{
+		d.evaluateMassDeath(cfg.MassDeath, time.Now()),
+		d.evaluatePressure(cfg.Pressure),
+		d.evaluateCrashLoop(cfg.CrashLoop),
+		d.evaluateQuotaExhausted(cfg.QuotaExhausted),
+		d.evaluateCostRate(cfg.CostRate, time.Now()),
+	} {
+		if trip != nil {
+			firing = append(firing, *trip)
+		}
+	}
+	return firing
+}
+
+// noteMassDeath records a mass-death event. The mass_death rule is
+// evaluated immediately rather than on the next heartbeat, since a storm
+// is exactly when waiting minutes is most expensive.
+func (d *Daemon) noteMassDeath(sessions []string) {
+	if !d.isPatrolActive("auto_estop") {
+		return
+	}
+
+	d.deathsMu.Lock()
+	d.massDeathStorms = append(d.massDeathStorms, massDeathStorm{at: time.Now(), sessions: sessions})
+	d.deathsMu.Unlock()
+
+	if trip := d.evaluateMassDeath(autoEstopConfig(d.patrolConfig).MassDeath, time.Now()); trip != nil {
+		d.tripEstop(*trip)
+	}
+}
+
+// evaluateMassDeath fires when enough mass-death events land in the window.
+// If every dead session belongs to one rig, the trip is scoped to that rig.
+func (d *Daemon) evaluateMassDeath(rule *AutoEstopRule, now time.Time) *autoEstopTrip {
+	if !rule.active() {
+		return nil
+	}
+	window := rule.window(defaultMassDeathStormWindow)
+	threshold := int(rule.threshold(defaultMassDeathStorms))
+
+	d.deathsMu.Lock()
+	cutoff := now.Add(-window)
+	var recent []massDeathStorm
+	for _, storm := range d.massDeathStorms {
+		if storm.at.After(cutoff) {
+			recent = append(recent, storm)
+		}
+	}
+	d.massDeathStorms = recent
+	d.deathsMu.Unlock()
+
+	if len(recent) < threshold {
+		return nil
+	}
+
+	var evidence []string
+	rigs := make(map[string]bool)
+	total := 0
+	for _, storm := range recent {
+		total += len(storm.sessions)
+		evidence = append(evidence, fmt.Sprintf("%s: %d sessions died (%s)",
+			storm.at.Format(time.RFC3339), len(storm.sessions), strings.Join(storm.sessions, ", ")))
+		for _, s := range storm.sessions {
+			rigs[sessionRig(s)] = true
+		}
+	}
+
+	trip := &autoEstopTrip{
+		Rule:     autoEstopRuleMassDeath,
+		Reason:   fmt.Sprintf("%d mass-death events (%d sessions) within %s", len(recent), total, window),
+		Evidence: evidence,
+	}
+	if len(rigs) == 1 {
+		for rig := range rigs {
+			trip.Rig = rig
+		}
+	}
+	return trip
+}
+
+// sessionRig returns the rig a session belongs to, or "" for town-level
+// or unparseable sessions.
+func sessionRig(sessionName string) string {
+	id, err := session.ParseSessionName(sessionName)
+	if err != nil {
+		return ""
+	}
+	return id.Rig
+}
+
+// evaluatePressure fires after Threshold consecutive heartbeats under pressure.
+func (d *Daemon) evaluatePressure(rule *AutoEstopRule) *autoEstopTrip {
+	if !rule.active() {
+		d.pressureStrikes = 0
+		return nil
+	}
+	p := d.checkPressure("auto_estop")
+	if p.OK {
+		d.pressureStrikes = 0
+		return nil
+	}
+	d.pressureStrikes++
+
+	threshold := int(rule.threshold(defaultPressureStrikes))
+	if d.pressureStrikes < threshold {
+		return nil
+	}
+	return &autoEstopTrip{
+		Rule:     autoEstopRulePressure,
+		Reason:   fmt.Sprintf("system overloaded for %d consecutive heartbeats", d.pressureStrikes),
+		Evidence: []string{p.Reason},
+	}
+}
+
+// evaluateCrashLoop fires when enough agents are held in crash-loop state.
+func (d *Daemon) evaluateCrashLoop(rule *AutoEstopRule) *autoEstopTrip {
+	if !rule.active() || d.restartTracker == nil {
+		return nil
+	}
+	agents := d.restartTracker.CrashLoopingAgents()
+	if len(agents) == 0 || len(agents) < int(rule.threshold(defaultCrashLoopAgents)) {
+		return nil
+	}
+	evidence := make([]string, 0, len(agents))
+	for _, agent := range agents {
+		evidence = append(evidence, fmt.Sprintf("%s in crash loop (clear with 'gt daemon clear-backoff %s')", agent, agent))
+	}
+	return &autoEstopTrip{
+		Rule:     autoEstopRuleCrashLoop,
+		Reason:   fmt.Sprintf("%d agent(s) crash-looping: %s", len(agents), strings.Join(agents, ", ")),
+		Evidence: evidence,
+	}
+}
+
+// evaluateQuotaExhausted fires when every tracked account is rate-limited.
+func (d *Daemon) evaluateQuotaExhausted(rule *AutoEstopRule) *autoEstopTrip {
+	if !rule.active() {
+		return nil
+	}
+	mgr := quota.NewManager(d.config.TownRoot)
+	state, err := mgr.Load()
+	if err != nil || len(state.Accounts) == 0 {
+		return nil
+	}
+	if len(mgr.AvailableAccounts(state)) > 0 {
+		return nil
+	}
+
+	handles := make([]string, 0, len(state.Accounts))
+	for handle := range state.Accounts {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+	evidence := make([]string, 0, len(handles))
+	for _, handle := range handles {
+		acct := state.Accounts[handle]
+		line := fmt.Sprintf("%s: %s", handle, acct.Status)
+		if acct.LimitedAt != "" {
+			line += " since " + acct.LimitedAt
+		}
+		if acct.ResetsAt != "" {
+			line += ", resets " + acct.ResetsAt
+		}
+		evidence = append(evidence, line)
+	}
+	return &autoEstopTrip{
+		Rule:     autoEstopRuleQuota,
+		Reason:   fmt.Sprintf("all %d account(s) rate-limited", len(handles)),
+		Evidence: evidence,
+	}
+}
+
+// costLogEntry is the subset of a costs.jsonl record the daemon needs.
+// Written by 'gt costs record' (see cmd.CostLogEntry).
+type costLogEntry struct {
+	SessionID string    `json:"session_id"`
+	CostUSD   float64   `json:"cost_usd"`
+	EndedAt   time.Time `json:"ended_at"`
+}
+
+// costsLogPath mirrors the gt CLI's costs log location:
+// $GT_HOME/.gt/costs.jsonl when GT_HOME is set, otherwise ~/.gt/costs.jsonl.
+func costsLogPath() string {
+	if h := os.Getenv("GT_HOME"); h != "" {
+		return filepath.Join(h, ".gt", "costs.jsonl")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), ".gt", "costs.jsonl")
+	}
+	return filepath.Join(home, ".gt", "costs.jsonl")
+}
+
+// evaluateCostRate fires when recorded spend over the window exceeds the
+// configured USD-per-hour rate.
+func (d *Daemon) evaluateCostRate(rule *AutoEstopRule, now time.Time) *autoEstopTrip {
+	if !rule.active() || rule.Threshold <= 0 {
+		return nil
+	}
+	window := rule.window(defaultCostRateWindow)
+
+	f, err := os.Open(costsLogPath())
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	cutoff := now.Add(-window)
+	var total float64
+	var recent []costLogEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry costLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if entry.EndedAt.Before(cutoff) || entry.EndedAt.After(now) || entry.CostUSD <= 0 {
+			continue
+		}
+		total += entry.CostUSD
+		recent = append(recent, entry)
+	}
+
+	rate := total / window.Hours()
+	if rate <= rule.Threshold {
+		return nil
+	}
+
+	sort.Slice(recent, func(i, j int) bool { return recent[i].CostUSD > recent[j].CostUSD })
+	if len(recent) > maxCostEvidence {
+		recent = recent[:maxCostEvidence]
+	}
+	evidence := []string{fmt.Sprintf("$%.2f spent in the last %s", total, window)}
+	for _, entry := range recent {
+		evidence = append(evidence, fmt.Sprintf("%s: $%.2f at %s", entry.SessionID, entry.CostUSD, entry.EndedAt.Format(time.RFC3339)))
+	}
+	return &autoEstopTrip{
+		Rule:     autoEstopRuleCostRate,
+		Reason:   fmt.Sprintf("spend rate $%.2f/h exceeds limit $%.2f/h", rate, rule.Threshold),
+		Evidence: evidence,
+	}
+}
+
+// tripEstop activates an auto E-stop for the trip's scope, freezes the
+// affected sessions, emits an estop event, and escalates. No-op if the
+// scope (or the whole town) is already stopped.
+func (d *Daemon) tripEstop(trip autoEstopTrip) {
+	townRoot := d.config.TownRoot
+	if estop.IsActive(townRoot) {
+		return
+	}
+
+	scope := "town"
+	var err error
+	if trip.Rig != "" {
+		if estop.IsRigActive(townRoot, trip.Rig) {
+			return
+		}
+		scope = trip.Rig
+		err = estop.ActivateRigAuto(townRoot, trip.Rig, trip.Rule, trip.Reason, trip.Evidence)
+	} else {
+		err = estop.ActivateAuto(townRoot, trip.Rule, trip.Reason, trip.Evidence)
+	}
+	if err != nil {
+		d.logger.Printf("auto_estop: failed to activate E-stop (%s, %s): %v", scope, trip.Rule, err)
+		return
+	}
+
+	frozen := d.signalAgentSessions(trip.Rig, stopProcessGroup)
+	d.logger.Printf("AUTO E-STOP (%s) tripped by %s: %s; froze %d session(s)", scope, trip.Rule, trip.Reason, len(frozen))
+	for _, e := range trip.Evidence {
+		d.logger.Printf("auto_estop:   %s", e)
+	}
+
+	_ = events.LogFeed(events.TypeEstop, "daemon",
+		events.EstopPayload(scope, estop.TriggerAuto, trip.Rule, trip.Reason, trip.Evidence))
+
+	d.escalateEstop(scope, trip)
+}
+
+// escalateEstop routes a critical escalation through 'gt escalate' so the
+// configured escalation routes (mail, email, sms) notify the overseer.
+func (d *Daemon) escalateEstop(scope string, trip autoEstopTrip) {
+	if d.gtPath == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), autoEstopEscalateTimeout)
+	defer cancel()
+
+	thaw := "gt thaw"
+	if scope != "town" {
+		thaw += " --rig " + scope
+	}
+	title := fmt.Sprintf("Auto E-stop (%s): %s", scope, trip.Reason)
+	reason := fmt.Sprintf("Rule %s tripped an E-stop for %s.\n\nEvidence:\n- %s\n\nResume with: %s",
+		trip.Rule, scope, strings.Join(trip.Evidence, "\n- "), thaw)
+
+	cmd := exec.CommandContext(ctx, d.gtPath, "escalate", title, //nolint:gosec // G204: gtPath resolved at daemon init
+		"-s", "critical", "--source", "daemon:auto_estop", "--reason", reason)
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = append(os.Environ(), "BD_ACTOR=daemon")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		d.logger.Printf("auto_estop: escalation failed: %v (%s)", err, strings.TrimSpace(string(output)))
+	}
+}
+
+// autoClearEstops clears auto-triggered E-stops that have been active for at
+// least the auto-clear delay and whose rule is no longer firing. Manual
+// E-stops and auto E-stops without a recorded rule are left alone.
+func (d *Daemon) autoClearEstops(cfg *AutoEstopConfig, firing []autoEstopTrip, now time.Time) {
+	after, ok := cfg.autoClearAfter()
+	if !ok {
+		return
+	}
+	townRoot := d.config.TownRoot
+
+	if info := estop.Read(townRoot); info != nil && canAutoClear(info, "", after, firing, now) {
+		if err := estop.Deactivate(townRoot, true); err != nil {
+			d.logger.Printf("auto_estop: failed to auto-clear E-stop: %v", err)
+		} else {
+			d.afterAutoClear("", info)
+		}
+	}
+
+	for _, rig := range estop.ActiveRigs(townRoot) {
+		info := estop.ReadRig(townRoot, rig)
+		if info == nil || !canAutoClear(info, rig, after, firing, now) {
+			continue
+		}
+		if err := estop.DeactivateRig(townRoot, rig); err != nil {
+			d.logger.Printf("auto_estop: failed to auto-clear E-stop for %s: %v", rig, err)
+			continue
+		}
+		d.afterAutoClear(rig, info)
+	}
+}
+
+// canAutoClear reports whether an E-stop may be cleared automatically.
+// rig is "" for the town-wide E-stop.
+func canAutoClear(info *estop.Info, rig string, after time.Duration, firing []autoEstopTrip, now time.Time) bool {
+	if info.Trigger != estop.TriggerAuto || info.Rule == "" {
+		return false
+	}
+	if now.Sub(info.Timestamp) < after {
+		return false
+	}
+	for _, trip := range firing {
+		if trip.Rule == info.Rule && (rig == "" || trip.Rig == "" || trip.Rig == rig) {
+			return false
+		}
+	}
+	return true
+}
+
+// afterAutoClear resumes sessions frozen by an auto-cleared E-stop.
+func (d *Daemon) afterAutoClear(rig string, info *estop.Info) {
+	scope := "town"
+	if rig != "" {
+		scope = rig
+	}
+
+	thawed := d.signalAgentSessions(rig, continueProcessGroup)
+	for _, sess := range thawed {
+		_ = d.tmux.NudgeSession(sess, "E-stop cleared. Work may resume.")
+	}
+
+	reason := fmt.Sprintf("auto-cleared: %s no longer firing", info.Rule)
+	d.logger.Printf("AUTO E-STOP (%s) %s after %s; resumed %d session(s)",
+		scope, reason, time.Since(info.Timestamp).Round(time.Second), len(thawed))
+	_ = events.LogFeed(events.TypeThaw, "daemon",
+		events.EstopPayload(scope, estop.TriggerAuto, info.Rule, reason, nil))
+}
+
+// isEstopped reports whether a town, rig or role E-stop freezes role in rig.
+// Patrols that start, restart or reap agents check this so they don't undo
+// a scoped E-stop.
+func (d *Daemon) isEstopped(rigName string, role session.Role) bool {
+	return estop.IsStopped(d.config.TownRoot, rigName, string(role))
+}
+
+// signalAgentSessions applies signal to the process group of every agent
+// session (or only those of rig, when non-empty), skipping the Mayor and
+// overseer so they can coordinate recovery. Returns the signaled sessions.
+func (d *Daemon) signalAgentSessions(rig string, signal func(pid int) error) []string {
+	if d.tmux == nil {
+		return nil
+	}
+	sessions, err := d.tmux.ListSessions()
+	if err != nil {
+		return nil
+	}
+
+	exempt := map[string]bool{
+		session.MayorSessionName():    true,
+		session.OverseerSessionName(): true,
+	}
+
+	var signaled []string
+	for _, name := range sessions {
+		if exempt[name] {
+			continue
+		}
+		sessRig := sessionRig(name)
+		if rig != "" && sessRig != rig {
+			continue
+		}
+		if sessRig == "" && !strings.HasPrefix(name, session.HQPrefix) {
+			continue // Not a Gas Town session
+		}
+		pidStr, err := d.tmux.GetPanePID(name)
+		if err != nil {
+			continue
+		}
+		pid, err := strconv.Atoi(pidStr)
+		if err != nil {
+			continue
+		}
+		if err := signal(pid); err != nil {
+			d.logger.Printf("auto_estop: failed to signal %s: %v", name, err)
+			continue
+		}
+		signaled = append(signaled, name)
+	}
+	return signaled
+}
@@ -0,0 +1,371 @@
+package daemon
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/estop"
+	"github.com/steveyegge/gastown/internal/quota"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// newAutoEstopTestDaemon returns a daemon with auto_estop enabled using cfg.
+// The working directory is moved to a temp dir so feed events stay out of
+// any real workspace.
+func newAutoEstopTestDaemon(t *testing.T, cfg *AutoEstopConfig) *Daemon {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "daemon"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Enabled = true
+	return &Daemon{
+		config:       &Config{TownRoot: townRoot},
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: &DaemonPatrolConfig{Patrols: &PatrolsConfig{AutoEstop: cfg}},
+	}
+}
+
+func TestIsPatrolEnabled_AutoEstop(t *testing.T) {
+	if IsPatrolEnabled(nil, "auto_estop") {
+		t.Error("expected auto_estop to be disabled with nil config")
+	}
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{}}
+	if IsPatrolEnabled(config, "auto_estop") {
+		t.Error("expected auto_estop to be disabled by default")
+	}
+	config.Patrols.AutoEstop = &AutoEstopConfig{Enabled: true}
+	if !IsPatrolEnabled(config, "auto_estop") {
+		t.Error("expected auto_estop to be enabled when configured")
+	}
+}
+
+func TestAutoEstopConfigJSON(t *testing.T) {
+	data := `{
+		"enabled": true,
+		"mass_death": {"enabled": true, "threshold": 3, "window": "5m"},
+		"cost_rate": {"enabled": true, "threshold": 25},
+		"auto_clear": "30m"
+	}`
+	var cfg AutoEstopConfig
+	if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !cfg.MassDeath.active() || cfg.MassDeath.threshold(defaultMassDeathStorms) != 3 {
+		t.Errorf("mass_death = %+v", cfg.MassDeath)
+	}
+	if got := cfg.MassDeath.window(defaultMassDeathStormWindow); got != 5*time.Minute {
+		t.Errorf("mass_death window = %v, want 5m", got)
+	}
+	if got := cfg.CostRate.window(defaultCostRateWindow); got != time.Hour {
+		t.Errorf("cost_rate window = %v, want default 1h", got)
+	}
+	if cfg.Pressure.active() {
+		t.Error("unconfigured pressure rule should be inactive")
+	}
+	if after, ok := cfg.autoClearAfter(); !ok || after != 30*time.Minute {
+		t.Errorf("autoClearAfter = %v, %v; want 30m, true", after, ok)
+	}
+	if _, ok := (&AutoEstopConfig{}).autoClearAfter(); ok {
+		t.Error("empty auto_clear should disable auto-clear")
+	}
+}
+
+func TestEvaluateMassDeath(t *testing.T) {
+	d := newAutoEstopTestDaemon(t, &AutoEstopConfig{})
+	rule := &AutoEstopRule{Enabled: true, Threshold: 2, Window: "10m"}
+	now := time.Now()
+
+	d.massDeathStorms = []massDeathStorm{
+		{at: now.Add(-30 * time.Minute), sessions: []string{"hq-deacon"}}, // outside window
+		{at: now.Add(-time.Minute), sessions: []string{"hq-deacon", "hq-boot"}},
+	}
+	if trip := d.evaluateMassDeath(rule, now); trip != nil {
+		t.Fatalf("one storm in window should not trip, got %+v", trip)
+	}
+	if len(d.massDeathStorms) != 1 {
+		t.Errorf("expected expired storms pruned, have %d", len(d.massDeathStorms))
+	}
+
+	d.massDeathStorms = append(d.massDeathStorms, massDeathStorm{at: now, sessions: []string{"hq-mayor"}})
+	trip := d.evaluateMassDeath(rule, now)
+	if trip == nil {
+		t.Fatal("two storms in window should trip")
+	}
+	if trip.Rule != autoEstopRuleMassDeath || trip.Rig != "" {
+		t.Errorf("trip = %+v, want town-wide mass_death", trip)
+	}
+	if len(trip.Evidence) != 2 || !strings.Contains(trip.Evidence[0], "hq-boot") {
+		t.Errorf("evidence = %q", trip.Evidence)
+	}
+}
+
+func TestEvaluateCrashLoop(t *testing.T) {
+	d := newAutoEstopTestDaemon(t, &AutoEstopConfig{})
+	d.restartTracker = NewRestartTracker(d.config.TownRoot, RestartTrackerConfig{CrashLoopCount: 2})
+	rule := &AutoEstopRule{Enabled: true}
+
+	if trip := d.evaluateCrashLoop(rule); trip != nil {
+		t.Fatalf("no crash loops should not trip, got %+v", trip)
+	}
+
+	d.restartTracker.RecordRestart("deacon")
+	d.restartTracker.RecordRestart("deacon")
+	trip := d.evaluateCrashLoop(rule)
+	if trip == nil {
+		t.Fatal("crash-looping deacon should trip")
+	}
+	if !strings.Contains(trip.Reason, "deacon") {
+		t.Errorf("reason = %q, want deacon named", trip.Reason)
+	}
+
+	rule.Threshold = 2
+	if trip := d.evaluateCrashLoop(rule); trip != nil {
+		t.Errorf("threshold 2 with one crash-looping agent should not trip")
+	}
+}
+
+func TestEvaluateQuotaExhausted(t *testing.T) {
+	d := newAutoEstopTestDaemon(t, &AutoEstopConfig{})
+	rule := &AutoEstopRule{Enabled: true}
+
+	// No accounts tracked: nothing to exhaust.
+	if trip := d.evaluateQuotaExhausted(rule); trip != nil {
+		t.Fatalf("no accounts should not trip, got %+v", trip)
+	}
+
+	mgr := quota.NewManager(d.config.TownRoot)
+	state := &config.QuotaState{Accounts: map[string]config.AccountQuotaState{
+		"work":     {Status: config.QuotaStatusLimited, ResetsAt: "7pm"},
+		"personal": {Status: config.QuotaStatusAvailable},
+	}}
+	if err := mgr.Save(state); err != nil {
+		t.Fatal(err)
+	}
+	if trip := d.evaluateQuotaExhausted(rule); trip != nil {
+		t.Fatalf("one available account should not trip, got %+v", trip)
+	}
+
+	if err := mgr.MarkLimited("personal", ""); err != nil {
+		t.Fatal(err)
+	}
+	trip := d.evaluateQuotaExhausted(rule)
+	if trip == nil {
+		t.Fatal("all accounts limited should trip")
+	}
+	if len(trip.Evidence) != 2 || !strings.Contains(trip.Evidence[1], "resets 7pm") {
+		t.Errorf("evidence = %q", trip.Evidence)
+	}
+}
+
+func TestEvaluateCostRate(t *testing.T) {
+	d := newAutoEstopTestDaemon(t, &AutoEstopConfig{})
+	gtHome := t.TempDir()
+	t.Setenv("GT_HOME", gtHome)
+	now := time.Now()
+
+	writeCosts := func(entries ...costLogEntry) {
+		t.Helper()
+		var sb strings.Builder
+		for _, e := range entries {
+			data, _ := json.Marshal(e)
+			sb.Write(data)
+			sb.WriteByte('\n')
+		}
+		sb.WriteString("not json\n")
+		if err := os.MkdirAll(filepath.Join(gtHome, ".gt"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(costsLogPath(), []byte(sb.String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rule := &AutoEstopRule{Enabled: true, Threshold: 10, Window: "1h"}
+	writeCosts(
+		costLogEntry{SessionID: "gt-old", CostUSD: 100, EndedAt: now.Add(-2 * time.Hour)},
+		costLogEntry{SessionID: "gt-a", CostUSD: 4, EndedAt: now.Add(-10 * time.Minute)},
+	)
+	if trip := d.evaluateCostRate(rule, now); trip != nil {
+		t.Fatalf("$4/h under $10/h limit should not trip, got %+v", trip)
+	}
+
+	writeCosts(
+		costLogEntry{SessionID: "gt-a", CostUSD: 4, EndedAt: now.Add(-10 * time.Minute)},
+		costLogEntry{SessionID: "gt-b", CostUSD: 9, EndedAt: now.Add(-5 * time.Minute)},
+	)
+	trip := d.evaluateCostRate(rule, now)
+	if trip == nil {
+		t.Fatal("$13/h over $10/h limit should trip")
+	}
+	if len(trip.Evidence) != 3 || !strings.HasPrefix(trip.Evidence[1], "gt-b:") {
+		t.Errorf("evidence = %q, want total then costliest first", trip.Evidence)
+	}
+
+	if trip := d.evaluateCostRate(&AutoEstopRule{Enabled: true}, now); trip != nil {
+		t.Error("cost_rate without a threshold should be inert")
+	}
+}
+
+func TestTripEstop(t *testing.T) {
+	d := newAutoEstopTestDaemon(t, &AutoEstopConfig{})
+	townRoot := d.config.TownRoot
+
+	d.tripEstop(autoEstopTrip{Rule: autoEstopRuleQuota, Reason: "all accounts limited", Evidence: []string{"work: limited"}})
+
+	info := estop.Read(townRoot)
+	if info == nil {
+		t.Fatal("expected town-wide E-stop")
+	}
+	if info.Trigger != estop.TriggerAuto || info.Rule != autoEstopRuleQuota {
+		t.Errorf("info = %+v, want auto quota_exhausted", info)
+	}
+	if len(info.Evidence) != 1 || info.Evidence[0] != "work: limited" {
+		t.Errorf("evidence = %q", info.Evidence)
+	}
+
+	// A second trip does not overwrite the active E-stop.
+	d.tripEstop(autoEstopTrip{Rule: autoEstopRuleCostRate, Reason: "spend"})
+	if got := estop.Read(townRoot).Rule; got != autoEstopRuleQuota {
+		t.Errorf("rule = %q after second trip, want original %q", got, autoEstopRuleQuota)
+	}
+
+	// Rig-scoped trips write the per-rig sentinel.
+	_ = estop.Deactivate(townRoot, false)
+	d.tripEstop(autoEstopTrip{Rule: autoEstopRuleMassDeath, Rig: "gastown", Reason: "storm"})
+	if estop.IsActive(townRoot) {
+		t.Error("rig-scoped trip should not stop the whole town")
+	}
+	if info := estop.ReadRig(townRoot, "gastown"); info == nil || info.Rule != autoEstopRuleMassDeath {
+		t.Errorf("ReadRig = %+v, want auto mass_death", info)
+	}
+}
+
+func TestCanAutoClear(t *testing.T) {
+	now := time.Now()
+	auto := &estop.Info{Trigger: estop.TriggerAuto, Rule: autoEstopRuleQuota, Timestamp: now.Add(-time.Hour)}
+
+	tests := []struct {
+		name   string
+		info   *estop.Info
+		rig    string
+		after  time.Duration
+		firing []autoEstopTrip
+		want   bool
+	}{
+		{name: "condition passed", info: auto, after: 30 * time.Minute, want: true},
+		{name: "too recent", info: auto, after: 2 * time.Hour, want: false},
+		{name: "still firing", info: auto, after: 0, firing: []autoEstopTrip{{Rule: autoEstopRuleQuota}}, want: false},
+		{name: "other rule firing", info: auto, after: 0, firing: []autoEstopTrip{{Rule: autoEstopRuleCostRate}}, want: true},
+		{name: "manual never clears", info: &estop.Info{Trigger: estop.TriggerManual, Timestamp: now.Add(-time.Hour)}, want: false},
+		{name: "auto without rule", info: &estop.Info{Trigger: estop.TriggerAuto, Timestamp: now.Add(-time.Hour)}, want: false},
+		{name: "rig firing elsewhere", info: auto, rig: "gastown", firing: []autoEstopTrip{{Rule: autoEstopRuleQuota, Rig: "beads"}}, want: true},
+		{name: "rig firing here", info: auto, rig: "gastown", firing: []autoEstopTrip{{Rule: autoEstopRuleQuota, Rig: "gastown"}}, want: false},
+		{name: "rig firing town-wide", info: auto, rig: "gastown", firing: []autoEstopTrip{{Rule: autoEstopRuleQuota}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canAutoClear(tt.info, tt.rig, tt.after, tt.firing, now); got != tt.want {
+				t.Errorf("canAutoClear = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAutoEstop_TripAndAutoClear(t *testing.T) {
+	cfg := &AutoEstopConfig{
+		QuotaExhausted: &AutoEstopRule{Enabled: true},
+		AutoClear:      "0s",
+	}
+	d := newAutoEstopTestDaemon(t, cfg)
+	townRoot := d.config.TownRoot
+
+	mgr := quota.NewManager(townRoot)
+	if err := mgr.MarkLimited("work", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	d.checkAutoEstop()
+	if info := estop.Read(townRoot); info == nil || info.Rule != autoEstopRuleQuota {
+		t.Fatalf("expected quota_exhausted E-stop, got %+v", info)
+	}
+
+	// Still exhausted: stays stopped.
+	d.checkAutoEstop()
+	if !estop.IsActive(townRoot) {
+		t.Fatal("E-stop cleared while condition still holds")
+	}
+
+	// Quota recovers: auto-clear lifts the stop.
+	if err := mgr.MarkAvailable("work"); err != nil {
+		t.Fatal(err)
+	}
+	d.checkAutoEstop()
+	if estop.IsActive(townRoot) {
+		t.Error("expected auto-clear once quota recovered")
+	}
+
+	// Manual E-stops are never auto-cleared.
+	if err := estop.Activate(townRoot, estop.TriggerManual, "operator"); err != nil {
+		t.Fatal(err)
+	}
+	d.checkAutoEstop()
+	if !estop.IsActive(townRoot) {
+		t.Error("manual E-stop must not be auto-cleared")
+	}
+}
+
+func TestIsEstopped(t *testing.T) {
+	d := newAutoEstopTestDaemon(t, &AutoEstopConfig{})
+	townRoot := d.config.TownRoot
+
+	if d.isEstopped("gastown", session.RolePolecat) {
+		t.Fatal("nothing should be e-stopped without ESTOP files")
+	}
+
+	if err := estop.ActivateScope(townRoot, estop.Scope{Rig: "gastown", Role: "polecat"}, estop.TriggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+	if !d.isEstopped("gastown", session.RolePolecat) {
+		t.Error("gastown polecats should be e-stopped")
+	}
+	if d.isEstopped("gastown", session.RoleWitness) {
+		t.Error("gastown witness should not be e-stopped by a polecat-only E-stop")
+	}
+	if d.isEstopped("beads", session.RolePolecat) {
+		t.Error("beads polecats should not be e-stopped")
+	}
+}
+
+func TestEnsureAgentsRunning_SkipsEstoppedRig(t *testing.T) {
+	d := newAutoEstopTestDaemon(t, &AutoEstopConfig{})
+	var logs strings.Builder
+	d.logger = log.New(&logs, "", 0)
+
+	if err := estop.ActivateRig(d.config.TownRoot, "gastown", estop.TriggerManual, "maintenance"); err != nil {
+		t.Fatal(err)
+	}
+
+	// With no tmux wired up, reaching the start path would panic; the E-stop
+	// check must return first.
+	d.ensureWitnessRunning("gastown")
+	d.ensureRefineryRunning("gastown")
+
+	out := logs.String()
+	for _, want := range []string{
+		"Skipping witness auto-start for gastown: e-stopped",
+		"Skipping refinery auto-start for gastown: e-stopped",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q; got:\n%s", want, out)
+		}
+	}
+}
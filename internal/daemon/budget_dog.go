@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBudgetDogInterval = 10 * time.Minute
+	// budgetDogTimeout is the maximum time allowed for a single enforcement cycle.
+	budgetDogTimeout = 3 * time.Minute
+)
+
+// BudgetDogConfig holds configuration for the budget_dog patrol.
+type BudgetDogConfig struct {
+	// Enabled controls whether the budget dog runs.
+	Enabled bool `json:"enabled"`
+
+	// IntervalStr is how often to run, as a string (e.g., "10m").
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// budgetDogInterval returns the configured interval, or the default (10m).
+func budgetDogInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.BudgetDog != nil {
+		if config.Patrols.BudgetDog.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.BudgetDog.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultBudgetDogInterval
+}
+
+// runBudgetDog enforces cost budgets by shelling out to `gt costs budget --enforce`,
+// which gathers spend, escalates at the soft threshold, and at the hard threshold
+// pauses the scheduler, parks rigs and records the state gt sling checks.
+// Budgets themselves live in town settings (settings/config.json "budgets").
+func (d *Daemon) runBudgetDog() {
+	if !d.isPatrolActive("budget_dog") {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(d.ctx, budgetDogTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, d.gtPath, "costs", "budget", "--enforce") //nolint:gosec // G204: gtPath resolved at daemon init
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = append(cmd.Environ(), "GT_DAEMON=1")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if stderrStr := strings.TrimSpace(stderr.String()); stderrStr != "" {
+			d.logger.Printf("budget_dog: enforcement failed (non-fatal): %v: %s", err, stderrStr)
+		} else {
+			d.logger.Printf("budget_dog: enforcement failed (non-fatal): %v", err)
+		}
+		return
+	}
+
+	// Only actions are worth logging; the burn-down table is noise here.
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if strings.Contains(line, "→") {
+			d.logger.Printf("budget_dog: %s", strings.TrimSpace(line))
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBudgetDogInterval(t *testing.T) {
+	if got := budgetDogInterval(nil); got != defaultBudgetDogInterval {
+		t.Errorf("expected default interval %v, got %v", defaultBudgetDogInterval, got)
+	}
+
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			BudgetDog: &BudgetDogConfig{Enabled: true, IntervalStr: "1m"},
+		},
+	}
+	if got := budgetDogInterval(config); got != time.Minute {
+		t.Errorf("expected 1m interval, got %v", got)
+	}
+
+	config.Patrols.BudgetDog.IntervalStr = "soon"
+	if got := budgetDogInterval(config); got != defaultBudgetDogInterval {
+		t.Errorf("expected default interval for invalid config, got %v", got)
+	}
+}
+
+func TestIsPatrolEnabled_BudgetDog(t *testing.T) {
+	if IsPatrolEnabled(nil, "budget_dog") {
+		t.Error("expected budget_dog to be disabled with nil config")
+	}
+
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{}}
+	if IsPatrolEnabled(config, "budget_dog") {
+		t.Error("expected budget_dog to be disabled by default")
+	}
+
+	config.Patrols.BudgetDog = &BudgetDogConfig{Enabled: true}
+	if !IsPatrolEnabled(config, "budget_dog") {
+		t.Error("expected budget_dog to be enabled when configured")
+	}
+}
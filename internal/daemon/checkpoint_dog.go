@@ -99,7 +99,7 @@ func (d *Daemon) checkpointRigPolecats(rigName string) (int, int) {
 		// Check if tmux session is alive — only checkpoint active sessions.
 		// Dead sessions can't benefit from checkpoints.
 		sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
-		alive, err := d.tmux.HasSession(sessionName)
+		alive, err := d.backend().HasSession(sessionName)
 		if err != nil {
 			d.logger.Printf("checkpoint_dog: error checking session %s: %v", sessionName, err)
 			continue
@@ -30,11 +30,14 @@ import (
 	"github.com/steveyegge/gastown/internal/deps"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/estop"
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/feed"
 	gitpkg "github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/headless"
 	"github.com/steveyegge/gastown/internal/mayor"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/protocol"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
@@ -54,10 +57,13 @@ type Daemon struct {
 	config        *Config
 	patrolConfig  *DaemonPatrolConfig
 	tmux          *tmux.Tmux
+	sessions      session.SessionBackend // agent sessions; tmux or headless
 	logger        *log.Logger
 	ctx           context.Context
 	cancel        context.CancelFunc
 	curator       *feed.Curator
+	eventBus      *eventbus.Server
+	headless      *headless.Server
 	convoyManager *ConvoyManager
 	beadsStores   map[string]beadsdk.Storage
 	doltServer    *DoltServerManager
@@ -72,6 +78,19 @@ type Daemon struct {
 	deathsMu     sync.Mutex
 	recentDeaths []sessionDeath
 
+	// Auto E-stop state. massDeathStorms is guarded by deathsMu;
+	// pressureStrikes is only accessed from the heartbeat loop goroutine.
+	massDeathStorms []massDeathStorm
+	pressureStrikes int
+
+	// pluginGateState tracks cron anchors and pending event gates for
+	// plugin dispatch. Only accessed from the main loop goroutine.
+	pluginGateState *pluginGateState
+
+	// pluginEvents queues activity events for event-gated plugins. It is
+	// nil when the handler patrol is off.
+	pluginEvents *pluginEventQueue
+
 	// Deacon startup tracking: prevents race condition where newly started
 	// sessions are immediately killed by the heartbeat check.
 	// See: https://github.com/steveyegge/gastown/issues/567
@@ -117,6 +136,12 @@ type Daemon struct {
 	// triggers a zombie restart, debouncing transient gaps during handoffs.
 	// Only accessed from heartbeat loop goroutine - no sync needed.
 	mayorZombieCount int
+
+	// deadLetters is the town's protocol dead-letter queue; deadLettersSeen
+	// is how many of its entries have been logged.
+	// Only accessed from heartbeat loop goroutine - no sync needed.
+	deadLetters     *protocol.DeadLetterQueue
+	deadLettersSeen int
 }
 
 // sessionDeath records a detected session death for mass death analysis.
@@ -273,13 +298,16 @@ func New(config *Config) (*Daemon, error) {
 	if otelErr != nil {
 		logger.Printf("Warning: telemetry init failed: %v", otelErr)
 	}
+	// Daemon instruments are also needed by the Prometheus endpoint, which
+	// reads them directly and works without an OTel provider.
+	_, scrapeEnabled := metricsListenAddr(patrolConfig)
 	var dm *daemonMetrics
-	if otelProvider != nil {
+	if otelProvider != nil || scrapeEnabled {
 		dm, err = newDaemonMetrics()
 		if err != nil {
 			logger.Printf("Warning: failed to register daemon metrics: %v", err)
 			dm = nil
-		} else {
+		} else if otelProvider != nil {
 			metricsURL := os.Getenv(telemetry.EnvMetricsURL)
 			if metricsURL == "" {
 				metricsURL = telemetry.DefaultMetricsURL
@@ -293,11 +321,14 @@ func New(config *Config) (*Daemon, error) {
 		}
 	}
 
+	deadLetters, deadLettersSeen := newDeadLetterQueue(config.TownRoot)
+
 	return &Daemon{
 		config:          config,
 		patrolConfig:    patrolConfig,
 		disabledPatrols: disabledPatrols,
 		tmux:            tmux.NewTmux(),
+		sessions:        session.NewBackend(config.TownRoot),
 		logger:          logger,
 		ctx:             ctx,
 		cancel:          cancel,
@@ -307,6 +338,8 @@ func New(config *Config) (*Daemon, error) {
 		restartTracker:  restartTracker,
 		otelProvider:    otelProvider,
 		metrics:         dm,
+		deadLetters:     deadLetters,
+		deadLettersSeen: deadLettersSeen,
 	}, nil
 }
 
@@ -383,6 +416,26 @@ func (d *Daemon) Run() (err error) {
 
 	d.logger.Printf("Daemon running, recovery heartbeat interval %v", d.recoveryHeartbeatInterval())
 
+	// Serve event bus subscriptions
+	d.eventBus = eventbus.NewServer(d.config.TownRoot, d.logger)
+	if err := d.eventBus.Start(); err != nil {
+		d.logger.Printf("Warning: failed to start event bus: %v", err)
+		d.eventBus = nil
+	} else {
+		d.logger.Println("Event bus started")
+	}
+
+	// Host headless agent sessions when the town runs without tmux
+	if session.BackendKind(d.config.TownRoot) == session.BackendHeadless {
+		d.headless = headless.NewServer(d.config.TownRoot, d.logger)
+		if err := d.headless.Start(); err != nil {
+			d.logger.Printf("Warning: failed to start headless session supervisor: %v", err)
+			d.headless = nil
+		} else {
+			d.logger.Println("Headless session supervisor started")
+		}
+	}
+
 	// Start feed curator goroutine
 	d.curator = feed.NewCurator(d.config.TownRoot)
 	if err := d.curator.Start(); err != nil {
@@ -580,6 +633,35 @@ func (d *Daemon) Run() (err error) {
 		d.logger.Printf("Quota dog ticker started (interval %v)", interval)
 	}
 
+	// Start budget dog ticker if configured.
+	// Enforces cost budgets from town settings (escalate, pause, park).
+	var budgetDogTicker *time.Ticker
+	var budgetDogChan <-chan time.Time
+	if d.isPatrolActive("budget_dog") {
+		interval := budgetDogInterval(d.patrolConfig)
+		budgetDogTicker = time.NewTicker(interval)
+		budgetDogChan = budgetDogTicker.C
+		defer budgetDogTicker.Stop()
+		d.logger.Printf("Budget dog ticker started (interval %v)", interval)
+	}
+
+	// Serve the opt-in Prometheus scrape endpoint (mayor/daemon.json "metrics").
+	if addr, ok := metricsListenAddr(d.patrolConfig); ok {
+		if err := d.startMetricsServer(addr); err != nil {
+			d.logger.Printf("Warning: metrics endpoint disabled: %v", err)
+		}
+	}
+
+	// Follow activity events on the bus so event-gated plugins fire when
+	// their event is logged instead of waiting for the next heartbeat.
+	var pluginEventChan chan struct{}
+	if d.isPatrolActive("handler") {
+		pluginEventChan = make(chan struct{}, 1)
+		d.pluginEvents = &pluginEventQueue{}
+		go d.followPluginEvents(d.ctx, d.pluginEvents, pluginEventChan)
+		d.logger.Println("Plugin event follower started")
+	}
+
 	// Note: PATCH-010 uses per-session hooks in deacon/manager.go (SetAutoRespawnHook).
 	// Global pane-died hooks don't fire reliably in tmux 3.2a, so we rely on the
 	// per-session approach which has been tested to work for continuous recovery.
@@ -689,6 +771,19 @@ func (d *Daemon) Run() (err error) {
 				d.runQuotaDog()
 			}
 
+		case <-budgetDogChan:
+			// Budget dog — evaluates spend against cost budgets and applies
+			// soft (escalate) and hard (pause scheduler, park rig) thresholds.
+			if !d.isShutdownInProgress() {
+				d.runBudgetDog()
+			}
+
+		case <-pluginEventChan:
+			// Event-gated plugins — dispatch on activity events.
+			if !d.isShutdownInProgress() {
+				d.dispatchEventPlugins()
+			}
+
 		case <-timer.C:
 			d.heartbeat(state)
 
@@ -722,6 +817,10 @@ func (d *Daemon) heartbeat(state *State) {
 		return
 	}
 
+	// Evaluate automatic E-stop triggers (opt-in). Runs ahead of the E-stop
+	// gate so auto-clear can lift a stop and resume management this cycle.
+	d.checkAutoEstop()
+
 	// Skip agent management if E-stop is active.
 	// The daemon stays alive (to maintain Dolt, etc.) but does NOT
 	// restart any agents. This prevents fighting the E-stop by auto-spawning
@@ -819,6 +918,9 @@ func (d *Daemon) heartbeat(state *State) {
 	// 7. Process lifecycle requests
 	d.processLifecycleRequests()
 
+	// 8. Report protocol mail rejected since the last heartbeat
+	d.checkDeadLetters()
+
 	// 9. (Removed) Stale agent check - violated "discover, don't track"
 
 	// 10. Check for GUPP violations (agents with work-on-hook not progressing)
@@ -1215,7 +1317,7 @@ func (d *Daemon) runDegradedBootTriage(b *boot.Boot) {
 	}
 
 	// Simple check: is Deacon session alive?
-	hasDeacon, err := d.tmux.HasSession(d.getDeaconSessionName())
+	hasDeacon, err := d.backend().HasSession(d.getDeaconSessionName())
 	if err != nil {
 		d.logger.Printf("Error checking Deacon session: %v", err)
 		status.LastAction = "error"
@@ -1367,7 +1469,7 @@ func (d *Daemon) checkDeaconHeartbeat() {
 	d.logger.Printf("Deacon heartbeat is stale (%s old), checking session...", age.Round(time.Minute))
 
 	// Check if session exists
-	hasSession, err := d.tmux.HasSession(sessionName)
+	hasSession, err := d.backend().HasSession(sessionName)
 	if err != nil {
 		d.logger.Printf("Error checking Deacon session: %v", err)
 		return
@@ -1429,6 +1531,12 @@ func (d *Daemon) hasPendingEvents(channel string) bool {
 // ensureWitnessRunning ensures the witness for a specific rig is running.
 // Discover, don't track: uses Manager.Start() which checks tmux directly (gt-zecmc).
 func (d *Daemon) ensureWitnessRunning(rigName string) {
+	// A frozen witness must stay frozen: restarting it would undo the E-stop.
+	if d.isEstopped(rigName, session.RoleWitness) {
+		d.logger.Printf("Skipping witness auto-start for %s: e-stopped", rigName)
+		return
+	}
+
 	// Check rig operational state before auto-starting
 	if operational, reason := d.isRigOperational(rigName); !operational {
 		d.logger.Printf("Skipping witness auto-start for %s: %s", rigName, reason)
@@ -1436,9 +1544,9 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 		// Without this, sessions started before the rig was docked survive until
 		// the next explicit 'gt rig dock' command. (hq-snx61)
 		name := session.WitnessSessionName(session.PrefixFor(rigName))
-		if exists, _ := d.tmux.HasSession(name); exists {
+		if exists, _ := d.backend().HasSession(name); exists {
 			d.logger.Printf("Killing leftover witness %s (rig %s)", name, reason)
-			if err := d.tmux.KillSessionWithProcesses(name); err != nil {
+			if err := d.backend().KillSessionWithProcesses(name); err != nil {
 				d.logger.Printf("Error killing leftover witness %s: %v", name, err)
 			}
 		}
@@ -1488,6 +1596,11 @@ func (d *Daemon) ensureRefineriesRunning() {
 // ensureRefineryRunning ensures the refinery for a specific rig is running.
 // Discover, don't track: uses Manager.Start() which checks tmux directly (gt-zecmc).
 func (d *Daemon) ensureRefineryRunning(rigName string) {
+	if d.isEstopped(rigName, session.RoleRefinery) {
+		d.logger.Printf("Skipping refinery auto-start for %s: e-stopped", rigName)
+		return
+	}
+
 	// Check rig operational state before auto-starting
 	if operational, reason := d.isRigOperational(rigName); !operational {
 		d.logger.Printf("Skipping refinery auto-start for %s: %s", rigName, reason)
@@ -1495,9 +1608,9 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 		// Without this, sessions started before the rig was docked survive until
 		// the next explicit 'gt rig dock' command. (hq-snx61)
 		name := session.RefinerySessionName(session.PrefixFor(rigName))
-		if exists, _ := d.tmux.HasSession(name); exists {
+		if exists, _ := d.backend().HasSession(name); exists {
 			d.logger.Printf("Killing leftover refinery %s (rig %s)", name, reason)
-			if err := d.tmux.KillSessionWithProcesses(name); err != nil {
+			if err := d.backend().KillSessionWithProcesses(name); err != nil {
 				d.logger.Printf("Error killing leftover refinery %s: %v", name, err)
 			}
 		}
@@ -1594,10 +1707,10 @@ func (d *Daemon) ensureMayorRunning() {
 	d.logger.Println("Mayor started successfully")
 }
 
-// isMayorAgentAlive checks if the Mayor's agent process is running in tmux.
+// isMayorAgentAlive checks if the Mayor's agent process is running. It asks
+// the town's session backend, which is where mayor.Manager started it.
 func (d *Daemon) isMayorAgentAlive(mgr *mayor.Manager) bool {
-	t := tmux.NewTmux()
-	return t.IsAgentAlive(mgr.SessionName())
+	return mgr.IsAgentAlive()
 }
 
 // killDeaconSessions kills leftover deacon and boot tmux sessions.
@@ -1605,10 +1718,10 @@ func (d *Daemon) isMayorAgentAlive(mgr *mayor.Manager) bool {
 // running their own patrol loops and spawning agents. (hq-2mstj)
 func (d *Daemon) killDeaconSessions() {
 	for _, name := range []string{session.DeaconSessionName(), session.BootSessionName()} {
-		exists, _ := d.tmux.HasSession(name)
+		exists, _ := d.backend().HasSession(name)
 		if exists {
 			d.logger.Printf("Killing leftover %s session (patrol disabled)", name)
-			if err := d.tmux.KillSessionWithProcesses(name); err != nil {
+			if err := d.backend().KillSessionWithProcesses(name); err != nil {
 				d.logger.Printf("Error killing %s session: %v", name, err)
 			}
 		}
@@ -1620,10 +1733,10 @@ func (d *Daemon) killDeaconSessions() {
 func (d *Daemon) killWitnessSessions() {
 	for _, rigName := range d.getKnownRigs() {
 		name := session.WitnessSessionName(session.PrefixFor(rigName))
-		exists, _ := d.tmux.HasSession(name)
+		exists, _ := d.backend().HasSession(name)
 		if exists {
 			d.logger.Printf("Killing leftover %s session (patrol disabled)", name)
-			if err := d.tmux.KillSessionWithProcesses(name); err != nil {
+			if err := d.backend().KillSessionWithProcesses(name); err != nil {
 				d.logger.Printf("Error killing %s session: %v", name, err)
 			}
 		}
@@ -1635,10 +1748,10 @@ func (d *Daemon) killWitnessSessions() {
 func (d *Daemon) killRefinerySessions() {
 	for _, rigName := range d.getKnownRigs() {
 		name := session.RefinerySessionName(session.PrefixFor(rigName))
-		exists, _ := d.tmux.HasSession(name)
+		exists, _ := d.backend().HasSession(name)
 		if exists {
 			d.logger.Printf("Killing leftover %s session (patrol disabled)", name)
-			if err := d.tmux.KillSessionWithProcesses(name); err != nil {
+			if err := d.backend().KillSessionWithProcesses(name); err != nil {
 				d.logger.Printf("Error killing %s session: %v", name, err)
 			}
 		}
@@ -1674,10 +1787,10 @@ func (d *Daemon) killDefaultPrefixGhosts() {
 	// Kill ghost sessions using the default "gt" prefix for patrol roles.
 	for _, role := range []string{"witness", "refinery"} {
 		ghostName := fmt.Sprintf("%s-%s", session.DefaultPrefix, role)
-		exists, _ := d.tmux.HasSession(ghostName)
+		exists, _ := d.backend().HasSession(ghostName)
 		if exists {
 			d.logger.Printf("Killing ghost session %s (default prefix, stale registry artifact)", ghostName)
-			if err := d.tmux.KillSessionWithProcesses(ghostName); err != nil {
+			if err := d.backend().KillSessionWithProcesses(ghostName); err != nil {
 				d.logger.Printf("Error killing ghost session %s: %v", ghostName, err)
 			}
 		}
@@ -1701,11 +1814,11 @@ func (d *Daemon) killDefaultPrefixGhosts() {
 			}
 			polecatName := entry.Name()
 			ghostName := fmt.Sprintf("%s-%s", session.DefaultPrefix, polecatName)
-			exists, _ := d.tmux.HasSession(ghostName)
+			exists, _ := d.backend().HasSession(ghostName)
 			if exists {
 				// Verify the correct session isn't also running (avoid killing legit sessions)
 				correctName := session.PolecatSessionName(rigPrefix, polecatName)
-				correctExists, _ := d.tmux.HasSession(correctName)
+				correctExists, _ := d.backend().HasSession(correctName)
 				if !correctExists {
 					// Ghost is the only session — it might be doing real work.
 					// Log but don't kill; the registry reload will prevent new ghosts.
@@ -1713,7 +1826,7 @@ func (d *Daemon) killDefaultPrefixGhosts() {
 				} else {
 					// Both exist — ghost is definitely a duplicate, kill it.
 					d.logger.Printf("Killing duplicate ghost polecat session %s (correct session %s exists)", ghostName, correctName)
-					if err := d.tmux.KillSessionWithProcesses(ghostName); err != nil {
+					if err := d.backend().KillSessionWithProcesses(ghostName); err != nil {
 						d.logger.Printf("Error killing ghost session %s: %v", ghostName, err)
 					}
 				}
@@ -1909,6 +2022,18 @@ func (d *Daemon) shutdown(state *State) error { //nolint:unparam // error return
 		d.logger.Println("Feed curator stopped")
 	}
 
+	// Stop event bus (subscribers fall back to following the journal)
+	if d.eventBus != nil {
+		d.eventBus.Stop()
+		d.logger.Println("Event bus stopped")
+	}
+
+	// Stop headless supervisor (kills its sessions; nothing can reach them after)
+	if d.headless != nil {
+		d.headless.Stop()
+		d.logger.Println("Headless session supervisor stopped")
+	}
+
 	// Stop convoy manager (also closes beads stores)
 	if d.convoyManager != nil {
 		d.convoyManager.Stop()
@@ -2234,6 +2359,11 @@ func (d *Daemon) checkPolecatSessionHealth() {
 
 // checkRigPolecatHealth checks polecat session health for a specific rig.
 func (d *Daemon) checkRigPolecatHealth(rigName string) {
+	// Frozen polecats look dead or stuck; don't restart them under an E-stop.
+	if d.isEstopped(rigName, session.RolePolecat) {
+		return
+	}
+
 	// Get polecat directories for this rig
 	polecatsDir := filepath.Join(d.config.TownRoot, rigName, "polecats")
 	polecats, err := listPolecatWorktrees(polecatsDir)
@@ -2268,13 +2398,13 @@ func listPolecatWorktrees(polecatsDir string) ([]string, error) {
 }
 
 // checkPolecatHealth checks a single polecat's session health.
-// If the polecat has work-on-hook but the tmux session is dead, it's restarted.
+// If the polecat has work-on-hook but its session is dead, it's restarted.
 func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
-	// Build the expected tmux session name
+	// Build the expected session name
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
 
-	// Check if tmux session exists
-	sessionAlive, err := d.tmux.HasSession(sessionName)
+	// Check if the session exists on the town's backend
+	sessionAlive, err := d.backend().HasSession(sessionName)
 	if err != nil {
 		d.logger.Printf("Error checking session %s: %v", sessionName, err)
 		return
@@ -2358,7 +2488,7 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	// TOCTOU guard: re-verify session is still dead before restarting.
 	// Between the initial check and now, the session may have been restarted
 	// by another heartbeat cycle, witness, or the polecat itself.
-	sessionRevived, err := d.tmux.HasSession(sessionName)
+	sessionRevived, err := d.backend().HasSession(sessionName)
 	if err == nil && sessionRevived {
 		return // Session came back - no restart needed
 	}
@@ -2378,8 +2508,34 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	d.notifyWitnessOfCrashedPolecat(rigName, polecatName, info.HookBead)
 }
 
+// backend returns the session backend the town's agents run on. Daemons
+// built without one fall back to tmux.
+func (d *Daemon) backend() session.SessionBackend {
+	if d.sessions != nil {
+		return d.sessions
+	}
+	return d.tmux
+}
+
+// isAgentRunning reports whether an agent is running in the session. Pane
+// commands only exist on tmux; other backends end the session with the agent.
+func (d *Daemon) isAgentRunning(sessionName string) bool {
+	if t, ok := d.backend().(*tmux.Tmux); ok {
+		return t.IsAgentRunning(sessionName)
+	}
+	return d.backend().IsAgentAlive(sessionName)
+}
+
 // recordSessionDeath records a session death and checks for mass death pattern.
 func (d *Daemon) recordSessionDeath(sessionName string) {
+	var storm []string
+	defer func() {
+		// Outside deathsMu: tripping an E-stop signals sessions and escalates.
+		if storm != nil {
+			d.noteMassDeath(storm)
+		}
+	}()
+
 	d.deathsMu.Lock()
 	defer d.deathsMu.Unlock()
 
@@ -2403,12 +2559,13 @@ func (d *Daemon) recordSessionDeath(sessionName string) {
 
 	// Check for mass death
 	if len(d.recentDeaths) >= massDeathThreshold {
-		d.emitMassDeathEvent()
+		storm = d.emitMassDeathEvent()
 	}
 }
 
 // emitMassDeathEvent logs a mass death event when multiple sessions die in a short window.
-func (d *Daemon) emitMassDeathEvent() {
+// Returns the sessions that died so the caller can feed the auto E-stop.
+func (d *Daemon) emitMassDeathEvent() []string {
 	// Collect session names
 	var sessions []string
 	for _, death := range d.recentDeaths {
@@ -2426,6 +2583,7 @@ func (d *Daemon) emitMassDeathEvent() {
 
 	// Clear the deaths to avoid repeated alerts
 	d.recentDeaths = nil
+	return sessions
 }
 
 // isBeadClosed checks if a bead's status is "closed" by querying bd show --json.
@@ -2511,6 +2669,11 @@ func (d *Daemon) reapIdlePolecats() {
 
 // reapRigIdlePolecats checks all polecats in a rig and kills idle sessions.
 func (d *Daemon) reapRigIdlePolecats(rigName string, timeout time.Duration) {
+	// Frozen polecats stop heartbeating; don't reap them as idle.
+	if d.isEstopped(rigName, session.RolePolecat) {
+		return
+	}
+
 	polecatsDir := filepath.Join(d.config.TownRoot, rigName, "polecats")
 	polecats, err := listPolecatWorktrees(polecatsDir)
 	if err != nil {
@@ -2533,7 +2696,7 @@ func (d *Daemon) reapIdlePolecat(rigName, polecatName string, timeout time.Durat
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
 
 	// Only check sessions that are actually alive
-	alive, err := d.tmux.HasSession(sessionName)
+	alive, err := d.backend().HasSession(sessionName)
 	if err != nil || !alive {
 		return
 	}
@@ -2570,7 +2733,7 @@ func (d *Daemon) reapIdlePolecat(rigName, polecatName string, timeout time.Durat
 			// If heartbeat is stale enough (2x timeout), reap anyway to prevent
 			// indefinite API burn when bead infrastructure is degraded.
 			// But first check if the agent is actually running (GH#3342).
-			if staleDuration >= timeout*2 && !d.tmux.IsAgentRunning(sessionName) {
+			if staleDuration >= timeout*2 && !d.isAgentRunning(sessionName) {
 				d.killIdlePolecat(rigName, polecatName, sessionName, staleDuration, timeout, "working-bead-lookup-failed")
 			}
 			return
@@ -2598,7 +2761,7 @@ func (d *Daemon) reapIdlePolecat(rigName, polecatName string, timeout time.Durat
 		// No hooked work + stale heartbeat — but check if the agent process
 		// is still actively running before reaping. A failed gt sling rollback
 		// can clear the hook while the agent is still working (GH#3342).
-		if d.tmux.IsAgentRunning(sessionName) {
+		if d.isAgentRunning(sessionName) {
 			return
 		}
 		d.killIdlePolecat(rigName, polecatName, sessionName, staleDuration, timeout, "working-no-hook")
@@ -2611,7 +2774,7 @@ func (d *Daemon) killIdlePolecat(rigName, polecatName, sessionName string, idleD
 		rigName, polecatName, reason, idleDuration.Truncate(time.Second), timeout)
 
 	// Kill the tmux session (and all descendant processes)
-	if err := d.tmux.KillSessionWithProcesses(sessionName); err != nil {
+	if err := d.backend().KillSessionWithProcesses(sessionName); err != nil {
 		d.logger.Printf("Warning: failed to kill idle polecat session %s: %v", sessionName, err)
 		return
 	}
@@ -0,0 +1,38 @@
+package daemon
+
+import (
+	"github.com/steveyegge/gastown/internal/protocol"
+)
+
+// newDeadLetterQueue opens the town's protocol dead-letter queue and returns
+// it with the number of entries already in it, so only messages rejected
+// while this daemon runs are reported.
+func newDeadLetterQueue(townRoot string) (*protocol.DeadLetterQueue, int) {
+	q := protocol.NewDeadLetterQueue(protocol.DeadLetterPath(townRoot))
+	letters, _ := q.List()
+	return q, len(letters)
+}
+
+// checkDeadLetters logs protocol messages dead-lettered since the last
+// heartbeat. Receivers (witness handlers, gt mail send) append to the queue
+// from their own processes; the daemon is where a sender writing bad mail
+// becomes visible to operators.
+func (d *Daemon) checkDeadLetters() {
+	if d.deadLetters == nil {
+		return
+	}
+	letters, err := d.deadLetters.List()
+	if err != nil {
+		d.logger.Printf("Warning: reading protocol dead-letter queue: %v", err)
+		return
+	}
+	if len(letters) < d.deadLettersSeen {
+		// The queue was truncated by hand; start over.
+		d.deadLettersSeen = 0
+	}
+	for _, dl := range letters[d.deadLettersSeen:] {
+		d.logger.Printf("Protocol message dead-lettered: %q from %s to %s (id=%s): %s",
+			dl.Subject, dl.From, dl.To, dl.MessageID, dl.Reason)
+	}
+	d.deadLettersSeen = len(letters)
+}
@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/protocol"
+)
+
+func TestCheckDeadLetters_LogsOnlyNewEntries(t *testing.T) {
+	townRoot := t.TempDir()
+	q := protocol.NewDeadLetterQueue(protocol.DeadLetterPath(townRoot))
+	reject := errors.New("missing required fields: Branch")
+	if err := q.Add(&mail.Message{ID: "hq-old", Subject: "MERGED nux"}, reject); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	dl, seen := newDeadLetterQueue(townRoot)
+	d := &Daemon{
+		config:          &Config{TownRoot: townRoot},
+		logger:          log.New(&buf, "", 0),
+		deadLetters:     dl,
+		deadLettersSeen: seen,
+	}
+
+	d.checkDeadLetters()
+	if buf.Len() != 0 {
+		t.Errorf("entries from before startup were logged: %s", buf.String())
+	}
+
+	if err := q.Add(&mail.Message{ID: "hq-new", From: "gastown/refinery", Subject: "MERGED ace"}, reject); err != nil {
+		t.Fatal(err)
+	}
+	d.checkDeadLetters()
+	out := buf.String()
+	if !strings.Contains(out, "hq-new") || strings.Contains(out, "hq-old") {
+		t.Errorf("log = %q, want only hq-new", out)
+	}
+
+	buf.Reset()
+	d.checkDeadLetters()
+	if buf.Len() != 0 {
+		t.Errorf("entry logged twice: %s", buf.String())
+	}
+}
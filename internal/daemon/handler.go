@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -54,7 +55,7 @@ func (d *Daemon) handleDogs() {
 	d.cleanupStuckDogs(mgr, sm)
 	d.detectStaleWorkingDogs(mgr, sm, opCfg)
 	d.reapIdleDogs(mgr, sm, opCfg)
-	d.dispatchPlugins(mgr, sm, rigsConfig)
+	d.dispatchPlugins(mgr, sm, rigsConfig, false)
 }
 
 // handleDogsCleanupOnly runs dog lifecycle cleanup (stuck, stale, idle) without
@@ -213,9 +214,10 @@ func (d *Daemon) reapIdleDogs(mgr *dog.Manager, sm *dog.SessionManager, daemonCf
 	}
 }
 
-// dispatchPlugins scans for plugins, evaluates cooldown gates, and dispatches
-// eligible plugins to idle dogs.
-func (d *Daemon) dispatchPlugins(mgr *dog.Manager, sm *dog.SessionManager, rigsConfig *config.RigsConfig) {
+// dispatchPlugins scans for plugins, evaluates their gates (cooldown, cron,
+// condition, event), and dispatches eligible plugins to idle dogs. With
+// eventsOnly, only event-gated plugins are considered.
+func (d *Daemon) dispatchPlugins(mgr *dog.Manager, sm *dog.SessionManager, rigsConfig *config.RigsConfig, eventsOnly bool) {
 	// Get rig names for scanner
 	var rigNames []string
 	if rigsConfig != nil {
@@ -238,22 +240,32 @@ func (d *Daemon) dispatchPlugins(mgr *dog.Manager, sm *dog.SessionManager, rigsC
 	recorder := plugin.NewRecorder(d.config.TownRoot)
 	router := mail.NewRouterWithTownRoot(d.config.TownRoot, d.config.TownRoot)
 
+	gates := d.pluginGates()
+	gates.collectEvents(plugins)
+
+	ctx := d.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	for _, p := range plugins {
-		// Only dispatch plugins with cooldown gates.
-		if p.Gate == nil || p.Gate.Type != plugin.GateCooldown {
+		// Manual gates (and plugins without one) never auto-dispatch.
+		if p.Gate == nil || p.Gate.Type == plugin.GateManual {
+			continue
+		}
+		if eventsOnly && p.Gate.Type != plugin.GateEvent {
 			continue
 		}
 
-		// Evaluate cooldown: skip if plugin ran recently.
-		if p.Gate.Duration != "" {
-			count, err := recorder.CountRunsSince(p.Name, p.Gate.Duration)
-			if err != nil {
-				d.logger.Printf("Handler: error checking cooldown for plugin %s: %v", p.Name, err)
-				continue
-			}
-			if count > 0 {
-				continue // Still in cooldown
-			}
+		gate, err := plugin.EvaluateGate(ctx, p, gates.input(p, recorder, time.Now()))
+		if err != nil {
+			d.logger.Printf("Handler: error evaluating %s gate for plugin %s: %v", p.Gate.Type, p.Name, err)
+			continue
+		}
+		if !gate.Open {
+			// An event that arrived inside the debounce interval is dropped.
+			gates.done(p.Name)
+			continue
 		}
 
 		// Find an idle dog.
@@ -299,7 +311,8 @@ func (d *Daemon) dispatchPlugins(mgr *dog.Manager, sm *dog.SessionManager, rigsC
 			// Session is already started — dog will find no mail and idle out.
 		}
 
-		d.logger.Printf("Handler: dispatched plugin %s to dog %s", p.Name, idleDog.Name)
+		d.logger.Printf("Handler: dispatched plugin %s to dog %s (%s gate: %s)", p.Name, idleDog.Name, p.Gate.Type, gate.Reason)
+		gates.done(p.Name)
 
 		// Record the dispatch immediately so the cooldown gate is satisfied
 		// for the next 1h regardless of what the dog does. Dogs create their
@@ -174,7 +174,7 @@ func (d *Daemon) executeLifecycleAction(request *LifecycleRequest) error {
 	}
 
 	// Check if session exists (tmux detection still needed for lifecycle actions)
-	running, err := d.tmux.HasSession(sessionName)
+	running, err := d.backend().HasSession(sessionName)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
 	}
@@ -184,7 +184,7 @@ func (d *Daemon) executeLifecycleAction(request *LifecycleRequest) error {
 		if running {
 			// Use KillSessionWithProcesses to ensure all descendant processes are killed.
 			// This prevents orphan bash processes from Claude's Bash tool surviving session termination.
-			if err := d.tmux.KillSessionWithProcesses(sessionName); err != nil {
+			if err := d.backend().KillSessionWithProcesses(sessionName); err != nil {
 				return fmt.Errorf("killing session: %w", err)
 			}
 			d.logger.Printf("Killed session %s", sessionName)
@@ -194,7 +194,7 @@ func (d *Daemon) executeLifecycleAction(request *LifecycleRequest) error {
 	case ActionCycle, ActionRestart:
 		if running {
 			// Kill the session first - use KillSessionWithProcesses to prevent orphan processes.
-			if err := d.tmux.KillSessionWithProcesses(sessionName); err != nil {
+			if err := d.backend().KillSessionWithProcesses(sessionName); err != nil {
 				return fmt.Errorf("killing session: %w", err)
 			}
 			d.logger.Printf("Killed session %s for restart", sessionName)
@@ -1090,7 +1090,7 @@ func (d *Daemon) checkRigGUPPViolations(rigName string) {
 		sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
 
 		// Check if tmux session exists and agent is running
-		if d.tmux.IsAgentAlive(sessionName) {
+		if d.backend().IsAgentAlive(sessionName) {
 			// Session is alive - check if it's been stuck too long
 			updatedAt, err := time.Parse(time.RFC3339, agent.UpdatedAt)
 			if err != nil {
@@ -1201,14 +1201,14 @@ func (d *Daemon) checkRigOrphanedWork(rigName string) {
 		sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
 
 		// Session running = not orphaned (work is being processed)
-		if d.tmux.IsAgentAlive(sessionName) {
+		if d.backend().IsAgentAlive(sessionName) {
 			continue
 		}
 
 		// TOCTOU guard: re-verify agent state before taking action.
 		// Between the bd list above and now, the agent may have been
 		// restarted or its hook_bead cleared. Re-check both conditions.
-		if d.tmux.IsAgentAlive(sessionName) {
+		if d.backend().IsAgentAlive(sessionName) {
 			continue
 		}
 		currentHookBead := d.getAgentHookBead(agent.ID)
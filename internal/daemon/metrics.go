@@ -30,6 +30,14 @@ type daemonMetrics struct {
 	doltLatencyMs      float64
 	doltDiskBytes      int64
 	doltHealthy        int64 // 1 = healthy, 0 = unhealthy
+
+	// countMu protects in-process mirrors of the counters above. The
+	// Prometheus endpoint reads these directly, so it works without an OTel
+	// push exporter configured.
+	countMu    sync.Mutex
+	heartbeats int64
+	restarts   map[string]int64 // by agent type
+	spawns     map[string]int64 // by rig
 }
 
 // newDaemonMetrics registers all daemon OTel instruments against the global
@@ -37,7 +45,10 @@ type daemonMetrics struct {
 // Returns a no-op struct if no provider is configured.
 func newDaemonMetrics() (*daemonMetrics, error) {
 	m := otel.GetMeterProvider().Meter(meterName)
-	dm := &daemonMetrics{}
+	dm := &daemonMetrics{
+		restarts: make(map[string]int64),
+		spawns:   make(map[string]int64),
+	}
 
 	var err error
 
@@ -124,6 +135,9 @@ func (dm *daemonMetrics) recordHeartbeat(ctx context.Context) {
 		return
 	}
 	dm.heartbeatTotal.Add(ctx, 1)
+	dm.countMu.Lock()
+	dm.heartbeats++
+	dm.countMu.Unlock()
 }
 
 // recordRestart increments the restart counter, labeled with the agent type
@@ -135,6 +149,9 @@ func (dm *daemonMetrics) recordRestart(ctx context.Context, agentType string) {
 	dm.restartTotal.Add(ctx, 1,
 		metric.WithAttributes(attribute.String("agent.type", agentType)),
 	)
+	dm.countMu.Lock()
+	dm.restarts[agentType]++
+	dm.countMu.Unlock()
 }
 
 // recordPolecatSpawn increments the polecat spawn counter, labeled with the rig name.
@@ -145,6 +162,9 @@ func (dm *daemonMetrics) recordPolecatSpawn(ctx context.Context, rigName string)
 	dm.polecatSpawns.Add(ctx, 1,
 		metric.WithAttributes(attribute.String("rig", rigName)),
 	)
+	dm.countMu.Lock()
+	dm.spawns[rigName]++
+	dm.countMu.Unlock()
 }
 
 // updateDoltHealth stores the latest Dolt health snapshot for observable gauges.
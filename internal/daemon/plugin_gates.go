@@ -0,0 +1,175 @@
+package daemon
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/dog"
+	"github.com/steveyegge/gastown/internal/estop"
+	"github.com/steveyegge/gastown/internal/eventbus"
+	"github.com/steveyegge/gastown/internal/plugin"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// pluginEventCoalesce is how long the plugin event follower batches events
+// before waking the dispatcher, so a burst of events costs one plugin scan.
+const pluginEventCoalesce = 2 * time.Second
+
+// pluginGateState carries plugin gate state between dispatch passes: the
+// daemon start (cron anchor), the queue of logged events, and event gates
+// that opened but have not been dispatched yet (e.g. no idle dog). Only
+// accessed from the main loop goroutine.
+type pluginGateState struct {
+	startedAt   time.Time
+	startupSeen bool
+	events      *pluginEventQueue
+	pending     map[string]string // plugin name → event that opened its gate
+}
+
+func newPluginGateState(events *pluginEventQueue, now time.Time) *pluginGateState {
+	return &pluginGateState{
+		startedAt: now,
+		events:    events,
+		pending:   make(map[string]string),
+	}
+}
+
+// pluginGates returns the daemon's plugin gate state, creating it on first use.
+func (d *Daemon) pluginGates() *pluginGateState {
+	if d.pluginGateState == nil {
+		d.pluginGateState = newPluginGateState(d.pluginEvents, time.Now())
+	}
+	return d.pluginGateState
+}
+
+// collectEvents takes the events logged since the last pass (plus "startup" on
+// the first pass) and marks every event-gated plugin subscribed to one of
+// them as pending.
+func (s *pluginGateState) collectEvents(plugins []*plugin.Plugin) {
+	newEvents := s.events.drain()
+	if !s.startupSeen {
+		s.startupSeen = true
+		newEvents = append(newEvents, plugin.EventStartup)
+	}
+	if len(newEvents) == 0 {
+		return
+	}
+	for _, p := range plugins {
+		if p.Gate == nil || p.Gate.Type != plugin.GateEvent {
+			continue
+		}
+		types := p.Gate.EventTypes()
+		for _, ev := range newEvents {
+			if slices.Contains(types, ev) {
+				s.pending[p.Name] = ev
+				break
+			}
+		}
+	}
+}
+
+// input builds the gate input for p.
+func (s *pluginGateState) input(p *plugin.Plugin, history plugin.RunHistory, now time.Time) plugin.GateInput {
+	in := plugin.GateInput{Now: now, Since: s.startedAt, History: history}
+	if ev, ok := s.pending[p.Name]; ok {
+		in.Events = []string{ev}
+	}
+	return in
+}
+
+// done drops p's pending event once it has been dispatched or debounced.
+func (s *pluginGateState) done(name string) {
+	delete(s.pending, name)
+}
+
+// pluginEventQueue collects the types of activity events logged since the
+// last dispatch pass. The event bus follower pushes and the main loop
+// drains.
+type pluginEventQueue struct {
+	mu    sync.Mutex
+	types []string
+}
+
+// push queues an event type. A type already waiting is not queued twice.
+func (q *pluginEventQueue) push(eventType string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if eventType != "" && !slices.Contains(q.types, eventType) {
+		q.types = append(q.types, eventType)
+	}
+}
+
+// drain returns the queued event types and empties the queue. A nil queue
+// is always empty.
+func (q *pluginEventQueue) drain() []string {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	types := q.types
+	q.types = nil
+	return types
+}
+
+// followPluginEvents queues every activity event appended to the journal
+// and signals notify (coalesced), until ctx is done. Channel events and town
+// log lines are skipped even when their types match a plugin's gate.
+// eventbus.Follow shares the journal watcher with the daemon's event bus
+// server.
+func (d *Daemon) followPluginEvents(ctx context.Context, q *pluginEventQueue, notify chan<- struct{}) {
+	var pending atomic.Bool
+	go func() {
+		coalesce := time.NewTicker(pluginEventCoalesce)
+		defer coalesce.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-coalesce.C:
+				if pending.Swap(false) {
+					select {
+					case notify <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}
+	}()
+
+	filter := eventbus.Filter{Sources: []string{eventbus.SourceGT}}
+	err := eventbus.Follow(ctx, d.config.TownRoot, eventbus.FromEnd, filter, func(ev eventbus.Event) error {
+		q.push(ev.Type)
+		pending.Store(true)
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		d.logger.Printf("Handler: plugin event follower failed: %v", err)
+	}
+}
+
+// dispatchEventPlugins dispatches event-gated plugins between heartbeats, as
+// soon as a subscribed event is logged. It applies the same E-stop and
+// pressure gates as the heartbeat's dog handling.
+func (d *Daemon) dispatchEventPlugins() {
+	if estop.IsActive(d.config.TownRoot) || !d.isPatrolActive("handler") {
+		return
+	}
+	if p := d.checkPressure("dog"); !p.OK {
+		d.logger.Printf("Deferring event plugin dispatch: %s", p.Reason)
+		return
+	}
+
+	rigsConfig, err := d.loadRigsConfig()
+	if err != nil {
+		d.logger.Printf("Handler: failed to load rigs config: %v", err)
+		return
+	}
+
+	mgr := dog.NewManager(d.config.TownRoot, rigsConfig)
+	sm := dog.NewSessionManager(tmux.NewTmux(), d.config.TownRoot, mgr)
+	d.dispatchPlugins(mgr, sm, rigsConfig, true)
+}
@@ -0,0 +1,134 @@
+package daemon
+
+import (
+	"context"
+	"io"
+	"log"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/eventbus"
+	"github.com/steveyegge/gastown/internal/plugin"
+)
+
+func TestPluginEventQueue(t *testing.T) {
+	var nilQueue *pluginEventQueue
+	if got := nilQueue.drain(); got != nil {
+		t.Fatalf("nil drain() = %v, want nil", got)
+	}
+
+	q := &pluginEventQueue{}
+	q.push("merged")
+	q.push("session_death")
+	q.push("merged")
+	if got := q.drain(); !slices.Equal(got, []string{"merged", "session_death"}) {
+		t.Fatalf("drain() = %v, want [merged session_death]", got)
+	}
+	if got := q.drain(); got != nil {
+		t.Fatalf("second drain() = %v, want nil", got)
+	}
+}
+
+func TestFollowPluginEvents_ActivityOnly(t *testing.T) {
+	townRoot := t.TempDir()
+	d := &Daemon{
+		config: &Config{TownRoot: townRoot},
+		logger: log.New(io.Discard, "", 0),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// History written before the follower starts is not replayed.
+	if _, err := eventbus.Append(townRoot, eventbus.Event{Source: eventbus.SourceGT, Type: "merged"}); err != nil {
+		t.Fatal(err)
+	}
+
+	q := &pluginEventQueue{}
+	notify := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		d.followPluginEvents(ctx, q, notify)
+		close(done)
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	// A town log "done" shares its type with the activity event but must
+	// not open a "done" gate.
+	for _, ev := range []eventbus.Event{
+		{Source: eventbus.SourceTownlog, Type: "done"},
+		{Source: eventbus.SourceChannel, Type: "done", Channel: "witness"},
+		{Source: eventbus.SourceGT, Type: "mass_death"},
+	} {
+		if _, err := eventbus.Append(townRoot, ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case <-notify:
+	case <-time.After(5 * time.Second):
+		t.Fatal("follower never signaled")
+	}
+	if got := q.drain(); !slices.Equal(got, []string{"mass_death"}) {
+		t.Errorf("queued = %v, want [mass_death]", got)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("follower did not stop")
+	}
+}
+
+func TestPluginGateState_CollectEvents(t *testing.T) {
+	now := time.Now()
+	q := &pluginEventQueue{}
+	s := newPluginGateState(q, now)
+
+	onStartup := &plugin.Plugin{Name: "on-startup", Gate: &plugin.Gate{Type: plugin.GateEvent, On: plugin.EventStartup}}
+	onDeath := &plugin.Plugin{Name: "on-death", Gate: &plugin.Gate{Type: plugin.GateEvent, On: "session_death, mass_death"}}
+	cooldown := &plugin.Plugin{Name: "cooldown", Gate: &plugin.Gate{Type: plugin.GateCooldown, Duration: "1h"}}
+	plugins := []*plugin.Plugin{onStartup, onDeath, cooldown}
+
+	// The first pass delivers "startup".
+	s.collectEvents(plugins)
+	if s.pending["on-startup"] != plugin.EventStartup {
+		t.Errorf("on-startup pending = %q, want startup", s.pending["on-startup"])
+	}
+	if _, ok := s.pending["on-death"]; ok {
+		t.Error("on-death should not be pending before any event")
+	}
+	s.done("on-startup")
+
+	// Startup is delivered only once.
+	s.collectEvents(plugins)
+	if _, ok := s.pending["on-startup"]; ok {
+		t.Error("startup delivered twice")
+	}
+
+	q.push("merged")
+	q.push("mass_death")
+	s.collectEvents(plugins)
+	if s.pending["on-death"] != "mass_death" {
+		t.Errorf("on-death pending = %q, want mass_death", s.pending["on-death"])
+	}
+	if _, ok := s.pending["cooldown"]; ok {
+		t.Error("non-event plugin marked pending")
+	}
+
+	// Pending events persist until dispatched, and feed the gate input.
+	s.collectEvents(plugins)
+	in := s.input(onDeath, nil, now)
+	if len(in.Events) != 1 || in.Events[0] != "mass_death" {
+		t.Errorf("input events = %v, want [mass_death]", in.Events)
+	}
+	if !in.Since.Equal(now) {
+		t.Errorf("input Since = %v, want daemon start %v", in.Since, now)
+	}
+	s.done("on-death")
+	if in := s.input(onDeath, nil, now); len(in.Events) != 0 {
+		t.Errorf("input events after done = %v, want none", in.Events)
+	}
+}
@@ -141,6 +141,49 @@ func TestCheckPolecatHealth_DetectsCrashedPolecat(t *testing.T) {
 	}
 }
 
+// liveBackend is a session backend (such as headless) on which the listed
+// sessions are alive.
+type liveBackend struct {
+	session.SessionBackend
+	live map[string]bool
+}
+
+func (b liveBackend) HasSession(name string) (bool, error) { return b.live[name], nil }
+func (b liveBackend) IsAgentAlive(name string) bool        { return b.live[name] }
+
+// TestCheckPolecatHealth_UsesSessionBackend verifies that a polecat alive on
+// a non-tmux backend is not reported as crashed just because tmux can't see it.
+func TestCheckPolecatHealth_UsesSessionBackend(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses Unix shell script mocks for tmux and bd")
+	}
+	binDir := t.TempDir()
+	writeFakeTestTmux(t, binDir)
+	recentTime := time.Now().UTC().Format(time.RFC3339)
+	bdPath := writeFakeTestBD(t, binDir, "working", "working", "gt-xyz", recentTime)
+
+	t.Setenv("PATH", binDir+":"+os.Getenv("PATH"))
+
+	sessionName := session.PolecatSessionName(session.PrefixFor("myr"), "mycat")
+	var logBuf strings.Builder
+	d := &Daemon{
+		config:   &Config{TownRoot: t.TempDir()},
+		logger:   log.New(&logBuf, "", 0),
+		tmux:     tmux.NewTmux(),
+		sessions: liveBackend{live: map[string]bool{sessionName: true}},
+		bdPath:   bdPath,
+	}
+
+	d.checkPolecatHealth("myr", "mycat")
+
+	if got := logBuf.String(); strings.Contains(got, "CRASH DETECTED") {
+		t.Errorf("polecat alive on the session backend reported as crashed: %q", got)
+	}
+	if len(d.recentDeaths) != 0 {
+		t.Errorf("recorded %d session death(s) for a live polecat", len(d.recentDeaths))
+	}
+}
+
 // TestCheckPolecatHealth_SpawningGuardExpires verifies that the spawning guard
 // has a time-bound: polecats stuck in agent_state=spawning for more than 5 minutes
 // are treated as crashed (gt sling may have failed during spawn).
@@ -30,3 +30,14 @@ func sendTermSignal(p *os.Process) error {
 func sendKillSignal(p *os.Process) error {
 	return p.Signal(syscall.SIGKILL)
 }
+
+// stopProcessGroup sends SIGTSTP to the process group led by pid, freezing
+// an agent session in place (E-stop).
+func stopProcessGroup(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGTSTP)
+}
+
+// continueProcessGroup sends SIGCONT to the process group led by pid.
+func continueProcessGroup(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGCONT)
+}
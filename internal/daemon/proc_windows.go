@@ -3,6 +3,7 @@
 package daemon
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 )
@@ -36,3 +37,14 @@ func sendTermSignal(p *os.Process) error {
 func sendKillSignal(p *os.Process) error {
 	return p.Kill()
 }
+
+// stopProcessGroup is not supported on Windows; E-stop relies on the
+// sentinel file alone.
+func stopProcessGroup(pid int) error {
+	return errors.New("process group signals not supported on windows")
+}
+
+// continueProcessGroup is not supported on Windows.
+func continueProcessGroup(pid int) error {
+	return errors.New("process group signals not supported on windows")
+}
@@ -0,0 +1,347 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/estop"
+	"github.com/steveyegge/gastown/internal/quota"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+const (
+	// defaultMetricsListen is the default scrape address. Loopback only, so
+	// exposing town state to the network is an explicit choice.
+	defaultMetricsListen = "127.0.0.1:9464"
+	// defaultMetricsCacheTTL bounds how often a scrape re-queries beads and
+	// tmux. Queue and anomaly lookups shell out to bd once per rig.
+	defaultMetricsCacheTTL = 30 * time.Second
+)
+
+// MetricsConfig configures the daemon's Prometheus scrape endpoint.
+// The endpoint is independent of GT_OTEL_METRICS_URL: it serves the same
+// daemon instruments for pull-based monitoring, plus town state gauges.
+type MetricsConfig struct {
+	// Enabled controls whether the /metrics endpoint is served.
+	Enabled bool `json:"enabled"`
+
+	// Listen is the address to serve on (default 127.0.0.1:9464).
+	Listen string `json:"listen,omitempty"`
+
+	// CacheTTLStr is how long collected town state is reused across
+	// scrapes, as a string (e.g., "30s").
+	CacheTTLStr string `json:"cache_ttl,omitempty"`
+}
+
+// metricsListenAddr returns the scrape address, or false when the endpoint
+// is disabled.
+func metricsListenAddr(config *DaemonPatrolConfig) (string, bool) {
+	if config == nil || config.Metrics == nil || !config.Metrics.Enabled {
+		return "", false
+	}
+	if config.Metrics.Listen != "" {
+		return config.Metrics.Listen, true
+	}
+	return defaultMetricsListen, true
+}
+
+// metricsCacheTTL returns the configured cache TTL, or the default (30s).
+func metricsCacheTTL(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Metrics != nil && config.Metrics.CacheTTLStr != "" {
+		if d, err := time.ParseDuration(config.Metrics.CacheTTLStr); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return defaultMetricsCacheTTL
+}
+
+// townSnapshot is the town state exposed on /metrics alongside the daemon's
+// own instruments.
+type townSnapshot struct {
+	QueueDepth     map[string]int            // rig -> open MRs
+	Anomalies      map[string]map[string]int // rig -> anomaly type -> count
+	ActivePolecats map[string]int            // rig -> running polecat sessions
+	MaxPolecats    int                       // scheduler.max_polecats (-1 = direct dispatch)
+	Quota          map[string]string         // account handle -> quota status
+	Estops         []string                  // active E-stop scopes ("town", "<rig>", ...)
+}
+
+// promExporter serves daemon metrics in the Prometheus text exposition format.
+type promExporter struct {
+	metrics *daemonMetrics
+	collect func() *townSnapshot
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	cached   *townSnapshot
+	cachedAt time.Time
+}
+
+func newPromExporter(dm *daemonMetrics, collect func() *townSnapshot, ttl time.Duration) *promExporter {
+	return &promExporter{metrics: dm, collect: collect, ttl: ttl, now: time.Now}
+}
+
+// snapshot returns the cached town state, re-collecting it once the TTL has
+// passed. Concurrent scrapes share a single collection.
+func (p *promExporter) snapshot() *townSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cached == nil || p.now().Sub(p.cachedAt) >= p.ttl {
+		p.cached = p.collect()
+		p.cachedAt = p.now()
+	}
+	return p.cached
+}
+
+func (p *promExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if r.Method == http.MethodHead {
+		return
+	}
+	p.write(w)
+}
+
+// write renders every metric family.
+func (p *promExporter) write(out io.Writer) {
+	w := &promWriter{w: out}
+
+	if dm := p.metrics; dm != nil {
+		dm.countMu.Lock()
+		heartbeats := dm.heartbeats
+		restarts := copyCounts(dm.restarts)
+		spawns := copyCounts(dm.spawns)
+		dm.countMu.Unlock()
+
+		w.family("gastown_daemon_heartbeat_total", "counter", "Total number of daemon heartbeat cycles")
+		w.sample("gastown_daemon_heartbeat_total", nil, float64(heartbeats))
+
+		w.family("gastown_daemon_restart_total", "counter", "Total number of agent session restarts")
+		for _, k := range sortedKeys(restarts) {
+			w.sample("gastown_daemon_restart_total", []string{"agent_type", k}, float64(restarts[k]))
+		}
+
+		w.family("gastown_polecat_spawns_total", "counter", "Total number of polecat session spawns")
+		for _, k := range sortedKeys(spawns) {
+			w.sample("gastown_polecat_spawns_total", []string{"rig", k}, float64(spawns[k]))
+		}
+
+		dm.doltMu.RLock()
+		dolt := []struct {
+			name, help string
+			value      float64
+		}{
+			{"gastown_dolt_connections", "Active Dolt server connections", float64(dm.doltConnections)},
+			{"gastown_dolt_max_connections", "Configured maximum Dolt server connections", float64(dm.doltMaxConnections)},
+			{"gastown_dolt_query_latency_ms", "Dolt health probe round-trip latency in milliseconds", dm.doltLatencyMs},
+			{"gastown_dolt_disk_usage_bytes", "Dolt data directory disk usage", float64(dm.doltDiskBytes)},
+			{"gastown_dolt_healthy", "Dolt server health (1=healthy, 0=unhealthy)", float64(dm.doltHealthy)},
+		}
+		dm.doltMu.RUnlock()
+		for _, g := range dolt {
+			w.family(g.name, "gauge", g.help)
+			w.sample(g.name, nil, g.value)
+		}
+	}
+
+	snap := p.snapshot()
+	if snap == nil {
+		return
+	}
+
+	w.family("gastown_merge_queue_depth", "gauge", "Open merge requests per rig")
+	for _, rigName := range sortedKeys(snap.QueueDepth) {
+		w.sample("gastown_merge_queue_depth", []string{"rig", rigName}, float64(snap.QueueDepth[rigName]))
+	}
+
+	w.family("gastown_merge_queue_anomalies", "gauge", "Merge queue anomalies per rig and type (stale-claim, orphaned-branch)")
+	for _, rigName := range sortedKeys(snap.Anomalies) {
+		byType := snap.Anomalies[rigName]
+		for _, typ := range sortedKeys(byType) {
+			w.sample("gastown_merge_queue_anomalies", []string{"rig", rigName, "type", typ}, float64(byType[typ]))
+		}
+	}
+
+	w.family("gastown_polecats_active", "gauge", "Running polecat sessions per rig")
+	for _, rigName := range sortedKeys(snap.ActivePolecats) {
+		w.sample("gastown_polecats_active", []string{"rig", rigName}, float64(snap.ActivePolecats[rigName]))
+	}
+
+	w.family("gastown_scheduler_max_polecats", "gauge", "Configured scheduler.max_polecats (-1 = direct dispatch, 0 = scheduler paused)")
+	w.sample("gastown_scheduler_max_polecats", nil, float64(snap.MaxPolecats))
+
+	w.family("gastown_quota_account_status", "gauge", "Account quota status (1 for the account's current status)")
+	for _, handle := range sortedKeys(snap.Quota) {
+		w.sample("gastown_quota_account_status", []string{"account", handle, "status", snap.Quota[handle]}, 1)
+	}
+
+	w.family("gastown_estop_active", "gauge", "Active E-stops (1 per active scope)")
+	for _, scope := range snap.Estops {
+		w.sample("gastown_estop_active", []string{"scope", scope}, 1)
+	}
+	townStopped := 0.0
+	for _, scope := range snap.Estops {
+		if scope == "town" {
+			townStopped = 1
+		}
+	}
+	w.family("gastown_estop_town", "gauge", "Town-wide E-stop (1=active, 0=clear)")
+	w.sample("gastown_estop_town", nil, townStopped)
+}
+
+// promWriter writes Prometheus text exposition lines.
+type promWriter struct {
+	w io.Writer
+}
+
+func (w *promWriter) family(name, typ, help string) {
+	fmt.Fprintf(w.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes one sample; labels are alternating name/value pairs.
+func (w *promWriter) sample(name string, labels []string, value float64) {
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(labels[i])
+			b.WriteString(`="`)
+			b.WriteString(escapeLabelValue(labels[i+1]))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	b.WriteByte('\n')
+	_, _ = io.WriteString(w.w, b.String())
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+func copyCounts(m map[string]int64) map[string]int64 {
+	out := make(map[string]int64, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// collectTownSnapshot gathers queue, polecat, quota and E-stop state.
+// Every source is best-effort: a failing rig or file is skipped rather than
+// failing the scrape.
+func (d *Daemon) collectTownSnapshot() *townSnapshot {
+	snap := &townSnapshot{
+		QueueDepth:     make(map[string]int),
+		Anomalies:      make(map[string]map[string]int),
+		ActivePolecats: make(map[string]int),
+		MaxPolecats:    -1,
+		Quota:          make(map[string]string),
+	}
+
+	rigs := d.getKnownRigs()
+	sort.Strings(rigs)
+	now := time.Now()
+	for _, rigName := range rigs {
+		snap.ActivePolecats[rigName] = 0
+		eng := refinery.NewEngineer(&rig.Rig{
+			Name: rigName,
+			Path: filepath.Join(d.config.TownRoot, rigName),
+		})
+		if mrs, err := eng.ListAllOpenMRs(); err == nil {
+			snap.QueueDepth[rigName] = len(mrs)
+		}
+		if anomalies, err := eng.ListQueueAnomalies(now); err == nil {
+			byType := make(map[string]int)
+			for _, a := range anomalies {
+				byType[a.Type]++
+			}
+			snap.Anomalies[rigName] = byType
+		}
+	}
+
+	if d.tmux != nil {
+		if sessions, err := d.tmux.ListSessions(); err == nil {
+			for _, name := range sessions {
+				identity, err := session.ParseSessionName(name)
+				if err != nil || identity.Role != session.RolePolecat {
+					continue
+				}
+				snap.ActivePolecats[identity.Rig]++
+			}
+		}
+	}
+
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(d.config.TownRoot)); err == nil {
+		snap.MaxPolecats = settings.Scheduler.GetMaxPolecats()
+	}
+
+	if state, err := quota.NewManager(d.config.TownRoot).Load(); err == nil {
+		for handle, acct := range state.Accounts {
+			snap.Quota[handle] = string(acct.Status)
+		}
+	}
+
+	for _, scope := range estop.ActiveScopes(d.config.TownRoot) {
+		snap.Estops = append(snap.Estops, scope.String())
+	}
+	return snap
+}
+
+// startMetricsServer serves /metrics on addr until the daemon context ends.
+func (d *Daemon) startMetricsServer(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", newPromExporter(d.metrics, d.collectTownSnapshot, metricsCacheTTL(d.patrolConfig)))
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			d.logger.Printf("Metrics server stopped: %v", err)
+		}
+	}()
+	go func() {
+		<-d.ctx.Done()
+		_ = srv.Close()
+	}()
+	d.logger.Printf("Prometheus metrics endpoint at http://%s/metrics", ln.Addr())
+	return nil
+}
@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsListenAddr(t *testing.T) {
+	if _, ok := metricsListenAddr(nil); ok {
+		t.Error("nil config should disable the endpoint")
+	}
+	if _, ok := metricsListenAddr(&DaemonPatrolConfig{Metrics: &MetricsConfig{}}); ok {
+		t.Error("enabled=false should disable the endpoint")
+	}
+	addr, ok := metricsListenAddr(&DaemonPatrolConfig{Metrics: &MetricsConfig{Enabled: true}})
+	if !ok || addr != defaultMetricsListen {
+		t.Errorf("default addr = %q, %v", addr, ok)
+	}
+	addr, _ = metricsListenAddr(&DaemonPatrolConfig{Metrics: &MetricsConfig{Enabled: true, Listen: ":9100"}})
+	if addr != ":9100" {
+		t.Errorf("addr = %q, want :9100", addr)
+	}
+	if got := metricsCacheTTL(&DaemonPatrolConfig{Metrics: &MetricsConfig{CacheTTLStr: "5s"}}); got != 5*time.Second {
+		t.Errorf("cache TTL = %v, want 5s", got)
+	}
+}
+
+func TestPromExporter_Output(t *testing.T) {
+	dm, err := newDaemonMetrics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	dm.recordHeartbeat(ctx)
+	dm.recordHeartbeat(ctx)
+	dm.recordRestart(ctx, "witness")
+	dm.recordPolecatSpawn(ctx, "gastown")
+	dm.updateDoltHealth(3, 100, 1.5, 2048, true)
+
+	snap := &townSnapshot{
+		QueueDepth:     map[string]int{"gastown": 4, "beads": 0},
+		Anomalies:      map[string]map[string]int{"gastown": {"stale-claim": 1}},
+		ActivePolecats: map[string]int{"gastown": 2},
+		MaxPolecats:    5,
+		Quota:          map[string]string{"work": "limited"},
+		Estops:         []string{"town", "gastown/polecat"},
+	}
+	exp := newPromExporter(dm, func() *townSnapshot { return snap }, time.Minute)
+
+	rec := httptest.NewRecorder()
+	exp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE gastown_daemon_heartbeat_total counter\n",
+		"gastown_daemon_heartbeat_total 2\n",
+		`gastown_daemon_restart_total{agent_type="witness"} 1` + "\n",
+		`gastown_polecat_spawns_total{rig="gastown"} 1` + "\n",
+		"gastown_dolt_connections 3\n",
+		"gastown_dolt_query_latency_ms 1.5\n",
+		`gastown_merge_queue_depth{rig="beads"} 0` + "\n",
+		`gastown_merge_queue_depth{rig="gastown"} 4` + "\n",
+		`gastown_merge_queue_anomalies{rig="gastown",type="stale-claim"} 1` + "\n",
+		`gastown_polecats_active{rig="gastown"} 2` + "\n",
+		"gastown_scheduler_max_polecats 5\n",
+		`gastown_quota_account_status{account="work",status="limited"} 1` + "\n",
+		`gastown_estop_active{scope="gastown/polecat"} 1` + "\n",
+		"gastown_estop_town 1\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in output:\n%s", want, body)
+		}
+	}
+	if strings.Index(body, `rig="beads"`) > strings.Index(body, `gastown_merge_queue_depth{rig="gastown"}`) {
+		t.Error("samples should be sorted by label value")
+	}
+
+	rec = httptest.NewRecorder()
+	exp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", rec.Code)
+	}
+}
+
+func TestPromExporter_CachesSnapshot(t *testing.T) {
+	calls := 0
+	exp := newPromExporter(nil, func() *townSnapshot {
+		calls++
+		return &townSnapshot{MaxPolecats: calls}
+	}, time.Minute)
+	now := time.Now()
+	exp.now = func() time.Time { return now }
+
+	exp.snapshot()
+	exp.snapshot()
+	if calls != 1 {
+		t.Fatalf("collect called %d times within TTL, want 1", calls)
+	}
+	now = now.Add(2 * time.Minute)
+	if got := exp.snapshot().MaxPolecats; got != 2 || calls != 2 {
+		t.Errorf("after TTL: MaxPolecats=%d calls=%d, want re-collect", got, calls)
+	}
+}
+
+func TestPromWriter_EscapesLabels(t *testing.T) {
+	var b strings.Builder
+	w := &promWriter{w: &b}
+	w.sample("m", []string{"l", "a\"b\\c\nd"}, 1)
+	if got, want := b.String(), `m{l="a\"b\\c\nd"} 1`+"\n"; got != want {
+		t.Errorf("sample = %q, want %q", got, want)
+	}
+}
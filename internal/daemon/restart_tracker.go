@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 )
@@ -225,6 +226,21 @@ func (rt *RestartTracker) IsInCrashLoop(agentID string) bool {
 	return !info.CrashLoopSince.IsZero()
 }
 
+// CrashLoopingAgents returns the IDs of agents currently in crash-loop state, sorted.
+func (rt *RestartTracker) CrashLoopingAgents() []string {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	var agents []string
+	for id, info := range rt.state.Agents {
+		if !info.CrashLoopSince.IsZero() {
+			agents = append(agents, id)
+		}
+	}
+	sort.Strings(agents)
+	return agents
+}
+
 // GetBackoffRemaining returns how long until the agent can be restarted.
 func (rt *RestartTracker) GetBackoffRemaining(agentID string) time.Duration {
 	rt.mu.RLock()
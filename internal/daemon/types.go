@@ -131,6 +131,8 @@ type PatrolsConfig struct {
 	MainBranchTest         *MainBranchTestConfig          `json:"main_branch_test,omitempty"`
 	QuotaDog               *QuotaDogConfig                `json:"quota_dog,omitempty"`
 	RestartTracker         *RestartTrackerConfig          `json:"restart_tracker,omitempty"`
+	AutoEstop              *AutoEstopConfig               `json:"auto_estop,omitempty"`
+	BudgetDog              *BudgetDogConfig               `json:"budget_dog,omitempty"`
 }
 
 // DoltRemotesConfig holds configuration for the dolt_remotes patrol.
@@ -204,6 +206,8 @@ type DaemonPatrolConfig struct {
 	// Propagated to all sessions spawned by the daemon and read by gt up/mayor attach.
 	// Example: {"GT_DOLT_PORT": "43211"}
 	Env       map[string]string `json:"env,omitempty"`
+	// Metrics configures the opt-in Prometheus /metrics endpoint.
+	Metrics *MetricsConfig `json:"metrics,omitempty"`
 }
 
 // PatrolConfigFile returns the path to the patrol config file.
@@ -308,6 +312,18 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		}
 		return config.Patrols.QuotaDog.Enabled
 	}
+	if patrol == "auto_estop" {
+		if config == nil || config.Patrols == nil || config.Patrols.AutoEstop == nil {
+			return false
+		}
+		return config.Patrols.AutoEstop.Enabled
+	}
+	if patrol == "budget_dog" {
+		if config == nil || config.Patrols == nil || config.Patrols.BudgetDog == nil {
+			return false
+		}
+		return config.Patrols.BudgetDog.Enabled
+	}
 
 	if config == nil || config.Patrols == nil {
 		return true // Default: enabled
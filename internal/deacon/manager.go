@@ -20,14 +20,11 @@ var (
 	ErrAlreadyRunning = errors.New("deacon already running")
 )
 
-// tmuxOps abstracts tmux operations for testing.
+// tmuxOps abstracts tmux operations for testing: the common session
+// backend plus the tmux-only setup the deacon relies on.
 type tmuxOps interface {
-	HasSession(name string) (bool, error)
-	IsAgentAlive(session string) bool
-	KillSessionWithProcesses(name string) error
-	NewSessionWithCommand(name, workDir, command string) error
+	session.SessionBackend
 	SetRemainOnExit(pane string, on bool) error
-	SetEnvironment(session, key, value string) error
 	GetPaneID(session string) (string, error)
 	ConfigureGasTownSession(session string, theme *tmux.Theme, rig, worker, role string) error
 	WaitForCommand(session string, excludeCommands []string, timeout time.Duration) error
@@ -35,7 +32,6 @@ type tmuxOps interface {
 	AcceptStartupDialogs(session string) error
 	AcceptWorkspaceTrustDialog(session string) error
 	AcceptBypassPermissionsWarning(session string) error
-	SendKeysRaw(session, keys string) error
 	GetSessionInfo(name string) (*tmux.SessionInfo, error)
 }
 
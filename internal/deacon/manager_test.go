@@ -44,9 +44,17 @@ func (m *mockTmux) NewSessionWithCommand(_, _, _ string) error {
 	return m.newSessionErr
 }
 
-func (m *mockTmux) SetRemainOnExit(_ string, _ bool) error { return nil }
-func (m *mockTmux) SetEnvironment(_, _, _ string) error    { return nil }
-func (m *mockTmux) GetPaneID(_ string) (string, error)     { return "%0", nil }
+func (m *mockTmux) NewSessionWithCommandAndEnv(name, workDir, command string, _ map[string]string) error {
+	return m.NewSessionWithCommand(name, workDir, command)
+}
+
+func (m *mockTmux) ListSessions() ([]string, error)             { return nil, nil }
+func (m *mockTmux) SendKeys(_, _ string) error                  { return m.sendKeysErr }
+func (m *mockTmux) CapturePane(_ string, _ int) (string, error) { return "", nil }
+func (m *mockTmux) GetEnvironment(_, _ string) (string, error)  { return "", nil }
+func (m *mockTmux) SetRemainOnExit(_ string, _ bool) error      { return nil }
+func (m *mockTmux) SetEnvironment(_, _, _ string) error         { return nil }
+func (m *mockTmux) GetPaneID(_ string) (string, error)          { return "%0", nil }
 func (m *mockTmux) ConfigureGasTownSession(_ string, _ *tmux.Theme, _, _, _ string) error {
 	return nil
 }
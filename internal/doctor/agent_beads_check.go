@@ -21,6 +21,8 @@ import (
 // Each rig uses its configured prefix (e.g., "gt-" for gastown, "bd-" for beads).
 type AgentBeadsCheck struct {
 	FixableCheck
+	missing      []string // agent bead IDs found by the last Run
+	missingLabel []string // agent bead IDs lacking the gt:agent label
 }
 
 // NewAgentBeadsCheck creates a new agent beads check.
@@ -31,6 +33,7 @@ func NewAgentBeadsCheck() *AgentBeadsCheck {
 				CheckName:        "agent-beads-exist",
 				CheckDescription: "Verify agent beads exist for all agents",
 				CheckCategory:    CategoryRig,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -48,6 +51,7 @@ func (c *AgentBeadsCheck) Run(ctx *CheckContext) *CheckResult {
 	beadsDir := filepath.Join(ctx.TownRoot, ".beads")
 	routes, err := beads.LoadRoutes(beadsDir)
 	if err != nil {
+		c.missing, c.missingLabel = nil, nil
 		return &CheckResult{
 			Name:    c.Name(),
 			Status:  StatusWarning,
@@ -135,6 +139,7 @@ func (c *AgentBeadsCheck) Run(ctx *CheckContext) *CheckResult {
 	checkAgentBead(deaconID)
 	checkAgentBead(mayorID)
 
+	c.missing, c.missingLabel = missing, missingLabel
 	if len(prefixToRig) == 0 {
 		// No rigs to check, but we still checked global agents
 		if len(missing) == 0 && len(missingLabel) == 0 {
@@ -180,6 +185,7 @@ func (c *AgentBeadsCheck) Run(ctx *CheckContext) *CheckResult {
 		}
 	}
 
+	c.missing, c.missingLabel = missing, missingLabel
 	if len(missing) == 0 && len(missingLabel) == 0 {
 		return &CheckResult{
 			Name:    c.Name(),
@@ -410,6 +416,19 @@ func (c *AgentBeadsCheck) Fix(ctx *CheckContext) error {
 	return errors.Join(errs...)
 }
 
+// PlanFix lists the agent beads Fix would create (or reopen, if closed) and
+// the ones it would label gt:agent.
+func (c *AgentBeadsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.missing)+len(c.missingLabel))
+	for _, id := range c.missing {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "create", Target: id, Detail: "reopened instead if closed"})
+	}
+	for _, id := range c.missingLabel {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "update", Target: id, Detail: "add gt:agent label"})
+	}
+	return changes
+}
+
 // listCrewWorkers returns the names of canonical crew workers in a rig.
 // Filters out git worktrees and other non-identity directories that may
 // exist under <rig>/crew/ (e.g., fix branches, cross-rig worktrees).
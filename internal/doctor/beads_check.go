@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/beads"
@@ -191,19 +192,56 @@ func (c *PrefixMismatchCheck) Run(ctx *CheckContext) *CheckResult {
 
 // Fix updates rigs.json to match the prefixes in routes.jsonl.
 func (c *PrefixMismatchCheck) Fix(ctx *CheckContext) error {
+	rigsPath, rigsConfig, updates := c.prefixUpdates(ctx)
+	if len(updates) == 0 {
+		return nil // Nothing to fix
+	}
+	for rigName, prefixes := range updates {
+		rigEntry := rigsConfig.Rigs[rigName]
+		// Ensure BeadsConfig exists
+		if rigEntry.BeadsConfig == nil {
+			rigEntry.BeadsConfig = &rigsConfigBeadsConfig{}
+		}
+		rigEntry.BeadsConfig.Prefix = prefixes[1]
+		rigsConfig.Rigs[rigName] = rigEntry
+	}
+	return saveRigsConfig(rigsPath, rigsConfig)
+}
+
+// PlanFix reports the rigs.json prefixes Fix would rewrite.
+func (c *PrefixMismatchCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	rigsPath, _, updates := c.prefixUpdates(ctx)
+	if len(updates) == 0 {
+		return nil
+	}
+	rigNames := make([]string, 0, len(updates))
+	for rigName := range updates {
+		rigNames = append(rigNames, rigName)
+	}
+	sort.Strings(rigNames)
+	details := make([]string, 0, len(rigNames))
+	for _, rigName := range rigNames {
+		details = append(details, fmt.Sprintf("%s prefix %q → %q", rigName, updates[rigName][0], updates[rigName][1]))
+	}
+	return []PlannedChange{{Kind: ChangeFile, Action: "update", Target: rigsPath, Detail: strings.Join(details, ", ")}}
+}
+
+// prefixUpdates loads rigs.json and returns, for each rig whose prefix
+// differs from its route in routes.jsonl, the [current, route] prefixes.
+func (c *PrefixMismatchCheck) prefixUpdates(ctx *CheckContext) (string, *rigsConfigFile, map[string][2]string) {
 	beadsDir := filepath.Join(ctx.TownRoot, ".beads")
 
 	// Load routes.jsonl
 	routes, err := beads.LoadRoutes(beadsDir)
 	if err != nil || len(routes) == 0 {
-		return nil // Nothing to fix
+		return "", nil, nil
 	}
 
 	// Load rigs.json
 	rigsPath := filepath.Join(ctx.TownRoot, "mayor", "rigs.json")
 	rigsConfig, err := loadRigsConfig(rigsPath)
 	if err != nil {
-		return nil // Nothing to fix
+		return "", nil, nil
 	}
 
 	// Build map of route path -> prefix from routes.jsonl
@@ -213,8 +251,8 @@ func (c *PrefixMismatchCheck) Fix(ctx *CheckContext) error {
 		routePrefixByPath[r.Path] = prefix
 	}
 
-	// Update each rig's prefix to match routes.jsonl
-	modified := false
+	// Find each rig whose prefix doesn't match routes.jsonl
+	updates := make(map[string][2]string)
 	for rigName, rigEntry := range rigsConfig.Rigs {
 		expectedPath := determineRigBeadsPath(ctx.TownRoot, rigName)
 		routePrefix, hasRoute := routePrefixByPath[expectedPath]
@@ -222,23 +260,15 @@ func (c *PrefixMismatchCheck) Fix(ctx *CheckContext) error {
 			continue
 		}
 
-		// Ensure BeadsConfig exists
-		if rigEntry.BeadsConfig == nil {
-			rigEntry.BeadsConfig = &rigsConfigBeadsConfig{}
+		var current string
+		if rigEntry.BeadsConfig != nil {
+			current = rigEntry.BeadsConfig.Prefix
 		}
-
-		if rigEntry.BeadsConfig.Prefix != routePrefix {
-			rigEntry.BeadsConfig.Prefix = routePrefix
-			rigsConfig.Rigs[rigName] = rigEntry
-			modified = true
+		if current != routePrefix {
+			updates[rigName] = [2]string{current, routePrefix}
 		}
 	}
-
-	if modified {
-		return saveRigsConfig(rigsPath, rigsConfig)
-	}
-
-	return nil
+	return rigsPath, rigsConfig, updates
 }
 
 // rigsConfigEntry is a local type for loading rigs.json without importing config package
@@ -335,6 +365,7 @@ func NewDatabasePrefixCheck() *DatabasePrefixCheck {
 				CheckName:        "database-prefix",
 				CheckDescription: "Check rig database issue_prefix matches routes.jsonl",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -474,3 +505,17 @@ func (c *DatabasePrefixCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix lists the databases whose issue_prefix Fix would reset.
+func (c *DatabasePrefixCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.mismatches))
+	for _, m := range c.mismatches {
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeDatabase,
+			Action: "update",
+			Target: m.rigPath,
+			Detail: fmt.Sprintf("issue_prefix %q → %q", m.dbPrefix, m.routesPrefix),
+		})
+	}
+	return changes
+}
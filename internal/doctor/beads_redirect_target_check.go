@@ -226,6 +226,30 @@ func (c *BeadsRedirectTargetCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the config.yaml files Fix would seed from metadata.json and
+// the redirects it would rewrite to the rig's canonical beads.
+func (c *BeadsRedirectTargetCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.brokenTargets))
+	for _, bt := range c.brokenTargets {
+		if strings.Contains(bt.reason, "no beads setup") && dirExists(bt.resolvedPath) {
+			changes = append(changes, PlannedChange{
+				Kind:   ChangeFile,
+				Action: "create",
+				Target: filepath.Join(bt.resolvedPath, "config.yaml"),
+				Detail: "from metadata.json; the redirect is rewritten instead if that fails",
+			})
+			continue
+		}
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeFile,
+			Action: "update",
+			Target: filepath.Join(bt.worktreePath, ".beads", "redirect"),
+			Detail: "point at the rig's canonical beads",
+		})
+	}
+	return changes
+}
+
 // extractRigName derives the rig name from a worktree path within a town.
 // For example, "/town/myrig/refinery/rig" returns "myrig".
 func extractRigName(townRoot, worktreePath string) string {
@@ -43,6 +43,14 @@ func (c *BootHealthCheck) Fix(ctx *CheckContext) error {
 	return b.EnsureDir()
 }
 
+// PlanFix reports the boot directory Fix would create.
+func (c *BootHealthCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if !c.missingDir {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeFile, Action: "create", Target: boot.New(ctx.TownRoot).Dir(), Detail: "directory"}}
+}
+
 // Run checks Boot health: directory, session, status, and marker freshness.
 func (c *BootHealthCheck) Run(ctx *CheckContext) *CheckResult {
 	b := boot.New(ctx.TownRoot)
@@ -117,6 +117,20 @@ func (c *BranchCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the worktrees Fix would switch back to their expected branch.
+func (c *BranchCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.offMainDirs))
+	for _, dir := range c.offMainDirs {
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeGit,
+			Action: "checkout",
+			Target: dir,
+			Detail: c.expectedBranch(ctx.TownRoot, dir) + ", then git pull --rebase",
+		})
+	}
+	return changes
+}
+
 // checkoutWithWorktreeRetry attempts git checkout, and if it fails because the
 // branch is already checked out in another worktree (typically .repo.git), it
 // detaches that worktree's HEAD to free the branch and retries.
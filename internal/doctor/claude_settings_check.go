@@ -683,7 +683,7 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 			errors = append(errors, fmt.Sprintf("failed to delete %s: %v", sf.path, err))
 			continue
 		}
-		fmt.Printf("  Deleted stale: %s\n", sf.path)
+		fmt.Fprintf(ctx.Output(), "  Deleted stale: %s\n", sf.path)
 		needsRestart = true
 
 		claudeDir := filepath.Dir(sf.path)
@@ -713,8 +713,8 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 			// Town-root files were inherited by ALL agents via directory traversal.
 			// Warn user to restart agents - don't auto-kill sessions as that's too disruptive,
 			// especially since deacon runs gt doctor automatically which would create a loop.
-			fmt.Printf("\n  %s Town-root settings were moved. Restart agents to pick up new config:\n", style.Warning.Render("⚠"))
-			fmt.Printf("      gt up --restore\n\n")
+			fmt.Fprintf(ctx.Output(), "\n  %s Town-root settings were moved. Restart agents to pick up new config:\n", style.Warning.Render("⚠"))
+			fmt.Fprintf(ctx.Output(), "      gt up --restore\n\n")
 			continue
 		}
 
@@ -760,15 +760,15 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 	// Report skipped files as warnings, not errors
 	if len(skipped) > 0 {
 		for _, s := range skipped {
-			fmt.Printf("  Warning: %s\n", s)
+			fmt.Fprintf(ctx.Output(), "  Warning: %s\n", s)
 		}
 	}
 
 	// Tell user to restart agents so they create correct settings
 	if needsRestart && !ctx.RestartSessions {
-		fmt.Printf("\n  %s Restart agents to create new settings:\n", style.Warning.Render("⚠"))
-		fmt.Printf("      gt up --restore\n")
-		fmt.Printf("\n  If you had custom Claude settings edits, re-apply them via 'gt hooks override <role>'.\n\n")
+		fmt.Fprintf(ctx.Output(), "\n  %s Restart agents to create new settings:\n", style.Warning.Render("⚠"))
+		fmt.Fprintf(ctx.Output(), "      gt up --restore\n")
+		fmt.Fprintf(ctx.Output(), "\n  If you had custom Claude settings edits, re-apply them via 'gt hooks override <role>'.\n\n")
 	}
 
 	if len(errors) > 0 {
@@ -777,6 +777,45 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the settings files Fix would delete and recreate, and the
+// patrol sessions it would cycle when --restart-sessions is set.
+func (c *ClaudeSettingsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, sf := range c.staleSettings {
+		if (!sf.wrongLocation && len(sf.missing) == 0) || sf.missingFile {
+			continue
+		}
+		if sf.gitStatus == gitStatusTrackedModified || sf.gitStatus == gitStatusTrackedClean {
+			continue
+		}
+		detail := "stale: " + strings.Join(sf.missing, ", ")
+		if sf.wrongLocation {
+			detail = "wrong location"
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: sf.path, Detail: detail})
+
+		if sf.agentType == "mayor" && !strings.Contains(sf.path, "/mayor/") {
+			changes = append(changes, PlannedChange{
+				Kind:   ChangeFile,
+				Action: "create",
+				Target: filepath.Join(ctx.TownRoot, "mayor", ".claude", filepath.Base(sf.path)),
+				Detail: "moved from town root",
+			})
+			continue
+		}
+		if !sf.wrongLocation {
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: sf.path, Detail: "regenerated for " + sf.agentType})
+		}
+		if ctx.RestartSessions && sf.sessionName != "" {
+			switch sf.agentType {
+			case "witness", "refinery", "deacon", "mayor":
+				changes = append(changes, PlannedChange{Kind: ChangeSession, Action: "restart", Target: sf.sessionName, Detail: "if running"})
+			}
+		}
+	}
+	return changes
+}
+
 // fileExists checks if a file exists.
 func fileExists(path string) bool {
 	info, err := os.Stat(path)
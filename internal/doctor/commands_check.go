@@ -2,6 +2,7 @@ package doctor
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/templates"
@@ -66,3 +67,16 @@ func (c *CommandsCheck) Fix(ctx *CheckContext) error {
 
 	return templates.ProvisionCommands(c.townRoot)
 }
+
+// PlanFix lists the slash command files Fix would write.
+func (c *CommandsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.missingCommands))
+	for _, name := range c.missingCommands {
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeFile,
+			Action: "create",
+			Target: filepath.Join(ctx.TownRoot, ".claude", "commands", name+".md"),
+		})
+	}
+	return changes
+}
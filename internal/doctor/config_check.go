@@ -97,6 +97,15 @@ func (c *SettingsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the settings/ directories Fix would create.
+func (c *SettingsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.missingSettings))
+	for _, path := range c.missingSettings {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: path, Detail: "directory"})
+	}
+	return changes
+}
+
 // RuntimeGitignoreCheck verifies .runtime/ is gitignored at town and rig levels.
 type RuntimeGitignoreCheck struct {
 	BaseCheck
@@ -263,6 +272,15 @@ func (c *LegacyGastownCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the legacy .gastown/ directories Fix would remove.
+func (c *LegacyGastownCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.legacyDirs))
+	for _, dir := range c.legacyDirs {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: dir, Detail: "directory and contents"})
+	}
+	return changes
+}
+
 // findRigs returns rig directories within the town.
 func (c *LegacyGastownCheck) findRigs(townRoot string) []string {
 	return findAllRigs(townRoot)
@@ -346,6 +364,16 @@ func (c *SessionHookCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the settings.json files whose 'gt prime' hooks Fix would
+// switch to 'gt prime --hook'.
+func (c *SessionHookCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.filesToFix))
+	for _, path := range c.filesToFix {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "update", Target: path, Detail: "gt prime → gt prime --hook"})
+	}
+	return changes
+}
+
 // fixSettingsFile updates a single settings.json file.
 func (c *SessionHookCheck) fixSettingsFile(path string) error {
 	// Read file
@@ -617,6 +645,7 @@ func NewCustomTypesCheck() *CustomTypesCheck {
 				CheckName:        "beads-custom-types",
 				CheckDescription: "Check that Gas Town custom types are registered with beads",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -727,6 +756,16 @@ func (c *CustomTypesCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix reports the types.custom setting Fix would write.
+func (c *CustomTypesCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	return []PlannedChange{{
+		Kind:   ChangeDatabase,
+		Action: "update",
+		Target: filepath.Join(c.townRoot, ".beads"),
+		Detail: fmt.Sprintf("types.custom = %s (adds %s)", constants.BeadsCustomTypes, strings.Join(c.missingTypes, ", ")),
+	}}
+}
+
 // CustomStatusesCheck verifies Gas Town custom statuses are registered with beads.
 type CustomStatusesCheck struct {
 	FixableCheck
@@ -742,6 +781,7 @@ func NewCustomStatusesCheck() *CustomStatusesCheck {
 				CheckName:        "beads-custom-statuses",
 				CheckDescription: "Check that Gas Town custom statuses are registered with beads",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -857,3 +897,13 @@ func (c *CustomStatusesCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix reports the statuses Fix would add to status.custom.
+func (c *CustomStatusesCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	return []PlannedChange{{
+		Kind:   ChangeDatabase,
+		Action: "update",
+		Target: filepath.Join(c.townRoot, ".beads"),
+		Detail: "status.custom += " + strings.Join(c.missingStatuses, ", "),
+	}}
+}
@@ -173,6 +173,15 @@ func (c *CrewStateCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the state.json files Fix would regenerate.
+func (c *CrewStateCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.invalidCrews))
+	for _, ic := range c.invalidCrews {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "update", Target: ic.stateFile, Detail: "regenerate (" + ic.issue + ")"})
+	}
+	return changes
+}
+
 type crewDir struct {
 	path     string
 	rigName  string
@@ -295,6 +304,15 @@ func (c *CrewWorktreeCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the cross-rig worktrees Fix would remove.
+func (c *CrewWorktreeCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.staleWorktrees))
+	for _, wt := range c.staleWorktrees {
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "delete", Target: wt.path, Detail: "git worktree remove --force"})
+	}
+	return changes
+}
+
 // findCrewWorktrees finds cross-rig worktrees in crew directories.
 // These are worktrees with hyphenated names (e.g., "beads-dave") that
 // indicate they were created via `gt worktree` for cross-rig work.
@@ -95,6 +95,14 @@ func (c *DaemonCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix reports the daemon Fix would start. With --no-start nothing runs.
+func (c *DaemonCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if ctx.NoStart {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeProcess, Action: "start", Target: "daemon", Detail: "gt daemon run"}}
+}
+
 // itoa is a simple int to string helper
 func itoa(i int) string {
 	if i == 0 {
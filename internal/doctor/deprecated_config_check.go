@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
 )
@@ -86,6 +88,25 @@ func (c *DeprecatedMergeQueueKeysCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the settings files Fix would rewrite and the keys it would drop.
+func (c *DeprecatedMergeQueueKeysCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	paths := make([]string, 0, len(c.affectedFiles))
+	for path := range c.affectedFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	changes := make([]PlannedChange, 0, len(paths))
+	for _, path := range paths {
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeFile,
+			Action: "update",
+			Target: path,
+			Detail: "remove merge_queue keys: " + strings.Join(c.affectedFiles[path], ", "),
+		})
+	}
+	return changes
+}
+
 // findDeprecatedKeys reads a settings file and returns any deprecated merge_queue keys found.
 func findDeprecatedKeys(path string) []string {
 	data, err := os.ReadFile(path)
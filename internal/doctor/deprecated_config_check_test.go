@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -177,6 +178,36 @@ func TestDeprecatedMergeQueueKeysCheck_Fix(t *testing.T) {
 	}
 }
 
+func TestDeprecatedMergeQueueKeysCheck_PlanFix(t *testing.T) {
+	townRoot := setupTownWithSettings(t, map[string]interface{}{
+		"merge_queue": map[string]interface{}{
+			"enabled":       true,
+			"target_branch": "develop",
+		},
+	})
+
+	check := NewDeprecatedMergeQueueKeysCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	check.Run(ctx)
+
+	settingsPath := filepath.Join(findAllRigs(townRoot)[0], "settings", "config.json")
+	before, _ := os.ReadFile(settingsPath)
+
+	changes := check.PlanFix(ctx)
+	if len(changes) != 1 {
+		t.Fatalf("PlanFix() = %+v, want one change", changes)
+	}
+	if changes[0].Kind != ChangeFile || changes[0].Target != settingsPath || changes[0].Action != "update" {
+		t.Errorf("change = %+v", changes[0])
+	}
+	if !strings.Contains(changes[0].Detail, "target_branch") {
+		t.Errorf("detail %q should name the removed key", changes[0].Detail)
+	}
+	if after, _ := os.ReadFile(settingsPath); string(after) != string(before) {
+		t.Error("PlanFix must not modify the settings file")
+	}
+}
+
 func TestDeprecatedMergeQueueKeysCheck_MultiRig(t *testing.T) {
 	townRoot := t.TempDir()
 
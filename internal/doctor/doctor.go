@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/ui"
@@ -11,13 +13,18 @@ import (
 
 // Doctor manages and executes health checks.
 type Doctor struct {
-	checks []Check
+	checks      []Check
+	parallelism int
+	timeout     time.Duration
+	journal     *Journal
 }
 
 // NewDoctor creates a new Doctor with no registered checks.
 func NewDoctor() *Doctor {
 	return &Doctor{
-		checks: make([]Check, 0),
+		checks:      make([]Check, 0),
+		parallelism: DefaultParallelism,
+		timeout:     DefaultCheckTimeout,
 	}
 }
 
@@ -47,66 +54,270 @@ func (d *Doctor) Run(ctx *CheckContext) *Report {
 }
 
 // RunStreaming executes all registered checks with optional real-time output.
-// If w is non-nil, prints each check name as it starts and result when done.
+// Checks run concurrently (up to the configured parallelism) while honoring
+// declared dependencies; a check whose dependency failed is skipped. Each
+// check is bounded by its timeout. Results are reported in registration order.
+// If w is non-nil, prints each result as it completes (with parallelism 1 the
+// check name is printed first and overwritten when done).
 // If slowThreshold > 0, shows hourglass icon for slow checks.
 func (d *Doctor) RunStreaming(ctx *CheckContext, w io.Writer, slowThreshold time.Duration) *Report {
 	report := NewReport()
+	sequential := d.parallelism <= 1
 
-	for _, check := range d.checks {
+	results := d.schedule(ctx, func(check Check) {
 		// Stream: print check name before running
-		if w != nil {
+		if w != nil && sequential {
 			fmt.Fprintf(w, "  %s  %s...", ui.RenderMuted("○"), check.Name())
 		}
+	}, func(result *CheckResult) {
+		if w == nil {
+			return
+		}
+		if sequential {
+			fmt.Fprint(w, "\r")
+		}
+		// Check if slow (hourglass replaces spaces to maintain alignment)
+		isSlow := slowThreshold > 0 && result.Elapsed >= slowThreshold
+		slowIndicator := "  "
+		if isSlow {
+			report.Summary.Slow++
+			slowIndicator = "⏳"
+		}
+		fmt.Fprintf(w, "  %s%s%s", resultIcon(result), slowIndicator, result.Name)
+		if result.Message != "" {
+			fmt.Fprintf(w, "%s", ui.RenderMuted(" "+result.Message))
+		}
+		if isSlow {
+			fmt.Fprintf(w, "%s", ui.RenderMuted(" ("+formatDuration(result.Elapsed)+")"))
+		}
+		fmt.Fprintln(w)
+	})
 
-		start := time.Now()
-		result := check.Run(ctx)
-		result.Elapsed = time.Since(start)
+	for _, result := range results {
+		report.Add(result)
+	}
+	return report
+}
 
-		// Ensure check name is populated
-		if result.Name == "" {
-			result.Name = check.Name()
+// SetParallelism sets how many checks RunStreaming runs at once.
+// Values below 1 run checks one at a time.
+func (d *Doctor) SetParallelism(n int) {
+	d.parallelism = n
+}
+
+// SetDefaultTimeout sets the timeout for checks that don't declare their own.
+// Zero disables the timeout.
+func (d *Doctor) SetDefaultTimeout(timeout time.Duration) {
+	d.timeout = timeout
+}
+
+// SetJournal makes FixStreaming record the pre-fix state of changed files in
+// j so the run can be undone. Pass nil to disable journaling.
+func (d *Doctor) SetJournal(j *Journal) {
+	d.journal = j
+}
+
+// checkDependencies returns the dependency IDs declared by a check.
+func checkDependencies(check Check) []string {
+	if dd, ok := check.(dependencyDeclarer); ok {
+		return dd.DependsOn()
+	}
+	return nil
+}
+
+// checkTimeout returns the effective timeout for a check.
+func (d *Doctor) checkTimeout(check Check) time.Duration {
+	if td, ok := check.(timeoutDeclarer); ok {
+		if t := td.Timeout(); t > 0 {
+			return t
 		}
-		// Set category from check if available
-		if cg, ok := check.(categoryGetter); ok && result.Category == "" {
-			result.Category = cg.Category()
+	}
+	return d.timeout
+}
+
+// schedule runs all registered checks, starting a check only once every
+// registered dependency has finished. Among ready checks, registration order
+// wins. onStart and onDone are called from the scheduling goroutine, so they
+// never run concurrently with each other. Checks caught in a dependency cycle
+// are reported as errors.
+func (d *Doctor) schedule(ctx *CheckContext, onStart func(Check), onDone func(*CheckResult)) []*CheckResult {
+	n := len(d.checks)
+	results := make([]*CheckResult, n)
+
+	index := make(map[string]int, n)
+	for i, check := range d.checks {
+		if _, dup := index[check.Name()]; !dup {
+			index[check.Name()] = i
 		}
+	}
 
-		// Stream: overwrite line with result
-		if w != nil {
-			var statusIcon string
-			switch result.Status {
-			case StatusOK:
-				statusIcon = ui.RenderPassIcon()
-			case StatusWarning:
-				statusIcon = ui.RenderWarnIcon()
-			case StatusError:
-				statusIcon = ui.RenderFailIcon()
+	// pending[i] counts unfinished dependencies; dependents[j] lists the
+	// checks waiting on j; blockedBy[i] names the first failed dependency.
+	pending := make([]int, n)
+	dependents := make([][]int, n)
+	blockedBy := make([]string, n)
+	for i, check := range d.checks {
+		for _, dep := range checkDependencies(check) {
+			j, ok := index[dep]
+			if !ok || j == i {
+				continue
 			}
-			// Check if slow (hourglass replaces spaces to maintain alignment)
-			isSlow := slowThreshold > 0 && result.Elapsed >= slowThreshold
-			slowIndicator := "  "
-			if isSlow {
-				report.Summary.Slow++
-				slowIndicator = "⏳"
+			pending[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	var ready []int
+	for i := range d.checks {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	limit := d.parallelism
+	if limit < 1 {
+		limit = 1
+	}
+
+	type finished struct {
+		i      int
+		result *CheckResult
+	}
+	doneCh := make(chan finished)
+	running, completed := 0, 0
+
+	var complete func(i int, result *CheckResult)
+	complete = func(i int, result *CheckResult) {
+		results[i] = result
+		completed++
+		if onDone != nil {
+			onDone(result)
+		}
+		failed := result.Skipped || result.Status == StatusError
+		for _, j := range dependents[i] {
+			if failed && blockedBy[j] == "" {
+				blockedBy[j] = d.checks[i].Name()
 			}
-			fmt.Fprintf(w, "\r  %s%s%s", statusIcon, slowIndicator, result.Name)
-			if result.Message != "" {
-				fmt.Fprintf(w, "%s", ui.RenderMuted(" "+result.Message))
+			pending[j]--
+			if pending[j] == 0 {
+				ready = insertSorted(ready, j)
 			}
-			if isSlow {
-				fmt.Fprintf(w, "%s", ui.RenderMuted(" ("+formatDuration(result.Elapsed)+")"))
+		}
+	}
+
+	for completed < n {
+		for len(ready) > 0 && running < limit {
+			i := ready[0]
+			ready = ready[1:]
+			check := d.checks[i]
+			if blockedBy[i] != "" {
+				complete(i, skippedResult(check, blockedBy[i]))
+				continue
 			}
-			fmt.Fprintln(w)
+			if onStart != nil {
+				onStart(check)
+			}
+			running++
+			go func(i int, check Check) {
+				doneCh <- finished{i, runCheck(check, ctx, d.checkTimeout(check))}
+			}(i, check)
 		}
+		if running == 0 {
+			break
+		}
+		f := <-doneCh
+		running--
+		complete(f.i, f.result)
+	}
 
-		report.Add(result)
+	// Anything left never became ready: its dependencies form a cycle.
+	for i, check := range d.checks {
+		if results[i] == nil {
+			result := &CheckResult{
+				Status:  StatusError,
+				Message: "dependency cycle: " + strings.Join(checkDependencies(check), ", "),
+			}
+			fillResult(check, result)
+			complete(i, result)
+		}
 	}
+	return results
+}
 
-	return report
+// insertSorted inserts v into the ascending slice s.
+func insertSorted(s []int, v int) []int {
+	pos := sort.SearchInts(s, v)
+	s = append(s, 0)
+	copy(s[pos+1:], s[pos:])
+	s[pos] = v
+	return s
+}
+
+// runCheck runs a single check with panic recovery and a timeout.
+// A check that exceeds its timeout is reported as an error; its goroutine is
+// abandoned since checks cannot be cancelled.
+func runCheck(check Check, ctx *CheckContext, timeout time.Duration) *CheckResult {
+	start := time.Now()
+	resultCh := make(chan *CheckResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				resultCh <- &CheckResult{Status: StatusError, Message: fmt.Sprintf("check panicked: %v", r)}
+			}
+		}()
+		resultCh <- check.Run(ctx)
+	}()
+
+	var result *CheckResult
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case result = <-resultCh:
+		case <-timer.C:
+			result = &CheckResult{
+				Status:  StatusError,
+				Message: fmt.Sprintf("timed out after %s", formatDuration(timeout)),
+				FixHint: "Re-run with a longer --timeout or investigate the hung dependency",
+			}
+		}
+	} else {
+		result = <-resultCh
+	}
+	if result == nil {
+		result = &CheckResult{Status: StatusError, Message: "check returned no result"}
+	}
+	result.Elapsed = time.Since(start)
+	fillResult(check, result)
+	return result
+}
+
+// skippedResult reports a check that did not run because dep failed.
+func skippedResult(check Check, dep string) *CheckResult {
+	result := &CheckResult{
+		Status:  StatusWarning,
+		Message: "skipped: depends on " + dep,
+		Skipped: true,
+	}
+	fillResult(check, result)
+	return result
+}
+
+// fillResult populates the name and category of a result from its check.
+func fillResult(check Check, result *CheckResult) {
+	// Ensure check name is populated
+	if result.Name == "" {
+		result.Name = check.Name()
+	}
+	// Set category from check if available
+	if cg, ok := check.(categoryGetter); ok && result.Category == "" {
+		result.Category = cg.Category()
+	}
 }
 
 // Fix runs all checks with auto-fix enabled where possible.
-// It first runs the check, then if it fails and can be fixed, attempts the fix.
+// Fixes mutate the workspace, so checks run one at a time in registration
+// order. It first runs the check, then if it fails and can be fixed, attempts the fix.
 func (d *Doctor) Fix(ctx *CheckContext) *Report {
 	return d.FixStreaming(ctx, nil, 0)
 }
@@ -163,7 +374,15 @@ func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 				fmt.Fprintf(w, "%s", ui.RenderMuted(" (fixing)..."))
 			}
 
+			var snap *fixSnapshot
+			if d.journal != nil {
+				snap = d.journal.begin(check, ctx)
+			}
 			err := safeFixCheck(check, ctx)
+			var journalErr error
+			if snap != nil {
+				journalErr = d.journal.commit(snap, err)
+			}
 			if err == nil {
 				// Re-run check to verify fix worked
 				result = check.Run(ctx)
@@ -186,6 +405,9 @@ func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 				// Fix failed, add error to details
 				result.Details = append(result.Details, "Fix failed: "+err.Error())
 			}
+			if journalErr != nil {
+				result.Details = append(result.Details, "Undo journal not saved: "+journalErr.Error())
+			}
 		}
 
 		// Record total elapsed time including any fix attempts
@@ -238,7 +460,9 @@ func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 type BaseCheck struct {
 	CheckName        string
 	CheckDescription string
-	CheckCategory    string // Category for grouping (e.g., CategoryCore)
+	CheckCategory    string        // Category for grouping (e.g., CategoryCore)
+	CheckDependsOn   []string      // IDs of checks that must pass before this one runs
+	CheckTimeout     time.Duration // Overrides the doctor's default timeout when non-zero
 }
 
 // Category returns the check's category for grouping in output.
@@ -256,6 +480,16 @@ func (b *BaseCheck) Description() string {
 	return b.CheckDescription
 }
 
+// DependsOn returns the IDs of checks this check depends on.
+func (b *BaseCheck) DependsOn() []string {
+	return b.CheckDependsOn
+}
+
+// Timeout returns the check's timeout override (zero means default).
+func (b *BaseCheck) Timeout() time.Duration {
+	return b.CheckTimeout
+}
+
 // CanFix returns false by default.
 func (b *BaseCheck) CanFix() bool {
 	return false
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // mockCheck is a test check that can be configured to return any status.
@@ -423,3 +426,178 @@ func TestFixableCheck(t *testing.T) {
 		t.Error("FixableCheck.CanFix() should return true")
 	}
 }
+
+// funcCheck runs an arbitrary function, for scheduling tests.
+type funcCheck struct {
+	BaseCheck
+	run func() *CheckResult
+}
+
+func newFuncCheck(name string, deps []string, run func() *CheckResult) *funcCheck {
+	return &funcCheck{
+		BaseCheck: BaseCheck{CheckName: name, CheckDependsOn: deps},
+		run:       run,
+	}
+}
+
+func (f *funcCheck) Run(ctx *CheckContext) *CheckResult {
+	return f.run()
+}
+
+func TestDoctor_RunRespectsDependencies(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(name string, status CheckStatus) func() *CheckResult {
+		return func() *CheckResult {
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return &CheckResult{Status: status}
+		}
+	}
+
+	d := NewDoctor()
+	d.SetParallelism(8)
+	// Registered before its dependency: the scheduler must still wait.
+	d.Register(newFuncCheck("rig-beads", []string{"dolt-binary", "not-registered"}, record("rig-beads", StatusOK)))
+	d.Register(newFuncCheck("dolt-binary", nil, record("dolt-binary", StatusOK)))
+	d.Register(newFuncCheck("independent", nil, record("independent", StatusOK)))
+
+	report := d.Run(&CheckContext{TownRoot: "/test"})
+
+	if report.Summary.OK != 3 {
+		t.Fatalf("OK = %d, want 3 (%+v)", report.Summary.OK, report.Summary)
+	}
+	pos := map[string]int{}
+	for i, name := range order {
+		pos[name] = i
+	}
+	if pos["rig-beads"] < pos["dolt-binary"] {
+		t.Errorf("rig-beads ran before dolt-binary: %v", order)
+	}
+	// Results stay in registration order regardless of completion order.
+	for i, want := range []string{"rig-beads", "dolt-binary", "independent"} {
+		if report.Checks[i].Name != want {
+			t.Errorf("Checks[%d] = %q, want %q", i, report.Checks[i].Name, want)
+		}
+	}
+}
+
+func TestDoctor_RunSkipsDependentsOfFailedCheck(t *testing.T) {
+	var ran atomic.Int32
+	ok := func() *CheckResult { ran.Add(1); return &CheckResult{Status: StatusOK} }
+
+	d := NewDoctor()
+	d.Register(newFuncCheck("dolt-binary", nil, func() *CheckResult {
+		return &CheckResult{Status: StatusError, Message: "dolt not found"}
+	}))
+	d.Register(newFuncCheck("rig-beads", []string{"dolt-binary"}, ok))
+	d.Register(newFuncCheck("agent-beads", []string{"rig-beads"}, ok))
+
+	report := d.Run(&CheckContext{TownRoot: "/test"})
+
+	if ran.Load() != 0 {
+		t.Errorf("dependent checks ran %d times, want 0", ran.Load())
+	}
+	if report.Summary.Skipped != 2 || report.Summary.Errors != 1 || report.Summary.Warnings != 0 {
+		t.Errorf("summary = %+v, want 1 error and 2 skipped", report.Summary)
+	}
+	if msg := report.Checks[2].Message; !strings.Contains(msg, "rig-beads") {
+		t.Errorf("transitively skipped message = %q, want it to name rig-beads", msg)
+	}
+}
+
+func TestDoctor_RunRunsConcurrently(t *testing.T) {
+	var active, peak atomic.Int32
+	slow := func() *CheckResult {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		active.Add(-1)
+		return &CheckResult{Status: StatusOK}
+	}
+
+	d := NewDoctor()
+	d.SetParallelism(3)
+	for i := 0; i < 6; i++ {
+		d.Register(newFuncCheck(fmt.Sprintf("c%d", i), nil, slow))
+	}
+	d.Run(&CheckContext{TownRoot: "/test"})
+
+	if got := peak.Load(); got < 2 || got > 3 {
+		t.Errorf("peak concurrency = %d, want 2..3", got)
+	}
+}
+
+func TestDoctor_RunTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	d := NewDoctor()
+	d.SetDefaultTimeout(20 * time.Millisecond)
+	d.Register(newFuncCheck("hangs", nil, func() *CheckResult {
+		<-release
+		return &CheckResult{Status: StatusOK}
+	}))
+	d.Register(newFuncCheck("after-hang", []string{"hangs"}, func() *CheckResult {
+		return &CheckResult{Status: StatusOK}
+	}))
+
+	report := d.Run(&CheckContext{TownRoot: "/test"})
+
+	if report.Checks[0].Status != StatusError || !strings.Contains(report.Checks[0].Message, "timed out") {
+		t.Errorf("hung check = %+v, want timeout error", report.Checks[0])
+	}
+	if !report.Checks[1].Skipped {
+		t.Errorf("dependent of timed-out check should be skipped, got %+v", report.Checks[1])
+	}
+}
+
+func TestDoctor_RunCheckTimeoutOverride(t *testing.T) {
+	d := NewDoctor()
+	d.SetDefaultTimeout(time.Millisecond)
+	check := newFuncCheck("slow-but-allowed", nil, func() *CheckResult {
+		time.Sleep(20 * time.Millisecond)
+		return &CheckResult{Status: StatusOK}
+	})
+	check.CheckTimeout = time.Second
+	d.Register(check)
+
+	if report := d.Run(&CheckContext{TownRoot: "/test"}); report.Summary.OK != 1 {
+		t.Errorf("check with its own timeout failed: %+v", report.Checks[0])
+	}
+}
+
+func TestDoctor_RunDependencyCycle(t *testing.T) {
+	ok := func() *CheckResult { return &CheckResult{Status: StatusOK} }
+	d := NewDoctor()
+	d.Register(newFuncCheck("a", []string{"b"}, ok))
+	d.Register(newFuncCheck("b", []string{"a"}, ok))
+	d.Register(newFuncCheck("c", nil, ok))
+
+	report := d.Run(&CheckContext{TownRoot: "/test"})
+
+	if report.Summary.Total != 3 || report.Summary.OK != 1 {
+		t.Fatalf("summary = %+v, want 3 total with 1 OK", report.Summary)
+	}
+	if !strings.Contains(report.Checks[0].Message, "cycle") {
+		t.Errorf("cycle message = %q", report.Checks[0].Message)
+	}
+}
+
+func TestDoctor_RunRecoversPanic(t *testing.T) {
+	d := NewDoctor()
+	d.Register(newFuncCheck("boom", nil, func() *CheckResult { panic("nil map") }))
+
+	report := d.Run(&CheckContext{TownRoot: "/test"})
+
+	if report.Checks[0].Status != StatusError || !strings.Contains(report.Checks[0].Message, "panicked") {
+		t.Errorf("panicking check = %+v", report.Checks[0])
+	}
+}
@@ -2,6 +2,8 @@ package doctor
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/session"
@@ -47,6 +49,7 @@ type EnvVarsCheck struct {
 	FixableCheck
 	reader   SessionEnvReader  // nil means use real tmux
 	accessor SessionEnvAccessor // non-nil when Fix() support is needed
+	toSet    map[string][]string // session -> env vars Fix would set, from the last Run
 }
 
 // NewEnvVarsCheck creates a new env vars check.
@@ -79,6 +82,7 @@ func NewEnvVarsCheckWithAccessor(accessor SessionEnvAccessor) *EnvVarsCheck {
 
 // Run checks environment variables for all Gas Town sessions.
 func (c *EnvVarsCheck) Run(ctx *CheckContext) *CheckResult {
+	c.toSet = nil
 	reader := c.reader
 	if reader == nil {
 		reader = &tmuxEnvReaderWriter{t: tmux.NewTmux()}
@@ -150,6 +154,12 @@ func (c *EnvVarsCheck) Run(ctx *CheckContext) *CheckResult {
 		// Compare each expected var
 		for key, expectedVal := range expected {
 			actualVal, exists := actual[key]
+			if !exists || actualVal != expectedVal {
+				if c.toSet == nil {
+					c.toSet = make(map[string][]string)
+				}
+				c.toSet[sess] = append(c.toSet[sess], key)
+			}
 			if !exists && expectedVal != "" {
 				// Only flag missing vars when the expected value is non-empty.
 				// An absent var has the same effect as an empty one (e.g. CLAUDECODE=""
@@ -259,3 +269,24 @@ func (c *EnvVarsCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the session environment variables Fix would set.
+func (c *EnvVarsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	sessions := make([]string, 0, len(c.toSet))
+	for sess := range c.toSet {
+		sessions = append(sessions, sess)
+	}
+	sort.Strings(sessions)
+	changes := make([]PlannedChange, 0, len(sessions))
+	for _, sess := range sessions {
+		keys := append([]string(nil), c.toSet[sess]...)
+		sort.Strings(keys)
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeSession,
+			Action: "update",
+			Target: sess,
+			Detail: "set " + strings.Join(keys, ", "),
+		})
+	}
+	return changes
+}
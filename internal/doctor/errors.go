@@ -9,4 +9,10 @@ var (
 
 	// ErrSkippedNoStart is returned when a fix is skipped due to --no-start.
 	ErrSkippedNoStart = errors.New("skipped: --no-start suppresses daemon/agent startup")
+
+	// ErrJournalNotFound is returned when no undo journal exists for a run ID.
+	ErrJournalNotFound = errors.New("no undo journal for run")
+
+	// ErrJournalUndone is returned when a run has already been rolled back.
+	ErrJournalUndone = errors.New("run was already undone")
 )
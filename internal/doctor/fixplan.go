@@ -0,0 +1,166 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// ChangeKind classifies what a planned fix change touches.
+type ChangeKind string
+
+const (
+	// ChangeFile is a file created, updated, or deleted on disk.
+	ChangeFile ChangeKind = "file"
+	// ChangeBead is a bead created, updated, or closed.
+	ChangeBead ChangeKind = "bead"
+	// ChangeSession is a tmux session killed, renamed, restarted, or
+	// reconfigured.
+	ChangeSession ChangeKind = "session"
+	// ChangeDatabase is a Dolt database created, updated, or removed.
+	ChangeDatabase ChangeKind = "database"
+	// ChangeGit is a checkout, branch, ref, or worktree changed in a git repo.
+	ChangeGit ChangeKind = "git"
+	// ChangeProcess is a daemon or server process started or stopped.
+	ChangeProcess ChangeKind = "process"
+)
+
+// Reversible reports whether gt doctor undo can roll back this kind of change.
+// Only file changes are captured in the undo journal.
+func (k ChangeKind) Reversible() bool {
+	return k == ChangeFile
+}
+
+// PlannedChange is one concrete mutation a fix would make.
+type PlannedChange struct {
+	Kind   ChangeKind `json:"kind"`
+	Action string     `json:"action"` // create, update, delete, close, checkout, kill, rename, restart, start
+	Target string     `json:"target"` // absolute path, bead ID, session name, or database name
+	Detail string     `json:"detail,omitempty"`
+}
+
+// FixPlanner is implemented by fixable checks that can describe the exact
+// changes Fix would make. PlanFix is called after Run and must not mutate
+// anything; it typically reads the state Run cached for Fix.
+type FixPlanner interface {
+	PlanFix(ctx *CheckContext) []PlannedChange
+}
+
+// fileAction returns "update" if path exists and "create" otherwise.
+func fileAction(path string) string {
+	if _, err := os.Stat(path); err == nil {
+		return "update"
+	}
+	return "create"
+}
+
+// CheckPlan is the planned fix for a single failing check.
+type CheckPlan struct {
+	Check       string          `json:"check"`
+	Status      string          `json:"status"`
+	Message     string          `json:"message,omitempty"`
+	Description string          `json:"description"`
+	Previewable bool            `json:"previewable"` // false if the check can't list its changes
+	Changes     []PlannedChange `json:"changes,omitempty"`
+}
+
+// FixPlan describes what gt doctor --fix would do, without doing it.
+type FixPlan struct {
+	Report *Report     `json:"-"`
+	Checks []CheckPlan `json:"checks"`
+	// Unfixable counts failing checks that have no automatic fix.
+	Unfixable int `json:"unfixable"`
+}
+
+// Plan runs all checks and collects the fixes that FixStreaming would
+// attempt. Nothing is modified. Fixable checks that don't implement
+// FixPlanner are listed without changes and marked not previewable.
+func (d *Doctor) Plan(ctx *CheckContext) *FixPlan {
+	plan := &FixPlan{}
+	plan.Report = d.RunStreaming(ctx, nil, 0)
+
+	for i, check := range d.checks {
+		result := plan.Report.Checks[i]
+		if result.Status == StatusOK || result.Skipped {
+			continue
+		}
+		if !check.CanFix() {
+			plan.Unfixable++
+			continue
+		}
+		cp := CheckPlan{
+			Check:       check.Name(),
+			Status:      statusKey(result),
+			Message:     result.Message,
+			Description: check.Description(),
+		}
+		if fp, ok := check.(FixPlanner); ok {
+			cp.Previewable = true
+			cp.Changes = fp.PlanFix(ctx)
+		}
+		plan.Checks = append(plan.Checks, cp)
+	}
+	return plan
+}
+
+// WriteJSON writes the plan as a JSON document.
+func (p *FixPlan) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// Print writes the plan in human-readable form.
+func (p *FixPlan) Print(w io.Writer) {
+	_, _ = fmt.Fprintln(w)
+	if len(p.Checks) == 0 {
+		_, _ = fmt.Fprintln(w, ui.RenderPass(ui.IconPass+" Nothing to fix"))
+		if p.Unfixable > 0 {
+			_, _ = fmt.Fprintf(w, "  %s\n", ui.RenderMuted(fmt.Sprintf("%d failing check(s) need manual attention", p.Unfixable)))
+		}
+		return
+	}
+
+	irreversible := 0
+	for _, cp := range p.Checks {
+		_, _ = fmt.Fprintf(w, "  %s %s", ui.IconFix, cp.Check)
+		if cp.Message != "" {
+			_, _ = fmt.Fprintf(w, "%s", ui.RenderMuted(" "+cp.Message))
+		}
+		_, _ = fmt.Fprintln(w)
+		if !cp.Previewable {
+			_, _ = fmt.Fprintf(w, "     %s%s %s\n", ui.MutedStyle.Render(ui.TreeLast),
+				ui.RenderWarn("[not previewable]"), ui.RenderMuted(cp.Description))
+			irreversible++
+			continue
+		}
+		if len(cp.Changes) == 0 {
+			_, _ = fmt.Fprintf(w, "     %s%s\n", ui.MutedStyle.Render(ui.TreeLast), ui.RenderMuted("no changes"))
+		}
+		for _, ch := range cp.Changes {
+			line := fmt.Sprintf("%s %s %s", ch.Action, ch.Kind, ch.Target)
+			if ch.Detail != "" {
+				line += " (" + ch.Detail + ")"
+			}
+			if !ch.Kind.Reversible() {
+				line += " [not undoable]"
+				irreversible++
+			}
+			_, _ = fmt.Fprintf(w, "     %s%s\n", ui.MutedStyle.Render(ui.TreeLast), line)
+		}
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "%d check(s) would be fixed", len(p.Checks))
+	if p.Unfixable > 0 {
+		_, _ = fmt.Fprintf(w, ", %d need manual attention", p.Unfixable)
+	}
+	_, _ = fmt.Fprintln(w)
+	if irreversible > 0 {
+		_, _ = fmt.Fprintf(w, "%s\n", ui.RenderWarn(fmt.Sprintf("%s %d change(s) cannot be rolled back with 'gt doctor undo'", ui.IconWarn, irreversible)))
+	}
+	_, _ = fmt.Fprintln(w, ui.RenderMuted("Run 'gt doctor --apply' to apply these fixes with an undo journal."))
+}
@@ -150,3 +150,17 @@ func (c *ForeignRemoteCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the remotes Fix would remove from the town repo.
+func (c *ForeignRemoteCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.foreignRemotes))
+	for _, fr := range c.foreignRemotes {
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeGit,
+			Action: "delete",
+			Target: "remote " + fr.name,
+			Detail: fmt.Sprintf("%s in %s", fr.url, ctx.TownRoot),
+		})
+	}
+	return changes
+}
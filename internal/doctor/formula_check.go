@@ -2,6 +2,8 @@ package doctor
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/formula"
@@ -12,6 +14,7 @@ import (
 // and modified formulas (user customized). Can auto-fix outdated and missing.
 type FormulaCheck struct {
 	FixableCheck
+	report *formula.HealthReport // Cached during Run for use in PlanFix
 }
 
 // NewFormulaCheck creates a new formula check.
@@ -30,6 +33,7 @@ func NewFormulaCheck() *FormulaCheck {
 // Run checks if formulas need updating.
 func (c *FormulaCheck) Run(ctx *CheckContext) *CheckResult {
 	report, err := formula.CheckFormulaHealth(ctx.TownRoot)
+	c.report = report
 	if err != nil {
 		return &CheckResult{
 			Name:    c.Name(),
@@ -128,3 +132,26 @@ func (c *FormulaCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix lists the formula files Fix would install or overwrite. Locally
+// modified formulas are left alone.
+func (c *FormulaCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if c.report == nil {
+		return nil
+	}
+	formulasDir := filepath.Join(ctx.TownRoot, ".beads", "formulas")
+	var changes []PlannedChange
+	for _, f := range c.report.Formulas {
+		action := "update"
+		switch f.Status {
+		case "missing", "new":
+			action = "create"
+		case "outdated", "untracked":
+		default:
+			continue
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: action, Target: filepath.Join(formulasDir, f.Name), Detail: f.Status})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Target < changes[j].Target })
+	return append(changes, PlannedChange{Kind: ChangeFile, Action: "update", Target: filepath.Join(formulasDir, ".installed.json")})
+}
@@ -33,6 +33,7 @@ func NewHookAttachmentValidCheck() *HookAttachmentValidCheck {
 				CheckName:        "hook-attachment-valid",
 				CheckDescription: "Verify attached molecules exist and are not closed",
 				CheckCategory:    CategoryHooks,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -187,6 +188,15 @@ func (c *HookAttachmentValidCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the pinned beads Fix would detach molecules from.
+func (c *HookAttachmentValidCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, inv := range c.invalidAttachments {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "update", Target: inv.pinnedBeadID, Detail: "detach " + inv.moleculeID})
+	}
+	return changes
+}
+
 // HookSingletonCheck ensures each agent has at most one handoff bead.
 // Detects when multiple pinned beads exist with the same "{role} Handoff" title,
 // which can cause confusion about which handoff is authoritative.
@@ -209,6 +219,7 @@ func NewHookSingletonCheck() *HookSingletonCheck {
 				CheckName:        "hook-singleton",
 				CheckDescription: "Ensure each agent has at most one handoff bead",
 				CheckCategory:    CategoryHooks,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -349,6 +360,7 @@ func NewOrphanedAttachmentsCheck() *OrphanedAttachmentsCheck {
 			CheckName:        "orphaned-attachments",
 			CheckDescription: "Detect handoff beads for non-existent agents",
 			CheckCategory:    CategoryHooks,
+			CheckDependsOn:   beadsDependencies,
 		},
 	}
 }
@@ -496,3 +508,14 @@ func dirExists(path string) bool {
 func (c *OrphanedAttachmentsCheck) formatOrphan(orph orphanedHandoff) string {
 	return fmt.Sprintf("%s: agent %q no longer exists", orph.beadID, orph.agent)
 }
+
+// PlanFix lists the duplicate handoff beads Fix would close.
+func (c *HookSingletonCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, dup := range c.duplicates {
+		for _, id := range dup.beadIDs[1:] {
+			changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "close", Target: id, Detail: "duplicate " + dup.title})
+		}
+	}
+	return changes
+}
@@ -97,6 +97,15 @@ func (c *HooksPathAllRigsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the clones whose git config Fix would update.
+func (c *HooksPathAllRigsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, clonePath := range c.unconfiguredClones {
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "update", Target: clonePath, Detail: "core.hooksPath=.githooks"})
+	}
+	return changes
+}
+
 // findRigClones returns all git clone paths within a rig.
 func findRigClones(rigPath string) []string {
 	var clones []string
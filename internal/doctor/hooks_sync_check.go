@@ -253,3 +253,15 @@ func (c *HooksSyncCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the hook settings files Fix would write.
+func (c *HooksSyncCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, target := range c.outOfSync {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(target.Path), Target: target.Path, Detail: target.DisplayKey()})
+	}
+	for _, tt := range c.templateOutOfSync {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(tt.path), Target: tt.path, Detail: tt.provider})
+	}
+	return changes
+}
@@ -2,6 +2,8 @@ package doctor
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/lock"
@@ -11,6 +13,7 @@ import (
 // IdentityCollisionCheck checks for agent identity collisions and stale locks.
 type IdentityCollisionCheck struct {
 	BaseCheck
+	staleDirs []string // Worker dirs with stale locks, cached for PlanFix
 }
 
 // NewIdentityCollisionCheck creates a new identity collision check.
@@ -77,6 +80,7 @@ func (c *IdentityCollisionCheck) Run(ctx *CheckContext) *CheckResult {
 	var staleLocks []string
 	var orphanedLocks []string
 	var healthyLocks int
+	c.staleDirs = nil
 
 	for workerDir, info := range locks {
 		// First check if the session exists in tmux - that's the real indicator
@@ -95,6 +99,7 @@ func (c *IdentityCollisionCheck) Run(ctx *CheckContext) *CheckResult {
 			// Both PID dead AND session gone = truly stale
 			staleLocks = append(staleLocks,
 				fmt.Sprintf("%s (dead PID %d)", workerDir, info.PID))
+			c.staleDirs = append(c.staleDirs, workerDir)
 			continue
 		}
 
@@ -160,8 +165,19 @@ func (c *IdentityCollisionCheck) Fix(ctx *CheckContext) error {
 	}
 
 	if cleaned > 0 {
-		fmt.Printf("  Cleaned %d stale lock(s)\n", cleaned)
+		fmt.Fprintf(ctx.Output(), "  Cleaned %d stale lock(s)\n", cleaned)
 	}
 
 	return nil
 }
+
+// PlanFix lists the stale lock files Fix would remove. Orphaned locks are
+// reported by Run but left in place.
+func (c *IdentityCollisionCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, dir := range c.staleDirs {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: filepath.Join(dir, ".runtime", "agent.lock"), Detail: "stale lock"})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Target < changes[j].Target })
+	return changes
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/beads"
@@ -14,6 +15,7 @@ import (
 // Gas Town uses a centralized Dolt server managed by systemd.
 type IdleTimeoutCheck struct {
 	FixableCheck
+	staleConfigs []string // config.yaml paths missing the setting, cached for PlanFix
 }
 
 // NewIdleTimeoutCheck creates a new idle timeout check.
@@ -64,6 +66,7 @@ func (c *IdleTimeoutCheck) Run(ctx *CheckContext) *CheckResult {
 
 	var missing []string
 	var checked int
+	c.staleConfigs = nil
 
 	// Check each rig for idle-timeout config
 	for rigName, beadsPath := range rigSet {
@@ -77,6 +80,7 @@ func (c *IdleTimeoutCheck) Run(ctx *CheckContext) *CheckResult {
 		if err != nil {
 			// Config file missing - will be created by EnsureConfigYAML
 			missing = append(missing, fmt.Sprintf("%s (config.yaml missing)", rigName))
+			c.staleConfigs = append(c.staleConfigs, configPath)
 			checked++
 			continue
 		}
@@ -85,6 +89,7 @@ func (c *IdleTimeoutCheck) Run(ctx *CheckContext) *CheckResult {
 		if !strings.Contains(content, "dolt.idle-timeout:") ||
 			!strings.Contains(content, "dolt.idle-timeout: \"0\"") {
 			missing = append(missing, rigName)
+			c.staleConfigs = append(c.staleConfigs, configPath)
 		}
 		checked++
 	}
@@ -143,3 +148,13 @@ func (c *IdleTimeoutCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix lists the rig config.yaml files Fix would create or update.
+func (c *IdleTimeoutCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, path := range c.staleConfigs {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(path), Target: path, Detail: `dolt.idle-timeout: "0"`})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Target < changes[j].Target })
+	return changes
+}
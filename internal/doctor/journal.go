@@ -0,0 +1,325 @@
+package doctor
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// maxSnapshotSize caps how large a file the undo journal will capture.
+// Larger files are left out of the journal rather than bloating it.
+const maxSnapshotSize = 4 << 20
+
+// journalWatchGlobs are town-relative config files snapshotted around every
+// fix, in addition to the files a check's PlanFix names. This lets undo cover
+// checks that don't itemize their changes, as long as they only touch
+// well-known config files.
+var journalWatchGlobs = []string{
+	"CLAUDE.md",
+	"mayor/*.json",
+	"settings/*.json",
+	".claude/settings*.json",
+	"*/.claude/settings*.json",
+	".beads/config.yaml",
+	".beads/metadata.json",
+	".beads/routes.jsonl",
+	".beads/redirect",
+	"*/config.json",
+	"*/settings/*.json",
+	"*/.beads/config.yaml",
+	"*/.beads/metadata.json",
+	"*/.beads/routes.jsonl",
+	"*/.beads/redirect",
+	"*/mayor/rig/.beads/config.yaml",
+	"*/mayor/rig/.beads/metadata.json",
+	"*/*/.claude/settings*.json",
+}
+
+// Journal records the pre-fix state of every file a doctor fix run changed,
+// so the run can be rolled back with gt doctor undo.
+type Journal struct {
+	RunID     string         `json:"run_id"`
+	TownRoot  string         `json:"town_root"`
+	StartedAt time.Time      `json:"started_at"`
+	UndoneAt  *time.Time     `json:"undone_at,omitempty"`
+	Entries   []JournalEntry `json:"entries"`
+
+	path string
+}
+
+// JournalEntry is the record of one check's fix.
+type JournalEntry struct {
+	Check string         `json:"check"`
+	Error string         `json:"error,omitempty"`
+	Files []FileSnapshot `json:"files,omitempty"`
+	// Irreversible lists planned changes undo cannot roll back
+	// (bead updates, killed sessions, removed databases).
+	Irreversible []PlannedChange `json:"irreversible,omitempty"`
+}
+
+// FileSnapshot is the state of a file before a fix changed it.
+type FileSnapshot struct {
+	Path    string      `json:"path"`
+	Existed bool        `json:"existed"`
+	Mode    os.FileMode `json:"mode,omitempty"`
+	Content []byte      `json:"content,omitempty"`
+	// AfterSHA256 is the hash left by the fix; empty if the fix deleted the
+	// file. Undo refuses to overwrite a file that has changed since.
+	AfterSHA256 string `json:"after_sha256,omitempty"`
+}
+
+// UndoResult reports what gt doctor undo did.
+type UndoResult struct {
+	Restored     []string        // Files rewritten to their pre-fix content
+	Removed      []string        // Files the fix created, now deleted
+	Conflicts    []string        // Files modified since the fix, left untouched
+	Irreversible []PlannedChange // Changes that had to be left in place
+}
+
+// JournalDir returns the directory holding undo journals for a town.
+func JournalDir(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "doctor", "journal")
+}
+
+// NewJournal starts an undo journal for a new fix run.
+// Nothing is written until the first fix is recorded.
+func NewJournal(townRoot string) *Journal {
+	now := time.Now().UTC()
+	suffix := make([]byte, 2)
+	_, _ = rand.Read(suffix)
+	runID := now.Format("20060102-150405") + "-" + hex.EncodeToString(suffix)
+	return &Journal{
+		RunID:     runID,
+		TownRoot:  townRoot,
+		StartedAt: now,
+		path:      filepath.Join(JournalDir(townRoot), runID+".json"),
+	}
+}
+
+// LoadJournal reads the undo journal for a run.
+func LoadJournal(townRoot, runID string) (*Journal, error) {
+	if runID == "" || strings.ContainsAny(runID, `/\`) {
+		return nil, fmt.Errorf("invalid run ID %q", runID)
+	}
+	path := filepath.Join(JournalDir(townRoot), runID+".json")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w %s", ErrJournalNotFound, runID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var j Journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("parsing journal %s: %w", path, err)
+	}
+	j.path = path
+	return &j, nil
+}
+
+// ListJournals returns the town's undo journals, newest first.
+func ListJournals(townRoot string) ([]*Journal, error) {
+	entries, err := os.ReadDir(JournalDir(townRoot))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var journals []*Journal
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		j, err := LoadJournal(townRoot, strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		journals = append(journals, j)
+	}
+	sort.Slice(journals, func(a, b int) bool {
+		return journals[a].StartedAt.After(journals[b].StartedAt)
+	})
+	return journals, nil
+}
+
+// Save writes the journal to disk.
+func (j *Journal) Save() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSONWithPerm(j.path, j, 0600)
+}
+
+// fileState is a point-in-time view of a file for journaling.
+type fileState struct {
+	exists  bool
+	mode    os.FileMode
+	content []byte
+}
+
+// readFileState captures a file. ok is false for directories, unreadable
+// files, and files over maxSnapshotSize, which the journal skips.
+func readFileState(path string) (state fileState, ok bool) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fileState{}, true
+	}
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxSnapshotSize {
+		return fileState{}, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fileState{}, false
+	}
+	return fileState{exists: true, mode: info.Mode().Perm(), content: content}, true
+}
+
+func (s fileState) sha256() string {
+	if !s.exists {
+		return ""
+	}
+	sum := sha256.Sum256(s.content)
+	return hex.EncodeToString(sum[:])
+}
+
+// fixSnapshot holds the pre-fix state captured by Journal.begin.
+type fixSnapshot struct {
+	check   string
+	before  map[string]fileState
+	planned []PlannedChange
+}
+
+// begin captures the files a fix may touch: those named by the check's plan
+// plus the well-known config files.
+func (j *Journal) begin(check Check, ctx *CheckContext) *fixSnapshot {
+	snap := &fixSnapshot{check: check.Name(), before: make(map[string]fileState)}
+	if fp, ok := check.(FixPlanner); ok {
+		snap.planned = fp.PlanFix(ctx)
+	}
+
+	var paths []string
+	for _, ch := range snap.planned {
+		if ch.Kind == ChangeFile {
+			paths = append(paths, ch.Target)
+		}
+	}
+	for _, pattern := range journalWatchGlobs {
+		matches, _ := filepath.Glob(filepath.Join(ctx.TownRoot, pattern))
+		paths = append(paths, matches...)
+	}
+	for _, path := range paths {
+		if _, seen := snap.before[path]; seen {
+			continue
+		}
+		if state, ok := readFileState(path); ok {
+			snap.before[path] = state
+		}
+	}
+	return snap
+}
+
+// commit records the files that changed since begin and saves the journal.
+func (j *Journal) commit(snap *fixSnapshot, fixErr error) error {
+	entry := JournalEntry{Check: snap.check}
+	if fixErr != nil {
+		entry.Error = fixErr.Error()
+	}
+
+	paths := make([]string, 0, len(snap.before))
+	for path := range snap.before {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		before := snap.before[path]
+		after, ok := readFileState(path)
+		if !ok {
+			continue
+		}
+		if before.exists == after.exists && bytes.Equal(before.content, after.content) {
+			continue
+		}
+		entry.Files = append(entry.Files, FileSnapshot{
+			Path:        path,
+			Existed:     before.exists,
+			Mode:        before.mode,
+			Content:     before.content,
+			AfterSHA256: after.sha256(),
+		})
+	}
+	for _, ch := range snap.planned {
+		if !ch.Kind.Reversible() {
+			entry.Irreversible = append(entry.Irreversible, ch)
+		}
+	}
+
+	j.Entries = append(j.Entries, entry)
+	return j.Save()
+}
+
+// Undo restores every journaled file to its pre-fix state, newest fix first.
+// Files modified since the fix are reported as conflicts and left alone
+// unless force is set. Bead, session, and database changes are reported
+// but cannot be reverted.
+func (j *Journal) Undo(force bool) (*UndoResult, error) {
+	if j.UndoneAt != nil && !force {
+		return nil, fmt.Errorf("%w %s at %s", ErrJournalUndone, j.RunID, j.UndoneAt.Format(time.RFC3339))
+	}
+
+	result := &UndoResult{}
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		entry := j.Entries[i]
+		result.Irreversible = append(result.Irreversible, entry.Irreversible...)
+		for k := len(entry.Files) - 1; k >= 0; k-- {
+			fs := entry.Files[k]
+			current, ok := readFileState(fs.Path)
+			if !force && (!ok || current.sha256() != fs.AfterSHA256) {
+				result.Conflicts = append(result.Conflicts, fs.Path)
+				continue
+			}
+			if !fs.Existed {
+				if err := os.Remove(fs.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+					return result, fmt.Errorf("removing %s: %w", fs.Path, err)
+				}
+				result.Removed = append(result.Removed, fs.Path)
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(fs.Path), 0755); err != nil {
+				return result, fmt.Errorf("restoring %s: %w", fs.Path, err)
+			}
+			if err := util.AtomicWriteFile(fs.Path, fs.Content, fs.Mode); err != nil {
+				return result, fmt.Errorf("restoring %s: %w", fs.Path, err)
+			}
+			result.Restored = append(result.Restored, fs.Path)
+		}
+	}
+
+	now := time.Now().UTC()
+	j.UndoneAt = &now
+	if err := j.Save(); err != nil {
+		return result, fmt.Errorf("marking journal undone: %w", err)
+	}
+	return result, nil
+}
+
+// FileCount returns how many file snapshots the journal holds.
+func (j *Journal) FileCount() int {
+	n := 0
+	for _, e := range j.Entries {
+		n += len(e.Files)
+	}
+	return n
+}
@@ -0,0 +1,232 @@
+package doctor
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fileFixCheck fails until Fix runs, and Fix rewrites, creates, and deletes
+// files under the town root.
+type fileFixCheck struct {
+	FixableCheck
+	fixed   bool
+	rewrite string
+	create  string
+	remove  string
+}
+
+func (c *fileFixCheck) Run(ctx *CheckContext) *CheckResult {
+	if c.fixed {
+		return &CheckResult{Status: StatusOK}
+	}
+	return &CheckResult{Status: StatusWarning, Message: "needs fix"}
+}
+
+func (c *fileFixCheck) Fix(ctx *CheckContext) error {
+	c.fixed = true
+	if c.rewrite != "" {
+		if err := os.WriteFile(c.rewrite, []byte("fixed\n"), 0644); err != nil {
+			return err
+		}
+	}
+	if c.create != "" {
+		if err := os.WriteFile(c.create, []byte("new\n"), 0600); err != nil {
+			return err
+		}
+	}
+	if c.remove != "" {
+		return os.Remove(c.remove)
+	}
+	return nil
+}
+
+type plannedFileFixCheck struct {
+	fileFixCheck
+}
+
+func (c *plannedFileFixCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	return []PlannedChange{
+		{Kind: ChangeFile, Action: "create", Target: c.create},
+		{Kind: ChangeSession, Action: "kill", Target: "gt-gastown-witness"},
+	}
+}
+
+func newFileFixCheck(name string) *fileFixCheck {
+	return &fileFixCheck{FixableCheck: FixableCheck{BaseCheck: BaseCheck{CheckName: name, CheckDescription: "Fix files"}}}
+}
+
+func TestJournal_FixAndUndo(t *testing.T) {
+	town := t.TempDir()
+	claudeMD := filepath.Join(town, "CLAUDE.md")    // watched config file
+	stale := filepath.Join(town, "mayor", "x.json") // watched, deleted by fix
+	created := filepath.Join(town, "unwatched.txt") // only known via PlanFix
+	if err := os.MkdirAll(filepath.Dir(stale), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(claudeMD, []byte("original\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stale, []byte(`{"stale":true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	check := &plannedFileFixCheck{*newFileFixCheck("files")}
+	check.rewrite, check.create, check.remove = claudeMD, created, stale
+
+	d := NewDoctor()
+	d.Register(check)
+	journal := NewJournal(town)
+	d.SetJournal(journal)
+	if report := d.Fix(&CheckContext{TownRoot: town}); report.Summary.Fixed != 1 {
+		t.Fatalf("fix did not apply: %+v", report.Checks[0])
+	}
+
+	loaded, err := LoadJournal(town, journal.RunID)
+	if err != nil {
+		t.Fatalf("LoadJournal: %v", err)
+	}
+	if loaded.FileCount() != 3 {
+		t.Fatalf("journal captured %d files, want 3: %+v", loaded.FileCount(), loaded.Entries)
+	}
+	if irr := loaded.Entries[0].Irreversible; len(irr) != 1 || irr[0].Target != "gt-gastown-witness" {
+		t.Errorf("irreversible = %+v", irr)
+	}
+
+	result, err := loaded.Undo(false)
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if len(result.Restored) != 2 || len(result.Removed) != 1 || len(result.Conflicts) != 0 {
+		t.Errorf("undo result = %+v", result)
+	}
+	if data, _ := os.ReadFile(claudeMD); string(data) != "original\n" {
+		t.Errorf("CLAUDE.md = %q, want original", data)
+	}
+	if info, err := os.Stat(claudeMD); err != nil || info.Mode().Perm() != 0640 {
+		t.Errorf("CLAUDE.md mode not restored: %v %v", info, err)
+	}
+	if data, _ := os.ReadFile(stale); string(data) != `{"stale":true}` {
+		t.Errorf("deleted file not restored: %q", data)
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Errorf("file created by fix still exists: %v", err)
+	}
+
+	if _, err := loaded.Undo(false); !errors.Is(err, ErrJournalUndone) {
+		t.Errorf("second undo err = %v, want ErrJournalUndone", err)
+	}
+}
+
+func TestJournal_UndoConflict(t *testing.T) {
+	town := t.TempDir()
+	claudeMD := filepath.Join(town, "CLAUDE.md")
+	if err := os.WriteFile(claudeMD, []byte("original\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	check := newFileFixCheck("rewrite")
+	check.rewrite = claudeMD
+
+	d := NewDoctor()
+	d.Register(check)
+	journal := NewJournal(town)
+	d.SetJournal(journal)
+	d.Fix(&CheckContext{TownRoot: town})
+
+	// Someone edits the file after the fix.
+	if err := os.WriteFile(claudeMD, []byte("hand edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := journal.Undo(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 1 || len(result.Restored) != 0 {
+		t.Errorf("undo result = %+v, want one conflict", result)
+	}
+	if data, _ := os.ReadFile(claudeMD); string(data) != "hand edited\n" {
+		t.Errorf("conflicting file was overwritten: %q", data)
+	}
+
+	if _, err := journal.Undo(true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(claudeMD); string(data) != "original\n" {
+		t.Errorf("forced undo did not restore: %q", data)
+	}
+}
+
+func TestListJournals(t *testing.T) {
+	town := t.TempDir()
+	if js, err := ListJournals(town); err != nil || len(js) != 0 {
+		t.Fatalf("empty town: %v, %v", js, err)
+	}
+	first := NewJournal(town)
+	first.Entries = []JournalEntry{{Check: "a"}}
+	if err := first.Save(); err != nil {
+		t.Fatal(err)
+	}
+	second := NewJournal(town)
+	second.StartedAt = first.StartedAt.Add(1)
+	if err := second.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	js, err := ListJournals(town)
+	if err != nil || len(js) != 2 {
+		t.Fatalf("ListJournals = %v, %v", js, err)
+	}
+	if js[0].RunID != second.RunID {
+		t.Errorf("journals not newest first: %s, %s", js[0].RunID, js[1].RunID)
+	}
+	if _, err := LoadJournal(town, "../etc/passwd"); err == nil {
+		t.Error("LoadJournal should reject path separators")
+	}
+	if _, err := LoadJournal(town, "nope"); !errors.Is(err, ErrJournalNotFound) {
+		t.Errorf("missing journal err = %v", err)
+	}
+}
+
+func TestDoctor_Plan(t *testing.T) {
+	town := t.TempDir()
+	planned := &plannedFileFixCheck{*newFileFixCheck("planned")}
+	planned.create = filepath.Join(town, "new.txt")
+	opaque := newFileFixCheck("opaque")
+	opaque.create = filepath.Join(town, "opaque.txt")
+
+	d := NewDoctor()
+	d.Register(newMockCheck("healthy", StatusOK))
+	d.Register(newMockCheck("manual", StatusError))
+	d.Register(planned)
+	d.Register(opaque)
+
+	plan := d.Plan(&CheckContext{TownRoot: town})
+
+	if plan.Unfixable != 1 || len(plan.Checks) != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	if !plan.Checks[0].Previewable || len(plan.Checks[0].Changes) != 2 {
+		t.Errorf("planned check = %+v", plan.Checks[0])
+	}
+	if plan.Checks[1].Previewable {
+		t.Errorf("check without PlanFix should not be previewable: %+v", plan.Checks[1])
+	}
+	if planned.fixed || opaque.fixed {
+		t.Error("Plan must not apply fixes")
+	}
+	if _, err := os.Stat(planned.create); !os.IsNotExist(err) {
+		t.Error("Plan created files")
+	}
+
+	var buf bytes.Buffer
+	plan.Print(&buf)
+	out := buf.String()
+	for _, want := range []string{"create file " + planned.create, "[not undoable]", "[not previewable]", "Fix files", "2 check(s) would be fixed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("plan output missing %q:\n%s", want, out)
+		}
+	}
+}
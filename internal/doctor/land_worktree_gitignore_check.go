@@ -82,6 +82,16 @@ func (c *LandWorktreeGitignoreCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the .gitignore files Fix would append to.
+func (c *LandWorktreeGitignoreCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, rigPath := range c.affectedRigs {
+		gitignorePath := filepath.Join(rigPath, ".gitignore")
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(gitignorePath), Target: gitignorePath, Detail: "add .land-worktree/"})
+	}
+	return changes
+}
+
 // hasGitignoreEntry checks if a .gitignore file contains the given entry.
 func hasGitignoreEntry(gitignorePath, entry string) bool {
 	file, err := os.Open(gitignorePath)
@@ -111,3 +111,12 @@ func (c *LifecycleHygieneCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the lifecycle messages Fix would delete.
+func (c *LifecycleHygieneCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, msg := range c.staleMessages {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "delete", Target: msg.ID, Detail: msg.Subject})
+	}
+	return changes
+}
@@ -98,3 +98,16 @@ func (c *LifecycleDefaultsCheck) Run(ctx *CheckContext) *CheckResult {
 func (c *LifecycleDefaultsCheck) Fix(ctx *CheckContext) error {
 	return daemon.EnsureLifecycleConfigFile(ctx.TownRoot)
 }
+
+// PlanFix reports the lifecycle patrol entries Fix would add to daemon.json.
+func (c *LifecycleDefaultsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if len(c.missing) == 0 {
+		return nil
+	}
+	return []PlannedChange{{
+		Kind:   ChangeFile,
+		Action: "update",
+		Target: daemon.PatrolConfigFile(ctx.TownRoot),
+		Detail: "add defaults for " + strings.Join(c.missing, ", "),
+	}}
+}
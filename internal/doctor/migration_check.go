@@ -138,6 +138,16 @@ func (c *DoltMetadataCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the metadata.json files Fix would write.
+func (c *DoltMetadataCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, rigName := range c.missingMetadata {
+		path := filepath.Join(c.findRigBeadsDir(ctx.TownRoot, rigName), "metadata.json")
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(path), Target: path, Detail: "dolt server config for " + rigName})
+	}
+	return changes
+}
+
 // hasDoltMetadata checks if a beads directory has proper dolt server config.
 func (c *DoltMetadataCheck) hasDoltMetadata(beadsDir, expectedDB string) bool {
 	metadataPath := filepath.Join(beadsDir, "metadata.json")
@@ -259,6 +269,7 @@ func NewDoltServerReachableCheck() *DoltServerReachableCheck {
 			CheckName:        "dolt-server-reachable",
 			CheckDescription: "Check that Dolt server is reachable when server mode is configured",
 			CheckCategory:    CategoryInfrastructure,
+			CheckDependsOn:   doltDependencies,
 		},
 	}
 }
@@ -400,6 +411,7 @@ func NewDoltOrphanedDatabaseCheck() *DoltOrphanedDatabaseCheck {
 				CheckName:        "dolt-orphaned-databases",
 				CheckDescription: "Detect orphaned databases in .dolt-data/",
 				CheckCategory:    CategoryCleanup,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -454,6 +466,15 @@ func (c *DoltOrphanedDatabaseCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the orphaned databases Fix would remove.
+func (c *DoltOrphanedDatabaseCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.orphanNames))
+	for _, name := range c.orphanNames {
+		changes = append(changes, PlannedChange{Kind: ChangeDatabase, Action: "delete", Target: name})
+	}
+	return changes
+}
+
 // formatBytes returns a human-readable size string.
 func formatBytes(b int64) string {
 	const unit = 1024
@@ -40,6 +40,7 @@ func NewCheckMisclassifiedWisps() *CheckMisclassifiedWisps {
 				CheckName:        "misclassified-wisps",
 				CheckDescription: "Detect ephemeral beads misplaced in the issues table",
 				CheckCategory:    CategoryCleanup,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 		misclassifiedRigs: make(map[string]int),
@@ -182,6 +183,15 @@ func (c *CheckMisclassifiedWisps) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the beads Fix would move from the issues table to wisps.
+func (c *CheckMisclassifiedWisps) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, w := range c.misclassified {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "update", Target: w.id, Detail: "move to wisps table"})
+	}
+	return changes
+}
+
 func resolveMisclassifiedWispWorkDir(townRoot string, w misclassifiedWisp) string {
 	if w.workDir != "" {
 		return w.workDir
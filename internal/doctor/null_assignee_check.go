@@ -142,6 +142,15 @@ func (c *NullAssigneeCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the beads Fix would reset to open.
+func (c *NullAssigneeCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, row := range c.affected {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "update", Target: row.ID, Detail: "in_progress → open"})
+	}
+	return changes
+}
+
 // queryNullAssigneeBeads returns in_progress beads with NULL/empty assignee for a rig.
 // Uses bd sql --csv (raw SQL passthrough, not affected by bd ORM deserialization).
 func queryNullAssigneeBeads(rigDir string) ([]nullAssigneeRow, error) {
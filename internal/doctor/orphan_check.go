@@ -158,6 +158,18 @@ func (c *OrphanSessionCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the orphaned sessions Fix would kill (crew sessions excluded).
+func (c *OrphanSessionCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, sess := range c.orphanSessions {
+		if isCrewSession(sess) {
+			continue
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeSession, Action: "kill", Target: sess})
+	}
+	return changes
+}
+
 // isCrewSession returns true if the session name matches the crew pattern.
 // Crew sessions are gt-<rig>-crew-<name> and are protected from auto-cleanup.
 func isCrewSession(sess string) bool {
@@ -0,0 +1,180 @@
+package doctor
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Output formats accepted by gt doctor --format.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatJUnit = "junit"
+)
+
+// statusKey returns the machine-readable status of a result.
+func statusKey(result *CheckResult) string {
+	if result.Skipped {
+		return "skipped"
+	}
+	switch result.Status {
+	case StatusOK:
+		return "ok"
+	case StatusWarning:
+		return "warning"
+	case StatusError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// jsonCheck is the JSON form of a single check result.
+// The id is the check's registered name and is stable across releases.
+type jsonCheck struct {
+	ID        string   `json:"id"`
+	Category  string   `json:"category,omitempty"`
+	Status    string   `json:"status"`
+	Message   string   `json:"message,omitempty"`
+	Details   []string `json:"details,omitempty"`
+	FixHint   string   `json:"fix_hint,omitempty"`
+	Fixed     bool     `json:"fixed,omitempty"`
+	ElapsedMS int64    `json:"elapsed_ms"`
+}
+
+type jsonSummary struct {
+	Total    int `json:"total"`
+	OK       int `json:"ok"`
+	Warnings int `json:"warnings"`
+	Errors   int `json:"errors"`
+	Fixed    int `json:"fixed"`
+	Skipped  int `json:"skipped"`
+}
+
+type jsonReport struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Healthy   bool        `json:"healthy"`
+	Summary   jsonSummary `json:"summary"`
+	Checks    []jsonCheck `json:"checks"`
+}
+
+// WriteJSON writes the report as a single JSON document.
+func (r *Report) WriteJSON(w io.Writer) error {
+	out := jsonReport{
+		Timestamp: r.Timestamp,
+		Healthy:   r.IsHealthy(),
+		Summary: jsonSummary{
+			Total:    r.Summary.Total,
+			OK:       r.Summary.OK,
+			Warnings: r.Summary.Warnings,
+			Errors:   r.Summary.Errors,
+			Fixed:    r.Summary.Fixed,
+			Skipped:  r.Summary.Skipped,
+		},
+		Checks: make([]jsonCheck, 0, len(r.Checks)),
+	}
+	for _, c := range r.Checks {
+		out.Checks = append(out.Checks, jsonCheck{
+			ID:        c.Name,
+			Category:  c.Category,
+			Status:    statusKey(c),
+			Message:   c.Message,
+			Details:   c.Details,
+			FixHint:   c.FixHint,
+			Fixed:     c.Fixed,
+			ElapsedMS: c.Elapsed.Milliseconds(),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML so CI systems can render it.
+// Errors become failures, skipped checks become skipped test cases, and
+// warnings pass with their message in system-out.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suite := junitSuite{
+		Name:      "gt doctor",
+		Tests:     len(r.Checks),
+		Failures:  r.Summary.Errors,
+		Skipped:   r.Summary.Skipped,
+		Timestamp: r.Timestamp.UTC().Format(time.RFC3339),
+	}
+	var total time.Duration
+	for _, c := range r.Checks {
+		total += c.Elapsed
+		class := c.Category
+		if class == "" {
+			class = "Other"
+		}
+		tc := junitCase{
+			Name:      c.Name,
+			ClassName: "doctor." + class,
+			Time:      junitSeconds(c.Elapsed),
+		}
+		body := strings.Join(c.Details, "\n")
+		if c.FixHint != "" {
+			body = strings.TrimPrefix(body+"\nFix: "+c.FixHint, "\n")
+		}
+		switch {
+		case c.Skipped:
+			tc.Skipped = &junitMessage{Message: c.Message}
+		case c.Status == StatusError:
+			tc.Failure = &junitMessage{Message: c.Message, Type: "error", Body: body}
+		case c.Status == StatusWarning:
+			tc.SystemOut = strings.TrimSuffix("warning: "+c.Message+"\n"+body, "\n")
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Time = junitSeconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,88 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleReport() *Report {
+	r := NewReport()
+	r.Add(&CheckResult{Name: "dolt-binary", Category: CategoryInfrastructure, Status: StatusError,
+		Message: "dolt not found in PATH", FixHint: "Install dolt", Elapsed: 1500 * time.Millisecond})
+	r.Add(&CheckResult{Name: "rig-beads-exist", Category: CategoryRig, Status: StatusWarning,
+		Message: "skipped: depends on dolt-binary", Skipped: true})
+	r.Add(&CheckResult{Name: "themes", Status: StatusWarning, Message: "2 rigs share a theme",
+		Details: []string{"gastown", "beads"}})
+	r.Add(&CheckResult{Name: "town-git", Category: CategoryCore, Status: StatusOK, Message: "ok"})
+	return r
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport().WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var got jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if got.Healthy {
+		t.Error("report with errors should not be healthy")
+	}
+	want := jsonSummary{Total: 4, OK: 1, Warnings: 1, Errors: 1, Skipped: 1}
+	if got.Summary != want {
+		t.Errorf("summary = %+v, want %+v", got.Summary, want)
+	}
+	statuses := []string{"error", "skipped", "warning", "ok"}
+	for i, c := range got.Checks {
+		if c.Status != statuses[i] {
+			t.Errorf("checks[%d] (%s) status = %q, want %q", i, c.ID, c.Status, statuses[i])
+		}
+	}
+	if got.Checks[0].ID != "dolt-binary" || got.Checks[0].ElapsedMS != 1500 || got.Checks[0].FixHint != "Install dolt" {
+		t.Errorf("checks[0] = %+v", got.Checks[0])
+	}
+}
+
+func TestReport_WriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport().WriteJUnit(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "<?xml") {
+		t.Errorf("missing XML header:\n%s", out)
+	}
+
+	var got junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, out)
+	}
+	suite := got.Suites[0]
+	if suite.Tests != 4 || suite.Failures != 1 || suite.Skipped != 1 {
+		t.Errorf("suite = tests %d failures %d skipped %d", suite.Tests, suite.Failures, suite.Skipped)
+	}
+	cases := suite.Cases
+	if cases[0].Failure == nil || cases[0].Failure.Message != "dolt not found in PATH" ||
+		!strings.Contains(cases[0].Failure.Body, "Fix: Install dolt") {
+		t.Errorf("failure case = %+v", cases[0])
+	}
+	if cases[0].ClassName != "doctor.Infrastructure" || cases[0].Time != "1.500" {
+		t.Errorf("case attrs = %q %q", cases[0].ClassName, cases[0].Time)
+	}
+	if cases[1].Skipped == nil {
+		t.Errorf("skipped case = %+v", cases[1])
+	}
+	if cases[2].Failure != nil || !strings.Contains(cases[2].SystemOut, "warning: 2 rigs share a theme") ||
+		cases[2].ClassName != "doctor.Other" {
+		t.Errorf("warning case = %+v", cases[2])
+	}
+	if cases[3].Failure != nil || cases[3].Skipped != nil {
+		t.Errorf("ok case = %+v", cases[3])
+	}
+}
@@ -139,6 +139,34 @@ func (c *OverlayHealthCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the overlay files Fix would rewrite, or remove when every
+// override in them is stale.
+func (c *OverlayHealthCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, f := range c.scanOverlays(ctx.TownRoot) {
+		if f.ParseErr != nil || len(f.StaleIDs) == 0 {
+			continue
+		}
+		staleSet := make(map[string]bool, len(f.StaleIDs))
+		for _, id := range f.StaleIDs {
+			staleSet[id] = true
+		}
+		kept := 0
+		for _, so := range f.Overlay.StepOverrides {
+			if !staleSet[so.StepID] {
+				kept++
+			}
+		}
+		detail := "drop stale step " + strings.Join(f.StaleIDs, ", ")
+		if kept == 0 {
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: f.Path, Detail: detail})
+		} else {
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "update", Target: f.Path, Detail: detail})
+		}
+	}
+	return changes
+}
+
 // scanOverlays discovers and validates all overlay files in the workspace.
 func (c *OverlayHealthCheck) scanOverlays(townRoot string) []overlayFile {
 	var results []overlayFile
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -155,6 +156,31 @@ func (c *PatrolMoleculesExistCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the embedded formulas Fix would write into each rig.
+// Existing formula files are never overwritten.
+func (c *PatrolMoleculesExistCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for rigName := range c.missingFormulas {
+		rigPath := filepath.Join(ctx.TownRoot, rigName)
+		if _, statErr := os.Stat(rigPath); os.IsNotExist(statErr) {
+			rigPath = ctx.TownRoot
+		}
+		report, err := formula.CheckFormulaHealth(rigPath)
+		if err != nil {
+			continue
+		}
+		formulasDir := filepath.Join(rigPath, ".beads", "formulas")
+		for _, f := range report.Formulas {
+			if f.Status == "missing" || f.Status == "new" {
+				changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: filepath.Join(formulasDir, f.Name)})
+			}
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(filepath.Join(formulasDir, ".installed.json")), Target: filepath.Join(formulasDir, ".installed.json")})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Target < changes[j].Target })
+	return changes
+}
+
 // PatrolHooksWiredCheck verifies that hooks trigger patrol execution.
 type PatrolHooksWiredCheck struct {
 	FixableCheck
@@ -226,6 +252,16 @@ func (c *PatrolHooksWiredCheck) Fix(ctx *CheckContext) error {
 	return config.EnsureDaemonPatrolConfig(ctx.TownRoot)
 }
 
+// PlanFix reports the default daemon config Fix would create. An existing
+// config is left alone.
+func (c *PatrolHooksWiredCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	path := config.DaemonPatrolConfigPath(ctx.TownRoot)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeFile, Action: "create", Target: path, Detail: "default patrols"}}
+}
+
 // PatrolNotStuckCheck detects wisps that have been in_progress too long.
 type PatrolNotStuckCheck struct {
 	BaseCheck
@@ -243,6 +279,7 @@ func NewPatrolNotStuckCheck() *PatrolNotStuckCheck {
 			CheckName:        "patrol-not-stuck",
 			CheckDescription: "Check for stuck patrol wisps (>1h in_progress)",
 			CheckCategory:    CategoryPatrol,
+			CheckDependsOn:   beadsDependencies,
 		},
 		stuckThreshold: DefaultStuckThreshold,
 	}
@@ -422,11 +459,21 @@ func (c *PatrolPluginsAccessibleCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the plugin directories Fix would create.
+func (c *PatrolPluginsAccessibleCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, dir := range c.missingDirs {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: dir, Detail: "directory"})
+	}
+	return changes
+}
+
 // PatrolPluginDriftCheck detects when runtime plugins are out of sync with source.
 type PatrolPluginDriftCheck struct {
 	FixableCheck
 	sourceDir string
 	targetDir string
+	report    *plugin.DriftReport // Cached during Run for use in PlanFix
 }
 
 // NewPatrolPluginDriftCheck creates a new plugin drift check.
@@ -445,6 +492,7 @@ func NewPatrolPluginDriftCheck() *PatrolPluginDriftCheck {
 // Run checks for plugin drift between source and runtime.
 func (c *PatrolPluginDriftCheck) Run(ctx *CheckContext) *CheckResult {
 	c.targetDir = filepath.Join(ctx.TownRoot, "plugins")
+	c.report = nil
 
 	sourceDir, err := plugin.FindGastownSource(ctx.TownRoot)
 	if err != nil {
@@ -477,6 +525,7 @@ func (c *PatrolPluginDriftCheck) Run(ctx *CheckContext) *CheckResult {
 		}
 	}
 
+	c.report = report
 	if !report.HasDrift() {
 		return &CheckResult{
 			Name:    c.Name(),
@@ -511,6 +560,21 @@ func (c *PatrolPluginDriftCheck) Fix(ctx *CheckContext) error {
 	return err
 }
 
+// PlanFix lists the runtime plugin directories Fix would copy from source.
+func (c *PatrolPluginDriftCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if c.report == nil {
+		return nil
+	}
+	var changes []PlannedChange
+	for _, d := range c.report.Drifted {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "update", Target: filepath.Join(c.targetDir, d.Name), Detail: "directory, from " + c.sourceDir})
+	}
+	for _, name := range c.report.Missing {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: filepath.Join(c.targetDir, name), Detail: "directory, from " + c.sourceDir})
+	}
+	return changes
+}
+
 // discoverRigs finds all registered rigs.
 func discoverRigs(townRoot string) ([]string, error) {
 	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
@@ -218,5 +218,23 @@ func (c *BranchProtectionCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the git hook files Fix would remove and write.
+func (c *BranchProtectionCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if !c.needsUpdate {
+		return nil
+	}
+	hooksDir := filepath.Join(ctx.TownRoot, ".git", "hooks")
+	var changes []PlannedChange
+	preCheckoutPath := filepath.Join(hooksDir, "pre-checkout")
+	if content, err := os.ReadFile(preCheckoutPath); err == nil && strings.Contains(string(content), "Gas Town pre-checkout hook") {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: preCheckoutPath, Detail: "obsolete hook"})
+	}
+	hookPath := filepath.Join(hooksDir, "post-checkout")
+	if content, err := os.ReadFile(hookPath); err == nil && strings.Contains(string(content), branchProtectionMarker) {
+		return changes
+	}
+	return append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(hookPath), Target: hookPath, Detail: "branch protection"})
+}
+
 // Legacy type alias for backwards compatibility
 type PreCheckoutHookCheck = BranchProtectionCheck
@@ -466,3 +466,35 @@ func (c *PrimingCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the files Fix would write or remove for each fixable
+// priming issue.
+func (c *PrimingCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, issue := range c.issues {
+		if !issue.fixable {
+			continue
+		}
+		agentPath := filepath.Join(ctx.TownRoot, issue.location)
+		switch issue.issueType {
+		case "no_prime_hook":
+			settingsPath := filepath.Join(agentPath, ".claude", "settings.json")
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(settingsPath), Target: settingsPath, Detail: "regenerated for " + issue.agentType})
+		case "missing_town_claude_md":
+			claudePath := filepath.Join(ctx.TownRoot, "CLAUDE.md")
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(claudePath), Target: claudePath, Detail: "identity anchor"})
+		case "orphaned_beads_dir":
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: filepath.Join(agentPath, ".beads"), Detail: "directory and contents"})
+		case "missing_prime_md":
+			primePath := filepath.Join(beads.ResolveBeadsDir(agentPath), "PRIME.md")
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: primePath})
+		case "stale_intermediate_instructions_md":
+			for _, filename := range []string{"CLAUDE.md", "AGENTS.md"} {
+				if filePath := filepath.Join(agentPath, filename); fileExists(filePath) {
+					changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: filePath})
+				}
+			}
+		}
+	}
+	return changes
+}
@@ -15,6 +15,7 @@ import (
 // They are created by gt rig add (see gt-zmznh) but may be missing for legacy rigs.
 type RigBeadsCheck struct {
 	FixableCheck
+	missing []string // Cached during Run for use in PlanFix
 }
 
 // NewRigBeadsCheck creates a new rig identity beads check.
@@ -25,6 +26,7 @@ func NewRigBeadsCheck() *RigBeadsCheck {
 				CheckName:        "rig-beads-exist",
 				CheckDescription: "Verify rig identity beads exist for all rigs",
 				CheckCategory:    CategoryRig,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -77,6 +79,7 @@ func (c *RigBeadsCheck) Run(ctx *CheckContext) *CheckResult {
 
 	var missing []string
 	var checked int
+	c.missing = nil
 
 	// Check each rig for its identity bead
 	for rigName, info := range rigSet {
@@ -90,6 +93,7 @@ func (c *RigBeadsCheck) Run(ctx *CheckContext) *CheckResult {
 		checked++
 	}
 
+	c.missing = missing
 	if len(missing) == 0 {
 		return &CheckResult{
 			Name:    c.Name(),
@@ -170,3 +174,12 @@ func (c *RigBeadsCheck) Fix(ctx *CheckContext) error {
 
 	return errors.Join(errs...)
 }
+
+// PlanFix lists the rig identity beads Fix would create.
+func (c *RigBeadsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, id := range c.missing {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "create", Target: id, Detail: "rig identity"})
+	}
+	return changes
+}
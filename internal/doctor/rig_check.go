@@ -243,6 +243,14 @@ func (c *GitExcludeConfiguredCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix reports the exclude file Fix would append the missing entries to.
+func (c *GitExcludeConfiguredCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if len(c.missingEntries) == 0 {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeFile, Action: fileAction(c.excludePath), Target: c.excludePath, Detail: "add " + strings.Join(c.missingEntries, ", ")}}
+}
+
 // HooksPathConfiguredCheck verifies all clones have core.hooksPath set to .githooks.
 // This ensures the pre-push hook blocks pushes to invalid branches (no internal PRs).
 type HooksPathConfiguredCheck struct {
@@ -364,6 +372,15 @@ func (c *HooksPathConfiguredCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the clones whose git config Fix would update.
+func (c *HooksPathConfiguredCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, clonePath := range c.unconfiguredClones {
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "update", Target: clonePath, Detail: "core.hooksPath=.githooks"})
+	}
+	return changes
+}
+
 // WitnessExistsCheck verifies the witness directory structure exists.
 type WitnessExistsCheck struct {
 	FixableCheck
@@ -471,6 +488,20 @@ func (c *WitnessExistsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the witness directories and files Fix would create. A
+// missing witness/rig/ clone is not created by Fix.
+func (c *WitnessExistsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	witnessDir := filepath.Join(c.rigPath, "witness")
+	var changes []PlannedChange
+	if c.needsCreate {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: witnessDir, Detail: "directory"})
+	}
+	if c.needsMail {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: filepath.Join(witnessDir, "mail", "inbox.jsonl")})
+	}
+	return changes
+}
+
 // RefineryExistsCheck verifies the refinery directory structure exists.
 type RefineryExistsCheck struct {
 	FixableCheck
@@ -607,6 +638,23 @@ func (c *RefineryExistsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the refinery directories, files, and worktree Fix would
+// create.
+func (c *RefineryExistsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	refineryDir := filepath.Join(c.rigPath, "refinery")
+	var changes []PlannedChange
+	if c.needsCreate {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: refineryDir, Detail: "directory"})
+	}
+	if c.needsMail {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: filepath.Join(refineryDir, "mail", "inbox.jsonl")})
+	}
+	if c.needsClone {
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "create", Target: filepath.Join(refineryDir, "rig"), Detail: "worktree of .repo.git"})
+	}
+	return changes
+}
+
 // MayorCloneExistsCheck verifies the mayor/rig clone exists.
 type MayorCloneExistsCheck struct {
 	FixableCheck
@@ -694,6 +742,15 @@ func (c *MayorCloneExistsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix reports the mayor/ directory Fix would create. A missing
+// mayor/rig/ clone is not created by Fix.
+func (c *MayorCloneExistsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if !c.needsCreate {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeFile, Action: "create", Target: filepath.Join(c.rigPath, "mayor"), Detail: "directory"}}
+}
+
 // PolecatClonesValidCheck verifies each polecat directory is a valid clone.
 type PolecatClonesValidCheck struct {
 	BaseCheck
@@ -841,6 +898,7 @@ func NewBeadsConfigValidCheck() *BeadsConfigValidCheck {
 				CheckName:        "beads-config-valid",
 				CheckDescription: "Verify beads configuration if .beads/ exists",
 				CheckCategory:    CategoryRig,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -895,6 +953,11 @@ func (c *BeadsConfigValidCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix reports no changes; Fix is a no-op.
+func (c *BeadsConfigValidCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	return nil
+}
+
 // BeadsRedirectCheck verifies that rig-level beads redirect exists for tracked beads.
 // When a repo has .beads/ tracked in git (at mayor/rig/.beads), the rig root needs
 // a redirect file pointing to that location.
@@ -1099,6 +1162,33 @@ func (c *BeadsRedirectCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the beads directory, database, or redirect Fix would
+// create for the rig.
+func (c *BeadsRedirectCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if ctx.RigName == "" {
+		return nil
+	}
+	rigPath := ctx.RigPath()
+	rigBeadsDir := filepath.Join(rigPath, ".beads")
+	redirectPath := filepath.Join(rigBeadsDir, "redirect")
+
+	if _, err := os.Stat(filepath.Join(rigPath, "mayor", "rig", ".beads")); os.IsNotExist(err) {
+		if _, err := os.Stat(rigBeadsDir); err == nil {
+			return nil
+		}
+		return []PlannedChange{
+			{Kind: ChangeFile, Action: "create", Target: rigBeadsDir, Detail: "directory"},
+			{Kind: ChangeDatabase, Action: "create", Target: config.GetRigPrefix(ctx.TownRoot, ctx.RigName), Detail: "bd init --server"},
+		}
+	}
+
+	var changes []PlannedChange
+	if hasBeadsData(rigBeadsDir) {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: rigBeadsDir, Detail: "conflicting local beads"})
+	}
+	return append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(redirectPath), Target: redirectPath, Detail: "→ mayor/rig/.beads"})
+}
+
 // hasBeadsData checks if a beads directory has actual data (issues.db, config.yaml)
 // as opposed to just being a redirect-only directory.
 func hasBeadsData(beadsDir string) bool {
@@ -1210,6 +1300,18 @@ func (c *BareRepoRefspecCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix reports the bare repo whose fetch refspec Fix would set.
+func (c *BareRepoRefspecCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if ctx.RigName == "" {
+		return nil
+	}
+	bareRepoPath := filepath.Join(ctx.RigPath(), ".repo.git")
+	if _, err := os.Stat(bareRepoPath); os.IsNotExist(err) {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeGit, Action: "update", Target: bareRepoPath, Detail: "remote.origin.fetch=+refs/heads/*:refs/remotes/origin/*"}}
+}
+
 // DefaultBranchExistsCheck verifies that the configured default_branch exists
 // as a remote tracking ref in the bare repo.
 type DefaultBranchExistsCheck struct {
@@ -1801,6 +1903,31 @@ func (c *BareRepoExistsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the bare repo and worktree registrations Fix would repair.
+func (c *BareRepoExistsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if ctx.RigName == "" {
+		return nil
+	}
+	rigPath := ctx.RigPath()
+	bareRepoPath := filepath.Join(rigPath, ".repo.git")
+	_, statErr := os.Stat(bareRepoPath)
+
+	var changes []PlannedChange
+	if c.pushURLMismatch && statErr == nil {
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "update", Target: bareRepoPath, Detail: "push URL from config.json"})
+	}
+	if len(c.brokenWorktrees) == 0 {
+		return changes
+	}
+	if statErr != nil {
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "create", Target: bareRepoPath, Detail: "git clone --bare from git_url"})
+	}
+	for _, relPath := range c.brokenWorktrees {
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "update", Target: filepath.Join(rigPath, relPath), Detail: "re-register worktree in .repo.git"})
+	}
+	return changes
+}
+
 // findWorktreeDirs returns paths to directories that may be git worktrees within a rig.
 // Checks refinery/rig and all polecat worktree directories.
 func (c *BareRepoExistsCheck) findWorktreeDirs(rigPath, rigName string) []string {
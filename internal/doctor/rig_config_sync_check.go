@@ -55,6 +55,7 @@ func NewRigConfigSyncCheck() *RigConfigSyncCheck {
 				CheckName:        "rig-config-sync",
 				CheckDescription: "Verify registered rigs have config.json, Dolt DB, and identity beads",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -446,6 +447,54 @@ func (c *RigConfigSyncCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the config files, databases, and rig beads Fix would create
+// or rename. Prefix mismatches are reported by Run but not changed by Fix.
+func (c *RigConfigSyncCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(ctx.TownRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil
+	}
+	hasPrefix := func(rigName string) (string, bool) {
+		entry, ok := rigsConfig.Rigs[rigName]
+		if !ok || entry.BeadsConfig == nil {
+			return "", false
+		}
+		return entry.BeadsConfig.Prefix, true
+	}
+
+	var changes []PlannedChange
+	for _, rigName := range c.missingConfig {
+		if _, ok := rigsConfig.Rigs[rigName]; ok {
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: filepath.Join(ctx.TownRoot, rigName, "config.json")})
+		}
+	}
+	for _, rigName := range c.missingPrefixCfg {
+		configYamlPath := filepath.Join(ctx.TownRoot, rigName, "mayor", "rig", ".beads", "config.yaml")
+		if prefix, ok := hasPrefix(rigName); ok && fileExists(configYamlPath) {
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "update", Target: configYamlPath, Detail: "issue-prefix: " + prefix})
+		}
+	}
+	for _, rigName := range c.missingDoltDB {
+		if prefix, ok := hasPrefix(rigName); ok {
+			changes = append(changes, PlannedChange{Kind: ChangeDatabase, Action: "create", Target: prefix, Detail: "bd init --force in " + rigName})
+		}
+	}
+	for _, m := range c.dbNameMismatches {
+		metadataPath := filepath.Join(ctx.TownRoot, m.rigName, "mayor", "rig", ".beads", "metadata.json")
+		changes = append(changes,
+			PlannedChange{Kind: ChangeFile, Action: "update", Target: metadataPath, Detail: "dolt_database: " + m.expectedDB},
+			PlannedChange{Kind: ChangeDatabase, Action: "update", Target: m.currentDB, Detail: "rename to " + m.expectedDB},
+		)
+	}
+	if len(c.dbNameMismatches) > 0 {
+		changes = append(changes, PlannedChange{Kind: ChangeProcess, Action: "restart", Target: "dolt server", Detail: "if running and stable"})
+	}
+	for _, info := range c.missingRigBeads {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "create", Target: fmt.Sprintf("%s-rig-%s", info.prefix, info.rigName), Detail: "rig identity"})
+	}
+	return changes
+}
+
 // doltDatabaseExists checks if a Dolt database exists on the server.
 func (c *RigConfigSyncCheck) doltDatabaseExists(ctx *CheckContext, dbName string) bool {
 	// Use the doltserver package to list databases
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // RigNameMismatchCheck detects when a rig's config.json has a name or beads
@@ -166,3 +167,30 @@ func (c *RigNameMismatchCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix reports the rig config.json fields Fix would rewrite.
+func (c *RigNameMismatchCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if ctx.RigName == "" {
+		return nil
+	}
+	rigPath := ctx.RigPath()
+	cfg, err := loadRigConfigLocal(rigPath)
+	if err != nil {
+		return nil
+	}
+
+	var details []string
+	if cfg.Name != ctx.RigName {
+		details = append(details, "name: "+ctx.RigName)
+	}
+	rigsConfig, rigsErr := loadRigsConfig(filepath.Join(ctx.TownRoot, "mayor", "rigs.json"))
+	if rigsErr == nil && cfg.Beads != nil && cfg.Beads.Prefix != "" {
+		if entry, ok := rigsConfig.Rigs[ctx.RigName]; ok && entry.BeadsConfig != nil && entry.BeadsConfig.Prefix != "" && cfg.Beads.Prefix != entry.BeadsConfig.Prefix {
+			details = append(details, "prefix: "+entry.BeadsConfig.Prefix)
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeFile, Action: "update", Target: filepath.Join(rigPath, "config.json"), Detail: strings.Join(details, ", ")}}
+}
@@ -119,6 +119,15 @@ func (c *RigRoutesJSONLCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the rig-level routes.jsonl files Fix would delete.
+func (c *RigRoutesJSONLCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, info := range c.affectedRigs {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: info.routesPath})
+	}
+	return changes
+}
+
 // findRigDirectories finds all rig directories in the town.
 func (c *RigRoutesJSONLCheck) findRigDirectories(townRoot string) []string {
 	var rigDirs []string
@@ -68,6 +68,15 @@ func (c *RigsJSONCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix reports the canonical rigs.json Fix would restore from the
+// town-root fallback copy.
+func (c *RigsJSONCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if !fileExists(c.fallbackPath) {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeFile, Action: fileAction(c.canonicalPath), Target: c.canonicalPath, Detail: "copied from " + c.fallbackPath}}
+}
+
 // Run checks that rigs.json exists at the canonical or fallback location.
 func (c *RigsJSONCheck) Run(ctx *CheckContext) *CheckResult {
 	c.townRoot = ctx.TownRoot
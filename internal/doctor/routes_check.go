@@ -2,8 +2,10 @@ package doctor
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/beads"
@@ -309,6 +311,34 @@ func (c *RoutesCheck) Fix(ctx *CheckContext) error {
 		return fmt.Errorf(".beads directory does not exist; run 'bd init' first")
 	}
 
+	routes, changed := c.fixedRoutes(ctx, os.Stderr)
+	if len(changed) > 0 {
+		return beads.WriteRoutes(beadsDir, routes)
+	}
+
+	return nil
+}
+
+// PlanFix reports the routes.jsonl entries Fix would add or rewrite.
+func (c *RoutesCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	beadsDir := filepath.Join(ctx.TownRoot, ".beads")
+	if _, err := os.Stat(beadsDir); os.IsNotExist(err) {
+		return nil
+	}
+	_, changed := c.fixedRoutes(ctx, io.Discard)
+	if len(changed) == 0 {
+		return nil
+	}
+	routesPath := filepath.Join(beadsDir, beads.RoutesFileName)
+	return []PlannedChange{{Kind: ChangeFile, Action: fileAction(routesPath), Target: routesPath, Detail: strings.Join(changed, ", ")}}
+}
+
+// fixedRoutes returns the town routes with missing entries added and
+// redirect-dependent ones rewritten, plus a description of each change.
+// Routes that can't be fixed automatically are reported to warn.
+func (c *RoutesCheck) fixedRoutes(ctx *CheckContext, warn io.Writer) ([]beads.Route, []string) {
+	beadsDir := filepath.Join(ctx.TownRoot, ".beads")
+
 	// Load existing routes
 	routes, err := beads.LoadRoutes(beadsDir)
 	if err != nil {
@@ -325,11 +355,11 @@ func (c *RoutesCheck) Fix(ctx *CheckContext) error {
 
 	// Ensure town root route exists (hq- -> .)
 	// This is normally created by gt install but may be missing if routes.jsonl was corrupted
-	modified := false
+	var changed []string
 	if _, exists := routeMap["hq-"]; !exists {
 		routeMap["hq-"] = len(routes)
 		routes = append(routes, beads.Route{Prefix: "hq-", Path: "."})
-		modified = true
+		changed = append(changed, "add hq- → .")
 	}
 
 	// Ensure convoy route exists (hq-cv- -> .)
@@ -337,18 +367,15 @@ func (c *RoutesCheck) Fix(ctx *CheckContext) error {
 	if _, exists := routeMap["hq-cv-"]; !exists {
 		routeMap["hq-cv-"] = len(routes)
 		routes = append(routes, beads.Route{Prefix: "hq-cv-", Path: "."})
-		modified = true
+		changed = append(changed, "add hq-cv- → .")
 	}
 
 	// Load rigs registry
 	rigsPath := filepath.Join(ctx.TownRoot, "mayor", "rigs.json")
 	rigsConfig, err := config.LoadRigsConfig(rigsPath)
 	if err != nil {
-		// No rigs config - just write town root route if we added it
-		if modified {
-			return beads.WriteRoutes(beadsDir, routes)
-		}
-		return nil
+		// No rigs config - just the town root routes
+		return routes, changed
 	}
 
 	// Collect prefixes from rigs to detect duplicates (finding #5).
@@ -366,7 +393,13 @@ func (c *RoutesCheck) Fix(ctx *CheckContext) error {
 	// the specific legacy pattern broken by beads#1749. Routes are rewritten
 	// to the canonical path (e.g., "crom/mayor/rig") which has a real .beads
 	// directory and needs no redirect resolution.
-	for rigName, rigEntry := range rigsConfig.Rigs {
+	rigNames := make([]string, 0, len(rigsConfig.Rigs))
+	for rigName := range rigsConfig.Rigs {
+		rigNames = append(rigNames, rigName)
+	}
+	sort.Strings(rigNames)
+	for _, rigName := range rigNames {
+		rigEntry := rigsConfig.Rigs[rigName]
 		prefix := ""
 		if rigEntry.BeadsConfig != nil && rigEntry.BeadsConfig.Prefix != "" {
 			prefix = rigEntry.BeadsConfig.Prefix + "-"
@@ -378,7 +411,7 @@ func (c *RoutesCheck) Fix(ctx *CheckContext) error {
 
 		// Skip duplicate prefixes to avoid non-deterministic rewrites
 		if prefixCount[prefix] > 1 {
-			fmt.Fprintf(os.Stderr, "Warning: skipping route fix for duplicate prefix %s (%d rigs share it)\n",
+			_, _ = fmt.Fprintf(warn, "Warning: skipping route fix for duplicate prefix %s (%d rigs share it)\n",
 				prefix, prefixCount[prefix])
 			continue
 		}
@@ -392,10 +425,10 @@ func (c *RoutesCheck) Fix(ctx *CheckContext) error {
 			// and canonical target has a real .beads directory (not a redirect).
 			if routes[idx].Path != rigRoutePath && isRedirectDependent(ctx.TownRoot, routes[idx].Path) {
 				if hasRealBeadsDir(canonicalPath) {
+					changed = append(changed, fmt.Sprintf("rewrite %s %s → %s", prefix, routes[idx].Path, rigRoutePath))
 					routes[idx].Path = rigRoutePath
-					modified = true
 				} else {
-					fmt.Fprintf(os.Stderr, "Warning: cannot rewrite route %s -> %s to %s (canonical path has no .beads directory)\n",
+					_, _ = fmt.Fprintf(warn, "Warning: cannot rewrite route %s -> %s to %s (canonical path has no .beads directory)\n",
 						prefix, routes[idx].Path, rigRoutePath)
 				}
 			}
@@ -407,14 +440,10 @@ func (c *RoutesCheck) Fix(ctx *CheckContext) error {
 					Prefix: prefix,
 					Path:   rigRoutePath,
 				})
-				modified = true
+				changed = append(changed, fmt.Sprintf("add %s → %s", prefix, rigRoutePath))
 			}
 		}
 	}
 
-	if modified {
-		return beads.WriteRoutes(beadsDir, routes)
-	}
-
-	return nil
+	return routes, changed
 }
@@ -26,6 +26,7 @@ func NewRoutingModeCheck() *RoutingModeCheck {
 				CheckName:        "routing-mode",
 				CheckDescription: "Check beads routing.mode is explicit (prevents .beads-planning routing)",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -133,6 +134,15 @@ func (c *RoutingModeCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix reports the beads configs whose routing.mode Fix would set.
+func (c *RoutingModeCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := []PlannedChange{{Kind: ChangeDatabase, Action: "update", Target: filepath.Join(ctx.TownRoot, ".beads"), Detail: "routing.mode = explicit"}}
+	if ctx.RigName != "" {
+		changes = append(changes, PlannedChange{Kind: ChangeDatabase, Action: "update", Target: filepath.Join(ctx.RigPath(), ".beads"), Detail: "routing.mode = explicit"})
+	}
+	return changes
+}
+
 // setRoutingMode sets routing.mode to "explicit" in the specified beads directory.
 func (c *RoutingModeCheck) setRoutingMode(beadsDir string) error {
 	cmd := exec.Command("bd", "config", "set", "routing.mode", "explicit")
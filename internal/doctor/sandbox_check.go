@@ -0,0 +1,89 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/sandbox"
+)
+
+// verifySandbox launches a probe under a rig's sandbox profile. Tests
+// replace it to avoid creating namespaces.
+var verifySandbox = sandbox.Verify
+
+// SandboxCheck verifies that every rig selecting a sandbox profile has valid
+// sandbox settings and that the profile is actually enforced, by launching a
+// probe under it exactly as a polecat would be launched.
+type SandboxCheck struct {
+	BaseCheck
+}
+
+// NewSandboxCheck creates a new sandbox enforcement check.
+func NewSandboxCheck() *SandboxCheck {
+	return &SandboxCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "sandbox-enforced",
+			CheckDescription: "Verify polecat sandbox profiles are enforced",
+			CheckCategory:    CategoryRig,
+		},
+	}
+}
+
+// Run validates and probes each sandboxed rig.
+func (c *SandboxCheck) Run(ctx *CheckContext) *CheckResult {
+	rigPaths := findAllRigs(ctx.TownRoot)
+	if ctx.RigName != "" {
+		rigPaths = []string{ctx.RigPath()}
+	}
+
+	var problems []string
+	checked := 0
+	for _, rigPath := range rigPaths {
+		rig := filepath.Base(rigPath)
+		name, _, err := config.ResolveRigSandbox(rigPath)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", rig, err))
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		checked++
+		report, err := verifySandbox(context.Background(), ctx.TownRoot, rig)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s (profile %s): %v", rig, name, err))
+			continue
+		}
+		for _, f := range report.Failures() {
+			detail := fmt.Sprintf("%s (profile %s): %s", rig, name, f.Name)
+			if f.Detail != "" {
+				detail += ": " + f.Detail
+			}
+			problems = append(problems, detail)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("%d sandbox problem(s)", len(problems)),
+			Details: problems,
+			FixHint: "Run 'gt sandbox verify <rig>' for the full report",
+		}
+	}
+	if checked == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No rigs use a sandbox profile",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("Sandbox profiles enforced in %d rig(s)", checked),
+	}
+}
@@ -0,0 +1,86 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/sandbox"
+)
+
+// writeSandboxRig creates a rig with the given settings/config.json body.
+func writeSandboxRig(t *testing.T, townRoot, rig, settings string) {
+	t.Helper()
+	rigPath := filepath.Join(townRoot, rig)
+	if err := os.MkdirAll(filepath.Join(rigPath, "polecats"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if settings == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Join(rigPath, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rigPath, "settings", "config.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func stubVerifySandbox(t *testing.T, fn func(ctx context.Context, townRoot, rig string) (*sandbox.Report, error)) {
+	t.Helper()
+	orig := verifySandbox
+	verifySandbox = fn
+	t.Cleanup(func() { verifySandbox = orig })
+}
+
+func TestSandboxCheck_NoSandboxedRigs(t *testing.T) {
+	townRoot := t.TempDir()
+	writeSandboxRig(t, townRoot, "plain", "")
+	stubVerifySandbox(t, func(context.Context, string, string) (*sandbox.Report, error) {
+		t.Fatal("verify should not run for unsandboxed rigs")
+		return nil, nil
+	})
+
+	result := NewSandboxCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("status = %v, want OK: %s", result.Status, result.Message)
+	}
+}
+
+func TestSandboxCheck_ReportsFailuresAndInvalidSettings(t *testing.T) {
+	townRoot := t.TempDir()
+	writeSandboxRig(t, townRoot, "good", `{"type":"rig-settings","version":1,"sandbox":{"profile":"standard"}}`)
+	writeSandboxRig(t, townRoot, "leaky", `{"type":"rig-settings","version":1,"sandbox":{"profile":"standard"}}`)
+	writeSandboxRig(t, townRoot, "broken", `{"type":"rig-settings","version":1,"sandbox":{"profile":"missing"}}`)
+	writeSandboxRig(t, townRoot, "down", `{"type":"rig-settings","version":1,"sandbox":{"profile":"standard"}}`)
+
+	stubVerifySandbox(t, func(_ context.Context, _ string, rig string) (*sandbox.Report, error) {
+		switch rig {
+		case "leaky":
+			return &sandbox.Report{Profile: "standard", Checks: []sandbox.Check{
+				{Name: "worktree writable", OK: true},
+				{Name: "direct internet blocked", OK: false, Detail: "connected to 1.1.1.1:443"},
+			}}, nil
+		case "down":
+			return nil, errors.New("sandbox did not start: proxy unreachable")
+		}
+		return &sandbox.Report{Profile: "standard", Checks: []sandbox.Check{{Name: "worktree writable", OK: true}}}, nil
+	})
+
+	result := NewSandboxCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusError {
+		t.Fatalf("status = %v, want Error", result.Status)
+	}
+	details := strings.Join(result.Details, "\n")
+	for _, want := range []string{"leaky (profile standard): direct internet blocked", "broken:", "down (profile standard): sandbox did not start"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("details missing %q:\n%s", want, details)
+		}
+	}
+	if strings.Contains(details, "good") {
+		t.Errorf("details mention passing rig:\n%s", details)
+	}
+}
@@ -176,6 +176,19 @@ func (c *MalformedSessionNameCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the sessions Fix would rename. Crew sessions are skipped,
+// as are renames whose target name is already taken when Fix runs.
+func (c *MalformedSessionNameCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, r := range c.malformed {
+		if r.isCrew {
+			continue
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeSession, Action: "rename", Target: r.oldName, Detail: "→ " + r.newName})
+	}
+	return changes
+}
+
 // knownRoleSuffixes are the simple role keywords that appear at the end of a
 // Gas Town session name (after the rig prefix).
 var knownRoleSuffixes = []string{"witness", "refinery"}
@@ -177,3 +177,12 @@ func (c *SparseCheckoutCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the repos whose sparse checkout Fix would disable.
+func (c *SparseCheckoutCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, repoPath := range c.affectedRepos {
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "update", Target: repoPath, Detail: "disable sparse checkout"})
+	}
+	return changes
+}
@@ -34,6 +34,7 @@ func NewStaleAgentBeadsCheck() *StaleAgentBeadsCheck {
 				CheckName:        "stale-agent-beads",
 				CheckDescription: "Detect agent beads for removed workers (crew and polecats)",
 				CheckCategory:    CategoryRig,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 	}
@@ -323,3 +324,17 @@ func (c *StaleAgentBeadsCheck) Fix(ctx *CheckContext) error {
 
 	return errors.Join(errs...)
 }
+
+// PlanFix lists the stale agent beads Fix would close. Like Fix, it
+// re-runs detection since the stale list is not cached.
+func (c *StaleAgentBeadsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	result := c.Run(ctx)
+	if result.Status == StatusOK {
+		return nil
+	}
+	changes := make([]PlannedChange, 0, len(result.Details))
+	for _, beadID := range result.Details {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "close", Target: beadID})
+	}
+	return changes
+}
@@ -180,6 +180,24 @@ func (c *StaleBeadsRedirectCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the stale beads files Fix would remove and the redirect
+// files it would write.
+func (c *StaleBeadsRedirectCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, relPath := range c.staleLocations {
+		for _, path := range staleBeadsFiles(filepath.Join(ctx.TownRoot, relPath)) {
+			changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: path, Detail: "stale, redirect in place"})
+		}
+	}
+	for _, issue := range c.missingRedirects {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create", Target: filepath.Join(issue.worktreePath, ".beads", "redirect")})
+	}
+	for _, issue := range c.incorrectRedirects {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "update", Target: filepath.Join(issue.worktreePath, ".beads", "redirect")})
+	}
+	return changes
+}
+
 // findRigDirs returns all rig directories in the town.
 func findRigDirs(townRoot string) ([]string, error) {
 	var rigs []string
@@ -310,12 +328,24 @@ func cleanStaleBeadsFiles(beadsDir string) error {
 		return fmt.Errorf("no redirect file found - refusing to clean")
 	}
 
+	for _, path := range staleBeadsFiles(beadsDir) {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("removing %s: %w", filepath.Base(path), err)
+		}
+	}
+
+	return nil
+}
+
+// staleBeadsFiles returns the stale files and directories in a redirected
+// beads directory that cleanStaleBeadsFiles removes.
+func staleBeadsFiles(beadsDir string) []string {
 	// Check if metadata.json declares a dolt_database — if so, preserve it.
 	// Removing it would disconnect the rig from its database and make the
 	// prefix-named DB appear orphaned. (gt-85w7)
 	preserveMetadata := metadataHasDoltDB(beadsDir)
 
-	// Remove files matching stale patterns
+	var paths []string
 	for _, pattern := range staleFilePatterns {
 		matches, err := filepath.Glob(filepath.Join(beadsDir, pattern))
 		if err != nil {
@@ -325,21 +355,16 @@ func cleanStaleBeadsFiles(beadsDir string) error {
 			if preserveMetadata && filepath.Base(match) == "metadata.json" {
 				continue
 			}
-			if err := os.RemoveAll(match); err != nil {
-				return fmt.Errorf("removing %s: %w", filepath.Base(match), err)
-			}
+			paths = append(paths, match)
 		}
 	}
 
-	// Also remove mq directory if it exists
+	// Also the mq directory if it exists
 	mqDir := filepath.Join(beadsDir, "mq")
 	if _, err := os.Stat(mqDir); err == nil {
-		if err := os.RemoveAll(mqDir); err != nil {
-			return fmt.Errorf("removing mq: %w", err)
-		}
+		paths = append(paths, mqDir)
 	}
-
-	return nil
+	return paths
 }
 
 // metadataHasDoltDB checks if a .beads/metadata.json declares a dolt_database.
@@ -390,7 +415,7 @@ func (c *StaleBeadsRedirectCheck) verifyRedirectTopology(ctx *CheckContext, rigD
 			// Other errors may indicate real problems - log them in verbose mode.
 			if ctx.Verbose && !strings.Contains(err.Error(), "no beads found") {
 				relPath, _ := filepath.Rel(townRoot, worktreePath)
-				fmt.Printf("  [verbose] skipping %s: %v\n", relPath, err)
+				fmt.Fprintf(ctx.Output(), "  [verbose] skipping %s: %v\n", relPath, err)
 			}
 			continue
 		}
@@ -141,6 +141,28 @@ func (c *StaleDoltPortCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the port files Fix would remove and metadata it would rewrite.
+func (c *StaleDoltPortCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, info := range c.stalePorts {
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeFile,
+			Action: "delete",
+			Target: info.path,
+			Detail: fmt.Sprintf("stale port %d, server on %d", info.port, info.correctPort),
+		})
+	}
+	for _, info := range c.staleMetadata {
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeFile,
+			Action: "update",
+			Target: info.path,
+			Detail: fmt.Sprintf("dolt_server_port %d -> %d", info.port, info.correctPort),
+		})
+	}
+	return changes
+}
+
 // getCorrectPort returns the port from the main Dolt server config.
 func (c *StaleDoltPortCheck) getCorrectPort(ctx *CheckContext) int {
 	// Check the main Dolt server config
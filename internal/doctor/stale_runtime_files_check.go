@@ -161,6 +161,18 @@ func (c *StaleRuntimeFilesCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the stale PID files and wisp configs Fix would remove.
+func (c *StaleRuntimeFilesCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, path := range c.stalePIDFiles {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: path, Detail: "dead PID"})
+	}
+	for _, path := range c.staleWispConfigs {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: path, Detail: "stale wisp config"})
+	}
+	return changes
+}
+
 // extractRigPrefix extracts the rig prefix from a PID filename.
 // Examples: sw-witness.pid -> sw, pir-crew-dickle.pid -> pir, hq-deacon.pid -> hq
 func extractRigPrefix(filename string) string {
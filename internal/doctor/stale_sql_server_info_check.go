@@ -110,6 +110,15 @@ func (c *StaleSQLServerInfoCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the sql-server.info files Fix would remove.
+func (c *StaleSQLServerInfoCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	changes := make([]PlannedChange, 0, len(c.staleFiles))
+	for _, path := range c.staleFiles {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: path})
+	}
+	return changes
+}
+
 // isStale checks if the sql-server.info file references a dead process.
 // The file format is "PID:port:UUID" (one line).
 func (c *StaleSQLServerInfoCheck) isStale(path string) bool {
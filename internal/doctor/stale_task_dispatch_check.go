@@ -174,3 +174,13 @@ func (c *StaleTaskDispatchCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the settings files Fix would rewrite without the
+// task-dispatch guard.
+func (c *StaleTaskDispatchCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, target := range c.staleTargets {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: fileAction(target.Path), Target: target.Path, Detail: "drop task-dispatch guard"})
+	}
+	return changes
+}
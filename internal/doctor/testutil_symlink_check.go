@@ -215,3 +215,14 @@ func (c *TestutilSymlinkCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix lists the testutil paths Fix would replace with symlinks to the
+// canonical copy.
+func (c *TestutilSymlinkCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	canonical := canonicalTestutilPath(ctx.RigPath())
+	var changes []PlannedChange
+	for _, issue := range c.issues {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "update", Target: issue.path, Detail: "symlink → " + canonical})
+	}
+	return changes
+}
@@ -12,6 +12,7 @@ import (
 // ThemeCheck verifies tmux sessions have correct themes applied.
 type ThemeCheck struct {
 	FixableCheck
+	sessions []string // Gas Town sessions found by Run, cached for PlanFix
 }
 
 // NewThemeCheck creates a new theme check.
@@ -30,6 +31,7 @@ func NewThemeCheck() *ThemeCheck {
 // Run checks if tmux sessions have themes applied correctly.
 func (c *ThemeCheck) Run(ctx *CheckContext) *CheckResult {
 	t := tmux.NewTmux()
+	c.sessions = nil
 
 	// List all sessions
 	sessions, err := t.ListSessions()
@@ -50,6 +52,7 @@ func (c *ThemeCheck) Run(ctx *CheckContext) *CheckResult {
 		}
 	}
 
+	c.sessions = gtSessions
 	if len(gtSessions) == 0 {
 		return &CheckResult{
 			Name:    c.Name(),
@@ -99,6 +102,16 @@ func (c *ThemeCheck) Fix(ctx *CheckContext) error {
 	return cmd.Run()
 }
 
+// PlanFix lists the sessions Fix would re-theme. gt theme apply --all
+// touches every Gas Town session, not just the outdated ones.
+func (c *ThemeCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, s := range c.sessions {
+		changes = append(changes, PlannedChange{Kind: ChangeSession, Action: "update", Target: s, Detail: "tmux theme"})
+	}
+	return changes
+}
+
 // getSessionStatusLeft retrieves the status-left setting for a tmux session.
 func getSessionStatusLeft(session string) (string, error) {
 	cmd := tmux.BuildCommand("show-options", "-t", session, "status-left")
@@ -131,6 +131,15 @@ func (c *LinkedPaneCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the sessions with linked panes that Fix would kill.
+func (c *LinkedPaneCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, session := range c.linkedSessions {
+		changes = append(changes, PlannedChange{Kind: ChangeSession, Action: "kill", Target: session, Detail: "linked panes"})
+	}
+	return changes
+}
+
 // getSessionPanes returns all pane IDs for a session.
 func (c *LinkedPaneCheck) getSessionPanes(session string) ([]string, error) {
 	// Get pane IDs using tmux list-panes with format
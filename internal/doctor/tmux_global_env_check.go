@@ -99,3 +99,8 @@ func (c *TmuxGlobalEnvCheck) Fix(ctx *CheckContext) error {
 	}
 	return accessor.SetGlobalEnvironment("GT_TOWN_ROOT", ctx.TownRoot)
 }
+
+// PlanFix reports the tmux global environment variable Fix would set.
+func (c *TmuxGlobalEnvCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	return []PlannedChange{{Kind: ChangeSession, Action: "update", Target: "tmux global environment", Detail: "GT_TOWN_ROOT=" + ctx.TownRoot}}
+}
@@ -154,3 +154,13 @@ func (c *SocketSplitBrainCheck) Fix(ctx *CheckContext) error {
 
 	return lastErr
 }
+
+// PlanFix lists the duplicate sessions on the "default" socket Fix would
+// kill.
+func (c *SocketSplitBrainCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, s := range c.staleSessions {
+		changes = append(changes, PlannedChange{Kind: ChangeSession, Action: "kill", Target: s, Detail: `on "default" socket`})
+	}
+	return changes
+}
@@ -85,3 +85,16 @@ func (c *TownBeadsConfigCheck) Fix(ctx *CheckContext) error {
 	beadsDir := filepath.Join(ctx.TownRoot, ".beads")
 	return beads.EnsureConfigYAMLFromMetadataIfMissing(beadsDir, "hq")
 }
+
+// PlanFix reports the config.yaml that Fix would create.
+func (c *TownBeadsConfigCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if !c.missingConfig {
+		return nil
+	}
+	return []PlannedChange{{
+		Kind:   ChangeFile,
+		Action: "create",
+		Target: filepath.Join(ctx.TownRoot, ".beads", "config.yaml"),
+		Detail: "prefix hq",
+	}}
+}
@@ -147,6 +147,27 @@ func (c *TownCLAUDEmdCheck) Fix(ctx *CheckContext) error {
 	return os.WriteFile(claudePath, []byte(updated), 0644)
 }
 
+// PlanFix reports whether Fix would create CLAUDE.md or append sections to it.
+func (c *TownCLAUDEmdCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	claudePath := filepath.Join(ctx.TownRoot, "CLAUDE.md")
+	if c.fileMissing {
+		return []PlannedChange{{Kind: ChangeFile, Action: "create", Target: claudePath, Detail: "from embedded template"}}
+	}
+	if len(c.missingSections) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(c.missingSections))
+	for _, s := range c.missingSections {
+		names = append(names, s.Name)
+	}
+	return []PlannedChange{{
+		Kind:   ChangeFile,
+		Action: "update",
+		Target: claudePath,
+		Detail: "append sections: " + strings.Join(names, ", "),
+	}}
+}
+
 // h2Section represents a section of markdown delimited by H2 headings.
 type h2Section struct {
 	heading string // The H2 heading line (e.g., "## Dolt Server — Operational Awareness")
@@ -116,3 +116,11 @@ func (c *TownRootBranchCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix reports the checkout Fix would make in the town root.
+func (c *TownRootBranchCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if c.currentBranch == "main" || c.currentBranch == "master" {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeGit, Action: "checkout", Target: ctx.TownRoot, Detail: "main (or master), from " + c.currentBranch}}
+}
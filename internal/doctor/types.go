@@ -4,6 +4,7 @@ package doctor
 import (
 	"fmt"
 	"io"
+	"os"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/ui"
@@ -59,11 +60,21 @@ func (s CheckStatus) String() string {
 
 // CheckContext provides context for running checks.
 type CheckContext struct {
-	TownRoot        string // Root directory of the Gas Town workspace
-	RigName         string // Rig name (empty for town-level checks)
-	Verbose         bool   // Enable verbose output
-	RestartSessions bool   // Restart patrol sessions when fixing (requires explicit --restart-sessions flag)
-	NoStart         bool   // Suppress starting daemon/agents during --fix
+	TownRoot        string    // Root directory of the Gas Town workspace
+	RigName         string    // Rig name (empty for town-level checks)
+	Verbose         bool      // Enable verbose output
+	RestartSessions bool      // Restart patrol sessions when fixing (requires explicit --restart-sessions flag)
+	NoStart         bool      // Suppress starting daemon/agents during --fix
+	Out             io.Writer // Where fixes report what they did (nil = stdout)
+}
+
+// Output returns the writer fixes should report progress to.
+// Machine-readable formats point it at stderr to keep stdout parseable.
+func (ctx *CheckContext) Output() io.Writer {
+	if ctx.Out == nil {
+		return os.Stdout
+	}
+	return ctx.Out
 }
 
 // RigPath returns the full path to the rig directory.
@@ -78,6 +89,19 @@ func (ctx *CheckContext) RigPath() string {
 // DefaultSlowThreshold is the default duration above which a check is considered slow.
 const DefaultSlowThreshold = 1 * time.Second
 
+// DefaultCheckTimeout bounds how long a single check may run before it is
+// reported as failed. Checks can override it via BaseCheck.CheckTimeout.
+const DefaultCheckTimeout = 2 * time.Minute
+
+// DefaultParallelism is the number of checks run concurrently by default.
+const DefaultParallelism = 4
+
+// Shared prerequisite lists for checks that shell out to dolt or bd.
+var (
+	doltDependencies  = []string{"dolt-binary"}
+	beadsDependencies = []string{"beads-binary", "dolt-binary", "dolt-server-reachable"}
+)
+
 // CheckResult represents the outcome of a health check.
 type CheckResult struct {
 	Name     string        // Check name
@@ -88,6 +112,7 @@ type CheckResult struct {
 	Category string        // Category for grouping (e.g., CategoryCore)
 	Elapsed  time.Duration // How long the check took to run
 	Fixed    bool          // True if this check was auto-fixed
+	Skipped  bool          // True if the check did not run because a dependency failed
 }
 
 // Check defines the interface for a health check.
@@ -109,6 +134,19 @@ type Check interface {
 	CanFix() bool
 }
 
+// dependencyDeclarer is implemented by checks that must run after others.
+// A check is skipped if any of its dependencies fails or is skipped.
+// Dependencies that are not registered are ignored.
+type dependencyDeclarer interface {
+	DependsOn() []string
+}
+
+// timeoutDeclarer is implemented by checks that need a non-default timeout.
+// A zero return value means "use the doctor's default".
+type timeoutDeclarer interface {
+	Timeout() time.Duration
+}
+
 // ReportSummary summarizes the results of all checks.
 type ReportSummary struct {
 	Total       int
@@ -116,6 +154,7 @@ type ReportSummary struct {
 	Warnings    int
 	Errors      int
 	Fixed       int           // Checks that were auto-fixed
+	Skipped     int           // Checks skipped because a dependency failed
 	Slow        int           // Checks that took longer than threshold (counted during Print)
 	SlowestName string        // Name of the slowest check
 	SlowestTime time.Duration // Duration of the slowest check
@@ -141,6 +180,11 @@ func (r *Report) Add(result *CheckResult) {
 	r.Checks = append(r.Checks, result)
 	r.Summary.Total++
 
+	if result.Skipped {
+		r.Summary.Skipped++
+		return
+	}
+
 	switch result.Status {
 	case StatusOK:
 		r.Summary.OK++
@@ -271,15 +315,7 @@ func (r *Report) Print(w io.Writer, verbose bool, slowThreshold time.Duration) {
 
 // printCheck outputs a single check result with semantic styling.
 func (r *Report) printCheck(w io.Writer, check *CheckResult, verbose bool, slowThreshold time.Duration) {
-	var statusIcon string
-	switch check.Status {
-	case StatusOK:
-		statusIcon = ui.RenderPassIcon()
-	case StatusWarning:
-		statusIcon = ui.RenderWarnIcon()
-	case StatusError:
-		statusIcon = ui.RenderFailIcon()
-	}
+	statusIcon := resultIcon(check)
 
 	// Add hourglass for slow checks (only when --slow is enabled)
 	isSlow := slowThreshold > 0 && check.Elapsed >= slowThreshold
@@ -310,6 +346,22 @@ func (r *Report) printCheck(w io.Writer, check *CheckResult, verbose bool, slowT
 	}
 }
 
+// resultIcon returns the status icon for a check result.
+func resultIcon(result *CheckResult) string {
+	if result.Skipped {
+		return ui.RenderSkipIcon()
+	}
+	switch result.Status {
+	case StatusOK:
+		return ui.RenderPassIcon()
+	case StatusWarning:
+		return ui.RenderWarnIcon()
+	case StatusError:
+		return ui.RenderFailIcon()
+	}
+	return ""
+}
+
 // formatDuration formats a duration in a human-readable way.
 // Examples: "1.2s", "45s", "1m 30s", "2h 5m"
 func formatDuration(d time.Duration) string {
@@ -342,6 +394,9 @@ func (r *Report) printSummary(w io.Writer, slowThreshold time.Duration) {
 	if r.Summary.Fixed > 0 {
 		summary += fmt.Sprintf("  🔧 %d fixed", r.Summary.Fixed)
 	}
+	if r.Summary.Skipped > 0 {
+		summary += fmt.Sprintf("  %s %d skipped", ui.RenderSkipIcon(), r.Summary.Skipped)
+	}
 	if slowThreshold > 0 && r.Summary.Slow > 0 {
 		summary += fmt.Sprintf("  ⏳ %d slow (slowest: %s %s)",
 			r.Summary.Slow,
@@ -357,6 +412,10 @@ func (r *Report) printWarningsSection(w io.Writer, issues []*CheckResult) {
 	// Separate into categories
 	var failures, warnings, fixed []*CheckResult
 	for _, check := range issues {
+		if check.Skipped {
+			// Explained by the failure of the check it depends on.
+			continue
+		}
 		if check.Fixed {
 			fixed = append(fixed, check)
 		} else if check.Status == StatusError {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"time"
 )
 
@@ -25,6 +26,7 @@ func NewWispGCCheck() *WispGCCheck {
 				CheckName:        "wisp-gc",
 				CheckDescription: "Detect and clean orphaned wisps (>1h old)",
 				CheckCategory:    CategoryCleanup,
+				CheckDependsOn:   beadsDependencies,
 			},
 		},
 		threshold:     1 * time.Hour,
@@ -143,3 +145,22 @@ func (c *WispGCCheck) Fix(ctx *CheckContext) error {
 
 	return lastErr
 }
+
+// PlanFix lists the rigs whose abandoned wisps Fix would garbage-collect.
+func (c *WispGCCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	rigs := make([]string, 0, len(c.abandonedRigs))
+	for rigName := range c.abandonedRigs {
+		rigs = append(rigs, rigName)
+	}
+	sort.Strings(rigs)
+	changes := make([]PlannedChange, 0, len(rigs))
+	for _, rigName := range rigs {
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeBead,
+			Action: "close",
+			Target: rigName + " wisps",
+			Detail: fmt.Sprintf("%d abandoned wisp(s) via bd mol wisp gc", c.abandonedRigs[rigName]),
+		})
+	}
+	return changes
+}
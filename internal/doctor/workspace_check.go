@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // TownConfigExistsCheck verifies mayor/town.json exists.
@@ -178,6 +179,12 @@ func (c *RigsRegistryExistsCheck) Fix(ctx *CheckContext) error {
 	return os.WriteFile(rigsPath, data, 0644)
 }
 
+// PlanFix reports the empty rigs.json Fix would write.
+func (c *RigsRegistryExistsCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	rigsPath := filepath.Join(ctx.TownRoot, "mayor", "rigs.json")
+	return []PlannedChange{{Kind: ChangeFile, Action: fileAction(rigsPath), Target: rigsPath, Detail: "empty registry"}}
+}
+
 // RigsRegistryValidCheck verifies mayor/rigs.json is valid and rigs exist.
 type RigsRegistryValidCheck struct {
 	FixableCheck
@@ -313,6 +320,15 @@ func (c *RigsRegistryValidCheck) Fix(ctx *CheckContext) error {
 	return os.WriteFile(rigsPath, newData, 0644)
 }
 
+// PlanFix reports the rigs.json entries Fix would remove.
+func (c *RigsRegistryValidCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	if len(c.missingRigs) == 0 {
+		return nil
+	}
+	rigsPath := filepath.Join(ctx.TownRoot, "mayor", "rigs.json")
+	return []PlannedChange{{Kind: ChangeFile, Action: "update", Target: rigsPath, Detail: "remove " + strings.Join(c.missingRigs, ", ")}}
+}
+
 // MayorExistsCheck verifies the mayor/ directory structure.
 type MayorExistsCheck struct {
 	BaseCheck
@@ -338,6 +338,26 @@ func (c *WorktreeGitdirCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the worktrees Fix would re-register with their bare repo.
+// Worktrees whose .repo.git is missing are left for 'gt rig install'.
+func (c *WorktreeGitdirCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, bw := range c.brokenWorktrees {
+		repoPath := bw.correctedBareRepo
+		if repoPath == "" {
+			repoPath = bw.bareRepoPath
+		}
+		if repoPath == "" {
+			continue
+		}
+		if _, err := os.Stat(repoPath); os.IsNotExist(err) {
+			continue
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "update", Target: bw.worktreePath, Detail: "re-register worktree in " + repoPath})
+	}
+	return changes
+}
+
 // fixOneWorktree repairs a single broken worktree.
 func (c *WorktreeGitdirCheck) fixOneWorktree(bw brokenWorktree, repoPath string) error {
 	// Remove the broken .git file
@@ -143,3 +143,21 @@ func (c *ZombieSessionCheck) Fix(ctx *CheckContext) error {
 
 	return lastErr
 }
+
+// PlanFix lists the zombie sessions Fix would kill. Crew sessions are never
+// killed, and Fix re-verifies each session just before killing it.
+func (c *ZombieSessionCheck) PlanFix(ctx *CheckContext) []PlannedChange {
+	var changes []PlannedChange
+	for _, sess := range c.zombieSessions {
+		if isCrewSession(sess) {
+			continue
+		}
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeSession,
+			Action: "kill",
+			Target: sess,
+			Detail: "agent not running; skipped if it has restarted",
+		})
+	}
+	return changes
+}
@@ -4,6 +4,10 @@
 // sentinel file (ESTOP) at the town root. When present, all agents should
 // be frozen (SIGTSTP) and the daemon should not restart them.
 //
+// E-stops can also be scoped to a single rig (ESTOP.<rig>), a role within a
+// rig (ESTOP.<rig>@<role>), or a role across every rig (ESTOP.@<role>), so
+// one misbehaving rig does not force freezing the whole town.
+//
 // The Mayor is exempt from E-stop so it can coordinate recovery.
 //
 // Original implementation by outdoorsea (PR #3237).
@@ -13,6 +17,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -31,6 +36,11 @@ type Info struct {
 	Trigger   string    // "manual" or "auto"
 	Reason    string    // human-readable reason
 	Timestamp time.Time // when the E-stop was triggered
+
+	// Rule and Evidence are set for auto-triggered E-stops: the daemon rule
+	// that tripped (e.g. "mass_death") and the observations that tripped it.
+	Rule     string
+	Evidence []string
 }
 
 // FilePath returns the full path to the ESTOP sentinel file.
@@ -55,9 +65,13 @@ func Read(townRoot string) *Info {
 
 // Activate creates the ESTOP sentinel file with the given trigger and reason.
 func Activate(townRoot, trigger, reason string) error {
-	ts := time.Now().Format(time.RFC3339)
-	content := fmt.Sprintf("%s\t%s\t%s\n", trigger, ts, reason)
-	return os.WriteFile(FilePath(townRoot), []byte(content), 0644)
+	return os.WriteFile(FilePath(townRoot), []byte(format(trigger, reason, "", nil)), 0644)
+}
+
+// ActivateAuto creates an auto-triggered ESTOP sentinel file recording the
+// rule that tripped and the evidence behind it.
+func ActivateAuto(townRoot, rule, reason string, evidence []string) error {
+	return os.WriteFile(FilePath(townRoot), []byte(format(TriggerAuto, reason, rule, evidence)), 0644)
 }
 
 // Deactivate removes the ESTOP sentinel file.
@@ -103,9 +117,12 @@ func ReadRig(townRoot, rigName string) *Info {
 
 // ActivateRig creates a per-rig ESTOP sentinel file.
 func ActivateRig(townRoot, rigName, trigger, reason string) error {
-	ts := time.Now().Format(time.RFC3339)
-	content := fmt.Sprintf("%s\t%s\t%s\n", trigger, ts, reason)
-	return os.WriteFile(RigFilePath(townRoot, rigName), []byte(content), 0644)
+	return os.WriteFile(RigFilePath(townRoot, rigName), []byte(format(trigger, reason, "", nil)), 0644)
+}
+
+// ActivateRigAuto creates an auto-triggered per-rig ESTOP sentinel file.
+func ActivateRigAuto(townRoot, rigName, rule, reason string, evidence []string) error {
+	return os.WriteFile(RigFilePath(townRoot, rigName), []byte(format(TriggerAuto, reason, rule, evidence)), 0644)
 }
 
 // DeactivateRig removes a per-rig ESTOP sentinel file.
@@ -117,19 +134,175 @@ func DeactivateRig(townRoot, rigName string) error {
 	return err
 }
 
+// ActiveRigs returns the names of rigs with a whole-rig E-stop, sorted.
+// Role-scoped E-stops are not included; see ActiveScopes.
+func ActiveRigs(townRoot string) []string {
+	entries, _ := filepath.Glob(filepath.Join(townRoot, FileName+".*"))
+	rigs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := strings.TrimPrefix(filepath.Base(entry), FileName+".")
+		if strings.Contains(name, "@") {
+			continue
+		}
+		rigs = append(rigs, name)
+	}
+	sort.Strings(rigs)
+	return rigs
+}
+
 // IsAnyActive checks if a town-wide or rig-specific E-stop affects this rig.
 func IsAnyActive(townRoot, rigName string) bool {
 	return IsActive(townRoot) || IsRigActive(townRoot, rigName)
 }
 
+// Scope identifies what an E-stop freezes. The zero Scope is town-wide; Rig
+// limits it to one rig and Role to one agent role (e.g. "polecat"), either
+// within Rig or, when Rig is empty, across every rig.
+type Scope struct {
+	Rig  string `json:"rig,omitempty"`
+	Role string `json:"role,omitempty"`
+}
+
+// IsTown reports whether s is the town-wide scope.
+func (s Scope) IsTown() bool {
+	return s.Rig == "" && s.Role == ""
+}
+
+// String returns "town", "<rig>", "<rig>/<role>" or "*/<role>".
+func (s Scope) String() string {
+	switch {
+	case s.IsTown():
+		return "town"
+	case s.Role == "":
+		return s.Rig
+	case s.Rig == "":
+		return "*/" + s.Role
+	default:
+		return s.Rig + "/" + s.Role
+	}
+}
+
+// ScopeFileName returns the sentinel file name for an E-stop scope.
+func ScopeFileName(s Scope) string {
+	switch {
+	case s.IsTown():
+		return FileName
+	case s.Role == "":
+		return RigFileName(s.Rig)
+	default:
+		return fmt.Sprintf("%s.%s@%s", FileName, s.Rig, s.Role)
+	}
+}
+
+// ScopeFilePath returns the full path to the sentinel file for an E-stop scope.
+func ScopeFilePath(townRoot string, s Scope) string {
+	return filepath.Join(townRoot, ScopeFileName(s))
+}
+
+// IsScopeActive checks whether an E-stop is active for exactly this scope.
+func IsScopeActive(townRoot string, s Scope) bool {
+	_, err := os.Stat(ScopeFilePath(townRoot, s))
+	return err == nil
+}
+
+// ReadScope reads and parses the ESTOP file for a scope. Returns nil if not active.
+func ReadScope(townRoot string, s Scope) *Info {
+	data, err := os.ReadFile(ScopeFilePath(townRoot, s))
+	if err != nil {
+		return nil
+	}
+	return parse(string(data))
+}
+
+// ActivateScope creates the ESTOP sentinel file for a scope.
+func ActivateScope(townRoot string, s Scope, trigger, reason string) error {
+	return os.WriteFile(ScopeFilePath(townRoot, s), []byte(format(trigger, reason, "", nil)), 0644)
+}
+
+// DeactivateScope removes the ESTOP sentinel file for a scope.
+func DeactivateScope(townRoot string, s Scope) error {
+	err := os.Remove(ScopeFilePath(townRoot, s))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// ActiveScopes returns every active E-stop scope, town-wide first and the
+// rest sorted by file name.
+func ActiveScopes(townRoot string) []Scope {
+	var scopes []Scope
+	if IsActive(townRoot) {
+		scopes = append(scopes, Scope{})
+	}
+	entries, _ := filepath.Glob(filepath.Join(townRoot, FileName+".*"))
+	sort.Strings(entries)
+	for _, entry := range entries {
+		name := strings.TrimPrefix(filepath.Base(entry), FileName+".")
+		rig, role, _ := strings.Cut(name, "@")
+		scopes = append(scopes, Scope{Rig: rig, Role: role})
+	}
+	return scopes
+}
+
+// StoppedBy returns the E-stop scope that freezes the given rig and role, or
+// false if none does. Either may be empty: an empty rig matches only
+// town-wide and role-wide E-stops, an empty role matches only town-wide and
+// whole-rig E-stops. Broader scopes are checked first.
+func StoppedBy(townRoot, rigName, role string) (Scope, bool) {
+	candidates := []Scope{{}}
+	if rigName != "" {
+		candidates = append(candidates, Scope{Rig: rigName})
+	}
+	if role != "" {
+		candidates = append(candidates, Scope{Role: role})
+		if rigName != "" {
+			candidates = append(candidates, Scope{Rig: rigName, Role: role})
+		}
+	}
+	for _, s := range candidates {
+		if IsScopeActive(townRoot, s) {
+			return s, true
+		}
+	}
+	return Scope{}, false
+}
+
+// IsStopped checks whether any active E-stop freezes the given rig and role.
+func IsStopped(townRoot, rigName, role string) bool {
+	_, stopped := StoppedBy(townRoot, rigName, role)
+	return stopped
+}
+
+// format renders ESTOP file contents. The first line is
+// trigger\ttimestamp\treason; auto E-stops append "rule\t<rule>" and one
+// "evidence\t<line>" per observation.
+func format(trigger, reason, rule string, evidence []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\t%s\t%s\n", trigger, time.Now().Format(time.RFC3339), oneLine(reason))
+	if rule != "" {
+		fmt.Fprintf(&sb, "rule\t%s\n", rule)
+	}
+	for _, e := range evidence {
+		fmt.Fprintf(&sb, "evidence\t%s\n", oneLine(e))
+	}
+	return sb.String()
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
 func parse(content string) *Info {
 	content = strings.TrimSpace(content)
 	if content == "" {
 		return &Info{Trigger: TriggerManual, Timestamp: time.Now()}
 	}
 
+	header, rest, _ := strings.Cut(content, "\n")
+
 	// Format: trigger\ttimestamp\treason
-	parts := strings.SplitN(content, "\t", 3)
+	parts := strings.SplitN(header, "\t", 3)
 	info := &Info{Trigger: TriggerManual}
 
 	if len(parts) >= 1 {
@@ -144,5 +317,18 @@ func parse(content string) *Info {
 		info.Reason = parts[2]
 	}
 
+	for _, line := range strings.Split(rest, "\n") {
+		key, value, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "rule":
+			info.Rule = value
+		case "evidence":
+			info.Evidence = append(info.Evidence, value)
+		}
+	}
+
 	return info
 }
@@ -195,3 +195,166 @@ func TestDeactivateNonExistent(t *testing.T) {
 		t.Fatalf("Deactivate non-existent: %v", err)
 	}
 }
+
+func TestActivateAutoRecordsRuleAndEvidence(t *testing.T) {
+	townRoot := t.TempDir()
+
+	evidence := []string{"gt-witness died", "gt-refinery\tdied"}
+	if err := ActivateAuto(townRoot, "mass_death", "3 sessions died in 30s", evidence); err != nil {
+		t.Fatalf("ActivateAuto: %v", err)
+	}
+
+	info := Read(townRoot)
+	if info == nil {
+		t.Fatal("Read returned nil")
+	}
+	if info.Trigger != TriggerAuto {
+		t.Errorf("trigger = %q, want %q", info.Trigger, TriggerAuto)
+	}
+	if info.Rule != "mass_death" {
+		t.Errorf("rule = %q, want %q", info.Rule, "mass_death")
+	}
+	if info.Reason != "3 sessions died in 30s" {
+		t.Errorf("reason = %q", info.Reason)
+	}
+	if len(info.Evidence) != 2 || info.Evidence[0] != "gt-witness died" || info.Evidence[1] != "gt-refinery died" {
+		t.Errorf("evidence = %q", info.Evidence)
+	}
+
+	// Auto E-stops can be cleared with onlyAuto.
+	if err := Deactivate(townRoot, true); err != nil {
+		t.Fatalf("Deactivate(onlyAuto=true): %v", err)
+	}
+}
+
+func TestActivateRigAutoAndActiveRigs(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if got := ActiveRigs(townRoot); len(got) != 0 {
+		t.Fatalf("ActiveRigs = %v, want none", got)
+	}
+
+	if err := ActivateRigAuto(townRoot, "gastown", "crash_loop", "deacon crash-looping", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := ActivateRig(townRoot, "beads", TriggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ActiveRigs(townRoot)
+	if len(got) != 2 || got[0] != "beads" || got[1] != "gastown" {
+		t.Errorf("ActiveRigs = %v, want [beads gastown]", got)
+	}
+
+	info := ReadRig(townRoot, "gastown")
+	if info == nil || info.Trigger != TriggerAuto || info.Rule != "crash_loop" {
+		t.Errorf("ReadRig = %+v, want auto crash_loop", info)
+	}
+}
+
+func TestScopeFileNames(t *testing.T) {
+	tests := []struct {
+		scope Scope
+		file  string
+		label string
+	}{
+		{Scope{}, "ESTOP", "town"},
+		{Scope{Rig: "gastown"}, "ESTOP.gastown", "gastown"},
+		{Scope{Rig: "gastown", Role: "polecat"}, "ESTOP.gastown@polecat", "gastown/polecat"},
+		{Scope{Role: "refinery"}, "ESTOP.@refinery", "*/refinery"},
+	}
+	for _, tt := range tests {
+		if got := ScopeFileName(tt.scope); got != tt.file {
+			t.Errorf("ScopeFileName(%+v) = %q, want %q", tt.scope, got, tt.file)
+		}
+		if got := tt.scope.String(); got != tt.label {
+			t.Errorf("Scope(%+v).String() = %q, want %q", tt.scope, got, tt.label)
+		}
+	}
+}
+
+func TestActiveScopesAndActiveRigs(t *testing.T) {
+	townRoot := t.TempDir()
+
+	for _, s := range []Scope{{}, {Rig: "gastown"}, {Rig: "beads", Role: "polecat"}, {Role: "refinery"}} {
+		if err := ActivateScope(townRoot, s, TriggerManual, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := ActiveScopes(townRoot)
+	want := []Scope{{}, {Role: "refinery"}, {Rig: "beads", Role: "polecat"}, {Rig: "gastown"}}
+	if len(got) != len(want) {
+		t.Fatalf("ActiveScopes = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ActiveScopes[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Role-scoped E-stops are not whole-rig E-stops.
+	if rigs := ActiveRigs(townRoot); len(rigs) != 1 || rigs[0] != "gastown" {
+		t.Errorf("ActiveRigs = %v, want [gastown]", rigs)
+	}
+
+	if info := ReadScope(townRoot, Scope{Rig: "beads", Role: "polecat"}); info == nil || info.Reason != "test" {
+		t.Errorf("ReadScope = %+v, want reason %q", info, "test")
+	}
+	if err := DeactivateScope(townRoot, Scope{Rig: "beads", Role: "polecat"}); err != nil {
+		t.Fatal(err)
+	}
+	if IsScopeActive(townRoot, Scope{Rig: "beads", Role: "polecat"}) {
+		t.Error("scope should be inactive after DeactivateScope")
+	}
+}
+
+func TestStoppedBy(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if IsStopped(townRoot, "gastown", "polecat") {
+		t.Fatal("nothing should be stopped without ESTOP files")
+	}
+
+	if err := ActivateScope(townRoot, Scope{Rig: "gastown", Role: "polecat"}, TriggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := ActivateScope(townRoot, Scope{Role: "refinery"}, TriggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rig, role string
+		stopped   bool
+		by        Scope
+	}{
+		{"gastown", "polecat", true, Scope{Rig: "gastown", Role: "polecat"}},
+		{"gastown", "witness", false, Scope{}},
+		{"gastown", "", false, Scope{}},
+		{"beads", "polecat", false, Scope{}},
+		{"beads", "refinery", true, Scope{Role: "refinery"}},
+		{"", "refinery", true, Scope{Role: "refinery"}},
+	}
+	for _, tt := range tests {
+		by, stopped := StoppedBy(townRoot, tt.rig, tt.role)
+		if stopped != tt.stopped || by != tt.by {
+			t.Errorf("StoppedBy(%q, %q) = %+v, %v; want %+v, %v", tt.rig, tt.role, by, stopped, tt.by, tt.stopped)
+		}
+	}
+
+	// A whole-rig E-stop covers every role in the rig and wins over narrower scopes.
+	if err := ActivateRig(townRoot, "gastown", TriggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+	if by, _ := StoppedBy(townRoot, "gastown", "polecat"); by != (Scope{Rig: "gastown"}) {
+		t.Errorf("StoppedBy(gastown, polecat) = %+v, want whole-rig scope", by)
+	}
+
+	// A town-wide E-stop covers everything.
+	if err := Activate(townRoot, TriggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+	if by, stopped := StoppedBy(townRoot, "beads", "witness"); !stopped || !by.IsTown() {
+		t.Errorf("StoppedBy(beads, witness) = %+v, %v; want town", by, stopped)
+	}
+}
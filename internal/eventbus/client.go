@@ -0,0 +1,189 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// dialTimeout bounds how long Subscribe waits for the daemon's socket.
+const dialTimeout = time.Second
+
+// Subscription is a live stream of bus events.
+type Subscription struct {
+	events chan Event
+	cancel context.CancelFunc
+	done   chan struct{}
+	daemon bool
+	err    error
+}
+
+// Subscribe streams journal events matching req.Filter, starting at
+// req.From. It subscribes through the daemon's socket when the daemon is
+// running and otherwise follows the journal in-process. If the daemon goes
+// away mid-stream the subscription carries on in-process from the last
+// delivered event, so consumers see no gap.
+//
+// Events are delivered in journal order. The channel is closed when ctx is
+// done, Close is called, or the stream fails (see Err).
+func Subscribe(ctx context.Context, townRoot string, req SubscribeRequest) (*Subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Subscription{
+		events: make(chan Event, 64),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	conn, r, from, err := dialSubscribe(townRoot, req)
+	if err != nil {
+		var refused *refusedError
+		if errors.As(err, &refused) {
+			cancel()
+			return nil, err
+		}
+		// No daemon: follow the journal ourselves.
+		c, err := newCursor(JournalPath(townRoot), req.From)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		go s.runLocal(ctx, townRoot, c, req.Filter)
+		return s, nil
+	}
+
+	s.daemon = true
+	go s.runDaemon(ctx, townRoot, conn, r, from, req.Filter)
+	return s, nil
+}
+
+// Events returns the event channel.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Daemon reports whether the subscription is served by the daemon.
+func (s *Subscription) Daemon() bool {
+	return s.daemon
+}
+
+// Err returns the error that ended the stream, if any. It is only
+// meaningful after the Events channel is closed.
+func (s *Subscription) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Close ends the subscription and waits for its goroutine to exit.
+func (s *Subscription) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// send delivers ev unless ctx is done first.
+func (s *Subscription) send(ctx context.Context, ev Event) error {
+	select {
+	case s.events <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Subscription) finish(err error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		err = nil
+	}
+	s.err = err
+	close(s.events)
+	close(s.done)
+}
+
+func (s *Subscription) runLocal(ctx context.Context, townRoot string, c *cursor, filter Filter) {
+	n, release := watchTown(townRoot)
+	defer release()
+	s.finish(follow(ctx, c, filter, n, func(ev Event) error { return s.send(ctx, ev) }))
+}
+
+func (s *Subscription) runDaemon(ctx context.Context, townRoot string, conn net.Conn, r *bufio.Reader, from int64, filter Filter) {
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	// next is where an in-process follower picks up if the daemon drops us:
+	// one byte into the last delivered event's line, which the cursor skips.
+	next := from
+	dec := json.NewDecoder(r)
+	for {
+		var ev Event
+		if err := dec.Decode(&ev); err != nil {
+			break
+		}
+		next = ev.Offset + 1
+		if err := s.send(ctx, ev); err != nil {
+			s.finish(err)
+			return
+		}
+	}
+	_ = conn.Close()
+	if ctx.Err() != nil {
+		s.finish(ctx.Err())
+		return
+	}
+
+	c, err := newCursor(JournalPath(townRoot), next)
+	if err != nil {
+		s.finish(err)
+		return
+	}
+	s.runLocal(ctx, townRoot, c, filter)
+}
+
+// refusedError is a subscription the daemon rejected, as opposed to a daemon
+// that could not be reached.
+type refusedError struct{ msg string }
+
+func (e *refusedError) Error() string { return "event bus: " + e.msg }
+
+// dialSubscribe opens a subscription on the daemon's socket and returns the
+// connection, a reader positioned at the event stream, and the resolved
+// start offset.
+func dialSubscribe(townRoot string, req SubscribeRequest) (net.Conn, *bufio.Reader, int64, error) {
+	conn, err := net.DialTimeout("unix", SocketPath(townRoot), dialTimeout)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	_ = conn.SetDeadline(time.Now().Add(requestTimeout))
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, 0, fmt.Errorf("marshaling subscribe request: %w", err)
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		_ = conn.Close()
+		return nil, nil, 0, err
+	}
+
+	r := bufio.NewReader(conn)
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, 0, err
+	}
+	var reply subscribeReply
+	if err := json.Unmarshal(line, &reply); err != nil {
+		_ = conn.Close()
+		return nil, nil, 0, fmt.Errorf("reading subscribe reply: %w", err)
+	}
+	if reply.Error != "" {
+		_ = conn.Close()
+		return nil, nil, 0, &refusedError{msg: reply.Error}
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return conn, r, reply.From, nil
+}
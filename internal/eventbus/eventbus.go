@@ -0,0 +1,174 @@
+// Package eventbus is Gas Town's local event bus.
+//
+// Every producer (activity events, channel events, town log lines) appends a
+// typed Event to one journal, ~/gt/.events.jsonl. An event's offset is the
+// byte position of its line in the journal, so a consumer that remembers the
+// offset of the last event it handled can resume exactly where it left off.
+//
+// The daemon hosts a subscription socket (~/gt/daemon/eventbus.sock) that
+// streams journal events matching a Filter, starting from any offset.
+// Subscribe uses it when the daemon is running and otherwise follows the
+// journal in-process, so consumers never have to poll files themselves.
+//
+// The journal and the legacy sinks (channel .event files, logs/town.log,
+// .feed.jsonl) stay on disk as the durable record.
+package eventbus
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofrs/flock"
+)
+
+// JournalFile is the name of the bus journal in the town root. It is the
+// same file the activity log has always used.
+const JournalFile = ".events.jsonl"
+
+// SocketFile is the path of the daemon's subscription socket, relative to
+// the town root.
+const SocketFile = "daemon/eventbus.sock"
+
+// Event sources.
+const (
+	SourceGT      = "gt"      // Activity events (events.Log)
+	SourceChannel = "channel" // Named-channel events (channelevents.Emit)
+	SourceTownlog = "townlog" // Agent lifecycle lines (townlog.Logger)
+)
+
+// Event is one entry in the bus journal.
+type Event struct {
+	// Offset is the byte position of the event's line in the journal. It is
+	// set on events read from the bus and never written to the journal.
+	Offset int64 `json:"offset,omitempty"`
+
+	Timestamp  string                 `json:"ts"`
+	Source     string                 `json:"source"`
+	Type       string                 `json:"type"`
+	Actor      string                 `json:"actor"`
+	Channel    string                 `json:"channel,omitempty"` // Set for SourceChannel events
+	Payload    map[string]interface{} `json:"payload,omitempty"`
+	Visibility string                 `json:"visibility"`
+}
+
+// IsActivity reports whether ev is an activity event from events.Log, as
+// opposed to a channel event or town log line. Channel and town log event
+// types overlap with activity types ("done", "spawn", ...), so readers that
+// want activity must check the source, not just the type.
+func (ev Event) IsActivity() bool {
+	return IsActivitySource(ev.Source)
+}
+
+// IsActivitySource reports whether a journal line's source marks it as an
+// activity event: anything but a channel event or town log line. Lines
+// without a source predate the bus and are activity.
+func IsActivitySource(source string) bool {
+	return source != SourceChannel && source != SourceTownlog
+}
+
+// Filter selects events. Each non-empty field must match; within a field any
+// listed value matches. Actors match by prefix, so "gastown/" selects every
+// agent in the gastown rig. SourceGT matches every activity event, including
+// lines without a source.
+type Filter struct {
+	Types      []string `json:"types,omitempty"`
+	Sources    []string `json:"sources,omitempty"`
+	Channels   []string `json:"channels,omitempty"`
+	Actors     []string `json:"actors,omitempty"`
+	Visibility []string `json:"visibility,omitempty"`
+}
+
+// Match reports whether ev passes the filter.
+func (f Filter) Match(ev Event) bool {
+	if len(f.Types) > 0 && !contains(f.Types, ev.Type) {
+		return false
+	}
+	if len(f.Sources) > 0 && !contains(f.Sources, ev.Source) &&
+		!(ev.IsActivity() && contains(f.Sources, SourceGT)) {
+		return false
+	}
+	if len(f.Channels) > 0 && !contains(f.Channels, ev.Channel) {
+		return false
+	}
+	if len(f.Visibility) > 0 && !contains(f.Visibility, ev.Visibility) {
+		return false
+	}
+	if len(f.Actors) > 0 {
+		ok := false
+		for _, a := range f.Actors {
+			if strings.HasPrefix(ev.Actor, a) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// JournalPath returns the path of the bus journal for a town.
+func JournalPath(townRoot string) string {
+	return filepath.Join(townRoot, JournalFile)
+}
+
+// SocketPath returns the path of the daemon's subscription socket for a town.
+func SocketPath(townRoot string) string {
+	return filepath.Join(townRoot, SocketFile)
+}
+
+// Append writes ev to the town's journal and returns its offset.
+// Uses flock for cross-process synchronization — multiple gt processes
+// append concurrently and each line must land whole.
+func Append(townRoot string, ev Event) (int64, error) {
+	path := JournalPath(townRoot)
+
+	ev.Offset = 0
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return 0, fmt.Errorf("marshaling event: %w", err)
+	}
+	data = append(data, '\n')
+
+	fl := flock.New(path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return 0, fmt.Errorf("acquiring events file lock: %w", err)
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: events file is non-sensitive operational data
+	if err != nil {
+		return 0, fmt.Errorf("opening events file: %w", err)
+	}
+
+	// Under the lock nobody else appends, so the current size is where this
+	// line starts.
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return 0, fmt.Errorf("stat events file: %w", err)
+	}
+	offset := info.Size()
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return 0, fmt.Errorf("writing event: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return 0, fmt.Errorf("closing events file: %w", err)
+	}
+	return offset, nil
+}
@@ -0,0 +1,272 @@
+package eventbus
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func appendN(t *testing.T, townRoot string, types ...string) []int64 {
+	t.Helper()
+	var offsets []int64
+	for _, typ := range types {
+		off, err := Append(townRoot, Event{Source: SourceGT, Type: typ, Actor: "gastown/witness", Visibility: "feed"})
+		if err != nil {
+			t.Fatalf("Append(%s): %v", typ, err)
+		}
+		offsets = append(offsets, off)
+	}
+	return offsets
+}
+
+func collect(t *testing.T, townRoot string, from int64, filter Filter) []Event {
+	t.Helper()
+	var got []Event
+	if _, err := ReadFrom(townRoot, from, filter, func(ev Event) error {
+		got = append(got, ev)
+		return nil
+	}); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	return got
+}
+
+func types(evs []Event) []string {
+	var out []string
+	for _, ev := range evs {
+		out = append(out, ev.Type)
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAppendOffsetsReplay(t *testing.T) {
+	town := t.TempDir()
+	offsets := appendN(t, town, "a", "b", "c")
+	if offsets[0] != 0 || offsets[1] <= offsets[0] || offsets[2] <= offsets[1] {
+		t.Fatalf("offsets not increasing: %v", offsets)
+	}
+
+	got := collect(t, town, 0, Filter{})
+	if !equal(types(got), []string{"a", "b", "c"}) {
+		t.Fatalf("replay from 0 = %v", types(got))
+	}
+	for i, ev := range got {
+		if ev.Offset != offsets[i] {
+			t.Errorf("event %d offset = %d, want %d", i, ev.Offset, offsets[i])
+		}
+	}
+
+	// Resuming from an event's offset includes it; one past skips it.
+	if got := collect(t, town, offsets[1], Filter{}); !equal(types(got), []string{"b", "c"}) {
+		t.Errorf("replay from offsets[1] = %v", types(got))
+	}
+	if got := collect(t, town, offsets[1]+1, Filter{}); !equal(types(got), []string{"c"}) {
+		t.Errorf("replay from offsets[1]+1 = %v", types(got))
+	}
+	if got := collect(t, town, FromEnd, Filter{}); len(got) != 0 {
+		t.Errorf("replay from end = %v", types(got))
+	}
+
+	data, err := os.ReadFile(JournalPath(town))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data[:len(`{"ts"`)]) != `{"ts"` {
+		t.Errorf("offset must not be written to the journal: %s", data)
+	}
+}
+
+func TestOffsetOfLast(t *testing.T) {
+	town := t.TempDir()
+	if off, err := OffsetOfLast(town, 5); err != nil || off != 0 {
+		t.Fatalf("missing journal: %d, %v", off, err)
+	}
+	offsets := appendN(t, town, "a", "b", "c", "d")
+	for n, want := range map[int]int64{1: offsets[3], 2: offsets[2], 4: 0, 10: 0} {
+		if got, _ := OffsetOfLast(town, n); got != want {
+			t.Errorf("OffsetOfLast(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	ev := Event{Source: SourceChannel, Type: "MERGE_READY", Actor: "gastown/witness", Channel: "refinery", Visibility: "audit"}
+	cases := []struct {
+		name string
+		f    Filter
+		want bool
+	}{
+		{"empty", Filter{}, true},
+		{"type", Filter{Types: []string{"x", "MERGE_READY"}}, true},
+		{"wrong type", Filter{Types: []string{"x"}}, false},
+		{"channel", Filter{Channels: []string{"refinery"}}, true},
+		{"actor prefix", Filter{Actors: []string{"gastown/"}}, true},
+		{"wrong actor", Filter{Actors: []string{"beads/"}}, false},
+		{"visibility", Filter{Visibility: []string{"feed", "both"}}, false},
+		{"all fields", Filter{Sources: []string{SourceChannel}, Channels: []string{"refinery"}, Types: []string{"MERGE_READY"}}, true},
+		{"activity only", Filter{Sources: []string{SourceGT}}, false},
+	}
+	for _, tc := range cases {
+		if got := tc.f.Match(ev); got != tc.want {
+			t.Errorf("%s: Match = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	// Lines written before the bus have no source and are activity.
+	legacy := Event{Type: "done"}
+	if !legacy.IsActivity() || !(Filter{Sources: []string{SourceGT}}).Match(legacy) {
+		t.Error("sourceless event should be activity")
+	}
+	if (Event{Source: SourceTownlog, Type: "done"}).IsActivity() {
+		t.Error("town log event reported as activity")
+	}
+}
+
+func TestWatchTownShared(t *testing.T) {
+	town := t.TempDir()
+	n1, release1 := watchTown(town)
+	n2, release2 := watchTown(town + "/")
+	if n1 != n2 {
+		t.Fatal("followers of one town should share a watcher")
+	}
+	release1()
+	release1() // releasing twice is harmless
+	townWatchers.Lock()
+	_, ok := townWatchers.m[town]
+	townWatchers.Unlock()
+	if !ok {
+		t.Fatal("watcher stopped while a follower remains")
+	}
+	release2()
+	townWatchers.Lock()
+	_, ok = townWatchers.m[town]
+	townWatchers.Unlock()
+	if ok {
+		t.Error("watcher kept after its last follower released it")
+	}
+}
+
+func TestCursorRestartsAfterTruncate(t *testing.T) {
+	town := t.TempDir()
+	appendN(t, town, "a", "b")
+	c, err := newCursor(JournalPath(town), FromEnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(JournalPath(town), 0); err != nil {
+		t.Fatal(err)
+	}
+	appendN(t, town, "c")
+
+	var got []Event
+	if err := c.read(Filter{}, func(ev Event) error { got = append(got, ev); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if !equal(types(got), []string{"c"}) {
+		t.Errorf("after truncate = %v", types(got))
+	}
+}
+
+// receive reads n events from sub or fails after a timeout.
+func receive(t *testing.T, sub *Subscription, n int) []Event {
+	t.Helper()
+	var got []Event
+	timeout := time.After(5 * time.Second)
+	for len(got) < n {
+		select {
+		case ev, ok := <-sub.Events():
+			if !ok {
+				t.Fatalf("subscription closed after %v: %v", types(got), sub.Err())
+			}
+			got = append(got, ev)
+		case <-timeout:
+			t.Fatalf("timed out after %v", types(got))
+		}
+	}
+	return got
+}
+
+func TestSubscribeThroughDaemon(t *testing.T) {
+	town := t.TempDir()
+	appendN(t, town, "old-1", "old-2")
+
+	srv := NewServer(town, nil)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	stopped := false
+	defer func() {
+		if !stopped {
+			srv.Stop()
+		}
+	}()
+
+	if err := NewServer(town, nil).Start(); err == nil {
+		t.Error("second server on the same socket should fail")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := Subscribe(ctx, town, SubscribeRequest{From: 0, Filter: Filter{Types: []string{"old-2", "new-1", "new-2"}}})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer sub.Close()
+	if !sub.Daemon() {
+		t.Fatal("expected a daemon-served subscription")
+	}
+
+	if got := receive(t, sub, 1); got[0].Type != "old-2" {
+		t.Fatalf("replay = %v", types(got))
+	}
+	appendN(t, town, "skipped", "new-1")
+	if got := receive(t, sub, 1); got[0].Type != "new-1" {
+		t.Fatalf("live = %v", types(got))
+	}
+
+	// The daemon going away must not lose events.
+	srv.Stop()
+	stopped = true
+	appendN(t, town, "new-2")
+	if got := receive(t, sub, 1); got[0].Type != "new-2" {
+		t.Fatalf("after daemon stop = %v", types(got))
+	}
+}
+
+func TestSubscribeWithoutDaemon(t *testing.T) {
+	town := t.TempDir()
+	appendN(t, town, "old")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := Subscribe(ctx, town, SubscribeRequest{From: FromEnd})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if sub.Daemon() {
+		t.Fatal("no daemon is running")
+	}
+	appendN(t, town, "new")
+	if got := receive(t, sub, 1); got[0].Type != "new" {
+		t.Fatalf("got %v", types(got))
+	}
+
+	cancel()
+	for range sub.Events() {
+	}
+	if err := sub.Err(); err != nil {
+		t.Errorf("Err after cancel = %v", err)
+	}
+}
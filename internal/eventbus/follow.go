@@ -0,0 +1,335 @@
+package eventbus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// FromEnd starts reading at the current end of the journal, so only events
+// appended afterwards are delivered.
+const FromEnd int64 = -1
+
+// rescanInterval is how often a follower re-reads the journal even without a
+// change notification, in case the watcher missed one.
+const rescanInterval = time.Second
+
+// pollInterval is used instead of rescanInterval when fsnotify is unavailable.
+const pollInterval = 200 * time.Millisecond
+
+// Follow calls fn for every journal event matching filter, starting at
+// offset from (or FromEnd), and keeps following the journal until ctx is
+// done or fn returns an error. It reads the journal in-process; consumers
+// outside the daemon should normally use Subscribe instead.
+func Follow(ctx context.Context, townRoot string, from int64, filter Filter, fn func(Event) error) error {
+	c, err := newCursor(JournalPath(townRoot), from)
+	if err != nil {
+		return err
+	}
+	n, release := watchTown(townRoot)
+	defer release()
+	return follow(ctx, c, filter, n, fn)
+}
+
+// ReadFrom calls fn for every complete journal event at or after offset from
+// that matches filter, and returns the offset just past the last event read.
+func ReadFrom(townRoot string, from int64, filter Filter, fn func(Event) error) (int64, error) {
+	c, err := newCursor(JournalPath(townRoot), from)
+	if err != nil {
+		return 0, err
+	}
+	err = c.read(filter, fn)
+	return c.pos, err
+}
+
+// OffsetOfLast returns the offset of the n-th last event in the journal, or 0
+// if the journal holds fewer than n events. Consumers use it to show recent
+// history before following new events.
+func OffsetOfLast(townRoot string, n int) (int64, error) {
+	f, err := os.Open(JournalPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	// Walk backwards counting line starts. The newline ending the last line
+	// does not start a new one.
+	const chunk = 64 * 1024
+	end := info.Size()
+	if end > 0 {
+		end--
+	}
+	buf := make([]byte, chunk)
+	for end > 0 {
+		start := end - chunk
+		if start < 0 {
+			start = 0
+		}
+		b := buf[:end-start]
+		if _, err := f.ReadAt(b, start); err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		for i := len(b) - 1; i >= 0; i-- {
+			if b[i] == '\n' {
+				n--
+				if n == 0 {
+					return start + int64(i) + 1, nil
+				}
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// cursor is a read position in the journal.
+type cursor struct {
+	path string
+	pos  int64
+	// skipPartial drops the first line read because pos was not at a line
+	// start when the cursor was created.
+	skipPartial bool
+}
+
+// newCursor positions a cursor at from. FromEnd and offsets past the end of
+// the journal resolve to its current end.
+func newCursor(path string, from int64) (*cursor, error) {
+	c := &cursor{path: path}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, err
+	}
+	switch {
+	case from < 0 || from >= info.Size():
+		c.pos = info.Size()
+	case from > 0:
+		c.pos = from
+		prev, err := readByteAt(path, from-1)
+		if err != nil {
+			return nil, err
+		}
+		c.skipPartial = prev != '\n'
+	}
+	return c, nil
+}
+
+func readByteAt(path string, off int64) (byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	b := make([]byte, 1)
+	if _, err := f.ReadAt(b, off); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// read delivers the complete events appended since the last read. A partially
+// written trailing line is left for the next read. If the journal shrank
+// (truncated or pruned) reading restarts from the beginning.
+func (c *cursor) read(filter Filter, fn func(Event) error) error {
+	f, err := os.Open(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			c.pos = 0
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() < c.pos {
+		c.pos = 0
+		c.skipPartial = false
+	}
+	if info.Size() == c.pos {
+		return nil
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(f, c.pos, info.Size()-c.pos))
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			// EOF before a newline: incomplete line, retry next time.
+			return nil
+		}
+		offset := c.pos
+		c.pos += int64(len(line))
+		if c.skipPartial {
+			c.skipPartial = false
+			continue
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var ev Event
+		if json.Unmarshal(line, &ev) != nil {
+			continue // Skip malformed lines
+		}
+		ev.Offset = offset
+		if !filter.Match(ev) {
+			continue
+		}
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+}
+
+// follow reads from c whenever n signals a journal change, until ctx is done
+// or fn fails.
+func follow(ctx context.Context, c *cursor, filter Filter, n *notifier, fn func(Event) error) error {
+	for {
+		// Take the wait channel before reading so a write that lands during
+		// the read still wakes us.
+		changed := n.wait()
+		if err := c.read(filter, fn); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// notifier broadcasts journal changes to any number of followers.
+type notifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+// wait returns a channel that is closed on the next broadcast.
+func (n *notifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ch == nil {
+		n.ch = make(chan struct{})
+	}
+	return n.ch
+}
+
+func (n *notifier) broadcast() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ch != nil {
+		close(n.ch)
+		n.ch = nil
+	}
+}
+
+// townWatchers shares one journal watcher per town among the followers in a
+// process. The daemon runs the subscription server, the feed curator and the
+// plugin gates side by side, and they all wake from the same watcher.
+var townWatchers = struct {
+	sync.Mutex
+	m map[string]*townWatcher
+}{m: make(map[string]*townWatcher)}
+
+type townWatcher struct {
+	n      *notifier
+	refs   int
+	cancel context.CancelFunc
+}
+
+// watchTown returns the notifier for a town's journal, starting its watcher
+// on first use. Call release when done following; the watcher stops with
+// its last follower.
+func watchTown(townRoot string) (*notifier, func()) {
+	key := filepath.Clean(townRoot)
+	townWatchers.Lock()
+	defer townWatchers.Unlock()
+	w := townWatchers.m[key]
+	if w == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		w = &townWatcher{n: &notifier{}, cancel: cancel}
+		townWatchers.m[key] = w
+		go watchJournal(ctx, townRoot, w.n)
+	}
+	w.refs++
+
+	var once sync.Once
+	return w.n, func() {
+		once.Do(func() {
+			townWatchers.Lock()
+			defer townWatchers.Unlock()
+			if w.refs--; w.refs == 0 {
+				w.cancel()
+				delete(townWatchers.m, key)
+			}
+		})
+	}
+}
+
+// watchJournal broadcasts on n whenever the journal is written, until ctx is
+// done. The town root is watched rather than the file itself so creation and
+// rotation are seen too. A periodic rescan covers missed notifications, and
+// becomes the only trigger when fsnotify is unavailable.
+func watchJournal(ctx context.Context, townRoot string, n *notifier) {
+	interval := rescanInterval
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	if watcher, err := fsnotify.NewWatcher(); err == nil {
+		defer func() { _ = watcher.Close() }()
+		if err := watcher.Add(townRoot); err == nil {
+			events, errs = watcher.Events, watcher.Errors
+		} else {
+			interval = pollInterval
+		}
+	} else {
+		interval = pollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			n.broadcast()
+			return
+		case ev, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if ev.Op&(fsnotify.Create|fsnotify.Write) != 0 && filepath.Base(ev.Name) == JournalFile {
+				n.broadcast()
+			}
+		case _, ok := <-errs:
+			if !ok {
+				errs = nil
+			}
+		case <-ticker.C:
+			n.broadcast()
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SubscribeRequest is the first line a client writes on the socket. The
+// server answers with a subscribeReply line, then streams matching events
+// as JSON lines until either side closes the connection.
+type SubscribeRequest struct {
+	// From is the journal offset to replay from: 0 replays the whole
+	// journal, FromEnd delivers only new events, and any other value is an
+	// Event.Offset (or an offset just past one) from an earlier read.
+	From   int64  `json:"from"`
+	Filter Filter `json:"filter"`
+}
+
+// subscribeReply acknowledges a subscription. From is the resolved start
+// offset; Error is set when the request was refused.
+type subscribeReply struct {
+	From  int64  `json:"from"`
+	Error string `json:"error,omitempty"`
+}
+
+// requestTimeout bounds how long a new connection may take to send its
+// SubscribeRequest.
+const requestTimeout = 5 * time.Second
+
+// writeTimeout drops subscribers that stop reading.
+const writeTimeout = 30 * time.Second
+
+// Server hosts the subscription socket. The daemon runs one per town.
+type Server struct {
+	townRoot string
+	logger   *log.Logger
+	notify   *notifier
+	release  func()
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	ln     net.Listener
+}
+
+// NewServer creates a subscription server for a town. A nil logger
+// discards log output.
+func NewServer(townRoot string, logger *log.Logger) *Server {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Server{
+		townRoot: townRoot,
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start listens on the town's socket and begins serving subscribers.
+// A stale socket left by a crashed daemon is replaced; a live one is an
+// error.
+func (s *Server) Start() error {
+	path := SocketPath(s.townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating socket directory: %w", err)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("event bus already served at %s", path)
+	}
+	_ = os.Remove(path)
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", path, err)
+	}
+	s.ln = ln
+	s.notify, s.release = watchTown(s.townRoot)
+
+	s.wg.Add(1)
+	go s.accept()
+	return nil
+}
+
+// Stop closes the socket and disconnects all subscribers.
+func (s *Server) Stop() {
+	s.cancel()
+	if s.ln != nil {
+		_ = s.ln.Close()
+	}
+	s.wg.Wait()
+	if s.ln != nil {
+		s.release()
+		_ = os.Remove(SocketPath(s.townRoot))
+	}
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if s.ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				s.logger.Printf("eventbus: accept failed: %v", err)
+			}
+			return
+		}
+		s.wg.Add(1)
+		go s.serve(conn)
+	}
+}
+
+// serve handles one subscriber connection.
+func (s *Server) serve(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(requestTimeout))
+	r := bufio.NewReader(conn)
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	enc := json.NewEncoder(conn)
+	var req SubscribeRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		_ = enc.Encode(subscribeReply{Error: fmt.Sprintf("invalid subscribe request: %v", err)})
+		return
+	}
+	c, err := newCursor(JournalPath(s.townRoot), req.From)
+	if err != nil {
+		_ = enc.Encode(subscribeReply{Error: err.Error()})
+		return
+	}
+	if err := enc.Encode(subscribeReply{From: c.pos}); err != nil {
+		return
+	}
+
+	// The client never writes after its request, so a read returning means
+	// it hung up.
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	go func() {
+		_, _ = r.ReadByte()
+		cancel()
+	}()
+
+	_ = follow(ctx, c, req.Filter, s.notify, func(ev Event) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		return enc.Encode(ev)
+	})
+}
@@ -1,29 +1,21 @@
 // Package events provides event logging for the gt activity feed.
 //
-// Events are written to ~/gt/.events.jsonl (raw audit log) and later
-// curated by the feed daemon into ~/.feed.jsonl (user-facing).
+// Events are published on the event bus, whose journal is ~/gt/.events.jsonl
+// (raw audit log), and later curated by the feed daemon into ~/.feed.jsonl
+// (user-facing).
 package events
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
-	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // Event represents an activity event in Gas Town.
-type Event struct {
-	Timestamp  string                 `json:"ts"`
-	Source     string                 `json:"source"`
-	Type       string                 `json:"type"`
-	Actor      string                 `json:"actor"`
-	Payload    map[string]interface{} `json:"payload,omitempty"`
-	Visibility string                 `json:"visibility"`
-}
+type Event = eventbus.Event
 
 // Visibility levels for events.
 const (
@@ -54,6 +46,10 @@ const (
 	TypeSessionDeath = "session_death" // Feed-visible session termination
 	TypeMassDeath    = "mass_death"    // Multiple sessions died in short window
 
+	// Emergency stop events
+	TypeEstop = "estop" // E-stop activated (manual or auto-triggered)
+	TypeThaw  = "thaw"  // E-stop cleared
+
 	// Witness patrol events
 	TypePatrolStarted   = "patrol_started"
 	TypePolecatChecked  = "polecat_checked"
@@ -63,6 +59,9 @@ const (
 	TypeEscalationClosed = "escalation_closed"
 	TypePatrolComplete   = "patrol_complete"
 
+	// Overseer events
+	TypeOverseerHandoff = "overseer_handoff" // On-call duty handed to another overseer
+
 	// Merge queue events (emitted by refinery)
 	TypeMergeStarted = "merge_started"
 	TypeMerged       = "merged"
@@ -74,24 +73,50 @@ const (
 	TypeSchedulerDispatch       = "scheduler_dispatch"        // Bead dispatched from scheduler
 	TypeSchedulerDispatchFailed = "scheduler_dispatch_failed" // Bead dispatch failed (requeued)
 	TypeSchedulerCloseRetry     = "scheduler_close_retry"     // Context close needed last-resort attempt
+
+	// Budget events
+	TypeBudgetWarning  = "budget_warning"  // Spend crossed a budget's soft threshold
+	TypeBudgetExceeded = "budget_exceeded" // Spend reached a budget's limit
+
+	// Dashboard events
+	TypeDashboardAccess = "dashboard_access" // Authenticated/denied dashboard request (audit)
+
+	// Proxy events
+	TypePushRejected = "push_rejected" // Polecat push rejected by the proxy's push policy
 )
 
-// EventsFile is the name of the raw events log.
-const EventsFile = ".events.jsonl"
+// EventsFile is the name of the raw events log (the event bus journal).
+const EventsFile = eventbus.JournalFile
 
 // Log writes an event to the events log.
 // The event is appended to ~/gt/.events.jsonl.
 // Returns nil if logging fails (events are best-effort).
 func Log(eventType, actor string, payload map[string]interface{}, visibility string) error {
-	event := Event{
+	// Find town root
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		// Silently ignore - we're not in a Gas Town workspace
+		return nil
+	}
+	return write(townRoot, newEvent(eventType, actor, payload, visibility))
+}
+
+// LogAt is like Log but writes to the events log of an explicit town root.
+// Long-running services such as gt-proxy-server use it because they are
+// configured with a town root rather than started inside the workspace.
+func LogAt(townRoot, eventType, actor string, payload map[string]interface{}, visibility string) error {
+	return write(townRoot, newEvent(eventType, actor, payload, visibility))
+}
+
+func newEvent(eventType, actor string, payload map[string]interface{}, visibility string) Event {
+	return Event{
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
-		Source:     "gt",
+		Source:     eventbus.SourceGT,
 		Type:       eventType,
 		Actor:      actor,
 		Payload:    payload,
 		Visibility: visibility,
 	}
-	return write(event)
 }
 
 // LogFeed is a convenience wrapper for feed-visible events.
@@ -104,48 +129,10 @@ func LogAudit(eventType, actor string, payload map[string]interface{}) error {
 	return Log(eventType, actor, payload, VisibilityAudit)
 }
 
-// write appends an event to the events file.
-// Uses flock for cross-process synchronization — sync.Mutex only protects
-// intra-process goroutines, but multiple gt processes write concurrently.
-func write(event Event) error {
-	// Find town root
-	townRoot, err := workspace.FindFromCwd()
-	if err != nil || townRoot == "" {
-		// Silently ignore - we're not in a Gas Town workspace
-		return nil
-	}
-
-	eventsPath := filepath.Join(townRoot, EventsFile)
-
-	// Marshal event to JSON
-	data, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("marshaling event: %w", err)
-	}
-	data = append(data, '\n')
-
-	// Acquire cross-process file lock
-	fl := flock.New(eventsPath + ".lock")
-	if err := fl.Lock(); err != nil {
-		return fmt.Errorf("acquiring events file lock: %w", err)
-	}
-	defer fl.Unlock() //nolint:errcheck // best-effort unlock
-
-	f, err := os.OpenFile(eventsPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: events file is non-sensitive operational data
-	if err != nil {
-		return fmt.Errorf("opening events file: %w", err)
-	}
-
-	if _, err := f.Write(data); err != nil {
-		_ = f.Close()
-		return fmt.Errorf("writing event: %w", err)
-	}
-
-	if err := f.Close(); err != nil {
-		return fmt.Errorf("closing events file: %w", err)
-	}
-
-	return nil
+// write publishes an event on the town's event bus.
+func write(townRoot string, event Event) error {
+	_, err := eventbus.Append(townRoot, event)
+	return err
 }
 
 // Payload helpers for common event structures.
@@ -323,6 +310,58 @@ func MassDeathPayload(count int, window string, sessions []string, possibleCause
 	return p
 }
 
+// EstopPayload creates a payload for E-stop and thaw events.
+// scope: "town" or the rig name for a per-rig E-stop
+// trigger: "manual" or "auto"
+// rule: auto-trigger rule that tripped (empty for manual)
+// reason: human-readable reason
+// evidence: observations behind an auto trip
+func EstopPayload(scope, trigger, rule, reason string, evidence []string) map[string]interface{} {
+	p := map[string]interface{}{
+		"scope":   scope,
+		"trigger": trigger,
+	}
+	if rule != "" {
+		p["rule"] = rule
+	}
+	if reason != "" {
+		p["reason"] = reason
+	}
+	if len(evidence) > 0 {
+		p["evidence"] = evidence
+	}
+	return p
+}
+
+// BudgetPayload creates a payload for budget warning/exceeded events.
+// scope: "town", "rig <name>", "role <name>" or "convoy <id>"
+// period: "daily" or "monthly"
+func BudgetPayload(scope, period string, spentUSD, limitUSD float64) map[string]interface{} {
+	return map[string]interface{}{
+		"scope":     scope,
+		"period":    period,
+		"spent_usd": spentUSD,
+		"limit_usd": limitUSD,
+	}
+}
+
+// DashboardAccessPayload creates a payload for dashboard audit events.
+// role: the caller's role ("viewer", "operator", or "" when unauthenticated)
+// command: the gt command for /api/run requests, otherwise empty
+func DashboardAccessPayload(method, path, role, command, remote string, status int) map[string]interface{} {
+	p := map[string]interface{}{
+		"method": method,
+		"path":   path,
+		"role":   role,
+		"status": status,
+		"remote": remote,
+	}
+	if command != "" {
+		p["command"] = command
+	}
+	return p
+}
+
 // SessionPayload creates a payload for session start/end events.
 // sessionID: Claude Code session UUID
 // role: Gas Town role (e.g., "gastown/crew/joe", "deacon")
@@ -368,3 +407,17 @@ func SchedulerDispatchFailedPayload(beadID, rig, errMsg string) map[string]inter
 		"error": errMsg,
 	}
 }
+
+// PushRejectedPayload creates a payload for push_rejected events.
+// violations are human-readable descriptions, one per policy failure.
+func PushRejectedPayload(rig string, refs, violations []string) map[string]interface{} {
+	p := map[string]interface{}{
+		"rig":        rig,
+		"refs":       refs,
+		"violations": violations,
+	}
+	if len(violations) > 0 {
+		p["reason"] = violations[0]
+	}
+	return p
+}
@@ -1,7 +1,7 @@
 // Package feed provides the feed daemon that curates raw events into a user-facing feed.
 //
 // The curator:
-// 1. Tails ~/gt/.events.jsonl (raw events)
+// 1. Follows the event bus journal ~/gt/.events.jsonl (raw events)
 // 2. Filters by visibility tag (drops audit-only events)
 // 3. Deduplicates repeated updates (5 molecule updates → "agent active")
 // 4. Aggregates related events (3 issues closed → "batch complete")
@@ -22,6 +22,7 @@ import (
 
 	"github.com/gofrs/flock"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/events"
 )
 
@@ -104,16 +105,16 @@ func (c *Curator) Start() error {
 			c.startErr = fmt.Errorf("opening events file: %w", err)
 			return
 		}
-
-		// Seek to end to only process new events
-		if _, err := file.Seek(0, io.SeekEnd); err != nil {
-			_ = file.Close() //nolint:gosec // G104: best effort cleanup on error
-			c.startErr = fmt.Errorf("seeking to end: %w", err)
+		info, err := file.Stat()
+		_ = file.Close() //nolint:gosec // G104: read-only handle
+		if err != nil {
+			c.startErr = fmt.Errorf("stat events file: %w", err)
 			return
 		}
 
+		// Start at the current end to only process new events
 		c.wg.Add(1)
-		go c.run(file)
+		go c.run(info.Size())
 	})
 	return c.startErr
 }
@@ -124,57 +125,31 @@ func (c *Curator) Stop() {
 	c.wg.Wait()
 }
 
-// run is the main curator loop.
+// run is the main curator loop: it follows feed-visible events on the bus
+// from offset from.
 // ZFC: No in-memory state to clean up - state is derived from the events file.
-func (c *Curator) run(file *os.File) {
+func (c *Curator) run(from int64) {
 	defer c.wg.Done()
-	defer file.Close()
 
-	reader := bufio.NewReader(file)
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-c.ctx.Done():
-			return
-
-		case <-ticker.C:
-			// Read available lines
-			for {
-				line, err := reader.ReadString('\n')
-				if err != nil {
-					break // No more data available
-				}
-				c.processLine(line)
-			}
-		}
+	filter := eventbus.Filter{
+		Sources:    []string{eventbus.SourceGT},
+		Visibility: []string{events.VisibilityFeed, events.VisibilityBoth},
 	}
+	_ = eventbus.Follow(c.ctx, c.townRoot, from, filter, func(ev eventbus.Event) error {
+		c.processEvent(&ev)
+		return nil
+	})
 }
 
-// processLine processes a single line from the events file.
-func (c *Curator) processLine(line string) {
-	if line == "" || line == "\n" {
-		return
-	}
-
-	var rawEvent events.Event
-	if err := json.Unmarshal([]byte(line), &rawEvent); err != nil {
-		return // Skip malformed lines
-	}
-
-	// Filter by visibility - only process feed-visible events
-	if rawEvent.Visibility != events.VisibilityFeed && rawEvent.Visibility != events.VisibilityBoth {
-		return
-	}
-
+// processEvent processes a single feed-visible event from the bus.
+func (c *Curator) processEvent(rawEvent *events.Event) {
 	// Apply deduplication and aggregation
-	if c.shouldDedupe(&rawEvent) {
+	if c.shouldDedupe(rawEvent) {
 		return
 	}
 
 	// Write to feed
-	c.writeFeedEvent(&rawEvent)
+	c.writeFeedEvent(rawEvent)
 }
 
 // shouldDedupe checks if an event should be deduplicated.
@@ -279,7 +254,7 @@ func (c *Curator) readRecentFeedEvents(window time.Duration) ([]FeedEvent, error
 	return result, nil
 }
 
-// readRecentEvents reads events from the events file within the given time window.
+// readRecentEvents reads activity events from the events file within the given time window.
 // ZFC: This is the observable state that replaces in-memory caching.
 // Reads at most tailReadSize bytes from the end to bound memory usage.
 func (c *Curator) readRecentEvents(window time.Duration) ([]events.Event, error) {
@@ -318,7 +293,7 @@ func (c *Curator) readRecentEvents(window time.Duration) ([]events.Event, error)
 	var result []events.Event
 	for scanner.Scan() {
 		var event events.Event
-		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || !event.IsActivity() {
 			continue
 		}
 		ts, err := time.Parse(time.RFC3339, event.Timestamp)
@@ -538,6 +513,41 @@ func (c *Curator) generateSummary(event *events.Event) string {
 		}
 		return "Multiple sessions died simultaneously"
 
+	case events.TypeEstop:
+		scope, _ := event.Payload["scope"].(string)
+		rule, _ := event.Payload["rule"].(string)
+		reason, _ := event.Payload["reason"].(string)
+		msg := "E-STOP"
+		if scope != "" && scope != "town" {
+			msg += " (" + scope + ")"
+		}
+		if rule != "" {
+			msg += " auto-tripped by " + rule
+		}
+		if reason != "" {
+			msg += ": " + reason
+		}
+		return msg
+
+	case events.TypePushRejected:
+		rig, _ := event.Payload["rig"].(string)
+		reason, _ := event.Payload["reason"].(string)
+		msg := fmt.Sprintf("Push by %s rejected", event.Actor)
+		if rig != "" {
+			msg = fmt.Sprintf("Push by %s to %s rejected", event.Actor, rig)
+		}
+		if reason != "" {
+			msg += ": " + reason
+		}
+		return msg
+
+	case events.TypeThaw:
+		scope, _ := event.Payload["scope"].(string)
+		if scope != "" && scope != "town" {
+			return fmt.Sprintf("E-stop cleared for %s", scope)
+		}
+		return "E-stop cleared"
+
 	default:
 		return fmt.Sprintf("%s: %s", event.Actor, event.Type)
 	}
@@ -34,6 +34,7 @@ type Formula struct {
 	Pour        bool        `toml:"pour"`        // If true, steps are materialized as sub-wisps with checkpoint recovery. Default false (inline/root-only).
 	Agent       string      `toml:"agent"`       // Default agent for all legs (GH#2118)
 	ReviewOnly  bool        `toml:"review_only"` // If true, all legs are analysis-only — no code commits expected (gt-kvf)
+	Paths       []string    `toml:"paths"`       // Files or directories work from this formula usually touches; used to predict convoy file conflicts
 
 	// Convoy-specific
 	Inputs    map[string]Input `toml:"inputs"`
@@ -890,6 +890,19 @@ func (g *Git) runMergeCheck(args ...string) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// ChangedFiles returns the files changed on head since it diverged from base
+// (git diff --name-only base...head).
+func (g *Git) ChangedFiles(base, head string) ([]string, error) {
+	out, err := g.run("diff", "--name-only", base+"..."+head)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
 // GetConflictingFiles returns the list of files with merge conflicts.
 // ZFC: Uses git's porcelain output (diff --diff-filter=U) instead of parsing stderr.
 // This is the proper way to detect conflicts without violating ZFC.
@@ -331,6 +331,47 @@ func TestRev(t *testing.T) {
 	}
 }
 
+func TestChangedFiles(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+
+	base, err := g.Rev("HEAD")
+	if err != nil {
+		t.Fatalf("Rev: %v", err)
+	}
+	if err := g.CheckoutNewBranch("feature", base); err != nil {
+		t.Fatalf("CheckoutNewBranch: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "pkg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pkg", "a.go"), []byte("package pkg\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.run("add", "."); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := g.run("commit", "-m", "feature"); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	files, err := g.ChangedFiles(base, "feature")
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	if strings.Join(files, ",") != "README.md,pkg/a.go" {
+		t.Errorf("files = %v, want [README.md pkg/a.go]", files)
+	}
+
+	files, err = g.ChangedFiles("feature", base)
+	if err != nil || len(files) != 0 {
+		t.Errorf("reverse diff = %v, %v; want no files", files, err)
+	}
+}
+
 func TestFetchBranch(t *testing.T) {
 	// Create a "remote" repo
 	remoteDir := t.TempDir()
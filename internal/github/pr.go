@@ -3,6 +3,8 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 )
 
 // ReviewState represents the overall review status of a PR.
@@ -223,3 +225,157 @@ func (c *Client) getPRNodeID(ctx context.Context, owner, repo string, prNumber i
 	}
 	return pr.NodeID, nil
 }
+
+// CheckState is the combined CI status of a commit.
+type CheckState string
+
+const (
+	ChecksPending CheckState = "pending"
+	ChecksSuccess CheckState = "success"
+	ChecksFailure CheckState = "failure"
+)
+
+// PRInfo holds the current state of a pull request.
+type PRInfo struct {
+	Number         int
+	URL            string
+	State          string // "open" or "closed"
+	Draft          bool
+	Merged         bool
+	MergeCommitSHA string
+	HeadSHA        string
+}
+
+// prResponse is the subset of the REST pull request object we decode.
+type prResponse struct {
+	Number         int    `json:"number"`
+	HTMLURL        string `json:"html_url"`
+	State          string `json:"state"`
+	Draft          bool   `json:"draft"`
+	Merged         bool   `json:"merged"`
+	MergeCommitSHA string `json:"merge_commit_sha"`
+	Head           struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+func (p prResponse) info() PRInfo {
+	return PRInfo{
+		Number:         p.Number,
+		URL:            p.HTMLURL,
+		State:          p.State,
+		Draft:          p.Draft,
+		Merged:         p.Merged,
+		MergeCommitSHA: p.MergeCommitSHA,
+		HeadSHA:        p.Head.SHA,
+	}
+}
+
+// GetPR returns the current state of a pull request.
+func (c *Client) GetPR(ctx context.Context, owner, repo string, prNumber int) (PRInfo, error) {
+	var pr prResponse
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, prNumber)
+	if err := c.restRequest(ctx, "GET", path, nil, &pr); err != nil {
+		return PRInfo{}, fmt.Errorf("get PR: %w", err)
+	}
+	return pr.info(), nil
+}
+
+// FindOpenPR returns the open pull request for head ("owner:branch"), if any.
+// The boolean result is false when no open PR exists for that head.
+func (c *Client) FindOpenPR(ctx context.Context, owner, repo, head string) (PRInfo, bool, error) {
+	var prs []prResponse
+	path := fmt.Sprintf("/repos/%s/%s/pulls?state=open&head=%s", owner, repo, url.QueryEscape(head))
+	if err := c.restRequest(ctx, "GET", path, nil, &prs); err != nil {
+		return PRInfo{}, false, fmt.Errorf("find open PR: %w", err)
+	}
+	if len(prs) == 0 {
+		return PRInfo{}, false, nil
+	}
+	return prs[0].info(), true, nil
+}
+
+// GetCheckState returns the combined CI state for a commit, folding together
+// legacy commit statuses and check runs. Any failure wins over pending, and
+// pending wins over success. A commit with no statuses and no check runs is
+// reported as success, since there is nothing to wait for.
+func (c *Client) GetCheckState(ctx context.Context, owner, repo, ref string) (CheckState, error) {
+	var combined struct {
+		State      string `json:"state"`
+		TotalCount int    `json:"total_count"`
+	}
+	path := fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, repo, ref)
+	if err := c.restRequest(ctx, "GET", path, nil, &combined); err != nil {
+		return "", fmt.Errorf("get check state: %w", err)
+	}
+
+	var runs struct {
+		CheckRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	path = fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs", owner, repo, ref)
+	if err := c.restRequest(ctx, "GET", path, nil, &runs); err != nil {
+		return "", fmt.Errorf("get check state: %w", err)
+	}
+
+	state := ChecksSuccess
+	// The combined status API reports "pending" when no statuses exist.
+	if combined.TotalCount > 0 {
+		switch combined.State {
+		case "failure", "error":
+			return ChecksFailure, nil
+		case "pending":
+			state = ChecksPending
+		}
+	}
+	for _, run := range runs.CheckRuns {
+		if run.Status != "completed" {
+			state = ChecksPending
+			continue
+		}
+		switch run.Conclusion {
+		case "success", "neutral", "skipped":
+		default:
+			return ChecksFailure, nil
+		}
+	}
+	return state, nil
+}
+
+// ParseRepoURL extracts the owner and repository name from a GitHub remote
+// URL. It accepts https (https://github.com/owner/repo.git), scp-style ssh
+// (git@github.com:owner/repo.git) and ssh:// forms.
+func ParseRepoURL(remote string) (owner, repo string, err error) {
+	s := strings.TrimSpace(remote)
+	s = strings.TrimSuffix(s, "/")
+	s = strings.TrimSuffix(s, ".git")
+
+	var path string
+	switch {
+	case strings.Contains(s, "://"):
+		u, parseErr := url.Parse(s)
+		if parseErr != nil {
+			return "", "", fmt.Errorf("github: parse remote %q: %w", remote, parseErr)
+		}
+		if !strings.HasSuffix(u.Hostname(), "github.com") {
+			return "", "", fmt.Errorf("github: remote %q is not a GitHub URL", remote)
+		}
+		path = strings.TrimPrefix(u.Path, "/")
+	case strings.Contains(s, "@") && strings.Contains(s, ":"):
+		host := s[strings.Index(s, "@")+1 : strings.Index(s, ":")]
+		if !strings.HasSuffix(host, "github.com") {
+			return "", "", fmt.Errorf("github: remote %q is not a GitHub URL", remote)
+		}
+		path = s[strings.Index(s, ":")+1:]
+	default:
+		return "", "", fmt.Errorf("github: unrecognized remote %q", remote)
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("github: remote %q does not name owner/repo", remote)
+	}
+	return parts[0], parts[1], nil
+}
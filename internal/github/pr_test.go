@@ -328,3 +328,129 @@ func TestConvertDraftToReady_GraphQLError(t *testing.T) {
 	err := c.ConvertDraftToReady(context.Background(), "octo", "repo", 42)
 	assert.ErrorContains(t, err, "Pull request is not a draft")
 }
+
+func TestGetPR(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/octo/repo/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"number":           42,
+			"html_url":         "https://github.com/octo/repo/pull/42",
+			"state":            "closed",
+			"draft":            false,
+			"merged":           true,
+			"merge_commit_sha": "abc123",
+			"head":             map[string]any{"sha": "def456"},
+		})
+	})
+
+	c, _ := newTestClient(t, mux)
+	pr, err := c.GetPR(t.Context(), "octo", "repo", 42)
+	require.NoError(t, err)
+	assert.True(t, pr.Merged)
+	assert.Equal(t, "abc123", pr.MergeCommitSHA)
+	assert.Equal(t, "def456", pr.HeadSHA)
+}
+
+func TestFindOpenPR(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/octo/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "octo:feat-branch", r.URL.Query().Get("head"))
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"number": 7, "html_url": "https://github.com/octo/repo/pull/7", "state": "open", "draft": true},
+		})
+	})
+
+	c, _ := newTestClient(t, mux)
+	pr, found, err := c.FindOpenPR(t.Context(), "octo", "repo", "octo:feat-branch")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 7, pr.Number)
+	assert.True(t, pr.Draft)
+}
+
+func TestGetCheckState(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		status   map[string]any
+		runs     []map[string]any
+		expected CheckState
+	}{
+		{
+			name:     "no checks configured",
+			status:   map[string]any{"state": "pending", "total_count": 0},
+			expected: ChecksSuccess,
+		},
+		{
+			name:     "status pending",
+			status:   map[string]any{"state": "pending", "total_count": 1},
+			expected: ChecksPending,
+		},
+		{
+			name:     "check run in progress",
+			status:   map[string]any{"state": "success", "total_count": 1},
+			runs:     []map[string]any{{"status": "in_progress"}},
+			expected: ChecksPending,
+		},
+		{
+			name:     "check run failed",
+			status:   map[string]any{"state": "pending", "total_count": 0},
+			runs:     []map[string]any{{"status": "completed", "conclusion": "success"}, {"status": "completed", "conclusion": "failure"}},
+			expected: ChecksFailure,
+		},
+		{
+			name:     "all green",
+			status:   map[string]any{"state": "success", "total_count": 2},
+			runs:     []map[string]any{{"status": "completed", "conclusion": "skipped"}},
+			expected: ChecksSuccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /repos/octo/repo/commits/sha1/status", func(w http.ResponseWriter, _ *http.Request) {
+				json.NewEncoder(w).Encode(tt.status)
+			})
+			mux.HandleFunc("GET /repos/octo/repo/commits/sha1/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+				json.NewEncoder(w).Encode(map[string]any{"check_runs": tt.runs})
+			})
+
+			c, _ := newTestClient(t, mux)
+			state, err := c.GetCheckState(t.Context(), "octo", "repo", "sha1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, state)
+		})
+	}
+}
+
+func TestParseRepoURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		url       string
+		owner     string
+		repo      string
+		expectErr bool
+	}{
+		{url: "https://github.com/octo/repo.git", owner: "octo", repo: "repo"},
+		{url: "https://github.com/octo/repo", owner: "octo", repo: "repo"},
+		{url: "git@github.com:octo/repo.git", owner: "octo", repo: "repo"},
+		{url: "ssh://git@github.com/octo/repo.git", owner: "octo", repo: "repo"},
+		{url: "https://gitlab.com/octo/repo.git", expectErr: true},
+		{url: "/srv/git/repo.git", expectErr: true},
+		{url: "https://github.com/octo", expectErr: true},
+	}
+	for _, tt := range tests {
+		owner, repo, err := ParseRepoURL(tt.url)
+		if tt.expectErr {
+			assert.Error(t, err, tt.url)
+			continue
+		}
+		require.NoError(t, err, tt.url)
+		assert.Equal(t, tt.owner, owner, tt.url)
+		assert.Equal(t, tt.repo, repo, tt.url)
+	}
+}
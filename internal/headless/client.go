@@ -0,0 +1,172 @@
+package headless
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// dialTimeout bounds how long a call waits for the daemon's socket.
+const dialTimeout = time.Second
+
+// callTimeout bounds a whole call. Kill may wait out killGrace before the
+// daemon replies.
+const callTimeout = requestTimeout + killGrace
+
+// Client talks to a town's headless supervisor. It implements the same
+// session operations as tmux.Tmux, so it can stand in as the town's session
+// backend.
+type Client struct {
+	townRoot string
+}
+
+// NewClient creates a client for the supervisor of the given town.
+func NewClient(townRoot string) *Client {
+	return &Client{townRoot: townRoot}
+}
+
+// IsAvailable reports whether the daemon is serving headless sessions.
+func (c *Client) IsAvailable() bool {
+	conn, err := net.DialTimeout("unix", SocketPath(c.townRoot), dialTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// call sends one request and waits for its reply.
+func (c *Client) call(req request) (*reply, error) {
+	conn, err := net.DialTimeout("unix", SocketPath(c.townRoot), dialTimeout)
+	if err != nil {
+		return nil, ErrNoSupervisor
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(callTimeout))
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("reading reply: %w", err)
+	}
+	var resp reply
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return nil, fmt.Errorf("reading reply: %w", err)
+	}
+	switch resp.Code {
+	case codeExists:
+		return nil, ErrSessionExists
+	case codeNotFound:
+		return nil, ErrSessionNotFound
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return &resp, nil
+}
+
+// NewSessionWithCommand starts command in a new headless session.
+func (c *Client) NewSessionWithCommand(name, workDir, command string) error {
+	return c.NewSessionWithCommandAndEnv(name, workDir, command, nil)
+}
+
+// NewSessionWithCommandAndEnv starts command in a new headless session with
+// env added to its process environment and environment table.
+func (c *Client) NewSessionWithCommandAndEnv(name, workDir, command string, env map[string]string) error {
+	_, err := c.call(request{Op: opNew, Session: name, WorkDir: workDir, Command: command, Env: env})
+	return err
+}
+
+// HasSession checks if a session exists. No daemon means no sessions.
+func (c *Client) HasSession(name string) (bool, error) {
+	resp, err := c.call(request{Op: opHas, Session: name})
+	if err != nil {
+		if errors.Is(err, ErrNoSupervisor) {
+			return false, nil
+		}
+		return false, err
+	}
+	return resp.Exists, nil
+}
+
+// ListSessions returns all session names. No daemon means no sessions.
+func (c *Client) ListSessions() ([]string, error) {
+	resp, err := c.call(request{Op: opList})
+	if err != nil {
+		if errors.Is(err, ErrNoSupervisor) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Sessions, nil
+}
+
+// KillSessionWithProcesses kills the session's process group. Killing a
+// session that does not exist is not an error.
+func (c *Client) KillSessionWithProcesses(name string) error {
+	_, err := c.call(request{Op: opKill, Session: name})
+	if errors.Is(err, ErrSessionNotFound) || errors.Is(err, ErrNoSupervisor) {
+		return nil
+	}
+	return err
+}
+
+// SendKeys types keys into the session and presses Enter after the
+// standard debounce.
+func (c *Client) SendKeys(session, keys string) error {
+	if _, err := c.call(request{Op: opSend, Session: session, Data: keys}); err != nil {
+		return err
+	}
+	time.Sleep(constants.DefaultDebounceMs * time.Millisecond)
+	_, err := c.call(request{Op: opSend, Session: session, Data: KeyBytes("Enter")})
+	return err
+}
+
+// SendKeysRaw sends a tmux-style key name (or literal text) without Enter.
+func (c *Client) SendKeysRaw(session, keys string) error {
+	_, err := c.call(request{Op: opSend, Session: session, Data: KeyBytes(keys)})
+	return err
+}
+
+// CapturePane returns the last lines of the session's output.
+func (c *Client) CapturePane(session string, lines int) (string, error) {
+	resp, err := c.call(request{Op: opCapture, Session: session, Lines: lines})
+	if err != nil {
+		return "", err
+	}
+	return resp.Output, nil
+}
+
+// SetEnvironment sets a variable in the session's environment table.
+func (c *Client) SetEnvironment(session, key, value string) error {
+	_, err := c.call(request{Op: opSetEnv, Session: session, Key: key, Value: value})
+	return err
+}
+
+// GetEnvironment reads a variable from the session's environment table.
+func (c *Client) GetEnvironment(session, key string) (string, error) {
+	resp, err := c.call(request{Op: opGetEnv, Session: session, Key: key})
+	if err != nil {
+		return "", err
+	}
+	return resp.Value, nil
+}
+
+// IsAgentAlive reports whether the session's agent is running. A headless
+// session's command is the agent and the session ends when it exits, so
+// this is session existence.
+func (c *Client) IsAgentAlive(session string) bool {
+	ok, _ := c.HasSession(session)
+	return ok
+}
@@ -0,0 +1,98 @@
+// Package headless runs agent sessions on pseudo-terminals supervised by the
+// daemon, for hosts without tmux.
+//
+// The daemon owns every headless session: it allocates the PTY, starts the
+// agent command in its own process group, keeps a rolling buffer of terminal
+// output and an environment table per session, and serves them over a Unix
+// socket. gt commands talk to the daemon through Client, which implements the
+// same create/kill/send-keys/capture/env/liveness operations as tmux.
+//
+// Headless sessions live and die with the daemon. There is nothing to attach
+// to; use gt peek (capture) and gt nudge (send-keys) to interact with them.
+package headless
+
+import (
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SocketFile is the path of the daemon's headless supervisor socket,
+// relative to the town root.
+const SocketFile = "daemon/headless.sock"
+
+// SocketPath returns the path of the supervisor socket for a town.
+func SocketPath(townRoot string) string {
+	return filepath.Join(townRoot, SocketFile)
+}
+
+// Common errors
+var (
+	ErrNoSupervisor    = errors.New("headless supervisor not running (is the daemon up?)")
+	ErrSessionExists   = errors.New("session already exists")
+	ErrSessionNotFound = errors.New("session not found")
+	ErrUnsupported     = errors.New("headless sessions are not supported on this platform")
+)
+
+// keyNames maps the tmux key names callers pass to SendKeysRaw onto the
+// bytes a terminal would send for them.
+var keyNames = map[string]string{
+	"Enter":  "\r",
+	"C-m":    "\r",
+	"Escape": "\x1b",
+	"Tab":    "\t",
+	"BSpace": "\x7f",
+	"Space":  " ",
+	"Up":     "\x1b[A",
+	"Down":   "\x1b[B",
+	"Right":  "\x1b[C",
+	"Left":   "\x1b[D",
+	"Home":   "\x1b[H",
+	"End":    "\x1b[F",
+}
+
+// KeyBytes translates a tmux key name ("Enter", "C-c", "Escape") into the
+// bytes written to the PTY. Anything that is not a key name is sent as-is,
+// matching tmux send-keys.
+func KeyBytes(key string) string {
+	if b, ok := keyNames[key]; ok {
+		return b
+	}
+	if len(key) == 3 && (key[:2] == "C-" || key[:2] == "c-") {
+		c := key[2]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c >= 'a' && c <= 'z' {
+			return string(rune(c & 0x1f))
+		}
+	}
+	return key
+}
+
+// ansiPattern matches CSI and OSC escape sequences and two-byte escapes.
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?<>=]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// renderLines turns raw terminal output into plain text lines, roughly as a
+// terminal would show them: escape sequences are dropped, and a carriage
+// return overwrites the line it returns to. Trailing blank lines are
+// trimmed. If n > 0 only the last n lines are returned.
+func renderLines(raw []byte, n int) []string {
+	text := ansiPattern.ReplaceAllString(string(raw), "")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if j := strings.LastIndexByte(line, '\r'); j >= 0 {
+			line = line[j+1:]
+		}
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,179 @@
+package headless
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requirePTY(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("headless sessions need a Unix PTY")
+	}
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestKeyBytes(t *testing.T) {
+	tests := map[string]string{
+		"Enter":  "\r",
+		"C-c":    "\x03",
+		"C-U":    "\x15",
+		"Escape": "\x1b",
+		"Up":     "\x1b[A",
+		"hello":  "hello",
+		"C-":     "C-",
+	}
+	for key, want := range tests {
+		if got := KeyBytes(key); got != want {
+			t.Errorf("KeyBytes(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestRenderLines(t *testing.T) {
+	raw := []byte("\x1b[1mbold\x1b[0m line\r\nprogress 10%\rprogress 100%\r\n\x1b]0;title\x07third\r\n\r\n")
+	got := renderLines(raw, 0)
+	want := []string{"bold line", "progress 100%", "third"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("renderLines = %q, want %q", got, want)
+	}
+	if got := renderLines(raw, 2); len(got) != 2 || got[0] != "progress 100%" {
+		t.Errorf("renderLines(n=2) = %q", got)
+	}
+}
+
+func TestSupervisorLifecycle(t *testing.T) {
+	requirePTY(t)
+	s := NewSupervisor()
+	defer s.Shutdown()
+
+	// cat echoes what we type, so the capture shows our keys arriving.
+	if err := s.Start("gt-test-cat", t.TempDir(), "cat", map[string]string{"GT_ROLE": "polecat"}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := s.Start("gt-test-cat", t.TempDir(), "cat", nil); !errors.Is(err, ErrSessionExists) {
+		t.Fatalf("duplicate Start: err = %v, want ErrSessionExists", err)
+	}
+	if got := s.List(); len(got) != 1 || got[0] != "gt-test-cat" {
+		t.Fatalf("List = %v", got)
+	}
+
+	if err := s.Send("gt-test-cat", "hello headless"+KeyBytes("Enter")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	waitFor(t, "echoed input", func() bool {
+		out, _ := s.Capture("gt-test-cat", 10)
+		return strings.Contains(out, "hello headless")
+	})
+
+	if v, err := s.GetEnv("gt-test-cat", "GT_ROLE"); err != nil || v != "polecat" {
+		t.Errorf("GetEnv(GT_ROLE) = %q, %v", v, err)
+	}
+	if err := s.SetEnv("gt-test-cat", "GT_PANE_ID", "%1"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := s.GetEnv("gt-test-cat", "GT_PANE_ID"); v != "%1" {
+		t.Errorf("GetEnv after SetEnv = %q", v)
+	}
+	if _, err := s.GetEnv("gt-test-cat", "MISSING"); err == nil {
+		t.Error("GetEnv of unset variable should fail")
+	}
+
+	if err := s.Kill("gt-test-cat"); err != nil {
+		t.Fatalf("Kill: %v", err)
+	}
+	if s.Has("gt-test-cat") {
+		t.Error("session should be gone after Kill")
+	}
+	if err := s.Kill("gt-test-cat"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("second Kill: err = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestSupervisorSessionEndsWithCommand(t *testing.T) {
+	requirePTY(t)
+	s := NewSupervisor()
+	defer s.Shutdown()
+
+	if err := s.Start("gt-test-echo", t.TempDir(), "echo done", nil); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "session exit", func() bool { return !s.Has("gt-test-echo") })
+}
+
+func TestClientServer(t *testing.T) {
+	requirePTY(t)
+	town := t.TempDir()
+	c := NewClient(town)
+
+	// No daemon: nothing exists, and starting a session says why it can't.
+	if ok, err := c.HasSession("gt-x"); ok || err != nil {
+		t.Fatalf("HasSession without daemon = %v, %v", ok, err)
+	}
+	if err := c.NewSessionWithCommand("gt-x", town, "cat"); !errors.Is(err, ErrNoSupervisor) {
+		t.Fatalf("NewSessionWithCommand without daemon: err = %v", err)
+	}
+
+	srv := NewServer(town, nil)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+	if err := NewServer(town, nil).Start(); err == nil {
+		t.Fatal("second server on the same town should fail")
+	}
+
+	if !c.IsAvailable() {
+		t.Fatal("client should see the server")
+	}
+	if err := c.NewSessionWithCommandAndEnv("gt-x", town, "cat", map[string]string{"GT_AGENT": "claude"}); err != nil {
+		t.Fatalf("NewSessionWithCommandAndEnv: %v", err)
+	}
+	if err := c.NewSessionWithCommand("gt-x", town, "cat"); !errors.Is(err, ErrSessionExists) {
+		t.Fatalf("duplicate session: err = %v, want ErrSessionExists", err)
+	}
+	if !c.IsAgentAlive("gt-x") {
+		t.Error("IsAgentAlive = false for a running session")
+	}
+	if v, err := c.GetEnvironment("gt-x", "GT_AGENT"); err != nil || v != "claude" {
+		t.Errorf("GetEnvironment = %q, %v", v, err)
+	}
+	if err := c.SendKeysRaw("gt-x", "ping"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SendKeysRaw("gt-x", "Enter"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "captured output", func() bool {
+		out, _ := c.CapturePane("gt-x", 5)
+		return strings.Contains(out, "ping")
+	})
+	if names, _ := c.ListSessions(); len(names) != 1 || names[0] != "gt-x" {
+		t.Errorf("ListSessions = %v", names)
+	}
+
+	if err := c.KillSessionWithProcesses("gt-x"); err != nil {
+		t.Fatalf("KillSessionWithProcesses: %v", err)
+	}
+	if ok, _ := c.HasSession("gt-x"); ok {
+		t.Error("session still exists after kill")
+	}
+	if _, err := c.CapturePane("gt-x", 5); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("CapturePane after kill: err = %v, want ErrSessionNotFound", err)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !linux && !darwin
+
+package headless
+
+import (
+	"os"
+	"os/exec"
+)
+
+func startInPTY(cmd *exec.Cmd) (*os.File, error) {
+	return nil, ErrUnsupported
+}
+
+func terminateGroup(p *os.Process) error {
+	return p.Kill()
+}
+
+func killGroup(p *os.Process) error {
+	return p.Kill()
+}
@@ -0,0 +1,50 @@
+//go:build linux || darwin
+
+package headless
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// ptyRows and ptyCols size every headless terminal. Agents render for this
+// size; capture output is wrapped accordingly.
+const (
+	ptyRows = 50
+	ptyCols = 200
+)
+
+// startInPTY starts cmd as a session leader with a new PTY as its
+// controlling terminal and stdio, and returns the master side.
+func startInPTY(cmd *exec.Cmd) (*os.File, error) {
+	master, slave, err := openPTY()
+	if err != nil {
+		return nil, err
+	}
+	defer slave.Close()
+
+	_ = unix.IoctlSetWinsize(int(slave.Fd()), unix.TIOCSWINSZ, &unix.Winsize{Row: ptyRows, Col: ptyCols})
+
+	cmd.Stdin = slave
+	cmd.Stdout = slave
+	cmd.Stderr = slave
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+	if err := cmd.Start(); err != nil {
+		_ = master.Close()
+		return nil, err
+	}
+	return master, nil
+}
+
+// terminateGroup sends SIGTERM to the process group led by p.
+func terminateGroup(p *os.Process) error {
+	return syscall.Kill(-p.Pid, syscall.SIGTERM)
+}
+
+// killGroup sends SIGKILL to the process group led by p.
+func killGroup(p *os.Process) error {
+	return syscall.Kill(-p.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,46 @@
+//go:build darwin
+
+package headless
+
+import (
+	"bytes"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// openPTY allocates a pseudo-terminal pair from /dev/ptmx.
+func openPTY() (master, slave *os.File, err error) {
+	fd, err := unix.Open("/dev/ptmx", unix.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	master = os.NewFile(uintptr(fd), "/dev/ptmx")
+
+	if err := unix.IoctlSetInt(fd, unix.TIOCPTYGRANT, 0); err != nil {
+		_ = master.Close()
+		return nil, nil, err
+	}
+	if err := unix.IoctlSetInt(fd, unix.TIOCPTYUNLK, 0); err != nil {
+		_ = master.Close()
+		return nil, nil, err
+	}
+	buf := make([]byte, 128)
+	//nolint:staticcheck // SA1019: no ioctl wrapper takes a buffer for TIOCPTYGNAME
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), uintptr(unix.TIOCPTYGNAME), uintptr(unsafe.Pointer(&buf[0]))); errno != 0 {
+		_ = master.Close()
+		return nil, nil, errno
+	}
+	if i := bytes.IndexByte(buf, 0); i >= 0 {
+		buf = buf[:i]
+	}
+
+	name := string(buf)
+	sfd, err := unix.Open(name, unix.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		_ = master.Close()
+		return nil, nil, err
+	}
+	return master, os.NewFile(uintptr(sfd), name), nil
+}
@@ -0,0 +1,205 @@
+package headless
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// request is the single JSON line a client writes per connection.
+type request struct {
+	Op      string            `json:"op"`
+	Session string            `json:"session,omitempty"`
+	WorkDir string            `json:"work_dir,omitempty"`
+	Command string            `json:"command,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	Data    string            `json:"data,omitempty"`
+	Key     string            `json:"key,omitempty"`
+	Value   string            `json:"value,omitempty"`
+	Lines   int               `json:"lines,omitempty"`
+}
+
+// Request operations.
+const (
+	opNew     = "new"
+	opHas     = "has"
+	opList    = "list"
+	opKill    = "kill"
+	opSend    = "send"
+	opCapture = "capture"
+	opSetEnv  = "setenv"
+	opGetEnv  = "getenv"
+)
+
+// reply answers a request. Code carries sentinel errors across the socket.
+type reply struct {
+	Error    string   `json:"error,omitempty"`
+	Code     string   `json:"code,omitempty"`
+	Exists   bool     `json:"exists,omitempty"`
+	Sessions []string `json:"sessions,omitempty"`
+	Output   string   `json:"output,omitempty"`
+	Value    string   `json:"value,omitempty"`
+}
+
+const (
+	codeExists   = "exists"
+	codeNotFound = "not_found"
+)
+
+// requestTimeout bounds how long a connection may take to send its request
+// and how long the server waits to write the reply.
+const requestTimeout = 5 * time.Second
+
+// Server hosts the supervisor socket. The daemon runs one per town when the
+// headless backend is selected.
+type Server struct {
+	townRoot   string
+	logger     *log.Logger
+	supervisor *Supervisor
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	ln     net.Listener
+}
+
+// NewServer creates a supervisor server for a town. A nil logger discards
+// log output.
+func NewServer(townRoot string, logger *log.Logger) *Server {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Server{
+		townRoot:   townRoot,
+		logger:     logger,
+		supervisor: NewSupervisor(),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start listens on the town's socket and begins serving requests. A stale
+// socket left by a crashed daemon is replaced; a live one is an error.
+func (s *Server) Start() error {
+	path := SocketPath(s.townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating socket directory: %w", err)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("headless supervisor already served at %s", path)
+	}
+	_ = os.Remove(path)
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", path, err)
+	}
+	s.ln = ln
+
+	s.wg.Add(1)
+	go s.accept()
+	return nil
+}
+
+// Stop closes the socket and kills every headless session.
+func (s *Server) Stop() {
+	s.cancel()
+	if s.ln != nil {
+		_ = s.ln.Close()
+	}
+	s.wg.Wait()
+	s.supervisor.Shutdown()
+	if s.ln != nil {
+		_ = os.Remove(SocketPath(s.townRoot))
+	}
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if s.ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				s.logger.Printf("headless: accept failed: %v", err)
+			}
+			return
+		}
+		s.wg.Add(1)
+		go s.serve(conn)
+	}
+}
+
+// serve handles one request.
+func (s *Server) serve(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(requestTimeout))
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return
+	}
+
+	var req request
+	var resp reply
+	if err := json.Unmarshal(line, &req); err != nil {
+		resp.Error = fmt.Sprintf("invalid request: %v", err)
+	} else {
+		resp = s.handle(req)
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(requestTimeout))
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+func (s *Server) handle(req request) reply {
+	sup := s.supervisor
+	var resp reply
+	var err error
+	switch req.Op {
+	case opNew:
+		err = sup.Start(req.Session, req.WorkDir, req.Command, req.Env)
+		if err == nil {
+			s.logger.Printf("headless: started %s", req.Session)
+		}
+	case opHas:
+		resp.Exists = sup.Has(req.Session)
+	case opList:
+		resp.Sessions = sup.List()
+	case opKill:
+		err = sup.Kill(req.Session)
+		if err == nil {
+			s.logger.Printf("headless: killed %s", req.Session)
+		}
+	case opSend:
+		err = sup.Send(req.Session, req.Data)
+	case opCapture:
+		resp.Output, err = sup.Capture(req.Session, req.Lines)
+	case opSetEnv:
+		err = sup.SetEnv(req.Session, req.Key, req.Value)
+	case opGetEnv:
+		resp.Value, err = sup.GetEnv(req.Session, req.Key)
+	default:
+		err = fmt.Errorf("unknown op %q", req.Op)
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		switch {
+		case errors.Is(err, ErrSessionExists):
+			resp.Code = codeExists
+		case errors.Is(err, ErrSessionNotFound):
+			resp.Code = codeNotFound
+		}
+	}
+	return resp
+}
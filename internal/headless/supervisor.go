@@ -0,0 +1,234 @@
+package headless
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// outputLimit is how much terminal output each session keeps for capture.
+const outputLimit = 256 * 1024
+
+// killGrace is how long a session's process group gets to exit after
+// SIGTERM before it is sent SIGKILL.
+const killGrace = 2 * time.Second
+
+// Supervisor owns the daemon's headless sessions.
+type Supervisor struct {
+	mu       sync.Mutex
+	sessions map[string]*ptySession
+}
+
+// ptySession is one agent command running on a PTY.
+type ptySession struct {
+	name string
+	cmd  *exec.Cmd
+	pty  *os.File
+	done chan struct{}
+
+	mu     sync.Mutex
+	env    map[string]string
+	output []byte
+}
+
+// NewSupervisor creates an empty supervisor.
+func NewSupervisor() *Supervisor {
+	return &Supervisor{sessions: make(map[string]*ptySession)}
+}
+
+// Start runs command under /bin/sh on a new PTY in workDir. env is added to
+// the daemon's environment for the process and seeds the session's
+// environment table.
+func (s *Supervisor) Start(name, workDir, command string, env map[string]string) error {
+	if name == "" {
+		return fmt.Errorf("session name is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sessions[name]; ok {
+		return ErrSessionExists
+	}
+
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Dir = workDir
+	cmd.Env = os.Environ()
+	table := make(map[string]string, len(env))
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+		table[k] = v
+	}
+
+	pty, err := startInPTY(cmd)
+	if err != nil {
+		return fmt.Errorf("starting %s: %w", name, err)
+	}
+	ps := &ptySession{
+		name: name,
+		cmd:  cmd,
+		pty:  pty,
+		done: make(chan struct{}),
+		env:  table,
+	}
+	s.sessions[name] = ps
+	go s.run(ps)
+	return nil
+}
+
+// run copies the session's output into its buffer until the process exits,
+// then forgets the session, as tmux does when a pane's command exits.
+func (s *Supervisor) run(ps *ptySession) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := ps.pty.Read(buf)
+		if n > 0 {
+			ps.appendOutput(buf[:n])
+		}
+		if err != nil {
+			break
+		}
+	}
+	_ = ps.cmd.Wait()
+	_ = ps.pty.Close()
+
+	s.mu.Lock()
+	if s.sessions[ps.name] == ps {
+		delete(s.sessions, ps.name)
+	}
+	s.mu.Unlock()
+	close(ps.done)
+}
+
+func (ps *ptySession) appendOutput(b []byte) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.output = append(ps.output, b...)
+	if over := len(ps.output) - outputLimit; over > 0 {
+		ps.output = append(ps.output[:0], ps.output[over:]...)
+	}
+}
+
+func (s *Supervisor) get(name string) (*ptySession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ps, ok := s.sessions[name]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	return ps, nil
+}
+
+// Has reports whether a session exists.
+func (s *Supervisor) Has(name string) bool {
+	_, err := s.get(name)
+	return err == nil
+}
+
+// List returns the names of all sessions, sorted.
+func (s *Supervisor) List() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.sessions))
+	for name := range s.sessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Kill terminates a session's whole process group and waits for it to go.
+func (s *Supervisor) Kill(name string) error {
+	ps, err := s.get(name)
+	if err != nil {
+		return err
+	}
+	ps.kill()
+	return nil
+}
+
+func (ps *ptySession) kill() {
+	_ = terminateGroup(ps.cmd.Process)
+	select {
+	case <-ps.done:
+		return
+	case <-time.After(killGrace):
+	}
+	_ = killGroup(ps.cmd.Process)
+	<-ps.done
+}
+
+// Send writes raw bytes to the session's terminal.
+func (s *Supervisor) Send(name, data string) error {
+	ps, err := s.get(name)
+	if err != nil {
+		return err
+	}
+	_, err = ps.pty.WriteString(data)
+	return err
+}
+
+// Capture returns the last lines of the session's output as plain text.
+// lines <= 0 returns everything still buffered.
+func (s *Supervisor) Capture(name string, lines int) (string, error) {
+	ps, err := s.get(name)
+	if err != nil {
+		return "", err
+	}
+	ps.mu.Lock()
+	raw := append([]byte(nil), ps.output...)
+	ps.mu.Unlock()
+	return strings.Join(renderLines(raw, lines), "\n"), nil
+}
+
+// SetEnv sets a variable in the session's environment table. Like tmux
+// set-environment, it does not reach the already-running process.
+func (s *Supervisor) SetEnv(name, key, value string) error {
+	ps, err := s.get(name)
+	if err != nil {
+		return err
+	}
+	ps.mu.Lock()
+	ps.env[key] = value
+	ps.mu.Unlock()
+	return nil
+}
+
+// GetEnv reads a variable from the session's environment table.
+func (s *Supervisor) GetEnv(name, key string) (string, error) {
+	ps, err := s.get(name)
+	if err != nil {
+		return "", err
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	v, ok := ps.env[key]
+	if !ok {
+		return "", fmt.Errorf("%s: unknown variable: %s", name, key)
+	}
+	return v, nil
+}
+
+// Shutdown kills every session. The daemon calls it on exit, since nothing
+// can reach a headless session once its supervisor is gone.
+func (s *Supervisor) Shutdown() {
+	s.mu.Lock()
+	all := make([]*ptySession, 0, len(s.sessions))
+	for _, ps := range s.sessions {
+		all = append(all, ps)
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, ps := range all {
+		wg.Add(1)
+		go func(ps *ptySession) {
+			defer wg.Done()
+			ps.kill()
+		}(ps)
+	}
+	wg.Wait()
+}
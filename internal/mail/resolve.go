@@ -144,6 +144,15 @@ func (r *Resolver) validateAgentAddress(address string) error {
 	case constants.RoleMayor + "/", constants.RoleMayor, constants.RoleDeacon + "/", constants.RoleDeacon, "overseer":
 		return nil
 	}
+	if handle, ok := config.ParseOverseerAddress(normalized); ok {
+		if r.townRoot == "" {
+			return nil
+		}
+		if roster, err := config.LoadTownOverseers(r.townRoot); err == nil && roster.Member(handle) != nil {
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrUnknownRecipient, address)
+	}
 
 	parts := strings.SplitN(normalized, "/", 3)
 	if len(parts) < 2 || parts[1] == "" {
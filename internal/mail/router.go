@@ -247,6 +247,9 @@ func (r *Router) buildLabels(msg *Message) []string {
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
+	if msg.TraceParent != "" {
+		labels = append(labels, "traceparent:"+msg.TraceParent)
+	}
 	for _, cc := range msg.CC {
 		ccIdentity := AddressToIdentity(cc)
 		labels = append(labels, "cc:"+ccIdentity)
@@ -254,10 +257,10 @@ func (r *Router) buildLabels(msg *Message) []string {
 	return labels
 }
 
-// isTownLevelAddress returns true if the address is for a town-level agent or the overseer.
+// isTownLevelAddress returns true if the address is for a town-level agent or an overseer.
 func isTownLevelAddress(address string) bool {
 	addr := strings.TrimSuffix(address, "/")
-	return addr == constants.RoleMayor || addr == constants.RoleDeacon || addr == "overseer"
+	return addr == constants.RoleMayor || addr == constants.RoleDeacon || config.IsOverseerAddress(addr)
 }
 
 // isGroupAddress returns true if the address is a @group address.
@@ -270,11 +273,12 @@ func isGroupAddress(address string) bool {
 type GroupType string
 
 const (
-	GroupTypeRig      GroupType = "rig"      // @rig/<rigname> - all agents in a rig
-	GroupTypeTown     GroupType = "town"     // @town - all town-level agents
-	GroupTypeRole     GroupType = "role"     // @witnesses, @dogs, etc. - all agents of a role
-	GroupTypeRigRole  GroupType = "rig-role" // @crew/<rigname>, @polecats/<rigname> - role in a rig
-	GroupTypeOverseer GroupType = "overseer" // @overseer - human operator
+	GroupTypeRig       GroupType = "rig"       // @rig/<rigname> - all agents in a rig
+	GroupTypeTown      GroupType = "town"      // @town - all town-level agents
+	GroupTypeRole      GroupType = "role"      // @witnesses, @dogs, etc. - all agents of a role
+	GroupTypeRigRole   GroupType = "rig-role"  // @crew/<rigname>, @polecats/<rigname> - role in a rig
+	GroupTypeOverseer  GroupType = "overseer"  // @overseer - on-call human operator
+	GroupTypeOverseers GroupType = "overseers" // @overseers - every human operator
 )
 
 // ParsedGroup represents a parsed @group address.
@@ -295,7 +299,8 @@ type ParsedGroup struct {
 //   - @crew/<rigname>: Crew workers in a specific rig
 //   - @polecats/<rigname>: Polecats in a specific rig
 //   - @dogs: All Deacon dogs
-//   - @overseer: Human operator (special case)
+//   - @overseer: On-call human operator (special case)
+//   - @overseers: Every human operator on the roster
 func parseGroupAddress(address string) *ParsedGroup {
 	if !isGroupAddress(address) {
 		return nil
@@ -308,6 +313,8 @@ func parseGroupAddress(address string) *ParsedGroup {
 	switch group {
 	case "overseer":
 		return &ParsedGroup{Type: GroupTypeOverseer, Original: address}
+	case "overseers":
+		return &ParsedGroup{Type: GroupTypeOverseers, Original: address}
 	case "town":
 		return &ParsedGroup{Type: GroupTypeTown, Original: address}
 	case "witnesses":
@@ -608,6 +615,8 @@ func (r *Router) resolveGroup(group *ParsedGroup) ([]string, error) {
 	switch group.Type {
 	case GroupTypeOverseer:
 		return r.resolveOverseer()
+	case GroupTypeOverseers:
+		return r.resolveOverseers()
 	case GroupTypeTown:
 		return r.resolveTownAgents()
 	case GroupTypeRole:
@@ -621,16 +630,30 @@ func (r *Router) resolveGroup(group *ParsedGroup) ([]string, error) {
 	}
 }
 
-// resolveOverseer resolves @overseer to the human operator's address.
-// Loads the overseer config and returns "overseer" as the address.
+// resolveOverseer resolves @overseer to the on-call human's address.
+// Towns with an overseer roster (mayor/overseers.json) resolve to the
+// on-call member's mailbox ("overseer/<handle>"); towns with a single
+// overseer resolve to "overseer".
 func (r *Router) resolveOverseer() ([]string, error) {
 	if r.townRoot == "" {
 		return nil, errors.New("town root not set, cannot resolve @overseer")
 	}
 
+	roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(r.townRoot))
+	if err == nil && len(roster.Overseers) > 0 {
+		member, err := roster.OnCall(time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("resolving @overseer: %w", err)
+		}
+		return []string{config.OverseerMemberAddress(member.Handle)}, nil
+	}
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return nil, fmt.Errorf("resolving @overseer: %w", err)
+	}
+
 	// Load overseer config to verify it exists
 	configPath := config.OverseerConfigPath(r.townRoot)
-	_, err := config.LoadOverseerConfig(configPath)
+	_, err = config.LoadOverseerConfig(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("resolving @overseer: %w", err)
 	}
@@ -639,6 +662,46 @@ func (r *Router) resolveOverseer() ([]string, error) {
 	return []string{"overseer"}, nil
 }
 
+// resolveOverseers resolves @overseers to every roster member's mailbox,
+// or to "overseer" in towns with a single overseer.
+func (r *Router) resolveOverseers() ([]string, error) {
+	if r.townRoot == "" {
+		return nil, errors.New("town root not set, cannot resolve @overseers")
+	}
+
+	roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(r.townRoot))
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			return r.resolveOverseer()
+		}
+		return nil, fmt.Errorf("resolving @overseers: %w", err)
+	}
+	if len(roster.Overseers) == 0 {
+		return r.resolveOverseer()
+	}
+
+	addresses := make([]string, 0, len(roster.Overseers))
+	for _, m := range roster.Overseers {
+		addresses = append(addresses, config.OverseerMemberAddress(m.Handle))
+	}
+	return addresses, nil
+}
+
+// OnCallOverseer returns the mailbox of the overseer currently on call:
+// "overseer/<handle>" in towns with a roster, otherwise "overseer". Unlike
+// @overseer it never fails, so callers forwarding urgent mail always have a
+// destination.
+func OnCallOverseer(townRoot string) string {
+	if townRoot != "" {
+		if roster, err := config.LoadOverseerRoster(config.OverseerRosterPath(townRoot)); err == nil && len(roster.Overseers) > 0 {
+			if member, err := roster.OnCall(time.Now()); err == nil {
+				return config.OverseerMemberAddress(member.Handle)
+			}
+		}
+	}
+	return config.OverseerAddress
+}
+
 // resolveTownAgents resolves @town to all town-level agents (mayor, deacon).
 func (r *Router) resolveTownAgents() ([]string, error) {
 	// Town-level agents have rig=null in their description
@@ -859,6 +922,11 @@ func (r *Router) shouldBeWisp(msg *Message) bool {
 // - Queues (queue:name) - stores single message for worker claiming
 // - Announces (announce:name) - bulletin board, no claiming, retention-limited
 func (r *Router) Send(msg *Message) error {
+	// Carry the sender's bead lifecycle trace (if any) with the message.
+	if msg.TraceParent == "" {
+		msg.TraceParent = os.Getenv(telemetry.EnvTraceParent)
+	}
+
 	// Check for mailing list address
 	if isListAddress(msg.To) {
 		return r.sendToList(msg)
@@ -932,6 +1000,9 @@ func (r *Router) validateRecipient(identity string) error {
 	if identity == "overseer" {
 		return nil
 	}
+	if handle, ok := config.ParseOverseerAddress(identity); ok {
+		return r.validateOverseerHandle(handle)
+	}
 
 	// Well-known town-level singletons always valid
 	switch identity {
@@ -1002,6 +1073,23 @@ func (r *Router) validateRecipient(identity string) error {
 	return fmt.Errorf("no agent found")
 }
 
+// validateOverseerHandle checks that an "overseer/<handle>" recipient is on
+// the town's overseer roster. Without a town root there is nothing to check
+// against, so any handle is accepted.
+func (r *Router) validateOverseerHandle(handle string) error {
+	if r.townRoot == "" {
+		return nil
+	}
+	roster, err := config.LoadTownOverseers(r.townRoot)
+	if err != nil {
+		return fmt.Errorf("no overseer roster: %w", err)
+	}
+	if roster.Member(handle) == nil {
+		return fmt.Errorf("no overseer %q on the roster", handle)
+	}
+	return nil
+}
+
 // validateAgentWorkspace checks if an agent's workspace directory exists on disk.
 // Used as a fallback when the agent isn't found in the bead registry.
 func (r *Router) validateAgentWorkspace(identity string) bool {
@@ -1265,6 +1353,9 @@ func (r *Router) sendToQueue(msg *Message) error {
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
+	if msg.TraceParent != "" {
+		labels = append(labels, "traceparent:"+msg.TraceParent)
+	}
 	for _, cc := range msg.CC {
 		ccIdentity := AddressToIdentity(cc)
 		labels = append(labels, "cc:"+ccIdentity)
@@ -1349,6 +1440,9 @@ func (r *Router) sendToAnnounce(msg *Message) error {
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
+	if msg.TraceParent != "" {
+		labels = append(labels, "traceparent:"+msg.TraceParent)
+	}
 	for _, cc := range msg.CC {
 		ccIdentity := AddressToIdentity(cc)
 		labels = append(labels, "cc:"+ccIdentity)
@@ -1435,6 +1529,9 @@ func (r *Router) sendToChannel(msg *Message) error {
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
+	if msg.TraceParent != "" {
+		labels = append(labels, "traceparent:"+msg.TraceParent)
+	}
 	for _, cc := range msg.CC {
 		ccIdentity := AddressToIdentity(cc)
 		labels = append(labels, "cc:"+ccIdentity)
@@ -1623,7 +1720,7 @@ func (r *Router) notifyRecipient(msg *Message) error {
 
 		// Overseer is a human operator - use a visible banner instead of NudgeSession
 		// (which types into Claude's input and would disrupt the human's terminal).
-		if msg.To == "overseer" {
+		if config.IsOverseerAddress(msg.To) {
 			return r.tmux.SendNotificationBanner(sessionID, msg.From, msg.Subject)
 		}
 
@@ -1654,12 +1751,13 @@ func (r *Router) notifyRecipient(msg *Message) error {
 			// Timeout (agent busy) — queue for cooperative delivery
 			// at the next turn boundary.
 			if err := nudge.Enqueue(r.townRoot, sessionID, nudge.QueuedNudge{
-				Sender:   msg.From,
-				Message:  notification,
-				Priority: priority,
-				Kind:     nudgeKindForMessage(msg),
-				ThreadID: msg.ThreadID,
-				Severity: prioritySeverityLabel(msg.Priority),
+				Sender:      msg.From,
+				Message:     notification,
+				Priority:    priority,
+				Kind:        nudgeKindForMessage(msg),
+				ThreadID:    msg.ThreadID,
+				Severity:    prioritySeverityLabel(msg.Priority),
+				TraceParent: msg.TraceParent,
 			}); err != nil {
 				return err
 			}
@@ -1678,12 +1776,13 @@ func (r *Router) notifyRecipient(msg *Message) error {
 	if r.townRoot != "" && len(sessionIDs) > 0 {
 		notification := formatNotificationMessage(msg)
 		return nudge.Enqueue(r.townRoot, sessionIDs[0], nudge.QueuedNudge{
-			Sender:   msg.From,
-			Message:  notification,
-			Priority: nudgePriorityForMailPriority(msg.Priority),
-			Kind:     nudgeKindForMessage(msg),
-			ThreadID: msg.ThreadID,
-			Severity: prioritySeverityLabel(msg.Priority),
+			Sender:      msg.From,
+			Message:     notification,
+			Priority:    nudgePriorityForMailPriority(msg.Priority),
+			Kind:        nudgeKindForMessage(msg),
+			ThreadID:    msg.ThreadID,
+			Severity:    prioritySeverityLabel(msg.Priority),
+			TraceParent: msg.TraceParent,
 		})
 	}
 
@@ -1787,8 +1886,8 @@ func (r *Router) isRecipientMuted(address string) bool {
 // Returns empty string if the address cannot be converted.
 func addressToAgentBeadID(address string) string {
 	switch {
-	case address == "overseer":
-		return "" // Overseer is a human, no agent bead
+	case config.IsOverseerAddress(address):
+		return "" // Overseers are humans, no agent bead
 	case strings.HasPrefix(address, constants.RoleMayor):
 		return session.MayorSessionName()
 	case strings.HasPrefix(address, constants.RoleDeacon):
@@ -1829,8 +1928,9 @@ func addressToAgentBeadID(address string) string {
 // This supersedes the approach in PR #896 which only handled slash-to-dash
 // conversion but didn't address the crew/polecat ambiguity.
 func AddressToSessionIDs(address string) []string {
-	// Overseer address: "overseer" (human operator)
-	if address == "overseer" {
+	// Overseer address: "overseer" or "overseer/<handle>" (human operators
+	// share the overseer session)
+	if config.IsOverseerAddress(address) {
 		return []string{session.OverseerSessionName()}
 	}
 
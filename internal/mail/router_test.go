@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/nudge"
 	"github.com/steveyegge/gastown/internal/session"
@@ -147,6 +148,7 @@ func TestIsTownLevelAddress(t *testing.T) {
 		{"deacon", true},
 		{"deacon/", true},
 		{"overseer", true},
+		{"overseer/alice", true},
 		{"gastown/refinery", false},
 		{"gastown/polecats/Toast", false},
 		{"gastown/", false},
@@ -178,6 +180,7 @@ func TestAddressToSessionIDs(t *testing.T) {
 	}{
 		// Overseer (human operator) - single session
 		{"overseer", []string{"hq-overseer"}},
+		{"overseer/alice", []string{"hq-overseer"}},
 
 		// Town-level addresses - single session
 		{"mayor", []string{"hq-mayor"}},
@@ -838,6 +841,7 @@ func TestParseGroupAddress(t *testing.T) {
 	}{
 		// Special patterns
 		{"@overseer", GroupTypeOverseer, "", "", false},
+		{"@overseers", GroupTypeOverseers, "", "", false},
 		{"@town", GroupTypeTown, "", "", false},
 
 		// Role-based patterns (all agents of a role type)
@@ -1287,6 +1291,58 @@ func TestValidateRecipient(t *testing.T) {
 	}
 }
 
+func TestResolveOverseerRoster(t *testing.T) {
+	townRoot := t.TempDir()
+	r := NewRouterWithTownRoot(townRoot, townRoot)
+
+	if _, err := r.resolveOverseer(); err == nil {
+		t.Error("@overseer without any overseer config should fail")
+	}
+	if got := OnCallOverseer(townRoot); got != "overseer" {
+		t.Errorf("OnCallOverseer without config = %q, want overseer", got)
+	}
+
+	// Single overseer: the shared mailbox.
+	if err := config.SaveOverseerConfig(config.OverseerConfigPath(townRoot), &config.OverseerConfig{Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := r.resolveOverseer(); err != nil || len(got) != 1 || got[0] != "overseer" {
+		t.Errorf("@overseer with single overseer = %v, %v", got, err)
+	}
+	if got, err := r.resolveOverseers(); err != nil || len(got) != 1 || got[0] != "overseer" {
+		t.Errorf("@overseers with single overseer = %v, %v", got, err)
+	}
+
+	// Roster with a hand-off to bob: @overseer follows the hand-off.
+	roster := config.NewOverseerRoster()
+	for _, h := range []string{"alice", "bob"} {
+		_ = roster.Add(config.OverseerMember{Handle: h, Name: h})
+	}
+	now := time.Now()
+	if err := roster.HandOff("bob", "alice", now, now.Add(time.Hour), ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveOverseerRoster(config.OverseerRosterPath(townRoot), roster); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := r.resolveOverseer(); err != nil || len(got) != 1 || got[0] != "overseer/bob" {
+		t.Errorf("@overseer with roster = %v, %v", got, err)
+	}
+	if got := OnCallOverseer(townRoot); got != "overseer/bob" {
+		t.Errorf("OnCallOverseer = %q, want overseer/bob", got)
+	}
+	got, err := r.ResolveGroupAddress("@overseers")
+	if err != nil || len(got) != 2 || got[0] != "overseer/alice" || got[1] != "overseer/bob" {
+		t.Errorf("@overseers with roster = %v, %v", got, err)
+	}
+
+	for identity, wantErr := range map[string]bool{"overseer/alice": false, "overseer/zed": true} {
+		if err := r.validateRecipient(identity); (err != nil) != wantErr {
+			t.Errorf("validateRecipient(%q) = %v, wantErr %v", identity, err, wantErr)
+		}
+	}
+}
+
 func TestValidateAgentWorkspaceDog(t *testing.T) {
 	tmpDir := t.TempDir()
 
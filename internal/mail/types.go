@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 // Priority levels for messages.
@@ -99,6 +101,10 @@ type Message struct {
 	// ReplyTo is the ID of the message this is replying to.
 	ReplyTo string `json:"reply_to,omitempty"`
 
+	// TraceParent is the W3C traceparent of the sender's bead lifecycle
+	// trace, if any. Stored as a traceparent:X label.
+	TraceParent string `json:"trace_parent,omitempty"`
+
 	// Pinned marks the message as pinned (won't be auto-archived).
 	Pinned bool `json:"pinned,omitempty"`
 
@@ -304,20 +310,21 @@ type BeadsMessage struct {
 	Priority    int       `json:"priority"`    // 0=urgent, 1=high, 2=normal, 3=low
 	Status      string    `json:"status"`      // open=unread, closed=read
 	CreatedAt   time.Time `json:"created_at"`
-	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, msg-type:X, cc:X, queue:X, channel:X, claimed-by:X, claimed-at:X)
+	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, msg-type:X, cc:X, queue:X, channel:X, claimed-by:X, claimed-at:X, traceparent:X)
 	Pinned      bool      `json:"pinned,omitempty"`
 	Wisp        bool      `json:"wisp,omitempty"` // Ephemeral message (not synced to git)
 
 	// Cached parsed values (populated by ParseLabels)
-	sender    string
-	threadID  string
-	replyTo   string
-	msgType   string
-	cc        []string   // CC recipients
-	queue     string     // Queue name (for queue messages)
-	channel   string     // Channel name (for broadcast messages)
-	claimedBy string     // Who claimed the queue message
-	claimedAt *time.Time // When the queue message was claimed
+	sender      string
+	threadID    string
+	replyTo     string
+	msgType     string
+	cc          []string   // CC recipients
+	queue       string     // Queue name (for queue messages)
+	channel     string     // Channel name (for broadcast messages)
+	claimedBy   string     // Who claimed the queue message
+	claimedAt   *time.Time // When the queue message was claimed
+	traceParent string     // Sender's bead lifecycle trace
 	// Two-phase delivery metadata
 	deliveryState   string
 	deliveryAckedBy string
@@ -336,6 +343,7 @@ func (bm *BeadsMessage) ParseLabels() {
 	bm.channel = ""
 	bm.claimedBy = ""
 	bm.claimedAt = nil
+	bm.traceParent = ""
 	bm.deliveryState = ""
 	bm.deliveryAckedBy = ""
 	bm.deliveryAckedAt = nil
@@ -362,6 +370,8 @@ func (bm *BeadsMessage) ParseLabels() {
 			if t, err := time.Parse(time.RFC3339, ts); err == nil {
 				bm.claimedAt = &t
 			}
+		} else if strings.HasPrefix(label, "traceparent:") {
+			bm.traceParent = strings.TrimPrefix(label, "traceparent:")
 		}
 	}
 
@@ -426,6 +436,7 @@ func (bm *BeadsMessage) ToMessage() *Message {
 		Type:            msgType,
 		ThreadID:        bm.threadID,
 		ReplyTo:         bm.replyTo,
+		TraceParent:     bm.traceParent,
 		Wisp:            bm.Wisp,
 		CC:              ccAddrs,
 		Queue:           bm.queue,
@@ -544,6 +555,7 @@ func ParseMessageType(s string) MessageType {
 //
 // Liberal normalization (Postel's Law - be liberal in what you accept):
 //   - "overseer" → "overseer" (human operator, no trailing slash)
+//   - "overseer/alice" → "overseer/alice" (one human on the overseer roster)
 //   - "mayor" or "mayor/" → "mayor/" (town-level, trailing slash)
 //   - "deacon" or "deacon/" → "deacon/" (town-level, trailing slash)
 //   - "gastown/polecats/Toast" → "gastown/Toast" (crew/polecats normalized)
@@ -551,9 +563,10 @@ func ParseMessageType(s string) MessageType {
 //   - "gastown/Toast" → "gastown/Toast" (already canonical)
 //   - "gastown/refinery" → "gastown/refinery"
 func normalizeAddress(s string) string {
-	// Overseer (human operator) - no trailing slash, distinct from agents
-	if s == "overseer" {
-		return "overseer"
+	// Overseers (human operators) - no trailing slash, distinct from agents.
+	// "overseer/<handle>" is one human's mailbox; handles are never rewritten.
+	if config.IsOverseerAddress(s) {
+		return strings.TrimSuffix(s, "/")
 	}
 
 	// Town-level agents: mayor and deacon keep trailing slash
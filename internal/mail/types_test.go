@@ -33,6 +33,11 @@ func TestAddressToIdentity(t *testing.T) {
 
 		// Rig broadcast (trailing slash removed)
 		{"gastown/", "gastown"},
+
+		// Overseer mailboxes are never rewritten, even for role-like handles
+		{"overseer", "overseer"},
+		{"overseer/alice", "overseer/alice"},
+		{"overseer/mayor", "overseer/mayor"},
 	}
 
 	for _, tt := range tests {
@@ -289,6 +294,35 @@ func TestBeadsMessageToMessageWithReplyTo(t *testing.T) {
 	}
 }
 
+func TestBeadsMessageToMessageWithTraceParent(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	bm := BeadsMessage{
+		ID:       "hq-traced",
+		Title:    "MERGED",
+		Status:   "open",
+		Assignee: "gastown/witness",
+		Labels:   []string{"from:gastown/refinery", "traceparent:" + tp},
+		Priority: 2,
+	}
+
+	msg := bm.ToMessage()
+	if msg.TraceParent != tp {
+		t.Errorf("TraceParent = %q, want %q", msg.TraceParent, tp)
+	}
+
+	r := &Router{}
+	labels := r.buildLabels(msg)
+	found := false
+	for _, l := range labels {
+		if l == "traceparent:"+tp {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("buildLabels = %v, missing traceparent label", labels)
+	}
+}
+
 func TestBeadsMessageToMessageWithEscalationTypeAndLabels(t *testing.T) {
 	bm := BeadsMessage{
 		ID:          "hq-esc",
@@ -139,7 +139,7 @@ func (m *Manager) StartTMUX(agentOverride string) error {
 		return ErrAlreadyRunning
 	}
 
-	t := tmux.NewTmux()
+	t := session.NewBackend(m.townRoot)
 	sessionID := m.SessionName()
 
 	// Kill any existing zombie session (tmux alive but agent dead).
@@ -324,7 +324,7 @@ func (m *Manager) StartACP(ctx context.Context, agentOverride, rigName string) e
 
 // Stop stops the mayor session.
 func (m *Manager) Stop() error {
-	t := tmux.NewTmux()
+	t := session.NewBackend(m.townRoot)
 	sessionID := m.SessionName()
 
 	// Check if session exists
@@ -350,13 +350,18 @@ func (m *Manager) Stop() error {
 
 // IsRunning checks if the mayor session is active in TMUX mode.
 func (m *Manager) IsRunning() (bool, error) {
-	t := tmux.NewTmux()
-	return t.HasSession(m.SessionName())
+	return session.NewBackend(m.townRoot).HasSession(m.SessionName())
+}
+
+// IsAgentAlive checks if the agent process in the mayor session is running,
+// on whichever backend the session was started with.
+func (m *Manager) IsAgentAlive() bool {
+	return session.NewBackend(m.townRoot).IsAgentAlive(m.SessionName())
 }
 
 // Status returns information about the mayor session.
 func (m *Manager) Status() (*tmux.SessionInfo, error) {
-	t := tmux.NewTmux()
+	t := session.NewBackend(m.townRoot)
 	sessionID := m.SessionName()
 
 	running, err := t.HasSession(sessionID)
@@ -367,7 +372,7 @@ func (m *Manager) Status() (*tmux.SessionInfo, error) {
 		return nil, ErrNotRunning
 	}
 
-	return t.GetSessionInfo(sessionID)
+	return session.GetSessionInfo(t, sessionID)
 }
 
 // buildACPStartupPrompt composes the startup prompt used for ACP mayor sessions.
@@ -10,6 +10,7 @@
 package nudge
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
@@ -22,6 +23,8 @@ import (
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Priority levels for nudge delivery.
@@ -57,14 +60,17 @@ func nudgeConfig(townRoot string) *config.NudgeThresholds {
 
 // QueuedNudge represents a nudge message stored in the queue.
 type QueuedNudge struct {
-	Sender    string    `json:"sender"`
-	Message   string    `json:"message"`
-	Priority  string    `json:"priority"`
-	Kind      string    `json:"kind,omitempty"`
-	ThreadID  string    `json:"thread_id,omitempty"`
-	Severity  string    `json:"severity,omitempty"`
-	Timestamp time.Time `json:"timestamp"`
-	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	Sender   string `json:"sender"`
+	Message  string `json:"message"`
+	Priority string `json:"priority"`
+	Kind     string `json:"kind,omitempty"`
+	ThreadID string `json:"thread_id,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	// TraceParent links the nudge to the sender's bead lifecycle trace.
+	// Enqueue fills it from TRACEPARENT; Drain records the queue wait as a span.
+	TraceParent string    `json:"trace_parent,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+	ExpiresAt   time.Time `json:"expires_at,omitempty"`
 	// DeliverAfter, if non-zero, defers delivery until this time has passed.
 	// Drain skips (but does not discard) the nudge until the deadline is met.
 	DeliverAfter time.Time `json:"deliver_after,omitempty"`
@@ -108,6 +114,9 @@ func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 	if nudge.Priority == "" {
 		nudge.Priority = PriorityNormal
 	}
+	if nudge.TraceParent == "" {
+		nudge.TraceParent = os.Getenv(telemetry.EnvTraceParent)
+	}
 
 	// Set expiry if not already specified by the caller.
 	if nudge.ExpiresAt.IsZero() {
@@ -268,6 +277,7 @@ func Drain(townRoot, session string) ([]QueuedNudge, error) {
 		}
 
 		nudges = append(nudges, n)
+		recordDelivery(session, n)
 
 		// Remove the claimed file after successful processing
 		if rmErr := os.Remove(claimPath); rmErr != nil {
@@ -278,6 +288,20 @@ func Drain(townRoot, session string) ([]QueuedNudge, error) {
 	return nudges, nil
 }
 
+// recordDelivery records a traced nudge's time in the queue as a span in the
+// sender's trace.
+func recordDelivery(session string, n QueuedNudge) {
+	if n.TraceParent == "" {
+		return
+	}
+	ctx := telemetry.ContextWithTraceParent(context.Background(), n.TraceParent)
+	_, span := telemetry.StartSpanAt(ctx, "nudge.deliver", n.Timestamp,
+		attribute.String("gt.session", session),
+		attribute.String("gt.nudge.sender", n.Sender),
+		attribute.String("gt.nudge.kind", n.Kind))
+	telemetry.EndSpan(span, nil)
+}
+
 // Pending returns the count of queued nudges for a session without draining.
 // This is an approximate count — it does not check expiry or read file contents.
 func Pending(townRoot, session string) (int, error) {
@@ -218,6 +218,24 @@ func TestEnqueueDefaults(t *testing.T) {
 	}
 }
 
+func TestEnqueueTraceParentFromEnv(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	t.Setenv("TRACEPARENT", tp)
+	townRoot := t.TempDir()
+	session := "gt-test-trace"
+
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "test", Message: "hello"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	nudges, err := Drain(townRoot, session)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if len(nudges) != 1 || nudges[0].TraceParent != tp {
+		t.Errorf("drained %+v, want TraceParent %q", nudges, tp)
+	}
+}
+
 func TestEnqueueUrgentTTL(t *testing.T) {
 	townRoot := t.TempDir()
 	session := "gt-test-urgent-ttl"
@@ -0,0 +1,70 @@
+package plugin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"abc * * * *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	base := time.Date(2026, time.March, 4, 10, 17, 30, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.March, 4, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC)},
+		{"30 10,14 * * *", time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * SUN", time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, time.March, 4, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches (the 10th, or a Friday).
+		{"0 0 10 * 5", time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		got := s.Next(base)
+		if !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+		if !s.Matches(got) {
+			t.Errorf("Matches(%q, %v) = false for its own Next", tt.expr, got)
+		}
+	}
+}
+
+func TestCronSchedule_NextNever(t *testing.T) {
+	s, err := ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Next for Feb 30 = %v, want zero", got)
+	}
+}
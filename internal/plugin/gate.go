@@ -0,0 +1,188 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EventStartup is the event-gate name that fires once when the daemon starts.
+const EventStartup = "startup"
+
+// DefaultConditionTimeout bounds a condition gate's check command when the
+// gate does not set its own timeout.
+const DefaultConditionTimeout = 30 * time.Second
+
+// RunHistory is the run ledger gates are evaluated against. *Recorder
+// implements it.
+type RunHistory interface {
+	GetLastRun(pluginName string) (*PluginRunBead, error)
+	CountRunsSince(pluginName string, since string) (int, error)
+}
+
+// GateInput is the state a gate is evaluated against.
+type GateInput struct {
+	// Now is the evaluation time.
+	Now time.Time
+
+	// Since anchors cron gates for plugins with no run history, so a plugin
+	// never run before waits for the next scheduled time after Since (the
+	// daemon's start) instead of firing immediately.
+	Since time.Time
+
+	// History is the plugin run ledger.
+	History RunHistory
+
+	// Events are the event types observed since the last evaluation,
+	// including EventStartup on the first evaluation after startup.
+	Events []string
+}
+
+// GateResult is the outcome of evaluating a gate.
+type GateResult struct {
+	Open   bool
+	Reason string
+}
+
+// EventTypes returns the event types an event gate subscribes to. On may
+// list several, comma-separated (e.g. "session_death, mass_death").
+func (g *Gate) EventTypes() []string {
+	var types []string
+	for _, t := range strings.Split(g.On, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// EvaluateGate decides whether p should run now.
+//
+// Cooldown gates are open when no run is recorded within Duration. Cron gates
+// are open when a scheduled time has passed since the last recorded run.
+// Condition gates are open when the Check command exits 0 within Timeout.
+// Event gates are open when one of their On types is in in.Events. Manual
+// gates (and plugins without a gate) never open automatically.
+//
+// For condition and event gates, Duration is an optional minimum interval
+// between runs, so a condition that stays true or a burst of events does not
+// dispatch the plugin on every evaluation.
+func EvaluateGate(ctx context.Context, p *Plugin, in GateInput) (GateResult, error) {
+	g := p.Gate
+	if g == nil || g.Type == GateManual {
+		return GateResult{Reason: "manual gate"}, nil
+	}
+
+	switch g.Type {
+	case GateCooldown:
+		return evaluateCooldown(p, in)
+
+	case GateCron:
+		return evaluateCron(p, in)
+
+	case GateCondition:
+		if res, err := evaluateCooldown(p, in); err != nil || !res.Open {
+			return res, err
+		}
+		return evaluateCondition(ctx, p)
+
+	case GateEvent:
+		if res, err := evaluateCooldown(p, in); err != nil || !res.Open {
+			return res, err
+		}
+		return evaluateEvent(p, in), nil
+
+	default:
+		return GateResult{}, fmt.Errorf("unknown gate type %q", g.Type)
+	}
+}
+
+// evaluateCooldown is open when the plugin has not run within Gate.Duration.
+// An empty Duration is always open.
+func evaluateCooldown(p *Plugin, in GateInput) (GateResult, error) {
+	if p.Gate.Duration == "" {
+		return GateResult{Open: true}, nil
+	}
+	count, err := in.History.CountRunsSince(p.Name, p.Gate.Duration)
+	if err != nil {
+		return GateResult{}, fmt.Errorf("checking cooldown: %w", err)
+	}
+	if count > 0 {
+		return GateResult{Reason: fmt.Sprintf("ran %d time(s) within %s cooldown", count, p.Gate.Duration)}, nil
+	}
+	return GateResult{Open: true, Reason: fmt.Sprintf("no run within %s", p.Gate.Duration)}, nil
+}
+
+func evaluateCron(p *Plugin, in GateInput) (GateResult, error) {
+	sched, err := ParseCron(p.Gate.Schedule)
+	if err != nil {
+		return GateResult{}, err
+	}
+
+	anchor := in.Since
+	last, err := in.History.GetLastRun(p.Name)
+	if err != nil {
+		return GateResult{}, fmt.Errorf("checking last run: %w", err)
+	}
+	if last != nil && !last.CreatedAt.IsZero() {
+		anchor = last.CreatedAt
+	}
+
+	next := sched.Next(anchor)
+	if next.IsZero() {
+		return GateResult{Reason: fmt.Sprintf("cron %q never fires", p.Gate.Schedule)}, nil
+	}
+	if next.After(in.Now) {
+		return GateResult{Reason: fmt.Sprintf("next run at %s", next.Format(time.RFC3339))}, nil
+	}
+	return GateResult{Open: true, Reason: fmt.Sprintf("scheduled for %s", next.Format(time.RFC3339))}, nil
+}
+
+func evaluateCondition(ctx context.Context, p *Plugin) (GateResult, error) {
+	if strings.TrimSpace(p.Gate.Check) == "" {
+		return GateResult{}, fmt.Errorf("condition gate has no check command")
+	}
+
+	timeout := DefaultConditionTimeout
+	if p.Gate.Timeout != "" {
+		d, err := time.ParseDuration(p.Gate.Timeout)
+		if err != nil {
+			return GateResult{}, fmt.Errorf("parsing condition timeout %q: %w", p.Gate.Timeout, err)
+		}
+		timeout = d
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", p.Gate.Check) //nolint:gosec // G204: check comes from a trusted plugin.md
+	cmd.Dir = p.Path
+	// Don't let a backgrounded grandchild holding the pipes outlive the timeout.
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return GateResult{Reason: fmt.Sprintf("check timed out after %s", timeout)}, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return GateResult{Reason: fmt.Sprintf("check exited %d", exitErr.ExitCode())}, nil
+	}
+	if err != nil {
+		return GateResult{}, fmt.Errorf("running condition check: %w", err)
+	}
+	return GateResult{Open: true, Reason: "check passed"}, nil
+}
+
+func evaluateEvent(p *Plugin, in GateInput) GateResult {
+	types := p.Gate.EventTypes()
+	for _, ev := range in.Events {
+		if slices.Contains(types, ev) {
+			return GateResult{Open: true, Reason: "event " + ev}
+		}
+	}
+	return GateResult{Reason: fmt.Sprintf("waiting for %s", strings.Join(types, ", "))}
+}
@@ -0,0 +1,173 @@
+package plugin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeHistory is an in-memory RunHistory.
+type fakeHistory struct {
+	runs []time.Time
+}
+
+func (h *fakeHistory) GetLastRun(string) (*PluginRunBead, error) {
+	if len(h.runs) == 0 {
+		return nil, nil
+	}
+	return &PluginRunBead{CreatedAt: h.runs[len(h.runs)-1]}, nil
+}
+
+func (h *fakeHistory) CountRunsSince(_ string, since string) (int, error) {
+	d, err := time.ParseDuration(since)
+	if err != nil {
+		return 0, err
+	}
+	cutoff := time.Now().Add(-d)
+	n := 0
+	for _, r := range h.runs {
+		if r.After(cutoff) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func gatePlugin(g *Gate) *Plugin {
+	return &Plugin{Name: "test-plugin", Gate: g}
+}
+
+func TestEvaluateGate_Manual(t *testing.T) {
+	for _, p := range []*Plugin{gatePlugin(nil), gatePlugin(&Gate{Type: GateManual})} {
+		res, err := EvaluateGate(context.Background(), p, GateInput{History: &fakeHistory{}})
+		if err != nil || res.Open {
+			t.Errorf("manual gate = %+v, %v; want closed", res, err)
+		}
+	}
+}
+
+func TestEvaluateGate_Cooldown(t *testing.T) {
+	p := gatePlugin(&Gate{Type: GateCooldown, Duration: "1h"})
+
+	res, err := EvaluateGate(context.Background(), p, GateInput{History: &fakeHistory{}})
+	if err != nil || !res.Open {
+		t.Errorf("cooldown with no runs = %+v, %v; want open", res, err)
+	}
+
+	hist := &fakeHistory{runs: []time.Time{time.Now().Add(-10 * time.Minute)}}
+	res, err = EvaluateGate(context.Background(), p, GateInput{History: hist})
+	if err != nil || res.Open {
+		t.Errorf("cooldown after recent run = %+v, %v; want closed", res, err)
+	}
+}
+
+func TestEvaluateGate_Cron(t *testing.T) {
+	p := gatePlugin(&Gate{Type: GateCron, Schedule: "0 9 * * *"})
+	day := func(h, m int) time.Time { return time.Date(2026, time.March, 4, h, m, 0, 0, time.Local) }
+
+	tests := []struct {
+		name  string
+		runs  []time.Time
+		since time.Time
+		now   time.Time
+		open  bool
+	}{
+		{"never run, started before schedule, now past it", nil, day(8, 0), day(9, 1), true},
+		{"never run, started after schedule", nil, day(10, 0), day(15, 0), false},
+		{"last run yesterday, now past schedule", []time.Time{day(9, 0).AddDate(0, 0, -1)}, day(0, 0), day(9, 5), true},
+		{"already ran today", []time.Time{day(9, 2)}, day(0, 0), day(15, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := EvaluateGate(context.Background(), p, GateInput{
+				Now:     tt.now,
+				Since:   tt.since,
+				History: &fakeHistory{runs: tt.runs},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Open != tt.open {
+				t.Errorf("open = %v (%s), want %v", res.Open, res.Reason, tt.open)
+			}
+		})
+	}
+
+	bad := gatePlugin(&Gate{Type: GateCron, Schedule: "not a cron"})
+	if _, err := EvaluateGate(context.Background(), bad, GateInput{History: &fakeHistory{}}); err == nil {
+		t.Error("expected error for invalid cron schedule")
+	}
+}
+
+func TestEvaluateGate_Condition(t *testing.T) {
+	dir := t.TempDir()
+	run := func(g *Gate) GateResult {
+		t.Helper()
+		p := &Plugin{Name: "test-plugin", Path: dir, Gate: g}
+		res, err := EvaluateGate(context.Background(), p, GateInput{History: &fakeHistory{}})
+		if err != nil {
+			t.Fatalf("EvaluateGate(%+v): %v", g, err)
+		}
+		return res
+	}
+
+	if res := run(&Gate{Type: GateCondition, Check: "true"}); !res.Open {
+		t.Errorf("passing check = %+v, want open", res)
+	}
+	if res := run(&Gate{Type: GateCondition, Check: "exit 3"}); res.Open || !strings.Contains(res.Reason, "exited 3") {
+		t.Errorf("failing check = %+v, want closed with exit code", res)
+	}
+	// The check runs in the plugin directory.
+	if res := run(&Gate{Type: GateCondition, Check: `test "$(pwd -P)" = "$(cd ` + dir + ` && pwd -P)"`}); !res.Open {
+		t.Errorf("check should run in plugin dir: %+v", res)
+	}
+
+	start := time.Now()
+	res := run(&Gate{Type: GateCondition, Check: "sleep 10", Timeout: "100ms"})
+	if res.Open || !strings.Contains(res.Reason, "timed out") {
+		t.Errorf("slow check = %+v, want timed out", res)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("timeout not enforced: took %v", elapsed)
+	}
+
+	p := &Plugin{Name: "test-plugin", Path: dir, Gate: &Gate{Type: GateCondition}}
+	if _, err := EvaluateGate(context.Background(), p, GateInput{History: &fakeHistory{}}); err == nil {
+		t.Error("expected error for condition gate without check")
+	}
+}
+
+func TestEvaluateGate_Event(t *testing.T) {
+	p := gatePlugin(&Gate{Type: GateEvent, On: "session_death, mass_death"})
+
+	res, _ := EvaluateGate(context.Background(), p, GateInput{History: &fakeHistory{}, Events: []string{"merged"}})
+	if res.Open {
+		t.Errorf("unrelated event opened gate: %+v", res)
+	}
+	res, _ = EvaluateGate(context.Background(), p, GateInput{History: &fakeHistory{}, Events: []string{"merged", "mass_death"}})
+	if !res.Open {
+		t.Errorf("mass_death should open gate: %+v", res)
+	}
+
+	startup := gatePlugin(&Gate{Type: GateEvent, On: EventStartup})
+	res, _ = EvaluateGate(context.Background(), startup, GateInput{History: &fakeHistory{}, Events: []string{EventStartup}})
+	if !res.Open {
+		t.Errorf("startup event should open gate: %+v", res)
+	}
+
+	// Duration debounces bursts of events.
+	debounced := gatePlugin(&Gate{Type: GateEvent, On: "session_death", Duration: "5m"})
+	hist := &fakeHistory{runs: []time.Time{time.Now().Add(-time.Minute)}}
+	res, _ = EvaluateGate(context.Background(), debounced, GateInput{History: hist, Events: []string{"session_death"}})
+	if res.Open {
+		t.Errorf("event within debounce interval opened gate: %+v", res)
+	}
+}
+
+func TestGateEventTypes(t *testing.T) {
+	got := (&Gate{On: " merged ,session_death,, "}).EventTypes()
+	if len(got) != 2 || got[0] != "merged" || got[1] != "session_death" {
+		t.Errorf("EventTypes = %q", got)
+	}
+}
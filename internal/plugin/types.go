@@ -67,7 +67,8 @@ type Gate struct {
 	// Type is the gate type: cooldown, cron, condition, event, or manual.
 	Type GateType `json:"type" toml:"type"`
 
-	// Duration is for cooldown gates (e.g., "1h", "24h").
+	// Duration is for cooldown gates (e.g., "1h", "24h"). On condition and
+	// event gates it is an optional minimum interval between runs.
 	Duration string `json:"duration,omitempty" toml:"duration,omitempty"`
 
 	// Schedule is for cron gates (e.g., "0 9 * * *").
@@ -76,7 +77,11 @@ type Gate struct {
 	// Check is for condition gates (command that returns exit 0 to run).
 	Check string `json:"check,omitempty" toml:"check,omitempty"`
 
-	// On is for event gates (e.g., "startup").
+	// Timeout bounds a condition gate's Check (default 30s).
+	Timeout string `json:"timeout,omitempty" toml:"timeout,omitempty"`
+
+	// On is for event gates: "startup" or .events.jsonl event types
+	// (e.g., "merged", "session_death, mass_death").
 	On string `json:"on,omitempty" toml:"on,omitempty"`
 }
 
@@ -93,7 +98,7 @@ const (
 	// GateCondition runs if a check command returns exit 0.
 	GateCondition GateType = "condition"
 
-	// GateEvent runs on specific events (startup, etc).
+	// GateEvent runs on specific events (startup, or .events.jsonl types).
 	GateEvent GateType = "event"
 
 	// GateManual never auto-runs, must be triggered explicitly.
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
 )
 
 func TestTouchAndReadSessionHeartbeat(t *testing.T) {
@@ -223,6 +225,26 @@ func TestIsSessionProcessDead_EmptyTownRoot(t *testing.T) {
 	}
 }
 
+// agentBackend is a session backend that only answers IsAgentAlive.
+type agentBackend struct {
+	session.SessionBackend
+	alive bool
+}
+
+func (b agentBackend) IsAgentAlive(string) bool { return b.alive }
+
+func TestIsSessionProcessDead_NonTmuxBackend(t *testing.T) {
+	// Without a heartbeat, backends other than tmux are asked directly
+	// instead of probing a tmux pane PID.
+	townRoot := t.TempDir()
+	if isSessionProcessDead(agentBackend{alive: true}, "gt-test-headless", townRoot) {
+		t.Error("expected alive when the backend reports the agent alive")
+	}
+	if !isSessionProcessDead(agentBackend{alive: false}, "gt-test-headless", townRoot) {
+		t.Error("expected dead when the backend reports the agent gone")
+	}
+}
+
 func TestReadSessionHeartbeat_V1BackwardsCompat(t *testing.T) {
 	townRoot := t.TempDir()
 
@@ -126,11 +126,12 @@ type Manager struct {
 	git      *git.Git
 	beads    *beads.Beads
 	namePool *NamePool
-	tmux     *tmux.Tmux
+	tmux     session.SessionBackend
 }
 
-// NewManager creates a new polecat manager.
-func NewManager(r *rig.Rig, g *git.Git, t *tmux.Tmux) *Manager {
+// NewManager creates a new polecat manager. t is the town's session
+// backend, or nil when the caller only lists polecats.
+func NewManager(r *rig.Rig, g *git.Git, t session.SessionBackend) *Manager {
 	// Use the resolved beads directory to find where bd commands should run.
 	// For tracked beads: rig/.beads/redirect -> mayor/rig/.beads, so use mayor/rig
 	// For local beads: rig/.beads is the database, so use rig root
@@ -1773,7 +1774,7 @@ func (m *Manager) ReconcilePoolWith(namesWithDirs, namesWithSessions []string) {
 //
 // Returns true only when we can confirm the process is dead, not on transient
 // failures (gt-kncti: permission denied false positives).
-func isSessionProcessDead(t *tmux.Tmux, sessionName string, townRoot string) bool {
+func isSessionProcessDead(t session.SessionBackend, sessionName string, townRoot string) bool {
 	// Primary: heartbeat-based liveness check (gt-qjtq ZFC fix).
 	if townRoot != "" {
 		stale, exists := IsSessionHeartbeatStale(townRoot, sessionName)
@@ -1784,7 +1785,12 @@ func isSessionProcessDead(t *tmux.Tmux, sessionName string, townRoot string) boo
 	}
 
 	// Fallback: PID signal probing (legacy, for sessions without heartbeat support).
-	pidStr, err := t.GetPanePID(sessionName)
+	// Backends other than tmux have no pane PID; ask them directly.
+	tm, ok := t.(*tmux.Tmux)
+	if !ok {
+		return t != nil && !t.IsAgentAlive(sessionName)
+	}
+	pidStr, err := tm.GetPanePID(sessionName)
 	if err != nil {
 		// Tmux query failed — could be permission denied, server busy, etc.
 		// Don't assume dead; let a future cycle retry.
@@ -21,7 +21,9 @@ import (
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // debugSession logs non-fatal errors during session startup when GT_DEBUG_SESSION=1.
@@ -40,18 +42,47 @@ var (
 
 // SessionManager handles polecat session lifecycle.
 type SessionManager struct {
-	tmux *tmux.Tmux
-	rig  *rig.Rig
+	backend session.SessionBackend
+	rig     *rig.Rig
 }
 
 // NewSessionManager creates a new polecat session manager for a rig.
-func NewSessionManager(t *tmux.Tmux, r *rig.Rig) *SessionManager {
+// b is usually the town's session.NewBackend. tmux-only setup (themes,
+// pane-died hooks, startup dialogs, PID tracking) is skipped for other
+// backends.
+func NewSessionManager(b session.SessionBackend, r *rig.Rig) *SessionManager {
 	return &SessionManager{
-		tmux: t,
-		rig:  r,
+		backend: b,
+		rig:     r,
 	}
 }
 
+// tmuxClient returns the backend as a *tmux.Tmux, if it is one.
+func (m *SessionManager) tmuxClient() (*tmux.Tmux, bool) {
+	t, ok := m.backend.(*tmux.Tmux)
+	return t, ok
+}
+
+// nudge delivers a message to the agent. tmux uses its debounced,
+// idle-aware nudge; other backends type the message and press Enter.
+func (m *SessionManager) nudge(sessionID, message string) error {
+	if t, ok := m.tmuxClient(); ok {
+		return t.NudgeSession(sessionID, message)
+	}
+	return m.backend.SendKeys(sessionID, message)
+}
+
+// waitForRuntimeReady waits for the agent to be ready at its prompt.
+func (m *SessionManager) waitForRuntimeReady(sessionID string, rc *config.RuntimeConfig) error {
+	if t, ok := m.tmuxClient(); ok {
+		return t.WaitForRuntimeReady(sessionID, rc, constants.ClaudeStartTimeout)
+	}
+	if !session.WaitForReadyPrompt(m.backend, sessionID, rc, constants.ClaudeStartTimeout) {
+		return fmt.Errorf("timed out waiting for %s to be ready", sessionID)
+	}
+	return nil
+}
+
 // SessionStartOptions configures polecat session startup.
 type SessionStartOptions struct {
 	// WorkDir overrides the default working directory (polecat clone dir).
@@ -74,6 +105,11 @@ type SessionStartOptions struct {
 	// If set, GT_AGENT is written to the tmux session environment table so that
 	// IsAgentAlive and waitForPolecatReady read the correct process names.
 	Agent string
+
+	// TraceParent is the W3C traceparent of the bead lifecycle trace (set by
+	// gt sling). The session start is recorded as a span in that trace and
+	// TRACEPARENT is injected so gt commands in the session continue it.
+	TraceParent string
 }
 
 // SessionInfo contains information about a running polecat session.
@@ -205,7 +241,13 @@ func (m *SessionManager) polecatSlot(polecat string) int {
 }
 
 // Start creates and starts a new session for a polecat.
-func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
+func (m *SessionManager) Start(polecat string, opts SessionStartOptions) (retErr error) {
+	traceCtx, span := telemetry.StartSpan(
+		telemetry.ContextWithTraceParent(context.Background(), opts.TraceParent),
+		"polecat.session.start",
+		attribute.String("gt.rig", m.rig.Name), attribute.String("gt.polecat", polecat))
+	defer func() { telemetry.EndSpan(span, retErr) }()
+
 	if !m.hasPolecat(polecat) {
 		return fmt.Errorf("%w: %s", ErrPolecatNotFound, polecat)
 	}
@@ -215,13 +257,13 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// Check if session already exists.
 	// If an existing session's pane process has died, kill the stale session
 	// and proceed rather than returning ErrSessionRunning (gt-jn40ft).
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.backend.HasSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
 	}
 	if running {
 		if m.isSessionStale(sessionID) {
-			if err := m.tmux.KillSessionWithProcesses(sessionID); err != nil {
+			if err := m.backend.KillSessionWithProcesses(sessionID); err != nil {
 				return fmt.Errorf("killing stale session %s: %w", sessionID, err)
 			}
 		} else {
@@ -341,11 +383,15 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	if polecatGitBranch != "" {
 		envVarsToInject["GT_BRANCH"] = polecatGitBranch
 	}
+	traceParent := telemetry.TraceParent(traceCtx)
+	if traceParent != "" {
+		envVarsToInject[telemetry.EnvTraceParent] = traceParent
+	}
 	command = config.PrependEnv(command, envVarsToInject)
 
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
-	if err := m.tmux.NewSessionWithCommand(sessionID, workDir, command); err != nil {
+	if err := m.backend.NewSessionWithCommand(sessionID, workDir, command); err != nil {
 		return fmt.Errorf("creating session: %w", err)
 	}
 
@@ -362,7 +408,7 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 		SessionName:      sessionID,
 	})
 	for k, v := range envVars {
-		debugSession("SetEnvironment "+k, m.tmux.SetEnvironment(sessionID, k, v))
+		debugSession("SetEnvironment "+k, m.backend.SetEnvironment(sessionID, k, v))
 	}
 
 	// Fallback: set GT_AGENT from resolved config when no explicit --agent override.
@@ -373,34 +419,41 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// exec env, but tmux show-environment reads the session table, not process env.
 	// This mirrors the daemon's compensating logic (daemon.go ~line 1593-1595).
 	if _, hasGTAgent := envVars["GT_AGENT"]; !hasGTAgent && runtimeConfig.ResolvedAgent != "" {
-		debugSession("SetEnvironment GT_AGENT (resolved)", m.tmux.SetEnvironment(sessionID, "GT_AGENT", runtimeConfig.ResolvedAgent))
+		debugSession("SetEnvironment GT_AGENT (resolved)", m.backend.SetEnvironment(sessionID, "GT_AGENT", runtimeConfig.ResolvedAgent))
 	}
 
 	// Set GT_BRANCH and GT_POLECAT_PATH in tmux session environment.
 	// This ensures respawned processes also inherit these for gt done fallback.
 	if polecatGitBranch != "" {
-		debugSession("SetEnvironment GT_BRANCH", m.tmux.SetEnvironment(sessionID, "GT_BRANCH", polecatGitBranch))
+		debugSession("SetEnvironment GT_BRANCH", m.backend.SetEnvironment(sessionID, "GT_BRANCH", polecatGitBranch))
 	}
-	debugSession("SetEnvironment GT_POLECAT_PATH", m.tmux.SetEnvironment(sessionID, "GT_POLECAT_PATH", workDir))
-	debugSession("SetEnvironment GT_TOWN_ROOT", m.tmux.SetEnvironment(sessionID, "GT_TOWN_ROOT", townRoot))
+	debugSession("SetEnvironment GT_POLECAT_PATH", m.backend.SetEnvironment(sessionID, "GT_POLECAT_PATH", workDir))
+	debugSession("SetEnvironment GT_TOWN_ROOT", m.backend.SetEnvironment(sessionID, "GT_TOWN_ROOT", townRoot))
 	// Set GT_RUN in the session environment so respawned processes also inherit it.
-	debugSession("SetEnvironment GT_RUN", m.tmux.SetEnvironment(sessionID, "GT_RUN", runID))
+	debugSession("SetEnvironment GT_RUN", m.backend.SetEnvironment(sessionID, "GT_RUN", runID))
+	// Likewise keep respawned processes in the bead's trace.
+	if traceParent != "" {
+		debugSession("SetEnvironment "+telemetry.EnvTraceParent, m.backend.SetEnvironment(sessionID, telemetry.EnvTraceParent, traceParent))
+	}
 
 	// Disable Dolt auto-commit in tmux session environment (gt-5cc2p).
 	// This ensures respawned processes also inherit the setting.
-	debugSession("SetEnvironment BD_DOLT_AUTO_COMMIT", m.tmux.SetEnvironment(sessionID, "BD_DOLT_AUTO_COMMIT", "off"))
+	debugSession("SetEnvironment BD_DOLT_AUTO_COMMIT", m.backend.SetEnvironment(sessionID, "BD_DOLT_AUTO_COMMIT", "off"))
 
 	// Set GT_PROCESS_NAMES for accurate liveness detection. Custom agents may
 	// shadow built-in preset names (e.g., custom "codex" running "opencode"),
 	// so we resolve process names from both agent name and actual command.
 	processNames := config.ResolveProcessNames(runtimeConfig.ResolvedAgent, runtimeConfig.Command)
-	debugSession("SetEnvironment GT_PROCESS_NAMES", m.tmux.SetEnvironment(sessionID, "GT_PROCESS_NAMES", strings.Join(processNames, ",")))
+	debugSession("SetEnvironment GT_PROCESS_NAMES", m.backend.SetEnvironment(sessionID, "GT_PROCESS_NAMES", strings.Join(processNames, ",")))
 
 	// Record agent's pane_id for ZFC-compliant liveness checks (gt-qmsx).
 	// Declared pane identity replaces process-tree inference in IsRuntimeRunning
 	// and FindAgentPane. Legacy sessions without GT_PANE_ID fall back to scanning.
-	if paneID, err := m.tmux.GetPaneID(sessionID); err == nil {
-		debugSession("SetEnvironment GT_PANE_ID", m.tmux.SetEnvironment(sessionID, "GT_PANE_ID", paneID))
+	t, isTmux := m.tmuxClient()
+	if isTmux {
+		if paneID, err := t.GetPaneID(sessionID); err == nil {
+			debugSession("SetEnvironment GT_PANE_ID", t.SetEnvironment(sessionID, "GT_PANE_ID", paneID))
+		}
 	}
 
 	// Hook the issue to the polecat if provided via --issue flag
@@ -411,63 +464,67 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 		}
 	}
 
-	// Apply theme (non-fatal)
-	theme := tmux.ResolveSessionTheme(townRoot, m.rig.Name, "polecat")
-	debugSession("ConfigureGasTownSession", m.tmux.ConfigureGasTownSession(sessionID, theme, m.rig.Name, polecat, "polecat"))
+	if isTmux {
+		// Apply theme (non-fatal)
+		theme := tmux.ResolveSessionTheme(townRoot, m.rig.Name, "polecat")
+		debugSession("ConfigureGasTownSession", t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, polecat, "polecat"))
 
-	// Set pane-died hook for crash detection (non-fatal)
-	agentID := fmt.Sprintf("%s/%s", m.rig.Name, polecat)
-	debugSession("SetPaneDiedHook", m.tmux.SetPaneDiedHook(sessionID, agentID))
+		// Set pane-died hook for crash detection (non-fatal)
+		agentID := fmt.Sprintf("%s/%s", m.rig.Name, polecat)
+		debugSession("SetPaneDiedHook", t.SetPaneDiedHook(sessionID, agentID))
 
-	// Wait for Claude to start (non-fatal)
-	debugSession("WaitForCommand", m.tmux.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout))
+		// Wait for Claude to start (non-fatal)
+		debugSession("WaitForCommand", t.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout))
 
-	// Accept startup dialogs (workspace trust + bypass permissions) if they appear
-	debugSession("AcceptStartupDialogs", m.tmux.AcceptStartupDialogs(sessionID))
+		// Accept startup dialogs (workspace trust + bypass permissions) if they appear
+		debugSession("AcceptStartupDialogs", t.AcceptStartupDialogs(sessionID))
+	}
 
 	// Wait for runtime to be fully ready at the prompt (not just started).
 	// Uses prompt-based polling for agents with ReadyPromptPrefix (e.g., Claude "❯ "),
 	// falling back to ReadyDelayMs sleep for agents without prompt detection.
-	debugSession("WaitForRuntimeReady", m.tmux.WaitForRuntimeReady(sessionID, runtimeConfig, constants.ClaudeStartTimeout))
+	debugSession("WaitForRuntimeReady", m.waitForRuntimeReady(sessionID, runtimeConfig))
 
 	// Handle fallback nudges for non-hook agents.
 	// See StartupFallbackInfo in runtime package for the fallback matrix.
 	if fallbackInfo.SendBeaconNudge && fallbackInfo.SendStartupNudge && fallbackInfo.StartupNudgeDelayMs == 0 {
 		// Hooks + no prompt: Single combined nudge (hook already ran gt prime synchronously)
 		combined := beacon + "\n\n" + runtime.StartupNudgeContent()
-		debugSession("SendCombinedNudge", m.tmux.NudgeSession(sessionID, combined))
+		debugSession("SendCombinedNudge", m.nudge(sessionID, combined))
 	} else {
 		if fallbackInfo.SendBeaconNudge {
 			// Agent doesn't support CLI prompt - send beacon via nudge
-			debugSession("SendBeaconNudge", m.tmux.NudgeSession(sessionID, beacon))
+			debugSession("SendBeaconNudge", m.nudge(sessionID, beacon))
 		}
 
 		if fallbackInfo.StartupNudgeDelayMs > 0 {
 			// Wait for agent to finish processing beacon + gt prime before sending work instructions.
 			// Uses prompt-based detection where available; falls back to max(ReadyDelayMs, StartupNudgeDelayMs).
 			primeWaitRC := runtime.RuntimeConfigWithMinDelay(runtimeConfig, fallbackInfo.StartupNudgeDelayMs)
-			debugSession("WaitForPrimeReady", m.tmux.WaitForRuntimeReady(sessionID, primeWaitRC, constants.ClaudeStartTimeout))
+			debugSession("WaitForPrimeReady", m.waitForRuntimeReady(sessionID, primeWaitRC))
 		}
 
 		if fallbackInfo.SendStartupNudge {
 			// Send work instructions via nudge
-			debugSession("SendStartupNudge", m.tmux.NudgeSession(sessionID, runtime.StartupNudgeContent()))
+			debugSession("SendStartupNudge", m.nudge(sessionID, runtime.StartupNudgeContent()))
 		}
 	}
 
 	// Verify startup nudge was delivered: poll for idle prompt and retry if lost.
 	// This fixes the Mode B race where the nudge arrives before Claude Code is ready,
 	// causing the polecat to sit idle at an empty prompt. See GH#1379.
-	if fallbackInfo.SendStartupNudge {
-		m.verifyStartupNudgeDelivery(sessionID, runtimeConfig)
+	if fallbackInfo.SendStartupNudge && isTmux {
+		m.verifyStartupNudgeDelivery(t, sessionID, runtimeConfig)
 	}
 
 	// Legacy fallback for other startup paths (non-fatal)
-	_ = runtime.RunStartupFallback(m.tmux, sessionID, "polecat", runtimeConfig)
+	if isTmux {
+		_ = runtime.RunStartupFallback(t, sessionID, "polecat", runtimeConfig)
+	}
 
 	// Verify session survived startup - if the command crashed, the session may have died.
 	// Without this check, Start() would return success even if the pane died during initialization.
-	running, err = m.tmux.HasSession(sessionID)
+	running, err = m.backend.HasSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("verifying session: %w", err)
 	}
@@ -478,9 +535,9 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// Validate GT_AGENT is set. Without GT_AGENT, IsAgentAlive falls back to
 	// ["node", "claude"] process detection and witness patrol will auto-nuke
 	// polecats running non-Claude agents (e.g., opencode). Fail fast.
-	gtAgent, _ := m.tmux.GetEnvironment(sessionID, "GT_AGENT")
+	gtAgent, _ := m.backend.GetEnvironment(sessionID, "GT_AGENT")
 	if gtAgent == "" {
-		_ = m.tmux.KillSessionWithProcesses(sessionID)
+		_ = m.backend.KillSessionWithProcesses(sessionID)
 		return fmt.Errorf("GT_AGENT not set in session %s (command=%q); "+
 			"witness patrol will misidentify this polecat as a zombie and auto-nuke it. "+
 			"Ensure RuntimeConfig.ResolvedAgent is set during agent config resolution",
@@ -488,7 +545,9 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	}
 
 	// Track PID for defense-in-depth orphan cleanup (non-fatal)
-	_ = session.TrackSessionPID(townRoot, sessionID, m.tmux)
+	if isTmux {
+		_ = session.TrackSessionPID(townRoot, sessionID, t)
+	}
 
 	// Touch initial heartbeat so liveness detection works from the start (gt-qjtq).
 	// Subsequent touches happen on every gt command via persistentPreRun.
@@ -514,14 +573,14 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 // This happens when the agent crashes during startup but tmux keeps the dead pane.
 // Delegates to isSessionProcessDead to avoid duplicating process-check logic (gt-qgzj1h).
 func (m *SessionManager) isSessionStale(sessionID string) bool {
-	return isSessionProcessDead(m.tmux, sessionID, filepath.Dir(m.rig.Path))
+	return isSessionProcessDead(m.backend, sessionID, filepath.Dir(m.rig.Path))
 }
 
 // Stop terminates a polecat session.
 func (m *SessionManager) Stop(polecat string, force bool) error {
 	sessionID := m.SessionName(polecat)
 
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.backend.HasSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
 	}
@@ -531,13 +590,13 @@ func (m *SessionManager) Stop(polecat string, force bool) error {
 
 	// Try graceful shutdown first
 	if !force {
-		_ = m.tmux.SendKeysRaw(sessionID, "C-c")
-		session.WaitForSessionExit(m.tmux, sessionID, constants.GracefulShutdownTimeout)
+		_ = m.backend.SendKeysRaw(sessionID, "C-c")
+		session.WaitForSessionExit(m.backend, sessionID, constants.GracefulShutdownTimeout)
 	}
 
 	// Use KillSessionWithProcesses to ensure all descendant processes are killed.
 	// This prevents orphan bash processes from Claude's Bash tool surviving session termination.
-	if err := m.tmux.KillSessionWithProcesses(sessionID); err != nil {
+	if err := m.backend.KillSessionWithProcesses(sessionID); err != nil {
 		return fmt.Errorf("killing session: %w", err)
 	}
 
@@ -549,7 +608,7 @@ func (m *SessionManager) Stop(polecat string, force bool) error {
 // reporting zombie sessions (tmux alive but Claude dead) as "running".
 func (m *SessionManager) IsRunning(polecat string) (bool, error) {
 	sessionID := m.SessionName(polecat)
-	status := m.tmux.CheckSessionHealth(sessionID, 0)
+	status := session.CheckSessionHealth(m.backend, sessionID, 0)
 	return status == tmux.SessionHealthy, nil
 }
 
@@ -557,7 +616,7 @@ func (m *SessionManager) IsRunning(polecat string) (bool, error) {
 func (m *SessionManager) Status(polecat string) (*SessionInfo, error) {
 	sessionID := m.SessionName(polecat)
 
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.backend.HasSession(sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("checking session: %w", err)
 	}
@@ -573,7 +632,7 @@ func (m *SessionManager) Status(polecat string) (*SessionInfo, error) {
 		return info, nil
 	}
 
-	tmuxInfo, err := m.tmux.GetSessionInfo(sessionID)
+	tmuxInfo, err := session.GetSessionInfo(m.backend, sessionID)
 	if err != nil {
 		return info, nil
 	}
@@ -611,7 +670,7 @@ func (m *SessionManager) Status(polecat string) (*SessionInfo, error) {
 // This includes polecats, witness, refinery, and crew sessions.
 // Use ListPolecats() to get only polecat sessions.
 func (m *SessionManager) List() ([]SessionInfo, error) {
-	sessions, err := m.tmux.ListSessions()
+	sessions, err := m.backend.ListSessions()
 	if err != nil {
 		return nil, err
 	}
@@ -660,7 +719,7 @@ func (m *SessionManager) ListPolecats() ([]SessionInfo, error) {
 func (m *SessionManager) Attach(polecat string) error {
 	sessionID := m.SessionName(polecat)
 
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.backend.HasSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
 	}
@@ -668,14 +727,18 @@ func (m *SessionManager) Attach(polecat string) error {
 		return ErrSessionNotFound
 	}
 
-	return m.tmux.AttachSession(sessionID)
+	t, ok := m.tmuxClient()
+	if !ok {
+		return fmt.Errorf("%s: sessions on the %s backend cannot be attached; use gt peek", sessionID, session.BackendHeadless)
+	}
+	return t.AttachSession(sessionID)
 }
 
 // Capture returns the recent output from a polecat session.
 func (m *SessionManager) Capture(polecat string, lines int) (string, error) {
 	sessionID := m.SessionName(polecat)
 
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.backend.HasSession(sessionID)
 	if err != nil {
 		return "", fmt.Errorf("checking session: %w", err)
 	}
@@ -683,12 +746,12 @@ func (m *SessionManager) Capture(polecat string, lines int) (string, error) {
 		return "", ErrSessionNotFound
 	}
 
-	return m.tmux.CapturePane(sessionID, lines)
+	return m.backend.CapturePane(sessionID, lines)
 }
 
 // CaptureSession returns the recent output from a session by raw session ID.
 func (m *SessionManager) CaptureSession(sessionID string, lines int) (string, error) {
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.backend.HasSession(sessionID)
 	if err != nil {
 		return "", fmt.Errorf("checking session: %w", err)
 	}
@@ -696,14 +759,14 @@ func (m *SessionManager) CaptureSession(sessionID string, lines int) (string, er
 		return "", ErrSessionNotFound
 	}
 
-	return m.tmux.CapturePane(sessionID, lines)
+	return m.backend.CapturePane(sessionID, lines)
 }
 
 // Inject sends a message to a polecat session.
 func (m *SessionManager) Inject(polecat, message string) error {
 	sessionID := m.SessionName(polecat)
 
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.backend.HasSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
 	}
@@ -716,7 +779,11 @@ func (m *SessionManager) Inject(polecat, message string) error {
 		debounceMs = 1500
 	}
 
-	return m.tmux.SendKeysDebounced(sessionID, message, debounceMs)
+	t, ok := m.tmuxClient()
+	if !ok {
+		return m.backend.SendKeys(sessionID, message)
+	}
+	return t.SendKeysDebounced(sessionID, message, debounceMs)
 }
 
 // StopAll terminates all polecat sessions for this rig.
@@ -787,7 +854,7 @@ func (m *SessionManager) validateIssue(issueID, workDir string) error {
 //
 // Non-fatal: if verification fails or times out, the session is left running.
 // The witness zombie patrol will eventually detect and handle truly idle polecats.
-func (m *SessionManager) verifyStartupNudgeDelivery(sessionID string, rc *config.RuntimeConfig) {
+func (m *SessionManager) verifyStartupNudgeDelivery(t *tmux.Tmux, sessionID string, rc *config.RuntimeConfig) {
 	// Only verify for agents with prompt detection. Without ReadyPromptPrefix,
 	// we can't distinguish "idle at prompt" from "busy processing".
 	if rc == nil || rc.Tmux == nil || rc.Tmux.ReadyPromptPrefix == "" {
@@ -810,7 +877,7 @@ func (m *SessionManager) verifyStartupNudgeDelivery(sessionID string, rc *config
 		time.Sleep(verifyDelay)
 
 		// Check if session is still alive
-		running, err := m.tmux.HasSession(sessionID)
+		running, err := t.HasSession(sessionID)
 		if err != nil || !running {
 			return // Session died, nothing to verify
 		}
@@ -820,14 +887,14 @@ func (m *SessionManager) verifyStartupNudgeDelivery(sessionID string, rc *config
 		// running tools, generating a response), the status bar shows the busy
 		// indicator and IsIdle returns false — even though ❯ may still be
 		// visible in the pane from before Claude started output.
-		if !m.tmux.IsIdle(sessionID) {
+		if !t.IsIdle(sessionID) {
 			return // Agent is busy — nudge was received and is being processed
 		}
 
 		// Agent is truly idle (no busy indicator, prompt visible) — nudge was likely lost. Retry.
 		fmt.Fprintf(os.Stderr, "[startup-nudge] attempt %d/%d: agent %s idle at prompt, retrying nudge\n",
 			attempt, maxRetries, sessionID)
-		if err := m.tmux.NudgeSession(sessionID, nudgeContent); err != nil {
+		if err := t.NudgeSession(sessionID, nudgeContent); err != nil {
 			fmt.Fprintf(os.Stderr, "[startup-nudge] retry nudge failed for %s: %v\n", sessionID, err)
 			return
 		}
@@ -835,7 +902,7 @@ func (m *SessionManager) verifyStartupNudgeDelivery(sessionID string, rc *config
 
 	// If we exhausted retries and the agent is still idle, log a warning.
 	// The witness zombie patrol will handle this case.
-	if m.tmux.IsIdle(sessionID) {
+	if t.IsIdle(sessionID) {
 		fmt.Fprintf(os.Stderr, "[startup-nudge] WARNING: agent %s still idle after %d nudge retries\n",
 			sessionID, maxRetries)
 	}
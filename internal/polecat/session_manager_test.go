@@ -483,7 +483,7 @@ func TestVerifyStartupNudgeDelivery_IdleAgent(t *testing.T) {
 	// plus overhead = ~60s. Use 90s for safety.
 	done := make(chan struct{})
 	go func() {
-		m.verifyStartupNudgeDelivery(sessionName, rc)
+		m.verifyStartupNudgeDelivery(tm, sessionName, rc)
 		close(done)
 	}()
 
@@ -504,7 +504,7 @@ func TestVerifyStartupNudgeDelivery_NilConfig(t *testing.T) {
 	m := NewSessionManager(tmux.NewTmux(), r)
 
 	// Should return immediately without error for nil config
-	m.verifyStartupNudgeDelivery("nonexistent-session", nil)
+	m.verifyStartupNudgeDelivery(tmux.NewTmux(), "nonexistent-session", nil)
 
 	// And for config without prompt prefix
 	rc := &config.RuntimeConfig{
@@ -513,7 +513,7 @@ func TestVerifyStartupNudgeDelivery_NilConfig(t *testing.T) {
 			ReadyDelayMs:      1000,
 		},
 	}
-	m.verifyStartupNudgeDelivery("nonexistent-session", rc)
+	m.verifyStartupNudgeDelivery(tmux.NewTmux(), "nonexistent-session", rc)
 }
 
 func TestValidateSessionName(t *testing.T) {
@@ -0,0 +1,105 @@
+package protocol
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// DeadLetter is a protocol message a handler could not decode.
+type DeadLetter struct {
+	// MessageID is the mail message ID, if it had one.
+	MessageID string `json:"message_id,omitempty"`
+
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+
+	// Reason is why the message was rejected.
+	Reason string `json:"reason"`
+
+	// RejectedAt is when the message was dead-lettered.
+	RejectedAt time.Time `json:"rejected_at"`
+}
+
+// DeadLetterQueue is an append-only JSONL file of rejected protocol
+// messages, kept so a bad sender can be diagnosed and its mail replayed.
+type DeadLetterQueue struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DeadLetterPath returns the town's dead-letter queue file.
+func DeadLetterPath(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "protocol", "dead-letter.jsonl")
+}
+
+// NewDeadLetterQueue returns a queue stored at path.
+func NewDeadLetterQueue(path string) *DeadLetterQueue {
+	return &DeadLetterQueue{path: path}
+}
+
+// Add records msg as rejected for reason.
+func (q *DeadLetterQueue) Add(msg *mail.Message, reason error) error {
+	line, err := json.Marshal(DeadLetter{
+		MessageID:  msg.ID,
+		From:       msg.From,
+		To:         msg.To,
+		Subject:    msg.Subject,
+		Body:       msg.Body,
+		Reason:     reason.Error(),
+		RejectedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(q.path), 0755); err != nil {
+		return fmt.Errorf("creating dead-letter directory: %w", err)
+	}
+	f, err := os.OpenFile(q.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644) //nolint:gosec // town runtime file
+	if err != nil {
+		return fmt.Errorf("opening dead-letter queue: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing dead-letter queue: %w", err)
+	}
+	return f.Close()
+}
+
+// List returns the dead letters in the order they were rejected. Lines
+// that can't be decoded are skipped.
+func (q *DeadLetterQueue) List() ([]DeadLetter, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	f, err := os.Open(q.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var letters []DeadLetter
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		var dl DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &dl); err == nil {
+			letters = append(letters, dl)
+		}
+	}
+	return letters, scanner.Err()
+}
@@ -0,0 +1,288 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// Protocol messages carry their payload twice: as "Key: value" prose for
+// humans, and as a JSON envelope on the last line of the body for handlers:
+//
+//	Branch: polecat/nux/gt-abc
+//	...
+//	<!-- gt-protocol {"type":"MERGED","version":1,"payload":{...}} -->
+//
+// The envelope is what handlers trust. The prose can be reworded freely.
+const (
+	envelopePrefix = "<!-- gt-protocol "
+	envelopeSuffix = " -->"
+)
+
+// ErrInvalidMessage is returned when a protocol message body can't be
+// decoded into a valid payload. Registries send such messages to their
+// dead-letter queue.
+var ErrInvalidMessage = errors.New("invalid protocol message")
+
+// ErrUnsupportedVersion is returned when an envelope's payload version is
+// outside the range this build reads. It always accompanies
+// ErrInvalidMessage.
+var ErrUnsupportedVersion = errors.New("unsupported payload version")
+
+// Envelope is the machine-readable form of a protocol message.
+type Envelope struct {
+	// Type is the protocol message type. It must match the subject.
+	Type MessageType `json:"type"`
+
+	// Version is the payload schema version for Type.
+	Version int `json:"version"`
+
+	// Payload is the JSON-encoded payload.
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Payload is implemented by every protocol message payload.
+type Payload interface {
+	// Validate returns an error wrapping ErrInvalidMessage if required
+	// fields are missing.
+	Validate() error
+}
+
+// Schema describes the payload a protocol message type carries.
+type Schema struct {
+	// Type is the message type the schema applies to.
+	Type MessageType
+
+	// Version is the payload version this build writes.
+	Version int
+
+	// MinVersion is the oldest payload version this build still reads.
+	MinVersion int
+
+	// newPayload returns an empty payload to unmarshal into.
+	newPayload func() Payload
+
+	// parseLegacy parses a body sent before envelopes existed.
+	parseLegacy func(body string) (Payload, error)
+}
+
+// schemas is the registry of payload schemas, one per message type. Bump a
+// schema's Version when its payload changes incompatibly, and raise
+// MinVersion once no sender still writes the old version.
+var schemas = map[MessageType]*Schema{
+	TypeMergeReady: {
+		Type: TypeMergeReady, Version: 1, MinVersion: 1,
+		newPayload:  func() Payload { return &MergeReadyPayload{} },
+		parseLegacy: func(body string) (Payload, error) { return ParseMergeReadyPayload(body) },
+	},
+	TypeMerged: {
+		Type: TypeMerged, Version: 1, MinVersion: 1,
+		newPayload:  func() Payload { return &MergedPayload{} },
+		parseLegacy: func(body string) (Payload, error) { return ParseMergedPayload(body) },
+	},
+	TypeMergeFailed: {
+		Type: TypeMergeFailed, Version: 1, MinVersion: 1,
+		newPayload:  func() Payload { return &MergeFailedPayload{} },
+		parseLegacy: func(body string) (Payload, error) { return ParseMergeFailedPayload(body) },
+	},
+	TypeFixNeeded: {
+		Type: TypeFixNeeded, Version: 1, MinVersion: 1,
+		newPayload:  func() Payload { return &FixNeededPayload{} },
+		parseLegacy: func(body string) (Payload, error) { return ParseFixNeededPayload(body) },
+	},
+	TypeReworkRequest: {
+		Type: TypeReworkRequest, Version: 1, MinVersion: 1,
+		newPayload:  func() Payload { return &ReworkRequestPayload{} },
+		parseLegacy: func(body string) (Payload, error) { return ParseReworkRequestPayload(body) },
+	},
+	TypeConvoyNeedsFeeding: {
+		Type: TypeConvoyNeedsFeeding, Version: 1, MinVersion: 1,
+		newPayload:  func() Payload { return &ConvoyNeedsFeedingPayload{} },
+		parseLegacy: func(body string) (Payload, error) { return ParseConvoyNeedsFeedingPayload(body) },
+	},
+}
+
+// SchemaFor returns the payload schema for a message type.
+func SchemaFor(msgType MessageType) (*Schema, bool) {
+	s, ok := schemas[msgType]
+	return s, ok
+}
+
+// encodeBody appends payload's envelope to the prose body. The payload
+// types always marshal, so a failure only drops the envelope.
+func encodeBody(msgType MessageType, prose string, payload Payload) string {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return prose
+	}
+	// json.Marshal escapes '<' and '>', so the payload can't end the comment.
+	line, err := json.Marshal(Envelope{Type: msgType, Version: schemas[msgType].Version, Payload: raw})
+	if err != nil {
+		return prose
+	}
+	if !strings.HasSuffix(prose, "\n") {
+		prose += "\n"
+	}
+	return prose + envelopePrefix + string(line) + envelopeSuffix + "\n"
+}
+
+// ExtractEnvelope splits a body into its envelope and prose. It returns a
+// nil envelope if the body has none, and an error wrapping
+// ErrInvalidMessage if the envelope is malformed.
+func ExtractEnvelope(body string) (*Envelope, string, error) {
+	trimmed := strings.TrimRight(body, "\n")
+	start := strings.LastIndex(trimmed, envelopePrefix)
+	if start < 0 || strings.Contains(trimmed[start:], "\n") {
+		return nil, body, nil
+	}
+	line := trimmed[start:]
+	if !strings.HasSuffix(line, envelopeSuffix) {
+		return nil, body, fmt.Errorf("%w: unterminated envelope", ErrInvalidMessage)
+	}
+	var env Envelope
+	if err := json.Unmarshal([]byte(line[len(envelopePrefix):len(line)-len(envelopeSuffix)]), &env); err != nil {
+		return nil, body, fmt.Errorf("%w: malformed envelope: %v", ErrInvalidMessage, err)
+	}
+	return &env, trimmed[:start], nil
+}
+
+// Decode returns the validated payload of a message of the schema's type.
+// Bodies without an envelope are parsed as prose when legacy is true, so
+// mail sent by older builds is still understood during rollout.
+func (s *Schema) Decode(body string, legacy bool) (Payload, error) {
+	env, _, err := ExtractEnvelope(body)
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		if !legacy {
+			return nil, fmt.Errorf("%w: %s message has no envelope", ErrInvalidMessage, s.Type)
+		}
+		return s.parseLegacy(body)
+	}
+	if env.Type != s.Type {
+		return nil, fmt.Errorf("%w: envelope type %s does not match %s", ErrInvalidMessage, env.Type, s.Type)
+	}
+	if env.Version < s.MinVersion || env.Version > s.Version {
+		return nil, fmt.Errorf("%w: %w: %s v%d (reading v%d-v%d)",
+			ErrInvalidMessage, ErrUnsupportedVersion, s.Type, env.Version, s.MinVersion, s.Version)
+	}
+	payload := s.newPayload()
+	if err := json.Unmarshal(env.Payload, payload); err != nil {
+		return nil, fmt.Errorf("%w: decoding %s payload: %v", ErrInvalidMessage, s.Type, err)
+	}
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// DecodeMessage returns the message's type and validated payload.
+func DecodeMessage(msg *mail.Message, legacy bool) (MessageType, Payload, error) {
+	msgType := ParseMessageType(msg.Subject)
+	schema, ok := SchemaFor(msgType)
+	if !ok {
+		return msgType, nil, fmt.Errorf("%w: no schema for subject %q", ErrInvalidMessage, msg.Subject)
+	}
+	body := msg.Body
+	if legacy {
+		body = legacyBody(msgType, msg)
+	}
+	payload, err := schema.Decode(body, legacy)
+	return msgType, payload, err
+}
+
+// Seal validates a protocol message and appends its envelope, so mail
+// composed by hand (for example by an agent running 'gt mail send') reaches
+// handlers in the same form as mail built by the New*Message constructors.
+// Messages that already carry an envelope are only validated. The error
+// wraps ErrInvalidMessage if the body is missing required fields.
+func Seal(msg *mail.Message) error {
+	msgType, payload, err := DecodeMessage(msg, true)
+	if err != nil {
+		return err
+	}
+	if env, _, _ := ExtractEnvelope(msg.Body); env != nil {
+		return nil
+	}
+	msg.Body = encodeBody(msgType, msg.Body, payload)
+	return nil
+}
+
+// NudgeText appends payload's envelope to a one-line nudge. Nudges are typed
+// into a session rather than delivered as mail, so the envelope goes on the
+// same line as the text instead of a line of its own.
+func NudgeText(text string, msgType MessageType, payload Payload) string {
+	body := encodeBody(msgType, "", payload)
+	return text + " " + strings.TrimSpace(body)
+}
+
+// legacyBody returns msg's body with the Polecat and Rig fields added when
+// the prose omits them. Older senders carried the polecat only in the
+// subject ("MERGED nux") and the rig only in the address.
+func legacyBody(msgType MessageType, msg *mail.Message) string {
+	if msgType == TypeConvoyNeedsFeeding {
+		return msg.Body
+	}
+	body := msg.Body
+	if parseField(body, "Polecat") == "" {
+		polecat := ExtractPolecat(msg.Subject)
+		if i := strings.LastIndex(polecat, "/"); i >= 0 {
+			polecat = polecat[i+1:]
+		}
+		if polecat != "" {
+			body = fmt.Sprintf("Polecat: %s\n%s", polecat, body)
+		}
+	}
+	if parseField(body, "Rig") == "" {
+		if rig := addressRig(msg.To, msg.From); rig != "" {
+			body = fmt.Sprintf("Rig: %s\n%s", rig, body)
+		}
+	}
+	return body
+}
+
+// addressRig returns the rig of the first rig-scoped address. Town-level
+// addresses like "mayor/" and "deacon/" have no rig.
+func addressRig(addrs ...string) string {
+	for _, addr := range addrs {
+		rig, _, ok := strings.Cut(addr, "/")
+		if !ok || rig == "" || rig == "mayor" || rig == "deacon" {
+			continue
+		}
+		return rig
+	}
+	return ""
+}
+
+// decodeAs decodes msg's payload as a T.
+func decodeAs[T Payload](msg *mail.Message, legacy bool) (T, error) {
+	var zero T
+	msgType, payload, err := DecodeMessage(msg, legacy)
+	if err != nil {
+		return zero, err
+	}
+	p, ok := payload.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s payload is %T, want %T", ErrInvalidMessage, msgType, payload, zero)
+	}
+	return p, nil
+}
+
+// requireFields returns an error naming the empty fields, given as
+// alternating names and values.
+func requireFields(msgType MessageType, fields ...string) error {
+	var missing []string
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i+1] == "" {
+			missing = append(missing, fields[i])
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s payload: missing required fields: %s", ErrInvalidMessage, msgType, strings.Join(missing, ", "))
+	}
+	return nil
+}
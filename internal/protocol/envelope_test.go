@@ -0,0 +1,220 @@
+package protocol
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	errOutput := "FAIL: TestFoo\n  expected <nil> --> got error\nexit status 1"
+	msg := NewFixNeededMessage("gastown", "nux", "polecat/nux", "gt-abc", "main", "tests", errOutput, "gt-mr1", 2)
+
+	// The prose is still there for humans.
+	if !strings.Contains(msg.Body, "Failure-Type: tests") {
+		t.Errorf("body lost its prose: %s", msg.Body)
+	}
+	env, prose, err := ExtractEnvelope(msg.Body)
+	if err != nil || env == nil {
+		t.Fatalf("ExtractEnvelope = %v, %v", env, err)
+	}
+	if env.Type != TypeFixNeeded || env.Version != 1 {
+		t.Errorf("envelope = %s v%d", env.Type, env.Version)
+	}
+	if strings.Contains(prose, envelopePrefix) {
+		t.Errorf("prose still contains the envelope: %s", prose)
+	}
+
+	msgType, payload, err := DecodeMessage(msg, false)
+	if err != nil {
+		t.Fatalf("DecodeMessage: %v", err)
+	}
+	p := payload.(*FixNeededPayload)
+	if msgType != TypeFixNeeded || p.Error != errOutput || p.MRBeadID != "gt-mr1" || p.AttemptNumber != 2 {
+		t.Errorf("decoded %s %+v", msgType, p)
+	}
+}
+
+func TestEnvelopeAllTypes(t *testing.T) {
+	msgs := []*mail.Message{
+		NewMergeReadyMessage("gastown", "nux", "polecat/nux", "gt-abc"),
+		NewMergedMessage("gastown", "nux", "polecat/nux", "gt-abc", "main", "abc123"),
+		NewMergeFailedMessage("gastown", "nux", "polecat/nux", "gt-abc", "main", "build", "boom"),
+		NewFixNeededMessage("gastown", "nux", "polecat/nux", "gt-abc", "main", "lint", "vet", "", 1),
+		NewReworkRequestMessage("gastown", "nux", "polecat/nux", "gt-abc", "main", []string{"a.go"}),
+		NewConvoyNeedsFeedingMessage("gastown", "hq-cv1", "gt-abc"),
+	}
+	for _, msg := range msgs {
+		msgType, _, err := DecodeMessage(msg, false)
+		if err != nil {
+			t.Errorf("%s: %v", msg.Subject, err)
+		}
+		if _, ok := SchemaFor(msgType); !ok {
+			t.Errorf("%s: no schema", msgType)
+		}
+	}
+}
+
+func TestDecodeLegacyBody(t *testing.T) {
+	msg := &mail.Message{
+		Subject: "MERGED nux",
+		Body:    "Branch: polecat/nux\nIssue: gt-abc\nPolecat: nux\nRig: gastown\nTarget: main",
+	}
+	_, payload, err := DecodeMessage(msg, true)
+	if err != nil {
+		t.Fatalf("legacy body: %v", err)
+	}
+	if p := payload.(*MergedPayload); p.Branch != "polecat/nux" || p.TargetBranch != "main" {
+		t.Errorf("legacy payload = %+v", p)
+	}
+	if _, _, err := DecodeMessage(msg, false); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("legacy off: err = %v, want ErrInvalidMessage", err)
+	}
+}
+
+func TestSeal(t *testing.T) {
+	// What an agent sends by hand: polecat in the subject, rig in the
+	// address, no envelope.
+	msg := mail.NewMessage("gastown/refinery", "gastown/witness", "MERGED nux",
+		"Branch: polecat/nux/gt-abc\nIssue: gt-abc\nMerged-At: 2026-01-02T03:04:05Z")
+	if err := Seal(msg); err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	_, payload, err := DecodeMessage(msg, false)
+	if err != nil {
+		t.Fatalf("sealed message without legacy: %v", err)
+	}
+	if p := payload.(*MergedPayload); p.Polecat != "nux" || p.Rig != "gastown" || p.Issue != "gt-abc" {
+		t.Errorf("sealed payload = %+v", p)
+	}
+
+	// Sealing twice leaves one envelope.
+	body := msg.Body
+	if err := Seal(msg); err != nil || msg.Body != body {
+		t.Errorf("second Seal changed the body or failed: %v", err)
+	}
+
+	bad := mail.NewMessage("gastown/refinery", "gastown/witness", "MERGED nux", "Issue: gt-abc")
+	if err := Seal(bad); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("Seal(no branch) = %v, want ErrInvalidMessage", err)
+	}
+	if strings.Contains(bad.Body, envelopePrefix) {
+		t.Error("invalid message was sealed")
+	}
+}
+
+func TestNudgeText(t *testing.T) {
+	payload := &ConvoyNeedsFeedingPayload{ConvoyID: "hq-cv1", SourceIssue: "gt-abc", Rig: "gastown"}
+	text := NudgeText("CONVOY_NEEDS_FEEDING: convoy=hq-cv1 issue=gt-abc", TypeConvoyNeedsFeeding, payload)
+	if strings.Contains(text, "\n") {
+		t.Errorf("nudge text spans lines: %q", text)
+	}
+	env, prose, err := ExtractEnvelope(text)
+	if err != nil || env == nil {
+		t.Fatalf("ExtractEnvelope = %v, %v", env, err)
+	}
+	if env.Type != TypeConvoyNeedsFeeding || !strings.HasPrefix(prose, "CONVOY_NEEDS_FEEDING: convoy=hq-cv1") {
+		t.Errorf("envelope %s, prose %q", env.Type, prose)
+	}
+	schema, _ := SchemaFor(TypeConvoyNeedsFeeding)
+	if _, err := schema.Decode(text, false); err != nil {
+		t.Errorf("Decode nudge text: %v", err)
+	}
+}
+
+func TestRegistryDecode(t *testing.T) {
+	registry := NewHandlerRegistry()
+	q := NewDeadLetterQueue(DeadLetterPath(t.TempDir()))
+	registry.SetDeadLetterQueue(q)
+
+	good := NewMergeFailedMessage("gastown", "nux", "polecat/nux", "gt-abc", "main", "tests", "boom")
+	payload, err := registry.Decode(good)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if p, ok := payload.(*MergeFailedPayload); !ok || p.Error != "boom" {
+		t.Errorf("payload = %#v", payload)
+	}
+
+	bad := &mail.Message{ID: "hq-9", Subject: "MERGE_FAILED nux", Body: "Error: boom"}
+	if _, err := registry.Decode(bad); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("Decode(bad) = %v, want ErrInvalidMessage", err)
+	}
+	letters, err := q.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(letters) != 1 || letters[0].MessageID != "hq-9" {
+		t.Errorf("dead letters = %+v", letters)
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	merged := NewMergedMessage("gastown", "nux", "polecat/nux", "gt-abc", "main", "abc123")
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		version bool
+	}{
+		{"type mismatch", "MERGE_READY nux", merged.Body, false},
+		{"future version", "MERGED nux", strings.Replace(merged.Body, `"version":1`, `"version":2`, 1), true},
+		{"malformed envelope", "MERGED nux", "Branch: x\n" + envelopePrefix + "{not json}" + envelopeSuffix, false},
+		{"unterminated envelope", "MERGED nux", "Branch: x\n" + envelopePrefix + "{}", false},
+		{"missing fields", "MERGED nux", "Branch: x\n" + envelopePrefix + `{"type":"MERGED","version":1,"payload":{"branch":"x"}}` + envelopeSuffix, false},
+		{"not a protocol message", "hello", merged.Body, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := DecodeMessage(&mail.Message{Subject: tt.subject, Body: tt.body}, true)
+			if !errors.Is(err, ErrInvalidMessage) {
+				t.Errorf("err = %v, want ErrInvalidMessage", err)
+			}
+			if tt.version && !errors.Is(err, ErrUnsupportedVersion) {
+				t.Errorf("err = %v, want ErrUnsupportedVersion", err)
+			}
+		})
+	}
+}
+
+func TestRegistryDeadLetters(t *testing.T) {
+	handler := &mockWitnessHandler{}
+	registry := WrapWitnessHandlers(handler)
+	q := NewDeadLetterQueue(DeadLetterPath(t.TempDir()))
+	registry.SetDeadLetterQueue(q)
+
+	bad := &mail.Message{ID: "hq-1", From: "gastown/refinery", Subject: "MERGED nux", Body: "garbage"}
+	isProto, err := registry.ProcessProtocolMessage(bad)
+	if !isProto || !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("ProcessProtocolMessage = %v, %v; want true, ErrInvalidMessage", isProto, err)
+	}
+	if handler.mergedCalled {
+		t.Error("handler should not see an invalid message")
+	}
+
+	good := NewMergedMessage("gastown", "nux", "polecat/nux", "gt-abc", "main", "abc123")
+	if _, err := registry.ProcessProtocolMessage(good); err != nil {
+		t.Errorf("valid message: %v", err)
+	}
+
+	letters, err := q.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(letters) != 1 || letters[0].MessageID != "hq-1" || letters[0].Body != "garbage" {
+		t.Fatalf("dead letters = %+v", letters)
+	}
+	if !strings.Contains(letters[0].Reason, "missing required fields") {
+		t.Errorf("reason = %q", letters[0].Reason)
+	}
+}
+
+func TestDeadLetterQueue_ListMissing(t *testing.T) {
+	q := NewDeadLetterQueue(filepath.Join(t.TempDir(), "none.jsonl"))
+	if letters, err := q.List(); letters != nil || err != nil {
+		t.Errorf("List = %v, %v; want nil, nil", letters, err)
+	}
+}
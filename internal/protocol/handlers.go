@@ -19,15 +19,35 @@ type Handler func(msg *mail.Message) error
 // HandlerRegistry maps message types to their handlers.
 type HandlerRegistry struct {
 	handlers map[MessageType]Handler
+
+	// deadLetters receives messages whose handler rejected them as
+	// ErrInvalidMessage. Nil discards them.
+	deadLetters *DeadLetterQueue
+
+	// legacy accepts bodies without an envelope, parsing the prose instead.
+	legacy bool
 }
 
-// NewHandlerRegistry creates a new handler registry.
+// NewHandlerRegistry creates a new handler registry. It accepts legacy
+// (pre-envelope) message bodies until SetAcceptLegacy(false) is called.
 func NewHandlerRegistry() *HandlerRegistry {
 	return &HandlerRegistry{
 		handlers: make(map[MessageType]Handler),
+		legacy:   true,
 	}
 }
 
+// SetDeadLetterQueue sets where messages that fail validation are kept.
+func (r *HandlerRegistry) SetDeadLetterQueue(q *DeadLetterQueue) {
+	r.deadLetters = q
+}
+
+// SetAcceptLegacy controls whether messages without an envelope are parsed
+// from their prose body. Turn it off once every sender writes envelopes.
+func (r *HandlerRegistry) SetAcceptLegacy(accept bool) {
+	r.legacy = accept
+}
+
 // Register adds a handler for a specific message type.
 func (r *HandlerRegistry) Register(msgType MessageType, handler Handler) {
 	r.handlers[msgType] = handler
@@ -35,6 +55,9 @@ func (r *HandlerRegistry) Register(msgType MessageType, handler Handler) {
 
 // Handle dispatches a message to the appropriate handler.
 // Returns an error if no handler is registered for the message type.
+// Messages the handler rejects with ErrInvalidMessage are added to the
+// dead-letter queue; the returned error still wraps ErrInvalidMessage so
+// the caller can archive the mail rather than retry it.
 func (r *HandlerRegistry) Handle(msg *mail.Message) error {
 	msgType := ParseMessageType(msg.Subject)
 	if msgType == "" {
@@ -46,7 +69,29 @@ func (r *HandlerRegistry) Handle(msg *mail.Message) error {
 		return fmt.Errorf("no handler registered for message type: %s", msgType)
 	}
 
-	return handler(msg)
+	return r.reject(msg, handler(msg))
+}
+
+// Decode returns the message's validated payload without dispatching it.
+// It is for receivers that act on the payload themselves. Like Handle, it
+// honors the registry's legacy setting and dead-letters invalid messages.
+func (r *HandlerRegistry) Decode(msg *mail.Message) (Payload, error) {
+	_, payload, err := DecodeMessage(msg, r.legacy)
+	if err != nil {
+		return nil, r.reject(msg, err)
+	}
+	return payload, nil
+}
+
+// reject adds msg to the dead-letter queue if err wraps ErrInvalidMessage,
+// and returns err.
+func (r *HandlerRegistry) reject(msg *mail.Message, err error) error {
+	if errors.Is(err, ErrInvalidMessage) && r.deadLetters != nil {
+		if dlErr := r.deadLetters.Add(msg, err); dlErr != nil {
+			return errors.Join(err, fmt.Errorf("dead-lettering message: %w", dlErr))
+		}
+	}
+	return err
 }
 
 // CanHandle returns true if a handler is registered for the message's type.
@@ -81,11 +126,13 @@ type RefineryHandler interface {
 }
 
 // WrapWitnessHandlers creates mail handlers from a WitnessHandler.
+// Payloads are decoded from the message envelope and validated before the
+// handler sees them.
 func WrapWitnessHandlers(h WitnessHandler) *HandlerRegistry {
 	registry := NewHandlerRegistry()
 
 	registry.Register(TypeMerged, func(msg *mail.Message) error {
-		payload, err := ParseMergedPayload(msg.Body)
+		payload, err := decodeAs[*MergedPayload](msg, registry.legacy)
 		if err != nil {
 			return err
 		}
@@ -93,7 +140,7 @@ func WrapWitnessHandlers(h WitnessHandler) *HandlerRegistry {
 	})
 
 	registry.Register(TypeMergeFailed, func(msg *mail.Message) error {
-		payload, err := ParseMergeFailedPayload(msg.Body)
+		payload, err := decodeAs[*MergeFailedPayload](msg, registry.legacy)
 		if err != nil {
 			return err
 		}
@@ -101,7 +148,7 @@ func WrapWitnessHandlers(h WitnessHandler) *HandlerRegistry {
 	})
 
 	registry.Register(TypeReworkRequest, func(msg *mail.Message) error {
-		payload, err := ParseReworkRequestPayload(msg.Body)
+		payload, err := decodeAs[*ReworkRequestPayload](msg, registry.legacy)
 		if err != nil {
 			return err
 		}
@@ -112,11 +159,13 @@ func WrapWitnessHandlers(h WitnessHandler) *HandlerRegistry {
 }
 
 // WrapRefineryHandlers creates mail handlers from a RefineryHandler.
+// Payloads are decoded from the message envelope and validated before the
+// handler sees them.
 func WrapRefineryHandlers(h RefineryHandler) *HandlerRegistry {
 	registry := NewHandlerRegistry()
 
 	registry.Register(TypeMergeReady, func(msg *mail.Message) error {
-		payload, err := ParseMergeReadyPayload(msg.Body)
+		payload, err := decodeAs[*MergeReadyPayload](msg, registry.legacy)
 		if err != nil {
 			return err
 		}
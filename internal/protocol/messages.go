@@ -20,7 +20,7 @@ func NewMergeReadyMessage(rig, polecat, branch, issue string) *mail.Message {
 		Timestamp: time.Now(),
 	}
 
-	body := formatMergeReadyBody(payload)
+	body := encodeBody(TypeMergeReady, formatMergeReadyBody(payload), &payload)
 
 	msg := mail.NewMessage(
 		fmt.Sprintf("%s/witness", rig),
@@ -60,7 +60,7 @@ func NewMergedMessage(rig, polecat, branch, issue, targetBranch, mergeCommit str
 		TargetBranch: targetBranch,
 	}
 
-	body := formatMergedBody(payload)
+	body := encodeBody(TypeMerged, formatMergedBody(payload), &payload)
 
 	msg := mail.NewMessage(
 		fmt.Sprintf("%s/refinery", rig),
@@ -103,7 +103,7 @@ func NewMergeFailedMessage(rig, polecat, branch, issue, targetBranch, failureTyp
 		TargetBranch: targetBranch,
 	}
 
-	body := formatMergeFailedBody(payload)
+	body := encodeBody(TypeMergeFailed, formatMergeFailedBody(payload), &payload)
 
 	msg := mail.NewMessage(
 		fmt.Sprintf("%s/refinery", rig),
@@ -149,7 +149,7 @@ func NewFixNeededMessage(rig, polecat, branch, issue, targetBranch, failureType,
 		AttemptNumber: attemptNumber,
 	}
 
-	body := formatFixNeededBody(payload)
+	body := encodeBody(TypeFixNeeded, formatFixNeededBody(payload), &payload)
 
 	msg := mail.NewMessage(
 		fmt.Sprintf("%s/refinery", rig),
@@ -207,18 +207,8 @@ func ParseFixNeededPayload(body string) (*FixNeededPayload, error) {
 		fmt.Sscanf(an, "%d", &payload.AttemptNumber)
 	}
 
-	var errs []string
-	if payload.Branch == "" {
-		errs = append(errs, "Branch")
-	}
-	if payload.Polecat == "" {
-		errs = append(errs, "Polecat")
-	}
-	if payload.Rig == "" {
-		errs = append(errs, "Rig")
-	}
-	if len(errs) > 0 {
-		return nil, fmt.Errorf("invalid FIX_NEEDED payload: missing required fields: %s", strings.Join(errs, ", "))
+	if err := payload.Validate(); err != nil {
+		return nil, err
 	}
 
 	return payload, nil
@@ -238,7 +228,7 @@ func NewReworkRequestMessage(rig, polecat, branch, issue, targetBranch string, c
 		Instructions:  formatRebaseInstructions(targetBranch),
 	}
 
-	body := formatReworkRequestBody(payload)
+	body := encodeBody(TypeReworkRequest, formatReworkRequestBody(payload), &payload)
 
 	msg := mail.NewMessage(
 		fmt.Sprintf("%s/refinery", rig),
@@ -295,7 +285,7 @@ func NewConvoyNeedsFeedingMessage(rig, convoyID, sourceIssue string) *mail.Messa
 		MergedAt:    time.Now(),
 	}
 
-	body := formatConvoyNeedsFeedingBody(payload)
+	body := encodeBody(TypeConvoyNeedsFeeding, formatConvoyNeedsFeedingBody(payload), &payload)
 
 	msg := mail.NewMessage(
 		fmt.Sprintf("%s/refinery", rig),
@@ -334,15 +324,8 @@ func ParseConvoyNeedsFeedingPayload(body string) (*ConvoyNeedsFeedingPayload, er
 		}
 	}
 
-	var errs []string
-	if payload.ConvoyID == "" {
-		errs = append(errs, "ConvoyID")
-	}
-	if payload.Rig == "" {
-		errs = append(errs, "Rig")
-	}
-	if len(errs) > 0 {
-		return nil, fmt.Errorf("invalid CONVOY_NEEDS_FEEDING payload: missing required fields: %s", strings.Join(errs, ", "))
+	if err := payload.Validate(); err != nil {
+		return nil, err
 	}
 
 	return payload, nil
@@ -360,18 +343,8 @@ func ParseMergeReadyPayload(body string) (*MergeReadyPayload, error) {
 		Timestamp: time.Now(), // Use current time if not parseable
 	}
 
-	var errs []string
-	if payload.Branch == "" {
-		errs = append(errs, "Branch")
-	}
-	if payload.Polecat == "" {
-		errs = append(errs, "Polecat")
-	}
-	if payload.Rig == "" {
-		errs = append(errs, "Rig")
-	}
-	if len(errs) > 0 {
-		return nil, fmt.Errorf("invalid MERGE_READY payload: missing required fields: %s", strings.Join(errs, ", "))
+	if err := payload.Validate(); err != nil {
+		return nil, err
 	}
 
 	return payload, nil
@@ -396,18 +369,8 @@ func ParseMergedPayload(body string) (*MergedPayload, error) {
 		}
 	}
 
-	var errs []string
-	if payload.Branch == "" {
-		errs = append(errs, "Branch")
-	}
-	if payload.Polecat == "" {
-		errs = append(errs, "Polecat")
-	}
-	if payload.Rig == "" {
-		errs = append(errs, "Rig")
-	}
-	if len(errs) > 0 {
-		return nil, fmt.Errorf("invalid MERGED payload: missing required fields: %s", strings.Join(errs, ", "))
+	if err := payload.Validate(); err != nil {
+		return nil, err
 	}
 
 	return payload, nil
@@ -433,18 +396,8 @@ func ParseMergeFailedPayload(body string) (*MergeFailedPayload, error) {
 		}
 	}
 
-	var errs []string
-	if payload.Branch == "" {
-		errs = append(errs, "Branch")
-	}
-	if payload.Polecat == "" {
-		errs = append(errs, "Polecat")
-	}
-	if payload.Rig == "" {
-		errs = append(errs, "Rig")
-	}
-	if len(errs) > 0 {
-		return nil, fmt.Errorf("invalid MERGE_FAILED payload: missing required fields: %s", strings.Join(errs, ", "))
+	if err := payload.Validate(); err != nil {
+		return nil, err
 	}
 
 	return payload, nil
@@ -473,18 +426,8 @@ func ParseReworkRequestPayload(body string) (*ReworkRequestPayload, error) {
 		payload.ConflictFiles = strings.Split(files, ", ")
 	}
 
-	var errs []string
-	if payload.Branch == "" {
-		errs = append(errs, "Branch")
-	}
-	if payload.Polecat == "" {
-		errs = append(errs, "Polecat")
-	}
-	if payload.Rig == "" {
-		errs = append(errs, "Rig")
-	}
-	if len(errs) > 0 {
-		return nil, fmt.Errorf("invalid REWORK_REQUEST payload: missing required fields: %s", strings.Join(errs, ", "))
+	if err := payload.Validate(); err != nil {
+		return nil, err
 	}
 
 	return payload, nil
@@ -9,6 +9,11 @@
 //   - MERGE_FAILED: Refinery → Witness (merge failed, needs rework) [LEGACY]
 //   - FIX_NEEDED: Refinery → Polecat (merge failed, fix and resubmit)
 //   - REWORK_REQUEST: Refinery → Witness (rebase needed)
+//
+// Each message body carries a versioned JSON envelope after its
+// human-readable prose. Handlers decode and validate the envelope against
+// the schema registered for the message type, and send messages that fail
+// to a dead-letter queue.
 package protocol
 
 import (
@@ -102,6 +107,11 @@ type MergeReadyPayload struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// Validate checks that the required fields (Branch, Polecat, Rig) are set.
+func (p *MergeReadyPayload) Validate() error {
+	return requireFields(TypeMergeReady, "Branch", p.Branch, "Polecat", p.Polecat, "Rig", p.Rig)
+}
+
 // MergedPayload contains the data for a MERGED message.
 // Sent by Refinery after successful merge to target branch.
 type MergedPayload struct {
@@ -127,6 +137,11 @@ type MergedPayload struct {
 	TargetBranch string `json:"target_branch"`
 }
 
+// Validate checks that the required fields (Branch, Polecat, Rig) are set.
+func (p *MergedPayload) Validate() error {
+	return requireFields(TypeMerged, "Branch", p.Branch, "Polecat", p.Polecat, "Rig", p.Rig)
+}
+
 // MergeFailedPayload contains the data for a MERGE_FAILED message.
 // Sent by Refinery when merge fails due to tests, build, or other errors.
 type MergeFailedPayload struct {
@@ -155,6 +170,11 @@ type MergeFailedPayload struct {
 	TargetBranch string `json:"target_branch"`
 }
 
+// Validate checks that the required fields (Branch, Polecat, Rig) are set.
+func (p *MergeFailedPayload) Validate() error {
+	return requireFields(TypeMergeFailed, "Branch", p.Branch, "Polecat", p.Polecat, "Rig", p.Rig)
+}
+
 // FixNeededPayload contains the data for a FIX_NEEDED message.
 // Sent by Refinery directly to the Polecat when merge fails due to tests,
 // build, lint, or other quality checks. The polecat fixes and resubmits.
@@ -190,6 +210,11 @@ type FixNeededPayload struct {
 	AttemptNumber int `json:"attempt_number"`
 }
 
+// Validate checks that the required fields (Branch, Polecat, Rig) are set.
+func (p *FixNeededPayload) Validate() error {
+	return requireFields(TypeFixNeeded, "Branch", p.Branch, "Polecat", p.Polecat, "Rig", p.Rig)
+}
+
 // ReworkRequestPayload contains the data for a REWORK_REQUEST message.
 // Sent by Refinery when a polecat's branch has conflicts requiring rebase.
 type ReworkRequestPayload struct {
@@ -218,6 +243,11 @@ type ReworkRequestPayload struct {
 	Instructions string `json:"instructions,omitempty"`
 }
 
+// Validate checks that the required fields (Branch, Polecat, Rig) are set.
+func (p *ReworkRequestPayload) Validate() error {
+	return requireFields(TypeReworkRequest, "Branch", p.Branch, "Polecat", p.Polecat, "Rig", p.Rig)
+}
+
 // PolecatDonePayload contains the data from a POLECAT_DONE notification.
 // This is not a formal protocol message (it's a mail convention), but the
 // payload is structured for programmatic parsing by witness handlers.
@@ -272,6 +302,11 @@ type ConvoyNeedsFeedingPayload struct {
 	MergedAt time.Time `json:"merged_at"`
 }
 
+// Validate checks that the required fields (ConvoyID, Rig) are set.
+func (p *ConvoyNeedsFeedingPayload) Validate() error {
+	return requireFields(TypeConvoyNeedsFeeding, "ConvoyID", p.ConvoyID, "Rig", p.Rig)
+}
+
 // IsProtocolMessage returns true if the subject matches a known protocol type.
 func IsProtocolMessage(subject string) bool {
 	return ParseMessageType(subject) != ""
@@ -6,7 +6,6 @@ import (
 	"os"
 
 	"github.com/steveyegge/gastown/internal/mail"
-	"github.com/steveyegge/gastown/internal/witness"
 )
 
 // DefaultWitnessHandler provides the default implementation for Witness protocol handlers.
@@ -44,7 +43,7 @@ func (h *DefaultWitnessHandler) SetOutput(w io.Writer) {
 // When a branch is successfully merged, the Witness:
 // 1. Logs the success
 // 2. Notifies the polecat of successful merge
-// 3. Initiates polecat cleanup (nuke worktree)
+// 3. Leaves the polecat idle with its sandbox preserved (gt-4ac)
 func (h *DefaultWitnessHandler) HandleMerged(payload *MergedPayload) error {
 	_, _ = fmt.Fprintf(h.Output, "[Witness] MERGED received for polecat %s\n", payload.Polecat)
 	_, _ = fmt.Fprintf(h.Output, "  Branch: %s\n", payload.Branch)
@@ -60,19 +59,8 @@ func (h *DefaultWitnessHandler) HandleMerged(payload *MergedPayload) error {
 		// Continue - notification is best-effort
 	}
 
-	// Initiate polecat cleanup using AutoNukeIfClean
-	// This verifies cleanup_status before nuking to prevent work loss.
-	nukeResult := witness.AutoNukeIfClean(h.WorkDir, h.Rig, payload.Polecat)
-	if nukeResult.Nuked {
-		fmt.Fprintf(h.Output, "[Witness] ✓ Auto-nuked polecat %s: %s\n", payload.Polecat, nukeResult.Reason)
-	} else if nukeResult.Skipped {
-		fmt.Fprintf(h.Output, "[Witness] ⚠ Cleanup skipped for %s: %s\n", payload.Polecat, nukeResult.Reason)
-	} else if nukeResult.Error != nil {
-		fmt.Fprintf(h.Output, "[Witness] ✗ Cleanup failed for %s: %v\n", payload.Polecat, nukeResult.Error)
-		return fmt.Errorf("cleanup failed for polecat %s: %w", payload.Polecat, nukeResult.Error)
-	} else {
-		fmt.Fprintf(h.Output, "[Witness] ✓ Polecat %s work merged, cleanup can proceed\n", payload.Polecat)
-	}
+	// Persistent polecat model (gt-4ac): polecats are never nuked on merge.
+	fmt.Fprintf(h.Output, "[Witness] ✓ Polecat %s work merged, sandbox preserved for reuse\n", payload.Polecat)
 
 	return nil
 }
@@ -119,17 +107,7 @@ func (h *DefaultWitnessHandler) HandlePolecatDone(payload *PolecatDonePayload) e
 
 	if payload.SkipMergeFlow() {
 		_, _ = fmt.Fprintf(h.Output, "[Witness] ✓ Owned+direct convoy %s — merge flow skipped\n", payload.ConvoyID)
-		_, _ = fmt.Fprintf(h.Output, "  Polecat already pushed to main. Proceeding with cleanup only.\n")
-
-		// Initiate polecat cleanup (same as HandleMerged)
-		nukeResult := witness.AutoNukeIfClean(h.WorkDir, h.Rig, payload.Polecat)
-		if nukeResult.Nuked {
-			fmt.Fprintf(h.Output, "[Witness] ✓ Auto-nuked polecat %s: %s\n", payload.Polecat, nukeResult.Reason)
-		} else if nukeResult.Skipped {
-			fmt.Fprintf(h.Output, "[Witness] ⚠ Cleanup skipped for %s: %s\n", payload.Polecat, nukeResult.Reason)
-		} else if nukeResult.Error != nil {
-			fmt.Fprintf(h.Output, "[Witness] ✗ Cleanup failed for %s: %v\n", payload.Polecat, nukeResult.Error)
-		}
+		_, _ = fmt.Fprintf(h.Output, "  Polecat already pushed to main. Sandbox preserved for reuse (gt-4ac).\n")
 
 		return nil
 	}
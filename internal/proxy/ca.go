@@ -15,6 +15,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -153,6 +154,16 @@ func (ca *CA) IssuePolecat(cn string, ttl time.Duration) (certPEM, keyPEM []byte
 	return ca.issue(cn, nil, nil, ttl, x509.ExtKeyUsageClientAuth)
 }
 
+// IssueClient issues a client-auth leaf certificate for a non-polecat consumer
+// of the town CA (e.g. a gt dashboard operator). CNs starting with "gt-" are
+// rejected so these certs can never be mistaken for polecat identities.
+func (ca *CA) IssueClient(cn string, ttl time.Duration) (certPEM, keyPEM []byte, err error) {
+	if cn == "" || strings.HasPrefix(cn, "gt-") {
+		return nil, nil, fmt.Errorf("invalid client CN %q: must be non-empty and must not start with \"gt-\"", cn)
+	}
+	return ca.issue(cn, nil, nil, ttl, x509.ExtKeyUsageClientAuth)
+}
+
 // issue creates and signs a leaf certificate. dnsNames and ipAddrs are added as SANs
 // for server certs so that modern TLS stacks (Go 1.15+) accept them without relying on CN.
 func (ca *CA) issue(cn string, dnsNames []string, ipAddrs []net.IP, ttl time.Duration, eku x509.ExtKeyUsage) (certPEM, keyPEM []byte, err error) {
@@ -355,6 +355,37 @@ func TestIssuePolecat(t *testing.T) {
 	})
 }
 
+func TestIssueClient(t *testing.T) {
+	ca, err := GenerateCA(t.TempDir())
+	require.NoError(t, err)
+
+	t.Run("issues client-auth cert with given CN", func(t *testing.T) {
+		certPEM, _, err := ca.IssueClient("dashboard-alice", time.Hour)
+		require.NoError(t, err)
+
+		block, _ := pem.Decode(certPEM)
+		require.NotNil(t, block)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		assert.Equal(t, "dashboard-alice", cert.Subject.CommonName)
+
+		pool := x509.NewCertPool()
+		pool.AddCert(ca.Cert)
+		_, err = cert.Verify(x509.VerifyOptions{
+			Roots:     pool,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("polecat-style and empty CNs are rejected", func(t *testing.T) {
+		for _, cn := range []string{"", "gt-gastown-furiosa", "gt-"} {
+			_, _, err := ca.IssueClient(cn, time.Hour)
+			assert.Error(t, err, "cn %q", cn)
+		}
+	})
+}
+
 func TestCertEdgeCases(t *testing.T) {
 	dir := t.TempDir()
 	ca, err := GenerateCA(dir)
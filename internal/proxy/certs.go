@@ -0,0 +1,354 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultAdminAddr is the admin server address gt-proxy-server listens on
+// unless configured otherwise.
+const DefaultAdminAddr = "127.0.0.1:9877"
+
+// DefaultListenAddr is the mTLS address gt-proxy-server listens on unless
+// configured otherwise.
+const DefaultListenAddr = "0.0.0.0:9876"
+
+// issuePolecat issues a polecat cert, records it in the registry, and builds
+// the response shared by the issue and renew endpoints. renewedFrom is the
+// serial of the cert being replaced, if any. A cert that can't be recorded is
+// not handed out: the registry is what lets a nuke revoke it later.
+func (s *Server) issuePolecat(cn string, ttl time.Duration, renewedFrom string) (*issueCertResponse, error) {
+	certPEM, keyPEM, err := s.ca.IssuePolecat(cn, ttl)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("failed to decode issued certificate PEM")
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.certs.Record(newIssuedCert(leaf, CertUsagePolecat, renewedFrom)); err != nil {
+		return nil, fmt.Errorf("record issued cert: %w", err)
+	}
+	return &issueCertResponse{
+		CN:        cn,
+		Cert:      string(certPEM),
+		Key:       string(keyPEM),
+		CA:        string(s.ca.CertPEM),
+		Serial:    leaf.SerialNumber.Text(16),
+		ExpiresAt: leaf.NotAfter.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// revokeSerial denies a serial, filling in the CN and expiry from the
+// registry when the cert was issued here.
+func (s *Server) revokeSerial(serial, reason string) error {
+	rev := Revocation{Serial: serial, Reason: reason}
+	if c, ok := s.certs.Get(serial); ok {
+		rev.CN = c.CN
+		rev.ExpiresAt = c.ExpiresAt
+	}
+	return s.denyList.Revoke(rev)
+}
+
+// handleRenewCert handles POST /v1/cert/renew on the mTLS server.
+// A polecat presenting a valid, unrevoked cert receives a fresh cert for the
+// same CN with the same lifetime, so short-lived certs can be rotated by the
+// client without operator involvement. The old cert is left to expire rather
+// than revoked, so requests already using it are not cut off.
+func (s *Server) handleRenewCert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		http.Error(w, "client certificate required", http.StatusUnauthorized)
+		return
+	}
+	leaf := r.TLS.PeerCertificates[0]
+	cn := leaf.Subject.CommonName
+	identity := cnToIdentity(cn)
+	if identity == "" {
+		http.Error(w, "only polecat certificates can be renewed", http.StatusForbidden)
+		return
+	}
+	if !s.limiterFor(identity).Allow() {
+		s.log.Warn("cert renew rate limit exceeded", "identity", identity)
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	// NotBefore is backdated by a minute at issue; keep the original lifetime.
+	ttl := leaf.NotAfter.Sub(leaf.NotBefore) - time.Minute
+	if ttl < time.Minute {
+		ttl = time.Minute
+	}
+	oldSerial := leaf.SerialNumber.Text(16)
+	resp, err := s.issuePolecat(cn, ttl, oldSerial)
+	if err != nil {
+		http.Error(w, "internal error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.log.Info("cert renewed", "identity", identity, "old_serial", oldSerial, "serial", resp.Serial)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// certInfo is an issued cert as reported by the admin list/inspect endpoints.
+type certInfo struct {
+	IssuedCert
+	Expired    bool        `json:"expired"`
+	Revocation *Revocation `json:"revocation,omitempty"`
+}
+
+// certInfoFor joins a registry record with its revocation, if any.
+func (s *Server) certInfoFor(c IssuedCert, now time.Time) certInfo {
+	info := certInfo{IssuedCert: c, Expired: !c.ExpiresAt.IsZero() && now.After(c.ExpiresAt)}
+	if rev, ok := s.denyList.Lookup(c.Serial); ok {
+		info.Revocation = &rev
+	}
+	return info
+}
+
+// handleListCerts handles GET /v1/admin/certs on the local admin server.
+// It lists issued certs with their revocation status, followed by revoked
+// serials the registry doesn't know (e.g. certs issued before it existed).
+// Query parameters: cn filters by CN; active=1 omits expired and revoked certs.
+func (s *Server) handleListCerts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cn := r.URL.Query().Get("cn")
+	activeOnly := r.URL.Query().Get("active") == "1"
+
+	now := time.Now()
+	out := []certInfo{}
+	known := make(map[string]bool)
+	for _, c := range s.certs.List() {
+		known[c.Serial] = true
+		info := s.certInfoFor(c, now)
+		if (cn != "" && c.CN != cn) || (activeOnly && (info.Expired || info.Revocation != nil)) {
+			continue
+		}
+		out = append(out, info)
+	}
+	if !activeOnly {
+		for _, rev := range s.denyList.Entries() {
+			if known[rev.Serial] || (cn != "" && rev.CN != cn) {
+				continue
+			}
+			rev := rev
+			out = append(out, certInfo{
+				IssuedCert: IssuedCert{Serial: rev.Serial, CN: rev.CN, ExpiresAt: rev.ExpiresAt},
+				Revocation: &rev,
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// handleInspectCert handles GET /v1/admin/certs/<serial> on the local admin
+// server, reporting one cert's registry record and revocation status.
+func (s *Server) handleInspectCert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	serial := strings.ToLower(strings.TrimPrefix(r.URL.Path, "/v1/admin/certs/"))
+	if serial == "" || strings.Contains(serial, "/") {
+		http.Error(w, "bad request: serial is required", http.StatusBadRequest)
+		return
+	}
+
+	var info certInfo
+	if c, ok := s.certs.Get(serial); ok {
+		info = s.certInfoFor(c, time.Now())
+	} else if rev, ok := s.denyList.Lookup(serial); ok {
+		info = certInfo{
+			IssuedCert: IssuedCert{Serial: rev.Serial, CN: rev.CN, ExpiresAt: rev.ExpiresAt},
+			Revocation: &rev,
+		}
+	} else {
+		http.Error(w, "certificate not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(info)
+}
+
+// revokePolecatRequest is the JSON body for POST /v1/admin/revoke-polecat.
+type revokePolecatRequest struct {
+	Rig    string `json:"rig"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// revokePolecatResponse is the JSON response for POST /v1/admin/revoke-polecat.
+type revokePolecatResponse struct {
+	CN      string   `json:"cn"`
+	Revoked []string `json:"revoked"`
+}
+
+// handleRevokePolecat handles POST /v1/admin/revoke-polecat on the local admin
+// server. It revokes every unexpired cert issued to the polecat, including
+// ones produced by renewal. gt polecat nuke calls it so a nuked polecat's
+// container can no longer reach the proxy.
+func (s *Server) handleRevokePolecat(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<10)
+	var req revokePolecatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Rig == "" || req.Name == "" {
+		http.Error(w, "bad request: rig and name are required", http.StatusBadRequest)
+		return
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = "polecat revoked"
+	}
+
+	cn := "gt-" + req.Rig + "-" + req.Name
+	resp := revokePolecatResponse{CN: cn, Revoked: []string{}}
+	for _, c := range s.certs.Active(cn) {
+		if _, revoked := s.denyList.Lookup(c.Serial); revoked {
+			continue
+		}
+		if err := s.revokeSerial(c.Serial, reason); err != nil {
+			http.Error(w, "revoked, but not persisted: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.Revoked = append(resp.Revoked, c.Serial)
+	}
+
+	s.log.Info("polecat certs revoked via admin API", "cn", cn, "count", len(resp.Revoked), "reason", reason)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// TownAdminAddr returns the proxy admin address for a town: admin_listen_addr
+// from <town>/.runtime/proxy/config.json, else DefaultAdminAddr. Like
+// gt-proxy-server, it treats an empty value as unset.
+func TownAdminAddr(townRoot string) string {
+	var cfg struct {
+		AdminListenAddr string `json:"admin_listen_addr"`
+	}
+	path := filepath.Join(townRoot, ".runtime", "proxy", "config.json")
+	if err := loadJSONFile(path, &cfg); err != nil || cfg.AdminListenAddr == "" {
+		return DefaultAdminAddr
+	}
+	return cfg.AdminListenAddr
+}
+
+// TownListenAddr returns a dialable address for a town's proxy: listen_addr
+// from <town>/.runtime/proxy/config.json, else DefaultListenAddr, with an
+// unspecified host (0.0.0.0, ::) replaced by loopback.
+func TownListenAddr(townRoot string) string {
+	var cfg struct {
+		ListenAddr string `json:"listen_addr"`
+	}
+	path := filepath.Join(townRoot, ".runtime", "proxy", "config.json")
+	addr := DefaultListenAddr
+	if err := loadJSONFile(path, &cfg); err == nil && cfg.ListenAddr != "" {
+		addr = cfg.ListenAddr
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// PolecatCert is a client cert issued to a polecat by the proxy admin API.
+// Cert, Key and CA are PEM-encoded.
+type PolecatCert struct {
+	CN        string `json:"cn"`
+	Cert      string `json:"cert"`
+	Key       string `json:"key"`
+	CA        string `json:"ca"`
+	Serial    string `json:"serial"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// IssuePolecatCert asks the proxy admin server at adminAddr for a new client
+// cert for the polecat rig/name, valid for ttl.
+func IssuePolecatCert(ctx context.Context, adminAddr, rig, name string, ttl time.Duration) (*PolecatCert, error) {
+	body, err := json.Marshal(issueCertRequest{Rig: rig, Name: name, TTL: ttl.String()})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+adminAddr+"/v1/admin/issue-cert", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return nil, fmt.Errorf("proxy admin: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var out PolecatCert
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode issue response: %w", err)
+	}
+	return &out, nil
+}
+
+// RevokePolecatCerts asks the proxy admin server at adminAddr to revoke every
+// cert issued to the polecat rig/name, returning the revoked serials.
+func RevokePolecatCerts(ctx context.Context, adminAddr, rig, name, reason string) ([]string, error) {
+	body, err := json.Marshal(revokePolecatRequest{Rig: rig, Name: name, Reason: reason})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+adminAddr+"/v1/admin/revoke-polecat", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return nil, fmt.Errorf("proxy admin: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var out revokePolecatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode revoke response: %w", err)
+	}
+	return out.Revoked, nil
+}
@@ -0,0 +1,244 @@
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCertTestServer returns a server with a fresh CA whose cert state is
+// persisted under stateDir.
+func newCertTestServer(t *testing.T, ca *CA, stateDir string) *Server {
+	t.Helper()
+	srv, err := New(Config{
+		AllowedCommands: []string{"echo"},
+		TownRoot:        t.TempDir(),
+		Logger:          discardLogger(),
+		StateDir:        stateDir,
+	}, ca)
+	require.NoError(t, err)
+	return srv
+}
+
+// issueViaAdmin issues a polecat cert through the admin endpoint.
+func issueViaAdmin(t *testing.T, srv *Server, rig, name string) issueCertResponse {
+	t.Helper()
+	body := `{"rig":"` + rig + `","name":"` + name + `","ttl":"1h"}`
+	rec := httptest.NewRecorder()
+	srv.handleIssueCert(rec, httptest.NewRequest("POST", "/v1/admin/issue-cert", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp issueCertResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return resp
+}
+
+// peerRequest builds a request carrying resp's cert as the TLS peer.
+func peerRequest(t *testing.T, method, path string, resp issueCertResponse) *http.Request {
+	t.Helper()
+	block, _ := pem.Decode([]byte(resp.Cert))
+	require.NotNil(t, block)
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	req := httptest.NewRequest(method, path, nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}
+	return req
+}
+
+func TestHandleRenewCert(t *testing.T) {
+	ca, err := GenerateCA(t.TempDir())
+	require.NoError(t, err)
+	srv := newCertTestServer(t, ca, t.TempDir())
+	orig := issueViaAdmin(t, srv, "gastown", "nux")
+
+	t.Run("GET returns 405", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.handleRenewCert(rec, peerRequest(t, "GET", "/v1/cert/renew", orig))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("no client cert returns 401", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.handleRenewCert(rec, httptest.NewRequest("POST", "/v1/cert/renew", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("non-polecat cert returns 403", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.handleRenewCert(rec, makeFakeRequest("POST", "/v1/cert/renew", "", "dashboard-alice"))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("renewal issues a fresh cert for the same CN", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.handleRenewCert(rec, peerRequest(t, "POST", "/v1/cert/renew", orig))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var renewed issueCertResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&renewed))
+		assert.Equal(t, "gt-gastown-nux", renewed.CN)
+		assert.NotEqual(t, orig.Serial, renewed.Serial)
+		_, err := tls.X509KeyPair([]byte(renewed.Cert), []byte(renewed.Key))
+		require.NoError(t, err)
+
+		expires, err := time.Parse(time.RFC3339, renewed.ExpiresAt)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), expires, time.Minute)
+
+		rec2, ok := srv.certs.Get(renewed.Serial)
+		require.True(t, ok)
+		assert.Equal(t, orig.Serial, rec2.RenewedFrom)
+		assert.Equal(t, CertUsagePolecat, rec2.Usage)
+	})
+}
+
+func TestAdminCertEndpoints(t *testing.T) {
+	ca, err := GenerateCA(t.TempDir())
+	require.NoError(t, err)
+	stateDir := t.TempDir()
+	srv := newCertTestServer(t, ca, stateDir)
+
+	nux1 := issueViaAdmin(t, srv, "gastown", "nux")
+	nux2 := issueViaAdmin(t, srv, "gastown", "nux")
+	ace := issueViaAdmin(t, srv, "gastown", "ace")
+
+	list := func(t *testing.T, query string) []certInfo {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		srv.handleListCerts(rec, httptest.NewRequest("GET", "/v1/admin/certs"+query, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var out []certInfo
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+		return out
+	}
+
+	t.Run("list returns every issued cert", func(t *testing.T) {
+		assert.Len(t, list(t, ""), 3)
+		got := list(t, "?cn=gt-gastown-ace")
+		require.Len(t, got, 1)
+		assert.Equal(t, ace.Serial, got[0].Serial)
+	})
+
+	t.Run("revoke-polecat revokes every active cert for the CN", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.handleRevokePolecat(rec, httptest.NewRequest("POST", "/v1/admin/revoke-polecat",
+			strings.NewReader(`{"rig":"gastown","name":"nux","reason":"polecat nuked"}`)))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp revokePolecatResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, "gt-gastown-nux", resp.CN)
+		assert.ElementsMatch(t, []string{nux1.Serial, nux2.Serial}, resp.Revoked)
+
+		// Already revoked: nothing more to do.
+		rec = httptest.NewRecorder()
+		srv.handleRevokePolecat(rec, httptest.NewRequest("POST", "/v1/admin/revoke-polecat",
+			strings.NewReader(`{"rig":"gastown","name":"nux"}`)))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Empty(t, resp.Revoked)
+
+		active := list(t, "?active=1")
+		require.Len(t, active, 1)
+		assert.Equal(t, ace.Serial, active[0].Serial)
+	})
+
+	t.Run("revoke-polecat requires rig and name", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.handleRevokePolecat(rec, httptest.NewRequest("POST", "/v1/admin/revoke-polecat", strings.NewReader(`{"rig":"gastown"}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("inspect reports revocation", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.handleInspectCert(rec, httptest.NewRequest("GET", "/v1/admin/certs/"+nux1.Serial, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var info certInfo
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&info))
+		assert.Equal(t, "gt-gastown-nux", info.CN)
+		require.NotNil(t, info.Revocation)
+		assert.Equal(t, "polecat nuked", info.Revocation.Reason)
+		assert.False(t, info.Expired)
+	})
+
+	t.Run("inspect unknown serial returns 404", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.handleInspectCert(rec, httptest.NewRequest("GET", "/v1/admin/certs/deadbeef", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("state survives a restart", func(t *testing.T) {
+		restarted := newCertTestServer(t, ca, stateDir)
+		_, revoked := restarted.denyList.Lookup(nux2.Serial)
+		assert.True(t, revoked)
+		_, revoked = restarted.denyList.Lookup(ace.Serial)
+		assert.False(t, revoked)
+		assert.Len(t, restarted.certs.List(), 3)
+	})
+}
+
+func TestRevokePolecatCertsClient(t *testing.T) {
+	ca, err := GenerateCA(t.TempDir())
+	require.NoError(t, err)
+	srv := newCertTestServer(t, ca, "")
+	issued := issueViaAdmin(t, srv, "gastown", "nux")
+
+	ts := httptest.NewServer(http.HandlerFunc(srv.handleRevokePolecat))
+	t.Cleanup(ts.Close)
+
+	revoked, err := RevokePolecatCerts(context.Background(), strings.TrimPrefix(ts.URL, "http://"), "gastown", "nux", "test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{issued.Serial}, revoked)
+
+	_, err = RevokePolecatCerts(context.Background(), strings.TrimPrefix(ts.URL, "http://"), "", "nux", "test")
+	assert.Error(t, err)
+}
+
+func TestTownAdminAddr(t *testing.T) {
+	town := t.TempDir()
+	assert.Equal(t, DefaultAdminAddr, TownAdminAddr(town))
+
+	require.NoError(t, saveJSONFile(town+"/.runtime/proxy/config.json", map[string]string{"admin_listen_addr": "127.0.0.1:19877"}))
+	assert.Equal(t, "127.0.0.1:19877", TownAdminAddr(town))
+
+	require.NoError(t, saveJSONFile(town+"/.runtime/proxy/config.json", map[string]string{"admin_listen_addr": ""}))
+	assert.Equal(t, DefaultAdminAddr, TownAdminAddr(town))
+}
+
+func TestIssuePolecatCertClient(t *testing.T) {
+	ca, err := GenerateCA(t.TempDir())
+	require.NoError(t, err)
+	srv := newCertTestServer(t, ca, "")
+
+	ts := httptest.NewServer(http.HandlerFunc(srv.handleIssueCert))
+	t.Cleanup(ts.Close)
+
+	cert, err := IssuePolecatCert(context.Background(), strings.TrimPrefix(ts.URL, "http://"), "gastown", "nux", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "gt-gastown-nux", cert.CN)
+	assert.NotEmpty(t, cert.Serial)
+	assert.Contains(t, cert.Key, "PRIVATE KEY")
+	assert.Contains(t, cert.CA, "CERTIFICATE")
+
+	_, err = IssuePolecatCert(context.Background(), strings.TrimPrefix(ts.URL, "http://"), "", "nux", time.Hour)
+	assert.Error(t, err)
+}
+
+func TestTownListenAddr(t *testing.T) {
+	town := t.TempDir()
+	assert.Equal(t, "127.0.0.1:9876", TownListenAddr(town))
+
+	require.NoError(t, saveJSONFile(town+"/.runtime/proxy/config.json", map[string]string{"listen_addr": "10.0.0.5:19876"}))
+	assert.Equal(t, "10.0.0.5:19876", TownListenAddr(town))
+
+	require.NoError(t, saveJSONFile(town+"/.runtime/proxy/config.json", map[string]string{"listen_addr": "[::]:19876"}))
+	assert.Equal(t, "127.0.0.1:19876", TownListenAddr(town))
+}
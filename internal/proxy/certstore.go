@@ -0,0 +1,176 @@
+package proxy
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cert usages recorded in the registry.
+const (
+	CertUsagePolecat = "polecat"
+	CertUsageClient  = "client"
+)
+
+// issuedRetention is how long an expired cert stays in the registry, so an
+// operator can still inspect it shortly after it lapses.
+const issuedRetention = 7 * 24 * time.Hour
+
+// IssuedCert is the registry record of a leaf certificate issued by the proxy.
+type IssuedCert struct {
+	Serial    string    `json:"serial"`
+	CN        string    `json:"cn"`
+	Usage     string    `json:"usage"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// RenewedFrom is the serial of the cert this one replaced, if it was
+	// issued by automatic renewal.
+	RenewedFrom string `json:"renewed_from,omitempty"`
+}
+
+// newIssuedCert builds the registry record for a freshly issued leaf.
+func newIssuedCert(leaf *x509.Certificate, usage, renewedFrom string) IssuedCert {
+	return IssuedCert{
+		Serial:      leaf.SerialNumber.Text(16),
+		CN:          leaf.Subject.CommonName,
+		Usage:       usage,
+		IssuedAt:    time.Now().UTC(),
+		ExpiresAt:   leaf.NotAfter.UTC(),
+		RenewedFrom: renewedFrom,
+	}
+}
+
+// CertRegistry is a thread-safe record of the certificates the proxy has
+// issued, keyed by lowercase hex serial. It lets operators list and inspect
+// issued certs and lets the server find every cert held by a polecat when it
+// is nuked.
+//
+// A registry created by LoadCertRegistry is persisted as JSON after every
+// change; NewCertRegistry keeps it in memory only. Records are dropped
+// issuedRetention after their cert expires.
+type CertRegistry struct {
+	mu    sync.RWMutex
+	certs map[string]IssuedCert
+	path  string
+}
+
+// NewCertRegistry returns an empty in-memory registry.
+func NewCertRegistry() *CertRegistry {
+	return &CertRegistry{certs: make(map[string]IssuedCert)}
+}
+
+// LoadCertRegistry returns a registry persisted at path, loading any records
+// already there. A missing file yields an empty registry.
+func LoadCertRegistry(path string) (*CertRegistry, error) {
+	var records []IssuedCert
+	if err := loadJSONFile(path, &records); err != nil {
+		return nil, fmt.Errorf("load cert registry: %w", err)
+	}
+	r := &CertRegistry{certs: make(map[string]IssuedCert, len(records)), path: path}
+	cutoff := time.Now().Add(-issuedRetention)
+	for _, c := range records {
+		if c.ExpiresAt.After(cutoff) {
+			r.certs[c.Serial] = c
+		}
+	}
+	return r, nil
+}
+
+// Record adds an issued cert to the registry and persists it.
+func (r *CertRegistry) Record(c IssuedCert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.certs[c.Serial] = c
+	return r.saveLocked()
+}
+
+// Get returns the record for a serial in lowercase hex.
+func (r *CertRegistry) Get(serial string) (IssuedCert, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.certs[serial]
+	return c, ok
+}
+
+// List returns every record, oldest first.
+func (r *CertRegistry) List() []IssuedCert {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedCerts(r.certs, func(IssuedCert) bool { return true })
+}
+
+// Active returns the unexpired records issued to cn, oldest first.
+func (r *CertRegistry) Active(cn string) []IssuedCert {
+	now := time.Now()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedCerts(r.certs, func(c IssuedCert) bool {
+		return c.CN == cn && c.ExpiresAt.After(now)
+	})
+}
+
+func sortedCerts(certs map[string]IssuedCert, keep func(IssuedCert) bool) []IssuedCert {
+	out := make([]IssuedCert, 0, len(certs))
+	for _, c := range certs {
+		if keep(c) {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].IssuedAt.Equal(out[j].IssuedAt) {
+			return out[i].IssuedAt.Before(out[j].IssuedAt)
+		}
+		return out[i].Serial < out[j].Serial
+	})
+	return out
+}
+
+// saveLocked prunes long-expired records and writes the registry to disk.
+func (r *CertRegistry) saveLocked() error {
+	cutoff := time.Now().Add(-issuedRetention)
+	for serial, c := range r.certs {
+		if c.ExpiresAt.Before(cutoff) {
+			delete(r.certs, serial)
+		}
+	}
+	if r.path == "" {
+		return nil
+	}
+	return saveJSONFile(r.path, sortedCerts(r.certs, func(IssuedCert) bool { return true }))
+}
+
+// loadJSONFile decodes the JSON file at path into v. A missing file leaves v
+// untouched and is not an error.
+func loadJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path) //nolint:gosec // path is derived from the proxy state dir
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// saveJSONFile writes v to path as indented JSON. Like GenerateCA it writes a
+// *.tmp sibling and renames it so a crash never leaves a truncated file.
+func saveJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
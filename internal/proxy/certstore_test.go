@@ -0,0 +1,95 @@
+package proxy
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenyListPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "revoked.json")
+
+	t.Run("missing file loads empty", func(t *testing.T) {
+		d, err := LoadDenyList(path)
+		require.NoError(t, err)
+		assert.Equal(t, 0, d.Len())
+	})
+
+	t.Run("revocations survive reload", func(t *testing.T) {
+		d, err := LoadDenyList(path)
+		require.NoError(t, err)
+		require.NoError(t, d.Revoke(Revocation{Serial: "abc", CN: "gt-gastown-nux", Reason: "polecat nuked"}))
+		require.NoError(t, d.Deny(big.NewInt(0xff)))
+		// Expired cert: dropped on the next save or load.
+		require.NoError(t, d.Revoke(Revocation{Serial: "old", ExpiresAt: time.Now().Add(-time.Minute)}))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		reloaded, err := LoadDenyList(path)
+		require.NoError(t, err)
+		assert.Equal(t, 2, reloaded.Len())
+		assert.True(t, reloaded.IsDenied(big.NewInt(0xff)))
+		rev, ok := reloaded.Lookup("abc")
+		require.True(t, ok)
+		assert.Equal(t, "polecat nuked", rev.Reason)
+		assert.False(t, rev.RevokedAt.IsZero())
+		_, ok = reloaded.Lookup("old")
+		assert.False(t, ok)
+	})
+
+	t.Run("re-revoking keeps the original entry", func(t *testing.T) {
+		d, err := LoadDenyList(path)
+		require.NoError(t, err)
+		before, _ := d.Lookup("abc")
+		require.NoError(t, d.Revoke(Revocation{Serial: "abc", Reason: "again"}))
+		after, _ := d.Lookup("abc")
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("corrupt file is an error", func(t *testing.T) {
+		bad := filepath.Join(t.TempDir(), "revoked.json")
+		require.NoError(t, os.WriteFile(bad, []byte("{not json"), 0600))
+		_, err := LoadDenyList(bad)
+		assert.Error(t, err)
+	})
+}
+
+func TestCertRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issued.json")
+	now := time.Now().UTC()
+
+	r, err := LoadCertRegistry(path)
+	require.NoError(t, err)
+	require.NoError(t, r.Record(IssuedCert{Serial: "a1", CN: "gt-gastown-nux", IssuedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(time.Hour)}))
+	require.NoError(t, r.Record(IssuedCert{Serial: "a2", CN: "gt-gastown-nux", IssuedAt: now.Add(-time.Hour), ExpiresAt: now.Add(2 * time.Hour), RenewedFrom: "a1"}))
+	require.NoError(t, r.Record(IssuedCert{Serial: "b1", CN: "gt-gastown-ace", IssuedAt: now, ExpiresAt: now.Add(time.Hour)}))
+	require.NoError(t, r.Record(IssuedCert{Serial: "x1", CN: "gt-gastown-nux", IssuedAt: now.Add(-48 * time.Hour), ExpiresAt: now.Add(-24 * time.Hour)}))
+	require.NoError(t, r.Record(IssuedCert{Serial: "z1", CN: "gt-gastown-nux", IssuedAt: now.Add(-30 * 24 * time.Hour), ExpiresAt: now.Add(-20 * 24 * time.Hour)}))
+
+	reloaded, err := LoadCertRegistry(path)
+	require.NoError(t, err)
+
+	var serials []string
+	for _, c := range reloaded.List() {
+		serials = append(serials, c.Serial)
+	}
+	// z1 expired past the retention window and was pruned.
+	assert.Equal(t, []string{"x1", "a1", "a2", "b1"}, serials)
+
+	var active []string
+	for _, c := range reloaded.Active("gt-gastown-nux") {
+		active = append(active, c.Serial)
+	}
+	assert.Equal(t, []string{"a1", "a2"}, active)
+
+	c, ok := reloaded.Get("a2")
+	require.True(t, ok)
+	assert.Equal(t, "a1", c.RenewedFrom)
+}
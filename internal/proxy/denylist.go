@@ -1,40 +1,104 @@
 package proxy
 
 import (
+	"fmt"
 	"math/big"
+	"sort"
 	"sync"
+	"time"
 )
 
-// DenyList is a thread-safe in-memory set of revoked certificate serial numbers.
+// Revocation is a deny-list entry: a revoked certificate and why.
+type Revocation struct {
+	Serial    string    `json:"serial"`
+	CN        string    `json:"cn,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	RevokedAt time.Time `json:"revoked_at"`
+	// ExpiresAt is the revoked cert's NotAfter, when known. The entry is
+	// dropped once it passes, since the handshake rejects expired certs anyway.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// DenyList is a thread-safe set of revoked certificate serial numbers.
 // Entries are keyed by the lowercase hexadecimal string of the serial number,
 // which is unique per RFC 5280 within a single CA's issued certificates.
 //
 // The deny list is checked during the TLS handshake via VerifyPeerCertificate.
-// Entries are never removed (TTLs are short; the whole CA rotates periodically).
+// A deny list created by LoadDenyList acts as a CRL: it is persisted as JSON
+// after every revocation and reloaded when the proxy restarts. Entries with a
+// known expiry are dropped once the revoked cert has expired.
 type DenyList struct {
 	mu     sync.RWMutex
-	denied map[string]bool
+	denied map[string]Revocation
+	path   string
 }
 
-// NewDenyList returns an empty deny list.
+// NewDenyList returns an empty in-memory deny list.
 func NewDenyList() *DenyList {
-	return &DenyList{denied: make(map[string]bool)}
+	return &DenyList{denied: make(map[string]Revocation)}
+}
+
+// LoadDenyList returns a deny list persisted at path, loading any revocations
+// already there. A missing file yields an empty deny list.
+func LoadDenyList(path string) (*DenyList, error) {
+	var entries []Revocation
+	if err := loadJSONFile(path, &entries); err != nil {
+		return nil, fmt.Errorf("load deny list: %w", err)
+	}
+	d := &DenyList{denied: make(map[string]Revocation, len(entries)), path: path}
+	now := time.Now()
+	for _, e := range entries {
+		if e.ExpiresAt.IsZero() || e.ExpiresAt.After(now) {
+			d.denied[e.Serial] = e
+		}
+	}
+	return d, nil
 }
 
 // Deny adds a certificate serial number to the deny list.
 // Subsequent IsDenied calls for the same serial return true.
 // Calling Deny on an already-denied serial is a no-op.
-func (d *DenyList) Deny(serial *big.Int) {
+func (d *DenyList) Deny(serial *big.Int) error {
+	return d.Revoke(Revocation{Serial: serial.Text(16)})
+}
+
+// Revoke adds an entry to the deny list and persists it. The serial is denied
+// even if persisting fails; the error reports that it won't survive a restart.
+// Revoking an already-denied serial is a no-op.
+func (d *DenyList) Revoke(r Revocation) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	d.denied[serial.Text(16)] = true
+	if _, ok := d.denied[r.Serial]; ok {
+		return nil
+	}
+	if r.RevokedAt.IsZero() {
+		r.RevokedAt = time.Now().UTC()
+	}
+	d.denied[r.Serial] = r
+	return d.saveLocked()
 }
 
 // IsDenied reports whether the given serial number is on the deny list.
 func (d *DenyList) IsDenied(serial *big.Int) bool {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
-	return d.denied[serial.Text(16)]
+	_, ok := d.denied[serial.Text(16)]
+	return ok
+}
+
+// Lookup returns the deny-list entry for a serial in lowercase hex.
+func (d *DenyList) Lookup(serial string) (Revocation, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	r, ok := d.denied[serial]
+	return r, ok
+}
+
+// Entries returns every deny-list entry, oldest revocation first.
+func (d *DenyList) Entries() []Revocation {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.sortedLocked()
 }
 
 // Len returns the number of entries currently in the deny list.
@@ -43,3 +107,31 @@ func (d *DenyList) Len() int {
 	defer d.mu.RUnlock()
 	return len(d.denied)
 }
+
+func (d *DenyList) sortedLocked() []Revocation {
+	out := make([]Revocation, 0, len(d.denied))
+	for _, r := range d.denied {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].RevokedAt.Equal(out[j].RevokedAt) {
+			return out[i].RevokedAt.Before(out[j].RevokedAt)
+		}
+		return out[i].Serial < out[j].Serial
+	})
+	return out
+}
+
+// saveLocked drops entries for expired certs and writes the list to disk.
+func (d *DenyList) saveLocked() error {
+	now := time.Now()
+	for serial, r := range d.denied {
+		if !r.ExpiresAt.IsZero() && r.ExpiresAt.Before(now) {
+			delete(d.denied, serial)
+		}
+	}
+	if d.path == "" {
+		return nil
+	}
+	return saveJSONFile(d.path, d.sortedLocked())
+}
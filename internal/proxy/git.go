@@ -33,6 +33,10 @@
 //     (format: "gt-<rig>-<name>") is parsed to extract the polecat name.
 //     Every pushed ref must match refs/heads/polecat/<name>-*; any other
 //     ref is rejected with 403 before git ever sees the request body.
+//   - When a PushPolicy is configured, the pushed objects are unpacked into a
+//     quarantine directory and checked for secrets, oversized blobs, and
+//     forbidden or out-of-scope paths before git-receive-pack runs; a
+//     violation rejects the whole push (see pushscan.go).
 //   - git-upload-pack is unrestricted for any authenticated client (read-only).
 //   - Subprocesses inherit only HOME and PATH from the server environment;
 //     no credentials, tokens, or secrets are visible to git.
@@ -181,6 +185,14 @@ func (s *Server) handlePack(w http.ResponseWriter, r *http.Request, repoPath, se
 			s.log.Warn("git push denied", "identity", identity, "rig", rig, "refs", refs)
 			return
 		}
+		if s.push != nil {
+			body := s.checkPushPolicy(w, r, repoPath, rig, clientCN)
+			if body == nil {
+				return
+			}
+			defer body.Close()
+			r.Body = body
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/x-"+service+"-result")
@@ -219,40 +231,12 @@ func (s *Server) authorizeReceivePack(w http.ResponseWriter, r *http.Request, cl
 	// Read only the pkt-line ref-update section (up to the flush packet),
 	// then stream the remaining pack data through to git untouched.
 	// This avoids loading the entire (potentially large) pack into memory.
-	const maxPktLineSection = 256 << 10 // 256 KiB: more than enough for ref lines
-	var pktBuf bytes.Buffer
-	limited := io.LimitReader(r.Body, maxPktLineSection)
-
-	// Read pkt-lines until the flush packet ("0000") or limit.
-	var tmp [4]byte
-	for {
-		_, err := io.ReadFull(limited, tmp[:])
-		if err != nil {
-			http.Error(w, "read pkt-line header: "+err.Error(), http.StatusBadRequest)
-			return false, nil
-		}
-		pktBuf.Write(tmp[:])
-		// Flush packet terminates the ref-update section.
-		if bytes.Equal(tmp[:], []byte("0000")) {
-			break
-		}
-		var pktLen int
-		_, err = fmt.Sscanf(string(tmp[:]), "%x", &pktLen)
-		if err != nil || pktLen < 4 {
-			http.Error(w, "malformed pkt-line length", http.StatusBadRequest)
-			return false, nil
-		}
-		payload := make([]byte, pktLen-4)
-		_, err = io.ReadFull(limited, payload)
-		if err != nil {
-			http.Error(w, "read pkt-line payload: "+err.Error(), http.StatusBadRequest)
-			return false, nil
-		}
-		pktBuf.Write(payload)
+	pktBytes, err := readPktLineSection(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false, nil
 	}
 
-	pktBytes := pktBuf.Bytes()
-
 	// Collect refs before validation so they are available for audit logging even
 	// when authorization is denied.
 	refs := collectReceivePackRefs(pktBytes)
@@ -0,0 +1,237 @@
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultSecretPatterns are the secret rules used when a PushPolicy does not
+// set SecretPatterns. They target credential formats with distinctive
+// prefixes, so false positives on ordinary source are rare.
+var DefaultSecretPatterns = map[string]string{
+	"aws-access-key-id": `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	"private-key":       `-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY( BLOCK)?-----`,
+	"github-token":      `\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{60,})\b`,
+	"slack-token":       `\bxox[abposr]-[A-Za-z0-9-]{10,}`,
+	"anthropic-api-key": `\bsk-ant-[A-Za-z0-9_-]{20,}`,
+	"google-api-key":    `\bAIza[0-9A-Za-z_-]{35}\b`,
+}
+
+// defaultMaxPushBytes bounds the request body spooled to disk for scanning
+// when PushPolicy.MaxPushBytes is unset.
+const defaultMaxPushBytes = 512 << 20 // 512 MiB
+
+// maxSecretScanBytes is the largest blob scanned for secrets. Larger blobs
+// are left to MaxBlobBytes; reading them would cost more than it finds.
+const maxSecretScanBytes = 8 << 20 // 8 MiB
+
+// maxReportedViolations caps how many violations a rejection reports.
+const maxReportedViolations = 20
+
+// PushPolicy configures the content rules applied to pushes through the
+// git-receive-pack endpoint. Before git-receive-pack sees a push, the proxy
+// unpacks the incoming objects into a quarantine directory and checks every
+// new blob and every path touched by the new commits. A push that breaks any
+// rule is rejected as a whole.
+//
+// Path globs use path.Match syntax per "/"-separated segment, plus "**" for
+// any number of segments. A glob without "/" matches the base name at any
+// depth ("*.pem"), and a trailing "/" matches everything under a directory
+// ("secrets/").
+type PushPolicy struct {
+	// SecretPatterns maps a rule name to a regular expression matched against
+	// the content of every new text blob. Nil uses DefaultSecretPatterns; an
+	// empty map disables secret scanning.
+	SecretPatterns map[string]string `json:"secret_patterns,omitempty"`
+
+	// MaxBlobBytes rejects any new blob larger than this. 0 disables the check.
+	MaxBlobBytes int64 `json:"max_blob_bytes,omitempty"`
+
+	// MaxPushBytes rejects request bodies larger than this, since the whole
+	// push is spooled to disk for scanning. 0 uses 512 MiB.
+	MaxPushBytes int64 `json:"max_push_bytes,omitempty"`
+
+	// ForbiddenPaths are globs no pushed commit may add, modify, or delete.
+	ForbiddenPaths []string `json:"forbidden_paths,omitempty"`
+
+	// AllowedPaths maps a rig name to the globs pushed commits in that rig
+	// must stay within. Rigs without an entry are unrestricted.
+	AllowedPaths map[string][]string `json:"allowed_paths,omitempty"`
+}
+
+// pushViolation is a single push policy failure.
+type pushViolation struct {
+	Rule   string // "secret", "blob-size", "forbidden-path", "path-scope", or "push-size"
+	Path   string // repo-relative path, when the rule concerns one
+	Detail string
+}
+
+func (v pushViolation) String() string {
+	if v.Path == "" {
+		return fmt.Sprintf("%s: %s", v.Rule, v.Detail)
+	}
+	return fmt.Sprintf("%s: %s (%s)", v.Path, v.Detail, v.Rule)
+}
+
+// secretRule is a compiled SecretPatterns entry.
+type secretRule struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// pushScanner is a validated PushPolicy ready to check pushes.
+type pushScanner struct {
+	policy  PushPolicy
+	secrets []secretRule
+}
+
+// compilePushPolicy validates p and compiles its patterns.
+func compilePushPolicy(p *PushPolicy) (*pushScanner, error) {
+	sc := &pushScanner{policy: *p}
+	if sc.policy.MaxPushBytes <= 0 {
+		sc.policy.MaxPushBytes = defaultMaxPushBytes
+	}
+
+	patterns := p.SecretPatterns
+	if patterns == nil {
+		patterns = DefaultSecretPatterns
+	}
+	names := make([]string, 0, len(patterns))
+	for name := range patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		re, err := regexp.Compile(patterns[name])
+		if err != nil {
+			return nil, fmt.Errorf("push policy: secret pattern %q: %w", name, err)
+		}
+		sc.secrets = append(sc.secrets, secretRule{name: name, re: re})
+	}
+
+	globs := append([]string(nil), p.ForbiddenPaths...)
+	for _, rigGlobs := range p.AllowedPaths {
+		globs = append(globs, rigGlobs...)
+	}
+	for _, g := range globs {
+		if err := validatePathGlob(g); err != nil {
+			return nil, fmt.Errorf("push policy: path glob %q: %w", g, err)
+		}
+	}
+	return sc, nil
+}
+
+// checkPaths applies the forbidden and per-rig allowed path rules to the
+// paths touched by a push.
+func (sc *pushScanner) checkPaths(rig string, paths []string) []pushViolation {
+	allowed, scoped := sc.policy.AllowedPaths[rig]
+	var out []pushViolation
+	for _, p := range paths {
+		if g, ok := matchAnyGlob(sc.policy.ForbiddenPaths, p); ok {
+			out = append(out, pushViolation{Rule: "forbidden-path", Path: p, Detail: fmt.Sprintf("path matches forbidden pattern %q", g)})
+			continue
+		}
+		if _, ok := matchAnyGlob(allowed, p); scoped && !ok {
+			out = append(out, pushViolation{Rule: "path-scope", Path: p, Detail: fmt.Sprintf("path is outside the allowed paths for rig %s", rig)})
+		}
+	}
+	return out
+}
+
+// checkBlobSize applies MaxBlobBytes to a new blob.
+func (sc *pushScanner) checkBlobSize(p string, size int64) []pushViolation {
+	if sc.policy.MaxBlobBytes > 0 && size > sc.policy.MaxBlobBytes {
+		return []pushViolation{{Rule: "blob-size", Path: p, Detail: fmt.Sprintf("blob is %d bytes, limit is %d", size, sc.policy.MaxBlobBytes)}}
+	}
+	return nil
+}
+
+// scansContent reports whether a blob of the given size should be read for
+// secret scanning.
+func (sc *pushScanner) scansContent(size int64) bool {
+	return len(sc.secrets) > 0 && size <= maxSecretScanBytes
+}
+
+// checkSecrets matches a new blob's content against the secret rules. Binary
+// content (a NUL in the first 8000 bytes, as git decides) is skipped. The
+// report names the rule and line, never the matched text.
+func (sc *pushScanner) checkSecrets(p string, content []byte) []pushViolation {
+	head := content
+	if len(head) > 8000 {
+		head = head[:8000]
+	}
+	if bytes.IndexByte(head, 0) >= 0 {
+		return nil
+	}
+	var out []pushViolation
+	for _, rule := range sc.secrets {
+		loc := rule.re.FindIndex(content)
+		if loc == nil {
+			continue
+		}
+		line := bytes.Count(content[:loc[0]], []byte("\n")) + 1
+		out = append(out, pushViolation{Rule: "secret", Path: p, Detail: fmt.Sprintf("line %d matches secret pattern %q", line, rule.name)})
+	}
+	return out
+}
+
+// validatePathGlob reports a malformed glob segment.
+func validatePathGlob(glob string) error {
+	if glob == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	for _, seg := range strings.Split(strings.Trim(glob, "/"), "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchAnyGlob returns the first glob that matches p.
+func matchAnyGlob(globs []string, p string) (string, bool) {
+	for _, g := range globs {
+		if matchPathGlob(g, p) {
+			return g, true
+		}
+	}
+	return "", false
+}
+
+// matchPathGlob reports whether the repo-relative path p matches glob.
+// See PushPolicy for the glob syntax.
+func matchPathGlob(glob, p string) bool {
+	if strings.HasSuffix(glob, "/") {
+		glob += "**"
+	}
+	if !strings.Contains(glob, "/") {
+		ok, _ := path.Match(glob, path.Base(p))
+		return ok
+	}
+	return matchSegments(strings.Split(strings.TrimPrefix(glob, "/"), "/"), strings.Split(p, "/"))
+}
+
+func matchSegments(glob, p []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := 0; i <= len(p); i++ {
+				if matchSegments(glob[1:], p[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(p) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(glob[0], p[0]); !ok {
+			return false
+		}
+		glob, p = glob[1:], p[1:]
+	}
+	return len(p) == 0
+}
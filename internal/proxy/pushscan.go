@@ -0,0 +1,446 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// zeroSHA is the object ID receive-pack uses for "no object" (ref creation
+// or deletion).
+var zeroSHA = strings.Repeat("0", 40)
+
+// refUpdate is one "<old-sha> <new-sha> <refname>" command from a
+// git-receive-pack request.
+type refUpdate struct {
+	Old, New, Ref string
+}
+
+// parseReceivePackCommands returns the ref-update commands and the client's
+// capabilities (sent after a NUL on the first command) from the pkt-line
+// section of a git-receive-pack request.
+func parseReceivePackCommands(body []byte) (updates []refUpdate, caps []string) {
+	offset := 0
+	for offset+4 <= len(body) {
+		lenHex := body[offset : offset+4]
+		if bytes.Equal(lenHex, []byte("0000")) {
+			break
+		}
+		pktLen, err := strconv.ParseUint(string(lenHex), 16, 16)
+		if err != nil || pktLen < 4 || offset+int(pktLen) > len(body) {
+			break
+		}
+		line := bytes.TrimRight(body[offset+4:offset+int(pktLen)], "\n")
+		offset += int(pktLen)
+
+		if idx := bytes.IndexByte(line, 0); idx >= 0 {
+			if caps == nil {
+				caps = strings.Fields(string(line[idx+1:]))
+			}
+			line = line[:idx]
+		}
+		parts := strings.Fields(string(line))
+		if len(parts) < 3 {
+			continue
+		}
+		updates = append(updates, refUpdate{Old: parts[0], New: parts[1], Ref: parts[2]})
+	}
+	return updates, caps
+}
+
+// readPktLineSection reads pkt-lines from r up to and including the flush
+// packet, leaving r positioned at the first byte after it.
+func readPktLineSection(r io.Reader) ([]byte, error) {
+	const maxPktLineSection = 256 << 10 // 256 KiB: more than enough for ref lines
+	var pktBuf bytes.Buffer
+	limited := io.LimitReader(r, maxPktLineSection)
+
+	var tmp [4]byte
+	for {
+		if _, err := io.ReadFull(limited, tmp[:]); err != nil {
+			return nil, fmt.Errorf("read pkt-line header: %w", err)
+		}
+		pktBuf.Write(tmp[:])
+		// Flush packet terminates the ref-update section.
+		if bytes.Equal(tmp[:], []byte("0000")) {
+			return pktBuf.Bytes(), nil
+		}
+		var pktLen int
+		if _, err := fmt.Sscanf(string(tmp[:]), "%x", &pktLen); err != nil || pktLen < 4 {
+			return nil, fmt.Errorf("malformed pkt-line length")
+		}
+		payload := make([]byte, pktLen-4)
+		if _, err := io.ReadFull(limited, payload); err != nil {
+			return nil, fmt.Errorf("read pkt-line payload: %w", err)
+		}
+		pktBuf.Write(payload)
+	}
+}
+
+// spooledBody is a request body spooled to a temp file, removed on Close.
+type spooledBody struct {
+	*os.File
+}
+
+func (b spooledBody) Close() error {
+	err := b.File.Close()
+	_ = os.Remove(b.Name())
+	return err
+}
+
+// checkPushPolicy enforces the push policy on an authorized receive-pack
+// request. It spools the body to disk, scans the incoming objects, and on
+// success returns the rewound body for git-receive-pack. On failure it has
+// already answered the request and returns nil.
+func (s *Server) checkPushPolicy(w http.ResponseWriter, r *http.Request, repoPath, rig, clientCN string) io.ReadCloser {
+	identity := cnToIdentity(clientCN)
+
+	f, err := os.CreateTemp("", "gt-push-*")
+	if err != nil {
+		s.log.Error("push scan: spool failed", "rig", rig, "err", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return nil
+	}
+	body := spooledBody{f}
+
+	limit := s.push.policy.MaxPushBytes
+	n, err := io.Copy(f, io.LimitReader(r.Body, limit+1))
+	if err != nil {
+		_ = body.Close()
+		http.Error(w, "read push body: "+err.Error(), http.StatusBadRequest)
+		return nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = body.Close()
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return nil
+	}
+	pkt, err := readPktLineSection(f)
+	if err != nil {
+		_ = body.Close()
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil
+	}
+	updates, caps := parseReceivePackCommands(pkt)
+
+	var violations []pushViolation
+	if n > limit {
+		violations = []pushViolation{{Rule: "push-size", Detail: fmt.Sprintf("push exceeds %d bytes", limit)}}
+	} else {
+		violations, err = s.push.scan(r.Context(), repoPath, rig, updates, f)
+		if err != nil {
+			_ = body.Close()
+			s.log.Error("push scan failed", "identity", identity, "rig", rig, "err", err)
+			http.Error(w, "push scan failed: "+err.Error(), http.StatusInternalServerError)
+			return nil
+		}
+	}
+
+	if len(violations) > 0 {
+		_ = body.Close()
+		s.rejectPush(w, rig, clientCN, updates, caps, violations)
+		return nil
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = body.Close()
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return nil
+	}
+	return body
+}
+
+// rejectPush logs a policy rejection, records it in the town event log, and
+// answers the polecat's git.
+func (s *Server) rejectPush(w http.ResponseWriter, rig, clientCN string, updates []refUpdate, caps []string, violations []pushViolation) {
+	refs := make([]string, 0, len(updates))
+	for _, u := range updates {
+		refs = append(refs, u.Ref)
+	}
+	reasons := make([]string, 0, len(violations))
+	for _, v := range violations {
+		reasons = append(reasons, v.String())
+	}
+
+	identity := cnToIdentity(clientCN)
+	s.log.Warn("git push rejected by policy", "identity", identity, "rig", rig, "refs", refs, "violations", reasons)
+
+	actor := identity
+	if name := polecatName(clientCN); name != "" {
+		actor = strings.TrimSuffix(identity, "/"+name) + "/polecats/" + name
+	}
+	if err := events.LogAt(s.cfg.TownRoot, events.TypePushRejected, actor,
+		events.PushRejectedPayload(rig, refs, reasons), events.VisibilityFeed); err != nil {
+		s.log.Warn("push rejection event not recorded", "err", err)
+	}
+
+	writePushRejection(w, updates, caps, violations)
+}
+
+// scan unpacks the pack data in pack into a quarantine object directory and
+// checks the objects and paths it introduces. Objects already reachable from
+// the repo's refs are not checked again. The repo itself is never written.
+func (sc *pushScanner) scan(ctx context.Context, repoPath, rig string, updates []refUpdate, pack io.Reader) ([]pushViolation, error) {
+	var tips []string
+	for _, u := range updates {
+		if u.New != zeroSHA {
+			tips = append(tips, u.New)
+		}
+	}
+	if len(tips) == 0 {
+		return nil, nil // deletions only
+	}
+
+	quarantine, err := os.MkdirTemp("", "gt-push-quarantine-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(quarantine)
+	if err := os.MkdirAll(filepath.Join(quarantine, "pack"), 0700); err != nil {
+		return nil, err
+	}
+	g := quarantineGit{env: append(minimalEnv(),
+		"GIT_DIR="+repoPath,
+		"GIT_OBJECT_DIRECTORY="+quarantine,
+		"GIT_ALTERNATE_OBJECT_DIRECTORIES="+filepath.Join(repoPath, "objects"),
+	)}
+
+	// A push that only moves refs to existing commits sends no pack.
+	br := bufio.NewReader(pack)
+	if _, err := br.Peek(1); err == nil {
+		if _, err := g.run(ctx, br, "index-pack", "--stdin", "--fix-thin", filepath.Join(quarantine, "pack", "push.pack")); err != nil {
+			return nil, fmt.Errorf("unpack: %w", err)
+		}
+	}
+
+	revArgs := append(append([]string{}, tips...), "--not", "--all")
+
+	// Paths touched by every new commit, including deletions.
+	out, err := g.run(ctx, nil, append([]string{"log", "-z", "--format=", "--name-only", "--no-renames", "--diff-merges=first-parent"}, revArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("list changed paths: %w", err)
+	}
+	seen := make(map[string]bool)
+	var paths []string
+	for _, p := range strings.Split(string(out), "\x00") {
+		if p != "" && !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	violations := sc.checkPaths(rig, paths)
+
+	// New objects, with the path each blob or tree was first seen at.
+	out, err = g.run(ctx, nil, append([]string{"rev-list", "--objects"}, revArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("list new objects: %w", err)
+	}
+	var objects []string
+	pathOf := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		sha, p, _ := strings.Cut(line, " ")
+		if sha != "" {
+			objects = append(objects, sha)
+			pathOf[sha] = p
+		}
+	}
+	if len(objects) == 0 {
+		return violations, nil
+	}
+
+	var scanIDs []string
+	out, err = g.run(ctx, strings.NewReader(strings.Join(objects, "\n")+"\n"),
+		"cat-file", "--batch-check=%(objectname) %(objecttype) %(objectsize)")
+	if err != nil {
+		return nil, fmt.Errorf("inspect new objects: %w", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[2], 10, 64)
+		violations = append(violations, sc.checkBlobSize(pathOf[fields[0]], size)...)
+		if sc.scansContent(size) {
+			scanIDs = append(scanIDs, fields[0])
+		}
+	}
+
+	if len(scanIDs) > 0 {
+		err := g.catBlobs(ctx, scanIDs, func(id string, content []byte) {
+			violations = append(violations, sc.checkSecrets(pathOf[id], content)...)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("read new blobs: %w", err)
+		}
+	}
+
+	if len(violations) > maxReportedViolations {
+		violations = violations[:maxReportedViolations]
+	}
+	return violations, nil
+}
+
+// quarantineGit runs git plumbing against a repo whose new objects live in
+// a separate quarantine object directory.
+type quarantineGit struct {
+	env []string
+}
+
+func (g quarantineGit) run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = g.env
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// catBlobs streams the content of each blob in ids to fn.
+func (g quarantineGit) catBlobs(ctx context.Context, ids []string, fn func(id string, content []byte)) error {
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	cmd.Env = g.env
+	cmd.Stdin = strings.NewReader(strings.Join(ids, "\n") + "\n")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(stdout)
+	var readErr error
+	for range ids {
+		header, err := br.ReadString('\n')
+		if err != nil {
+			readErr = err
+			break
+		}
+		fields := strings.Fields(header) // "<sha> <type> <size>"
+		if len(fields) != 3 {
+			readErr = fmt.Errorf("unexpected cat-file header %q", strings.TrimSpace(header))
+			break
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			readErr = err
+			break
+		}
+		content := make([]byte, size+1) // content plus trailing LF
+		if _, err := io.ReadFull(br, content); err != nil {
+			readErr = err
+			break
+		}
+		fn(fields[0], content[:size])
+	}
+	_, _ = io.Copy(io.Discard, br)
+	if err := cmd.Wait(); err != nil && readErr == nil {
+		readErr = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if readErr != nil && !errors.Is(readErr, io.EOF) {
+		return readErr
+	}
+	return nil
+}
+
+// writePushRejection answers a receive-pack request the way a rejecting
+// pre-receive hook would: every ref is reported "ng" with a short reason, and
+// the violations are sent as progress messages, so the polecat's git prints
+//
+//	remote: push rejected by gt-proxy-server policy:
+//	remote:   config/.env: line 3 matches secret pattern "aws-access-key-id" (secret)
+//	 ! [remote rejected] polecat/nux-1 -> polecat/nux-1 (push policy violation)
+//
+// Clients that did not ask for report-status get a plain 403 instead.
+func writePushRejection(w http.ResponseWriter, updates []refUpdate, caps []string, violations []pushViolation) {
+	var msg strings.Builder
+	msg.WriteString("push rejected by gt-proxy-server policy:\n")
+	for _, v := range violations {
+		line := v.String()
+		if len(line) > 900 {
+			line = line[:900] + "..."
+		}
+		msg.WriteString("  " + line + "\n")
+	}
+
+	if !hasCap(caps, "report-status") && !hasCap(caps, "report-status-v2") {
+		http.Error(w, msg.String(), http.StatusForbidden)
+		return
+	}
+
+	var status bytes.Buffer
+	status.WriteString(encodePktLine("unpack ok\n"))
+	for _, u := range updates {
+		status.WriteString(encodePktLine("ng " + u.Ref + " push policy violation\n"))
+	}
+	status.WriteString("0000")
+
+	w.Header().Set("Content-Type", "application/x-git-receive-pack-result")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	maxData := 0
+	switch {
+	case hasCap(caps, "side-band-64k"):
+		maxData = 65515
+	case hasCap(caps, "side-band"):
+		maxData = 999
+	}
+	if maxData == 0 {
+		// No side-band: the report is all the client reads.
+		_, _ = w.Write(status.Bytes())
+		return
+	}
+	for _, line := range strings.SplitAfter(msg.String(), "\n") {
+		if line != "" {
+			writeSideband(w, 2, []byte(line), maxData)
+		}
+	}
+	writeSideband(w, 1, status.Bytes(), maxData)
+	_, _ = io.WriteString(w, "0000")
+}
+
+// writeSideband writes data on a side-band channel, split into packets of at
+// most maxData bytes.
+func writeSideband(w io.Writer, band byte, data []byte, maxData int) {
+	for len(data) > 0 {
+		n := min(len(data), maxData)
+		_, _ = fmt.Fprintf(w, "%04x%c", n+5, band)
+		_, _ = w.Write(data[:n])
+		data = data[n:]
+	}
+}
+
+// encodePktLine encodes s as a single pkt-line.
+func encodePktLine(s string) string {
+	return fmt.Sprintf("%04x%s", len(s)+4, s)
+}
+
+func hasCap(caps []string, name string) bool {
+	for _, c := range caps {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
 	"log/slog"
 	"math/big"
@@ -58,6 +57,13 @@ type Config struct {
 	// ExecTimeout is the maximum duration a single exec subprocess may run.
 	// 0 uses the default (60s). Use a negative value to disable the timeout.
 	ExecTimeout time.Duration
+	// PushPolicy, if set, enables content checks on pushes through the git
+	// receive-pack endpoint. See PushPolicy.
+	PushPolicy *PushPolicy
+	// StateDir is where the revocation list (revoked.json) and issued-cert
+	// registry (issued.json) are persisted so they survive restarts.
+	// If empty, both are kept in memory only.
+	StateDir string
 }
 
 // Server is an mTLS HTTP proxy server.
@@ -69,6 +75,8 @@ type Server struct {
 	resolvedPaths map[string]string
 	log           *slog.Logger
 	denyList      *DenyList
+	certs         *CertRegistry
+	push          *pushScanner // nil when no PushPolicy is configured
 
 	// execSem is a semaphore limiting global concurrent exec subprocesses.
 	execSem chan struct{}
@@ -155,6 +163,26 @@ func New(cfg Config, ca *CA) (*Server, error) {
 		et = 60 * time.Second
 	}
 
+	var push *pushScanner
+	if cfg.PushPolicy != nil {
+		var err error
+		if push, err = compilePushPolicy(cfg.PushPolicy); err != nil {
+			return nil, err
+		}
+	}
+
+	denyList, certs := NewDenyList(), NewCertRegistry()
+	if cfg.StateDir != "" {
+		var err error
+		if denyList, err = LoadDenyList(filepath.Join(cfg.StateDir, "revoked.json")); err != nil {
+			return nil, err
+		}
+		if certs, err = LoadCertRegistry(filepath.Join(cfg.StateDir, "issued.json")); err != nil {
+			return nil, err
+		}
+		l.Info("cert state loaded", "dir", cfg.StateDir, "revoked", denyList.Len(), "issued", len(certs.List()))
+	}
+
 	return &Server{
 		cfg:           cfg,
 		ca:            ca,
@@ -162,7 +190,9 @@ func New(cfg Config, ca *CA) (*Server, error) {
 		allowedSubs:   allowedSubs,
 		resolvedPaths: resolvedPaths,
 		log:           l,
-		denyList:      NewDenyList(),
+		denyList:      denyList,
+		certs:         certs,
+		push:          push,
 		execSem:       make(chan struct{}, maxConcurrent),
 		execTimeout:   et,
 		rateLimit:     rate.Limit(rl),
@@ -197,8 +227,9 @@ func (s *Server) AdminAddr() net.Addr {
 // DenyCert adds a certificate serial number to the server's deny list.
 // Any active or future TLS connection presenting a cert with this serial will be
 // rejected at the TLS handshake. This method is safe for concurrent use.
-func (s *Server) DenyCert(serial *big.Int) {
-	s.denyList.Deny(serial)
+// An error means the revocation is in effect but could not be persisted.
+func (s *Server) DenyCert(serial *big.Int) error {
+	return s.revokeSerial(serial.Text(16), "")
 }
 
 // Start begins listening and serving. Blocks until ctx is canceled.
@@ -232,6 +263,7 @@ func (s *Server) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/v1/exec", s.handleExec)
 	mux.HandleFunc("/v1/git/", s.handleGit)
+	mux.HandleFunc("/v1/cert/renew", s.handleRenewCert)
 
 	srv := &http.Server{
 		Addr:        s.cfg.ListenAddr,
@@ -288,6 +320,9 @@ func (s *Server) Start(ctx context.Context) error {
 		adminMux := http.NewServeMux()
 		adminMux.HandleFunc("/v1/admin/deny-cert", s.handleDenyCert)
 		adminMux.HandleFunc("/v1/admin/issue-cert", s.handleIssueCert)
+		adminMux.HandleFunc("/v1/admin/revoke-polecat", s.handleRevokePolecat)
+		adminMux.HandleFunc("/v1/admin/certs", s.handleListCerts)
+		adminMux.HandleFunc("/v1/admin/certs/", s.handleInspectCert)
 
 		adminSrv = &http.Server{
 			Addr:         s.cfg.AdminListenAddr,
@@ -447,40 +482,27 @@ func (s *Server) handleIssueCert(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cn := "gt-" + req.Rig + "-" + req.Name
-	certPEM, keyPEM, err := s.ca.IssuePolecat(cn, ttl)
-	if err != nil {
-		http.Error(w, "failed to issue certificate: "+err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	// Parse the cert to extract serial and expiry for the response.
-	block, _ := pem.Decode(certPEM)
-	if block == nil || block.Type != "CERTIFICATE" {
-		http.Error(w, "internal error: failed to decode issued certificate PEM", http.StatusInternalServerError)
+	if cnToIdentity(cn) == "" {
+		http.Error(w, fmt.Sprintf("failed to issue certificate: invalid polecat CN %q", cn), http.StatusBadRequest)
 		return
 	}
-	leaf, err := x509.ParseCertificate(block.Bytes)
+	resp, err := s.issuePolecat(cn, ttl, "")
 	if err != nil {
 		http.Error(w, "internal error: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	s.log.Info("cert issued via admin API", "cn", cn, "serial", leaf.SerialNumber.Text(16))
+	s.log.Info("cert issued via admin API", "cn", cn, "serial", resp.Serial)
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(issueCertResponse{
-		CN:        cn,
-		Cert:      string(certPEM),
-		Key:       string(keyPEM),
-		CA:        string(s.ca.CertPEM),
-		Serial:    leaf.SerialNumber.Text(16),
-		ExpiresAt: leaf.NotAfter.UTC().Format(time.RFC3339),
-	})
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
 // denyCertRequest is the JSON body for POST /v1/admin/deny-cert.
 type denyCertRequest struct {
 	// Serial is the certificate serial number in lowercase hexadecimal (no "0x" prefix).
 	Serial string `json:"serial"`
+	// Reason is an optional note recorded with the revocation.
+	Reason string `json:"reason"`
 }
 
 // handleDenyCert handles POST /v1/admin/deny-cert on the local admin server.
@@ -512,8 +534,11 @@ func (s *Server) handleDenyCert(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.denyList.Deny(serial)
-	s.log.Info("cert revoked via admin API", "serial", req.Serial)
+	if err := s.revokeSerial(serial.Text(16), req.Reason); err != nil {
+		http.Error(w, "revoked, but not persisted: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.log.Info("cert revoked via admin API", "serial", req.Serial, "reason", req.Reason)
 	w.WriteHeader(http.StatusNoContent)
 }
 
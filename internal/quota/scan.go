@@ -24,7 +24,8 @@ type ScanResult struct {
 }
 
 // TmuxClient is the interface for tmux operations needed by the scanner.
-// This allows testing without a real tmux server.
+// Any session.SessionBackend satisfies it, so scans work against tmux or
+// headless sessions, and tests need no real tmux server.
 type TmuxClient interface {
 	ListSessions() ([]string, error)
 	CapturePane(session string, lines int) (string, error)
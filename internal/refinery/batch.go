@@ -45,6 +45,10 @@ type BatchResult struct {
 	// Conflicts is the set of MRs that had merge conflicts during stack construction.
 	Conflicts []*MRInfo
 
+	// Pending is the set of MRs whose pull requests are still waiting on
+	// reviews or checks (merge_strategy "pr"). They stay in the queue.
+	Pending []*MRInfo
+
 	// MergeCommit is the final SHA pushed to the target branch (empty if nothing merged).
 	MergeCommit string
 
@@ -207,6 +211,12 @@ func (e *Engineer) ProcessBatch(ctx context.Context, batch []*MRInfo, target str
 		return result
 	}
 
+	// PR strategy: each MR lands through its own pull request, so there is
+	// no local stack to build or bisect.
+	if e.config.MergeStrategy == MergeStrategyPR {
+		return e.processPRBatch(ctx, batch)
+	}
+
 	// Single MR: use existing doMerge path (no batch overhead)
 	if len(batch) == 1 {
 		return e.processSingleMR(ctx, batch[0], target)
@@ -227,17 +237,18 @@ func (e *Engineer) ProcessBatch(ctx context.Context, batch []*MRInfo, target str
 		return result
 	}
 
-	// If only one MR survived after conflict removal, just process it directly
-	if len(stacked) == 1 {
-		_, _ = fmt.Fprintln(e.output, "[Batch] Only 1 MR survived stack construction, processing directly")
-		// We already have the squash merge on the target branch, run gates and push
-		return e.verifyAndPush(ctx, stacked, target)
-	}
-
 	// Step 2: Run gates on the stack tip
 	_, _ = fmt.Fprintf(e.output, "[Batch] Running gates on stack tip (%d MRs)...\n", len(stacked))
 	gateResult := e.runBatchGates(ctx)
 
+	return e.settleBatch(ctx, stacked, target, batchCfg, gateResult, result)
+}
+
+// settleBatch finishes a batch whose stack has been built and gated once.
+// The working tree must be on the target branch with the stack applied.
+// A green gate result pushes the stack; a red one retries (if configured),
+// bisects, and pushes whatever subset passes.
+func (e *Engineer) settleBatch(ctx context.Context, stacked []*MRInfo, target string, batchCfg *BatchConfig, gateResult ProcessResult, result *BatchResult) *BatchResult {
 	// Step 3: Happy path — all green
 	if gateResult.Success {
 		return e.fastForwardBatch(ctx, stacked, target, result)
@@ -258,7 +269,18 @@ func (e *Engineer) ProcessBatch(ctx context.Context, batch []*MRInfo, target str
 			_, _ = fmt.Fprintln(e.output, "[Batch] Retry succeeded (was flaky)")
 			return e.fastForwardBatch(ctx, stacked, target, result)
 		}
-		_, _ = fmt.Fprintln(e.output, "[Batch] Retry also failed, proceeding to bisection")
+		_, _ = fmt.Fprintln(e.output, "[Batch] Retry also failed")
+		gateResult = retryResult
+	}
+
+	// A lone MR has nothing to bisect: it is the culprit.
+	if len(stacked) == 1 {
+		if gateResult.TestsFailed {
+			result.Culprits = stacked
+		} else {
+			result.Error = fmt.Errorf("gates failed: %s", gateResult.Error)
+		}
+		return result
 	}
 
 	// Step 5: Bisect to find the culprit
@@ -314,6 +336,39 @@ func (e *Engineer) processSingleMR(ctx context.Context, mr *MRInfo, target strin
 	return result
 }
 
+// processPRBatch advances the pull request for each MR in the batch.
+// Outcomes are classified the same way as processSingleMR.
+func (e *Engineer) processPRBatch(ctx context.Context, batch []*MRInfo) *BatchResult {
+	result := &BatchResult{}
+	for _, mr := range batch {
+		processResult := e.doPRMerge(ctx, mr)
+		switch {
+		case processResult.Success:
+			result.Merged = append(result.Merged, mr)
+			result.MergeCommit = processResult.MergeCommit
+			e.HandleMRInfoSuccess(mr, processResult)
+		case processResult.PRPending:
+			result.Pending = append(result.Pending, mr)
+		case processResult.Conflict:
+			result.Conflicts = append(result.Conflicts, mr)
+		case processResult.TestsFailed, processResult.ChangesRequested:
+			result.Culprits = append(result.Culprits, mr)
+		case processResult.BranchNotFound:
+			e.HandleMRInfoFailure(mr, processResult)
+			result.Conflicts = append(result.Conflicts, mr)
+		case processResult.NoMerge:
+			_, _ = fmt.Fprintf(e.output, "[Batch] MR %s: no_merge flag set, dequeuing\n", mr.ID)
+			e.HandleMRInfoFailure(mr, processResult)
+		default:
+			_, _ = fmt.Fprintf(e.output, "[Batch] MR %s: PR merge failed: %s\n", mr.ID, processResult.Error)
+			if result.Error == nil {
+				result.Error = fmt.Errorf("merge failed: %s", processResult.Error)
+			}
+		}
+	}
+	return result
+}
+
 // runBatchGates runs quality gates (or legacy tests) on the current working tree.
 func (e *Engineer) runBatchGates(ctx context.Context) ProcessResult {
 	if len(e.config.Gates) > 0 {
@@ -334,23 +389,6 @@ func (e *Engineer) runBatchGates(ctx context.Context) ProcessResult {
 	return ProcessResult{Success: true}
 }
 
-// verifyAndPush runs gates and pushes the current state for a set of stacked MRs.
-func (e *Engineer) verifyAndPush(ctx context.Context, stacked []*MRInfo, target string) *BatchResult {
-	result := &BatchResult{}
-
-	gateResult := e.runBatchGates(ctx)
-	if !gateResult.Success {
-		if gateResult.TestsFailed {
-			result.Culprits = stacked
-		} else {
-			result.Error = fmt.Errorf("gates failed: %s", gateResult.Error)
-		}
-		return result
-	}
-
-	return e.fastForwardBatch(ctx, stacked, target, result)
-}
-
 // fastForwardBatch pushes the current state to the target branch.
 // The working tree must already be on the target branch with all squash-merges applied.
 func (e *Engineer) fastForwardBatch(ctx context.Context, stacked []*MRInfo, target string, result *BatchResult) *BatchResult {
@@ -94,6 +94,7 @@ func newTestEngineer(t *testing.T, workDir string, g *gitpkg.Git) *Engineer {
 	e.git = g
 	e.workDir = workDir
 	e.output = &bytes.Buffer{}
+	e.gateHistory = NewGateHistory(filepath.Join(t.TempDir(), "gate-history.jsonl"))
 	// No-op merge slot functions for tests
 	e.mergeSlotEnsureExists = func() (string, error) { return "test-slot", nil }
 	e.mergeSlotAcquire = func(holder string, addWaiter bool) (*beads.MergeSlotStatus, error) {
@@ -566,6 +567,39 @@ func TestProcessBatch_RetryOnFlaky_MultipleMRs(t *testing.T) {
 	}
 }
 
+func TestProcessBatch_RetryOnFlaky_SingleSurvivor(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+
+	createFeatureBranch(t, workDir, "feature-a", "a.txt", "hello a\n")
+
+	e := newTestEngineer(t, workDir, g)
+
+	// Fails on the first run only.
+	counterFile := filepath.Join(t.TempDir(), "gate_counter")
+	e.config.Gates = map[string]*GateConfig{
+		"flaky": {Cmd: fmt.Sprintf(`count=$(cat %s 2>/dev/null || echo 0); count=$((count + 1)); echo $count > %s; test $count -ge 2`, counterFile, counterFile)},
+	}
+
+	// mr-gone drops out of the stack, leaving a batch of one.
+	batch := []*MRInfo{
+		makeMR("mr-a", "feature-a", "main"),
+		makeMR("mr-gone", "ghost-branch", "main"),
+	}
+	cfg := &BatchConfig{MaxBatchSize: 5, RetryBatchOnFlaky: true}
+
+	result := e.ProcessBatch(context.Background(), batch, "main", cfg)
+	if result.Error != nil {
+		t.Fatalf("unexpected error: %v", result.Error)
+	}
+	if len(result.Culprits) != 0 {
+		t.Errorf("flaky failure blamed on %v", stackedIDs(result.Culprits))
+	}
+	if got := stackedIDs(result.Merged); len(got) != 1 || got[0] != "mr-a" {
+		t.Errorf("expected mr-a merged after flaky retry, got %v", got)
+	}
+}
+
 func TestProcessBatch_AllConflict(t *testing.T) {
 	workDir, g, cleanup := testGitRepo(t)
 	defer cleanup()
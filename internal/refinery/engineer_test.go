@@ -404,6 +404,38 @@ func TestEngineer_LoadConfig_GateInvalidTimeout(t *testing.T) {
 	}
 }
 
+func TestEngineer_LoadConfig_FlakySettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		mq      map[string]interface{}
+		wantErr bool
+	}{
+		{"valid", map[string]interface{}{"gate_history_window": 50, "flaky_quarantine_rate": 0.5}, false},
+		{"zero window", map[string]interface{}{"gate_history_window": 0}, true},
+		{"rate above one", map[string]interface{}{"flaky_quarantine_rate": 1.5}, true},
+		{"negative flaky retries", map[string]interface{}{"max_flaky_retries": -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			data, _ := json.Marshal(map[string]interface{}{"merge_queue": tt.mq})
+			if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+			err := e.LoadConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfig() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (e.config.GateHistoryWindow != 50 || e.config.FlakyQuarantineRate != 0.5) {
+				t.Errorf("config = window %d rate %v", e.config.GateHistoryWindow, e.config.FlakyQuarantineRate)
+			}
+		})
+	}
+}
+
 func TestEngineer_LoadConfig_GatePhase(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -0,0 +1,359 @@
+package refinery
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// Gate history defaults.
+const (
+	// DefaultGateHistoryWindow is how many recent commits per gate are
+	// considered when computing flake rates.
+	DefaultGateHistoryWindow = 30
+
+	// DefaultMaxFlakyRetries is how many polls an MR that failed only
+	// known-flaky gates stays queued before the failure is sent back to the
+	// polecat like any other.
+	DefaultMaxFlakyRetries = 3
+
+	// minQuarantineSamples is the fewest commits a gate must have run on
+	// before it can be quarantined, so one early flake can't disable it.
+	minQuarantineSamples = 5
+
+	// maxGateHistoryRuns caps the history file; older runs are dropped.
+	maxGateHistoryRuns = 5000
+)
+
+// GateRun is one execution of a gate, as stored in the gate history.
+type GateRun struct {
+	Gate    string        `json:"gate"`
+	Commit  string        `json:"commit"`
+	Tree    string        `json:"tree,omitempty"`
+	Success bool          `json:"success"`
+	Attempt int           `json:"attempt"`
+	At      time.Time     `json:"at"`
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// codeKey identifies the code a run tested. Runs are grouped by tree so a
+// stack rebuilt with identical content counts as the same commit.
+func (r GateRun) codeKey() string {
+	if r.Tree != "" {
+		return r.Tree
+	}
+	return r.Commit
+}
+
+// GateStats summarizes a gate's recent history.
+//
+// Each commit the gate ran on is classified by its runs: passed (every run
+// passed), failed (every run failed), or flaky (both). Only flaky commits
+// are evidence of flakiness; a gate that fails consistently on a commit is
+// reporting a real failure.
+type GateStats struct {
+	Gate        string    `json:"gate"`
+	Commits     int       `json:"commits"`
+	Passed      int       `json:"passed"`
+	Failed      int       `json:"failed"`
+	Flaky       int       `json:"flaky"`
+	FlakeRate   float64   `json:"flake_rate"`
+	Quarantined bool      `json:"quarantined"`
+	LastRun     time.Time `json:"last_run"`
+}
+
+// KnownFlaky reports whether the gate has flaked within the history window.
+func (s GateStats) KnownFlaky() bool {
+	return s.Flaky > 0
+}
+
+// GateHistory is an append-only record of gate runs for a rig, stored as
+// JSONL under the rig's runtime directory. It is safe for concurrent use.
+type GateHistory struct {
+	path string
+
+	mu sync.Mutex
+}
+
+// GateHistoryPath returns the gate history file for a rig.
+func GateHistoryPath(rigPath string) string {
+	return filepath.Join(constants.RigRuntimePath(rigPath), "refinery", "gate-history.jsonl")
+}
+
+// NewGateHistory returns the gate history stored at path.
+func NewGateHistory(path string) *GateHistory {
+	return &GateHistory{path: path}
+}
+
+// Record appends runs to the history.
+func (h *GateHistory) Record(runs ...GateRun) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, r := range runs {
+		if err := enc.Encode(r); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return h.compactLocked()
+}
+
+// Runs returns every recorded run, oldest first.
+func (h *GateHistory) Runs() ([]GateRun, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.readLocked()
+}
+
+func (h *GateHistory) readLocked() ([]GateRun, error) {
+	f, err := os.Open(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var runs []GateRun
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r GateRun
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue // tolerate a torn final line
+		}
+		runs = append(runs, r)
+	}
+	return runs, scanner.Err()
+}
+
+// compactLocked rewrites the file with the newest runs once it outgrows
+// maxGateHistoryRuns.
+func (h *GateHistory) compactLocked() error {
+	runs, err := h.readLocked()
+	if err != nil || len(runs) <= maxGateHistoryRuns {
+		return err
+	}
+	runs = runs[len(runs)-maxGateHistoryRuns/2:]
+
+	tmp := h.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, r := range runs {
+		if err := enc.Encode(r); err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmp)
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, h.path)
+}
+
+// Stats computes per-gate statistics over the last window commits each gate
+// ran on. Gates whose flake rate reaches quarantineRate (with at least
+// minQuarantineSamples commits) are marked quarantined; a rate <= 0
+// disables quarantine. Results are sorted by gate name.
+func (h *GateHistory) Stats(window int, quarantineRate float64) ([]GateStats, error) {
+	runs, err := h.Runs()
+	if err != nil {
+		return nil, err
+	}
+	return computeGateStats(runs, window, quarantineRate), nil
+}
+
+// StatsByGate is Stats keyed by gate name.
+func (h *GateHistory) StatsByGate(window int, quarantineRate float64) (map[string]GateStats, error) {
+	stats, err := h.Stats(window, quarantineRate)
+	if err != nil {
+		return nil, err
+	}
+	byGate := make(map[string]GateStats, len(stats))
+	for _, s := range stats {
+		byGate[s.Gate] = s
+	}
+	return byGate, nil
+}
+
+func computeGateStats(runs []GateRun, window int, quarantineRate float64) []GateStats {
+	if window <= 0 {
+		window = DefaultGateHistoryWindow
+	}
+
+	type outcome struct{ pass, fail bool }
+	type gateRuns struct {
+		order    []string // code keys, newest first
+		outcomes map[string]*outcome
+		last     time.Time
+	}
+	gates := make(map[string]*gateRuns)
+
+	// Walk newest first so the window keeps the most recent commits.
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := runs[i]
+		g := gates[r.Gate]
+		if g == nil {
+			g = &gateRuns{outcomes: make(map[string]*outcome), last: r.At}
+			gates[r.Gate] = g
+		}
+		key := r.codeKey()
+		o := g.outcomes[key]
+		if o == nil {
+			if len(g.order) >= window {
+				continue
+			}
+			o = &outcome{}
+			g.outcomes[key] = o
+			g.order = append(g.order, key)
+		}
+		if r.Success {
+			o.pass = true
+		} else {
+			o.fail = true
+		}
+	}
+
+	stats := make([]GateStats, 0, len(gates))
+	for name, g := range gates {
+		s := GateStats{Gate: name, Commits: len(g.order), LastRun: g.last}
+		for _, o := range g.outcomes {
+			switch {
+			case o.pass && o.fail:
+				s.Flaky++
+			case o.pass:
+				s.Passed++
+			default:
+				s.Failed++
+			}
+		}
+		if s.Commits > 0 {
+			s.FlakeRate = float64(s.Flaky) / float64(s.Commits)
+		}
+		s.Quarantined = quarantineRate > 0 && s.Commits >= minQuarantineSamples && s.FlakeRate >= quarantineRate
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Gate < stats[j].Gate })
+	return stats
+}
+
+// gateRound is the gate history snapshot for one round of gates on one commit.
+type gateRound struct {
+	stats  map[string]GateStats
+	commit string
+	tree   string
+}
+
+// loadGateHistory snapshots gate stats and the commit under test before a
+// round of gates. Returns nil if gate history is disabled.
+func (e *Engineer) loadGateHistory() *gateRound {
+	if e.gateHistory == nil {
+		return nil
+	}
+	round := &gateRound{}
+	round.commit, _ = e.git.Rev("HEAD")
+	round.tree, _ = e.git.Rev("HEAD^{tree}")
+	stats, err := e.gateHistory.StatsByGate(e.config.GateHistoryWindow, e.config.FlakyQuarantineRate)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: reading gate history: %v\n", err)
+	}
+	round.stats = stats
+	return round
+}
+
+// runGateWithRetry runs a gate, retrying failures up to RetryFlakyTests
+// attempts (at least one retry for gates known to flake), and records every
+// attempt in the gate history. A failure that passes on retry is flaky; a
+// failure of a quarantined gate is marked so it doesn't block the merge.
+func (e *Engineer) runGateWithRetry(ctx context.Context, name string, gate *GateConfig, round *gateRound) GateResult {
+	var stats GateStats
+	if round != nil {
+		stats = round.stats[name]
+	}
+	attempts := e.config.RetryFlakyTests
+	if attempts < 1 {
+		attempts = 1
+	}
+	if stats.KnownFlaky() && attempts < 2 {
+		attempts = 2
+	}
+
+	var result GateResult
+	var elapsed time.Duration
+	var runs []GateRun
+	failedOnce := false
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: retrying (attempt %d/%d)...\n", name, attempt, attempts)
+		}
+		result = e.runGate(ctx, name, gate)
+		elapsed += result.Elapsed
+		runs = append(runs, GateRun{
+			Gate:    name,
+			Success: result.Success,
+			Attempt: attempt,
+			At:      time.Now().UTC(),
+			Elapsed: result.Elapsed,
+		})
+		if result.Success || ctx.Err() != nil {
+			break
+		}
+		failedOnce = true
+	}
+
+	result.Attempts = len(runs)
+	result.Elapsed = elapsed
+	result.Flaky = result.Success && failedOnce
+	result.KnownFlaky = stats.KnownFlaky() || result.Flaky
+	result.Quarantined = !result.Success && stats.Quarantined
+
+	// Canceled runs (e.g. discarded speculation) say nothing about the gate.
+	if round != nil && round.commit != "" && ctx.Err() == nil {
+		for i := range runs {
+			runs[i].Commit = round.commit
+			runs[i].Tree = round.tree
+		}
+		if err := e.gateHistory.Record(runs...); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: recording gate history: %v\n", err)
+		}
+	}
+	return result
+}
+
+// GateStats returns flake statistics for the rig's gates using the
+// engineer's history window and quarantine rate.
+func (e *Engineer) GateStats() ([]GateStats, error) {
+	if e.gateHistory == nil {
+		return nil, nil
+	}
+	return e.gateHistory.Stats(e.config.GateHistoryWindow, e.config.FlakyQuarantineRate)
+}
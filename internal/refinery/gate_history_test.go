@@ -0,0 +1,236 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func gateRuns(gate, commit string, outcomes ...bool) []GateRun {
+	runs := make([]GateRun, len(outcomes))
+	for i, ok := range outcomes {
+		runs[i] = GateRun{Gate: gate, Commit: commit, Success: ok, Attempt: i + 1, At: time.Now()}
+	}
+	return runs
+}
+
+func TestComputeGateStats(t *testing.T) {
+	var runs []GateRun
+	runs = append(runs, gateRuns("test", "c1", true)...)
+	runs = append(runs, gateRuns("test", "c2", false, true)...) // flaky
+	runs = append(runs, gateRuns("test", "c3", false, false)...)
+	runs = append(runs, gateRuns("lint", "c1", true)...)
+
+	stats := computeGateStats(runs, 10, 0.25)
+	if len(stats) != 2 || stats[0].Gate != "lint" || stats[1].Gate != "test" {
+		t.Fatalf("stats = %+v", stats)
+	}
+	s := stats[1]
+	if s.Commits != 3 || s.Passed != 1 || s.Flaky != 1 || s.Failed != 1 {
+		t.Errorf("test stats = %+v", s)
+	}
+	if s.FlakeRate < 0.33 || s.FlakeRate > 0.34 {
+		t.Errorf("flake rate = %v, want 1/3", s.FlakeRate)
+	}
+	if s.Quarantined {
+		t.Error("gate with fewer than minQuarantineSamples commits must not be quarantined")
+	}
+	if !s.KnownFlaky() || stats[0].KnownFlaky() {
+		t.Error("KnownFlaky misclassified")
+	}
+}
+
+func TestComputeGateStats_GroupsByTree(t *testing.T) {
+	// A rebuilt stack has a new commit but the same tree.
+	runs := []GateRun{
+		{Gate: "test", Commit: "c1", Tree: "t1", Success: false},
+		{Gate: "test", Commit: "c2", Tree: "t1", Success: true},
+	}
+	stats := computeGateStats(runs, 10, 0)
+	if stats[0].Commits != 1 || stats[0].Flaky != 1 {
+		t.Errorf("stats = %+v, want one flaky commit", stats[0])
+	}
+}
+
+func TestComputeGateStats_QuarantineAndWindow(t *testing.T) {
+	var runs []GateRun
+	for i := 0; i < 4; i++ {
+		runs = append(runs, gateRuns("e2e", fmt.Sprintf("old%d", i), false, true)...)
+	}
+	for i := 0; i < 6; i++ {
+		runs = append(runs, gateRuns("e2e", fmt.Sprintf("new%d", i), true)...)
+	}
+
+	// All 10 commits: 40% flaky.
+	if s := computeGateStats(runs, 10, 0.25)[0]; !s.Quarantined {
+		t.Errorf("expected quarantine at 40%% flaky: %+v", s)
+	}
+	// Only the newest 6 commits, which are clean.
+	if s := computeGateStats(runs, 6, 0.25)[0]; s.Flaky != 0 || s.Quarantined {
+		t.Errorf("window should drop old flakes: %+v", s)
+	}
+	// Rate 0 disables quarantine.
+	if s := computeGateStats(runs, 10, 0)[0]; s.Quarantined {
+		t.Errorf("quarantine rate 0 should disable quarantine: %+v", s)
+	}
+}
+
+func TestGateHistory_RecordAndCompact(t *testing.T) {
+	h := NewGateHistory(filepath.Join(t.TempDir(), "sub", "gate-history.jsonl"))
+	if runs, err := h.Runs(); err != nil || len(runs) != 0 {
+		t.Fatalf("empty history: %v, %v", runs, err)
+	}
+	if err := h.Record(gateRuns("test", "c1", false, true)...); err != nil {
+		t.Fatal(err)
+	}
+	runs, err := h.Runs()
+	if err != nil || len(runs) != 2 || runs[1].Attempt != 2 {
+		t.Fatalf("runs = %+v, %v", runs, err)
+	}
+
+	bulk := make([]GateRun, maxGateHistoryRuns)
+	for i := range bulk {
+		bulk[i] = GateRun{Gate: "test", Commit: fmt.Sprintf("c%d", i), Success: true}
+	}
+	if err := h.Record(bulk...); err != nil {
+		t.Fatal(err)
+	}
+	runs, _ = h.Runs()
+	if len(runs) != maxGateHistoryRuns/2 {
+		t.Errorf("after compaction: %d runs, want %d", len(runs), maxGateHistoryRuns/2)
+	}
+	if last := runs[len(runs)-1].Commit; last != fmt.Sprintf("c%d", maxGateHistoryRuns-1) {
+		t.Errorf("compaction dropped newest runs, last = %s", last)
+	}
+}
+
+func TestRunGates_RetriesFlakyGate(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+	e := newTestEngineer(t, workDir, g)
+	e.config.RetryFlakyTests = 2
+
+	// Fails on the first run, passes on the second.
+	marker := filepath.ToSlash(filepath.Join(t.TempDir(), "ran-once"))
+	e.config.Gates = map[string]*GateConfig{
+		"test": {Cmd: fmt.Sprintf("if [ -f '%s' ]; then exit 0; fi; touch '%s'; exit 1", marker, marker)},
+	}
+
+	result := e.runGates(context.Background())
+	if !result.Success {
+		t.Fatalf("flaky gate should pass on retry: %s", result.Error)
+	}
+
+	stats, err := e.GateStats()
+	if err != nil || len(stats) != 1 {
+		t.Fatalf("GateStats = %+v, %v", stats, err)
+	}
+	if stats[0].Flaky != 1 || stats[0].Commits != 1 {
+		t.Errorf("stats = %+v, want one flaky commit", stats[0])
+	}
+}
+
+func TestRunGates_QuarantinedGateDoesNotBlock(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+	e := newTestEngineer(t, workDir, g)
+	e.config.FlakyQuarantineRate = 0.25
+	e.config.Gates = map[string]*GateConfig{
+		"e2e":  {Cmd: "exit 1"},
+		"unit": {Cmd: "true"},
+	}
+	for i := 0; i < minQuarantineSamples; i++ {
+		if err := e.gateHistory.Record(gateRuns("e2e", fmt.Sprintf("c%d", i), false, true)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result := e.runGates(context.Background())
+	if !result.Success {
+		t.Errorf("quarantined gate failure should not block: %s", result.Error)
+	}
+
+	e.config.FlakyQuarantineRate = 0
+	result = e.runGates(context.Background())
+	if result.Success || !result.TestsFailed {
+		t.Fatal("with quarantine disabled the failing gate should block")
+	}
+	if !result.Flaky {
+		t.Error("failure of a known-flaky gate should be classified flaky")
+	}
+}
+
+func TestRunGates_RealFailureNotFlaky(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+	e := newTestEngineer(t, workDir, g)
+	e.config.RetryFlakyTests = 2
+	e.config.Gates = map[string]*GateConfig{"test": {Cmd: "exit 1"}}
+
+	result := e.runGates(context.Background())
+	if result.Success || result.Flaky {
+		t.Errorf("consistent failure should be real, got %+v", result)
+	}
+	stats, _ := e.GateStats()
+	if len(stats) != 1 || stats[0].Failed != 1 || stats[0].Flaky != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestQuarantineOptIn(t *testing.T) {
+	if rate := DefaultMergeQueueConfig().FlakyQuarantineRate; rate != 0 {
+		t.Errorf("default FlakyQuarantineRate = %v, want 0 (opt-in)", rate)
+	}
+}
+
+func TestRetryFlakyMR_CapsAttempts(t *testing.T) {
+	var out bytes.Buffer
+	store := map[string]*beads.MRFields{}
+	e := &Engineer{
+		output: &out,
+		config: &MergeQueueConfig{MaxFlakyRetries: 2},
+		updateMRFields: func(mrID string, update func(*beads.MRFields)) error {
+			f := store[mrID]
+			if f == nil {
+				f = &beads.MRFields{}
+				store[mrID] = f
+			}
+			update(f)
+			return nil
+		},
+	}
+	result := ProcessResult{TestsFailed: true, Flaky: true, Error: "gate e2e failed"}
+
+	// Each poll reloads the MR from its bead, so carry the count over the
+	// way issueToMRInfo would.
+	for want := 1; want <= 2; want++ {
+		mr := &MRInfo{ID: "gt-mr1"}
+		if f := store["gt-mr1"]; f != nil {
+			mr.FlakyRetries = f.FlakyRetries
+		}
+		if !e.retryFlakyMR(mr, result) {
+			t.Fatalf("retry %d: want MR kept queued", want)
+		}
+		if got := store["gt-mr1"].FlakyRetries; got != want {
+			t.Errorf("retry %d: recorded flaky_retries = %d", want, got)
+		}
+	}
+
+	mr := &MRInfo{ID: "gt-mr1", FlakyRetries: store["gt-mr1"].FlakyRetries}
+	if e.retryFlakyMR(mr, result) {
+		t.Error("MR past MaxFlakyRetries should be treated as a real failure")
+	}
+	if store["gt-mr1"].FlakyRetries != 2 {
+		t.Errorf("flaky_retries changed past the cap: %d", store["gt-mr1"].FlakyRetries)
+	}
+
+	e.config.MaxFlakyRetries = 0
+	if e.retryFlakyMR(&MRInfo{ID: "gt-mr2"}, result) {
+		t.Error("MaxFlakyRetries 0 should never keep a flaky MR queued")
+	}
+}
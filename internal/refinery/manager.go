@@ -71,9 +71,7 @@ func (m *Manager) SessionName() string {
 // ZFC: tmux session existence is the source of truth for session state,
 // but agent liveness determines if the session is actually functional.
 func (m *Manager) IsRunning() (bool, error) {
-	t := tmux.NewTmux()
-	sessionName := m.SessionName()
-	status := t.CheckSessionHealth(sessionName, 0)
+	status := session.CheckSessionHealth(m.backend(), m.SessionName(), 0)
 	return status == tmux.SessionHealthy, nil
 }
 
@@ -83,17 +81,21 @@ func (m *Manager) IsRunning() (bool, error) {
 // Returns the detailed ZombieStatus for callers that need to distinguish
 // between different failure modes.
 func (m *Manager) IsHealthy(maxInactivity time.Duration) tmux.ZombieStatus {
-	t := tmux.NewTmux()
-	return t.CheckSessionHealth(m.SessionName(), maxInactivity)
+	return session.CheckSessionHealth(m.backend(), m.SessionName(), maxInactivity)
+}
+
+// backend returns the town's session backend.
+func (m *Manager) backend() session.SessionBackend {
+	return session.NewBackend(filepath.Dir(m.rig.Path))
 }
 
 // Status returns information about the refinery session.
 // ZFC-compliant: tmux session is the source of truth.
 func (m *Manager) Status() (*tmux.SessionInfo, error) {
-	t := tmux.NewTmux()
+	b := m.backend()
 	sessionID := m.SessionName()
 
-	running, err := t.HasSession(sessionID)
+	running, err := b.HasSession(sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("checking session: %w", err)
 	}
@@ -101,16 +103,19 @@ func (m *Manager) Status() (*tmux.SessionInfo, error) {
 		return nil, ErrNotRunning
 	}
 
-	return t.GetSessionInfo(sessionID)
+	return session.GetSessionInfo(b, sessionID)
 }
 
 // Start starts the refinery.
 // If foreground is true, returns an error (foreground mode deprecated).
-// Otherwise, spawns a Claude agent in a tmux session to process the merge queue.
+// Otherwise, spawns a Claude agent in a session on the town's backend to
+// process the merge queue. tmux-only setup (theme, startup dialogs, PID
+// tracking) is skipped for other backends.
 // The agentOverride parameter allows specifying an agent alias to use instead of the town default.
 // ZFC-compliant: no state file, tmux session is source of truth.
 func (m *Manager) Start(foreground bool, agentOverride string) error {
-	t := tmux.NewTmux()
+	b := m.backend()
+	t, isTmux := b.(*tmux.Tmux)
 	sessionID := m.SessionName()
 
 	if foreground {
@@ -119,15 +124,15 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 	}
 
 	// Check if session already exists
-	running, _ := t.HasSession(sessionID)
+	running, _ := b.HasSession(sessionID)
 	if running {
 		// Session exists - check if agent is actually running (healthy vs zombie)
-		if t.IsAgentAlive(sessionID) {
+		if b.IsAgentAlive(sessionID) {
 			return ErrAlreadyRunning
 		}
 		// Zombie - tmux alive but agent dead. Kill and recreate.
 		_, _ = fmt.Fprintln(m.output, "⚠ Detected zombie session (tmux alive, agent dead). Recreating...")
-		if err := t.KillSession(sessionID); err != nil {
+		if err := session.KillSession(b, sessionID); err != nil {
 			return fmt.Errorf("killing zombie session: %w", err)
 		}
 	}
@@ -200,8 +205,8 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
-	if err := t.NewSessionWithCommand(sessionID, refineryRigDir, command); err != nil {
-		return fmt.Errorf("creating tmux session: %w", err)
+	if err := b.NewSessionWithCommand(sessionID, refineryRigDir, command); err != nil {
+		return fmt.Errorf("creating session: %w", err)
 	}
 
 	// Set environment variables (non-fatal: session works without these)
@@ -218,26 +223,31 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 	// Add refinery-specific flag
 	envVars["GT_REFINERY"] = "1"
 
-	// Set all env vars in tmux session (for debugging) and they'll also be exported to Claude
+	// Set all env vars in the session (for debugging) and they'll also be exported to Claude
 	for k, v := range envVars {
-		_ = t.SetEnvironment(sessionID, k, v)
+		_ = b.SetEnvironment(sessionID, k, v)
 	}
-	_ = t.SetEnvironment(sessionID, "GT_RUN", runID)
+	_ = b.SetEnvironment(sessionID, "GT_RUN", runID)
 
-	// Apply theme (non-fatal: theming failure doesn't affect operation)
-	theme := tmux.ResolveSessionTheme(townRoot, m.rig.Name, "refinery")
-	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, "refinery", "refinery")
+	if isTmux {
+		// Apply theme (non-fatal: theming failure doesn't affect operation)
+		theme := tmux.ResolveSessionTheme(townRoot, m.rig.Name, "refinery")
+		_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, "refinery", "refinery")
 
-	// Accept startup dialogs (workspace trust + bypass permissions) if they appear.
-	// Must be before WaitForRuntimeReady to avoid race where dialog blocks prompt detection.
-	_ = t.AcceptStartupDialogs(sessionID)
+		// Accept startup dialogs (workspace trust + bypass permissions) if they appear.
+		// Must be before WaitForRuntimeReady to avoid race where dialog blocks prompt detection.
+		_ = t.AcceptStartupDialogs(sessionID)
 
-	// Wait for Claude to start and show its prompt - fatal if Claude fails to launch
-	// WaitForRuntimeReady waits for the runtime to be ready
-	if err := t.WaitForRuntimeReady(sessionID, runtimeConfig, constants.ClaudeStartTimeout); err != nil {
-		// Kill the zombie session before returning error
-		_ = t.KillSessionWithProcesses(sessionID)
-		return fmt.Errorf("waiting for refinery to start: %w", err)
+		// Wait for Claude to start and show its prompt - fatal if Claude fails to launch
+		// WaitForRuntimeReady waits for the runtime to be ready
+		if err := t.WaitForRuntimeReady(sessionID, runtimeConfig, constants.ClaudeStartTimeout); err != nil {
+			// Kill the zombie session before returning error
+			_ = t.KillSessionWithProcesses(sessionID)
+			return fmt.Errorf("waiting for refinery to start: %w", err)
+		}
+	} else if !session.WaitForReadyPrompt(b, sessionID, runtimeConfig, constants.ClaudeStartTimeout) {
+		_ = b.KillSessionWithProcesses(sessionID)
+		return fmt.Errorf("waiting for refinery to start: timed out")
 	}
 
 	// Start nudge-queue poller (gt-dgf). Claude's UserPromptSubmit hook only
@@ -247,12 +257,14 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 		log.Printf("warning: could not start nudge poller for %s: %v", sessionID, pollerErr)
 	}
 
-	_ = runtime.RunStartupFallback(t, sessionID, "refinery", runtimeConfig)
-	_ = runtime.DeliverStartupPromptFallback(t, sessionID, initialPrompt, runtimeConfig, constants.ClaudeStartTimeout)
+	if isTmux {
+		_ = runtime.RunStartupFallback(t, sessionID, "refinery", runtimeConfig)
+		_ = runtime.DeliverStartupPromptFallback(t, sessionID, initialPrompt, runtimeConfig, constants.ClaudeStartTimeout)
 
-	// Track PID for defense-in-depth orphan cleanup (non-fatal)
-	if err := session.TrackSessionPID(townRoot, sessionID, t); err != nil {
-		log.Printf("warning: tracking session PID for %s: %v", sessionID, err)
+		// Track PID for defense-in-depth orphan cleanup (non-fatal)
+		if err := session.TrackSessionPID(townRoot, sessionID, t); err != nil {
+			log.Printf("warning: tracking session PID for %s: %v", sessionID, err)
+		}
 	}
 
 	// Stream refinery's Claude Code JSONL conversation log to VictoriaLogs (opt-in).
@@ -309,17 +321,17 @@ func (m *Manager) repairRefineryWorktree(refineryRigDir string) error {
 // Stop stops the refinery.
 // ZFC-compliant: tmux session is the source of truth.
 func (m *Manager) Stop() error {
-	t := tmux.NewTmux()
+	b := m.backend()
 	sessionID := m.SessionName()
 
-	// Check if tmux session exists
-	running, _ := t.HasSession(sessionID)
+	// Check if the session exists
+	running, _ := b.HasSession(sessionID)
 	if !running {
 		return ErrNotRunning
 	}
 
-	// Kill the tmux session
-	return t.KillSession(sessionID)
+	// Kill the session
+	return session.KillSession(b, sessionID)
 }
 
 // Queue returns the current merge queue.
@@ -0,0 +1,366 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// speculation is a batch being gated ahead of its turn, stacked on the
+// predicted tip of the batch before it.
+//
+// Speculative work never touches the target branch or MR beads: the stack
+// lives in a detached worktree and is only adopted once every batch ahead
+// of it has landed exactly as predicted.
+type speculation struct {
+	batch   []*MRInfo
+	stacked []*MRInfo
+	base    string // predicted tip of the previous batch
+	tip     string // HEAD of the speculative stack
+	dir     string // detached worktree holding the stack
+
+	// err is set if the stack could not be built speculatively (missing
+	// branch, conflict). The batch is then processed normally in its turn.
+	err error
+
+	gate   ProcessResult
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// AssemblePipeline carves up to depth consecutive batches from the ready queue.
+// Each batch is assembled as AssembleBatch would from the MRs left over by the
+// batches ahead of it, so an MR blocked by one in an earlier batch waits for a
+// later cycle rather than riding on a prediction.
+func (e *Engineer) AssemblePipeline(readyMRs []*MRInfo, config *BatchConfig, depth int) [][]*MRInfo {
+	if depth < 1 {
+		depth = 1
+	}
+	var batches [][]*MRInfo
+	remaining := readyMRs
+	for len(batches) < depth {
+		batch := e.AssembleBatch(remaining, config)
+		if len(batch) == 0 {
+			break
+		}
+		batches = append(batches, batch)
+
+		taken := make(map[string]bool, len(batch))
+		for _, mr := range batch {
+			taken[mr.ID] = true
+		}
+		next := make([]*MRInfo, 0, len(remaining)-len(batch))
+		for _, mr := range remaining {
+			if !taken[mr.ID] {
+				next = append(next, mr)
+			}
+		}
+		remaining = next
+	}
+	return batches
+}
+
+// ProcessPipeline processes consecutive batches with speculative pipelining.
+//
+// While the head batch is gated in the refinery worktree, up to
+// MaxConcurrent-1 following batches are stacked on its predicted result
+// (the head stack's tip, then each speculative tip in turn) and gated in
+// parallel in detached worktrees. When the head lands exactly as predicted,
+// the next speculation is adopted: its stack is pushed if its gates passed,
+// or retried and bisected in place if they failed. As soon as a batch lands
+// differently than predicted — conflicts, bisection, a flaky retry — every
+// later speculation is discarded and those batches are re-stacked on the
+// real tip.
+//
+// With MaxConcurrent <= 1, or with the PR merge strategy, batches are
+// processed one after another with ProcessBatch.
+//
+// Results are returned in batch order.
+func (e *Engineer) ProcessPipeline(ctx context.Context, batches [][]*MRInfo, target string, batchCfg *BatchConfig) []*BatchResult {
+	if batchCfg == nil {
+		batchCfg = DefaultBatchConfig()
+	}
+
+	depth := e.config.MaxConcurrent
+	if depth <= 1 || len(batches) <= 1 || e.config.MergeStrategy == MergeStrategyPR {
+		results := make([]*BatchResult, 0, len(batches))
+		for _, batch := range batches {
+			results = append(results, e.ProcessBatch(ctx, batch, target, batchCfg))
+		}
+		return results
+	}
+
+	// Gates for several batches now log concurrently.
+	origOutput := e.output
+	e.output = &lockedWriter{w: origOutput}
+	defer func() { e.output = origOutput }()
+
+	results := make([]*BatchResult, 0, len(batches))
+	next := 0
+	for next < len(batches) {
+		if ctx.Err() != nil {
+			results = append(results, &BatchResult{Error: ctx.Err()})
+			next++
+			continue
+		}
+
+		head := batches[next]
+		next++
+		_, _ = fmt.Fprintf(e.output, "[Pipeline] Processing batch of %d MRs targeting %s\n", len(head), target)
+
+		result := &BatchResult{}
+		stacked, conflicts, err := e.BuildRebaseStack(ctx, head, target)
+		if err != nil {
+			result.Error = fmt.Errorf("build rebase stack: %w", err)
+			results = append(results, result)
+			continue
+		}
+		result.Conflicts = conflicts
+		if len(stacked) == 0 {
+			_, _ = fmt.Fprintln(e.output, "[Pipeline] No MRs could be stacked (all conflicted)")
+			results = append(results, result)
+			continue
+		}
+		predicted, err := e.git.Rev("HEAD")
+		if err != nil {
+			result.Error = fmt.Errorf("get stack tip: %w", err)
+			results = append(results, result)
+			continue
+		}
+
+		end := next + depth - 1
+		if end > len(batches) {
+			end = len(batches)
+		}
+		specs := e.speculate(ctx, batches[next:end], predicted)
+
+		_, _ = fmt.Fprintf(e.output, "[Pipeline] Running gates on stack tip (%d MRs, %d speculative batch(es) behind it)...\n", len(stacked), len(specs))
+		gateResult := e.runBatchGates(ctx)
+		result = e.settleBatch(ctx, stacked, target, batchCfg, gateResult, result)
+		results = append(results, result)
+
+		// Walk the speculation chain while each batch lands as predicted.
+		onTrack := result.Error == nil && result.MergeCommit == predicted
+		for _, spec := range specs {
+			if onTrack {
+				<-spec.done
+				onTrack = spec.err == nil
+			}
+			if !onTrack {
+				e.discardSpeculation(spec)
+				continue
+			}
+
+			_, _ = fmt.Fprintf(e.output, "[Pipeline] Adopting speculative batch %v (stacked on %s)\n", mrIDs(spec.stacked), shortSHA(spec.base))
+			specResult := e.adoptSpeculation(ctx, spec, target, batchCfg)
+			e.removeSpeculationWorktree(spec)
+			results = append(results, specResult)
+			next++
+			onTrack = specResult.Error == nil && specResult.MergeCommit == spec.tip
+		}
+	}
+	return results
+}
+
+// ProcessQueue runs one pass of the merge queue. Ready MRs are grouped by
+// target branch in score order, and up to MaxConcurrent batches per target
+// are carved with AssemblePipeline and landed with ProcessPipeline. Culprits
+// and conflicted MRs are handed to HandleMRInfoFailure; MRs caught in an
+// infrastructure error stay queued for the next pass.
+//
+// Batching follows the Batch config; without one every batch is a single MR.
+func (e *Engineer) ProcessQueue(ctx context.Context) ([]*BatchResult, error) {
+	ready, err := e.ListReadyMRs()
+	if err != nil {
+		return nil, err
+	}
+	return e.processReady(ctx, ready), nil
+}
+
+func (e *Engineer) processReady(ctx context.Context, ready []*MRInfo) []*BatchResult {
+	batchCfg := e.config.Batch
+	if batchCfg == nil {
+		batchCfg = &BatchConfig{MaxBatchSize: 1, RetryBatchOnFlaky: true}
+	}
+
+	now := time.Now()
+	sort.SliceStable(ready, func(i, j int) bool {
+		return ready[i].ScoreAt(now) > ready[j].ScoreAt(now)
+	})
+	var targets []string
+	byTarget := make(map[string][]*MRInfo)
+	for _, mr := range ready {
+		target := mr.Target
+		if target == "" {
+			target = e.rig.DefaultBranch()
+		}
+		if _, ok := byTarget[target]; !ok {
+			targets = append(targets, target)
+		}
+		byTarget[target] = append(byTarget[target], mr)
+	}
+
+	var results []*BatchResult
+	for _, target := range targets {
+		if ctx.Err() != nil {
+			break
+		}
+		batches := e.AssemblePipeline(byTarget[target], batchCfg, e.config.MaxConcurrent)
+		for _, result := range e.ProcessPipeline(ctx, batches, target, batchCfg) {
+			e.reportBatchFailures(result)
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// reportBatchFailures sends a batch's culprits and conflicts back through
+// HandleMRInfoFailure. MRs whose branch is gone were already escalated to the
+// mayor while the stack was built, so they are skipped here.
+func (e *Engineer) reportBatchFailures(result *BatchResult) {
+	for _, mr := range result.Culprits {
+		failure := ProcessResult{TestsFailed: true, Error: "gates failed"}
+		if mr.PRState == PRStateChangesRequested {
+			failure = ProcessResult{ChangesRequested: true, Error: "changes requested"}
+		}
+		e.HandleMRInfoFailure(mr, failure)
+	}
+	for _, mr := range result.Conflicts {
+		if exists, err := e.git.BranchExists(mr.Branch); err != nil || !exists {
+			continue
+		}
+		e.HandleMRInfoFailure(mr, ProcessResult{Conflict: true, Error: "conflicts with " + mr.Target})
+	}
+	if result.Error != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Batch error, MRs stay queued: %v\n", result.Error)
+	}
+}
+
+// speculate stacks each batch on the tip predicted for the one before it and
+// starts gating the stacks in the background. Stacking stops at the first
+// batch that cannot be stacked, since nothing behind it has a prediction.
+func (e *Engineer) speculate(ctx context.Context, batches [][]*MRInfo, base string) []*speculation {
+	var specs []*speculation
+	for i, batch := range batches {
+		spec := &speculation{batch: batch, base: base, done: make(chan struct{})}
+		specs = append(specs, spec)
+
+		spec.dir = filepath.Join(filepath.Dir(e.workDir), fmt.Sprintf(".refinery-speculative-%d", i+1))
+		se, err := e.speculativeEngineer(spec.dir, base)
+		if err == nil {
+			err = se.stackSpeculatively(spec)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Pipeline] Not speculating on batch %v: %v\n", mrIDs(batch), err)
+			spec.err = err
+			close(spec.done)
+			break
+		}
+
+		var specCtx context.Context
+		specCtx, spec.cancel = context.WithCancel(ctx)
+		_, _ = fmt.Fprintf(e.output, "[Pipeline] Gating speculative batch %v on %s\n", mrIDs(spec.stacked), shortSHA(base))
+		go func() {
+			defer close(spec.done)
+			spec.gate = se.runBatchGates(specCtx)
+		}()
+		base = spec.tip
+	}
+	return specs
+}
+
+// speculativeEngineer returns a copy of the engineer working in a fresh
+// detached worktree at base. Any leftover worktree at dir is replaced.
+func (e *Engineer) speculativeEngineer(dir, base string) (*Engineer, error) {
+	_ = e.git.WorktreeRemove(dir, true)
+	_ = os.RemoveAll(dir)
+	_ = e.git.WorktreePrune()
+	if err := e.git.WorktreeAddDetached(dir, base); err != nil {
+		return nil, fmt.Errorf("create speculative worktree: %w", err)
+	}
+	se := *e
+	se.git = git.NewGit(dir)
+	se.workDir = dir
+	return &se, nil
+}
+
+// stackSpeculatively squash-merges the batch onto the speculative worktree.
+// Unlike BuildRebaseStack it never drops MRs or reports failures on their
+// beads: anything unexpected abandons the speculation, and the batch gets
+// the full treatment when it reaches the head of the pipeline.
+func (e *Engineer) stackSpeculatively(spec *speculation) error {
+	for _, mr := range spec.batch {
+		exists, err := e.git.BranchExists(mr.Branch)
+		if err != nil || !exists {
+			return fmt.Errorf("branch %s not found for %s", mr.Branch, mr.ID)
+		}
+		if err := e.git.MergeSquash(mr.Branch, e.getMergeMessage(mr)); err != nil {
+			return fmt.Errorf("squash merge %s: %w", mr.ID, err)
+		}
+		spec.stacked = append(spec.stacked, mr)
+	}
+	tip, err := e.git.Rev("HEAD")
+	if err != nil {
+		return fmt.Errorf("get speculative tip: %w", err)
+	}
+	spec.tip = tip
+	return nil
+}
+
+// adoptSpeculation lands a speculative batch whose base has just been pushed.
+// The refinery worktree is moved to the speculative tip and the batch is
+// settled with the gate result it already has, so a green speculation is
+// pushed without re-running gates.
+func (e *Engineer) adoptSpeculation(ctx context.Context, spec *speculation, target string, batchCfg *BatchConfig) *BatchResult {
+	result := &BatchResult{}
+	if err := e.git.Checkout(target); err != nil {
+		result.Error = fmt.Errorf("checkout target %s: %w", target, err)
+		return result
+	}
+	if err := e.git.ResetHard(spec.tip); err != nil {
+		result.Error = fmt.Errorf("reset to speculative tip: %w", err)
+		return result
+	}
+	return e.settleBatch(ctx, spec.stacked, target, batchCfg, spec.gate, result)
+}
+
+// discardSpeculation cancels a speculative gate run and removes its worktree.
+func (e *Engineer) discardSpeculation(spec *speculation) {
+	if spec.cancel != nil {
+		spec.cancel()
+	}
+	<-spec.done
+	if spec.err == nil {
+		_, _ = fmt.Fprintf(e.output, "[Pipeline] Discarding speculative batch %v: base %s did not land as predicted\n", mrIDs(spec.stacked), shortSHA(spec.base))
+	}
+	e.removeSpeculationWorktree(spec)
+}
+
+func (e *Engineer) removeSpeculationWorktree(spec *speculation) {
+	if spec.cancel != nil {
+		spec.cancel()
+	}
+	if err := e.git.WorktreeRemove(spec.dir, true); err != nil {
+		_ = os.RemoveAll(spec.dir)
+		_ = e.git.WorktreePrune()
+	}
+}
+
+// lockedWriter serializes writes from concurrently gated batches.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
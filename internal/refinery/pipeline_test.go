@@ -0,0 +1,266 @@
+package refinery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gateLogCmd returns a gate that records the directory it ran in and fails
+// if FAIL_MARKER is present.
+func gateLogCmd(logPath string) string {
+	return "pwd >> '" + filepath.ToSlash(logPath) + "' && test ! -f FAIL_MARKER"
+}
+
+func readGateLog(t *testing.T, logPath string) []string {
+	t.Helper()
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("reading gate log: %v", err)
+	}
+	return strings.Fields(string(data))
+}
+
+func TestAssemblePipeline(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+	e := newTestEngineer(t, workDir, g)
+
+	ready := []*MRInfo{
+		makeMR("mr-1", "b1", "main"),
+		makeMR("mr-2", "b2", "main"),
+		makeMR("mr-3", "b3", "main"),
+		makeMR("mr-4", "b4", "main"),
+		makeMR("mr-5", "b5", "main"),
+	}
+	ready[3].BlockedBy = "mr-1" // blocker is in an earlier batch
+
+	batches := e.AssemblePipeline(ready, &BatchConfig{MaxBatchSize: 2}, 3)
+	if len(batches) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(batches))
+	}
+	if got := stackedIDs(batches[0]); strings.Join(got, ",") != "mr-1,mr-2" {
+		t.Errorf("batch 0 = %v", got)
+	}
+	if got := stackedIDs(batches[1]); strings.Join(got, ",") != "mr-3,mr-5" {
+		t.Errorf("batch 1 = %v", got)
+	}
+
+	if batches := e.AssemblePipeline(ready, &BatchConfig{MaxBatchSize: 2}, 0); len(batches) != 1 {
+		t.Errorf("depth 0 should assemble one batch, got %d", len(batches))
+	}
+}
+
+func TestProcessPipeline_AllPass_SpeculatesAhead(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		createFeatureBranch(t, workDir, "feature-"+name, name+".txt", "hello "+name+"\n")
+	}
+
+	e := newTestEngineer(t, workDir, g)
+	e.config.MaxConcurrent = 3
+	logPath := filepath.Join(t.TempDir(), "gates.log")
+	e.config.Gates = map[string]*GateConfig{"check": {Cmd: gateLogCmd(logPath)}}
+
+	batches := [][]*MRInfo{
+		{makeMR("mr-a", "feature-a", "main"), makeMR("mr-b", "feature-b", "main")},
+		{makeMR("mr-c", "feature-c", "main"), makeMR("mr-d", "feature-d", "main")},
+		{makeMR("mr-e", "feature-e", "main"), makeMR("mr-f", "feature-f", "main")},
+	}
+
+	results := e.ProcessPipeline(context.Background(), batches, "main", DefaultBatchConfig())
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.Error != nil || len(r.Merged) != 2 {
+			t.Errorf("batch %d: merged %v, err %v", i, stackedIDs(r.Merged), r.Error)
+		}
+	}
+
+	// Each batch was gated exactly once, two of them speculatively.
+	dirs := readGateLog(t, logPath)
+	if len(dirs) != 3 {
+		t.Fatalf("expected 3 gate runs, got %d: %v", len(dirs), dirs)
+	}
+	speculative := 0
+	for _, dir := range dirs {
+		if strings.Contains(dir, ".refinery-speculative-") {
+			speculative++
+		}
+	}
+	if speculative != 2 {
+		t.Errorf("expected 2 speculative gate runs, got %d: %v", speculative, dirs)
+	}
+
+	if tip := run(t, workDir, "git", "rev-parse", "origin/main"); tip != results[2].MergeCommit {
+		t.Errorf("origin/main = %s, want last batch tip %s", tip, results[2].MergeCommit)
+	}
+	run(t, workDir, "git", "checkout", "main")
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		if _, err := os.Stat(filepath.Join(workDir, name+".txt")); err != nil {
+			t.Errorf("%s.txt missing on main: %v", name, err)
+		}
+	}
+	if wt := run(t, workDir, "git", "worktree", "list"); strings.Contains(wt, "speculative") {
+		t.Errorf("speculative worktrees left behind:\n%s", wt)
+	}
+}
+
+func TestProcessPipeline_HeadFailureDiscardsSpeculation(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+
+	createFeatureBranch(t, workDir, "feature-a", "a.txt", "hello a\n")
+	createFeatureBranch(t, workDir, "feature-bad", "FAIL_MARKER", "boom\n")
+	createFeatureBranch(t, workDir, "feature-c", "c.txt", "hello c\n")
+	createFeatureBranch(t, workDir, "feature-d", "d.txt", "hello d\n")
+
+	e := newTestEngineer(t, workDir, g)
+	e.config.MaxConcurrent = 2
+	logPath := filepath.Join(t.TempDir(), "gates.log")
+	e.config.Gates = map[string]*GateConfig{"check": {Cmd: gateLogCmd(logPath)}}
+
+	batches := [][]*MRInfo{
+		{makeMR("mr-a", "feature-a", "main"), makeMR("mr-bad", "feature-bad", "main")},
+		{makeMR("mr-c", "feature-c", "main"), makeMR("mr-d", "feature-d", "main")},
+	}
+	cfg := &BatchConfig{MaxBatchSize: 5, RetryBatchOnFlaky: false}
+
+	results := e.ProcessPipeline(context.Background(), batches, "main", cfg)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if got := stackedIDs(results[0].Culprits); len(got) != 1 || got[0] != "mr-bad" {
+		t.Errorf("batch 0 culprits = %v, want [mr-bad]", got)
+	}
+	if got := stackedIDs(results[0].Merged); len(got) != 1 || got[0] != "mr-a" {
+		t.Errorf("batch 0 merged = %v, want [mr-a]", got)
+	}
+	if results[1].Error != nil || len(results[1].Merged) != 2 {
+		t.Errorf("batch 1: merged %v, err %v", stackedIDs(results[1].Merged), results[1].Error)
+	}
+
+	// The speculative stack contained FAIL_MARKER from its base, so batch 1
+	// must have been re-stacked on the real tip rather than adopted.
+	run(t, workDir, "git", "checkout", "main")
+	if _, err := os.Stat(filepath.Join(workDir, "FAIL_MARKER")); !os.IsNotExist(err) {
+		t.Error("culprit's file landed on main")
+	}
+	if tip := run(t, workDir, "git", "rev-parse", "origin/main"); tip != results[1].MergeCommit {
+		t.Errorf("origin/main = %s, want %s", tip, results[1].MergeCommit)
+	}
+}
+
+func TestProcessPipeline_SpeculativeFailureIsBisected(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+
+	createFeatureBranch(t, workDir, "feature-a", "a.txt", "hello a\n")
+	createFeatureBranch(t, workDir, "feature-b", "b.txt", "hello b\n")
+	createFeatureBranch(t, workDir, "feature-c", "c.txt", "hello c\n")
+	createFeatureBranch(t, workDir, "feature-bad", "FAIL_MARKER", "boom\n")
+
+	e := newTestEngineer(t, workDir, g)
+	e.config.MaxConcurrent = 2
+	e.config.Gates = map[string]*GateConfig{"check": {Cmd: failMarkerGateCmd()}}
+
+	batches := [][]*MRInfo{
+		{makeMR("mr-a", "feature-a", "main"), makeMR("mr-b", "feature-b", "main")},
+		{makeMR("mr-c", "feature-c", "main"), makeMR("mr-bad", "feature-bad", "main")},
+	}
+	cfg := &BatchConfig{MaxBatchSize: 5, RetryBatchOnFlaky: false}
+
+	results := e.ProcessPipeline(context.Background(), batches, "main", cfg)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Error != nil || len(results[0].Merged) != 2 {
+		t.Errorf("batch 0: merged %v, err %v", stackedIDs(results[0].Merged), results[0].Error)
+	}
+	if got := stackedIDs(results[1].Culprits); len(got) != 1 || got[0] != "mr-bad" {
+		t.Errorf("batch 1 culprits = %v, want [mr-bad]", got)
+	}
+	if got := stackedIDs(results[1].Merged); len(got) != 1 || got[0] != "mr-c" {
+		t.Errorf("batch 1 merged = %v, want [mr-c]", got)
+	}
+}
+
+func TestProcessReady_PipelinesUpToMaxConcurrent(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+
+	for _, name := range []string{"a", "b", "c"} {
+		createFeatureBranch(t, workDir, "feature-"+name, name+".txt", "hello "+name+"\n")
+	}
+
+	e := newTestEngineer(t, workDir, g)
+	e.config.MaxConcurrent = 2
+	logPath := filepath.Join(t.TempDir(), "gates.log")
+	e.config.Gates = map[string]*GateConfig{"check": {Cmd: gateLogCmd(logPath)}}
+
+	ready := []*MRInfo{
+		makeMR("mr-a", "feature-a", "main"),
+		makeMR("mr-b", "feature-b", "main"),
+		makeMR("mr-c", "feature-c", ""),
+	}
+	ready[2].Priority = 4 // lowest score: left for the next pass
+
+	// Without a batch config every MR is its own batch, so MaxConcurrent=2
+	// lands two of the three this pass.
+	results := e.processReady(context.Background(), ready)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 batch results, got %d", len(results))
+	}
+	var merged []string
+	for _, r := range results {
+		if r.Error != nil {
+			t.Errorf("batch error: %v", r.Error)
+		}
+		merged = append(merged, stackedIDs(r.Merged)...)
+	}
+	if strings.Join(merged, ",") != "mr-a,mr-b" {
+		t.Errorf("merged = %v, want [mr-a mr-b]", merged)
+	}
+
+	speculated := false
+	for _, dir := range readGateLog(t, logPath) {
+		speculated = speculated || strings.Contains(dir, "speculative")
+	}
+	if !speculated {
+		t.Error("MaxConcurrent=2 should gate the second batch speculatively")
+	}
+}
+
+func TestProcessPipeline_SequentialWhenMaxConcurrentIsOne(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+
+	createFeatureBranch(t, workDir, "feature-a", "a.txt", "hello a\n")
+	createFeatureBranch(t, workDir, "feature-b", "b.txt", "hello b\n")
+
+	e := newTestEngineer(t, workDir, g)
+	e.config.MaxConcurrent = 1
+	logPath := filepath.Join(t.TempDir(), "gates.log")
+	e.config.Gates = map[string]*GateConfig{"check": {Cmd: gateLogCmd(logPath)}}
+
+	batches := [][]*MRInfo{
+		{makeMR("mr-a", "feature-a", "main")},
+		{makeMR("mr-b", "feature-b", "main")},
+	}
+	results := e.ProcessPipeline(context.Background(), batches, "main", DefaultBatchConfig())
+	for i, r := range results {
+		if r.Error != nil || len(r.Merged) != 1 {
+			t.Errorf("batch %d: merged %v, err %v", i, stackedIDs(r.Merged), r.Error)
+		}
+	}
+	for _, dir := range readGateLog(t, logPath) {
+		if strings.Contains(dir, "speculative") {
+			t.Errorf("MaxConcurrent=1 should not speculate, gate ran in %s", dir)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package refinery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/github"
+)
+
+// PR states recorded on the MR bead (pr_state field) as the refinery drives a
+// pull request through its lifecycle under merge_strategy "pr".
+const (
+	PRStateDraft            = "draft"
+	PRStateChangesRequested = "changes_requested"
+	PRStateChecksFailed     = "checks_failed"
+	PRStateReady            = "ready"
+	PRStateMerged           = "merged"
+	PRStateClosed           = "closed"
+)
+
+// prRepo identifies the GitHub repository a rig lands work into, and the
+// head ref its branches are pushed under.
+type prRepo struct {
+	Owner     string
+	Repo      string
+	HeadOwner string // Fork owner when the rig pushes to a fork (PushURL)
+}
+
+// head returns the PR head ref for a branch, qualified with the fork owner
+// for cross-repo PRs.
+func (r prRepo) head(branch string) string {
+	if r.HeadOwner != "" && r.HeadOwner != r.Owner {
+		return r.HeadOwner + ":" + branch
+	}
+	return r.Owner + ":" + branch
+}
+
+// resolvePRRepo derives the upstream owner/repo from the rig's git URL
+// (falling back to the refinery worktree's origin) and the fork owner from
+// the rig's push URL.
+func (e *Engineer) resolvePRRepo() (prRepo, error) {
+	upstream := e.rig.GitURL
+	if upstream == "" {
+		url, err := e.git.RemoteURL("origin")
+		if err != nil {
+			return prRepo{}, fmt.Errorf("resolving origin URL: %w", err)
+		}
+		upstream = url
+	}
+	owner, repo, err := github.ParseRepoURL(upstream)
+	if err != nil {
+		return prRepo{}, err
+	}
+	ref := prRepo{Owner: owner, Repo: repo}
+	if e.rig.PushURL != "" {
+		if forkOwner, _, err := github.ParseRepoURL(e.rig.PushURL); err == nil {
+			ref.HeadOwner = forkOwner
+		}
+	}
+	return ref, nil
+}
+
+// doPRMerge lands an MR through a GitHub pull request instead of pushing to
+// the target directly. It is re-entrant: each call advances the PR as far as
+// GitHub allows and returns PRPending when it must wait for reviews or checks,
+// so the MR stays in the queue and is picked up again on the next poll.
+func (e *Engineer) doPRMerge(ctx context.Context, mr *MRInfo) ProcessResult {
+	if e.sourceIssueNoMerge(mr.SourceIssue) {
+		return ProcessResult{NoMerge: true, Error: "no_merge flag set on source issue"}
+	}
+
+	ref, err := e.resolvePRRepo()
+	if err != nil {
+		return ProcessResult{Error: fmt.Sprintf("merge_strategy pr: %v", err)}
+	}
+	client, err := e.newGitHubClient()
+	if err != nil {
+		return ProcessResult{Error: fmt.Sprintf("merge_strategy pr: %v", err)}
+	}
+
+	// The PR can only be opened once the branch is on the remote. Polecats
+	// normally push it in gt done, but the refinery owns remote pushes.
+	if mr.PRNumber == 0 {
+		exists, err := e.git.BranchExists(mr.Branch)
+		if err != nil {
+			return ProcessResult{Error: fmt.Sprintf("failed to check branch %s: %v", mr.Branch, err)}
+		}
+		if !exists {
+			return ProcessResult{
+				BranchNotFound: true,
+				Error:          fmt.Sprintf("branch %s not found locally", mr.Branch),
+			}
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Pushing %s to origin for PR...\n", mr.Branch)
+		if err := e.git.Push("origin", mr.Branch, false); err != nil {
+			return ProcessResult{Error: fmt.Sprintf("failed to push %s: %v", mr.Branch, err)}
+		}
+	}
+
+	return e.advancePR(ctx, client, ref, mr)
+}
+
+// advancePR drives the MR's pull request one step at a time:
+// open draft → wait for approval → wait for checks → ready → merge.
+// Every state change is written back to the MR bead; terminal outcomes are
+// also emitted as merged/merge_failed events.
+//
+// GitHub API and transport errors (outages, rate limits) are not the
+// polecat's to fix, so they leave the MR pending for the next poll. A PR
+// still in the failed state recorded by an earlier poll is also left pending,
+// so the failure is reported once rather than on every poll.
+func (e *Engineer) advancePR(ctx context.Context, client *github.Client, ref prRepo, mr *MRInfo) ProcessResult {
+	retry := func(err error) ProcessResult {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] GitHub error for %s, will retry next poll: %v\n", mr.ID, err)
+		return ProcessResult{PRPending: true, Error: err.Error(), PRNumber: mr.PRNumber, PRURL: mr.PRURL}
+	}
+	fail := func(state, msg string, result ProcessResult) ProcessResult {
+		if state != "" {
+			if mr.PRState == state {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] PR #%d still %s, already reported\n", mr.PRNumber, state)
+				return ProcessResult{PRPending: true, Error: msg, PRNumber: mr.PRNumber, PRURL: mr.PRURL}
+			}
+			e.recordPRState(mr, state)
+		}
+		result.Error = msg
+		result.PRNumber = mr.PRNumber
+		result.PRURL = mr.PRURL
+		e.logMergeEvent(events.TypeMergeFailed, mr, msg)
+		return result
+	}
+
+	// Step 1: Open the draft PR (or adopt one that already exists for the head).
+	if mr.PRNumber == 0 {
+		head := ref.head(mr.Branch)
+		existing, found, err := client.FindOpenPR(ctx, ref.Owner, ref.Repo, head)
+		if err != nil {
+			return retry(err)
+		}
+		if found {
+			mr.PRNumber, mr.PRURL = existing.Number, existing.URL
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Adopting existing PR #%d for %s\n", mr.PRNumber, mr.Branch)
+		} else {
+			created, err := client.CreateDraftPR(ctx, ref.Owner, ref.Repo, head, mr.Target, prTitle(mr), prBody(mr))
+			if err != nil {
+				return retry(err)
+			}
+			mr.PRNumber, mr.PRURL = created.Number, created.URL
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Opened draft PR #%d: %s\n", mr.PRNumber, mr.PRURL)
+		}
+		e.recordPRState(mr, PRStateDraft)
+	}
+
+	pending := ProcessResult{PRPending: true, PRNumber: mr.PRNumber, PRURL: mr.PRURL}
+
+	pr, err := client.GetPR(ctx, ref.Owner, ref.Repo, mr.PRNumber)
+	if err != nil {
+		return retry(err)
+	}
+	if pr.Merged {
+		// Merged out-of-band (e.g. by a human) — treat as landed.
+		return e.prMerged(mr, pr.MergeCommitSHA)
+	}
+	if pr.State == "closed" {
+		return fail(PRStateClosed, fmt.Sprintf("PR #%d was closed without merging", mr.PRNumber), ProcessResult{})
+	}
+
+	// Step 2: Wait for required reviews.
+	review, err := client.GetPRReviewStatus(ctx, ref.Owner, ref.Repo, mr.PRNumber)
+	if err != nil {
+		return retry(err)
+	}
+	switch review {
+	case github.ReviewChangesRequired:
+		return fail(PRStateChangesRequested,
+			fmt.Sprintf("changes requested on PR #%d", mr.PRNumber),
+			ProcessResult{ChangesRequested: true})
+	case github.ReviewApproved:
+	default:
+		_, _ = fmt.Fprintf(e.output, "[Engineer] PR #%d awaiting review\n", mr.PRNumber)
+		return pending
+	}
+
+	// Step 3: Wait for checks on the PR head.
+	checks, err := client.GetCheckState(ctx, ref.Owner, ref.Repo, pr.HeadSHA)
+	if err != nil {
+		return retry(err)
+	}
+	switch checks {
+	case github.ChecksFailure:
+		return fail(PRStateChecksFailed,
+			fmt.Sprintf("checks failed on PR #%d", mr.PRNumber),
+			ProcessResult{TestsFailed: true})
+	case github.ChecksPending:
+		_, _ = fmt.Fprintf(e.output, "[Engineer] PR #%d awaiting checks\n", mr.PRNumber)
+		return pending
+	}
+
+	// Step 4: Convert to ready for review.
+	if pr.Draft {
+		if err := client.ConvertDraftToReady(ctx, ref.Owner, ref.Repo, mr.PRNumber); err != nil {
+			return retry(err)
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] PR #%d marked ready for review\n", mr.PRNumber)
+	}
+	e.recordPRState(mr, PRStateReady)
+
+	// Step 5: Merge with the repo's configured method.
+	method, err := client.GetRepoMergeMethod(ctx, ref.Owner, ref.Repo)
+	if err != nil {
+		return retry(err)
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Merging PR #%d (%s)...\n", mr.PRNumber, method)
+	if err := client.MergePR(ctx, ref.Owner, ref.Repo, mr.PRNumber, method); err != nil {
+		// 405/409: GitHub refused (branch protection, stale head, conflict).
+		// 405 is typically a requirement not yet met — wait rather than fail.
+		var apiErr *github.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusMethodNotAllowed {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] PR #%d not yet mergeable: %v\n", mr.PRNumber, err)
+			return pending
+		}
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			return fail("", fmt.Sprintf("PR #%d has merge conflicts: %v", mr.PRNumber, err),
+				ProcessResult{Conflict: true})
+		}
+		return retry(err)
+	}
+
+	merged, err := client.GetPR(ctx, ref.Owner, ref.Repo, mr.PRNumber)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: could not read merge commit for PR #%d: %v\n", mr.PRNumber, err)
+	}
+	return e.prMerged(mr, merged.MergeCommitSHA)
+}
+
+// prMerged records the merged state and builds the success result.
+func (e *Engineer) prMerged(mr *MRInfo, mergeCommit string) ProcessResult {
+	e.recordPRState(mr, PRStateMerged)
+	e.logMergeEvent(events.TypeMerged, mr, "")
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Successfully merged PR #%d: %s\n", mr.PRNumber, shortSHA(mergeCommit))
+	return ProcessResult{
+		Success:     true,
+		MergeCommit: mergeCommit,
+		PRNumber:    mr.PRNumber,
+		PRURL:       mr.PRURL,
+	}
+}
+
+// recordPRState writes the PR number, URL and state onto the MR bead.
+// Unchanged states are skipped so re-polling a waiting PR does not churn the bead.
+func (e *Engineer) recordPRState(mr *MRInfo, state string) {
+	if mr.PRState == state {
+		return
+	}
+	mr.PRState = state
+	if mr.ID == "" || e.updateMRFields == nil {
+		return
+	}
+	err := e.updateMRFields(mr.ID, func(f *beads.MRFields) {
+		f.PRNumber = mr.PRNumber
+		f.PRURL = mr.PRURL
+		f.PRState = state
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to record PR state %s on %s: %v\n", state, mr.ID, err)
+	}
+}
+
+// logMergeEvent emits a merge queue event annotated with the source bead
+// and PR details. The bead lets ETA forecasting measure sling-to-merge time.
+func (e *Engineer) logMergeEvent(eventType string, mr *MRInfo, reason string) {
+	payload := events.MergePayload(mr.ID, mr.Worker, mr.Branch, reason)
+	if mr.SourceIssue != "" {
+		payload["bead"] = mr.SourceIssue
+	}
+	if mr.PRNumber > 0 {
+		payload["pr"] = mr.PRNumber
+		payload["pr_url"] = mr.PRURL
+	}
+	if mr.PRState != "" {
+		payload["pr_state"] = mr.PRState
+	}
+	_ = events.LogFeed(eventType, e.rig.Name+"/refinery", payload)
+}
+
+// updateMRBeadFields applies update to the MR fields stored in a bead's
+// description and writes the result back.
+func updateMRBeadFields(b *beads.Beads, mrID string, update func(*beads.MRFields)) error {
+	issue, err := b.Show(mrID)
+	if err != nil {
+		return err
+	}
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		fields = &beads.MRFields{}
+	}
+	update(fields)
+	desc := beads.SetMRFields(issue, fields)
+	return b.Update(mrID, beads.UpdateOptions{Description: &desc})
+}
+
+// prTitle returns the PR title for an MR.
+func prTitle(mr *MRInfo) string {
+	if t := strings.TrimSpace(mr.Title); t != "" {
+		return t
+	}
+	if mr.SourceIssue != "" {
+		return fmt.Sprintf("Merge %s (%s)", mr.Branch, mr.SourceIssue)
+	}
+	return "Merge " + mr.Branch
+}
+
+// prBody returns the PR description, linking back to the Gas Town beads.
+func prBody(mr *MRInfo) string {
+	var sb strings.Builder
+	sb.WriteString("Opened by the Gas Town refinery.\n\n")
+	fmt.Fprintf(&sb, "- MR: %s\n", mr.ID)
+	if mr.SourceIssue != "" {
+		fmt.Fprintf(&sb, "- Source issue: %s\n", mr.SourceIssue)
+	}
+	if mr.Worker != "" {
+		fmt.Fprintf(&sb, "- Worker: %s\n", mr.Worker)
+	}
+	if mr.ConvoyID != "" {
+		fmt.Fprintf(&sb, "- Convoy: %s\n", mr.ConvoyID)
+	}
+	return sb.String()
+}
@@ -0,0 +1,407 @@
+package refinery
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/github"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// fakeGitHub is an httptest stand-in for the subset of the GitHub API the
+// PR merge strategy uses.
+type fakeGitHub struct {
+	existingPR  bool
+	draft       bool
+	review      string // latest review state, "" = no reviews
+	checkStatus string // combined status state
+	merged      bool
+	mergeStatus int // status code for the merge call (0 = 200)
+	prStatus    int // status code for reading the PR (0 = 200)
+
+	createCalls int
+	readyCalls  int
+	mergeMethod string
+}
+
+func (f *fakeGitHub) handler(t *testing.T) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/octo/repo/pulls", func(w http.ResponseWriter, _ *http.Request) {
+		if !f.existingPR {
+			_ = json.NewEncoder(w).Encode([]any{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"number": 9, "html_url": "https://github.com/octo/repo/pull/9", "state": "open", "draft": f.draft},
+		})
+	})
+	mux.HandleFunc("POST /repos/octo/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		f.createCalls++
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["head"] != "octo:polecat/nux/gt-1" || body["draft"] != true {
+			t.Errorf("unexpected create body: %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"number": 42, "html_url": "https://github.com/octo/repo/pull/42"})
+	})
+	prHandler := func(w http.ResponseWriter, r *http.Request) {
+		if f.prStatus != 0 {
+			w.WriteHeader(f.prStatus)
+			_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"number":           42,
+			"node_id":          "PR_node",
+			"state":            "open",
+			"draft":            f.draft,
+			"merged":           f.merged,
+			"merge_commit_sha": "feedface1234",
+			"head":             map[string]any{"sha": "headsha"},
+		})
+	}
+	mux.HandleFunc("GET /repos/octo/repo/pulls/42", prHandler)
+	mux.HandleFunc("GET /repos/octo/repo/pulls/9", prHandler)
+	mux.HandleFunc("GET /repos/octo/repo/pulls/42/reviews", func(w http.ResponseWriter, _ *http.Request) {
+		if f.review == "" {
+			_ = json.NewEncoder(w).Encode([]any{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"state": f.review, "user": map[string]any{"login": "reviewer"}},
+		})
+	})
+	mux.HandleFunc("GET /repos/octo/repo/commits/headsha/status", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"state": f.checkStatus, "total_count": 1})
+	})
+	mux.HandleFunc("GET /repos/octo/repo/commits/headsha/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"check_runs": []any{}})
+	})
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, _ *http.Request) {
+		f.readyCalls++
+		f.draft = false
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{}})
+	})
+	mux.HandleFunc("GET /repos/octo/repo", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"allow_squash_merge": false, "allow_rebase_merge": true})
+	})
+	mux.HandleFunc("PUT /repos/octo/repo/pulls/42/merge", func(w http.ResponseWriter, r *http.Request) {
+		if f.mergeStatus != 0 {
+			w.WriteHeader(f.mergeStatus)
+			_, _ = w.Write([]byte(`{"message":"not mergeable"}`))
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.mergeMethod, _ = body["merge_method"].(string)
+		f.merged = true
+		_ = json.NewEncoder(w).Encode(map[string]any{"merged": true})
+	})
+	return mux
+}
+
+// newPRTestEngineer returns an Engineer wired to a fake GitHub and an
+// in-memory MR field store.
+func newPRTestEngineer(t *testing.T, gh *fakeGitHub) (*Engineer, *github.Client, map[string]*beads.MRFields) {
+	t.Helper()
+	// Keep the merged/merge_failed events out of any enclosing workspace.
+	t.Chdir(t.TempDir())
+	srv := httptest.NewServer(gh.handler(t))
+	t.Cleanup(srv.Close)
+	client, err := github.NewClient(
+		github.WithToken("test-token"),
+		github.WithHTTPClient(srv.Client()),
+		github.WithRESTBase(srv.URL),
+		github.WithGraphQLBase(srv.URL+"/graphql"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := map[string]*beads.MRFields{}
+	e := &Engineer{
+		rig:    &rig.Rig{Name: "testrig", GitURL: "https://github.com/octo/repo.git"},
+		output: io.Discard,
+		config: &MergeQueueConfig{MergeStrategy: MergeStrategyPR},
+		updateMRFields: func(mrID string, update func(*beads.MRFields)) error {
+			f := store[mrID]
+			if f == nil {
+				f = &beads.MRFields{}
+				store[mrID] = f
+			}
+			update(f)
+			return nil
+		},
+	}
+	return e, client, store
+}
+
+func testPRRef(t *testing.T, e *Engineer) prRepo {
+	t.Helper()
+	ref, err := e.resolvePRRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ref
+}
+
+func TestAdvancePR_OpensDraftAndWaitsForReview(t *testing.T) {
+	gh := &fakeGitHub{draft: true}
+	e, client, store := newPRTestEngineer(t, gh)
+	mr := &MRInfo{ID: "gt-mr1", Branch: "polecat/nux/gt-1", Target: "main", SourceIssue: "gt-1"}
+
+	result := e.advancePR(t.Context(), client, testPRRef(t, e), mr)
+
+	if !result.PRPending || result.Success {
+		t.Fatalf("expected pending result, got %+v", result)
+	}
+	if gh.createCalls != 1 {
+		t.Errorf("expected 1 create call, got %d", gh.createCalls)
+	}
+	got := store["gt-mr1"]
+	if got == nil || got.PRNumber != 42 || got.PRState != PRStateDraft {
+		t.Errorf("expected MR bead to record draft PR #42, got %+v", got)
+	}
+}
+
+func TestAdvancePR_ApprovedAndGreenMerges(t *testing.T) {
+	gh := &fakeGitHub{draft: true, review: "APPROVED", checkStatus: "success"}
+	e, client, store := newPRTestEngineer(t, gh)
+	mr := &MRInfo{ID: "gt-mr1", Branch: "polecat/nux/gt-1", Target: "main", PRNumber: 42, PRState: PRStateDraft}
+
+	result := e.advancePR(t.Context(), client, testPRRef(t, e), mr)
+
+	if !result.Success {
+		t.Fatalf("expected success, got %+v", result)
+	}
+	if result.MergeCommit != "feedface1234" {
+		t.Errorf("MergeCommit = %q, want feedface1234", result.MergeCommit)
+	}
+	if gh.createCalls != 0 {
+		t.Errorf("expected no create call for existing PR, got %d", gh.createCalls)
+	}
+	if gh.readyCalls != 1 {
+		t.Errorf("expected draft to be converted to ready once, got %d", gh.readyCalls)
+	}
+	if gh.mergeMethod != "rebase" {
+		t.Errorf("expected repo merge method rebase, got %q", gh.mergeMethod)
+	}
+	if got := store["gt-mr1"]; got == nil || got.PRState != PRStateMerged {
+		t.Errorf("expected MR bead state merged, got %+v", got)
+	}
+}
+
+func TestAdvancePR_WaitsForChecks(t *testing.T) {
+	gh := &fakeGitHub{draft: true, review: "APPROVED", checkStatus: "pending"}
+	e, client, _ := newPRTestEngineer(t, gh)
+	mr := &MRInfo{ID: "gt-mr1", Branch: "polecat/nux/gt-1", Target: "main", PRNumber: 42, PRState: PRStateDraft}
+
+	result := e.advancePR(t.Context(), client, testPRRef(t, e), mr)
+
+	if !result.PRPending {
+		t.Fatalf("expected pending result while checks run, got %+v", result)
+	}
+	if gh.readyCalls != 0 {
+		t.Error("draft should not be marked ready before checks pass")
+	}
+}
+
+func TestAdvancePR_ChangesRequestedFails(t *testing.T) {
+	gh := &fakeGitHub{draft: true, review: "CHANGES_REQUESTED"}
+	e, client, store := newPRTestEngineer(t, gh)
+	mr := &MRInfo{ID: "gt-mr1", Branch: "polecat/nux/gt-1", Target: "main", PRNumber: 42, PRState: PRStateDraft}
+
+	result := e.advancePR(t.Context(), client, testPRRef(t, e), mr)
+
+	if result.Success || result.PRPending || !result.ChangesRequested {
+		t.Fatalf("expected changes-requested failure, got %+v", result)
+	}
+	if got := store["gt-mr1"]; got == nil || got.PRState != PRStateChangesRequested {
+		t.Errorf("expected MR bead state changes_requested, got %+v", got)
+	}
+}
+
+func TestAdvancePR_ChangesRequestedReportedOnce(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a Unix shell script mock for gt")
+	}
+	gh := &fakeGitHub{draft: true, review: "CHANGES_REQUESTED"}
+	e, client, _ := newPRTestEngineer(t, gh)
+
+	// A town for the merge events, and a gt that records its nudges.
+	town := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(town, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(town, "mayor", "town.json"), []byte(`{"type":"town","name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(town)
+	binDir := t.TempDir()
+	nudgeLog := filepath.Join(binDir, "nudges.log")
+	script := "#!/bin/sh\necho \"$1 $2\" >> " + nudgeLog + "\n"
+	if err := os.WriteFile(filepath.Join(binDir, "gt"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	mr := &MRInfo{ID: "gt-mr1", Branch: "polecat/nux/gt-1", Target: "main", Worker: "polecats/nux", PRNumber: 42, PRState: PRStateDraft}
+	ref := testPRRef(t, e)
+	for poll := 1; poll <= 2; poll++ {
+		result := e.advancePR(t.Context(), client, ref, mr)
+		if result.Success {
+			t.Fatalf("poll %d: unexpected success", poll)
+		}
+		if !result.PRPending {
+			e.HandleMRInfoFailure(mr, result)
+		} else if poll == 1 {
+			t.Fatalf("poll 1: expected changes-requested failure, got %+v", result)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(town, events.EventsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), `"type":"`+events.TypeMergeFailed+`"`); n != 1 {
+		t.Errorf("merge_failed events = %d, want 1:\n%s", n, data)
+	}
+	nudges, err := os.ReadFile(nudgeLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(nudges), "nudge testrig/nux"); n != 1 {
+		t.Errorf("polecat nudges = %d, want 1:\n%s", n, nudges)
+	}
+}
+
+func TestAdvancePR_APIErrorRetries(t *testing.T) {
+	gh := &fakeGitHub{prStatus: http.StatusForbidden}
+	e, client, store := newPRTestEngineer(t, gh)
+	mr := &MRInfo{ID: "gt-mr1", Branch: "polecat/nux/gt-1", Target: "main", PRNumber: 42, PRState: PRStateDraft}
+
+	result := e.advancePR(t.Context(), client, testPRRef(t, e), mr)
+
+	if !result.PRPending || result.TestsFailed || result.ChangesRequested {
+		t.Fatalf("expected API error to leave the PR pending, got %+v", result)
+	}
+	if got := store["gt-mr1"]; got != nil {
+		t.Errorf("API error should not change the MR bead, got %+v", got)
+	}
+}
+
+func TestAdvancePR_FailedChecks(t *testing.T) {
+	gh := &fakeGitHub{review: "APPROVED", checkStatus: "failure"}
+	e, client, _ := newPRTestEngineer(t, gh)
+	mr := &MRInfo{ID: "gt-mr1", Branch: "polecat/nux/gt-1", Target: "main", PRNumber: 42, PRState: PRStateDraft}
+
+	result := e.advancePR(t.Context(), client, testPRRef(t, e), mr)
+
+	if result.Success || !result.TestsFailed {
+		t.Fatalf("expected tests-failed result, got %+v", result)
+	}
+}
+
+func TestAdvancePR_NotYetMergeableStaysPending(t *testing.T) {
+	gh := &fakeGitHub{review: "APPROVED", checkStatus: "success", mergeStatus: http.StatusMethodNotAllowed}
+	e, client, _ := newPRTestEngineer(t, gh)
+	mr := &MRInfo{ID: "gt-mr1", Branch: "polecat/nux/gt-1", Target: "main", PRNumber: 42, PRState: PRStateReady}
+
+	result := e.advancePR(t.Context(), client, testPRRef(t, e), mr)
+
+	if !result.PRPending {
+		t.Fatalf("expected pending result on 405, got %+v", result)
+	}
+}
+
+func TestResolvePRRepo_ForkHead(t *testing.T) {
+	e := &Engineer{rig: &rig.Rig{
+		GitURL:  "https://github.com/upstream/repo.git",
+		PushURL: "git@github.com:me/repo.git",
+	}}
+	ref, err := e.resolvePRRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Owner != "upstream" || ref.Repo != "repo" {
+		t.Errorf("unexpected repo %+v", ref)
+	}
+	if got := ref.head("polecat/nux"); got != "me:polecat/nux" {
+		t.Errorf("head = %q, want me:polecat/nux", got)
+	}
+}
+
+func TestEngineer_LoadConfig_Batch(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := map[string]any{"merge_queue": map[string]any{
+		"max_concurrent": 3,
+		"batch":          map[string]any{"max_batch_size": 4, "retry_batch_on_flaky": false},
+	}}
+	data, _ := json.Marshal(cfg)
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	if err := e.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	b := e.Config().Batch
+	if b == nil || b.MaxBatchSize != 4 || b.RetryBatchOnFlaky || b.BatchWaitTime != DefaultBatchConfig().BatchWaitTime {
+		t.Errorf("Batch = %+v", b)
+	}
+	if e.Config().MaxConcurrent != 3 {
+		t.Errorf("MaxConcurrent = %d, want 3", e.Config().MaxConcurrent)
+	}
+
+	cfg["merge_queue"] = map[string]any{"batch": map[string]any{"max_batch_size": 0}}
+	data, _ = json.Marshal(cfg)
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("expected error for max_batch_size 0")
+	}
+}
+
+func TestEngineer_LoadConfig_MergeStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		strategy string
+		wantErr  bool
+	}{
+		{strategy: "pr"},
+		{strategy: "direct"},
+		{strategy: "rebase", wantErr: true},
+	} {
+		tmpDir := t.TempDir()
+		cfg := map[string]any{"merge_queue": map[string]any{"merge_strategy": tc.strategy}}
+		data, _ := json.Marshal(cfg)
+		if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+		err := e.LoadConfig()
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("merge_strategy %q: expected error", tc.strategy)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("merge_strategy %q: %v", tc.strategy, err)
+		}
+		if e.Config().MergeStrategy != tc.strategy {
+			t.Errorf("MergeStrategy = %q, want %q", e.Config().MergeStrategy, tc.strategy)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cgroupRoot is where the cgroup v2 hierarchy is mounted, and procSelfCgroup
+// names the calling process's cgroup. Tests point them at temp files.
+var (
+	cgroupRoot     = "/sys/fs/cgroup"
+	procSelfCgroup = "/proc/self/cgroup"
+)
+
+// cpuPeriod is the cpu.max period, in microseconds.
+const cpuPeriod = 100000
+
+// pageSize is the granularity of memory.max.
+const pageSize = 4096
+
+// cgroup is the cgroup a sandbox runs in, and the one it came from.
+type cgroup struct {
+	dir  string
+	prev string
+}
+
+// ownCgroup returns the calling process's cgroup v2 path, e.g.
+// "/user.slice/user-1000.slice/session-2.scope".
+func ownCgroup() (string, error) {
+	data, err := os.ReadFile(procSelfCgroup)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if p, ok := strings.CutPrefix(line, "0::"); ok {
+			return p, nil
+		}
+	}
+	return "", errors.New("cgroup v2 is not in use (no unified entry in /proc/self/cgroup)")
+}
+
+// cgroupLimits returns the cgroup v2 interface files and values that
+// enforce the spec's resource limits.
+func cgroupLimits(spec *Spec) map[string]string {
+	limits := make(map[string]string)
+	if spec.MemoryBytes > 0 {
+		// The kernel rounds memory.max down to whole pages.
+		limits["memory.max"] = strconv.FormatInt(spec.MemoryBytes&^(pageSize-1), 10)
+	}
+	if spec.CPUs > 0 {
+		limits["cpu.max"] = fmt.Sprintf("%d %d", int64(spec.CPUs*cpuPeriod), cpuPeriod)
+	}
+	if spec.Pids > 0 {
+		limits["pids.max"] = strconv.Itoa(spec.Pids)
+	}
+	return limits
+}
+
+// joinCgroup moves the calling process into a new cgroup carrying the spec's
+// limits, so everything it starts inherits them. The cgroup is created next
+// to the caller's own: a cgroup that has processes can't also delegate
+// controllers to children. It returns nil if the spec sets no limits.
+func joinCgroup(spec *Spec) (*cgroup, error) {
+	limits := cgroupLimits(spec)
+	if len(limits) == 0 {
+		return nil, nil
+	}
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return nil, fmt.Errorf("resource limits need cgroup v2 mounted at %s", cgroupRoot)
+	}
+	own, err := ownCgroup()
+	if err != nil {
+		return nil, err
+	}
+	parentDir := filepath.Join(cgroupRoot, path.Dir(own))
+
+	available := readFields(filepath.Join(parentDir, "cgroup.controllers"))
+	enabled := readFields(filepath.Join(parentDir, "cgroup.subtree_control"))
+	for file := range limits {
+		controller, _, _ := strings.Cut(file, ".")
+		if !available[controller] {
+			return nil, fmt.Errorf("cgroup controller %q is not available in %s (is it delegated to this user?)", controller, parentDir)
+		}
+		if enabled[controller] {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(parentDir, "cgroup.subtree_control"), []byte("+"+controller), 0644); err != nil { //nolint:gosec // cgroupfs interface file
+			return nil, fmt.Errorf("enabling %s controller in %s: %w", controller, parentDir, err)
+		}
+	}
+
+	dir := filepath.Join(parentDir, "gt-sandbox-"+spec.Rig+"-"+spec.Polecat)
+	if err := os.Mkdir(dir, 0755); err != nil && !os.IsExist(err) {
+		return nil, fmt.Errorf("creating cgroup: %w", err)
+	}
+	if procs, _ := os.ReadFile(filepath.Join(dir, "cgroup.procs")); len(strings.TrimSpace(string(procs))) > 0 { //nolint:gosec // cgroupfs interface file
+		return nil, fmt.Errorf("cgroup %s is in use: is %s/%s already running?", dir, spec.Rig, spec.Polecat)
+	}
+	for file, value := range limits {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(value), 0644); err != nil { //nolint:gosec // cgroupfs interface file
+			return nil, fmt.Errorf("setting %s: %w", file, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cgroup.procs"), []byte(strconv.Itoa(os.Getpid())), 0644); err != nil { //nolint:gosec // cgroupfs interface file
+		return nil, fmt.Errorf("joining cgroup %s: %w", dir, err)
+	}
+	return &cgroup{dir: dir, prev: filepath.Join(cgroupRoot, own)}, nil
+}
+
+// leave moves the calling process back to its original cgroup and removes
+// the sandbox cgroup. Errors are ignored: the kernel refuses to remove a
+// cgroup that still has processes, and an empty leftover is harmless.
+func (c *cgroup) leave() {
+	if c == nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(c.prev, "cgroup.procs"), []byte(strconv.Itoa(os.Getpid())), 0644) //nolint:gosec // cgroupfs interface file
+	_ = os.Remove(c.dir)
+}
+
+// currentLimits reads the limit files of the calling process's cgroup.
+func currentLimits() (map[string]string, error) {
+	own, err := ownCgroup()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(cgroupRoot, own)
+	limits := make(map[string]string)
+	for _, file := range []string{"memory.max", "cpu.max", "pids.max"} {
+		if data, err := os.ReadFile(filepath.Join(dir, file)); err == nil { //nolint:gosec // cgroupfs interface file
+			limits[file] = strings.TrimSpace(string(data))
+		}
+	}
+	return limits, nil
+}
+
+// readFields reads a space-separated cgroupfs list into a set.
+func readFields(path string) map[string]bool {
+	set := make(map[string]bool)
+	data, err := os.ReadFile(path) //nolint:gosec // cgroupfs interface file
+	if err != nil {
+		return set
+	}
+	for _, f := range strings.Fields(string(data)) {
+		set[f] = true
+	}
+	return set
+}
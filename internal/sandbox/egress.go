@@ -0,0 +1,140 @@
+package sandbox
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// egressDialTimeout bounds how long the egress proxy waits for an upstream.
+const egressDialTimeout = 10 * time.Second
+
+// EgressProxy is an HTTP CONNECT proxy that only tunnels to allow-listed
+// host:port destinations. It runs outside the sandbox; agents reach it
+// through HTTPS_PROXY.
+type EgressProxy struct {
+	allow []string
+	dial  func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// NewEgressProxy returns a proxy allowing the given host:port entries. A
+// leading "*." on the host matches any subdomain.
+func NewEgressProxy(allow []string) *EgressProxy {
+	d := &net.Dialer{Timeout: egressDialTimeout}
+	return &EgressProxy{allow: allow, dial: d.DialContext}
+}
+
+// Allowed reports whether hostport may be tunnelled to.
+func (p *EgressProxy) Allowed(hostport string) bool {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return false
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, entry := range p.allow {
+		h, pt, err := net.SplitHostPort(entry)
+		if err != nil || pt != port {
+			continue
+		}
+		h = strings.ToLower(h)
+		if h == host {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(h, "*"); ok && strings.HasPrefix(suffix, ".") && strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Serve accepts connections on l until it is closed.
+func (p *EgressProxy) Serve(l net.Listener) error {
+	srv := &http.Server{Handler: p, ReadHeaderTimeout: 10 * time.Second}
+	return srv.Serve(l)
+}
+
+// ServeHTTP tunnels CONNECT requests to allowed destinations.
+func (p *EgressProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodConnect {
+		http.Error(w, "sandbox egress proxy only supports CONNECT", http.StatusMethodNotAllowed)
+		return
+	}
+	if !p.Allowed(r.Host) {
+		http.Error(w, "destination not allowed by sandbox profile: "+r.Host, http.StatusForbidden)
+		return
+	}
+	upstream, err := p.dial(r.Context(), "tcp", r.Host)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close()
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, buf, err := hj.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	if _, err := conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		conn.Close()
+		upstream.Close()
+		return
+	}
+	// Bytes the client sent after the CONNECT headers are already buffered.
+	if n := buf.Reader.Buffered(); n > 0 {
+		pending, _ := buf.Reader.Peek(n)
+		if _, err := upstream.Write(pending); err != nil {
+			conn.Close()
+			upstream.Close()
+			return
+		}
+	}
+	pipe(conn, upstream)
+}
+
+// forward accepts connections on l and pipes each to a connection from dial,
+// until l is closed.
+func forward(l net.Listener, dial func() (net.Conn, error)) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			upstream, err := dial()
+			if err != nil {
+				conn.Close()
+				return
+			}
+			pipe(conn, upstream)
+		}()
+	}
+}
+
+// pipe copies between a and b until both directions finish, then closes both.
+func pipe(a, b net.Conn) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	copyHalf := func(dst, src net.Conn) {
+		defer wg.Done()
+		_, _ = io.Copy(dst, src)
+		if cw, ok := dst.(interface{ CloseWrite() error }); ok {
+			_ = cw.CloseWrite()
+		} else {
+			dst.Close()
+		}
+	}
+	go copyHalf(a, b)
+	go copyHalf(b, a)
+	wg.Wait()
+	a.Close()
+	b.Close()
+}
@@ -0,0 +1,397 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/proxy"
+)
+
+// initCommand re-executes the running gt binary as the sandbox's init
+// process. Tests point it at the test binary.
+var initCommand = []string{"/proc/self/exe", "sandbox", "init", "--"}
+
+// socketDirFD is the descriptor on which Init receives the directory holding
+// the host-side sockets. The directory is reached through /proc/self/fd so
+// the sockets stay reachable after /tmp is replaced.
+const socketDirFD = 3
+
+// forwardedSignals are passed from the launcher to the sandbox and on to
+// the agent.
+var forwardedSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT}
+
+// Exec runs argv inside the sandbox described by spec and returns its exit
+// status. It fails without starting argv if any part of the sandbox can't
+// be set up.
+func Exec(ctx context.Context, spec *Spec, argv []string) (int, error) {
+	if len(argv) == 0 {
+		return 0, errors.New("no command to run")
+	}
+	if err := os.MkdirAll(spec.RuntimeDir, 0700); err != nil {
+		return 0, fmt.Errorf("creating sandbox runtime dir: %w", err)
+	}
+	if spec.GitCommonDir != "" {
+		for _, dir := range []string{"upper", "work"} {
+			if err := os.MkdirAll(filepath.Join(spec.gitLayerDir(), dir), 0700); err != nil {
+				return 0, fmt.Errorf("creating git overlay layer: %w", err)
+			}
+		}
+		spec.ReadWrite = append(spec.ReadWrite, spec.gitLayerDir())
+	}
+
+	realGT := ""
+	if spec.Network != config.SandboxNetworkNone {
+		if err := issueCert(ctx, spec); err != nil {
+			return 0, err
+		}
+		spec.ReadWrite = append(spec.ReadWrite, spec.certDir())
+		if spec.Network == config.SandboxNetworkProxy {
+			spec.ProxyURL = "https://" + ProxyAddr
+		} else {
+			spec.ProxyURL = "https://" + proxy.TownListenAddr(spec.TownRoot)
+		}
+		shimmed, err := installShims(spec)
+		if err != nil {
+			return 0, fmt.Errorf("installing gt/bd shims: %w", err)
+		}
+		if shimmed {
+			realGT, _ = os.Executable()
+		} else {
+			fmt.Fprintf(os.Stderr, "gt sandbox: warning: %s not found; gt and bd inside the sandbox cannot write\n", proxyClientBinary)
+		}
+	}
+
+	sockDir, err := os.MkdirTemp("", "gt-sandbox-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(sockDir)
+	if spec.Network == config.SandboxNetworkProxy {
+		closeSockets, err := serveHostSockets(spec, sockDir)
+		if err != nil {
+			return 0, err
+		}
+		defer closeSockets()
+	}
+	dir, err := os.Open(sockDir)
+	if err != nil {
+		return 0, err
+	}
+	defer dir.Close()
+
+	cg, err := joinCgroup(spec)
+	if err != nil {
+		return 0, fmt.Errorf("applying resource limits: %w", err)
+	}
+	defer cg.leave()
+
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		return 0, err
+	}
+	env := os.Environ()
+	for k, v := range agentEnv(spec, realGT) {
+		env = append(env, k+"="+v)
+	}
+	env = append(env, SpecEnv+"="+string(encoded))
+
+	cloneFlags := uintptr(syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS)
+	if spec.Network != config.SandboxNetworkHost {
+		cloneFlags |= syscall.CLONE_NEWNET
+	}
+	uid, gid := os.Getuid(), os.Getgid()
+	cmd := exec.Command(initCommand[0], append(initCommand[1:], argv...)...) //nolint:gosec // re-exec of our own binary
+	cmd.Env = env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.ExtraFiles = []*os.File{dir}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:                 cloneFlags,
+		UidMappings:                []syscall.SysProcIDMap{{ContainerID: uid, HostID: uid, Size: 1}},
+		GidMappings:                []syscall.SysProcIDMap{{ContainerID: gid, HostID: gid, Size: 1}},
+		GidMappingsEnableSetgroups: false,
+		Pdeathsig:                  syscall.SIGKILL,
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("entering sandbox (are unprivileged user namespaces enabled?): %w", err)
+	}
+	return waitForwardingSignals(cmd)
+}
+
+// serveHostSockets listens on the sandbox's proxy and egress sockets in
+// sockDir: the first forwards to gt-proxy-server, the second is the egress
+// allow-list proxy.
+func serveHostSockets(spec *Spec, sockDir string) (func(), error) {
+	proxyL, err := net.Listen("unix", filepath.Join(sockDir, proxySocket))
+	if err != nil {
+		return nil, err
+	}
+	egressL, err := net.Listen("unix", filepath.Join(sockDir, egressSocket))
+	if err != nil {
+		proxyL.Close()
+		return nil, err
+	}
+	upstream := proxy.TownListenAddr(spec.TownRoot)
+	go forward(proxyL, func() (net.Conn, error) {
+		return net.DialTimeout("tcp", upstream, egressDialTimeout)
+	})
+	go func() { _ = NewEgressProxy(spec.EgressAllow).Serve(egressL) }()
+	return func() {
+		proxyL.Close()
+		egressL.Close()
+	}, nil
+}
+
+// waitForwardingSignals waits for cmd, relaying termination signals to it,
+// and returns its exit status.
+func waitForwardingSignals(cmd *exec.Cmd) (int, error) {
+	sigs := make(chan os.Signal, 4)
+	signal.Notify(sigs, forwardedSignals...)
+	defer signal.Stop(sigs)
+	go func() {
+		for sig := range sigs {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+	err := cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// Init runs inside the namespaces Exec created. It locks down the
+// filesystem and network as the spec in SpecEnv describes, then runs argv
+// and returns its exit status.
+func Init(argv []string) (int, error) {
+	if len(argv) == 0 {
+		return 0, errors.New("no command to run")
+	}
+	var spec Spec
+	if err := json.Unmarshal([]byte(os.Getenv(SpecEnv)), &spec); err != nil {
+		return 0, fmt.Errorf("reading %s: %w", SpecEnv, err)
+	}
+	unix.CloseOnExec(socketDirFD)
+
+	if err := lockFilesystem(&spec); err != nil {
+		return 0, fmt.Errorf("filesystem: %w", err)
+	}
+	if spec.Network != config.SandboxNetworkHost {
+		if err := loopbackUp(); err != nil {
+			return 0, fmt.Errorf("network: %w", err)
+		}
+	}
+	if spec.Network == config.SandboxNetworkProxy {
+		if err := serveLoopbackForwarders(); err != nil {
+			return 0, fmt.Errorf("network: %w", err)
+		}
+	}
+
+	if spec.ProxyURL != "" {
+		if _, err := os.Stat(filepath.Join(spec.shimDir(), "gt")); err == nil {
+			os.Setenv("PATH", spec.shimDir()+string(os.PathListSeparator)+os.Getenv("PATH"))
+		}
+	}
+	path, err := exec.LookPath(argv[0])
+	if err != nil {
+		return 127, err
+	}
+	cmd := exec.Command(path, argv[1:]...) //nolint:gosec // argv is the agent command from the startup command
+	cmd.Args[0] = argv[0]
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Start(); err != nil {
+		return 126, err
+	}
+	return waitForwardingSignals(cmd)
+}
+
+// lockFilesystem makes every mount read-only, then reopens the spec's
+// writable paths, overlays the shared git directory, masks its hidden paths
+// and gives the sandbox a private /tmp. /dev and /proc stay writable where
+// the kernel allows, so terminals and process tools work.
+func lockFilesystem(spec *Spec) error {
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("making mounts private: %w", err)
+	}
+	// Clone the writable trees before anything is made read-only or hidden:
+	// a writable path may live under /tmp, which is replaced below.
+	clones := make([]int, len(spec.ReadWrite))
+	isDir := make([]bool, len(spec.ReadWrite))
+	for i, path := range spec.ReadWrite {
+		if info, err := os.Stat(path); err == nil {
+			isDir[i] = info.IsDir()
+		}
+		fd, err := unix.OpenTree(unix.AT_FDCWD, path, unix.OPEN_TREE_CLONE|unix.OPEN_TREE_CLOEXEC|unix.AT_RECURSIVE)
+		if err != nil {
+			return fmt.Errorf("cloning %s: %w", path, err)
+		}
+		defer unix.Close(fd)
+		clones[i] = fd
+	}
+
+	if err := setReadOnly("/", true); err != nil {
+		return err
+	}
+	for _, dir := range []string{"/dev", "/proc"} {
+		_ = setReadOnly(dir, false)
+	}
+	if err := unix.Mount("tmpfs", "/tmp", "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=1777"); err != nil {
+		return fmt.Errorf("mounting /tmp: %w", err)
+	}
+
+	// The worktree's own git directory lives inside the shared one, so it
+	// is mounted after the overlay, on top of it.
+	mountWritable := func(underGitCommonDir bool) error {
+		for i, path := range spec.ReadWrite {
+			if (spec.GitCommonDir != "" && within(path, spec.GitCommonDir)) != underGitCommonDir {
+				continue
+			}
+			if err := ensureMountpoint(path, isDir[i]); err != nil {
+				return err
+			}
+			if err := unix.MoveMount(clones[i], "", unix.AT_FDCWD, path, unix.MOVE_MOUNT_F_EMPTY_PATH); err != nil {
+				return fmt.Errorf("mounting %s writable: %w", path, err)
+			}
+		}
+		return nil
+	}
+	if err := mountWritable(false); err != nil {
+		return err
+	}
+	if spec.GitCommonDir != "" {
+		if err := overlayGitCommonDir(spec); err != nil {
+			return err
+		}
+	}
+	if err := mountWritable(true); err != nil {
+		return err
+	}
+	for _, path := range spec.Hidden {
+		if err := hide(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// overlayGitCommonDir mounts an overlay on the spec's GitCommonDir whose
+// writable layer is in the polecat's runtime directory. Writes to hooks,
+// config, refs and objects land there, never in the shared repository.
+func overlayGitCommonDir(spec *Spec) error {
+	lower := spec.GitCommonDir
+	upper := filepath.Join(spec.gitLayerDir(), "upper")
+	work := filepath.Join(spec.gitLayerDir(), "work")
+	for _, dir := range []string{lower, upper, work} {
+		if strings.ContainsAny(dir, ",:\\") {
+			return fmt.Errorf("cannot overlay git directory: unsupported character in %s", dir)
+		}
+	}
+	opts := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s,userxattr", lower, upper, work)
+	if err := unix.Mount("overlay", lower, "overlay", unix.MS_NOSUID|unix.MS_NODEV, opts); err != nil {
+		return fmt.Errorf("overlaying git directory %s (needs Linux 5.11+): %w", lower, err)
+	}
+	return nil
+}
+
+// ensureMountpoint recreates path, as a directory or an empty file, if the
+// private /tmp hid it.
+func ensureMountpoint(path string, isDir bool) error {
+	if _, err := os.Lstat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if isDir {
+		return os.Mkdir(path, 0755)
+	}
+	return os.WriteFile(path, nil, 0600)
+}
+
+// setReadOnly sets or clears the read-only flag on the mount at path and
+// every mount below it.
+func setReadOnly(path string, readOnly bool) error {
+	attr := &unix.MountAttr{}
+	if readOnly {
+		attr.Attr_set = unix.MOUNT_ATTR_RDONLY
+	} else {
+		attr.Attr_clr = unix.MOUNT_ATTR_RDONLY
+	}
+	if err := unix.MountSetattr(unix.AT_FDCWD, path, unix.AT_RECURSIVE, attr); err != nil {
+		return fmt.Errorf("mount_setattr %s: %w", path, err)
+	}
+	return nil
+}
+
+// hide masks path with an empty tmpfs (directories) or /dev/null (files).
+func hide(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if info.IsDir() {
+		err = unix.Mount("tmpfs", path, "tmpfs", unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV, "size=4k,mode=0755")
+	} else {
+		err = unix.Mount("/dev/null", path, "", unix.MS_BIND, "")
+	}
+	if err != nil {
+		return fmt.Errorf("hiding %s: %w", path, err)
+	}
+	return nil
+}
+
+// loopbackUp brings up lo in the new network namespace.
+func loopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+	ifr, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifr); err != nil {
+		return fmt.Errorf("reading lo flags: %w", err)
+	}
+	ifr.SetUint16(ifr.Uint16() | unix.IFF_UP)
+	if err := unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifr); err != nil {
+		return fmt.Errorf("bringing up lo: %w", err)
+	}
+	return nil
+}
+
+// serveLoopbackForwarders listens on ProxyAddr and EgressAddr inside the
+// sandbox and forwards connections to the launcher's sockets.
+func serveLoopbackForwarders() error {
+	sockDir := fmt.Sprintf("/proc/self/fd/%d", socketDirFD)
+	for addr, socket := range map[string]string{ProxyAddr: proxySocket, EgressAddr: egressSocket} {
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(sockDir, socket)
+		go forward(l, func() (net.Conn, error) {
+			return net.DialTimeout("unix", target, 5*time.Second)
+		})
+	}
+	return nil
+}
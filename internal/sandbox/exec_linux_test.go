@@ -0,0 +1,191 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// helperEnv marks a re-executed test binary acting as sandbox init or probe.
+const helperEnv = "GT_SANDBOX_TEST_HELPER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(helperEnv) == "1" && len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "init":
+			code, err := Init(os.Args[3:]) // skip "init" "--"
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			os.Exit(code)
+		case "probe":
+			report, err := Probe()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			_ = json.NewEncoder(os.Stdout).Encode(report)
+			os.Exit(0)
+		}
+	}
+	os.Exit(m.Run())
+}
+
+// userNamespacesAvailable reports whether this process may create a user
+// namespace.
+func userNamespacesAvailable() bool {
+	cmd := exec.Command("/bin/true")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:  syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}},
+	}
+	return cmd.Run() == nil
+}
+
+func TestExecEnforcesProfile(t *testing.T) {
+	if !userNamespacesAvailable() {
+		t.Skip("user namespaces are not available")
+	}
+	orig := initCommand
+	initCommand = []string{"/proc/self/exe", "init", "--"}
+	t.Cleanup(func() { initCommand = orig })
+	t.Setenv(helperEnv, "1")
+
+	// The town must live outside /tmp, which the sandbox replaces with a
+	// private tmpfs.
+	townRoot, err := os.MkdirTemp("/var/tmp", "gt-sandbox-test-")
+	if err != nil {
+		t.Skipf("no /var/tmp: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(townRoot) })
+	worktree := filepath.Join(townRoot, "gastown", "polecats", "nux")
+	secrets := filepath.Join(townRoot, "secrets")
+	for _, dir := range []string{worktree, secrets} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(secrets, "token"), []byte("s3cret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	spec := &Spec{
+		Profile:    "test",
+		TownRoot:   townRoot,
+		Rig:        "gastown",
+		Polecat:    "nux",
+		Worktree:   worktree,
+		Network:    config.SandboxNetworkNone,
+		ReadWrite:  []string{worktree},
+		Hidden:     []string{secrets},
+		RuntimeDir: RuntimeDir(townRoot, "gastown", "nux"),
+	}
+
+	out, err := os.Create(filepath.Join(t.TempDir(), "report.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = out
+	// The test binary lives under /tmp, which the sandbox replaces.
+	code, err := Exec(context.Background(), spec, []string{"/proc/self/exe", "probe"})
+	os.Stdout = stdout
+	out.Close()
+	if err != nil || code != 0 {
+		t.Fatalf("Exec = %d, %v", code, err)
+	}
+
+	data, err := os.ReadFile(out.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("decoding probe report %q: %v", data, err)
+	}
+	if len(report.Checks) == 0 {
+		t.Fatal("probe made no checks")
+	}
+	for _, c := range report.Checks {
+		if !c.OK {
+			t.Errorf("check %q failed: %s", c.Name, c.Detail)
+		}
+	}
+}
+
+func TestExecIsolatesSharedGitDir(t *testing.T) {
+	if !userNamespacesAvailable() {
+		t.Skip("user namespaces are not available")
+	}
+	orig := initCommand
+	initCommand = []string{"/proc/self/exe", "init", "--"}
+	t.Cleanup(func() { initCommand = orig })
+	t.Setenv(helperEnv, "1")
+
+	townRoot, err := os.MkdirTemp("/var/tmp", "gt-sandbox-test-")
+	if err != nil {
+		t.Skipf("no /var/tmp: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(townRoot) })
+	repo := filepath.Join(townRoot, "gastown", ".repo.git")
+	worktree := filepath.Join(townRoot, "gastown", "polecats", "nux")
+	initLinkedWorktree(t, repo, worktree, "polecat/nux")
+	gitDir, common := gitDirs(worktree)
+	before := revParse(t, repo, "polecat/nux")
+
+	spec := &Spec{
+		Profile:      "test",
+		TownRoot:     townRoot,
+		Rig:          "gastown",
+		Polecat:      "nux",
+		Worktree:     worktree,
+		Network:      config.SandboxNetworkNone,
+		ReadWrite:    []string{worktree, gitDir},
+		GitCommonDir: common,
+		RuntimeDir:   RuntimeDir(townRoot, "gastown", "nux"),
+	}
+	hook := filepath.Join(common, "hooks", "post-commit")
+	script := "set -e\n" +
+		"printf '#!/bin/sh\\n' > " + hook + "\n" +
+		"git config core.fsmonitor /bin/false\n" +
+		"git -c user.name=t -c user.email=t@example.com commit -q --allow-empty -m sandboxed\n"
+	cmd := []string{"/bin/sh", "-c", "cd " + worktree + " && " + script}
+	if code, err := Exec(context.Background(), spec, cmd); err != nil || code != 0 {
+		if err != nil && strings.Contains(err.Error(), "overlay") {
+			t.Skipf("overlayfs unavailable: %v", err)
+		}
+		t.Fatalf("Exec = %d, %v", code, err)
+	}
+
+	if _, err := os.Stat(hook); err == nil {
+		t.Error("a hook written in the sandbox reached the shared repository")
+	}
+	out, _ := exec.Command("git", "-C", repo, "config", "--get", "core.fsmonitor").Output()
+	if len(out) > 0 {
+		t.Errorf("shared config gained core.fsmonitor = %s", out)
+	}
+	if after := revParse(t, repo, "polecat/nux"); after != before {
+		t.Errorf("shared branch moved from %s to %s; only a proxy push may update it", before, after)
+	}
+}
+
+// revParse returns the commit ref names in repo.
+func revParse(t *testing.T, repo, ref string) string {
+	t.Helper()
+	out, err := exec.Command("git", "-C", repo, "rev-parse", ref).Output()
+	if err != nil {
+		t.Fatalf("rev-parse %s: %v", ref, err)
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package sandbox
+
+import "context"
+
+// Exec runs argv inside the sandbox described by spec. Sandboxing needs
+// Linux namespaces and cgroups; elsewhere it always fails.
+func Exec(ctx context.Context, spec *Spec, argv []string) (int, error) {
+	return 0, ErrUnsupported
+}
+
+// Init is the sandbox's init process. It is only reached on Linux.
+func Init(argv []string) (int, error) {
+	return 0, ErrUnsupported
+}
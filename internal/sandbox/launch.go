@@ -0,0 +1,131 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/proxy"
+)
+
+// proxyClientBinary is the mTLS client installed in place of gt and bd
+// inside the sandbox.
+const proxyClientBinary = "gt-proxy-client"
+
+// shimmedTools are the commands routed through gt-proxy-client.
+var shimmedTools = []string{"gt", "bd"}
+
+// certDir returns the directory holding the polecat's proxy cert. It is
+// writable inside the sandbox so gt-proxy-client can renew the cert.
+func (s *Spec) certDir() string {
+	return filepath.Join(s.RuntimeDir, "certs")
+}
+
+// shimDir returns the directory of gt/bd shims prepended to PATH.
+func (s *Spec) shimDir() string {
+	return filepath.Join(s.RuntimeDir, "bin")
+}
+
+// issueCert asks gt-proxy-server for a fresh client cert for the polecat
+// and writes it, with the CA, to the spec's cert directory.
+func issueCert(ctx context.Context, spec *Spec) error {
+	ttl, err := time.ParseDuration(spec.CertTTL)
+	if err != nil || ttl <= 0 {
+		ttl = config.DefaultSandboxCertTTL
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cert, err := proxy.IssuePolecatCert(ctx, proxy.TownAdminAddr(spec.TownRoot), spec.Rig, spec.Polecat, ttl)
+	if err != nil {
+		return fmt.Errorf("issuing proxy cert (is gt-proxy-server running?): %w", err)
+	}
+	if err := os.MkdirAll(spec.certDir(), 0700); err != nil {
+		return err
+	}
+	files := map[string]string{"client.key": cert.Key, "client.crt": cert.Cert, "ca.crt": cert.CA}
+	for name, pem := range files {
+		if err := os.WriteFile(filepath.Join(spec.certDir(), name), []byte(pem), 0600); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// installShims links gt and bd to gt-proxy-client in the spec's shim
+// directory. It returns false if gt-proxy-client can't be found, in which
+// case gt and bd inside the sandbox are the real binaries and can only read.
+func installShims(spec *Spec) (bool, error) {
+	client, err := exec.LookPath(proxyClientBinary)
+	if err != nil {
+		self, selfErr := os.Executable()
+		if selfErr != nil {
+			return false, nil
+		}
+		client = filepath.Join(filepath.Dir(self), proxyClientBinary)
+		if _, err := os.Stat(client); err != nil {
+			return false, nil
+		}
+	}
+	client, err = filepath.Abs(client)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(spec.shimDir(), 0755); err != nil {
+		return false, err
+	}
+	for _, tool := range shimmedTools {
+		link := filepath.Join(spec.shimDir(), tool)
+		_ = os.Remove(link)
+		if err := os.Symlink(client, link); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// agentEnv returns the environment variables Init adds for the agent.
+// realGT is the gt binary gt-proxy-client falls back to when a proxy
+// variable is missing.
+func agentEnv(spec *Spec, realGT string) map[string]string {
+	env := map[string]string{
+		"GT_SANDBOX": spec.Profile,
+	}
+	if spec.ProxyURL != "" {
+		env["GT_PROXY_URL"] = spec.ProxyURL
+		env["GT_PROXY_CERT"] = filepath.Join(spec.certDir(), "client.crt")
+		env["GT_PROXY_KEY"] = filepath.Join(spec.certDir(), "client.key")
+		env["GT_PROXY_CA"] = filepath.Join(spec.certDir(), "ca.crt")
+		if realGT != "" {
+			env["GT_REAL_BIN"] = realGT
+		}
+		// Route fetch and push for origin through the proxy's git endpoint,
+		// which serves the rig's .repo.git and applies the push policy.
+		gitConfig := [][2]string{
+			{"http.sslCert", env["GT_PROXY_CERT"]},
+			{"http.sslKey", env["GT_PROXY_KEY"]},
+			{"http.sslCAInfo", env["GT_PROXY_CA"]},
+		}
+		if spec.GitOrigin != "" {
+			gitConfig = append(gitConfig, [2]string{"url." + spec.ProxyURL + "/v1/git/" + spec.Rig + ".insteadOf", spec.GitOrigin})
+		}
+		env["GIT_CONFIG_COUNT"] = strconv.Itoa(len(gitConfig))
+		for i, kv := range gitConfig {
+			env["GIT_CONFIG_KEY_"+strconv.Itoa(i)] = kv[0]
+			env["GIT_CONFIG_VALUE_"+strconv.Itoa(i)] = kv[1]
+		}
+	}
+	if spec.Network == config.SandboxNetworkProxy {
+		egress := "http://" + EgressAddr
+		for _, k := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+			env[k] = egress
+		}
+		env["NO_PROXY"] = "localhost,127.0.0.1"
+		env["no_proxy"] = env["NO_PROXY"]
+	}
+	return env
+}
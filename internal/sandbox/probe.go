@@ -0,0 +1,321 @@
+package sandbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// VerifyPolecat is the polecat name Verify launches its probe as.
+const VerifyPolecat = "sandbox-verify"
+
+// probeDialTimeout bounds each network check.
+const probeDialTimeout = 3 * time.Second
+
+// probeBlockedAddr is dialed to confirm direct internet access is blocked.
+const probeBlockedAddr = "1.1.1.1:443"
+
+// probeDeniedHost is requested through the egress proxy to confirm the
+// allow-list is applied. The .invalid TLD never resolves.
+const probeDeniedHost = "gt-sandbox-probe.invalid:443"
+
+// Check is one enforcement check made by Probe.
+type Check struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report is the result of probing a sandbox from the inside.
+type Report struct {
+	Profile string  `json:"profile"`
+	Checks  []Check `json:"checks"`
+}
+
+// Failures returns the checks that did not pass.
+func (r *Report) Failures() []Check {
+	var failed []Check
+	for _, c := range r.Checks {
+		if !c.OK {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+func (r *Report) add(name string, ok bool, detail string) {
+	r.Checks = append(r.Checks, Check{Name: name, OK: ok, Detail: detail})
+}
+
+// Probe checks, from inside a sandbox, that the limits of the spec in
+// SpecEnv are in force: writable and read-only paths, hidden paths, network
+// isolation, the proxy and egress forwarders, and cgroup limits.
+func Probe() (*Report, error) {
+	var spec Spec
+	raw := os.Getenv(SpecEnv)
+	if raw == "" {
+		return nil, errors.New("not running inside a sandbox")
+	}
+	if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", SpecEnv, err)
+	}
+	r := &Report{Profile: spec.Profile}
+
+	probeFilesystem(r, &spec)
+	probeNetwork(r, &spec)
+	probeLimits(r, &spec)
+	return r, nil
+}
+
+func probeFilesystem(r *Report, spec *Spec) {
+	err := tryWrite(spec.Worktree)
+	r.add("worktree writable", err == nil, errDetail(err))
+
+	err = tryWrite(spec.TownRoot)
+	r.add("town read-only", err != nil, wroteDetail(err, spec.TownRoot))
+
+	if home, _ := os.UserHomeDir(); home != "" && !spec.writable(home) {
+		err = tryWrite(home)
+		r.add("home read-only", err != nil, wroteDetail(err, home))
+	}
+	if spec.GitCommonDir != "" {
+		fsType := mountFSType(spec.GitCommonDir)
+		r.add("shared git directory overlaid", fsType == "overlay",
+			fmt.Sprintf("%s is mounted as %q", spec.GitCommonDir, fsType))
+	}
+	for _, path := range spec.Hidden {
+		r.add("hidden "+path, isEmpty(path), "")
+	}
+}
+
+func probeNetwork(r *Report, spec *Spec) {
+	if spec.Network == config.SandboxNetworkHost {
+		r.add("network isolated", true, "profile uses the host network")
+		return
+	}
+	conn, err := net.DialTimeout("tcp", probeBlockedAddr, probeDialTimeout)
+	if err == nil {
+		conn.Close()
+		r.add("direct internet blocked", false, "connected to "+probeBlockedAddr)
+	} else {
+		r.add("direct internet blocked", true, "")
+	}
+	if spec.Network != config.SandboxNetworkProxy {
+		return
+	}
+
+	err = probeProxy()
+	r.add("gt-proxy-server reachable over mTLS", err == nil, errDetail(err))
+
+	status, err := connectStatus(EgressAddr, probeDeniedHost)
+	switch {
+	case err != nil:
+		r.add("egress allow-list enforced", false, err.Error())
+	case status != http.StatusForbidden:
+		r.add("egress allow-list enforced", false, fmt.Sprintf("CONNECT %s returned %d, want 403", probeDeniedHost, status))
+	default:
+		r.add("egress allow-list enforced", true, "")
+	}
+}
+
+func probeLimits(r *Report, spec *Spec) {
+	want := cgroupLimits(spec)
+	if len(want) == 0 {
+		return
+	}
+	got, err := currentLimits()
+	if err != nil {
+		r.add("resource limits", false, err.Error())
+		return
+	}
+	for _, file := range []string{"memory.max", "cpu.max", "pids.max"} {
+		if want[file] == "" {
+			continue
+		}
+		r.add(file, got[file] == want[file], fmt.Sprintf("got %q, want %q", got[file], want[file]))
+	}
+}
+
+// probeProxy completes an mTLS handshake with gt-proxy-server through the
+// sandbox's proxy forwarder, using the cert Exec issued.
+func probeProxy() error {
+	cert, err := tls.LoadX509KeyPair(os.Getenv("GT_PROXY_CERT"), os.Getenv("GT_PROXY_KEY"))
+	if err != nil {
+		return fmt.Errorf("loading proxy cert: %w", err)
+	}
+	caPEM, err := os.ReadFile(os.Getenv("GT_PROXY_CA"))
+	if err != nil {
+		return fmt.Errorf("reading proxy CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(caPEM)
+	host, _, _ := net.SplitHostPort(ProxyAddr)
+	dialer := &net.Dialer{Timeout: probeDialTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", ProxyAddr, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   host,
+		MinVersion:   tls.VersionTLS12,
+	})
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// connectStatus sends CONNECT target to the HTTP proxy at proxyAddr and
+// returns the response status.
+func connectStatus(proxyAddr, target string) (int, error) {
+	conn, err := net.DialTimeout("tcp", proxyAddr, probeDialTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(probeDialTimeout))
+	if _, err := fmt.Fprintf(conn, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", target, target); err != nil {
+		return 0, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// tryWrite creates and removes a file in dir.
+func tryWrite(dir string) error {
+	f, err := os.CreateTemp(dir, ".gt-sandbox-probe-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// mountFSType returns the filesystem type of the topmost mount at path, or
+// "" if nothing is mounted there.
+func mountFSType(path string) string {
+	data, err := os.ReadFile("/proc/self/mountinfo")
+	if err != nil {
+		return ""
+	}
+	fsType := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		// id parent major:minor root mountpoint options [tags...] - fstype source super
+		fields := strings.Fields(line)
+		sep := slices.Index(fields, "-")
+		if sep < 5 || sep+1 >= len(fields) {
+			continue
+		}
+		if unescapeMountPath(fields[4]) == path {
+			fsType = fields[sep+1]
+		}
+	}
+	return fsType
+}
+
+// unescapeMountPath decodes the octal escapes (\040 for a space) in a
+// mountinfo path.
+func unescapeMountPath(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// isEmpty reports whether path is an empty directory or an empty file.
+func isEmpty(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return true
+	}
+	if !info.IsDir() {
+		return info.Size() == 0
+	}
+	entries, err := os.ReadDir(path)
+	return err == nil && len(entries) == 0
+}
+
+func errDetail(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func wroteDetail(err error, path string) string {
+	if err == nil {
+		return "could write to " + path
+	}
+	return ""
+}
+
+// Verify launches a probe under rig's sandbox profile through gt sandbox
+// exec, exactly as a polecat would be launched, and returns its report. The
+// probe runs as polecat VerifyPolecat in a scratch worktree under the
+// sandbox runtime directory.
+func Verify(ctx context.Context, townRoot, rig string) (*Report, error) {
+	if runtime.GOOS != "linux" {
+		return nil, ErrUnsupported
+	}
+	gt, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	worktree := filepath.Join(RuntimeDir(townRoot, rig, VerifyPolecat), "worktree")
+	if err := os.MkdirAll(worktree, 0755); err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, gt, "sandbox", "exec", //nolint:gosec // runs our own binary
+		"--rig", rig, "--polecat", VerifyPolecat, "--worktree", worktree,
+		"--", gt, "sandbox", "probe")
+	cmd.Env = append(os.Environ(), "GT_TOWN_ROOT="+townRoot)
+	cmd.Dir = worktree
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	runErr := cmd.Run()
+
+	var report Report
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" && runErr != nil {
+			msg = runErr.Error()
+		}
+		return nil, fmt.Errorf("sandbox did not start: %s", msg)
+	}
+	return &report, nil
+}
+
+// String summarises the report, e.g. "7/8 checks passed".
+func (r *Report) String() string {
+	return strconv.Itoa(len(r.Checks)-len(r.Failures())) + "/" + strconv.Itoa(len(r.Checks)) + " checks passed"
+}
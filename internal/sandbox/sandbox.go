@@ -0,0 +1,207 @@
+// Package sandbox launches polecats under the sandbox profile declared in
+// their rig's settings.
+//
+// gt sandbox exec, which config.BuildStartupCommand puts in front of the agent
+// command for sandboxed rigs, calls Plan and Exec. Exec issues the polecat a
+// proxy client cert, moves itself into a cgroup carrying the profile's
+// resource limits, and re-executes the gt binary as "gt sandbox init" in new
+// user, mount and (unless the profile uses the host network) network
+// namespaces. Init makes the filesystem read-only except the worktree, the
+// git directory and the profile's writable paths, gives the shared repository
+// a private overlay, wires loopback forwarders to gt-proxy-server and an
+// egress allow-list proxy, and runs the agent.
+//
+// Probe, run inside a sandbox by Verify, checks that each of those limits is
+// actually in force.
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// SpecEnv carries the JSON-encoded Spec from Exec to Init, and from Init to
+// the agent so tools (and Probe) can tell they are sandboxed.
+const SpecEnv = "GT_SANDBOX_SPEC"
+
+// Loopback addresses the sandbox forwards to the host in proxy network mode.
+const (
+	ProxyAddr  = "127.0.0.1:9876"
+	EgressAddr = "127.0.0.1:3128"
+)
+
+// Socket names in the sandbox's socket directory.
+const (
+	proxySocket  = "proxy.sock"
+	egressSocket = "egress.sock"
+)
+
+// Common errors
+var (
+	ErrNotSandboxed = errors.New("rig has no sandbox profile")
+	ErrUnsupported  = errors.New("sandboxing is only supported on Linux")
+)
+
+// Spec is a resolved sandbox: the profile plus the concrete paths and limits
+// for one polecat.
+type Spec struct {
+	Profile  string `json:"profile"`
+	TownRoot string `json:"town_root"`
+	Rig      string `json:"rig"`
+	Polecat  string `json:"polecat"`
+	Worktree string `json:"worktree"`
+	Network  string `json:"network"`
+
+	// ReadWrite are the existing paths mounted writable: the worktree, its
+	// git directory and the profile's read_write entries.
+	ReadWrite []string `json:"read_write"`
+	// GitCommonDir is the shared repository (the rig's .repo.git) when the
+	// worktree is a linked worktree. Its hooks, config, refs and objects are
+	// never written from inside: the sandbox sees it through an overlay whose
+	// writable layer is private to the polecat, so commits stay local until
+	// they are pushed through the proxy, which applies the push policy.
+	GitCommonDir string `json:"git_common_dir,omitempty"`
+	// Hidden are existing paths masked inside the sandbox.
+	Hidden []string `json:"hidden,omitempty"`
+
+	EgressAllow []string `json:"egress_allow,omitempty"`
+	MemoryBytes int64    `json:"memory_bytes,omitempty"`
+	CPUs        float64  `json:"cpus,omitempty"`
+	Pids        int      `json:"pids,omitempty"`
+
+	// RuntimeDir holds the polecat's proxy cert and the gt/bd shims. It is
+	// visible, read-only, inside the sandbox.
+	RuntimeDir string `json:"runtime_dir"`
+	// ProxyURL is the gt-proxy-server URL the agent uses (set by Exec).
+	ProxyURL string `json:"proxy_url,omitempty"`
+	// CertTTL is the proxy cert lifetime, as a duration string.
+	CertTTL string `json:"cert_ttl,omitempty"`
+	// GitOrigin is the worktree's origin URL, rewritten inside the sandbox
+	// to the proxy's git endpoint.
+	GitOrigin string `json:"git_origin,omitempty"`
+}
+
+// Plan resolves the sandbox for polecat in rig, whose worktree is worktree.
+// It returns ErrNotSandboxed if the rig selects no profile.
+func Plan(townRoot, rig, polecat, worktree string) (*Spec, error) {
+	if townRoot == "" || rig == "" || polecat == "" {
+		return nil, fmt.Errorf("town root, rig and polecat are required (GT_TOWN_ROOT, GT_RIG, GT_POLECAT)")
+	}
+	name, profile, err := config.ResolveRigSandbox(filepath.Join(townRoot, rig))
+	if err != nil {
+		return nil, fmt.Errorf("loading sandbox profile for rig %s: %w", rig, err)
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("%s: %w", rig, ErrNotSandboxed)
+	}
+	if err := config.ValidateSandboxProfile(profile); err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+	memory, _ := profile.MemoryBytes()
+
+	worktree, err = filepath.Abs(worktree)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(worktree); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("worktree %s is not a directory", worktree)
+	}
+	home, _ := os.UserHomeDir()
+
+	spec := &Spec{
+		Profile:     name,
+		TownRoot:    townRoot,
+		Rig:         rig,
+		Polecat:     polecat,
+		Worktree:    worktree,
+		Network:     profile.NetworkMode(),
+		EgressAllow: profile.EgressAllow,
+		MemoryBytes: memory,
+		CPUs:        profile.CPUs,
+		Pids:        profile.Pids,
+		RuntimeDir:  RuntimeDir(townRoot, rig, polecat),
+		CertTTL:     profile.CertLifetime().String(),
+	}
+
+	readWrite, hidden := profile.SandboxPaths(home, worktree)
+	gitDir, commonDir := gitDirs(worktree)
+	readWrite = append([]string{worktree, gitDir}, readWrite...)
+	spec.ReadWrite = existingPaths(readWrite)
+	if commonDir != "" && commonDir != gitDir && !within(commonDir, worktree) {
+		spec.GitCommonDir = commonDir
+	}
+	spec.Hidden = existingPaths(hidden)
+	if out, err := exec.Command("git", "-C", worktree, "remote", "get-url", "origin").Output(); err == nil {
+		spec.GitOrigin = strings.TrimSpace(string(out))
+	}
+	return spec, nil
+}
+
+// RuntimeDir returns the directory holding a sandboxed polecat's cert and
+// shims.
+func RuntimeDir(townRoot, rig, polecat string) string {
+	return filepath.Join(townRoot, ".runtime", "sandbox", rig, polecat)
+}
+
+// gitLayerDir returns the directory holding the private overlay layer of
+// the spec's GitCommonDir.
+func (s *Spec) gitLayerDir() string {
+	return filepath.Join(s.RuntimeDir, "git")
+}
+
+// gitDirs returns the worktree's git directory (HEAD, index) and the
+// repository's common directory (hooks, config, refs, objects). They are
+// the same directory unless the worktree is a linked worktree.
+func gitDirs(worktree string) (string, string) {
+	out, err := exec.Command("git", "-C", worktree, "rev-parse", "--path-format=absolute",
+		"--git-dir", "--git-common-dir").Output()
+	if err != nil {
+		return "", ""
+	}
+	dirs := strings.Fields(string(out))
+	if len(dirs) != 2 {
+		return "", ""
+	}
+	return filepath.Clean(dirs[0]), filepath.Clean(dirs[1])
+}
+
+// existingPaths cleans paths and drops missing ones and duplicates.
+func existingPaths(paths []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		p = filepath.Clean(p)
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		if _, err := os.Lstat(p); err == nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// writable reports whether path is inside one of the spec's writable paths.
+func (s *Spec) writable(path string) bool {
+	for _, rw := range s.ReadWrite {
+		if within(path, rw) {
+			return true
+		}
+	}
+	return false
+}
+
+// within reports whether path is dir or inside it.
+func within(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
@@ -0,0 +1,313 @@
+package sandbox
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// writeRigSettings writes settings/config.json for rig under townRoot.
+func writeRigSettings(t *testing.T, townRoot, rig, body string) {
+	t.Helper()
+	dir := filepath.Join(townRoot, rig, "settings")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	townRoot := t.TempDir()
+	worktree := filepath.Join(townRoot, "gastown", "polecats", "nux")
+	if err := os.MkdirAll(filepath.Join(worktree, "build"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("git", "init", "-q", worktree).CombinedOutput(); err != nil {
+		t.Skipf("git init: %v: %s", err, out)
+	}
+	_ = exec.Command("git", "-C", worktree, "remote", "add", "origin", "https://github.com/example/gastown.git").Run()
+	writeRigSettings(t, townRoot, "gastown", `{
+		"type": "rig-settings", "version": 1,
+		"sandbox": {
+			"profile": "tight",
+			"profiles": {
+				"tight": {
+					"read_write": ["build", "missing"],
+					"network": "none",
+					"memory": "1G",
+					"cpus": 1.5,
+					"pids": 100
+				}
+			}
+		}
+	}`)
+
+	spec, err := Plan(townRoot, "gastown", "nux", worktree)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	if spec.Profile != "tight" || spec.Network != config.SandboxNetworkNone {
+		t.Errorf("profile/network = %q/%q", spec.Profile, spec.Network)
+	}
+	want := []string{worktree, filepath.Join(worktree, ".git"), filepath.Join(worktree, "build")}
+	if strings.Join(spec.ReadWrite, ",") != strings.Join(want, ",") {
+		t.Errorf("ReadWrite = %v, want %v", spec.ReadWrite, want)
+	}
+	if spec.MemoryBytes != 1<<30 || spec.CPUs != 1.5 || spec.Pids != 100 {
+		t.Errorf("limits = %d/%g/%d", spec.MemoryBytes, spec.CPUs, spec.Pids)
+	}
+	if spec.GitOrigin != "https://github.com/example/gastown.git" {
+		t.Errorf("GitOrigin = %q", spec.GitOrigin)
+	}
+	if spec.RuntimeDir != filepath.Join(townRoot, ".runtime", "sandbox", "gastown", "nux") {
+		t.Errorf("RuntimeDir = %q", spec.RuntimeDir)
+	}
+	if !spec.writable(filepath.Join(worktree, "src", "main.go")) || spec.writable(townRoot) {
+		t.Error("writable() disagrees with ReadWrite")
+	}
+}
+
+// initLinkedWorktree creates a repository at repo with a linked worktree at
+// worktree on branch, as polecats are created from the rig's .repo.git.
+func initLinkedWorktree(t *testing.T, repo, worktree, branch string) {
+	t.Helper()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v: %s", args, err, out)
+		}
+	}
+	git("init", "-q", repo)
+	git("-C", repo, "commit", "-q", "--allow-empty", "-m", "init")
+	git("-C", repo, "worktree", "add", "-q", "-b", branch, worktree)
+}
+
+func TestPlan_LinkedWorktree(t *testing.T) {
+	townRoot := t.TempDir()
+	repo := filepath.Join(townRoot, "gastown", ".repo.git")
+	worktree := filepath.Join(townRoot, "gastown", "polecats", "nux")
+	initLinkedWorktree(t, repo, worktree, "polecat/nux")
+	writeRigSettings(t, townRoot, "gastown", `{"type":"rig-settings","version":1,"sandbox":{"profile":"standard"}}`)
+
+	spec, err := Plan(townRoot, "gastown", "nux", worktree)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	common := filepath.Join(repo, ".git")
+	if spec.GitCommonDir != common {
+		t.Errorf("GitCommonDir = %q, want %q", spec.GitCommonDir, common)
+	}
+	if !spec.writable(filepath.Join(common, "worktrees", "nux", "HEAD")) {
+		t.Errorf("the worktree's git directory should be writable: %v", spec.ReadWrite)
+	}
+	for _, path := range []string{"hooks", "config", "refs", "objects"} {
+		if spec.writable(filepath.Join(common, path)) {
+			t.Errorf("shared %s is writable: %v", path, spec.ReadWrite)
+		}
+	}
+}
+
+func TestPlan_NotSandboxed(t *testing.T) {
+	townRoot := t.TempDir()
+	writeRigSettings(t, townRoot, "gastown", `{"type":"rig-settings","version":1}`)
+	if _, err := Plan(townRoot, "gastown", "nux", t.TempDir()); !errors.Is(err, ErrNotSandboxed) {
+		t.Errorf("err = %v, want ErrNotSandboxed", err)
+	}
+	if _, err := Plan(townRoot, "gastown", "", t.TempDir()); err == nil {
+		t.Error("expected error without a polecat name")
+	}
+}
+
+func TestEgressAllowed(t *testing.T) {
+	p := NewEgressProxy([]string{"api.anthropic.com:443", "*.example.com:443"})
+	tests := []struct {
+		hostport string
+		want     bool
+	}{
+		{"api.anthropic.com:443", true},
+		{"API.Anthropic.com:443", true},
+		{"api.anthropic.com:80", false},
+		{"evil.com:443", false},
+		{"a.example.com:443", true},
+		{"a.b.example.com:443", true},
+		{"example.com:443", false},
+		{"notexample.com:443", false},
+		{"api.anthropic.com", false},
+	}
+	for _, tt := range tests {
+		if got := p.Allowed(tt.hostport); got != tt.want {
+			t.Errorf("Allowed(%q) = %v, want %v", tt.hostport, got, tt.want)
+		}
+	}
+}
+
+func TestEgressProxy(t *testing.T) {
+	upstream, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer upstream.Close()
+	go func() {
+		for {
+			conn, err := upstream.Accept()
+			if err != nil {
+				return
+			}
+			go func() { _, _ = io.Copy(conn, conn); conn.Close() }()
+		}
+	}()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() { _ = NewEgressProxy([]string{upstream.Addr().String()}).Serve(l) }()
+
+	status, err := connectStatus(l.Addr().String(), "denied.invalid:443")
+	if err != nil || status != http.StatusForbidden {
+		t.Errorf("denied CONNECT = %d, %v; want 403", status, err)
+	}
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	fmt.Fprintf(conn, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", upstream.Addr(), upstream.Addr())
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, &http.Request{Method: http.MethodConnect})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("allowed CONNECT = %v, %v", resp, err)
+	}
+	fmt.Fprint(conn, "ping\n")
+	line, err := br.ReadString('\n')
+	if err != nil || line != "ping\n" {
+		t.Errorf("tunnel echo = %q, %v", line, err)
+	}
+}
+
+// fakeCgroupFS points the cgroup code at a temp dir laid out like cgroupfs
+// with the calling process in /app.slice/term.scope.
+func fakeCgroupFS(t *testing.T, controllers string) (root string) {
+	t.Helper()
+	root = t.TempDir()
+	parent := filepath.Join(root, "app.slice")
+	if err := os.MkdirAll(filepath.Join(parent, "term.scope"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(root, "cgroup.controllers"):       controllers,
+		filepath.Join(parent, "cgroup.controllers"):     controllers,
+		filepath.Join(parent, "cgroup.subtree_control"): "",
+	}
+	for path, data := range files {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	self := filepath.Join(t.TempDir(), "cgroup")
+	if err := os.WriteFile(self, []byte("0::/app.slice/term.scope\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	origRoot, origSelf := cgroupRoot, procSelfCgroup
+	cgroupRoot, procSelfCgroup = root, self
+	t.Cleanup(func() { cgroupRoot, procSelfCgroup = origRoot, origSelf })
+	return root
+}
+
+func TestJoinCgroup(t *testing.T) {
+	root := fakeCgroupFS(t, "cpu memory pids")
+	spec := &Spec{Rig: "gastown", Polecat: "nux", MemoryBytes: 1<<30 + 100, CPUs: 0.5, Pids: 64}
+
+	cg, err := joinCgroup(spec)
+	if err != nil {
+		t.Fatalf("joinCgroup: %v", err)
+	}
+	dir := filepath.Join(root, "app.slice", "gt-sandbox-gastown-nux")
+	if cg.dir != dir {
+		t.Errorf("dir = %s, want %s", cg.dir, dir)
+	}
+	want := map[string]string{
+		"memory.max":   strconv.Itoa(1 << 30),
+		"cpu.max":      "50000 100000",
+		"pids.max":     "64",
+		"cgroup.procs": strconv.Itoa(os.Getpid()),
+	}
+	for file, value := range want {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil || string(data) != value {
+			t.Errorf("%s = %q, %v; want %q", file, data, err, value)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "app.slice", "cgroup.subtree_control")); len(data) == 0 {
+		t.Error("controllers were not enabled in the parent cgroup")
+	}
+
+	// A second launch of the same polecat finds the cgroup occupied.
+	if _, err := joinCgroup(spec); err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Errorf("second joinCgroup err = %v, want in use", err)
+	}
+}
+
+func TestJoinCgroup_Errors(t *testing.T) {
+	fakeCgroupFS(t, "cpu pids")
+	if _, err := joinCgroup(&Spec{Rig: "r", Polecat: "p", MemoryBytes: 1 << 20}); err == nil || !strings.Contains(err.Error(), `"memory"`) {
+		t.Errorf("err = %v, want missing memory controller", err)
+	}
+	if cg, err := joinCgroup(&Spec{Rig: "r", Polecat: "p"}); cg != nil || err != nil {
+		t.Errorf("no limits: got %v, %v; want nil, nil", cg, err)
+	}
+}
+
+func TestAgentEnv(t *testing.T) {
+	spec := &Spec{
+		Profile:    "standard",
+		Rig:        "gastown",
+		Network:    config.SandboxNetworkProxy,
+		RuntimeDir: "/town/.runtime/sandbox/gastown/nux",
+		ProxyURL:   "https://" + ProxyAddr,
+		GitOrigin:  "https://github.com/example/gastown.git",
+	}
+	env := agentEnv(spec, "/usr/local/bin/gt")
+
+	checks := map[string]string{
+		"GT_SANDBOX":         "standard",
+		"GT_PROXY_URL":       "https://127.0.0.1:9876",
+		"GT_PROXY_CERT":      "/town/.runtime/sandbox/gastown/nux/certs/client.crt",
+		"GT_REAL_BIN":        "/usr/local/bin/gt",
+		"HTTPS_PROXY":        "http://127.0.0.1:3128",
+		"GIT_CONFIG_COUNT":   "4",
+		"GIT_CONFIG_KEY_3":   "url.https://127.0.0.1:9876/v1/git/gastown.insteadOf",
+		"GIT_CONFIG_VALUE_3": "https://github.com/example/gastown.git",
+	}
+	for k, want := range checks {
+		if env[k] != want {
+			t.Errorf("%s = %q, want %q", k, env[k], want)
+		}
+	}
+
+	spec.Network, spec.ProxyURL = config.SandboxNetworkNone, ""
+	env = agentEnv(spec, "")
+	for _, k := range []string{"GT_PROXY_URL", "HTTPS_PROXY", "GIT_CONFIG_COUNT"} {
+		if _, ok := env[k]; ok {
+			t.Errorf("network none: %s should not be set", k)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package session
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/headless"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// SessionBackend is the set of terminal-session operations agent lifecycle
+// code needs: create, kill, send-keys, capture, environment and liveness.
+//
+// tmux.Tmux is the default implementation. headless.Client runs sessions on
+// PTYs supervised by the daemon, for hosts without tmux. Managers that only
+// need these operations should take a SessionBackend so they work with
+// either and can be tested with a fake. tmux-only features (themes, hooks,
+// respawn, dialogs) stay on tmux.Tmux and are skipped for other backends.
+type SessionBackend interface {
+	// NewSessionWithCommand starts command in a new detached session.
+	NewSessionWithCommand(name, workDir, command string) error
+	// NewSessionWithCommandAndEnv is NewSessionWithCommand with extra
+	// environment variables for the command.
+	NewSessionWithCommandAndEnv(name, workDir, command string, env map[string]string) error
+	// HasSession reports whether a session exists (exact match).
+	HasSession(name string) (bool, error)
+	// ListSessions returns all session names.
+	ListSessions() ([]string, error)
+	// KillSessionWithProcesses kills a session and every process in it.
+	KillSessionWithProcesses(name string) error
+	// SendKeys types keys into the session and presses Enter.
+	SendKeys(session, keys string) error
+	// SendKeysRaw sends a key name such as "C-c" or "Escape" without Enter.
+	SendKeysRaw(session, keys string) error
+	// CapturePane returns the last lines of the session's terminal.
+	CapturePane(session string, lines int) (string, error)
+	// SetEnvironment sets a variable in the session's environment table.
+	SetEnvironment(session, key, value string) error
+	// GetEnvironment reads a variable from the session's environment table.
+	GetEnvironment(session, key string) (string, error)
+	// IsAgentAlive reports whether the agent process in the session is running.
+	IsAgentAlive(session string) bool
+}
+
+var (
+	_ SessionBackend = (*tmux.Tmux)(nil)
+	_ SessionBackend = (*headless.Client)(nil)
+)
+
+// Session backend names, as used in settings/config.json session_backend and
+// GT_SESSION_BACKEND.
+const (
+	BackendTmux     = "tmux"
+	BackendHeadless = "headless"
+)
+
+// BackendKind returns the session backend configured for a town:
+// GT_SESSION_BACKEND if set, else session_backend in the town settings,
+// else tmux. Unknown values fall back to tmux.
+func BackendKind(townRoot string) string {
+	kind := os.Getenv("GT_SESSION_BACKEND")
+	if kind == "" && townRoot != "" {
+		if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+			kind = settings.SessionBackend
+		}
+	}
+	if strings.EqualFold(strings.TrimSpace(kind), BackendHeadless) {
+		return BackendHeadless
+	}
+	return BackendTmux
+}
+
+// NewBackend returns the session backend configured for a town.
+func NewBackend(townRoot string) SessionBackend {
+	if BackendKind(townRoot) == BackendHeadless {
+		return headless.NewClient(townRoot)
+	}
+	return tmux.NewTmux()
+}
+
+// WaitForReadyPrompt polls a non-tmux backend until a captured line starts
+// with the runtime's ready prompt, or falls back to its fixed ready delay.
+// It mirrors tmux.WaitForRuntimeReady.
+func WaitForReadyPrompt(b SessionBackend, sessionID string, rc *config.RuntimeConfig, timeout time.Duration) bool {
+	if rc == nil || rc.Tmux == nil {
+		return true
+	}
+	if rc.Tmux.ReadyPromptPrefix == "" {
+		if rc.Tmux.ReadyDelayMs > 0 {
+			time.Sleep(min(time.Duration(rc.Tmux.ReadyDelayMs)*time.Millisecond, timeout))
+		}
+		return true
+	}
+	prefix := strings.TrimSpace(rc.Tmux.ReadyPromptPrefix)
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if out, err := b.CapturePane(sessionID, 10); err == nil {
+			for _, line := range strings.Split(out, "\n") {
+				if strings.HasPrefix(strings.TrimSpace(line), prefix) {
+					return true
+				}
+			}
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return false
+}
+
+// CheckSessionHealth reports whether a session exists and its agent is
+// alive. tmux sessions are also checked for inactivity; other backends keep
+// no activity clock, so maxInactivity only applies to tmux.
+func CheckSessionHealth(b SessionBackend, name string, maxInactivity time.Duration) tmux.ZombieStatus {
+	if t, ok := b.(*tmux.Tmux); ok {
+		return t.CheckSessionHealth(name, maxInactivity)
+	}
+	if alive, err := b.HasSession(name); err != nil || !alive {
+		return tmux.SessionDead
+	}
+	if !b.IsAgentAlive(name) {
+		return tmux.AgentDead
+	}
+	return tmux.SessionHealthy
+}
+
+// GetSessionInfo returns information about a running session. Backends
+// other than tmux only report its name.
+func GetSessionInfo(b SessionBackend, name string) (*tmux.SessionInfo, error) {
+	if t, ok := b.(*tmux.Tmux); ok {
+		return t.GetSessionInfo(name)
+	}
+	return &tmux.SessionInfo{Name: name}, nil
+}
+
+// KillSession kills a session. tmux kills only the session, leaving
+// processes that escaped it; other backends always kill every process.
+func KillSession(b SessionBackend, name string) error {
+	if t, ok := b.(*tmux.Tmux); ok {
+		return t.KillSession(name)
+	}
+	return b.KillSessionWithProcesses(name)
+}
@@ -0,0 +1,168 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/headless"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// fakeBackend is an in-memory SessionBackend.
+type fakeBackend struct {
+	sessions map[string]bool // name -> agent alive
+	keys     []string
+	killed   []string
+	exitOnCC bool // sessions exit when sent C-c
+}
+
+func newFakeBackend(names ...string) *fakeBackend {
+	f := &fakeBackend{sessions: make(map[string]bool)}
+	for _, n := range names {
+		f.sessions[n] = true
+	}
+	return f
+}
+
+func (f *fakeBackend) NewSessionWithCommand(name, _, _ string) error {
+	f.sessions[name] = true
+	return nil
+}
+
+func (f *fakeBackend) NewSessionWithCommandAndEnv(name, workDir, command string, _ map[string]string) error {
+	return f.NewSessionWithCommand(name, workDir, command)
+}
+
+func (f *fakeBackend) HasSession(name string) (bool, error) {
+	_, ok := f.sessions[name]
+	return ok, nil
+}
+
+func (f *fakeBackend) ListSessions() ([]string, error) {
+	var names []string
+	for n := range f.sessions {
+		names = append(names, n)
+	}
+	return names, nil
+}
+
+func (f *fakeBackend) KillSessionWithProcesses(name string) error {
+	f.killed = append(f.killed, name)
+	delete(f.sessions, name)
+	return nil
+}
+
+func (f *fakeBackend) SendKeys(session, keys string) error { return f.SendKeysRaw(session, keys) }
+
+func (f *fakeBackend) SendKeysRaw(session, keys string) error {
+	f.keys = append(f.keys, keys)
+	if keys == "C-c" && f.exitOnCC {
+		delete(f.sessions, session)
+	}
+	return nil
+}
+
+func (f *fakeBackend) CapturePane(_ string, _ int) (string, error) { return "", nil }
+func (f *fakeBackend) SetEnvironment(_, _, _ string) error         { return nil }
+func (f *fakeBackend) GetEnvironment(_, _ string) (string, error)  { return "", nil }
+func (f *fakeBackend) IsAgentAlive(session string) bool            { return f.sessions[session] }
+
+func TestBackendKind(t *testing.T) {
+	town := t.TempDir()
+	t.Setenv("GT_SESSION_BACKEND", "")
+
+	if got := BackendKind(town); got != BackendTmux {
+		t.Errorf("default = %q, want tmux", got)
+	}
+	if _, ok := NewBackend(town).(*tmux.Tmux); !ok {
+		t.Error("default backend should be tmux")
+	}
+
+	settings := config.NewTownSettings()
+	settings.SessionBackend = "headless"
+	if err := config.SaveTownSettings(config.TownSettingsPath(town), settings); err != nil {
+		t.Fatal(err)
+	}
+	if got := BackendKind(town); got != BackendHeadless {
+		t.Errorf("settings = %q, want headless", got)
+	}
+	if _, ok := NewBackend(town).(*headless.Client); !ok {
+		t.Error("headless setting should give a headless client")
+	}
+
+	t.Setenv("GT_SESSION_BACKEND", "tmux")
+	if got := BackendKind(town); got != BackendTmux {
+		t.Errorf("env override = %q, want tmux", got)
+	}
+	t.Setenv("GT_SESSION_BACKEND", "screen")
+	if got := BackendKind(town); got != BackendTmux {
+		t.Errorf("unknown backend = %q, want tmux", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(town, "settings", "config.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GT_SESSION_BACKEND", "")
+	if got := BackendKind(town); got != BackendTmux {
+		t.Errorf("unreadable settings = %q, want tmux", got)
+	}
+}
+
+func TestKillExistingSession_Backend(t *testing.T) {
+	f := newFakeBackend("hq-mayor")
+
+	if _, err := KillExistingSession(f, "hq-mayor", true); err == nil {
+		t.Fatal("healthy session should not be killed when checkAlive is set")
+	}
+
+	f.sessions["hq-mayor"] = false // zombie: session up, agent dead
+	killed, err := KillExistingSession(f, "hq-mayor", true)
+	if err != nil || !killed {
+		t.Fatalf("zombie: killed=%v err=%v", killed, err)
+	}
+	if killed, _ := KillExistingSession(f, "hq-mayor", false); killed {
+		t.Error("missing session reported as killed")
+	}
+}
+
+func TestStopSession_Backend(t *testing.T) {
+	f := newFakeBackend("hq-deacon")
+	f.exitOnCC = true
+
+	if err := StopSession(f, "hq-deacon", true); err != nil {
+		t.Fatalf("StopSession: %v", err)
+	}
+	if len(f.keys) != 1 || f.keys[0] != "C-c" {
+		t.Errorf("keys = %v, want [C-c]", f.keys)
+	}
+	if err := StopSession(f, "hq-deacon", false); err == nil {
+		t.Error("stopping a missing session should fail")
+	}
+}
+
+func TestCheckSessionHealth_NonTmux(t *testing.T) {
+	f := newFakeBackend("hq-mayor")
+	f.sessions["gt-witness"] = false // session up, agent gone
+
+	tests := map[string]tmux.ZombieStatus{
+		"hq-mayor":   tmux.SessionHealthy,
+		"gt-witness": tmux.AgentDead,
+		"missing":    tmux.SessionDead,
+	}
+	for name, want := range tests {
+		if got := CheckSessionHealth(f, name, time.Minute); got != want {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+
+	info, err := GetSessionInfo(f, "hq-mayor")
+	if err != nil || info.Name != "hq-mayor" {
+		t.Errorf("GetSessionInfo = %+v, %v", info, err)
+	}
+	if err := KillSession(f, "hq-mayor"); err != nil || len(f.killed) != 1 {
+		t.Errorf("KillSession: err=%v killed=%v", err, f.killed)
+	}
+}
@@ -41,7 +41,7 @@ func ParseAddress(address string) (*AgentIdentity, error) {
 	if address == string(RoleDeacon) || address == string(RoleDeacon)+"/" {
 		return &AgentIdentity{Role: RoleDeacon}, nil
 	}
-	if address == "overseer" {
+	if address == "overseer" || strings.HasPrefix(address, "overseer/") {
 		return nil, fmt.Errorf("overseer has no session")
 	}
 
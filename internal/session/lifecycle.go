@@ -141,7 +141,12 @@ type StartResult struct {
 // Role-specific concerns (issue validation, fallback nudges, pane-died hooks,
 // crew cycle bindings, etc.) should be handled by the caller before/after
 // calling StartSession.
-func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error) {
+//
+// b is usually a *tmux.Tmux. Other backends get the same create, env, ready
+// and verify steps; the tmux-only steps (remain-on-exit, theme, respawn hook,
+// dialog acceptance, pane ID and PID tracking) are skipped.
+func StartSession(b SessionBackend, cfg SessionConfig) (_ *StartResult, retErr error) {
+	t, isTmux := b.(*tmux.Tmux)
 	// Generate the GASTA run ID — the root identifier for all telemetry emitted
 	// by this agent session and its subprocesses (bd, mail, …).
 	runID := uuid.New().String()
@@ -197,13 +202,13 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 	extraWithRun["GT_RUN"] = runID
 	command = config.PrependEnv(command, extraWithRun)
 
-	// 4. Create tmux session with command.
-	if err := t.NewSessionWithCommand(cfg.SessionID, cfg.WorkDir, command); err != nil {
+	// 4. Create session with command.
+	if err := b.NewSessionWithCommand(cfg.SessionID, cfg.WorkDir, command); err != nil {
 		return nil, fmt.Errorf("creating session: %w", err)
 	}
 
 	// 5. Set remain-on-exit immediately if requested (before anything else can fail).
-	if cfg.RemainOnExit {
+	if cfg.RemainOnExit && isTmux {
 		_ = t.SetRemainOnExit(cfg.SessionID, true)
 	}
 
@@ -219,21 +224,22 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 	})
 	envVars = MergeRuntimeLivenessEnv(envVars, runtimeConfig)
 	for _, k := range mapKeysSorted(envVars) {
-		_ = t.SetEnvironment(cfg.SessionID, k, envVars[k])
+		_ = b.SetEnvironment(cfg.SessionID, k, envVars[k])
 	}
 	// Set GT_RUN in the session environment so respawned processes also inherit it.
-	_ = t.SetEnvironment(cfg.SessionID, "GT_RUN", runID)
+	_ = b.SetEnvironment(cfg.SessionID, "GT_RUN", runID)
 	for _, k := range mapKeysSorted(cfg.ExtraEnv) {
-		_ = t.SetEnvironment(cfg.SessionID, k, cfg.ExtraEnv[k])
+		_ = b.SetEnvironment(cfg.SessionID, k, cfg.ExtraEnv[k])
 	}
 
 	// 7. Apply theme.
-	if cfg.Theme != nil {
+	if cfg.Theme != nil && isTmux {
 		_ = t.ConfigureGasTownSession(cfg.SessionID, cfg.Theme, cfg.RigName, cfg.AgentName, cfg.Role)
 	}
 
-	// 8. Wait for agent to start.
-	if cfg.WaitForAgent {
+	// 8. Wait for agent to start. A headless session's command is the agent,
+	// so there is no shell to wait past.
+	if cfg.WaitForAgent && isTmux {
 		if err := t.WaitForCommand(cfg.SessionID, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {
 			if cfg.WaitFatal {
 				_ = t.KillSessionWithProcesses(cfg.SessionID)
@@ -243,14 +249,14 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 	}
 
 	// 9. Auto-respawn hook.
-	if cfg.AutoRespawn {
+	if cfg.AutoRespawn && isTmux {
 		if err := t.SetAutoRespawnHook(cfg.SessionID); err != nil {
 			fmt.Printf("warning: failed to set auto-respawn hook for %s: %v\n", cfg.Role, err)
 		}
 	}
 
 	// 10. Accept startup dialogs (workspace trust + bypass permissions).
-	if cfg.AcceptBypass {
+	if cfg.AcceptBypass && isTmux {
 		_ = t.AcceptStartupDialogs(cfg.SessionID)
 	}
 
@@ -258,17 +264,21 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 	// Uses prompt-based polling for agents with ReadyPromptPrefix,
 	// falling back to ReadyDelayMs sleep for agents without prompt detection.
 	if cfg.ReadyDelay {
-		if err := t.WaitForRuntimeReady(cfg.SessionID, runtimeConfig, constants.ClaudeStartTimeout); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: agent readiness detection timed out for %s: %v\n", cfg.SessionID, err)
+		if isTmux {
+			if err := t.WaitForRuntimeReady(cfg.SessionID, runtimeConfig, constants.ClaudeStartTimeout); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: agent readiness detection timed out for %s: %v\n", cfg.SessionID, err)
+			}
+		} else if !WaitForReadyPrompt(b, cfg.SessionID, runtimeConfig, constants.ClaudeStartTimeout) {
+			fmt.Fprintf(os.Stderr, "Warning: agent readiness detection timed out for %s\n", cfg.SessionID)
 		}
 	}
 
 	// 12. Verify session survived startup.
 	if cfg.VerifySurvived {
-		running, err := t.HasSession(cfg.SessionID)
+		running, err := b.HasSession(cfg.SessionID)
 		if err != nil {
 			// Clean up session on verification error to prevent orphan
-			_ = t.KillSessionWithProcesses(cfg.SessionID)
+			_ = b.KillSessionWithProcesses(cfg.SessionID)
 			return nil, fmt.Errorf("verifying session: %w", err)
 		}
 		if !running {
@@ -279,12 +289,14 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 	// 13. Record agent's pane_id for ZFC-compliant liveness checks (gt-qmsx).
 	// Declared pane identity replaces process-tree inference in IsRuntimeRunning
 	// and FindAgentPane. Legacy sessions without GT_PANE_ID fall back to scanning.
-	if paneID, err := t.GetPaneID(cfg.SessionID); err == nil {
-		_ = t.SetEnvironment(cfg.SessionID, "GT_PANE_ID", paneID)
+	if isTmux {
+		if paneID, err := t.GetPaneID(cfg.SessionID); err == nil {
+			_ = t.SetEnvironment(cfg.SessionID, "GT_PANE_ID", paneID)
+		}
 	}
 
 	// 14. Track PID for defense-in-depth orphan cleanup.
-	if cfg.TrackPID && cfg.TownRoot != "" {
+	if cfg.TrackPID && cfg.TownRoot != "" && isTmux {
 		_ = TrackSessionPID(cfg.TownRoot, cfg.SessionID, t)
 	}
 
@@ -341,8 +353,8 @@ func RecordAgentInstantiateFromDir(ctx context.Context, runID, resolvedAgent, ro
 //
 // If graceful is true, sends Ctrl-C first and waits for the session to exit
 // before force-killing. This allows the agent to clean up.
-func StopSession(t *tmux.Tmux, sessionID string, graceful bool) error {
-	running, err := t.HasSession(sessionID)
+func StopSession(b SessionBackend, sessionID string, graceful bool) error {
+	running, err := b.HasSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
 	}
@@ -351,15 +363,15 @@ func StopSession(t *tmux.Tmux, sessionID string, graceful bool) error {
 	}
 
 	if graceful {
-		_ = t.SendKeysRaw(sessionID, "C-c")
-		WaitForSessionExit(t, sessionID, constants.GracefulShutdownTimeout)
+		_ = b.SendKeysRaw(sessionID, "C-c")
+		WaitForSessionExit(b, sessionID, constants.GracefulShutdownTimeout)
 	}
 
 	// Kill any detached agent-log watcher for this session before tearing down
 	// the tmux session, to avoid orphan processes accumulating over time.
 	DeactivateAgentLogging(sessionID)
 
-	if err := t.KillSessionWithProcesses(sessionID); err != nil {
+	if err := b.KillSessionWithProcesses(sessionID); err != nil {
 		return fmt.Errorf("killing session: %w", err)
 	}
 
@@ -424,8 +436,8 @@ func MergeRuntimeLivenessEnv(envVars map[string]string, runtimeConfig *config.Ru
 // If checkAlive is true, only kills zombie sessions (tmux alive but agent dead).
 // If the session exists and the agent is alive, returns ErrAlreadyRunning.
 // If checkAlive is false, kills any existing session unconditionally.
-func KillExistingSession(t *tmux.Tmux, sessionID string, checkAlive bool) (bool, error) {
-	running, err := t.HasSession(sessionID)
+func KillExistingSession(b SessionBackend, sessionID string, checkAlive bool) (bool, error) {
+	running, err := b.HasSession(sessionID)
 	if err != nil {
 		return false, fmt.Errorf("checking session: %w", err)
 	}
@@ -433,11 +445,11 @@ func KillExistingSession(t *tmux.Tmux, sessionID string, checkAlive bool) (bool,
 		return false, nil
 	}
 
-	if checkAlive && t.IsAgentAlive(sessionID) {
+	if checkAlive && b.IsAgentAlive(sessionID) {
 		return false, fmt.Errorf("session already running: %s", sessionID)
 	}
 
-	if err := t.KillSessionWithProcesses(sessionID); err != nil {
+	if err := b.KillSessionWithProcesses(sessionID); err != nil {
 		return false, fmt.Errorf("killing session %s: %w", sessionID, err)
 	}
 
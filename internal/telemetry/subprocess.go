@@ -79,22 +79,28 @@ func SetProcessOTELAttrs() {
 // (beads.go run, mail/bd.go runBdCommand) so the vars aren't lost when the
 // explicit env slice is built from scratch instead of os.Environ().
 //
-// Returns nil when GT telemetry is not active (GT_OTEL_METRICS_URL not set).
+// TRACEPARENT/TRACESTATE are passed through whenever set so subprocesses stay
+// in the bead's trace. Returns nil when GT telemetry is not active
+// (GT_OTEL_METRICS_URL not set) and no trace context is present.
 func OTELEnvForSubprocess() []string {
-	metricsURL := os.Getenv(EnvMetricsURL)
-	if metricsURL == "" {
-		return nil
-	}
 	var env []string
-	if attrs := buildGTResourceAttrs(); attrs != "" {
-		env = append(env, "OTEL_RESOURCE_ATTRIBUTES="+attrs)
-	}
-	env = append(env, "BD_OTEL_METRICS_URL="+metricsURL)
-	if logsURL := os.Getenv(EnvLogsURL); logsURL != "" {
-		env = append(env, "BD_OTEL_LOGS_URL="+logsURL)
-	}
-	if runID := os.Getenv("GT_RUN"); runID != "" {
-		env = append(env, "GT_RUN="+runID)
+	if metricsURL := os.Getenv(EnvMetricsURL); metricsURL != "" {
+		if attrs := buildGTResourceAttrs(); attrs != "" {
+			env = append(env, "OTEL_RESOURCE_ATTRIBUTES="+attrs)
+		}
+		env = append(env, "BD_OTEL_METRICS_URL="+metricsURL)
+		if logsURL := os.Getenv(EnvLogsURL); logsURL != "" {
+			env = append(env, "BD_OTEL_LOGS_URL="+logsURL)
+		}
+		if runID := os.Getenv("GT_RUN"); runID != "" {
+			env = append(env, "GT_RUN="+runID)
+		}
+	}
+	if tp := os.Getenv(EnvTraceParent); tp != "" {
+		env = append(env, EnvTraceParent+"="+tp)
+		if ts := os.Getenv(EnvTraceState); ts != "" {
+			env = append(env, EnvTraceState+"="+ts)
+		}
 	}
 	return env
 }
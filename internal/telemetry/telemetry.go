@@ -1,12 +1,19 @@
-// Package telemetry initializes OpenTelemetry providers for metric and log export.
+// Package telemetry initializes OpenTelemetry providers for metric, log and
+// trace export.
 //
 // Metrics → VictoriaMetrics via OTLP HTTP
 // Logs    → VictoriaLogs via OTLP HTTP
+// Traces  → any OTLP HTTP collector (opt-in)
 //
 // Enabled by setting at least one of:
 //
 //	GT_OTEL_METRICS_URL  (default: http://localhost:8428/opentelemetry/api/v1/push)
 //	GT_OTEL_LOGS_URL     (default: http://localhost:9428/insert/opentelemetry/v1/logs)
+//	GT_OTEL_TRACES_URL   (no default; e.g. http://localhost:4318/v1/traces)
+//
+// Traces follow a bead from gt sling to merge: the sling span's traceparent
+// is stored on the bead and MR bead and injected into agent sessions as
+// TRACEPARENT, so every gt process working the bead joins the same trace.
 //
 // Telemetry is best-effort: initialization errors are returned but do not
 // affect normal gt operation — callers should log and continue.
@@ -86,14 +93,15 @@ func (p *Provider) Shutdown(ctx context.Context) error {
 }
 
 // IsActive reports whether OTel telemetry is configured in the current process.
-// Returns true when at least one of GT_OTEL_METRICS_URL or GT_OTEL_LOGS_URL is set.
+// Returns true when at least one of GT_OTEL_METRICS_URL, GT_OTEL_LOGS_URL or
+// GT_OTEL_TRACES_URL is set.
 // Used to gate side-effectful operations (env var injection, tmux session updates)
 // that only make sense when telemetry is collecting data.
 func IsActive() bool {
-	return os.Getenv(EnvMetricsURL) != "" || os.Getenv(EnvLogsURL) != ""
+	return os.Getenv(EnvMetricsURL) != "" || os.Getenv(EnvLogsURL) != "" || os.Getenv(EnvTracesURL) != ""
 }
 
-// Init initializes OTel metric and log providers.
+// Init initializes OTel metric, log and trace providers.
 //
 // Idempotent: subsequent calls (same or different arguments) return the
 // provider created on the first call. The serviceName and serviceVersion
@@ -102,13 +110,15 @@ func IsActive() bool {
 // issue. If multiple packages call Init, ensure the entry-point (main or
 // cobra root) calls it first with the correct service name.
 //
-// Returns (nil, nil) if neither GT_OTEL_METRICS_URL nor GT_OTEL_LOGS_URL is set,
-// so that telemetry is strictly opt-in. Set either variable to activate.
+// Returns (nil, nil) if none of GT_OTEL_METRICS_URL, GT_OTEL_LOGS_URL or
+// GT_OTEL_TRACES_URL is set, so that telemetry is strictly opt-in.
 //
-// When active, defaults are used for any unset endpoint:
+// When metrics or logs are active, defaults are used for the other unset endpoint:
 //
 //	metrics → http://localhost:8428/opentelemetry/api/v1/push
 //	logs    → http://localhost:9428/insert/opentelemetry/v1/logs
+//
+// Tracing is enabled only when GT_OTEL_TRACES_URL is set.
 func Init(ctx context.Context, serviceName, serviceVersion string) (*Provider, error) {
 	initMu.Lock()
 	defer initMu.Unlock()
@@ -118,19 +128,14 @@ func Init(ctx context.Context, serviceName, serviceVersion string) (*Provider, e
 
 	metricsURL := os.Getenv(EnvMetricsURL)
 	logsURL := os.Getenv(EnvLogsURL)
+	tracesURL := os.Getenv(EnvTracesURL)
 
-	// Both unset → telemetry disabled, not an error.
-	if metricsURL == "" && logsURL == "" {
+	// All unset → telemetry disabled, not an error.
+	if metricsURL == "" && logsURL == "" && tracesURL == "" {
 		initDone = true
 		globalProvider = nil
 		return nil, nil
 	}
-	if metricsURL == "" {
-		metricsURL = DefaultMetricsURL
-	}
-	if logsURL == "" {
-		logsURL = DefaultLogsURL
-	}
 
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
@@ -146,6 +151,26 @@ func Init(ctx context.Context, serviceName, serviceVersion string) (*Provider, e
 
 	p := &Provider{}
 
+	// Traces → OTLP collector
+	if tracesURL != "" {
+		shutdown, err := initTracing(ctx, res, tracesURL)
+		if err != nil {
+			return nil, err
+		}
+		p.shutdowns = append(p.shutdowns, shutdown)
+	}
+	if metricsURL == "" && logsURL == "" {
+		initDone = true
+		globalProvider = p
+		return p, nil
+	}
+	if metricsURL == "" {
+		metricsURL = DefaultMetricsURL
+	}
+	if logsURL == "" {
+		logsURL = DefaultLogsURL
+	}
+
 	// Metrics → VictoriaMetrics
 	metricExp, err := otlpmetrichttp.New(ctx,
 		otlpmetrichttp.WithEndpointURL(metricsURL),
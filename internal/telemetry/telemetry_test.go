@@ -27,6 +27,7 @@ func TestInit_BothURLsUnset_ReturnsNil(t *testing.T) {
 	resetInitState(t)
 	t.Setenv(EnvMetricsURL, "")
 	t.Setenv(EnvLogsURL, "")
+	t.Setenv(EnvTracesURL, "")
 
 	p, err := Init(context.Background(), "test-svc", "0.0.1")
 	if err != nil {
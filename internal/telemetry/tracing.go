@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// EnvTracesURL is the env var for the OTLP/HTTP traces endpoint
+	// (e.g. http://localhost:4318/v1/traces). Tracing is off when unset.
+	EnvTracesURL = "GT_OTEL_TRACES_URL"
+
+	// EnvTraceParent carries the W3C traceparent of the current bead's
+	// lifecycle trace into agent sessions and subprocesses, following the
+	// OpenTelemetry environment-carrier convention.
+	EnvTraceParent = "TRACEPARENT"
+
+	// EnvTraceState carries the W3C tracestate alongside TRACEPARENT.
+	EnvTraceState = "TRACESTATE"
+
+	// tracerName is the instrumentation scope for gastown spans.
+	tracerName = "github.com/steveyegge/gastown"
+
+	// traceExportTimeout bounds a single OTLP trace export request.
+	traceExportTimeout = 10 * time.Second
+)
+
+// traceContext is the propagator for traceparent/tracestate values.
+var traceContext = propagation.TraceContext{}
+
+// initTracing installs the global TracerProvider exporting to tracesURL.
+// Returns the provider's shutdown function, which flushes pending spans.
+func initTracing(ctx context.Context, res *resource.Resource, tracesURL string) (func(context.Context) error, error) {
+	traceExp, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpointURL(tracesURL),
+		otlptracehttp.WithTimeout(traceExportTimeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(traceExp)),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(traceContext)
+	return tp.Shutdown, nil
+}
+
+// StartSpan starts a span named name on the gastown tracer.
+// When tracing is not initialized the span is a no-op that still carries
+// any parent span context already present in ctx. A nil ctx is treated as
+// context.Background().
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartSpanAt is StartSpan with an explicit start time, for spans that
+// cover work begun before the current process (e.g. queue wait time).
+func StartSpanAt(ctx context.Context, name string, start time.Time, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err (if any) on span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent returns the W3C traceparent for the span in ctx, or "" when
+// ctx carries no valid span context.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// ContextWithTraceParent returns ctx with the remote span described by
+// traceparent as its parent. Invalid or empty values leave ctx unchanged.
+func ContextWithTraceParent(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	return traceContext.Extract(ctx, propagation.MapCarrier{"traceparent": traceparent})
+}
+
+// ContextFromEnv returns ctx parented to the trace in TRACEPARENT/TRACESTATE,
+// so a gt process started inside a polecat session joins its bead's trace.
+func ContextFromEnv(ctx context.Context) context.Context {
+	tp := os.Getenv(EnvTraceParent)
+	if tp == "" {
+		return ctx
+	}
+	return traceContext.Extract(ctx, propagation.MapCarrier{
+		"traceparent": tp,
+		"tracestate":  os.Getenv(EnvTraceState),
+	})
+}
+
+// TraceEnv returns TRACEPARENT (and TRACESTATE) assignments for the span in
+// ctx, for subprocesses whose cmd.Env is built explicitly. Returns nil when
+// ctx carries no span context.
+func TraceEnv(ctx context.Context) []string {
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(ctx, carrier)
+	tp := carrier.Get("traceparent")
+	if tp == "" {
+		return nil
+	}
+	env := []string{EnvTraceParent + "=" + tp}
+	if ts := carrier.Get("tracestate"); ts != "" {
+		env = append(env, EnvTraceState+"="+ts)
+	}
+	return env
+}
@@ -0,0 +1,145 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTraceParent_RoundTrip(t *testing.T) {
+	ctx := ContextWithTraceParent(context.Background(), testTraceParent)
+	if got := TraceParent(ctx); got != testTraceParent {
+		t.Errorf("TraceParent = %q, want %q", got, testTraceParent)
+	}
+	if got := TraceParent(context.Background()); got != "" {
+		t.Errorf("TraceParent(empty ctx) = %q, want empty", got)
+	}
+	if got := TraceParent(ContextWithTraceParent(context.Background(), "garbage")); got != "" {
+		t.Errorf("invalid traceparent propagated as %q", got)
+	}
+}
+
+func TestContextFromEnv(t *testing.T) {
+	t.Setenv(EnvTraceParent, testTraceParent)
+	t.Setenv(EnvTraceState, "gt=1")
+	ctx := ContextFromEnv(context.Background())
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || !sc.IsRemote() {
+		t.Fatalf("span context not extracted: %+v", sc)
+	}
+	env := TraceEnv(ctx)
+	want := []string{EnvTraceParent + "=" + testTraceParent, EnvTraceState + "=gt=1"}
+	if strings.Join(env, " ") != strings.Join(want, " ") {
+		t.Errorf("TraceEnv = %v, want %v", env, want)
+	}
+
+	t.Setenv(EnvTraceParent, "")
+	if TraceEnv(ContextFromEnv(context.Background())) != nil {
+		t.Error("TraceEnv should be nil without TRACEPARENT")
+	}
+}
+
+func TestOTELEnvForSubprocess_PassesTraceParent(t *testing.T) {
+	t.Setenv(EnvMetricsURL, "")
+	t.Setenv(EnvTraceParent, testTraceParent)
+	t.Setenv(EnvTraceState, "")
+	env := OTELEnvForSubprocess()
+	if len(env) != 1 || env[0] != EnvTraceParent+"="+testTraceParent {
+		t.Errorf("OTELEnvForSubprocess = %v", env)
+	}
+}
+
+func TestInitTracing_ExportsOTLP(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies [][]byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-protobuf" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+
+	shutdown, err := initTracing(context.Background(), resource.Empty(), srv.URL+"/v1/traces")
+	if err != nil {
+		t.Fatalf("initTracing: %v", err)
+	}
+	parent := ContextWithTraceParent(context.Background(), testTraceParent)
+	ctx, span := StartSpan(parent, "gt.sling", attribute.String("gt.bead", "gt-abc"))
+	_, child := StartSpan(ctx, "polecat.spawn")
+	EndSpan(child, errors.New("boom"))
+	EndSpan(span, nil)
+	// Shutdown flushes the batch processor.
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	all := bytes.Join(bodies, nil)
+	traceID := trace.SpanContextFromContext(parent).TraceID()
+	for _, want := range [][]byte{[]byte("gt.sling"), []byte("polecat.spawn"), []byte("gt-abc"), traceID[:]} {
+		if !bytes.Contains(all, want) {
+			t.Errorf("export is missing %q", want)
+		}
+	}
+}
+
+func TestEndSpan_RecordsError(t *testing.T) {
+	exp := &recordingExporter{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	_, span := tp.Tracer(tracerName).Start(context.Background(), "refinery.gate")
+	EndSpan(span, errors.New("gate failed"))
+	_ = tp.Shutdown(context.Background())
+
+	if len(exp.spans) != 1 {
+		t.Fatalf("got %d spans", len(exp.spans))
+	}
+	s := exp.spans[0]
+	if s.Status().Code != codes.Error || s.Status().Description != "gate failed" {
+		t.Errorf("status = %+v", s.Status())
+	}
+	if len(s.Events()) != 1 || s.Events()[0].Name != "exception" {
+		t.Errorf("events = %v", s.Events())
+	}
+}
+
+type recordingExporter struct {
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (e *recordingExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error { return nil }
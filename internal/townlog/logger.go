@@ -1,4 +1,7 @@
 // Package townlog provides centralized logging for Gas Town agent lifecycle events.
+//
+// Events are written as human-readable lines to ~/gt/logs/town.log and
+// published on the event bus with source "townlog".
 package townlog
 
 import (
@@ -7,6 +10,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/eventbus"
 )
 
 // EventType represents the type of agent lifecycle event.
@@ -55,8 +60,9 @@ type Event struct {
 
 // Logger handles writing events to the town log file.
 type Logger struct {
-	logPath string
-	mu      sync.Mutex
+	townRoot string // Empty disables event bus publishing
+	logPath  string
+	mu       sync.Mutex
 }
 
 // logDir returns the directory for town logs.
@@ -72,7 +78,8 @@ func logPath(townRoot string) string {
 // NewLogger creates a new Logger for the given town root.
 func NewLogger(townRoot string) *Logger {
 	return &Logger{
-		logPath: logPath(townRoot),
+		townRoot: townRoot,
+		logPath:  logPath(townRoot),
 	}
 }
 
@@ -99,9 +106,29 @@ func (l *Logger) LogEvent(event Event) error {
 		return fmt.Errorf("writing log line: %w", err)
 	}
 
+	if l.townRoot != "" {
+		// Best-effort: town.log is the durable copy.
+		_, _ = eventbus.Append(l.townRoot, busEvent(event))
+	}
 	return nil
 }
 
+// busEvent converts a town log event for the event bus. Lifecycle lines are
+// audit-only; the activity feed gets its own events from the events package.
+func busEvent(e Event) eventbus.Event {
+	ev := eventbus.Event{
+		Timestamp:  e.Timestamp.UTC().Format(time.RFC3339),
+		Source:     eventbus.SourceTownlog,
+		Type:       string(e.Type),
+		Actor:      e.Agent,
+		Visibility: "audit",
+	}
+	if e.Context != "" {
+		ev.Payload = map[string]interface{}{"context": e.Context}
+	}
+	return ev
+}
+
 // Log is a convenience method that creates an Event and logs it.
 func (l *Logger) Log(eventType EventType, agent, context string) error {
 	return l.LogEvent(Event{
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/eventbus"
 )
 
 func TestFormatLogLine(t *testing.T) {
@@ -162,6 +164,18 @@ func TestLoggerLogEvent(t *testing.T) {
 	if !strings.Contains(string(content), "gastown/crew/max") {
 		t.Errorf("log file should contain agent name, got: %s", content)
 	}
+
+	// The event is also published on the bus.
+	var got []eventbus.Event
+	if _, err := eventbus.ReadFrom(tmpDir, 0, eventbus.Filter{Sources: []string{eventbus.SourceTownlog}}, func(ev eventbus.Event) error {
+		got = append(got, ev)
+		return nil
+	}); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	if len(got) != 1 || got[0].Type != "spawn" || got[0].Actor != "gastown/crew/max" || got[0].Payload["context"] != "gt-xyz" {
+		t.Errorf("unexpected bus events: %+v", got)
+	}
 }
 
 func TestFilterEvents(t *testing.T) {
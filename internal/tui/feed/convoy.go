@@ -16,8 +16,10 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	convoyops "github.com/steveyegge/gastown/internal/convoy"
 )
 
 // convoyIDPattern validates convoy IDs.
@@ -32,6 +34,9 @@ type Convoy struct {
 	Total     int       `json:"total"`
 	CreatedAt time.Time `json:"created_at"`
 	ClosedAt  time.Time `json:"closed_at,omitempty"`
+
+	// Forecast of an open convoy's remaining work; nil when not forecast.
+	Forecast *convoyops.Forecast `json:"forecast,omitempty"`
 }
 
 // MQEntry represents a single merge request in the merge queue
@@ -68,9 +73,13 @@ func FetchConvoys(townRoot string) (*ConvoyState, error) {
 		return state, nil
 	}
 
+	// Merge history and capacity are shared by every open convoy's forecast.
+	fc := &convoyForecaster{townRoot: townRoot, capacity: convoyops.SchedulerCapacity(townRoot)}
+	fc.hist, _ = convoyops.LoadDurationHistory(townRoot)
+
 	for _, c := range openConvoys {
 		// Get detailed status for each convoy
-		convoy := enrichConvoy(townBeads, c)
+		convoy := enrichConvoy(townBeads, c, fc)
 		state.InProgress = append(state.InProgress, convoy)
 	}
 
@@ -79,7 +88,7 @@ func FetchConvoys(townRoot string) (*ConvoyState, error) {
 	if err == nil {
 		cutoff := time.Now().Add(-24 * time.Hour)
 		for _, c := range closedConvoys {
-			convoy := enrichConvoy(townBeads, c)
+			convoy := enrichConvoy(townBeads, c, nil)
 			if !convoy.ClosedAt.IsZero() && convoy.ClosedAt.After(cutoff) {
 				state.Landed = append(state.Landed, convoy)
 			}
@@ -132,8 +141,33 @@ type convoyListItem struct {
 	ClosedAt  string `json:"closed_at,omitempty"`
 }
 
-// enrichConvoy adds tracked issue counts to a convoy
-func enrichConvoy(beadsDir string, item convoyListItem) Convoy {
+// convoyForecaster projects ETAs for open convoys.
+type convoyForecaster struct {
+	townRoot string
+	hist     *convoyops.DurationHistory
+	capacity int
+}
+
+// forecast projects when the slingable tracked issues will be merged.
+func (fc *convoyForecaster) forecast(tracked []trackedStatus) *convoyops.Forecast {
+	tasks := make([]convoyops.ForecastTask, 0, len(tracked))
+	for _, t := range tracked {
+		if !convoyops.IsSlingableType(t.Type) {
+			continue
+		}
+		tasks = append(tasks, convoyops.ForecastTask{
+			ID:        t.ID,
+			Rig:       beads.GetRigNameForPrefix(fc.townRoot, beads.ExtractPrefix(t.ID)),
+			Status:    t.Status,
+			BlockedBy: t.BlockedBy,
+		})
+	}
+	return convoyops.ForecastTasks(tasks, fc.hist, fc.capacity, time.Now())
+}
+
+// enrichConvoy adds tracked issue counts to a convoy, and a forecast when fc
+// is non-nil.
+func enrichConvoy(beadsDir string, item convoyListItem, fc *convoyForecaster) Convoy {
 	convoy := Convoy{
 		ID:     item.ID,
 		Title:  item.Title,
@@ -160,6 +194,9 @@ func enrichConvoy(beadsDir string, item convoyListItem) Convoy {
 			convoy.Completed++
 		}
 	}
+	if fc != nil && convoy.Completed < convoy.Total {
+		convoy.Forecast = fc.forecast(tracked)
+	}
 
 	return convoy
 }
@@ -194,6 +231,9 @@ var (
 
 	ConvoyAgeStyle = lipgloss.NewStyle().
 			Foreground(colorDim)
+
+	ConvoyCriticalStyle = lipgloss.NewStyle().
+				Foreground(colorWarning)
 )
 
 // renderConvoyPanel renders the convoy status panel
@@ -277,7 +317,18 @@ func renderConvoyLine(c Convoy, landed bool) string {
 	// Show progress bar
 	progress := renderProgressBar(c.Completed, c.Total)
 	count := ConvoyProgressStyle.Render(fmt.Sprintf("%d/%d", c.Completed, c.Total))
-	return fmt.Sprintf("  %s  %-20s  %s %s", id, title, count, progress)
+	line := fmt.Sprintf("  %s  %-20s  %s %s", id, title, count, progress)
+
+	// ETA, with the critical path on its own line beneath
+	f := c.Forecast
+	if f == nil || f.Done() {
+		return line
+	}
+	line += "  " + ConvoyAgeStyle.Render("ETA ~"+convoyops.FormatRemaining(f.Remaining))
+	if len(f.CriticalPath) > 1 {
+		line += "\n      " + ConvoyCriticalStyle.Render("◆ "+strings.Join(f.CriticalPath, " → "))
+	}
+	return line
 }
 
 // renderMQLine renders a single merge queue entry
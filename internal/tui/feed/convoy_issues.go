@@ -8,11 +8,14 @@ import (
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/constants"
+	convoyops "github.com/steveyegge/gastown/internal/convoy"
 )
 
 type trackedStatus struct {
-	ID     string
-	Status string
+	ID        string
+	Status    string
+	Type      string   // issue type, when the batch show succeeded
+	BlockedBy []string // blocking dependencies, for ETA forecasting
 }
 
 
@@ -50,25 +53,26 @@ func getTrackedIssueStatus(beadsDir, convoyID string) []trackedStatus {
 	// Refresh status via cross-rig lookup. bd dep list returns status from
 	// the dependency record in HQ beads which is never updated when cross-rig
 	// issues (e.g., gt-* tracked by hq-* convoys) are closed in their rig.
-	freshStatus := refreshTrackedStatus(ctx, deps)
+	fresh := refreshTrackedStatus(ctx, deps)
 
 	var tracked []trackedStatus
 	for _, dep := range deps {
-		status := dep.Status
-		if fresh, ok := freshStatus[dep.ID]; ok {
-			status = fresh
+		if t, ok := fresh[dep.ID]; ok {
+			tracked = append(tracked, t)
+			continue
 		}
-		tracked = append(tracked, trackedStatus{ID: dep.ID, Status: status})
+		tracked = append(tracked, trackedStatus{ID: dep.ID, Status: dep.Status})
 	}
 
 	return tracked
 }
 
-// refreshTrackedStatus does a batch bd show to get current status for tracked issues.
+// refreshTrackedStatus does a batch bd show to get current status, type and
+// blocking dependencies for tracked issues.
 func refreshTrackedStatus(ctx context.Context, deps []struct {
 	ID     string `json:"id"`
 	Status string `json:"status"`
-}) map[string]string {
+}) map[string]trackedStatus {
 	if len(deps) == 0 {
 		return nil
 	}
@@ -88,16 +92,27 @@ func refreshTrackedStatus(ctx context.Context, deps []struct {
 	}
 
 	var issues []struct {
-		ID     string `json:"id"`
-		Status string `json:"status"`
+		ID           string `json:"id"`
+		Status       string `json:"status"`
+		IssueType    string `json:"issue_type"`
+		Dependencies []struct {
+			ID             string `json:"id"`
+			DependencyType string `json:"dependency_type"`
+		} `json:"dependencies"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
 		return nil
 	}
 
-	result := make(map[string]string, len(issues))
+	result := make(map[string]trackedStatus, len(issues))
 	for _, issue := range issues {
-		result[issue.ID] = issue.Status
+		t := trackedStatus{ID: issue.ID, Status: issue.Status, Type: issue.IssueType}
+		for _, dep := range issue.Dependencies {
+			if convoyops.IsBlockingDepType(dep.DependencyType) {
+				t.BlockedBy = append(t.BlockedBy, beads.ExtractIssueID(dep.ID))
+			}
+		}
+		result[issue.ID] = t
 	}
 	return result
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/eventbus"
 )
 
 // EventSource represents a source of events
@@ -213,9 +214,10 @@ func parseBeadContext(beadID string) (actor, rig, role string) {
 	return
 }
 
-// GtEventsSource reads events from ~/gt/.events.jsonl (gt activity log)
+// GtEventsSource follows feed-visible gt activity events on the event bus
+// (journal ~/gt/.events.jsonl).
 type GtEventsSource struct {
-	file   *os.File
+	sub    *eventbus.Subscription
 	events chan Event
 	cancel context.CancelFunc
 }
@@ -230,97 +232,48 @@ type GtEvent struct {
 	Visibility string                 `json:"visibility"`
 }
 
-// NewGtEventsSource creates a source that tails ~/gt/.events.jsonl
+// gtRecentEvents is how many journal events GtEventsSource replays for the
+// initial display.
+const gtRecentEvents = 200
+
+// NewGtEventsSource creates a source that replays recent feed-visible events
+// and then follows new ones on the town's event bus.
 func NewGtEventsSource(townRoot string) (*GtEventsSource, error) {
-	eventsPath := filepath.Join(townRoot, ".events.jsonl")
-	file, err := os.Open(eventsPath)
+	if _, err := os.Stat(eventbus.JournalPath(townRoot)); err != nil {
+		return nil, err
+	}
+	from, err := eventbus.OffsetOfLast(townRoot, gtRecentEvents)
 	if err != nil {
 		return nil, err
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := eventbus.Subscribe(ctx, townRoot, eventbus.SubscribeRequest{
+		From:   from,
+		Filter: eventbus.Filter{Sources: []string{eventbus.SourceGT}, Visibility: []string{"feed", "both"}},
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
 
 	source := &GtEventsSource{
-		file:   file,
+		sub:    sub,
 		events: make(chan Event, 200),
 		cancel: cancel,
 	}
 
-	go source.tail(ctx)
+	go source.run()
 
 	return source, nil
 }
 
-// tail loads recent history then follows the file for new events.
-func (s *GtEventsSource) tail(ctx context.Context) {
+// run converts bus events for the TUI, dropping them if the display falls
+// behind.
+func (s *GtEventsSource) run() {
 	defer close(s.events)
-
-	// Load recent events (last 200 lines) for initial display
-	s.loadRecentEvents()
-
-	// Seek to true EOF so the tail scanner starts cleanly,
-	// regardless of the preload scanner's internal read-ahead buffer.
-	_, _ = s.file.Seek(0, 2)
-
-	// Now tail for new events
-	scanner := bufio.NewScanner(s.file)
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			for scanner.Scan() {
-				line := scanner.Text()
-				if event := parseGtEventLine(line); event != nil {
-					select {
-					case s.events <- *event:
-					default:
-					}
-				}
-			}
-		}
-	}
-}
-
-// loadRecentEvents reads the last N lines of the file and emits them as events.
-// Uses a ring buffer so memory is O(maxLines) regardless of file size.
-func (s *GtEventsSource) loadRecentEvents() {
-	const maxLines = 200
-
-	if _, err := s.file.Seek(0, 0); err != nil {
-		return
-	}
-
-	// Ring buffer: only keep the last maxLines lines in memory
-	ring := make([]string, maxLines)
-	idx := 0
-	count := 0
-
-	scanner := bufio.NewScanner(s.file)
-	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
-	for scanner.Scan() {
-		ring[idx%maxLines] = scanner.Text()
-		idx++
-		count++
-	}
-	if scanner.Err() != nil {
-		// Scanner failed (e.g. token too long) — seek to EOF so tail starts clean
-		_, _ = s.file.Seek(0, 2)
-		return
-	}
-
-	// Emit lines in order (oldest first)
-	n := count
-	if n > maxLines {
-		n = maxLines
-	}
-	start := idx - n
-	for i := start; i < idx; i++ {
-		line := ring[i%maxLines]
-		if event := parseGtEventLine(line); event != nil {
+	for ev := range s.sub.Events() {
+		if event := gtEventFromBus(ev); event != nil {
 			select {
 			case s.events <- *event:
 			default:
@@ -337,7 +290,25 @@ func (s *GtEventsSource) Events() <-chan Event {
 // Close stops the source
 func (s *GtEventsSource) Close() error {
 	s.cancel()
-	return s.file.Close()
+	s.sub.Close()
+	return nil
+}
+
+// gtEventFromBus converts a bus event for display.
+func gtEventFromBus(ev eventbus.Event) *Event {
+	ev.Offset = 0
+	raw, err := json.Marshal(ev)
+	if err != nil {
+		return nil
+	}
+	return convertGtEvent(GtEvent{
+		Timestamp:  ev.Timestamp,
+		Source:     ev.Source,
+		Type:       ev.Type,
+		Actor:      ev.Actor,
+		Payload:    ev.Payload,
+		Visibility: ev.Visibility,
+	}, string(raw))
 }
 
 // parseGtEventLine parses a line from .events.jsonl
@@ -350,11 +321,18 @@ func parseGtEventLine(line string) *Event {
 	if err := json.Unmarshal([]byte(line), &ge); err != nil {
 		return nil
 	}
+	return convertGtEvent(ge, line)
+}
 
-	// Only show feed-visible events
+// convertGtEvent builds a display event from a parsed gt event. Only
+// feed-visible activity events are shown.
+func convertGtEvent(ge GtEvent, line string) *Event {
 	if ge.Visibility != "feed" && ge.Visibility != "both" {
 		return nil
 	}
+	if !eventbus.IsActivitySource(ge.Source) {
+		return nil
+	}
 
 	t, err := time.Parse(time.RFC3339, ge.Timestamp)
 	if err != nil {
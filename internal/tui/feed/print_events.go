@@ -4,12 +4,15 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/eventbus"
 )
 
 // PrintOptions controls filtering and behavior for PrintGtEvents.
@@ -24,8 +27,8 @@ type PrintOptions struct {
 }
 
 // PrintGtEvents reads .events.jsonl and prints events to stdout.
-// When opts.Follow is true, it tails the file for new events after printing
-// the initial batch, polling every 200ms. Canceled via opts.Ctx or SIGINT.
+// When opts.Follow is true, it follows new events on the event bus after
+// printing the initial batch. Canceled via opts.Ctx or SIGINT.
 func PrintGtEvents(townRoot string, opts PrintOptions) error {
 	eventsPath := filepath.Join(townRoot, ".events.jsonl")
 	file, err := os.Open(eventsPath)
@@ -34,6 +37,13 @@ func PrintGtEvents(townRoot string, opts PrintOptions) error {
 	}
 	defer file.Close()
 
+	// Read only what exists now; follow mode picks up from here.
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat events file: %w", err)
+	}
+	end := info.Size()
+
 	// Parse --since into a cutoff time
 	var sinceTime time.Time
 	if opts.Since != "" {
@@ -45,7 +55,7 @@ func PrintGtEvents(townRoot string, opts PrintOptions) error {
 	}
 
 	var events []Event
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(io.LimitReader(file, end))
 	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
 
 	for scanner.Scan() {
@@ -89,10 +99,6 @@ func PrintGtEvents(townRoot string, opts PrintOptions) error {
 		return nil
 	}
 
-	// Tail mode: poll for new lines using a fresh scanner each tick.
-	// bufio.Scanner sets an internal 'done' flag after EOF and won't retry,
-	// so we must create a new scanner each poll cycle while preserving the
-	// file offset (os.File tracks position across scanner instances).
 	ctx := opts.Ctx
 	if ctx == nil {
 		var stop context.CancelFunc
@@ -100,26 +106,23 @@ func PrintGtEvents(townRoot string, opts PrintOptions) error {
 		defer stop()
 	}
 
-	ticker := time.NewTicker(200 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		case <-ticker.C:
-			s := bufio.NewScanner(file)
-			s.Buffer(make([]byte, 1024*1024), 1024*1024)
-			for s.Scan() {
-				line := s.Text()
-				if event := parseGtEventLine(line); event != nil {
-					if matchesFilters(event, sinceTime, opts.Mol, opts.Type, opts.Rig) {
-						printEvent(*event)
-					}
-				}
+	sub, err := eventbus.Subscribe(ctx, townRoot, eventbus.SubscribeRequest{
+		From:   end,
+		Filter: eventbus.Filter{Sources: []string{eventbus.SourceGT}},
+	})
+	if err != nil {
+		return fmt.Errorf("subscribing to events: %w", err)
+	}
+	defer sub.Close()
+
+	for ev := range sub.Events() {
+		if event := gtEventFromBus(ev); event != nil {
+			if matchesFilters(event, sinceTime, opts.Mol, opts.Type, opts.Rig) {
+				printEvent(*event)
 			}
 		}
 	}
+	return sub.Err()
 }
 
 // matchesFilters checks whether an event passes the --since, --mol, --type, and --rig filters.
@@ -10,13 +10,16 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // CommandRequest is the JSON request body for /api/run.
@@ -50,6 +53,9 @@ type APIHandler struct {
 	gtPath string
 	// workDir is the working directory for command execution.
 	workDir string
+	// townRoot is the Gas Town workspace containing workDir, if any. The SSE
+	// handler follows its event bus.
+	townRoot string
 	// Configurable timeouts (from TownSettings.WebTimeouts)
 	defaultRunTimeout time.Duration
 	maxRunTimeout     time.Duration
@@ -77,9 +83,11 @@ func NewAPIHandler(defaultRunTimeout, maxRunTimeout time.Duration, csrfToken str
 	// Use PATH lookup for gt binary. Do NOT use os.Executable() here - during
 	// tests it returns the test binary, causing fork bombs when executed.
 	workDir, _ := os.Getwd()
+	townRoot, _ := workspace.Find(workDir)
 	return &APIHandler{
 		gtPath:            "gt",
 		workDir:           workDir,
+		townRoot:          townRoot,
 		defaultRunTimeout: defaultRunTimeout,
 		maxRunTimeout:     maxRunTimeout,
 		cmdSem:            make(chan struct{}, maxConcurrentCommands),
@@ -97,8 +105,9 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate CSRF token on all POST requests.
-	if r.Method == http.MethodPost && h.csrfToken != "" {
+	// Validate CSRF token on all POST requests. Requests authenticated with
+	// an Authorization bearer header are exempt (see csrfExempt).
+	if r.Method == http.MethodPost && h.csrfToken != "" && !csrfExempt(r) {
 		if r.Header.Get("X-Dashboard-Token") != h.csrfToken {
 			h.sendError(w, "Invalid or missing dashboard token", http.StatusForbidden)
 			return
@@ -2058,10 +2067,21 @@ func parseCommandArgs(command string) []string {
 	return args
 }
 
+// SSE refresh timing. Without the event bus the dashboard state is re-hashed
+// every ssePollInterval. With it, state is re-hashed after bus activity
+// (coalesced to ssePollInterval) and at least every sseIdleRecheck, for
+// changes that publish no events.
+const (
+	ssePollInterval = 2 * time.Second
+	sseIdleRecheck  = 30 * time.Second
+)
+
 // handleSSE streams Server-Sent Events to the dashboard client.
-// It polls key dashboard state every 2 seconds and sends an event when
-// changes are detected, allowing the client to trigger a re-render.
-// Falls through gracefully if the client disconnects.
+// Feed-visible events from the town's event bus are forwarded as "activity"
+// events whose id is the bus offset, so a reconnecting client (which sends
+// Last-Event-ID) resumes without gaps. A "dashboard-update" event is sent
+// when key dashboard state changes, allowing the client to trigger a
+// re-render. Falls through gracefully if the client disconnects.
 func (h *APIHandler) handleSSE(w http.ResponseWriter, r *http.Request) {
 	flusher, ok := w.(http.Flusher)
 	if !ok {
@@ -2080,8 +2100,22 @@ func (h *APIHandler) handleSSE(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "event: connected\ndata: ok\n\n")
 	flusher.Flush()
 
+	var busEvents <-chan eventbus.Event
+	if h.townRoot != "" {
+		sub, err := eventbus.Subscribe(ctx, h.townRoot, eventbus.SubscribeRequest{
+			From:   sseReplayFrom(r),
+			Filter: eventbus.Filter{Sources: []string{eventbus.SourceGT}, Visibility: []string{"feed", "both"}},
+		})
+		if err == nil {
+			defer sub.Close()
+			busEvents = sub.Events()
+		}
+	}
+
 	var lastHash string
-	ticker := time.NewTicker(2 * time.Second)
+	var lastCheck time.Time
+	dirty := true
+	ticker := time.NewTicker(ssePollInterval)
 	defer ticker.Stop()
 
 	// Send keepalive comment every 15 seconds to prevent connection timeouts
@@ -2095,7 +2129,22 @@ func (h *APIHandler) handleSSE(w http.ResponseWriter, r *http.Request) {
 		case <-keepalive.C:
 			fmt.Fprintf(w, ": keepalive\n\n")
 			flusher.Flush()
+		case ev, ok := <-busEvents:
+			if !ok {
+				busEvents = nil // Subscription ended; keep polling
+				continue
+			}
+			if data, err := json.Marshal(ev); err == nil {
+				fmt.Fprintf(w, "id: %d\nevent: activity\ndata: %s\n\n", ev.Offset, data)
+				flusher.Flush()
+			}
+			dirty = true
 		case <-ticker.C:
+			if busEvents != nil && !dirty && time.Since(lastCheck) < sseIdleRecheck {
+				continue
+			}
+			dirty = false
+			lastCheck = time.Now()
 			hash := h.computeDashboardHash(ctx)
 			if hash != "" && hash != lastHash {
 				lastHash = hash
@@ -2106,6 +2155,17 @@ func (h *APIHandler) handleSSE(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// sseReplayFrom returns the bus offset an SSE stream starts at: just past the
+// client's Last-Event-ID when it is reconnecting, otherwise new events only.
+func sseReplayFrom(r *http.Request) int64 {
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		if off, err := strconv.ParseInt(id, 10, 64); err == nil && off >= 0 {
+			return off + 1
+		}
+	}
+	return eventbus.FromEnd
+}
+
 // computeDashboardHash generates a lightweight hash of key dashboard state.
 // It runs quick commands in parallel and hashes their output to detect changes.
 func (h *APIHandler) computeDashboardHash(ctx context.Context) string {
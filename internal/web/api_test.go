@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/session"
 )
 
@@ -878,6 +880,42 @@ func TestAPIHandler_SSE_ContentType(t *testing.T) {
 	}
 }
 
+func TestAPIHandler_SSE_ForwardsBusEvents(t *testing.T) {
+	townRoot := t.TempDir()
+	handler := NewAPIHandler(30*time.Second, 60*time.Second, "test-token")
+	handler.townRoot = townRoot
+	handler.gtPath = "true" // dashboard hash commands are no-ops
+
+	// An event the client already saw, and one it missed while disconnected.
+	seen, err := eventbus.Append(townRoot, eventbus.Event{Source: "gt", Type: "sling", Actor: "mayor", Visibility: "feed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := eventbus.Append(townRoot, eventbus.Event{Source: "gt", Type: "done", Actor: "gastown/polecats/Toast", Visibility: "feed"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := eventbus.Append(townRoot, eventbus.Event{Source: "gt", Type: "internal", Visibility: "audit"}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/events", nil)
+	req.Header.Set("Last-Event-ID", strconv.FormatInt(seen, 10))
+	ctx, cancel := context.WithTimeout(req.Context(), 300*time.Millisecond)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	if strings.Count(body, "event: activity") != 1 {
+		t.Fatalf("expected exactly the missed feed event, got:\n%s", body)
+	}
+	if !strings.Contains(body, `"type":"done"`) || strings.Contains(body, `"type":"sling"`) {
+		t.Errorf("unexpected activity events:\n%s", body)
+	}
+}
+
 // TestOptionsCacheConcurrentAccess verifies that concurrent cache reads and
 // writes don't race. The read lock is held through serialization so a
 // concurrent writer can't replace the cached pointer mid-encode.
@@ -0,0 +1,666 @@
+package web
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/refinery"
+)
+
+// openAPIV1 is the OpenAPI 3 document describing /api/v1.
+//
+//go:embed openapi_v1.json
+var openAPIV1 []byte
+
+// defaultMailAddress is the mailbox read and written by the dashboard when a
+// request does not name one: the human operator.
+const defaultMailAddress = "overseer"
+
+// errV1NotFound marks backend lookups that found nothing; handlers map it to 404.
+var errV1NotFound = errors.New("not found")
+
+// APIv1Backend supplies the data served by /api/v1. The live implementation
+// reads the Go packages directly; tests substitute a fake.
+type APIv1Backend interface {
+	Rigs() ([]string, error)
+	Polecats(rig string) ([]*polecat.Polecat, error)
+	Crew(rig string) ([]*crew.CrewWorker, error)
+	MergeQueue(rig string) ([]*refinery.MRInfo, error)
+	Issues(opts beads.ListOptions) ([]*beads.Issue, error)
+	Issue(id string) (*beads.Issue, error)
+	Convoys(status string) ([]*beads.Issue, error)
+	Convoy(id string) (*beads.Issue, []*beads.Issue, error)
+	Inbox(address string) ([]*mail.Message, error)
+	Message(address, id string) (*mail.Message, error)
+	SendMail(msg *mail.Message) error
+}
+
+// ── Stable v1 schemas ─────────────────────────────────────────────────────────
+//
+// These types are the /api/v1 contract documented in openapi_v1.json. They are
+// deliberately separate from the internal package types so internal changes do
+// not leak into the API: add fields, never rename or remove them.
+
+// V1Error is the body of every non-2xx /api/v1 response.
+type V1Error struct {
+	Error V1ErrorDetail `json:"error"`
+}
+
+// V1ErrorDetail describes an /api/v1 error.
+type V1ErrorDetail struct {
+	Code    string `json:"code"` // bad_request, not_found, forbidden, internal
+	Message string `json:"message"`
+}
+
+// V1Rig is a rig registered in the town.
+type V1Rig struct {
+	Name string `json:"name"`
+}
+
+// V1Polecat is a polecat worker.
+type V1Polecat struct {
+	Name      string    `json:"name"`
+	Rig       string    `json:"rig"`
+	State     string    `json:"state"`
+	Branch    string    `json:"branch,omitempty"`
+	Issue     string    `json:"issue,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// V1CrewWorker is a crew workspace.
+type V1CrewWorker struct {
+	Name      string    `json:"name"`
+	Rig       string    `json:"rig"`
+	Branch    string    `json:"branch,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// V1MergeRequest is a merge request in a rig's refinery queue.
+type V1MergeRequest struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Branch      string    `json:"branch"`
+	Target      string    `json:"target"`
+	SourceIssue string    `json:"source_issue,omitempty"`
+	Worker      string    `json:"worker,omitempty"`
+	Priority    int       `json:"priority"`
+	ConvoyID    string    `json:"convoy_id,omitempty"`
+	BlockedBy   string    `json:"blocked_by,omitempty"`
+	Assignee    string    `json:"assignee,omitempty"`
+	RetryCount  int       `json:"retry_count"`
+	PRURL       string    `json:"pr_url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// V1Dependency is a link from an issue to another issue.
+type V1Dependency struct {
+	ID     string `json:"id"`
+	Type   string `json:"type,omitempty"` // blocks, parent-child, tracks, …
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// V1Issue is a bead.
+type V1Issue struct {
+	ID           string         `json:"id"`
+	Title        string         `json:"title"`
+	Description  string         `json:"description,omitempty"`
+	Status       string         `json:"status"`
+	Priority     int            `json:"priority"`
+	Type         string         `json:"type"`
+	Assignee     string         `json:"assignee,omitempty"`
+	Parent       string         `json:"parent,omitempty"`
+	Labels       []string       `json:"labels"`
+	Dependencies []V1Dependency `json:"dependencies"`
+	CreatedAt    string         `json:"created_at,omitempty"`
+	UpdatedAt    string         `json:"updated_at,omitempty"`
+	ClosedAt     string         `json:"closed_at,omitempty"`
+}
+
+// V1Convoy is a convoy with its tracked issues.
+type V1Convoy struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Labels    []string  `json:"labels"`
+	CreatedAt string    `json:"created_at,omitempty"`
+	Completed int       `json:"completed"`
+	Total     int       `json:"total"`
+	Tracked   []V1Issue `json:"tracked,omitempty"`
+}
+
+// V1MailMessage is a mail message.
+type V1MailMessage struct {
+	ID        string    `json:"id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Read      bool      `json:"read"`
+	Priority  string    `json:"priority"`
+	Type      string    `json:"type,omitempty"`
+	ThreadID  string    `json:"thread_id,omitempty"`
+	ReplyTo   string    `json:"reply_to,omitempty"`
+}
+
+// V1SendMailRequest is the body of POST /api/v1/mail/messages.
+type V1SendMailRequest struct {
+	To       string `json:"to"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body,omitempty"`
+	Priority string `json:"priority,omitempty"` // low, normal, high, urgent
+	ReplyTo  string `json:"reply_to,omitempty"`
+	From     string `json:"from,omitempty"` // must be the caller's mailbox ("overseer") if set
+}
+
+// V1List wraps every collection response.
+type V1List[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+// V1Inbox is the response for GET /api/v1/mail/inbox.
+type V1Inbox struct {
+	Address     string          `json:"address"`
+	Items       []V1MailMessage `json:"items"`
+	Total       int             `json:"total"`
+	UnreadCount int             `json:"unread_count"`
+}
+
+func newV1List[T any](items []T) V1List[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return V1List[T]{Items: items, Total: len(items)}
+}
+
+func toV1Issue(issue *beads.Issue) V1Issue {
+	v := V1Issue{
+		ID:           issue.ID,
+		Title:        issue.Title,
+		Description:  issue.Description,
+		Status:       issue.Status,
+		Priority:     issue.Priority,
+		Type:         issue.Type,
+		Assignee:     issue.Assignee,
+		Parent:       issue.Parent,
+		Labels:       issue.Labels,
+		Dependencies: []V1Dependency{},
+		CreatedAt:    issue.CreatedAt,
+		UpdatedAt:    issue.UpdatedAt,
+		ClosedAt:     issue.ClosedAt,
+	}
+	if v.Labels == nil {
+		v.Labels = []string{}
+	}
+	for _, dep := range issue.Dependencies {
+		v.Dependencies = append(v.Dependencies, V1Dependency{
+			ID: dep.ID, Type: dep.DependencyType, Title: dep.Title, Status: dep.Status,
+		})
+	}
+	return v
+}
+
+func toV1Mail(msg *mail.Message) V1MailMessage {
+	return V1MailMessage{
+		ID:        msg.ID,
+		From:      msg.From,
+		To:        msg.To,
+		Subject:   msg.Subject,
+		Body:      msg.Body,
+		Timestamp: msg.Timestamp,
+		Read:      msg.Read,
+		Priority:  string(msg.Priority),
+		Type:      string(msg.Type),
+		ThreadID:  msg.ThreadID,
+		ReplyTo:   msg.ReplyTo,
+	}
+}
+
+// ── Handler ───────────────────────────────────────────────────────────────────
+
+// v1Route is one /api/v1 endpoint. Pattern uses net/http wildcard syntax
+// relative to /api/v1; the OpenAPI test checks every route is documented.
+type v1Route struct {
+	Method  string
+	Pattern string
+	Handle  func(h *APIv1Handler, w http.ResponseWriter, r *http.Request)
+}
+
+var v1Routes = []v1Route{
+	{http.MethodGet, "/openapi.json", (*APIv1Handler).handleOpenAPI},
+	{http.MethodGet, "/rigs", (*APIv1Handler).handleRigs},
+	{http.MethodGet, "/rigs/{rig}/polecats", (*APIv1Handler).handlePolecats},
+	{http.MethodGet, "/rigs/{rig}/crew", (*APIv1Handler).handleCrew},
+	{http.MethodGet, "/rigs/{rig}/merge-queue", (*APIv1Handler).handleMergeQueue},
+	{http.MethodGet, "/issues", (*APIv1Handler).handleIssues},
+	{http.MethodGet, "/issues/{id}", (*APIv1Handler).handleIssue},
+	{http.MethodGet, "/convoys", (*APIv1Handler).handleConvoys},
+	{http.MethodGet, "/convoys/{id}", (*APIv1Handler).handleConvoy},
+	{http.MethodGet, "/mail/inbox", (*APIv1Handler).handleInbox},
+	{http.MethodGet, "/mail/messages/{id}", (*APIv1Handler).handleMessage},
+	{http.MethodPost, "/mail/messages", (*APIv1Handler).handleSendMail},
+}
+
+// APIv1Handler serves the typed, versioned JSON API under /api/v1.
+// Unlike APIHandler it never shells out to gt/bd/gh or parses CLI text.
+type APIv1Handler struct {
+	backend   APIv1Backend
+	mux       *http.ServeMux
+	csrfToken string
+}
+
+// NewAPIv1Handler creates the /api/v1 handler over backend. POST requests
+// must carry csrfToken in X-Dashboard-Token, as for the legacy API.
+func NewAPIv1Handler(backend APIv1Backend, csrfToken string) *APIv1Handler {
+	if csrfToken == "" {
+		log.Printf("WARNING: APIv1Handler created with empty CSRF token — POST requests will not be protected")
+	}
+	h := &APIv1Handler{backend: backend, mux: http.NewServeMux(), csrfToken: csrfToken}
+	for _, route := range v1Routes {
+		handle := route.Handle
+		h.mux.HandleFunc(route.Method+" /api/v1"+route.Pattern, func(w http.ResponseWriter, r *http.Request) {
+			handle(h, w, r)
+		})
+	}
+	h.mux.HandleFunc("/api/v1/", func(w http.ResponseWriter, r *http.Request) {
+		writeV1Error(w, http.StatusNotFound, "not_found", "no such endpoint: "+r.Method+" "+r.URL.Path)
+	})
+	return h
+}
+
+// ServeHTTP validates the CSRF token on writes and dispatches to the route.
+func (h *APIv1Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && h.csrfToken != "" && !csrfExempt(r) {
+		if r.Header.Get("X-Dashboard-Token") != h.csrfToken {
+			writeV1Error(w, http.StatusForbidden, "forbidden", "invalid or missing dashboard token")
+			return
+		}
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+func writeV1JSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeV1Error(w http.ResponseWriter, status int, code, message string) {
+	writeV1JSON(w, status, V1Error{Error: V1ErrorDetail{Code: code, Message: message}})
+}
+
+// writeV1BackendError maps a backend error to a 404 or 500 response.
+func writeV1BackendError(w http.ResponseWriter, what string, err error) {
+	if errors.Is(err, errV1NotFound) || errors.Is(err, beads.ErrNotFound) || errors.Is(err, mail.ErrMessageNotFound) {
+		writeV1Error(w, http.StatusNotFound, "not_found", what+" not found")
+		return
+	}
+	writeV1Error(w, http.StatusInternalServerError, "internal", fmt.Sprintf("%s: %v", what, err))
+}
+
+// v1RigParam returns the validated {rig} path value, writing a 400 if invalid.
+func v1RigParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	rig := r.PathValue("rig")
+	if !isValidRigName(rig) {
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid rig name")
+		return "", false
+	}
+	return rig, true
+}
+
+// v1IDParam returns the validated {id} path value, writing a 400 if invalid.
+func v1IDParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := r.PathValue("id")
+	if !isValidID(id) {
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid id")
+		return "", false
+	}
+	return id, true
+}
+
+// v1CallerMailbox returns the mailbox the caller reads and sends as.
+// Dashboard credentials act for the human operator, so every caller's own
+// mailbox is the overseer's.
+func v1CallerMailbox(_ *http.Request) string {
+	return defaultMailAddress
+}
+
+// v1Address returns the validated ?address= mailbox, defaulting to the
+// caller's own. Reading another agent's mailbox needs an operator.
+func v1Address(w http.ResponseWriter, r *http.Request) (string, bool) {
+	own := v1CallerMailbox(r)
+	address := r.URL.Query().Get("address")
+	if address == "" || address == own {
+		return own, true
+	}
+	if !isValidMailAddress(address) {
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid address")
+		return "", false
+	}
+	if id, ok := identityFromContext(r.Context()); !ok || id.Role < RoleOperator {
+		writeV1Error(w, http.StatusForbidden, "forbidden", "operator role required to read another mailbox")
+		return "", false
+	}
+	return address, true
+}
+
+func (h *APIv1Handler) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openAPIV1)
+}
+
+func (h *APIv1Handler) handleRigs(w http.ResponseWriter, _ *http.Request) {
+	names, err := h.backend.Rigs()
+	if err != nil {
+		writeV1BackendError(w, "rigs", err)
+		return
+	}
+	rigs := make([]V1Rig, 0, len(names))
+	for _, name := range names {
+		rigs = append(rigs, V1Rig{Name: name})
+	}
+	writeV1JSON(w, http.StatusOK, newV1List(rigs))
+}
+
+func (h *APIv1Handler) handlePolecats(w http.ResponseWriter, r *http.Request) {
+	rig, ok := v1RigParam(w, r)
+	if !ok {
+		return
+	}
+	polecats, err := h.backend.Polecats(rig)
+	if err != nil {
+		writeV1BackendError(w, "rig "+rig, err)
+		return
+	}
+	items := make([]V1Polecat, 0, len(polecats))
+	for _, p := range polecats {
+		items = append(items, V1Polecat{
+			Name: p.Name, Rig: p.Rig, State: string(p.State), Branch: p.Branch,
+			Issue: p.Issue, CreatedAt: p.CreatedAt, UpdatedAt: p.UpdatedAt,
+		})
+	}
+	writeV1JSON(w, http.StatusOK, newV1List(items))
+}
+
+func (h *APIv1Handler) handleCrew(w http.ResponseWriter, r *http.Request) {
+	rig, ok := v1RigParam(w, r)
+	if !ok {
+		return
+	}
+	workers, err := h.backend.Crew(rig)
+	if err != nil {
+		writeV1BackendError(w, "rig "+rig, err)
+		return
+	}
+	items := make([]V1CrewWorker, 0, len(workers))
+	for _, c := range workers {
+		items = append(items, V1CrewWorker{
+			Name: c.Name, Rig: c.Rig, Branch: c.Branch, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt,
+		})
+	}
+	writeV1JSON(w, http.StatusOK, newV1List(items))
+}
+
+func (h *APIv1Handler) handleMergeQueue(w http.ResponseWriter, r *http.Request) {
+	rig, ok := v1RigParam(w, r)
+	if !ok {
+		return
+	}
+	mrs, err := h.backend.MergeQueue(rig)
+	if err != nil {
+		writeV1BackendError(w, "rig "+rig, err)
+		return
+	}
+	items := make([]V1MergeRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		items = append(items, V1MergeRequest{
+			ID: mr.ID, Title: mr.Title, Branch: mr.Branch, Target: mr.Target,
+			SourceIssue: mr.SourceIssue, Worker: mr.Worker, Priority: mr.Priority,
+			ConvoyID: mr.ConvoyID, BlockedBy: mr.BlockedBy, Assignee: mr.Assignee,
+			RetryCount: mr.RetryCount, PRURL: mr.PRURL, CreatedAt: mr.CreatedAt,
+		})
+	}
+	writeV1JSON(w, http.StatusOK, newV1List(items))
+}
+
+func (h *APIv1Handler) handleIssues(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	opts := beads.ListOptions{
+		Status:   q.Get("status"),
+		Label:    q.Get("label"),
+		Assignee: q.Get("assignee"),
+		Parent:   q.Get("parent"),
+		Priority: -1,
+		Limit:    100,
+	}
+	switch opts.Status {
+	case "", "open", "closed", "in_progress", "blocked", "hooked", "all":
+	default:
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid status")
+		return
+	}
+	if opts.Parent != "" && !isValidID(opts.Parent) {
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid parent")
+		return
+	}
+	for _, v := range []string{opts.Label, opts.Assignee} {
+		if strings.ContainsAny(v, "\x00\n") || strings.HasPrefix(v, "-") {
+			writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid filter")
+			return
+		}
+	}
+	if s := q.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > 1000 {
+			writeV1Error(w, http.StatusBadRequest, "bad_request", "limit must be 1-1000")
+			return
+		}
+		opts.Limit = n
+	}
+
+	issues, err := h.backend.Issues(opts)
+	if err != nil {
+		writeV1BackendError(w, "issues", err)
+		return
+	}
+	items := make([]V1Issue, 0, len(issues))
+	for _, issue := range issues {
+		items = append(items, toV1Issue(issue))
+	}
+	writeV1JSON(w, http.StatusOK, newV1List(items))
+}
+
+func (h *APIv1Handler) handleIssue(w http.ResponseWriter, r *http.Request) {
+	id, ok := v1IDParam(w, r)
+	if !ok {
+		return
+	}
+	issue, err := h.backend.Issue(id)
+	if err != nil {
+		writeV1BackendError(w, "issue "+id, err)
+		return
+	}
+	writeV1JSON(w, http.StatusOK, toV1Issue(issue))
+}
+
+func (h *APIv1Handler) handleConvoys(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = "open"
+	case "open", "closed", "all":
+	default:
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid status")
+		return
+	}
+	convoys, err := h.backend.Convoys(status)
+	if err != nil {
+		writeV1BackendError(w, "convoys", err)
+		return
+	}
+	items := make([]V1Convoy, 0, len(convoys))
+	for _, c := range convoys {
+		items = append(items, toV1Convoy(c, nil))
+	}
+	writeV1JSON(w, http.StatusOK, newV1List(items))
+}
+
+func (h *APIv1Handler) handleConvoy(w http.ResponseWriter, r *http.Request) {
+	id, ok := v1IDParam(w, r)
+	if !ok {
+		return
+	}
+	convoy, tracked, err := h.backend.Convoy(id)
+	if err != nil {
+		writeV1BackendError(w, "convoy "+id, err)
+		return
+	}
+	writeV1JSON(w, http.StatusOK, toV1Convoy(convoy, tracked))
+}
+
+// toV1Convoy builds a convoy response. Without tracked issues (list view) the
+// progress counts come from the convoy's "tracks" dependencies, when present.
+func toV1Convoy(convoy *beads.Issue, tracked []*beads.Issue) V1Convoy {
+	v := V1Convoy{
+		ID: convoy.ID, Title: convoy.Title, Status: convoy.Status,
+		Labels: convoy.Labels, CreatedAt: convoy.CreatedAt,
+	}
+	if v.Labels == nil {
+		v.Labels = []string{}
+	}
+	if tracked != nil {
+		v.Tracked = make([]V1Issue, 0, len(tracked))
+		for _, issue := range tracked {
+			v.Tracked = append(v.Tracked, toV1Issue(issue))
+			v.Total++
+			if issue.Status == "closed" {
+				v.Completed++
+			}
+		}
+		return v
+	}
+	for _, dep := range convoy.Dependencies {
+		if dep.DependencyType == "tracks" {
+			v.Total++
+			if dep.Status == "closed" {
+				v.Completed++
+			}
+		}
+	}
+	return v
+}
+
+func (h *APIv1Handler) handleInbox(w http.ResponseWriter, r *http.Request) {
+	address, ok := v1Address(w, r)
+	if !ok {
+		return
+	}
+	messages, err := h.backend.Inbox(address)
+	if err != nil {
+		writeV1BackendError(w, "inbox", err)
+		return
+	}
+	inbox := V1Inbox{Address: address, Items: make([]V1MailMessage, 0, len(messages))}
+	for _, msg := range messages {
+		item := toV1Mail(msg)
+		item.Body = "" // list view; fetch the message for its body
+		inbox.Items = append(inbox.Items, item)
+		if !msg.Read {
+			inbox.UnreadCount++
+		}
+	}
+	inbox.Total = len(inbox.Items)
+	writeV1JSON(w, http.StatusOK, inbox)
+}
+
+func (h *APIv1Handler) handleMessage(w http.ResponseWriter, r *http.Request) {
+	id, ok := v1IDParam(w, r)
+	if !ok {
+		return
+	}
+	address, ok := v1Address(w, r)
+	if !ok {
+		return
+	}
+	msg, err := h.backend.Message(address, id)
+	if err != nil {
+		writeV1BackendError(w, "message "+id, err)
+		return
+	}
+	writeV1JSON(w, http.StatusOK, toV1Mail(msg))
+}
+
+func (h *APIv1Handler) handleSendMail(w http.ResponseWriter, r *http.Request) {
+	var req V1SendMailRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid request body")
+		return
+	}
+
+	// Same limits as the legacy /api/mail/send.
+	const maxSubjectLen = 500
+	const maxBodyLen = 100_000
+	switch {
+	case req.To == "" || req.Subject == "":
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "missing required fields (to, subject)")
+		return
+	case !isValidMailAddress(req.To):
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid recipient")
+		return
+	case req.From != "" && req.From != v1CallerMailbox(r):
+		writeV1Error(w, http.StatusForbidden, "forbidden", "mail can only be sent as "+v1CallerMailbox(r))
+		return
+	case req.ReplyTo != "" && !isValidID(req.ReplyTo):
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid reply_to")
+		return
+	case len(req.Subject) > maxSubjectLen || len(req.Body) > maxBodyLen:
+		writeV1Error(w, http.StatusBadRequest, "bad_request",
+			fmt.Sprintf("subject (max %d bytes) or body (max %d bytes) too long", maxSubjectLen, maxBodyLen))
+		return
+	case strings.Contains(req.Subject, "\x00") || strings.Contains(req.Body, "\x00"):
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "subject and body cannot contain null bytes")
+		return
+	}
+	switch req.Priority {
+	case "", string(mail.PriorityLow), string(mail.PriorityNormal), string(mail.PriorityHigh), string(mail.PriorityUrgent):
+	default:
+		writeV1Error(w, http.StatusBadRequest, "bad_request", "invalid priority")
+		return
+	}
+
+	// The sender is always the caller, never taken from the request.
+	from := v1CallerMailbox(r)
+	msg := mail.NewMessage(from, req.To, req.Subject, req.Body)
+	if req.ReplyTo != "" {
+		// Join the original's thread when the sender can still see it.
+		if original, err := h.backend.Message(from, req.ReplyTo); err == nil {
+			msg = mail.NewReplyMessage(from, req.To, req.Subject, req.Body, original)
+		} else {
+			msg.ReplyTo = req.ReplyTo
+			msg.Type = mail.TypeReply
+		}
+	}
+	if req.Priority != "" {
+		msg.Priority = mail.ParsePriority(req.Priority)
+	}
+	if err := h.backend.SendMail(msg); err != nil {
+		writeV1BackendError(w, "send", err)
+		return
+	}
+	writeV1JSON(w, http.StatusCreated, toV1Mail(msg))
+}
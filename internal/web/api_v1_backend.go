@@ -0,0 +1,164 @@
+package web
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// LiveAPIv1Backend serves /api/v1 from the town's Go packages.
+type LiveAPIv1Backend struct {
+	townRoot string
+}
+
+// NewLiveAPIv1Backend creates a backend for the town at townRoot.
+func NewLiveAPIv1Backend(townRoot string) *LiveAPIv1Backend {
+	return &LiveAPIv1Backend{townRoot: townRoot}
+}
+
+func (b *LiveAPIv1Backend) rigsConfig() *config.RigsConfig {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(b.townRoot))
+	if err != nil {
+		return &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+	return rigsConfig
+}
+
+// rig loads a registered rig, returning errV1NotFound for unknown names.
+func (b *LiveAPIv1Backend) rig(name string) (*rig.Rig, error) {
+	mgr := rig.NewManager(b.townRoot, b.rigsConfig(), git.NewGit(b.townRoot))
+	r, err := mgr.GetRig(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errV1NotFound, err)
+	}
+	return r, nil
+}
+
+func (b *LiveAPIv1Backend) townBeads() *beads.Beads {
+	return beads.NewWithBeadsDir(b.townRoot, filepath.Join(b.townRoot, ".beads"))
+}
+
+// Rigs returns the registered rig names, sorted.
+func (b *LiveAPIv1Backend) Rigs() ([]string, error) {
+	cfg := b.rigsConfig()
+	names := make([]string, 0, len(cfg.Rigs))
+	for name := range cfg.Rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Polecats lists a rig's polecats.
+func (b *LiveAPIv1Backend) Polecats(rigName string) ([]*polecat.Polecat, error) {
+	r, err := b.rig(rigName)
+	if err != nil {
+		return nil, err
+	}
+	return polecat.NewManager(r, git.NewGit(r.Path), tmux.NewTmux()).List()
+}
+
+// Crew lists a rig's crew workspaces.
+func (b *LiveAPIv1Backend) Crew(rigName string) ([]*crew.CrewWorker, error) {
+	r, err := b.rig(rigName)
+	if err != nil {
+		return nil, err
+	}
+	return crew.NewManager(r, git.NewGit(r.Path)).List()
+}
+
+// MergeQueue lists a rig's open merge requests.
+func (b *LiveAPIv1Backend) MergeQueue(rigName string) ([]*refinery.MRInfo, error) {
+	r, err := b.rig(rigName)
+	if err != nil {
+		return nil, err
+	}
+	return refinery.NewEngineer(r).ListAllOpenMRs()
+}
+
+// Issues lists town beads matching opts.
+func (b *LiveAPIv1Backend) Issues(opts beads.ListOptions) ([]*beads.Issue, error) {
+	return b.townBeads().List(opts)
+}
+
+// Issue shows one bead; prefix routing resolves rig beads from the town root.
+func (b *LiveAPIv1Backend) Issue(id string) (*beads.Issue, error) {
+	return b.townBeads().Show(id)
+}
+
+// Convoys lists convoys with the given status ("open", "closed" or "all").
+func (b *LiveAPIv1Backend) Convoys(status string) ([]*beads.Issue, error) {
+	issues, err := b.townBeads().List(beads.ListOptions{Status: status, Priority: -1})
+	if err != nil {
+		return nil, err
+	}
+	var convoys []*beads.Issue
+	for _, issue := range issues {
+		if issue.Type == "convoy" {
+			convoys = append(convoys, issue)
+		}
+	}
+	return convoys, nil
+}
+
+// Convoy returns a convoy and the issues it tracks.
+func (b *LiveAPIv1Backend) Convoy(id string) (*beads.Issue, []*beads.Issue, error) {
+	bd := b.townBeads()
+	convoy, err := bd.Show(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if convoy.Type != "convoy" {
+		return nil, nil, fmt.Errorf("%w: %s is a %s, not a convoy", errV1NotFound, id, convoy.Type)
+	}
+	var trackedIDs []string
+	for _, dep := range convoy.Dependencies {
+		if dep.DependencyType == "tracks" {
+			trackedIDs = append(trackedIDs, dep.ID)
+		}
+	}
+	tracked := []*beads.Issue{}
+	if len(trackedIDs) == 0 {
+		return convoy, tracked, nil
+	}
+	found, err := bd.ShowMultiple(trackedIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, trackedID := range trackedIDs {
+		if issue, ok := found[trackedID]; ok {
+			tracked = append(tracked, issue)
+		}
+	}
+	return convoy, tracked, nil
+}
+
+func (b *LiveAPIv1Backend) mailbox(address string) *mail.Mailbox {
+	return mail.NewMailboxFromAddress(address, b.townRoot)
+}
+
+// Inbox lists the messages in address's mailbox.
+func (b *LiveAPIv1Backend) Inbox(address string) ([]*mail.Message, error) {
+	return b.mailbox(address).List()
+}
+
+// Message returns one message from address's mailbox.
+func (b *LiveAPIv1Backend) Message(address, id string) (*mail.Message, error) {
+	return b.mailbox(address).Get(id)
+}
+
+// SendMail routes msg like gt mail send.
+func (b *LiveAPIv1Backend) SendMail(msg *mail.Message) error {
+	return mail.NewRouterWithTownRoot(b.townRoot, b.townRoot).Send(msg)
+}
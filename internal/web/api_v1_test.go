@@ -0,0 +1,383 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/refinery"
+)
+
+// fakeAPIv1Backend serves canned data and records sent mail.
+type fakeAPIv1Backend struct {
+	issues  map[string]*beads.Issue
+	inbox   []*mail.Message
+	sent    []*mail.Message
+	listed  beads.ListOptions
+	failAll bool
+}
+
+func newFakeAPIv1Backend() *fakeAPIv1Backend {
+	return &fakeAPIv1Backend{
+		issues: map[string]*beads.Issue{
+			"gt-1": {ID: "gt-1", Title: "Login", Status: "closed", Type: "task"},
+			"gt-2": {ID: "gt-2", Title: "Logout", Status: "open", Type: "bug", Labels: []string{"ui"}},
+			"hq-cv-1": {ID: "hq-cv-1", Title: "Auth", Status: "open", Type: "convoy",
+				Dependencies: []beads.IssueDep{
+					{ID: "gt-1", Status: "closed", DependencyType: "tracks"},
+					{ID: "gt-2", Status: "open", DependencyType: "tracks"},
+				}},
+		},
+		inbox: []*mail.Message{
+			{ID: "hq-m1", From: "mayor/", To: "overseer", Subject: "Status", Body: "all good",
+				Priority: mail.PriorityNormal, ThreadID: "thread-a"},
+			{ID: "hq-m2", From: "gastown/Toast", To: "overseer", Subject: "Done", Read: true,
+				Priority: mail.PriorityHigh},
+		},
+	}
+}
+
+var errFakeBackend = errors.New("backend down")
+
+func (f *fakeAPIv1Backend) Rigs() ([]string, error) {
+	if f.failAll {
+		return nil, errFakeBackend
+	}
+	return []string{"beads", "gastown"}, nil
+}
+
+func (f *fakeAPIv1Backend) rigErr(rig string) error {
+	if rig != "gastown" {
+		return errV1NotFound
+	}
+	return nil
+}
+
+func (f *fakeAPIv1Backend) Polecats(rig string) ([]*polecat.Polecat, error) {
+	if err := f.rigErr(rig); err != nil {
+		return nil, err
+	}
+	return []*polecat.Polecat{{Name: "Toast", Rig: rig, State: polecat.StateWorking, Issue: "gt-2"}}, nil
+}
+
+func (f *fakeAPIv1Backend) Crew(rig string) ([]*crew.CrewWorker, error) {
+	if err := f.rigErr(rig); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (f *fakeAPIv1Backend) MergeQueue(rig string) ([]*refinery.MRInfo, error) {
+	if err := f.rigErr(rig); err != nil {
+		return nil, err
+	}
+	return []*refinery.MRInfo{{ID: "gt-mr1", Branch: "polecat/toast", Target: "main", SourceIssue: "gt-2"}}, nil
+}
+
+func (f *fakeAPIv1Backend) Issues(opts beads.ListOptions) ([]*beads.Issue, error) {
+	f.listed = opts
+	return []*beads.Issue{f.issues["gt-1"], f.issues["gt-2"]}, nil
+}
+
+func (f *fakeAPIv1Backend) Issue(id string) (*beads.Issue, error) {
+	if issue, ok := f.issues[id]; ok {
+		return issue, nil
+	}
+	return nil, beads.ErrNotFound
+}
+
+func (f *fakeAPIv1Backend) Convoys(status string) ([]*beads.Issue, error) {
+	return []*beads.Issue{f.issues["hq-cv-1"]}, nil
+}
+
+func (f *fakeAPIv1Backend) Convoy(id string) (*beads.Issue, []*beads.Issue, error) {
+	if id != "hq-cv-1" {
+		return nil, nil, beads.ErrNotFound
+	}
+	return f.issues[id], []*beads.Issue{f.issues["gt-1"], f.issues["gt-2"]}, nil
+}
+
+func (f *fakeAPIv1Backend) Inbox(address string) ([]*mail.Message, error) {
+	return f.inbox, nil
+}
+
+func (f *fakeAPIv1Backend) Message(address, id string) (*mail.Message, error) {
+	for _, m := range f.inbox {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+	return nil, mail.ErrMessageNotFound
+}
+
+func (f *fakeAPIv1Backend) SendMail(msg *mail.Message) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func doV1(t *testing.T, h http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("X-Dashboard-Token", token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// asRole serves h as an authenticated caller with role, as DashboardAuth would.
+func asRole(h http.Handler, role Role) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := Identity{Name: role.String(), Role: role, Bearer: true}
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), identityKey{}, id)))
+	})
+}
+
+func decodeV1[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decoding %s: %v", rec.Body.String(), err)
+	}
+	return v
+}
+
+func TestAPIv1_Reads(t *testing.T) {
+	h := NewAPIv1Handler(newFakeAPIv1Backend(), "tok")
+
+	rec := doV1(t, h, "GET", "/api/v1/rigs", "", "")
+	if rigs := decodeV1[V1List[V1Rig]](t, rec); rec.Code != 200 || rigs.Total != 2 || rigs.Items[1].Name != "gastown" {
+		t.Errorf("rigs = %d %s", rec.Code, rec.Body)
+	}
+
+	rec = doV1(t, h, "GET", "/api/v1/rigs/gastown/polecats", "", "")
+	if ps := decodeV1[V1List[V1Polecat]](t, rec); ps.Total != 1 || ps.Items[0].State != "working" {
+		t.Errorf("polecats = %s", rec.Body)
+	}
+
+	// Empty collections are [] rather than null.
+	rec = doV1(t, h, "GET", "/api/v1/rigs/gastown/crew", "", "")
+	if !strings.Contains(rec.Body.String(), `"items":[]`) {
+		t.Errorf("crew = %s, want empty items array", rec.Body)
+	}
+
+	rec = doV1(t, h, "GET", "/api/v1/rigs/gastown/merge-queue", "", "")
+	if mq := decodeV1[V1List[V1MergeRequest]](t, rec); mq.Total != 1 || mq.Items[0].SourceIssue != "gt-2" {
+		t.Errorf("merge-queue = %s", rec.Body)
+	}
+
+	rec = doV1(t, h, "GET", "/api/v1/issues/gt-2", "", "")
+	if issue := decodeV1[V1Issue](t, rec); issue.Type != "bug" || len(issue.Labels) != 1 || issue.Dependencies == nil {
+		t.Errorf("issue = %s", rec.Body)
+	}
+
+	rec = doV1(t, h, "GET", "/api/v1/convoys", "", "")
+	if cs := decodeV1[V1List[V1Convoy]](t, rec); cs.Total != 1 || cs.Items[0].Completed != 1 || cs.Items[0].Total != 2 || cs.Items[0].Tracked != nil {
+		t.Errorf("convoys = %s", rec.Body)
+	}
+
+	rec = doV1(t, h, "GET", "/api/v1/convoys/hq-cv-1", "", "")
+	if c := decodeV1[V1Convoy](t, rec); len(c.Tracked) != 2 || c.Completed != 1 {
+		t.Errorf("convoy = %s", rec.Body)
+	}
+
+	rec = doV1(t, h, "GET", "/api/v1/mail/inbox", "", "")
+	inbox := decodeV1[V1Inbox](t, rec)
+	if inbox.Address != "overseer" || inbox.Total != 2 || inbox.UnreadCount != 1 || inbox.Items[0].Body != "" {
+		t.Errorf("inbox = %s", rec.Body)
+	}
+
+	rec = doV1(t, asRole(h, RoleOperator), "GET", "/api/v1/mail/messages/hq-m1?address=mayor/", "", "")
+	if m := decodeV1[V1MailMessage](t, rec); m.Body != "all good" {
+		t.Errorf("message = %s", rec.Body)
+	}
+}
+
+func TestAPIv1_IssueFilters(t *testing.T) {
+	backend := newFakeAPIv1Backend()
+	h := NewAPIv1Handler(backend, "tok")
+
+	rec := doV1(t, h, "GET", "/api/v1/issues?status=open&label=ui&limit=5", "", "")
+	if rec.Code != 200 {
+		t.Fatalf("issues = %d %s", rec.Code, rec.Body)
+	}
+	if backend.listed.Status != "open" || backend.listed.Label != "ui" || backend.listed.Limit != 5 || backend.listed.Priority != -1 {
+		t.Errorf("list options = %+v", backend.listed)
+	}
+
+	for _, q := range []string{"status=bogus", "limit=0", "limit=x", "label=--all", "parent=bad%20id"} {
+		if rec := doV1(t, h, "GET", "/api/v1/issues?"+q, "", ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("issues?%s = %d, want 400", q, rec.Code)
+		}
+	}
+}
+
+func TestAPIv1_Errors(t *testing.T) {
+	backend := newFakeAPIv1Backend()
+	h := NewAPIv1Handler(backend, "tok")
+
+	tests := []struct {
+		method, path string
+		code         int
+		errCode      string
+	}{
+		{"GET", "/api/v1/rigs/nope/polecats", 404, "not_found"},
+		{"GET", "/api/v1/rigs/bad%20rig/crew", 400, "bad_request"},
+		{"GET", "/api/v1/issues/gt-404", 404, "not_found"},
+		{"GET", "/api/v1/convoys/hq-x", 404, "not_found"},
+		{"GET", "/api/v1/mail/messages/hq-zz", 404, "not_found"},
+		{"GET", "/api/v1/mail/inbox?address=-rf", 400, "bad_request"},
+		{"GET", "/api/v1/nowhere", 404, "not_found"},
+		{"DELETE", "/api/v1/issues/gt-1", 404, "not_found"},
+	}
+	for _, tt := range tests {
+		rec := doV1(t, h, tt.method, tt.path, "", "")
+		if rec.Code != tt.code {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.path, rec.Code, tt.code)
+			continue
+		}
+		if e := decodeV1[V1Error](t, rec); e.Error.Code != tt.errCode {
+			t.Errorf("%s %s error code = %q, want %q", tt.method, tt.path, e.Error.Code, tt.errCode)
+		}
+	}
+
+	backend.failAll = true
+	if rec := doV1(t, h, "GET", "/api/v1/rigs", "", ""); rec.Code != 500 {
+		t.Errorf("backend failure = %d, want 500", rec.Code)
+	}
+}
+
+func TestAPIv1_SendMail(t *testing.T) {
+	backend := newFakeAPIv1Backend()
+	h := NewAPIv1Handler(backend, "tok")
+
+	body := `{"to":"mayor/","subject":"Re: Status","body":"thanks","priority":"high","reply_to":"hq-m1"}`
+	if rec := doV1(t, h, "POST", "/api/v1/mail/messages", "", body); rec.Code != http.StatusForbidden {
+		t.Errorf("send without token = %d, want 403", rec.Code)
+	}
+
+	rec := doV1(t, h, "POST", "/api/v1/mail/messages", "tok", body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("send = %d %s", rec.Code, rec.Body)
+	}
+	if len(backend.sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(backend.sent))
+	}
+	msg := backend.sent[0]
+	if msg.From != "overseer" || msg.Priority != mail.PriorityHigh || msg.ThreadID != "thread-a" || msg.ReplyTo != "hq-m1" {
+		t.Errorf("sent message = %+v", msg)
+	}
+
+	for _, bad := range []string{
+		`{"subject":"x"}`,
+		`{"to":"mayor/","subject":"x","priority":"meh"}`,
+		`{"to":"--all","subject":"x"}`,
+		`{"to":"mayor/","subject":"` + strings.Repeat("s", 501) + `"}`,
+		`not json`,
+	} {
+		if rec := doV1(t, h, "POST", "/api/v1/mail/messages", "tok", bad); rec.Code != http.StatusBadRequest {
+			t.Errorf("send %.40s = %d, want 400", bad, rec.Code)
+		}
+	}
+}
+
+func TestAPIv1_MailboxScoping(t *testing.T) {
+	h := NewAPIv1Handler(newFakeAPIv1Backend(), "tok")
+	viewer, operator := asRole(h, RoleViewer), asRole(h, RoleOperator)
+
+	if rec := doV1(t, viewer, "GET", "/api/v1/mail/inbox", "", ""); rec.Code != http.StatusOK {
+		t.Errorf("viewer own inbox = %d, want 200", rec.Code)
+	}
+	for _, path := range []string{"/api/v1/mail/inbox?address=mayor/", "/api/v1/mail/messages/hq-m1?address=gastown/Toast"} {
+		if rec := doV1(t, viewer, "GET", path, "", ""); rec.Code != http.StatusForbidden {
+			t.Errorf("viewer %s = %d, want 403", path, rec.Code)
+		}
+		if rec := doV1(t, h, "GET", path, "", ""); rec.Code != http.StatusForbidden {
+			t.Errorf("unauthenticated %s = %d, want 403", path, rec.Code)
+		}
+	}
+	if rec := doV1(t, operator, "GET", "/api/v1/mail/inbox?address=mayor/", "", ""); rec.Code != http.StatusOK {
+		t.Errorf("operator other inbox = %d, want 200", rec.Code)
+	}
+}
+
+func TestAPIv1_SendMailCannotSpoofSender(t *testing.T) {
+	backend := newFakeAPIv1Backend()
+	h := asRole(NewAPIv1Handler(backend, "tok"), RoleOperator)
+
+	body := `{"to":"gastown/Toast","subject":"Orders","from":"mayor/"}`
+	if rec := doV1(t, h, "POST", "/api/v1/mail/messages", "", body); rec.Code != http.StatusForbidden {
+		t.Errorf("send as mayor/ = %d, want 403", rec.Code)
+	}
+	body = `{"to":"gastown/Toast","subject":"Orders","from":"overseer"}`
+	if rec := doV1(t, h, "POST", "/api/v1/mail/messages", "", body); rec.Code != http.StatusCreated {
+		t.Fatalf("send as overseer = %d %s", rec.Code, rec.Body)
+	}
+	if len(backend.sent) != 1 || backend.sent[0].From != "overseer" {
+		t.Errorf("sent = %+v", backend.sent)
+	}
+}
+
+// TestAPIv1_OpenAPICoversRoutes keeps openapi_v1.json in step with v1Routes.
+func TestAPIv1_OpenAPICoversRoutes(t *testing.T) {
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPIV1, &doc); err != nil {
+		t.Fatalf("openapi_v1.json is not valid JSON: %v", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("openapi = %q, want 3.x", doc.OpenAPI)
+	}
+
+	documented := 0
+	for _, ops := range doc.Paths {
+		documented += len(ops)
+	}
+	if documented != len(v1Routes) {
+		t.Errorf("openapi documents %d operations, router has %d", documented, len(v1Routes))
+	}
+	for _, route := range v1Routes {
+		if _, ok := doc.Paths[route.Pattern][strings.ToLower(route.Method)]; !ok {
+			t.Errorf("%s %s missing from openapi_v1.json", route.Method, route.Pattern)
+		}
+	}
+
+	// Every $ref resolves.
+	var raw map[string]interface{}
+	_ = json.Unmarshal(openAPIV1, &raw)
+	components := raw["components"].(map[string]interface{})
+	for _, m := range regexp.MustCompile(`"\$ref":\s*"#/components/(\w+)/(\w+)"`).FindAllSubmatch(openAPIV1, -1) {
+		section, _ := components[string(m[1])].(map[string]interface{})
+		if _, ok := section[string(m[2])]; !ok {
+			t.Errorf("unresolved $ref #/components/%s/%s", m[1], m[2])
+		}
+	}
+
+	rec := doV1(t, NewAPIv1Handler(newFakeAPIv1Backend(), "tok"), "GET", "/api/v1/openapi.json", "", "")
+	if rec.Code != 200 || !bytes.Equal(rec.Body.Bytes(), openAPIV1) {
+		t.Errorf("GET openapi.json = %d", rec.Code)
+	}
+}
+
+func TestV1Schemas_TimeFormat(t *testing.T) {
+	// time fields serialize as RFC 3339 and survive a round trip.
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	b, _ := json.Marshal(V1Polecat{Name: "Toast", CreatedAt: now})
+	if !strings.Contains(string(b), `"created_at":"2026-03-01T10:00:00Z"`) {
+		t.Errorf("polecat JSON = %s", b)
+	}
+}
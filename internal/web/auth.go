@@ -0,0 +1,362 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Role is a dashboard access level. Roles are ordered: each role includes
+// every permission of the roles below it.
+type Role int
+
+const (
+	// RoleNone is an unauthenticated caller.
+	RoleNone Role = iota
+	// RoleViewer may read dashboard pages and read-only API endpoints.
+	RoleViewer
+	// RoleOperator may additionally run gt commands, send mail, modify
+	// issues and preview live session output.
+	RoleOperator
+)
+
+// String returns the configuration name of the role.
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleOperator:
+		return "operator"
+	default:
+		return ""
+	}
+}
+
+// ParseRole parses a role name as stored in DashboardAuthConfig.
+func ParseRole(s string) (Role, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "viewer", "read-only", "readonly":
+		return RoleViewer, nil
+	case "operator":
+		return RoleOperator, nil
+	default:
+		return RoleNone, fmt.Errorf("unknown dashboard role %q (want viewer or operator)", s)
+	}
+}
+
+// Identity is an authenticated dashboard caller.
+type Identity struct {
+	// Name is the token name, client certificate CN, or "local" when
+	// authentication is disabled.
+	Name string
+	Role Role
+	// Bearer is true when the credential came from an Authorization header.
+	// Browsers never attach that header on their own, so such requests
+	// cannot be forged cross-site and skip the CSRF token check.
+	Bearer bool
+}
+
+type identityKey struct{}
+
+// identityFromContext returns the caller identity attached by DashboardAuth.
+func identityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// csrfExempt reports whether r was authenticated with a bearer header and
+// therefore does not need the X-Dashboard-Token CSRF header.
+func csrfExempt(r *http.Request) bool {
+	id, ok := identityFromContext(r.Context())
+	return ok && id.Bearer
+}
+
+const (
+	// dashboardTokenPrefix marks dashboard bearer tokens so they are easy to
+	// recognize in logs and secret scanners.
+	dashboardTokenPrefix = "gtd_"
+	// authCookieName carries the bearer token for browser sessions.
+	authCookieName = "gt_dashboard_token"
+	// maxAuditBodyBytes bounds how much of an /api/run body is read for the
+	// audit log.
+	maxAuditBodyBytes = 1 << 20
+)
+
+// GenerateDashboardToken returns a new random dashboard bearer token.
+func GenerateDashboardToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	return dashboardTokenPrefix + hex.EncodeToString(b), nil
+}
+
+// HashDashboardToken returns the hex SHA-256 hash stored in settings for token.
+func HashDashboardToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// DashboardAuth authenticates dashboard requests, enforces per-endpoint
+// roles and records an audit trail of privileged requests in .events.jsonl.
+type DashboardAuth struct {
+	// open disables authentication: every caller is a local operator.
+	// Used for loopback binds without DashboardAuthConfig.Required.
+	open   bool
+	tokens map[string]Identity // keyed by token SHA-256
+	certs  map[string]Role     // keyed by client certificate CN
+	// audit records an audit event; defaults to events.LogAudit.
+	audit func(actor string, payload map[string]interface{})
+}
+
+// NewDashboardAuth builds the authenticator from town settings. When
+// required is false, authentication is disabled and requests are only
+// audited. When required is true, at least one credential must be configured.
+func NewDashboardAuth(cfg *config.DashboardAuthConfig, required bool) (*DashboardAuth, error) {
+	a := &DashboardAuth{
+		open:   !required,
+		tokens: make(map[string]Identity),
+		certs:  make(map[string]Role),
+		audit: func(actor string, payload map[string]interface{}) {
+			_ = events.LogAudit(events.TypeDashboardAccess, actor, payload)
+		},
+	}
+	if cfg != nil {
+		for _, t := range cfg.Tokens {
+			role, err := ParseRole(t.Role)
+			if err != nil {
+				return nil, fmt.Errorf("dashboard token %q: %w", t.Name, err)
+			}
+			hash := strings.ToLower(strings.TrimSpace(t.SHA256))
+			if len(hash) != sha256.Size*2 {
+				return nil, fmt.Errorf("dashboard token %q: sha256 must be %d hex characters", t.Name, sha256.Size*2)
+			}
+			a.tokens[hash] = Identity{Name: t.Name, Role: role}
+		}
+		for cn, r := range cfg.ClientCerts {
+			if strings.HasPrefix(cn, "gt-") {
+				return nil, fmt.Errorf("dashboard client cert %q: polecat CNs (gt-*) cannot be granted dashboard access", cn)
+			}
+			role, err := ParseRole(r)
+			if err != nil {
+				return nil, fmt.Errorf("dashboard client cert %q: %w", cn, err)
+			}
+			a.certs[cn] = role
+		}
+	}
+	if required && len(a.tokens) == 0 && len(a.certs) == 0 {
+		return nil, fmt.Errorf("dashboard authentication is required but no tokens or client certificates are configured")
+	}
+	return a, nil
+}
+
+// Open reports whether authentication is disabled.
+func (a *DashboardAuth) Open() bool {
+	return a.open
+}
+
+// requiredRole returns the minimum role needed for r.
+// Every state-changing request and /api/session/preview (which exposes raw
+// terminal output) need an operator; static assets are public; everything
+// else needs a viewer.
+func requiredRole(r *http.Request) Role {
+	path := r.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/static/"):
+		return RoleNone
+	case r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions:
+		return RoleOperator
+	case path == "/api/session/preview":
+		return RoleOperator
+	default:
+		return RoleViewer
+	}
+}
+
+// identify resolves the caller from a verified client certificate, an
+// Authorization bearer header or the auth cookie, in that order.
+func (a *DashboardAuth) identify(r *http.Request) Identity {
+	if a.open {
+		return Identity{Name: "local", Role: RoleOperator}
+	}
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		cn := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		if role, ok := a.certs[cn]; ok {
+			return Identity{Name: cn, Role: role}
+		}
+	}
+	if h := r.Header.Get("Authorization"); h != "" {
+		if token, ok := strings.CutPrefix(h, "Bearer "); ok {
+			if id, ok := a.lookupToken(token); ok {
+				id.Bearer = true
+				return id
+			}
+		}
+		return Identity{}
+	}
+	if c, err := r.Cookie(authCookieName); err == nil {
+		if id, ok := a.lookupToken(c.Value); ok {
+			return id
+		}
+	}
+	return Identity{}
+}
+
+func (a *DashboardAuth) lookupToken(token string) (Identity, bool) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return Identity{}, false
+	}
+	id, ok := a.tokens[HashDashboardToken(token)]
+	return id, ok
+}
+
+// Wrap returns next guarded by authentication, authorization and auditing.
+func (a *DashboardAuth) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Browser login: /?token=... stores the token in an HttpOnly cookie
+		// and redirects so the token does not linger in the address bar.
+		if !a.open && r.Method == http.MethodGet && !strings.HasPrefix(r.URL.Path, "/api/") {
+			if token := r.URL.Query().Get("token"); token != "" {
+				a.login(w, r, token)
+				return
+			}
+		}
+
+		need := requiredRole(r)
+		id := a.identify(r)
+		if id.Role < need {
+			status := http.StatusForbidden
+			msg := fmt.Sprintf("%s role required", need)
+			if id.Role == RoleNone {
+				status = http.StatusUnauthorized
+				msg = "authentication required"
+				w.Header().Set("WWW-Authenticate", `Bearer realm="gt dashboard"`)
+			}
+			a.record(r, id, "", status)
+			writeAuthError(w, r, msg, status)
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), identityKey{}, id))
+		if need < RoleOperator {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		command := ""
+		if r.URL.Path == "/api/run" {
+			command = peekRunCommand(r)
+		}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		a.record(r, id, command, rec.status)
+	})
+}
+
+// login validates a query-string token and sets the auth cookie.
+func (a *DashboardAuth) login(w http.ResponseWriter, r *http.Request, token string) {
+	id, ok := a.lookupToken(token)
+	if !ok {
+		a.record(r, Identity{}, "", http.StatusUnauthorized)
+		writeAuthError(w, r, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     authCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	q := r.URL.Query()
+	q.Del("token")
+	target := *r.URL
+	target.RawQuery = q.Encode()
+	a.record(r, id, "", http.StatusSeeOther)
+	http.Redirect(w, r, target.RequestURI(), http.StatusSeeOther)
+}
+
+// record writes an audit event for r.
+func (a *DashboardAuth) record(r *http.Request, id Identity, command string, status int) {
+	if a.audit == nil {
+		return
+	}
+	actor := "dashboard:anonymous"
+	if id.Name != "" {
+		actor = "dashboard:" + id.Name
+	}
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	a.audit(actor, events.DashboardAccessPayload(r.Method, r.URL.Path, id.Role.String(), command, remote, status))
+}
+
+// peekRunCommand extracts the command from an /api/run body for the audit
+// log and restores the body for the handler.
+func peekRunCommand(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxAuditBodyBytes))
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	var req CommandRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+	return req.Command
+}
+
+// writeAuthError writes a JSON error for API paths and plain text otherwise.
+func writeAuthError(w http.ResponseWriter, r *http.Request, msg string, status int) {
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(map[string]string{"error": msg}); err != nil {
+			log.Printf("dashboard auth: encoding error response: %v", err)
+		}
+		return
+	}
+	http.Error(w, msg, status)
+}
+
+// statusRecorder captures the response status for the audit log.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Flush supports streaming handlers behind the recorder.
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
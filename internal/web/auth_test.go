@@ -0,0 +1,279 @@
+package web
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+type auditRecord struct {
+	actor   string
+	payload map[string]interface{}
+}
+
+// newTestAuth builds a DashboardAuth with one viewer and one operator token
+// and captures audit events instead of writing .events.jsonl.
+func newTestAuth(t *testing.T) (*DashboardAuth, *[]auditRecord) {
+	t.Helper()
+	cfg := &config.DashboardAuthConfig{
+		Tokens: []config.DashboardToken{
+			{Name: "vera", Role: "viewer", SHA256: HashDashboardToken("view-token")},
+			{Name: "otto", Role: "operator", SHA256: HashDashboardToken("op-token")},
+		},
+		ClientCerts: map[string]string{"dashboard-carl": "operator"},
+	}
+	auth, err := NewDashboardAuth(cfg, true)
+	if err != nil {
+		t.Fatalf("NewDashboardAuth: %v", err)
+	}
+	var records []auditRecord
+	auth.audit = func(actor string, payload map[string]interface{}) {
+		records = append(records, auditRecord{actor, payload})
+	}
+	return auth, &records
+}
+
+// echoHandler reports the identity and body it received.
+func echoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := identityFromContext(r.Context())
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Identity", id.Name)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	})
+}
+
+func TestParseRole(t *testing.T) {
+	cases := map[string]Role{"viewer": RoleViewer, "read-only": RoleViewer, "Operator": RoleOperator}
+	for in, want := range cases {
+		got, err := ParseRole(in)
+		if err != nil || got != want {
+			t.Errorf("ParseRole(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseRole("admin"); err == nil {
+		t.Error("ParseRole(admin) should fail")
+	}
+}
+
+func TestNewDashboardAuth_Validation(t *testing.T) {
+	if _, err := NewDashboardAuth(nil, true); err == nil {
+		t.Error("required auth without credentials should fail")
+	}
+	if a, err := NewDashboardAuth(nil, false); err != nil || !a.Open() {
+		t.Errorf("optional auth without credentials should be open, got %v, %v", a, err)
+	}
+	bad := []*config.DashboardAuthConfig{
+		{Tokens: []config.DashboardToken{{Name: "x", Role: "root", SHA256: HashDashboardToken("t")}}},
+		{Tokens: []config.DashboardToken{{Name: "x", Role: "viewer", SHA256: "abc"}}},
+		{ClientCerts: map[string]string{"gt-gastown-furiosa": "operator"}},
+	}
+	for i, cfg := range bad {
+		if _, err := NewDashboardAuth(cfg, true); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
+
+func TestDashboardAuth_Authorization(t *testing.T) {
+	auth, _ := newTestAuth(t)
+	h := auth.Wrap(echoHandler())
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{"static is public", http.MethodGet, "/static/app.js", "", http.StatusOK},
+		{"page needs auth", http.MethodGet, "/", "", http.StatusUnauthorized},
+		{"bad token", http.MethodGet, "/api/v1/rigs", "nope", http.StatusUnauthorized},
+		{"viewer reads", http.MethodGet, "/api/v1/rigs", "view-token", http.StatusOK},
+		{"viewer cannot preview", http.MethodGet, "/api/session/preview", "view-token", http.StatusForbidden},
+		{"viewer cannot run", http.MethodPost, "/api/run", "view-token", http.StatusForbidden},
+		{"operator previews", http.MethodGet, "/api/session/preview", "op-token", http.StatusOK},
+		{"operator runs", http.MethodPost, "/api/run", "op-token", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 without WWW-Authenticate")
+			}
+		})
+	}
+}
+
+func TestDashboardAuth_AuditsPrivilegedAndDenied(t *testing.T) {
+	auth, records := newTestAuth(t)
+	h := auth.Wrap(echoHandler())
+
+	// Viewer reads are not audited.
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/rigs", nil)
+	req.Header.Set("Authorization", "Bearer view-token")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if len(*records) != 0 {
+		t.Fatalf("viewer read audited: %+v", *records)
+	}
+
+	body := `{"command":"status --json"}`
+	req = httptest.NewRequest(http.MethodPost, "/api/run", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer op-token")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Body.String() != body {
+		t.Errorf("handler body = %q, want request body restored", rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/mail/send", nil)
+	req.Header.Set("Authorization", "Bearer view-token")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(*records) != 2 {
+		t.Fatalf("got %d audit records, want 2: %+v", len(*records), *records)
+	}
+	run := (*records)[0]
+	if run.actor != "dashboard:otto" || run.payload["command"] != "status --json" ||
+		run.payload["role"] != "operator" || run.payload["status"] != http.StatusOK {
+		t.Errorf("run audit = %+v", run)
+	}
+	denied := (*records)[1]
+	if denied.actor != "dashboard:vera" || denied.payload["status"] != http.StatusForbidden ||
+		denied.payload["path"] != "/api/mail/send" {
+		t.Errorf("denied audit = %+v", denied)
+	}
+}
+
+func TestDashboardAuth_CookieLogin(t *testing.T) {
+	auth, _ := newTestAuth(t)
+	h := auth.Wrap(echoHandler())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?token=op-token&expand=mail", nil))
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("login status = %d, want 303", rec.Code)
+	}
+	if loc := rec.Header().Get("Location"); loc != "/?expand=mail" {
+		t.Errorf("redirect = %q, want token stripped", loc)
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != authCookieName || !cookies[0].HttpOnly {
+		t.Fatalf("cookies = %+v", cookies)
+	}
+
+	// Cookie-authenticated requests work but are not CSRF-exempt.
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	var exempt bool
+	auth.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exempt = csrfExempt(r)
+	})).ServeHTTP(httptest.NewRecorder(), req)
+	if exempt {
+		t.Error("cookie auth must not bypass CSRF")
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?token=wrong", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("bad login status = %d, want 401", rec.Code)
+	}
+}
+
+func TestDashboardAuth_ClientCert(t *testing.T) {
+	auth, _ := newTestAuth(t)
+	h := auth.Wrap(echoHandler())
+
+	for _, tc := range []struct {
+		cn   string
+		want int
+	}{
+		{"dashboard-carl", http.StatusOK},
+		{"dashboard-mallory", http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/session/preview", nil)
+		req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{selfSignedCert(t, tc.cn)}}}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.cn, rec.Code, tc.want)
+		}
+	}
+}
+
+func TestDashboardAuth_OpenMode(t *testing.T) {
+	auth, err := NewDashboardAuth(nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []auditRecord
+	auth.audit = func(actor string, payload map[string]interface{}) {
+		records = append(records, auditRecord{actor, payload})
+	}
+	rec := httptest.NewRecorder()
+	auth.Wrap(echoHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/run", strings.NewReader(`{"command":"mail inbox"}`)))
+	if rec.Code != http.StatusOK || rec.Header().Get("X-Identity") != "local" {
+		t.Errorf("open mode: status %d identity %q", rec.Code, rec.Header().Get("X-Identity"))
+	}
+	if len(records) != 1 || records[0].actor != "dashboard:local" {
+		t.Errorf("open mode audit = %+v", records)
+	}
+}
+
+func TestAPIHandler_BearerSkipsCSRF(t *testing.T) {
+	auth, _ := newTestAuth(t)
+	h := auth.Wrap(NewAPIHandler(time.Second, time.Second, "csrf"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/run", strings.NewReader(`{"command":"rm -rf /"}`))
+	req.Header.Set("Authorization", "Bearer op-token")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	// The command is blocked by the whitelist, not by the CSRF check.
+	if !strings.Contains(rec.Body.String(), "Command blocked") {
+		t.Errorf("bearer request hit CSRF check: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func selfSignedCert(t *testing.T, cn string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
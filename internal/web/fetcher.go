@@ -18,6 +18,8 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	convoyops "github.com/steveyegge/gastown/internal/convoy"
+	"github.com/steveyegge/gastown/internal/eventbus"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -209,6 +211,14 @@ func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 		return nil, fmt.Errorf("parsing convoy list: %w", err)
 	}
 
+	// Merge history and capacity are shared by every convoy's ETA forecast
+	var hist *convoyops.DurationHistory
+	capacity := 0
+	if len(convoys) > 0 {
+		hist, _ = convoyops.LoadDurationHistory(f.townRoot)
+		capacity = convoyops.SchedulerCapacity(f.townRoot)
+	}
+
 	// Build convoy rows with activity data
 	rows := make([]ConvoyRow, 0, len(convoys))
 	for _, c := range convoys {
@@ -297,6 +307,14 @@ func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 		// Calculate work status based on progress and activity
 		row.WorkStatus = calculateWorkStatus(row.Completed, row.Total, row.LastActivity.ColorClass)
 
+		// Forecast ETA and critical path from merge history
+		forecast := f.forecastTracked(tracked, hist, capacity)
+		if !forecast.Done() {
+			row.ETA = "~" + convoyops.FormatRemaining(forecast.Remaining)
+			row.ETAAt = forecast.ETA.Local().Format("Mon 15:04")
+			row.CriticalPath = forecast.CriticalPath
+		}
+
 		// Get tracked issues for expandable view
 		row.TrackedIssues = make([]TrackedIssue, len(tracked))
 		for i, t := range tracked {
@@ -305,6 +323,7 @@ func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 				Title:    t.Title,
 				Status:   t.Status,
 				Assignee: t.Assignee,
+				Critical: forecast.IsCritical(t.ID),
 			}
 		}
 
@@ -315,12 +334,31 @@ func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 	return rows, nil
 }
 
+// forecastTracked projects when a convoy's slingable tracked issues will be merged.
+func (f *LiveConvoyFetcher) forecastTracked(tracked []trackedIssueInfo, hist *convoyops.DurationHistory, capacity int) *convoyops.Forecast {
+	tasks := make([]convoyops.ForecastTask, 0, len(tracked))
+	for _, t := range tracked {
+		if t.Status == "unknown" || !convoyops.IsSlingableType(t.IssueType) {
+			continue
+		}
+		tasks = append(tasks, convoyops.ForecastTask{
+			ID:        t.ID,
+			Rig:       beads.GetRigNameForPrefix(f.townRoot, beads.ExtractPrefix(t.ID)),
+			Status:    t.Status,
+			BlockedBy: t.BlockedBy,
+		})
+	}
+	return convoyops.ForecastTasks(tasks, hist, capacity, time.Now())
+}
+
 // trackedIssueInfo holds info about an issue being tracked by a convoy.
 type trackedIssueInfo struct {
 	ID           string
 	Title        string
 	Status       string
+	IssueType    string
 	Assignee     string
+	BlockedBy    []string // Blocking dependencies, for ETA forecasting
 	LastActivity time.Time
 	UpdatedAt    time.Time // Fallback for activity when no assignee
 }
@@ -363,7 +401,9 @@ func (f *LiveConvoyFetcher) getTrackedIssues(convoyID string) ([]trackedIssueInf
 		if d, ok := details[id]; ok {
 			info.Title = d.Title
 			info.Status = d.Status
+			info.IssueType = d.IssueType
 			info.Assignee = d.Assignee
+			info.BlockedBy = d.BlockedBy
 			info.UpdatedAt = d.UpdatedAt
 		} else {
 			info.Title = "(external)"
@@ -385,7 +425,9 @@ type issueDetail struct {
 	ID        string
 	Title     string
 	Status    string
+	IssueType string
 	Assignee  string
+	BlockedBy []string
 	UpdatedAt time.Time
 }
 
@@ -408,8 +450,13 @@ func (f *LiveConvoyFetcher) getIssueDetailsBatch(issueIDs []string) (map[string]
 		ID        string `json:"id"`
 		Title     string `json:"title"`
 		Status    string `json:"status"`
+		IssueType string `json:"issue_type"`
 		Assignee  string `json:"assignee"`
 		UpdatedAt string `json:"updated_at"`
+		Deps      []struct {
+			ID             string `json:"id"`
+			DependencyType string `json:"dependency_type"`
+		} `json:"dependencies"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
 		return nil, fmt.Errorf("bd show returned invalid JSON (issue_count=%d): %w", len(issueIDs), err)
@@ -417,10 +464,16 @@ func (f *LiveConvoyFetcher) getIssueDetailsBatch(issueIDs []string) (map[string]
 
 	for _, issue := range issues {
 		detail := &issueDetail{
-			ID:       issue.ID,
-			Title:    issue.Title,
-			Status:   issue.Status,
-			Assignee: issue.Assignee,
+			ID:        issue.ID,
+			Title:     issue.Title,
+			Status:    issue.Status,
+			IssueType: issue.IssueType,
+			Assignee:  issue.Assignee,
+		}
+		for _, dep := range issue.Deps {
+			if convoyops.IsBlockingDepType(dep.DependencyType) {
+				detail.BlockedBy = append(detail.BlockedBy, beads.ExtractIssueID(dep.ID))
+			}
 		}
 		// Parse updated_at timestamp
 		if issue.UpdatedAt != "" {
@@ -1765,6 +1818,7 @@ func (f *LiveConvoyFetcher) FetchActivity() ([]ActivityRow, error) {
 
 		var event struct {
 			Timestamp  string                 `json:"ts"`
+			Source     string                 `json:"source"`
 			Type       string                 `json:"type"`
 			Actor      string                 `json:"actor"`
 			Payload    map[string]interface{} `json:"payload"`
@@ -1774,8 +1828,8 @@ func (f *LiveConvoyFetcher) FetchActivity() ([]ActivityRow, error) {
 			continue
 		}
 
-		// Skip audit-only events
-		if event.Visibility == "audit" {
+		// Skip audit-only events, and channel events and town log lines
+		if event.Visibility == "audit" || !eventbus.IsActivitySource(event.Source) {
 			continue
 		}
 
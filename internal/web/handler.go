@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 //go:embed static
@@ -482,6 +483,9 @@ func NewDashboardMux(fetcher ConvoyFetcher, webCfg *config.WebTimeoutsConfig) (h
 
 	mux := http.NewServeMux()
 	mux.Handle("/api/", apiHandler)
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		mux.Handle("/api/v1/", NewAPIv1Handler(NewLiveAPIv1Backend(townRoot), csrfToken))
+	}
 	mux.Handle("/static/", http.StripPrefix("/static/", staticHandler))
 	mux.Handle("/", convoyHandler)
 
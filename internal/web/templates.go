@@ -210,6 +210,14 @@ type ConvoyRow struct {
 	Assignees     []string // unique assignees across tracked issues
 	LastActivity  activity.Info
 	TrackedIssues []TrackedIssue
+	ETA           string   // Forecast time remaining, e.g. "~3h20m"; empty when done
+	ETAAt         string   // Forecast completion clock time, e.g. "Tue 17:40"
+	CriticalPath  []string // Longest chain of dependent open issues
+}
+
+// CriticalPathText renders the critical path as "a → b → c".
+func (r ConvoyRow) CriticalPathText() string {
+	return strings.Join(r.CriticalPath, " → ")
 }
 
 // TrackedIssue represents an issue tracked by a convoy.
@@ -218,6 +226,7 @@ type TrackedIssue struct {
 	Title    string
 	Status   string
 	Assignee string
+	Critical bool // On the convoy's critical path
 }
 
 // LoadTemplates loads and parses all HTML templates.
@@ -173,6 +173,51 @@ func TestConvoyTemplate_ProgressDisplay(t *testing.T) {
 	}
 }
 
+func TestConvoyTemplate_ETADisplay(t *testing.T) {
+	tmpl, err := LoadTemplates()
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+
+	data := ConvoyData{
+		Convoys: []ConvoyRow{
+			{
+				ID:           "hq-cv-eta",
+				Status:       "open",
+				Progress:     "1/3",
+				Completed:    1,
+				Total:        3,
+				ETA:          "~3h20m",
+				ETAAt:        "Tue 17:40",
+				CriticalPath: []string{"gt-a", "gt-b"},
+			},
+			{
+				ID:        "hq-cv-done",
+				Status:    "open",
+				Progress:  "2/2",
+				Completed: 2,
+				Total:     2,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "convoy.html", data); err != nil {
+		t.Fatalf("ExecuteTemplate() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "ETA ~3h20m") || !strings.Contains(output, "Tue 17:40") {
+		t.Error("Template should display the ETA and completion time")
+	}
+	if !strings.Contains(output, "critical path: gt-a → gt-b") {
+		t.Error("Template should show the critical path")
+	}
+	if strings.Count(output, `class="convoy-eta"`) != 1 {
+		t.Error("ETA should only render for convoys with a forecast")
+	}
+}
+
 func TestConvoyTemplate_StatusIndicators(t *testing.T) {
 	tmpl, err := LoadTemplates()
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/mayor"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/protocol"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -382,6 +383,16 @@ func HandleHelp(workDir, rigName string, msg *mail.Message, router *mail.Router)
 	return result
 }
 
+// protocolRegistry returns the registry refinery mail is decoded through.
+// Messages that fail validation are kept in the town's dead-letter queue.
+func protocolRegistry(workDir string) *protocol.HandlerRegistry {
+	registry := protocol.NewHandlerRegistry()
+	if townRoot, err := workspace.Find(workDir); err == nil && townRoot != "" {
+		registry.SetDeadLetterQueue(protocol.NewDeadLetterQueue(protocol.DeadLetterPath(townRoot)))
+	}
+	return registry
+}
+
 // HandleMerged processes a MERGED message from the Refinery.
 // Verifies cleanup_status before allowing nuke, escalates if work is at risk.
 func HandleMerged(bd *BdCli, workDir, rigName string, msg *mail.Message) *HandlerResult {
@@ -390,13 +401,18 @@ func HandleMerged(bd *BdCli, workDir, rigName string, msg *mail.Message) *Handle
 		ProtocolType: ProtoMerged,
 	}
 
-	payload, err := ParseMerged(msg.Subject, msg.Body)
+	decoded, err := protocolRegistry(workDir).Decode(msg)
 	if err != nil {
-		result.Error = fmt.Errorf("parsing MERGED: %w", err)
+		result.Error = fmt.Errorf("decoding MERGED: %w", err)
+		return result
+	}
+	payload, ok := decoded.(*protocol.MergedPayload)
+	if !ok {
+		result.Error = fmt.Errorf("decoding MERGED: got %T", decoded)
 		return result
 	}
 
-	wispID, err := findCleanupWisp(bd, workDir, payload.PolecatName)
+	wispID, err := findCleanupWisp(bd, workDir, payload.Polecat)
 	if err != nil {
 		result.Error = fmt.Errorf("finding cleanup wisp: %w", err)
 		return result
@@ -404,24 +420,24 @@ func HandleMerged(bd *BdCli, workDir, rigName string, msg *mail.Message) *Handle
 
 	if wispID == "" {
 		result.Handled = true
-		result.Action = fmt.Sprintf("no cleanup wisp found for %s (may be already cleaned)", payload.PolecatName)
+		result.Action = fmt.Sprintf("no cleanup wisp found for %s (may be already cleaned)", payload.Polecat)
 		return result
 	}
 
 	// Verify the polecat's commit is actually on main before allowing nuke.
-	onMain, err := verifyCommitOnMain(workDir, rigName, payload.PolecatName)
+	onMain, err := verifyCommitOnMain(workDir, rigName, payload.Polecat)
 	if err != nil {
-		result.Action = fmt.Sprintf("warning: couldn't verify commit on main for %s: %v", payload.PolecatName, err)
+		result.Action = fmt.Sprintf("warning: couldn't verify commit on main for %s: %v", payload.Polecat, err)
 	} else if !onMain {
 		result.Handled = true
 		result.WispCreated = wispID
-		result.Error = fmt.Errorf("polecat %s commit is NOT on main - MERGED signal may be stale, DO NOT NUKE", payload.PolecatName)
-		result.Action = fmt.Sprintf("BLOCKED: %s commit not verified on main, merge may have failed", payload.PolecatName)
+		result.Error = fmt.Errorf("polecat %s commit is NOT on main - MERGED signal may be stale, DO NOT NUKE", payload.Polecat)
+		result.Action = fmt.Sprintf("BLOCKED: %s commit not verified on main, merge may have failed", payload.Polecat)
 		return result
 	}
 
-	cleanupStatus := getCleanupStatus(bd, workDir, rigName, payload.PolecatName)
-	handleMergedCleanupStatus(workDir, rigName, payload.PolecatName, cleanupStatus, wispID, result)
+	cleanupStatus := getCleanupStatus(bd, workDir, rigName, payload.Polecat)
+	handleMergedCleanupStatus(workDir, rigName, payload.Polecat, cleanupStatus, wispID, result)
 	return result
 }
 
@@ -444,18 +460,24 @@ func HandleMergeFailed(workDir, rigName string, msg *mail.Message, router *mail.
 		ProtocolType: ProtoMergeFailed,
 	}
 
-	// Parse the message
-	payload, err := ParseMergeFailed(msg.Subject, msg.Body)
+	decoded, err := protocolRegistry(workDir).Decode(msg)
 	if err != nil {
-		result.Error = fmt.Errorf("parsing MERGE_FAILED: %w", err)
+		result.Error = fmt.Errorf("decoding MERGE_FAILED: %w", err)
+		return result
+	}
+	payload, ok := decoded.(*protocol.MergeFailedPayload)
+	if !ok {
+		result.Error = fmt.Errorf("decoding MERGE_FAILED: got %T", decoded)
 		return result
 	}
 
 	// Nudge the polecat about the failure instead of sending permanent mail.
 	initRegistryFromWorkDir(workDir)
-	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), payload.PolecatName)
-	nudgeMsg := fmt.Sprintf("MERGE_FAILED: branch=%s issue=%s type=%s error=%s — fix and resubmit with 'gt done'",
-		payload.Branch, payload.IssueID, payload.FailureType, payload.Error)
+	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), payload.Polecat)
+	nudgeMsg := protocol.NudgeText(
+		fmt.Sprintf("MERGE_FAILED: branch=%s issue=%s type=%s error=%s — fix and resubmit with 'gt done'",
+			payload.Branch, payload.Issue, payload.FailureType, payload.Error),
+		protocol.TypeMergeFailed, payload)
 	t := tmux.NewTmux()
 	if err := t.NudgeSession(sessionName, nudgeMsg); err != nil {
 		result.Error = fmt.Errorf("nudging polecat about failure: %w", err)
@@ -463,7 +485,7 @@ func HandleMergeFailed(workDir, rigName string, msg *mail.Message, router *mail.
 	}
 
 	result.Handled = true
-	result.Action = fmt.Sprintf("nudged %s about merge failure: %s - %s", payload.PolecatName, payload.FailureType, payload.Error)
+	result.Action = fmt.Sprintf("nudged %s about merge failure: %s - %s", payload.Polecat, payload.FailureType, payload.Error)
 
 	return result
 }
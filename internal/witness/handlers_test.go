@@ -2,6 +2,7 @@ package witness
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,7 +13,9 @@ import (
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/protocol"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
 
@@ -1913,3 +1916,84 @@ func TestNotifyRefineryMergeReady_EmitsChannelEvent(t *testing.T) {
 		t.Errorf("payload.rig = %v, want dashboard", payload["rig"])
 	}
 }
+
+// newTestTownRoot creates a directory workspace.Find recognizes as a town.
+func newTestTownRoot(t *testing.T) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot
+}
+
+func TestHandleMerged_LegacyBody(t *testing.T) {
+	townRoot := newTestTownRoot(t)
+	bd, _ := mockBd(
+		func(args []string) (string, error) { return "[]", nil },
+		func(args []string) error { return nil },
+	)
+
+	// The shape the refinery formula sends: polecat in the subject, rig in
+	// the address, no envelope.
+	msg := &mail.Message{
+		ID:      "hq-1",
+		From:    "gastown/refinery",
+		To:      "gastown/witness",
+		Subject: "MERGED nux",
+		Body:    "Branch: polecat/nux/gt-abc\nIssue: gt-abc\nMerged-At: 2026-01-02T03:04:05Z",
+	}
+	result := HandleMerged(bd, townRoot, "gastown", msg)
+	if result.Error != nil {
+		t.Fatalf("HandleMerged error: %v", result.Error)
+	}
+	if !result.Handled || !strings.Contains(result.Action, "no cleanup wisp found for nux") {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestHandleMerged_DeadLettersInvalidMessage(t *testing.T) {
+	townRoot := newTestTownRoot(t)
+	bd, mock := mockBd(
+		func(args []string) (string, error) { return "[]", nil },
+		func(args []string) error { return nil },
+	)
+
+	msg := &mail.Message{ID: "hq-2", From: "gastown/refinery", To: "gastown/witness", Subject: "MERGED nux", Body: "garbage"}
+	result := HandleMerged(bd, townRoot, "gastown", msg)
+	if !errors.Is(result.Error, protocol.ErrInvalidMessage) {
+		t.Fatalf("Error = %v, want ErrInvalidMessage", result.Error)
+	}
+	if result.Handled || len(mock.calls) != 0 {
+		t.Errorf("invalid message was acted on: handled=%v calls=%v", result.Handled, mock.calls)
+	}
+
+	letters, err := protocol.NewDeadLetterQueue(protocol.DeadLetterPath(townRoot)).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(letters) != 1 || letters[0].MessageID != "hq-2" {
+		t.Errorf("dead letters = %+v", letters)
+	}
+}
+
+func TestHandleMergeFailed_DeadLettersInvalidMessage(t *testing.T) {
+	townRoot := newTestTownRoot(t)
+
+	msg := &mail.Message{ID: "hq-3", From: "gastown/refinery", To: "gastown/witness", Subject: "MERGE_FAILED nux", Body: "Error: tests failed"}
+	result := HandleMergeFailed(townRoot, "gastown", msg, nil)
+	if !errors.Is(result.Error, protocol.ErrInvalidMessage) {
+		t.Fatalf("Error = %v, want ErrInvalidMessage", result.Error)
+	}
+
+	letters, err := protocol.NewDeadLetterQueue(protocol.DeadLetterPath(townRoot)).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(letters) != 1 || letters[0].MessageID != "hq-3" {
+		t.Errorf("dead letters = %+v", letters)
+	}
+}
@@ -48,8 +48,7 @@ func NewManager(r *rig.Rig) *Manager {
 // ZFC: tmux session existence is the source of truth for session state,
 // but agent liveness determines if the session is actually functional.
 func (m *Manager) IsRunning() (bool, error) {
-	t := tmux.NewTmux()
-	status := t.CheckSessionHealth(m.SessionName(), 0)
+	status := session.CheckSessionHealth(m.backend(), m.SessionName(), 0)
 	return status == tmux.SessionHealthy, nil
 }
 
@@ -59,8 +58,12 @@ func (m *Manager) IsRunning() (bool, error) {
 // Returns the detailed ZombieStatus for callers that need to distinguish
 // between different failure modes.
 func (m *Manager) IsHealthy(maxInactivity time.Duration) tmux.ZombieStatus {
-	t := tmux.NewTmux()
-	return t.CheckSessionHealth(m.SessionName(), maxInactivity)
+	return session.CheckSessionHealth(m.backend(), m.SessionName(), maxInactivity)
+}
+
+// backend returns the town's session backend.
+func (m *Manager) backend() session.SessionBackend {
+	return session.NewBackend(m.townRoot())
 }
 
 // SessionName returns the tmux session name for this witness.
@@ -71,10 +74,10 @@ func (m *Manager) SessionName() string {
 // Status returns information about the witness session.
 // ZFC-compliant: tmux session is the source of truth.
 func (m *Manager) Status() (*tmux.SessionInfo, error) {
-	t := tmux.NewTmux()
+	b := m.backend()
 	sessionID := m.SessionName()
 
-	running, err := t.HasSession(sessionID)
+	running, err := b.HasSession(sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("checking session: %w", err)
 	}
@@ -82,7 +85,7 @@ func (m *Manager) Status() (*tmux.SessionInfo, error) {
 		return nil, ErrNotRunning
 	}
 
-	return t.GetSessionInfo(sessionID)
+	return session.GetSessionInfo(b, sessionID)
 }
 
 // witnessDir returns the working directory for the witness.
@@ -103,12 +106,15 @@ func (m *Manager) witnessDir() string {
 
 // Start starts the witness.
 // If foreground is true, returns an error (foreground mode deprecated).
-// Otherwise, spawns a Claude agent in a tmux session.
+// Otherwise, spawns a Claude agent in a session on the town's backend.
+// tmux-only setup (theme, startup dialogs, PID tracking) is skipped for
+// other backends.
 // agentOverride optionally specifies a different agent alias to use.
 // envOverrides are KEY=VALUE pairs that override all other env var sources.
 // ZFC-compliant: no state file, tmux session is source of truth.
 func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []string) error {
-	t := tmux.NewTmux()
+	b := m.backend()
+	t, isTmux := b.(*tmux.Tmux)
 	sessionID := m.SessionName()
 
 	if foreground {
@@ -117,10 +123,10 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 	}
 
 	// Check if session already exists
-	running, _ := t.HasSession(sessionID)
+	running, _ := b.HasSession(sessionID)
 	if running {
 		// Session exists - check if Claude is actually running (healthy vs zombie)
-		if t.IsAgentAlive(sessionID) {
+		if b.IsAgentAlive(sessionID) {
 			// Healthy - Claude is running
 			return ErrAlreadyRunning
 		}
@@ -129,20 +135,25 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 		// dead during initialization. Record session creation time, wait
 		// briefly, then re-verify before killing to avoid destroying a
 		// session that just became healthy.
-		createdAt, _ := t.GetSessionCreatedUnix(sessionID)
+		var createdAt int64
+		if isTmux {
+			createdAt, _ = t.GetSessionCreatedUnix(sessionID)
+		}
 		time.Sleep(constants.ZombieKillGracePeriod)
 
 		// Re-check: abort kill if agent started or session was replaced
-		if t.IsAgentAlive(sessionID) {
+		if b.IsAgentAlive(sessionID) {
 			return ErrAlreadyRunning
 		}
-		if createdNow, _ := t.GetSessionCreatedUnix(sessionID); createdAt > 0 && createdNow != createdAt {
-			// Session was replaced between checks — another process already
-			// handled the zombie. Treat as already running; caller can retry.
-			return ErrAlreadyRunning
+		if isTmux {
+			if createdNow, _ := t.GetSessionCreatedUnix(sessionID); createdAt > 0 && createdNow != createdAt {
+				// Session was replaced between checks — another process already
+				// handled the zombie. Treat as already running; caller can retry.
+				return ErrAlreadyRunning
+			}
 		}
 
-		if err := t.KillSession(sessionID); err != nil {
+		if err := session.KillSession(b, sessionID); err != nil {
 			return fmt.Errorf("killing zombie session: %w", err)
 		}
 	}
@@ -190,8 +201,8 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
-	if err := t.NewSessionWithCommand(sessionID, witnessDir, command); err != nil {
-		return fmt.Errorf("creating tmux session: %w", err)
+	if err := b.NewSessionWithCommand(sessionID, witnessDir, command); err != nil {
+		return fmt.Errorf("creating session: %w", err)
 	}
 
 	// Set environment variables (non-fatal: session works without these)
@@ -205,9 +216,9 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 	})
 	envVars = session.MergeRuntimeLivenessEnv(envVars, runtimeConfig)
 	for k, v := range envVars {
-		_ = t.SetEnvironment(sessionID, k, v)
+		_ = b.SetEnvironment(sessionID, k, v)
 	}
-	_ = t.SetEnvironment(sessionID, "GT_RUN", runID)
+	_ = b.SetEnvironment(sessionID, "GT_RUN", runID)
 	// Apply role config env vars if present (non-fatal).
 	// Skip keys already set by AgentEnv to prevent TOML env overriding
 	// the canonical qualified GT_ROLE (e.g., "gastown/witness" not "witness").
@@ -216,34 +227,36 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 		if _, alreadySet := envVars[key]; alreadySet {
 			continue
 		}
-		_ = t.SetEnvironment(sessionID, key, value)
+		_ = b.SetEnvironment(sessionID, key, value)
 	}
 	// Apply CLI env overrides (highest priority, non-fatal).
 	for _, override := range envOverrides {
 		if key, value, ok := strings.Cut(override, "="); ok {
-			_ = t.SetEnvironment(sessionID, key, value)
+			_ = b.SetEnvironment(sessionID, key, value)
 		}
 	}
 
-	// Apply Gas Town theming (non-fatal: theming failure doesn't affect operation)
-	theme := tmux.ResolveSessionTheme(townRoot, m.rig.Name, "witness")
-	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, "witness", "witness")
+	if isTmux {
+		// Apply Gas Town theming (non-fatal: theming failure doesn't affect operation)
+		theme := tmux.ResolveSessionTheme(townRoot, m.rig.Name, "witness")
+		_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, "witness", "witness")
 
-	// Wait for Claude to start - fatal if Claude fails to launch
-	if err := t.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {
-		// Kill the zombie session before returning error
-		_ = t.KillSessionWithProcesses(sessionID)
-		return fmt.Errorf("waiting for witness to start: %w", err)
-	}
+		// Wait for Claude to start - fatal if Claude fails to launch
+		if err := t.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {
+			// Kill the zombie session before returning error
+			_ = t.KillSessionWithProcesses(sessionID)
+			return fmt.Errorf("waiting for witness to start: %w", err)
+		}
 
-	// Accept startup dialogs (workspace trust + bypass permissions) if they appear.
-	if err := t.AcceptStartupDialogs(sessionID); err != nil {
-		log.Printf("warning: accepting startup dialogs for %s: %v", sessionID, err)
-	}
+		// Accept startup dialogs (workspace trust + bypass permissions) if they appear.
+		if err := t.AcceptStartupDialogs(sessionID); err != nil {
+			log.Printf("warning: accepting startup dialogs for %s: %v", sessionID, err)
+		}
 
-	// Track PID for defense-in-depth orphan cleanup (non-fatal)
-	if err := session.TrackSessionPID(townRoot, sessionID, t); err != nil {
-		log.Printf("warning: tracking session PID for %s: %v", sessionID, err)
+		// Track PID for defense-in-depth orphan cleanup (non-fatal)
+		if err := session.TrackSessionPID(townRoot, sessionID, t); err != nil {
+			log.Printf("warning: tracking session PID for %s: %v", sessionID, err)
+		}
 	}
 
 	// Start nudge-queue poller (gt-dgf). Claude's UserPromptSubmit hook only
@@ -253,13 +266,15 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 		log.Printf("warning: could not start nudge poller for %s: %v", sessionID, pollerErr)
 	}
 
-	_ = runtime.RunStartupFallback(t, sessionID, "witness", runtimeConfig)
-	initialPrompt := session.BuildStartupPrompt(session.BeaconConfig{
-		Recipient: session.BeaconRecipient("witness", "", m.rig.Name),
-		Sender:    "deacon",
-		Topic:     "patrol",
-	}, "Run `gt prime --hook` and begin patrol.")
-	_ = runtime.DeliverStartupPromptFallback(t, sessionID, initialPrompt, runtimeConfig, constants.ClaudeStartTimeout)
+	if isTmux {
+		_ = runtime.RunStartupFallback(t, sessionID, "witness", runtimeConfig)
+		initialPrompt := session.BuildStartupPrompt(session.BeaconConfig{
+			Recipient: session.BeaconRecipient("witness", "", m.rig.Name),
+			Sender:    "deacon",
+			Topic:     "patrol",
+		}, "Run `gt prime --hook` and begin patrol.")
+		_ = runtime.DeliverStartupPromptFallback(t, sessionID, initialPrompt, runtimeConfig, constants.ClaudeStartTimeout)
+	}
 
 	// Stream witness's Claude Code JSONL conversation log to VictoriaLogs (opt-in).
 	if os.Getenv("GT_LOG_AGENT_OUTPUT") == "true" && os.Getenv("GT_OTEL_LOGS_URL") != "" {
@@ -367,15 +382,15 @@ func isBuiltinClaudeStartCommand(cmd string) bool {
 // Stop stops the witness.
 // ZFC-compliant: tmux session is the source of truth.
 func (m *Manager) Stop() error {
-	t := tmux.NewTmux()
+	b := m.backend()
 	sessionID := m.SessionName()
 
-	// Check if tmux session exists
-	running, _ := t.HasSession(sessionID)
+	// Check if the session exists
+	running, _ := b.HasSession(sessionID)
 	if !running {
 		return ErrNotRunning
 	}
 
-	// Kill the tmux session
-	return t.KillSession(sessionID)
+	// Kill the session
+	return session.KillSession(b, sessionID)
 }
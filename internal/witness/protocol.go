@@ -150,34 +150,6 @@ type HelpPayload struct {
 	Assessment  *HelpAssessment // Populated by AssessHelp()
 }
 
-// MergedPayload contains parsed data from a MERGED message.
-type MergedPayload struct {
-	PolecatName string
-	Branch      string
-	IssueID     string
-	MergedAt    time.Time
-}
-
-// MergeReadyPayload contains parsed data from a MERGE_READY message.
-// This is sent by Witness to Refinery when a polecat completes work with a pending MR.
-type MergeReadyPayload struct {
-	PolecatName string
-	Branch      string
-	IssueID     string
-	MRID        string
-	ReadyAt     time.Time
-}
-
-// MergeFailedPayload contains parsed data from a MERGE_FAILED message.
-type MergeFailedPayload struct {
-	PolecatName string
-	Branch      string
-	IssueID     string
-	FailureType string // "build", "test", "lint", etc.
-	Error       string
-	FailedAt    time.Time
-}
-
 // SwarmStartPayload contains parsed data from a SWARM_START message.
 type SwarmStartPayload struct {
 	SwarmID   string
@@ -323,113 +295,6 @@ func ParseHelp(subject, body string) (*HelpPayload, error) {
 	return payload, nil
 }
 
-// ParseMerged extracts payload from a MERGED message.
-// Subject format: MERGED <polecat-name>
-// Body format:
-//
-//	Branch: <branch>
-//	Issue: <issue-id>
-//	Merged-At: <timestamp>
-func ParseMerged(subject, body string) (*MergedPayload, error) {
-	matches := PatternMerged.FindStringSubmatch(subject)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("invalid MERGED subject: %s", subject)
-	}
-
-	payload := &MergedPayload{
-		PolecatName: matches[1],
-	}
-
-	// Parse body for structured fields
-	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "Branch:") {
-			payload.Branch = strings.TrimSpace(strings.TrimPrefix(line, "Branch:"))
-		} else if strings.HasPrefix(line, "Issue:") {
-			payload.IssueID = strings.TrimSpace(strings.TrimPrefix(line, "Issue:"))
-		} else if strings.HasPrefix(line, "Merged-At:") {
-			ts := strings.TrimSpace(strings.TrimPrefix(line, "Merged-At:"))
-			if t, err := time.Parse(time.RFC3339, ts); err == nil {
-				payload.MergedAt = t
-			}
-		}
-	}
-
-	return payload, nil
-}
-
-// ParseMergeFailed extracts payload from a MERGE_FAILED message.
-// Subject format: MERGE_FAILED <polecat-name>
-// Body format:
-//
-//	Branch: <branch>
-//	Issue: <issue-id>
-//	FailureType: <type>
-//	Error: <error-message>
-func ParseMergeFailed(subject, body string) (*MergeFailedPayload, error) {
-	matches := PatternMergeFailed.FindStringSubmatch(subject)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("invalid MERGE_FAILED subject: %s", subject)
-	}
-
-	payload := &MergeFailedPayload{
-		PolecatName: matches[1],
-		FailedAt:    time.Now(),
-	}
-
-	// Parse body for structured fields
-	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		switch {
-		case strings.HasPrefix(line, "Branch:"):
-			payload.Branch = strings.TrimSpace(strings.TrimPrefix(line, "Branch:"))
-		case strings.HasPrefix(line, "Issue:"):
-			payload.IssueID = strings.TrimSpace(strings.TrimPrefix(line, "Issue:"))
-		case strings.HasPrefix(line, "FailureType:"):
-			payload.FailureType = strings.TrimSpace(strings.TrimPrefix(line, "FailureType:"))
-		case strings.HasPrefix(line, "Error:"):
-			payload.Error = strings.TrimSpace(strings.TrimPrefix(line, "Error:"))
-		}
-	}
-
-	return payload, nil
-}
-
-// ParseMergeReady extracts payload from a MERGE_READY message.
-// Subject format: MERGE_READY <polecat-name>
-// Body format:
-//
-//	Branch: <branch>
-//	Issue: <issue-id>
-//	MR: <mr-id>
-//	Verified: clean git state
-func ParseMergeReady(subject, body string) (*MergeReadyPayload, error) {
-	matches := PatternMergeReady.FindStringSubmatch(subject)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("invalid MERGE_READY subject: %s", subject)
-	}
-
-	payload := &MergeReadyPayload{
-		PolecatName: matches[1],
-		ReadyAt:     time.Now(),
-	}
-
-	// Parse body for structured fields
-	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		switch {
-		case strings.HasPrefix(line, "Branch:"):
-			payload.Branch = strings.TrimSpace(strings.TrimPrefix(line, "Branch:"))
-		case strings.HasPrefix(line, "Issue:"):
-			payload.IssueID = strings.TrimSpace(strings.TrimPrefix(line, "Issue:"))
-		case strings.HasPrefix(line, "MR:"):
-			payload.MRID = strings.TrimSpace(strings.TrimPrefix(line, "MR:"))
-		}
-	}
-
-	return payload, nil
-}
-
 // ParseSwarmStart extracts payload from a SWARM_START message.
 // Subject format: SWARM_START
 // Body format:
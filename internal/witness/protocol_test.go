@@ -181,162 +181,6 @@ func TestParseHelp_InvalidSubject(t *testing.T) {
 	}
 }
 
-func TestParseMerged(t *testing.T) {
-	t.Parallel()
-	subject := "MERGED nux"
-	body := `Branch: feature-nux
-Issue: gt-abc123
-Merged-At: 2025-12-30T10:30:00Z`
-
-	payload, err := ParseMerged(subject, body)
-	if err != nil {
-		t.Fatalf("ParseMerged() error = %v", err)
-	}
-
-	if payload.PolecatName != "nux" {
-		t.Errorf("PolecatName = %q, want %q", payload.PolecatName, "nux")
-	}
-	if payload.Branch != "feature-nux" {
-		t.Errorf("Branch = %q, want %q", payload.Branch, "feature-nux")
-	}
-	if payload.IssueID != "gt-abc123" {
-		t.Errorf("IssueID = %q, want %q", payload.IssueID, "gt-abc123")
-	}
-	if payload.MergedAt.IsZero() {
-		t.Error("MergedAt should not be zero")
-	}
-}
-
-func TestParseMerged_InvalidSubject(t *testing.T) {
-	t.Parallel()
-	_, err := ParseMerged("Not merged", "body")
-	if err == nil {
-		t.Error("ParseMerged() expected error for invalid subject")
-	}
-}
-
-func TestParseMergeFailed(t *testing.T) {
-	t.Parallel()
-	subject := "MERGE_FAILED nux"
-	body := `Branch: feature-nux
-Issue: gt-abc123
-FailureType: tests
-Error: unit tests failed with 3 errors`
-
-	payload, err := ParseMergeFailed(subject, body)
-	if err != nil {
-		t.Fatalf("ParseMergeFailed() error = %v", err)
-	}
-
-	if payload.PolecatName != "nux" {
-		t.Errorf("PolecatName = %q, want %q", payload.PolecatName, "nux")
-	}
-	if payload.Branch != "feature-nux" {
-		t.Errorf("Branch = %q, want %q", payload.Branch, "feature-nux")
-	}
-	if payload.IssueID != "gt-abc123" {
-		t.Errorf("IssueID = %q, want %q", payload.IssueID, "gt-abc123")
-	}
-	if payload.FailureType != "tests" {
-		t.Errorf("FailureType = %q, want %q", payload.FailureType, "tests")
-	}
-	if payload.Error != "unit tests failed with 3 errors" {
-		t.Errorf("Error = %q, want %q", payload.Error, "unit tests failed with 3 errors")
-	}
-	if payload.FailedAt.IsZero() {
-		t.Error("FailedAt should not be zero")
-	}
-}
-
-func TestParseMergeFailed_MinimalBody(t *testing.T) {
-	t.Parallel()
-	subject := "MERGE_FAILED ace"
-	body := "FailureType: build"
-
-	payload, err := ParseMergeFailed(subject, body)
-	if err != nil {
-		t.Fatalf("ParseMergeFailed() error = %v", err)
-	}
-
-	if payload.PolecatName != "ace" {
-		t.Errorf("PolecatName = %q, want %q", payload.PolecatName, "ace")
-	}
-	if payload.FailureType != "build" {
-		t.Errorf("FailureType = %q, want %q", payload.FailureType, "build")
-	}
-	if payload.Branch != "" {
-		t.Errorf("Branch = %q, want empty", payload.Branch)
-	}
-}
-
-func TestParseMergeFailed_InvalidSubject(t *testing.T) {
-	t.Parallel()
-	_, err := ParseMergeFailed("Not a merge failed", "body")
-	if err == nil {
-		t.Error("ParseMergeFailed() expected error for invalid subject")
-	}
-}
-
-func TestParseMergeReady(t *testing.T) {
-	t.Parallel()
-	subject := "MERGE_READY nux"
-	body := `Branch: polecat/nux/gt-abc123
-Issue: gt-abc123
-MR: mr-xyz789
-Polecat: nux
-Verified: clean git state`
-
-	payload, err := ParseMergeReady(subject, body)
-	if err != nil {
-		t.Fatalf("ParseMergeReady() error = %v", err)
-	}
-
-	if payload.PolecatName != "nux" {
-		t.Errorf("PolecatName = %q, want %q", payload.PolecatName, "nux")
-	}
-	if payload.Branch != "polecat/nux/gt-abc123" {
-		t.Errorf("Branch = %q, want %q", payload.Branch, "polecat/nux/gt-abc123")
-	}
-	if payload.IssueID != "gt-abc123" {
-		t.Errorf("IssueID = %q, want %q", payload.IssueID, "gt-abc123")
-	}
-	if payload.MRID != "mr-xyz789" {
-		t.Errorf("MRID = %q, want %q", payload.MRID, "mr-xyz789")
-	}
-	if payload.ReadyAt.IsZero() {
-		t.Error("ReadyAt should not be zero")
-	}
-}
-
-func TestParseMergeReady_MinimalBody(t *testing.T) {
-	t.Parallel()
-	subject := "MERGE_READY ace"
-	body := "Branch: feature-ace"
-
-	payload, err := ParseMergeReady(subject, body)
-	if err != nil {
-		t.Fatalf("ParseMergeReady() error = %v", err)
-	}
-
-	if payload.PolecatName != "ace" {
-		t.Errorf("PolecatName = %q, want %q", payload.PolecatName, "ace")
-	}
-	if payload.Branch != "feature-ace" {
-		t.Errorf("Branch = %q, want %q", payload.Branch, "feature-ace")
-	}
-	if payload.IssueID != "" {
-		t.Errorf("IssueID = %q, want empty", payload.IssueID)
-	}
-}
-
-func TestParseMergeReady_InvalidSubject(t *testing.T) {
-	t.Parallel()
-	_, err := ParseMergeReady("Not a merge ready", "body")
-	if err == nil {
-		t.Error("ParseMergeReady() expected error for invalid subject")
-	}
-}
-
 func TestParseSwarmStart(t *testing.T) {
 	t.Parallel()
 	body := `SwarmID: batch-123